@@ -17,6 +17,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -33,6 +34,11 @@ func main() {
 	vibeflowcli.SetVersionInfo(version, commit, date)
 	if err := vibeflowcli.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		// `vibeflow run` passes on the agent's own exit status.
+		var coder interface{ ExitCode() int }
+		if errors.As(err, &coder) && coder.ExitCode() > 0 {
+			os.Exit(coder.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
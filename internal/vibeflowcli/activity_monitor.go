@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ActivityState classifies what an agent session is doing, derived from how
+// its pane changes between samples.
+type ActivityState int
+
+const (
+	// ActivityUnknown means the session has not been sampled twice yet.
+	ActivityUnknown ActivityState = iota
+	// ActivityWorking means the pane content or cursor changed recently.
+	ActivityWorking
+	// ActivityIdle means the pane has been static for at least idleAfter.
+	ActivityIdle
+	// ActivityWaiting means the pane ends in a prompt asking the user for
+	// input (permission dialog, y/n question, ...).
+	ActivityWaiting
+)
+
+// String returns the status label shown in the session list.
+func (s ActivityState) String() string {
+	switch s {
+	case ActivityWorking:
+		return "working"
+	case ActivityIdle:
+		return "idle"
+	case ActivityWaiting:
+		return "waiting"
+	default:
+		return "running"
+	}
+}
+
+// defaultIdleAfter is how long a pane must stay unchanged before the session
+// is considered idle. Agents routinely pause a few seconds between tool calls
+// while the model is thinking, so this is deliberately generous.
+const defaultIdleAfter = 20 * time.Second
+
+// activityTailLines is how many trailing lines are scanned for input prompts.
+// Prompts sit at the bottom of the pane; scanning further up would match
+// prompts the user already answered.
+const activityTailLines = 8
+
+// inputPromptPatterns match the tail of a pane that is blocked on the user.
+// They cover the built-in providers' permission dialogs plus generic y/n
+// prompts; matching is case-insensitive.
+var inputPromptPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)do you want to (proceed|continue|make this edit|create|run)`),
+	regexp.MustCompile(`(?i)\((y/n)\)|\[y/n\]|\(yes/no\)`),
+	regexp.MustCompile(`(?i)allow (this|once|always)|approve (this|the)|waiting for (your )?(approval|input|confirmation)`),
+	regexp.MustCompile(`(?i)press enter to (continue|confirm)`),
+	regexp.MustCompile(`(?i)allow (command|execution)\b[^\n]*\?`),
+	regexp.MustCompile(`(?i)❯\s*1\.\s*yes`),
+}
+
+// looksLikeInputPrompt reports whether the last few lines of output match
+// an input prompt.
+func looksLikeInputPrompt(output string) bool {
+	tail := lastNLines(strings.TrimRight(output, "\n "), activityTailLines)
+	for _, re := range inputPromptPatterns {
+		if re.MatchString(tail) {
+			return true
+		}
+	}
+	return false
+}
+
+type activityTrack struct {
+	hash       uint64
+	lastChange time.Time
+	state      ActivityState
+}
+
+// ActivityMonitor tracks pane activity per session. It is safe for
+// concurrent use: samples are taken in a tea.Cmd goroutine while the UI
+// reads states during rendering.
+type ActivityMonitor struct {
+	mu        sync.Mutex
+	sessions  map[string]*activityTrack
+	idleAfter time.Duration
+}
+
+// NewActivityMonitor creates a monitor. idleAfter <= 0 uses defaultIdleAfter.
+func NewActivityMonitor(idleAfter time.Duration) *ActivityMonitor {
+	if idleAfter <= 0 {
+		idleAfter = defaultIdleAfter
+	}
+	return &ActivityMonitor{
+		sessions:  make(map[string]*activityTrack),
+		idleAfter: idleAfter,
+	}
+}
+
+// Observe records a pane sample (output plus cursor position) taken at now
+// and returns the session's updated state. A waiting prompt wins over the
+// change-based classification: an agent redrawing a spinner next to a
+// permission dialog is still blocked on the user.
+func (a *ActivityMonitor) Observe(name, output, cursor string, now time.Time) ActivityState {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(output))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(cursor))
+	sum := h.Sum64()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tr, ok := a.sessions[name]
+	if !ok {
+		// First sample: nothing to compare against yet.
+		tr = &activityTrack{hash: sum, lastChange: now}
+		a.sessions[name] = tr
+		if looksLikeInputPrompt(output) {
+			tr.state = ActivityWaiting
+		}
+		return tr.state
+	}
+	if sum != tr.hash {
+		tr.hash = sum
+		tr.lastChange = now
+	}
+	switch {
+	case looksLikeInputPrompt(output):
+		tr.state = ActivityWaiting
+	case now.Sub(tr.lastChange) >= a.idleAfter:
+		tr.state = ActivityIdle
+	default:
+		tr.state = ActivityWorking
+	}
+	return tr.state
+}
+
+// State returns the last classification for name.
+func (a *ActivityMonitor) State(name string) ActivityState {
+	if a == nil {
+		return ActivityUnknown
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if tr, ok := a.sessions[name]; ok {
+		return tr.state
+	}
+	return ActivityUnknown
+}
+
+// IdleSince returns when name's pane last changed, or zero if it has not
+// been observed.
+func (a *ActivityMonitor) IdleSince(name string) time.Time {
+	if a == nil {
+		return time.Time{}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if tr, ok := a.sessions[name]; ok {
+		return tr.lastChange
+	}
+	return time.Time{}
+}
+
+// Prune forgets sessions not in live.
+func (a *ActivityMonitor) Prune(live []string) {
+	keep := make(map[string]bool, len(live))
+	for _, n := range live {
+		keep[n] = true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for n := range a.sessions {
+		if !keep[n] {
+			delete(a.sessions, n)
+		}
+	}
+}
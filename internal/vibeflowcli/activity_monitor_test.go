@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityMonitor_WorkingThenIdle(t *testing.T) {
+	a := NewActivityMonitor(10 * time.Second)
+	t0 := time.Unix(1000, 0)
+
+	if got := a.Observe("s", "building...", "0,1", t0); got != ActivityUnknown {
+		t.Errorf("first sample = %v, want unknown", got)
+	}
+	if got := a.Observe("s", "building...\ndone", "0,2", t0.Add(3*time.Second)); got != ActivityWorking {
+		t.Errorf("changed output = %v, want working", got)
+	}
+	if got := a.Observe("s", "building...\ndone", "0,2", t0.Add(6*time.Second)); got != ActivityWorking {
+		t.Errorf("static for 3s = %v, want still working", got)
+	}
+	if got := a.Observe("s", "building...\ndone", "0,2", t0.Add(13*time.Second)); got != ActivityIdle {
+		t.Errorf("static for 10s = %v, want idle", got)
+	}
+	// Cursor movement alone counts as activity.
+	if got := a.Observe("s", "building...\ndone", "4,2", t0.Add(16*time.Second)); got != ActivityWorking {
+		t.Errorf("cursor moved = %v, want working", got)
+	}
+	if got := a.State("s"); got != ActivityWorking {
+		t.Errorf("State = %v, want working", got)
+	}
+}
+
+func TestActivityMonitor_WaitingPrompt(t *testing.T) {
+	a := NewActivityMonitor(0)
+	now := time.Now()
+	prompt := "Edit file main.go\n\nDo you want to make this edit to main.go?\n❯ 1. Yes\n  2. No"
+	if got := a.Observe("s", prompt, "0,5", now); got != ActivityWaiting {
+		t.Errorf("prompt on first sample = %v, want waiting", got)
+	}
+	// Still waiting while a spinner redraws around the prompt.
+	if got := a.Observe("s", prompt+"\n⠋", "1,6", now.Add(time.Second)); got != ActivityWaiting {
+		t.Errorf("prompt with redraw = %v, want waiting", got)
+	}
+	// A prompt scrolled out of the tail no longer counts.
+	answered := prompt + "\n\n\n\n\n\n\n\n\nApplied edit."
+	if got := a.Observe("s", answered, "0,9", now.Add(2*time.Second)); got != ActivityWorking {
+		t.Errorf("answered prompt = %v, want working", got)
+	}
+}
+
+func TestLooksLikeInputPrompt(t *testing.T) {
+	for _, in := range []string{
+		"Overwrite existing file? (y/n)",
+		"Continue? [Y/n] ",
+		"Press Enter to continue",
+		"Allow once   Allow always   Deny",
+	} {
+		if !looksLikeInputPrompt(in) {
+			t.Errorf("looksLikeInputPrompt(%q) = false, want true", in)
+		}
+	}
+	for _, in := range []string{"", "Running tests...\nok  vibeflow-cli 0.4s", "yes, that worked"} {
+		if looksLikeInputPrompt(in) {
+			t.Errorf("looksLikeInputPrompt(%q) = true, want false", in)
+		}
+	}
+}
+
+func TestActivityMonitor_Prune(t *testing.T) {
+	a := NewActivityMonitor(0)
+	now := time.Now()
+	a.Observe("a", "x (y/n)", "0,0", now)
+	a.Observe("b", "x (y/n)", "0,0", now)
+	a.Prune([]string{"a"})
+	if a.State("a") != ActivityWaiting || a.State("b") != ActivityUnknown {
+		t.Errorf("after prune: a=%v b=%v", a.State("a"), a.State("b"))
+	}
+}
+
+func TestApplyActivity_KeepsExitedAndUnknown(t *testing.T) {
+	rows := []SessionRow{
+		{Name: "a", Status: "running"},
+		{Name: "b", Status: "attached"},
+		{Name: "c", Status: "exited"},
+	}
+	states := map[string]ActivityState{"a": ActivityIdle, "c": ActivityWorking}
+	applyActivity(rows, func(n string) ActivityState { return states[n] })
+	want := []string{"idle", "attached", "exited"}
+	for i, w := range want {
+		if rows[i].Status != w {
+			t.Errorf("row %s status = %q, want %q", rows[i].Name, rows[i].Status, w)
+		}
+	}
+}
+
+func TestCaptureActivitySample_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := &TmuxManager{socketName: "vfacttest"}
+	t.Cleanup(func() { _, _ = tm.run("kill-server") })
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_act", "printf 'hello\\n'; sleep 30"); err != nil {
+		t.Skipf("cannot start tmux: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	out, cursor, err := tm.CaptureActivitySample("act", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "hello") {
+		t.Errorf("output = %q, want pane content", out)
+	}
+	if cursor != "0,1" {
+		t.Errorf("cursor = %q, want 0,1", cursor)
+	}
+}
@@ -17,9 +17,11 @@
 package vibeflowcli
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strings"
+	"text/template"
 )
 
 // DefaultMCPToolName is the default name of the vibeflow MCP server. Users
@@ -53,6 +55,47 @@ func BuildVibeflowCloudDispatchInitPrompt(mcpName, projectName, persona, session
 	)
 }
 
+// PromptTemplateVars are the variables available in a Provider's PromptTemplate.
+type PromptTemplateVars struct {
+	Provider      string // provider key, e.g. "claude"
+	Project       string
+	Persona       string
+	Branch        string
+	WorkDir       string
+	ServerURL     string
+	SessionID     string // VibeFlow session ID; empty when the agent creates its own
+	MCPToolName   string // never empty; defaults to DefaultMCPToolName
+	CloudDispatch bool   // the session takes work from the cloud dispatch queue
+}
+
+// RenderInitPrompt returns the initial prompt for a vibeflow session. A
+// provider with a PromptTemplate gets the template rendered with vars; one
+// without gets the built-in prompt. A template that renders to nothing
+// means the agent starts without a prompt. On a template error the
+// built-in prompt is returned along with the error, so a typo in
+// config.yaml degrades to the default instead of breaking the launch.
+func RenderInitPrompt(prov Provider, vars PromptTemplateVars) (string, error) {
+	if vars.MCPToolName == "" {
+		vars.MCPToolName = DefaultMCPToolName
+	}
+	builtin := BuildVibeflowInitPrompt(vars.MCPToolName, vars.Project, vars.Persona)
+	if vars.CloudDispatch {
+		builtin = BuildVibeflowCloudDispatchInitPrompt(vars.MCPToolName, vars.Project, vars.Persona, vars.SessionID)
+	}
+	if prov.PromptTemplate == "" {
+		return builtin, nil
+	}
+	t, err := template.New("prompt").Option("missingkey=error").Parse(prov.PromptTemplate)
+	if err != nil {
+		return builtin, fmt.Errorf("parse prompt template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return builtin, fmt.Errorf("render prompt template: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
 // AppendVibeflowInitPrompt appends a vibeflow init prompt to a rendered
 // launch command in the argument shape each provider's CLI expects, and
 // sh-escapes embedded single quotes so the result is a safe single-string
@@ -70,13 +113,25 @@ func BuildVibeflowCloudDispatchInitPrompt(mcpName, projectName, persona, session
 //     exits, which is wrong for autonomous sessions. The `-i` /
 //     `--prompt-interactive` flag is the documented way to seed an
 //     interactive run with an initial prompt.
+//   - openhands → `--task 'prompt'`, which starts the conversation with it.
+//   - aider → nothing. aider's positional arguments are files to add to the
+//     chat and `--message` answers once and exits, so there is no way to
+//     seed an interactive run; the session rules reach it through
+//     `--read AGENTS.md` in its launch template instead.
 func AppendVibeflowInitPrompt(baseCommand, providerKey, prompt string) string {
+	if prompt == "" {
+		return baseCommand
+	}
 	escaped := strings.ReplaceAll(prompt, "'", `'\''`)
 	switch providerKey {
 	case "gemini":
 		return baseCommand + fmt.Sprintf(" -p '%s'", escaped)
 	case "qwen":
 		return baseCommand + fmt.Sprintf(" -i '%s'", escaped)
+	case "openhands":
+		return baseCommand + fmt.Sprintf(" --task '%s'", escaped)
+	case "aider":
+		return baseCommand
 	default:
 		return baseCommand + fmt.Sprintf(" '%s'", escaped)
 	}
@@ -90,6 +90,65 @@ func TestDefaultMCPToolName(t *testing.T) {
 	}
 }
 
+func TestRenderInitPrompt(t *testing.T) {
+	vars := PromptTemplateVars{
+		Provider:  "claude",
+		Project:   "demo",
+		Persona:   "developer",
+		Branch:    "feat/x",
+		ServerURL: "https://vf.example",
+		SessionID: "s-1",
+	}
+
+	t.Run("no template uses the built-in prompt", func(t *testing.T) {
+		got, err := RenderInitPrompt(Provider{}, vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := BuildVibeflowInitPrompt(DefaultMCPToolName, "demo", "developer"); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("no template with cloud dispatch", func(t *testing.T) {
+		cloud := vars
+		cloud.CloudDispatch = true
+		got, _ := RenderInitPrompt(Provider{}, cloud)
+		if want := BuildVibeflowCloudDispatchInitPrompt(DefaultMCPToolName, "demo", "developer", "s-1"); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("template renders every variable", func(t *testing.T) {
+		prov := Provider{PromptTemplate: "{{.Provider}} {{.Project}} {{.Persona}} {{.Branch}} {{.ServerURL}} {{.SessionID}} {{.MCPToolName}}{{if .CloudDispatch}} cloud{{end}}\n"}
+		got, err := RenderInitPrompt(prov, vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "claude demo developer feat/x https://vf.example s-1 vibeflow"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("empty render means no prompt", func(t *testing.T) {
+		got, err := RenderInitPrompt(Provider{PromptTemplate: "{{if .CloudDispatch}}x{{end}}  "}, vars)
+		if err != nil || got != "" {
+			t.Errorf("got (%q, %v), want empty prompt", got, err)
+		}
+		if cmd := AppendVibeflowInitPrompt("claude", "claude", got); cmd != "claude" {
+			t.Errorf("AppendVibeflowInitPrompt with empty prompt = %q, want the bare command", cmd)
+		}
+	})
+	t.Run("broken template falls back with an error", func(t *testing.T) {
+		for _, tmpl := range []string{"{{.Project", "{{.NoSuchField}}"} {
+			got, err := RenderInitPrompt(Provider{PromptTemplate: tmpl}, vars)
+			if err == nil {
+				t.Errorf("%q: want an error", tmpl)
+			}
+			if want := BuildVibeflowInitPrompt(DefaultMCPToolName, "demo", "developer"); got != want {
+				t.Errorf("%q: got %q, want the built-in prompt", tmpl, got)
+			}
+		}
+	})
+}
+
 func TestAppendVibeflowInitPrompt(t *testing.T) {
 	const prompt = `Initialize a vibeflow session for project demo with persona "developer" and follow the agent prompt.`
 
@@ -157,6 +216,18 @@ func TestAppendVibeflowInitPrompt_QwenIsInteractive(t *testing.T) {
 	}
 }
 
+func TestAppendVibeflowInitPrompt_AiderAndOpenHands(t *testing.T) {
+	// aider treats positional arguments as files to edit, so the prompt is
+	// not passed on the command line at all.
+	if got := AppendVibeflowInitPrompt("aider --read AGENTS.md", "aider", "hello"); got != "aider --read AGENTS.md" {
+		t.Errorf("aider command = %q, want it unchanged", got)
+	}
+	const want = `openhands --always-approve --task 'hello'`
+	if got := AppendVibeflowInitPrompt("openhands --always-approve", "openhands", "hello"); got != want {
+		t.Errorf("openhands command = %q, want %q", got, want)
+	}
+}
+
 func TestAppendVibeflowInitPrompt_EscapesSingleQuotes(t *testing.T) {
 	// Embedded single quotes in the prompt must be sh-escaped via the
 	// '\'' idiom so the wrapping single-quoted argument stays balanced
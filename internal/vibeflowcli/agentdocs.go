@@ -41,6 +41,10 @@ var providerDocFile = map[string]string{
 	// Qwen Code reads QWEN.md (plus AGENTS.md) — it does NOT read GEMINI.md despite
 	// being a gemini-cli fork. See https://github.com/QwenLM/qwen-code docs/users/features/memory.md
 	"qwen": "QWEN.md",
+	// Aider is launched with --read AGENTS.md; OpenHands reads AGENTS.md from
+	// the repository root.
+	"aider":     "AGENTS.md",
+	"openhands": "AGENTS.md",
 }
 
 // vibeflowSectionMarker is the heading used to identify the vibeflow rules
@@ -52,11 +56,36 @@ const vibeflowSectionMarker = "## vibeflow Agent Session Rules"
 func GetAgentDoc(providerKey string) ([]byte, error) {
 	docFile, ok := providerDocFile[providerKey]
 	if !ok {
-		return nil, fmt.Errorf("unknown provider %q (valid: claude, codex, gemini, cursor, qwen)", providerKey)
+		return nil, fmt.Errorf("unknown provider %q (valid: claude, codex, gemini, cursor, qwen, aider, openhands)", providerKey)
 	}
 	return agentDocsFS.ReadFile("agentdocs/" + docFile)
 }
 
+// isEmbeddedAgentDoc reports whether name is one of the bundled templates.
+func isEmbeddedAgentDoc(name string) bool {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return false
+	}
+	_, err := agentDocsFS.ReadFile("agentdocs/" + name)
+	return err == nil
+}
+
+// GetProviderAgentDoc is GetAgentDoc extended to custom providers: when
+// providerKey isn't built in, the provider's agent_doc template is used.
+func GetProviderAgentDoc(providerKey string, registry *ProviderRegistry) ([]byte, error) {
+	if _, ok := providerDocFile[providerKey]; ok || registry == nil {
+		return GetAgentDoc(providerKey)
+	}
+	p, ok := registry.Get(providerKey)
+	if !ok {
+		return GetAgentDoc(providerKey)
+	}
+	if !isEmbeddedAgentDoc(p.AgentDoc) {
+		return nil, fmt.Errorf("provider %q has no agent_doc template configured", providerKey)
+	}
+	return agentDocsFS.ReadFile("agentdocs/" + p.AgentDoc)
+}
+
 // EnsureAllAgentDocs ensures all agent-specific markdown files (CLAUDE.md,
 // AGENTS.md, GEMINI.md, QWEN.md) exist in workDir with the vibeflow session
 // rules section. This guarantees that any provider session started in the
@@ -65,21 +94,58 @@ func GetAgentDoc(providerKey string) ([]byte, error) {
 //
 // Returns the list of filenames that were created or updated.
 func EnsureAllAgentDocs(workDir string) []string {
+	return ensureAgentDocs(workDir, nil)
+}
+
+// EnsureAllAgentDocsFor is EnsureAllAgentDocs with the rules section
+// rendered from the agent_docs template configured for vars.Project. A
+// template error falls back to the bundled section and is returned along
+// with the files written.
+func EnsureAllAgentDocsFor(workDir string, cfg *Config, vars AgentDocVars) ([]string, error) {
+	tmpl := agentDocTemplate(cfg, vars.Project)
+	if tmpl == "" {
+		return ensureAgentDocs(workDir, nil), nil
+	}
+	var firstErr error
+	render := func(bundled string) string {
+		section, err := renderAgentDocSection(tmpl, bundled, vars)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return section
+	}
+	return ensureAgentDocs(workDir, render), firstErr
+}
+
+// allAgentDocFiles returns each bundled agent doc file once, in a stable
+// order; codex before cursor so AGENTS.md is written once (both use the
+// same file).
+func allAgentDocFiles() []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, providerKey := range []string{"claude", "codex", "gemini", "cursor", "qwen", "aider", "openhands"} {
+		if doc, ok := providerDocFile[providerKey]; ok && !seen[doc] {
+			seen[doc] = true
+			files = append(files, doc)
+		}
+	}
+	return files
+}
+
+// ensureAgentDocs writes every agent doc in workDir whose rules section is
+// out of date. render maps a file's bundled section to the one to write;
+// nil keeps the bundled section.
+func ensureAgentDocs(workDir string, render func(bundled string) string) []string {
 	var updated []string
-	seenFile := make(map[string]bool)
-	// Stable order; codex before cursor so AGENTS.md is written once (both use same file).
-	for _, providerKey := range []string{"claude", "codex", "gemini", "cursor", "qwen"} {
-		docName, ok := providerDocFile[providerKey]
+	for _, docFile := range allAgentDocFiles() {
+		change, ok := planAgentDoc(workDir, docFile, render)
 		if !ok {
 			continue
 		}
-		if seenFile[docName] {
+		if err := os.WriteFile(change.Path, []byte(change.New), 0644); err != nil {
 			continue
 		}
-		seenFile[docName] = true
-		if docFile := EnsureAgentDoc(workDir, providerKey); docFile != "" {
-			updated = append(updated, docFile)
-		}
+		updated = append(updated, docFile)
 	}
 	return updated
 }
@@ -99,54 +165,73 @@ func EnsureAgentDoc(workDir, providerKey string) string {
 	if !ok {
 		return ""
 	}
+	change, ok := planAgentDoc(workDir, docFile, nil)
+	if !ok {
+		return ""
+	}
+	if err := os.WriteFile(change.Path, []byte(change.New), 0644); err != nil {
+		return ""
+	}
+	return docFile
+}
+
+// agentDocChange is the rewrite EnsureAgentDoc would make to one file.
+type agentDocChange struct {
+	Path string
+	Old  string // empty when the file doesn't exist yet
+	New  string
+}
 
+// planAgentDoc works out what EnsureAgentDoc would write to docFile in
+// workDir without touching the disk, with the section rendered as in
+// ensureAgentDocs. ok is false when the file is already up to date or the
+// template can't be read.
+func planAgentDoc(workDir, docFile string, render func(bundled string) string) (agentDocChange, bool) {
 	destPath := filepath.Join(workDir, docFile)
 	template, err := agentDocsFS.ReadFile("agentdocs/" + docFile)
 	if err != nil {
-		return ""
+		return agentDocChange{}, false
+	}
+	bundledSection := extractVibeflowSection(string(template))
+	section := bundledSection
+	if render != nil && bundledSection != "" {
+		section = render(bundledSection)
 	}
 
 	existing, readErr := os.ReadFile(destPath)
 	if readErr != nil {
-		// File doesn't exist — write the full template.
-		if err := os.WriteFile(destPath, template, 0644); err != nil {
-			return ""
+		// File doesn't exist — write the full template, with the rendered
+		// section in place of the bundled one.
+		content := string(template)
+		if section != bundledSection {
+			head := strings.TrimRight(content[:strings.Index(content, vibeflowSectionMarker)], "\n")
+			content = section + "\n"
+			if head != "" {
+				content = head + "\n\n" + content
+			}
 		}
-		return docFile
+		return agentDocChange{Path: destPath, New: content}, true
 	}
 
 	// File exists — check if vibeflow section is already present.
 	content := string(existing)
-	bundledSection := extractVibeflowSection(string(template))
+	if section == "" {
+		return agentDocChange{}, false // template has no vibeflow section (shouldn't happen)
+	}
 	if strings.Contains(content, vibeflowSectionMarker) {
-		// Section exists — check if it matches the bundled version.
-		installedSection := extractVibeflowSection(content)
-		if installedSection == bundledSection {
-			return "" // already up to date
+		// Section exists — check if it matches the wanted version.
+		if extractVibeflowSection(content) == section {
+			return agentDocChange{}, false // already up to date
 		}
 		// Stale section — replace it while preserving user content above.
-		if bundledSection == "" {
-			return ""
-		}
 		markerIdx := strings.Index(content, vibeflowSectionMarker)
 		userContent := strings.TrimRight(content[:markerIdx], "\n")
-		content = userContent + "\n\n" + bundledSection + "\n"
-		if err := os.WriteFile(destPath, []byte(content), 0644); err != nil {
-			return ""
-		}
-		return docFile
+		return agentDocChange{Path: destPath, Old: content, New: userContent + "\n\n" + section + "\n"}, true
 	}
 
-	// Extract the vibeflow section from the template and append it.
-	if bundledSection == "" {
-		return "" // template has no vibeflow section (shouldn't happen)
-	}
-
-	content = strings.TrimRight(content, "\n") + "\n\n" + bundledSection + "\n"
-	if err := os.WriteFile(destPath, []byte(content), 0644); err != nil {
-		return ""
-	}
-	return docFile
+	// Append the vibeflow section.
+	updated := strings.TrimRight(content, "\n") + "\n\n" + section + "\n"
+	return agentDocChange{Path: destPath, Old: content, New: updated}, true
 }
 
 // extractVibeflowSection returns the vibeflow rules section from a template
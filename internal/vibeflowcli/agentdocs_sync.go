@@ -0,0 +1,300 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// diffContext is the number of unchanged lines shown around a change.
+const diffContext = 3
+
+// agentDocSyncFiles returns the agent doc files sync should maintain: every
+// bundled file EnsureAllAgentDocs writes, or just providerKey's file.
+func agentDocSyncFiles(providerKey string, registry *ProviderRegistry) ([]string, error) {
+	if providerKey == "" {
+		return allAgentDocFiles(), nil
+	}
+	if doc, ok := providerDocFile[providerKey]; ok {
+		return []string{doc}, nil
+	}
+	if registry != nil {
+		if p, ok := registry.Get(providerKey); ok && isEmbeddedAgentDoc(p.AgentDoc) {
+			return []string{p.AgentDoc}, nil
+		}
+	}
+	if _, err := GetProviderAgentDoc(providerKey, registry); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("provider %q has no agent doc", providerKey)
+}
+
+// agentDocSyncDirs returns dir and, with worktrees set, every other
+// worktree of the repository dir belongs to. Paths are absolute and unique.
+func agentDocSyncDirs(dir string, worktrees bool) ([]string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{abs}
+	if !worktrees {
+		return dirs, nil
+	}
+	wm, err := NewWorktreeManager(abs, "")
+	if err != nil {
+		return nil, err
+	}
+	list, err := wm.List()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{abs: true}
+	for _, wt := range list {
+		if wt.Bare || seen[wt.Path] {
+			continue
+		}
+		seen[wt.Path] = true
+		dirs = append(dirs, wt.Path)
+	}
+	return dirs, nil
+}
+
+// planAgentDocSync returns the rewrites needed to bring files up to date in
+// each of dirs, in directory then file order. The rules section comes from
+// the agent_docs template for vars.Project, rendered per directory with its
+// branch and path; a template error is returned rather than falling back.
+func planAgentDocSync(dirs, files []string, cfg *Config, vars AgentDocVars) ([]agentDocChange, error) {
+	tmpl := agentDocTemplate(cfg, vars.Project)
+	var changes []agentDocChange
+	for _, dir := range dirs {
+		var render func(string) string
+		var renderErr error
+		if tmpl != "" {
+			dirVars := vars
+			dirVars.WorkDir = dir
+			dirVars.Branch = GetGitBranch(dir)
+			render = func(bundled string) string {
+				section, err := renderAgentDocSection(tmpl, bundled, dirVars)
+				if err != nil && renderErr == nil {
+					renderErr = err
+				}
+				return section
+			}
+		}
+		for _, doc := range files {
+			change, ok := planAgentDoc(dir, doc, render)
+			if renderErr != nil {
+				return nil, renderErr
+			}
+			if ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// unifiedDiff renders the change from oldText to newText as a single-hunk
+// unified diff. Agent doc rewrites only ever touch one contiguous block (the
+// vibeflow section), so trimming the common prefix and suffix is enough.
+func unifiedDiff(path, oldText, newText string) string {
+	a, b := diffLines(oldText), diffLines(newText)
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	if prefix == len(a) && prefix == len(b) {
+		return ""
+	}
+
+	start := max(prefix-diffContext, 0)
+	aEnd := min(len(a)-suffix+diffContext, len(a))
+	bEnd := min(len(b)-suffix+diffContext, len(b))
+
+	var sb strings.Builder
+	name := strings.TrimPrefix(filepath.ToSlash(path), "/")
+	from := "a/" + name
+	if oldText == "" {
+		from = "/dev/null"
+	}
+	fmt.Fprintf(&sb, "--- %s\n+++ b/%s\n", from, name)
+	fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(start, aEnd-start), hunkRange(start, bEnd-start))
+	for _, l := range a[start:prefix] {
+		sb.WriteString(" " + l + "\n")
+	}
+	for _, l := range a[prefix : len(a)-suffix] {
+		sb.WriteString("-" + l + "\n")
+	}
+	for _, l := range b[prefix : len(b)-suffix] {
+		sb.WriteString("+" + l + "\n")
+	}
+	for _, l := range a[len(a)-suffix : aEnd] {
+		sb.WriteString(" " + l + "\n")
+	}
+	return sb.String()
+}
+
+// diffLines splits text into lines without their terminators.
+func diffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// hunkRange formats one side of a hunk header. Lines are 1-based; an empty
+// range names the line before it, as diff(1) does.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// syncDisplayPath shows path relative to the working directory when it lies
+// beneath it, so diffs of the current checkout read like git's.
+func syncDisplayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// syncAgentDocs applies (or, with preview set, only reports) changes and
+// returns how many files were out of date.
+func syncAgentDocs(w io.Writer, changes []agentDocChange, showDiff, preview bool) (int, error) {
+	for _, c := range changes {
+		verb := "update"
+		if c.Old == "" {
+			verb = "create"
+		}
+		name := syncDisplayPath(c.Path)
+		if showDiff {
+			fmt.Fprint(w, unifiedDiff(name, c.Old, c.New))
+		}
+		if preview {
+			if !showDiff {
+				fmt.Fprintf(w, "would %s %s\n", verb, name)
+			}
+			continue
+		}
+		if err := os.WriteFile(c.Path, []byte(c.New), 0644); err != nil {
+			return 0, fmt.Errorf("write %s: %w", name, err)
+		}
+		fmt.Fprintf(w, "%sd %s\n", verb, name)
+	}
+	return len(changes), nil
+}
+
+// --- agent-doc sync ---
+
+func agentDocSyncCmd() *cobra.Command {
+	var dir, project, persona string
+	var showDiff, check, worktrees bool
+	cmd := &cobra.Command{
+		Use:   "sync [provider]",
+		Short: "Bring agent docs up to date with the bundled session rules",
+		Long: `Write the bundled vibeflow session rules into CLAUDE.md, AGENTS.md,
+GEMINI.md and QWEN.md, creating missing files and replacing a stale rules
+section while keeping everything above it. This is what launching a session
+does silently; sync lets you see and control it.
+
+Give a provider key to sync only that provider's file. --diff previews the
+changes without writing them, and --check exits non-zero when any file is
+out of date, for use in CI. --worktrees covers every worktree of the
+repository at once.
+
+When agent_docs in the config templates the section, it is rendered for
+--project (default: default_project) and --persona, with each directory's
+branch and path. There is no session here, so {{.SessionID}} is empty.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			if cfgPath == "" {
+				cfgPath = ConfigPath()
+			}
+			// A missing or unreadable config just means the bundled rules.
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				cfg = nil
+			}
+			var providerKey string
+			var registry *ProviderRegistry
+			if len(args) > 0 {
+				providerKey = args[0]
+				if cfg != nil {
+					registry = NewProviderRegistry(cfg)
+				}
+			}
+			files, err := agentDocSyncFiles(providerKey, registry)
+			if err != nil {
+				return err
+			}
+			dirs, err := agentDocSyncDirs(dir, worktrees)
+			if err != nil {
+				return err
+			}
+
+			if project == "" && cfg != nil {
+				project = cfg.DefaultProject
+			}
+			vars := AgentDocVars{Provider: providerKey, Project: project, Persona: persona}
+			vars.pendingWork = agentDocPendingWork(cfg, project)
+			changes, err := planAgentDocSync(dirs, files, cfg, vars)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			stale, err := syncAgentDocs(out, changes, showDiff, showDiff || check)
+			if err != nil {
+				return err
+			}
+			if stale == 0 {
+				fmt.Fprintln(out, "Agent docs are up to date.")
+				return nil
+			}
+			if check {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d agent doc(s) out of date; run `vibeflow agent-doc sync` to update them", stale)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", ".", "Directory to sync")
+	cmd.Flags().BoolVar(&showDiff, "diff", false, "Show the changes as a diff without writing them")
+	cmd.Flags().BoolVar(&check, "check", false, "Exit non-zero if any agent doc is out of date; write nothing")
+	cmd.Flags().BoolVar(&worktrees, "worktrees", false, "Also sync every other worktree of the repository")
+	cmd.Flags().StringVar(&project, "project", "", "Project whose agent_docs template to render (default: default_project)")
+	cmd.Flags().StringVar(&persona, "persona", "", "Persona to render the agent_docs template for")
+	return cmd
+}
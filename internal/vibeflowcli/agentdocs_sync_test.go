@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\n"
+	got := unifiedDiff("X.md", old, "a\nb\nc\nd\nE\nf\n")
+	want := "--- a/X.md\n+++ b/X.md\n@@ -2,5 +2,5 @@\n b\n c\n d\n-e\n+E\n f\n"
+	if got != want {
+		t.Errorf("diff:\n%s\nwant:\n%s", got, want)
+	}
+	if got := unifiedDiff("X.md", "", "new\n"); got != "--- /dev/null\n+++ b/X.md\n@@ -0,0 +1,1 @@\n+new\n" {
+		t.Errorf("diff of a new file:\n%s", got)
+	}
+	if got := unifiedDiff("X.md", old, old); got != "" {
+		t.Errorf("identical texts should not diff, got:\n%s", got)
+	}
+}
+
+func TestSyncAgentDocs_PreviewThenWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(path, []byte("# Mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files, err := agentDocSyncFiles("claude", nil)
+	if err != nil || len(files) != 1 || files[0] != "CLAUDE.md" {
+		t.Fatalf("agentDocSyncFiles(claude) = %v, %v", files, err)
+	}
+
+	var out bytes.Buffer
+	stale, err := syncAgentDocs(&out, mustPlanSync(t, []string{dir}, files), true, true)
+	if err != nil || stale != 1 {
+		t.Fatalf("preview: stale = %d, err = %v", stale, err)
+	}
+	if !strings.Contains(out.String(), "+"+vibeflowSectionMarker) {
+		t.Errorf("preview should show the added rules section:\n%s", out.String())
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# Mine\n" {
+		t.Error("preview must not write the file")
+	}
+
+	if _, err := syncAgentDocs(&out, mustPlanSync(t, []string{dir}, files), false, false); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# Mine\n") || !strings.Contains(string(data), vibeflowSectionMarker) {
+		t.Errorf("synced file = %q, want user content kept and rules appended", data)
+	}
+	if changes := mustPlanSync(t, []string{dir}, files); len(changes) != 0 {
+		t.Errorf("after sync %d change(s) remain", len(changes))
+	}
+}
+
+func mustPlanSync(t *testing.T, dirs, files []string) []agentDocChange {
+	t.Helper()
+	changes, err := planAgentDocSync(dirs, files, nil, AgentDocVars{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+func TestAgentDocSyncFiles_AllDeduplicated(t *testing.T) {
+	files, err := agentDocSyncFiles("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(files, ",") != "CLAUDE.md,AGENTS.md,GEMINI.md,QWEN.md" {
+		t.Errorf("files = %v", files)
+	}
+	if _, err := agentDocSyncFiles("nope", nil); err == nil {
+		t.Error("unknown provider should error")
+	}
+}
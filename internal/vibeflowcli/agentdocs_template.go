@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// maxPendingWorkItems caps how many work items PendingWork lists.
+const maxPendingWorkItems = 10
+
+// AgentDocVars are the variables available in agent_docs section templates.
+type AgentDocVars struct {
+	Provider  string // provider key, e.g. "claude"
+	Project   string
+	Persona   string
+	SessionID string
+	Branch    string
+	WorkDir   string // the worktree path the session runs in
+	// Rules is the bundled vibeflow section for the file being written, so
+	// a template can add to it rather than replace it.
+	Rules string
+
+	pendingWork func() string
+}
+
+// PendingWork summarizes the project's stuck and ready work items as a
+// markdown list. The server is only asked when a template uses it.
+func (v AgentDocVars) PendingWork() string {
+	if v.pendingWork == nil {
+		return ""
+	}
+	return v.pendingWork()
+}
+
+// agentDocTemplate returns the section template configured for project:
+// its agent_docs.projects entry, else agent_docs.section_template.
+func agentDocTemplate(cfg *Config, project string) string {
+	if cfg == nil {
+		return ""
+	}
+	if t := cfg.AgentDocs.Projects[project]; t != "" {
+		return t
+	}
+	return cfg.AgentDocs.SectionTemplate
+}
+
+// renderAgentDocSection renders tmpl for one agent doc whose bundled
+// section is bundled. The result always starts with vibeflowSectionMarker
+// so later launches can find and replace it. On error the bundled section
+// is returned with the error.
+func renderAgentDocSection(tmpl, bundled string, vars AgentDocVars) (string, error) {
+	if tmpl == "" {
+		return bundled, nil
+	}
+	vars.Rules = bundled
+	t, err := template.New("agent_doc").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return bundled, fmt.Errorf("parse agent_docs template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return bundled, fmt.Errorf("render agent_docs template: %w", err)
+	}
+	section := strings.TrimSpace(buf.String())
+	if !strings.HasPrefix(section, vibeflowSectionMarker) {
+		section = vibeflowSectionMarker + "\n\n" + section
+	}
+	return section, nil
+}
+
+// agentDocPendingWork returns a PendingWork source for project that polls
+// the server once, on first use.
+func agentDocPendingWork(cfg *Config, project string) func() string {
+	var summary string
+	var done bool
+	return func() string {
+		if done {
+			return summary
+		}
+		done = true
+		if cfg == nil || cfg.ServerURL == "" || project == "" {
+			return ""
+		}
+		client := newAPIClient(cfg)
+		projects, err := client.ListProjects()
+		if err != nil {
+			return ""
+		}
+		p, err := findProject(projects, project)
+		if err != nil {
+			return ""
+		}
+		work, err := client.PollPendingWork(p.ID)
+		if err != nil {
+			return ""
+		}
+		summary = pendingWorkSummary(work)
+		return summary
+	}
+}
+
+// pendingWorkSummary lists stuck then ready work items, one per line, or
+// "No pending work." when there is none.
+func pendingWorkSummary(work *PollResult) string {
+	if work == nil {
+		return ""
+	}
+	var lines []string
+	add := func(kind string, items []WorkItem) {
+		for _, it := range items {
+			lines = append(lines, fmt.Sprintf("- %s #%d: %s", kind, it.ID, it.Title))
+		}
+	}
+	add("stuck todo", work.StuckTodos)
+	add("stuck issue", work.StuckIssues)
+	add("ready todo", work.ReadyTodos)
+	add("ready issue", work.ReadyIssues)
+	if len(lines) == 0 {
+		return "No pending work."
+	}
+	if len(lines) > maxPendingWorkItems {
+		more := len(lines) - maxPendingWorkItems
+		lines = append(lines[:maxPendingWorkItems], fmt.Sprintf("- … and %d more", more))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnsureAllAgentDocsFor_ProjectTemplate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{AgentDocs: AgentDocConfig{
+		SectionTemplate: "Everyone: {{.Project}}",
+		Projects: map[string]string{
+			"web": vibeflowSectionMarker + "\n\n{{.Persona}} on {{.Branch}} in {{.WorkDir}} ({{.SessionID}})\n\n{{.PendingWork}}",
+		},
+	}}
+	vars := AgentDocVars{Project: "web", Persona: "developer", SessionID: "s-1", Branch: "main", WorkDir: dir}
+	vars.pendingWork = func() string { return "- ready issue #7: Fix login" }
+	if _, err := EnsureAllAgentDocsFor(dir, cfg, vars); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := vibeflowSectionMarker + "\n\ndeveloper on main in " + dir + " (s-1)\n\n- ready issue #7: Fix login"
+	if got := extractVibeflowSection(string(data)); got != want {
+		t.Errorf("section = %q, want %q", got, want)
+	}
+
+	// Another project falls back to section_template; the marker heading is
+	// added so the section can be found and replaced later.
+	vars.Project = "api"
+	if _, err := EnsureAllAgentDocsFor(dir, cfg, vars); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+	if got := extractVibeflowSection(string(data)); got != vibeflowSectionMarker+"\n\nEveryone: api" {
+		t.Errorf("fallback section = %q", got)
+	}
+}
+
+func TestEnsureAllAgentDocsFor_RulesAndBadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	bundled, _ := GetAgentDoc("claude")
+	rules := extractVibeflowSection(string(bundled))
+
+	cfg := &Config{AgentDocs: AgentDocConfig{SectionTemplate: "{{.Rules}}\n\nAlso run the linters."}}
+	if _, err := EnsureAllAgentDocsFor(dir, cfg, AgentDocVars{}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+	if got := extractVibeflowSection(string(data)); got != rules+"\n\nAlso run the linters." {
+		t.Errorf("section should extend the bundled rules, got %q", got)
+	}
+
+	cfg.AgentDocs.SectionTemplate = "{{.Nope}}"
+	if _, err := EnsureAllAgentDocsFor(dir, cfg, AgentDocVars{}); err == nil {
+		t.Error("unknown template variable should be reported")
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+	if got := extractVibeflowSection(string(data)); got != rules {
+		t.Error("a broken template should fall back to the bundled rules")
+	}
+}
+
+func TestPendingWorkSummary(t *testing.T) {
+	if got := pendingWorkSummary(&PollResult{}); got != "No pending work." {
+		t.Errorf("empty summary = %q", got)
+	}
+	work := &PollResult{
+		StuckTodos:  []WorkItem{{ID: 1, Title: "Stuck"}},
+		ReadyIssues: make([]WorkItem, maxPendingWorkItems+1),
+	}
+	lines := strings.Split(pendingWorkSummary(work), "\n")
+	if lines[0] != "- stuck todo #1: Stuck" {
+		t.Errorf("first line = %q", lines[0])
+	}
+	if len(lines) != maxPendingWorkItems+1 || lines[len(lines)-1] != "- … and 2 more" {
+		t.Errorf("summary should be capped, got %d lines ending %q", len(lines), lines[len(lines)-1])
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mockAPI is an in-memory VibeflowAPI. Unset hooks return zero values, and
+// every call is recorded in calls.
+type mockAPI struct {
+	projects    []Project
+	projectsErr error
+	sessions    map[int64][]Session
+	poll        map[int64]*PollResult
+	expired     bool
+	calls       []string
+}
+
+var _ VibeflowAPI = (*mockAPI)(nil)
+
+func (m *mockAPI) record(call string) { m.calls = append(m.calls, call) }
+
+func (m *mockAPI) ListProjects() ([]Project, error) {
+	m.record("ListProjects")
+	return m.projects, m.projectsErr
+}
+
+func (m *mockAPI) CreateProject(name string) (*Project, error) {
+	m.record("CreateProject " + name)
+	p := Project{ID: int64(len(m.projects) + 1), Name: name}
+	m.projects = append(m.projects, p)
+	return &p, nil
+}
+
+func (m *mockAPI) ListSessions(projectID int64) ([]Session, error) {
+	m.record(fmt.Sprintf("ListSessions %d", projectID))
+	if s, ok := m.sessions[projectID]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("project %d not found", projectID)
+}
+
+func (m *mockAPI) PollPendingWork(projectID int64) (*PollResult, error) {
+	m.record("PollPendingWork")
+	if r, ok := m.poll[projectID]; ok {
+		return r, nil
+	}
+	return &PollResult{}, nil
+}
+
+func (m *mockAPI) SessionInit(SessionInitRequest) (*SessionInitResult, error) {
+	m.record("SessionInit")
+	return &SessionInitResult{}, nil
+}
+
+func (m *mockAPI) SessionRegister(req SessionRegisterRequest) error {
+	m.record("SessionRegister " + req.SessionID)
+	return nil
+}
+
+func (m *mockAPI) SessionHeartbeat(sessionID, status string) error {
+	m.record("SessionHeartbeat " + sessionID + " " + status)
+	return nil
+}
+
+func (m *mockAPI) SessionArchive(sessionID string, _ SessionArchiveRequest) error {
+	m.record("SessionArchive " + sessionID)
+	return nil
+}
+
+func (m *mockAPI) GetIssue(int64, int64) (*Issue, error) {
+	m.record("GetIssue")
+	return &Issue{}, nil
+}
+
+func (m *mockAPI) GetSessionStatus(string) (*SessionStatus, error) {
+	m.record("GetSessionStatus")
+	return &SessionStatus{}, nil
+}
+
+func (m *mockAPI) DispatchNext(DispatchNextRequest) (*DispatchNextResponse, error) {
+	m.record("DispatchNext")
+	return &DispatchNextResponse{}, nil
+}
+
+func (m *mockAPI) DispatchAck(int64, string) error {
+	m.record("DispatchAck")
+	return nil
+}
+
+func (m *mockAPI) DispatchNack(int64, string, string) error {
+	m.record("DispatchNack")
+	return nil
+}
+
+func (m *mockAPI) AuthExpired() bool { return m.expired }
+
+func TestNewVibeflowAPI_DefaultsToHTTPClient(t *testing.T) {
+	api := NewVibeflowAPI(&Config{ServerURL: "http://vf.invalid", APIToken: "tok"})
+	if _, ok := api.(*Client); !ok {
+		t.Errorf("NewVibeflowAPI = %T, want *Client", api)
+	}
+}
+
+func TestWizard_ProjectsFromAPI(t *testing.T) {
+	reg := NewProviderRegistry(DefaultConfig())
+	api := &mockAPI{projects: []Project{{ID: 1, Name: "alpha"}, {ID: 2, Name: "beta"}}}
+	w := NewWizardModel(reg, ".", nil, api, "", nil, DefaultConfig())
+	if len(w.projects) != 2 || w.projectErr != "" {
+		t.Errorf("projects = %+v, err = %q", w.projects, w.projectErr)
+	}
+
+	api = &mockAPI{projectsErr: errors.New("connection refused")}
+	w = NewWizardModel(reg, ".", nil, api, "", nil, DefaultConfig())
+	if len(w.projects) != 0 || !strings.Contains(w.projectErr, "connection refused") {
+		t.Errorf("projects = %+v, err = %q", w.projects, w.projectErr)
+	}
+}
+
+func TestResolveProjectID_WithMock(t *testing.T) {
+	api := &mockAPI{projects: []Project{{ID: 7, Name: "web"}}}
+	if got := resolveProjectID(api, "web"); got != 7 {
+		t.Errorf("resolveProjectID(web) = %d, want 7", got)
+	}
+	if got := resolveProjectID(api, "missing"); got != 0 {
+		t.Errorf("resolveProjectID(missing) = %d, want 0", got)
+	}
+	api.projectsErr = errors.New("down")
+	if got := resolveProjectID(api, "web"); got != 0 {
+		t.Errorf("resolveProjectID with an error = %d, want 0", got)
+	}
+}
+
+func TestPendingWork_WithMock(t *testing.T) {
+	api := &mockAPI{poll: map[int64]*PollResult{
+		4: {ReadyIssues: []WorkItem{{Type: "issue", ID: 5, Title: "Fix login"}}},
+	}}
+	m := Model{config: &Config{}, client: api, projectID: 4, logger: &Logger{}}
+	nm, _ := m.Update(m.fetchPendingWork()())
+	m = nm.(Model)
+	if len(m.pending) != 1 || m.pending[0].Item.ID != 5 {
+		t.Errorf("pending = %+v, want issue 5", m.pending)
+	}
+}
+
+func TestAuthBanner_NonHTTPBackend(t *testing.T) {
+	m := Model{keys: defaultKeymap(), client: &mockAPI{expired: true}}
+	if got := m.authBanner(); !strings.Contains(got, "api_token") {
+		t.Errorf("banner = %q, want the api_token hint", got)
+	}
+	if m.oauthSource() != nil {
+		t.Error("a non-HTTP backend has no OAuth login")
+	}
+}
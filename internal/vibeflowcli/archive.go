@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultArchiveTailLines is used when archive.tail_lines is unset.
+	defaultArchiveTailLines = 50
+	// archiveInterval is how often the TUI uploads newly ended sessions.
+	archiveInterval = time.Minute
+	// archiveMaxAge bounds the backlog: sessions that ended longer ago are
+	// not uploaded, e.g. when archiving is first turned on.
+	archiveMaxAge = 7 * 24 * time.Hour
+	// archiveMaxCommits caps the commit SHAs sent per session.
+	archiveMaxCommits = 100
+	// archiveLogReadBytes is how much of a session log is read for its tail.
+	archiveLogReadBytes = 64 << 10
+)
+
+// tailLines returns the configured tail length, or the default.
+func (c ArchiveConfig) tailLines() int {
+	if c.TailLines > 0 {
+		return c.TailLines
+	}
+	return defaultArchiveTailLines
+}
+
+// SessionArchiver uploads a summary of every ended session to the server.
+// Ended sessions are found in the history, so sessions killed from the CLI
+// are uploaded too, by the next TUI to run.
+type SessionArchiver struct {
+	client    VibeflowAPI
+	logger    *Logger
+	tailLines int
+}
+
+// NewSessionArchiver returns nil when archiving is disabled or there is no
+// server to upload to, so callers can nil-check once.
+func NewSessionArchiver(cfg ArchiveConfig, client VibeflowAPI, logger *Logger) *SessionArchiver {
+	if !cfg.Enabled || client == nil {
+		return nil
+	}
+	return &SessionArchiver{client: client, logger: logger, tailLines: cfg.tailLines()}
+}
+
+// archivePending returns the ended entries not yet uploaded, oldest first.
+func archivePending(entries []HistoryEntry, now time.Time) []HistoryEntry {
+	var out []HistoryEntry
+	for _, e := range entries {
+		if e.Open() || !e.ArchivedAt.IsZero() || now.Sub(e.EndedAt) > archiveMaxAge {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// Archive uploads every pending history entry. projectID resolves an
+// entry's server project (0 skips it); tail reads the final output of
+// entries that have none saved. A failed upload is retried next time.
+func (a *SessionArchiver) Archive(history *SessionHistory, projectID func(HistoryEntry) int64, tail func(HistoryEntry) string, now time.Time) {
+	entries, err := history.List()
+	if err != nil {
+		a.logger.Warn("archive: read history: %v", err)
+		return
+	}
+	for _, e := range archivePending(entries, now) {
+		pid := projectID(e)
+		if pid <= 0 {
+			continue
+		}
+		output := e.OutputTail
+		if output == "" && tail != nil {
+			output = tail(e)
+		}
+		req := archiveRequest(e, pid, lastNLines(strings.TrimRight(output, "\n"), a.tailLines), sessionCommits(e))
+		if err := a.client.SessionArchive(heartbeatSessionID(e.SessionMeta), req); err != nil {
+			a.logger.Warn("archive: %s: %v", e.Name, err)
+			continue
+		}
+		if err := history.MarkArchived(e.Name, e.CreatedAt, now); err != nil {
+			a.logger.Warn("archive: mark %s: %v", e.Name, err)
+		}
+	}
+}
+
+// archiveRequest builds the upload for one ended session.
+func archiveRequest(e HistoryEntry, projectID int64, tail string, commits []string) SessionArchiveRequest {
+	dir := e.WorkingDir
+	if e.WorktreePath != "" {
+		dir = e.WorktreePath
+	}
+	req := SessionArchiveRequest{
+		ProjectID:        projectID,
+		SessionName:      e.Name,
+		Provider:         e.Provider,
+		Persona:          e.Persona,
+		GitBranch:        e.Branch,
+		WorkingDirectory: dir,
+		StartedAt:        e.CreatedAt,
+		EndedAt:          e.EndedAt,
+		DurationSeconds:  int64(e.Duration(e.EndedAt) / time.Second),
+		ExitReason:       e.ExitReason,
+		CommitSHAs:       commits,
+		OutputTail:       tail,
+		RecoveryAttempts: e.RecoveryAttempts,
+	}
+	for _, r := range e.Recoveries {
+		req.Recoveries = append(req.Recoveries, SessionArchiveRecovery{At: r.At, Error: r.Error})
+	}
+	return req
+}
+
+// sessionCommits returns the SHAs committed on the session's branch while
+// it ran, newest first, or nil when its checkout is gone.
+func sessionCommits(e HistoryEntry) []string {
+	dir := e.WorktreePath
+	if dir == "" {
+		dir = e.WorkingDir
+	}
+	if dir == "" || e.CreatedAt.IsZero() {
+		return nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil
+	}
+	ref := e.Branch
+	if ref == "" {
+		ref = "HEAD"
+	}
+	out, err := gitIn(dir, "log", "--format=%H", "-n", strconv.Itoa(archiveMaxCommits),
+		"--since="+e.CreatedAt.Format(time.RFC3339), "--until="+e.EndedAt.Format(time.RFC3339), ref, "--")
+	if err != nil || out == "" {
+		return nil
+	}
+	return strings.Fields(out)
+}
+
+// saveArchiveTail stores the pane's last output on meta's history entry
+// before the session is killed, so the archive upload still has it.
+func saveArchiveTail(cfg *Config, tmux *TmuxManager, store *Store, meta SessionMeta) {
+	if cfg == nil || !cfg.Archive.Enabled || store == nil {
+		return
+	}
+	name := meta.TmuxSession
+	if name == "" {
+		name = meta.Name
+	}
+	out, err := tmux.CapturePaneOutput(name, cfg.Archive.tailLines())
+	if err != nil || strings.TrimSpace(out) == "" {
+		return
+	}
+	_ = store.History().RecordOutputTail(meta.Name, out)
+}
+
+// sessionLogTail returns the last lines of the session log written for e,
+// or "" when there is none from that run.
+func sessionLogTail(e HistoryEntry, lines int) string {
+	// Logs are written under the tmux name.
+	name := e.TmuxSession
+	if name == "" {
+		name = e.Name
+	}
+	path := SessionLogPath(name)
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	// A log written after the session ended belongs to a later launch.
+	if err != nil || info.ModTime().Before(e.CreatedAt) || info.ModTime().After(e.EndedAt.Add(time.Minute)) {
+		return ""
+	}
+	var offset int64
+	if info.Size() > archiveLogReadBytes {
+		offset = info.Size() - archiveLogReadBytes
+	}
+	buf := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil {
+		return ""
+	}
+	return lastNLines(strings.TrimRight(stripANSI(string(buf)), "\n"), lines)
+}
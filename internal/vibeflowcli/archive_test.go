@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewSessionArchiver_Disabled(t *testing.T) {
+	if NewSessionArchiver(ArchiveConfig{}, NewClient("http://x", ""), &Logger{}) != nil {
+		t.Error("disabled config should yield nil archiver")
+	}
+	if NewSessionArchiver(ArchiveConfig{Enabled: true}, nil, &Logger{}) != nil {
+		t.Error("no client should yield nil archiver")
+	}
+}
+
+func TestSessionArchiver_UploadsEndedSessionsOnce(t *testing.T) {
+	var mu sync.Mutex
+	got := map[string]SessionArchiveRequest{}
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !strings.HasSuffix(r.URL.Path, "/archive") {
+			http.NotFound(w, r)
+			return
+		}
+		if fail {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		var req SessionArchiveRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got[strings.Split(r.URL.Path, "/")[5]] = req
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	h := NewSessionHistoryWithPath(filepath.Join(t.TempDir(), "state.db"))
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-2 * time.Hour)
+	_ = h.Start(SessionMeta{Name: "api", VibeFlowSessionID: "session-1", Provider: "claude", Branch: "feat/x", ProjectID: 4, CreatedAt: start})
+	_ = h.RecordRecovery("api", "Claude API 5xx", start.Add(time.Minute))
+	_ = h.RecordOutputTail("api", "one\ntwo\nthree")
+	_ = h.End("api", ExitKilled, now.Add(-time.Hour))
+	_ = h.Start(SessionMeta{Name: "local", CreatedAt: start})
+	_ = h.End("local", ExitExited, now.Add(-time.Hour))
+	_ = h.Start(SessionMeta{Name: "old", ProjectID: 4, CreatedAt: start.Add(-30 * 24 * time.Hour)})
+	_ = h.End("old", ExitKilled, start.Add(-29*24*time.Hour))
+	_ = h.Start(SessionMeta{Name: "running", ProjectID: 4, CreatedAt: start})
+
+	a := &SessionArchiver{client: NewClient(srv.URL, ""), logger: &Logger{}, tailLines: 2}
+	projectID := func(e HistoryEntry) int64 { return e.ProjectID }
+	a.Archive(h, projectID, nil, now)
+	if entries, _ := h.List(); !entries[0].ArchivedAt.IsZero() {
+		t.Fatal("a failed upload must not mark the entry archived")
+	}
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	a.Archive(h, projectID, nil, now)
+	a.Archive(h, projectID, nil, now)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 {
+		t.Fatalf("uploads = %v, want only the recent session with a project", got)
+	}
+	req := got["session-1"]
+	if req.ProjectID != 4 || req.GitBranch != "feat/x" || req.ExitReason != ExitKilled || req.DurationSeconds != 3600 {
+		t.Errorf("request = %+v", req)
+	}
+	if req.OutputTail != "two\nthree" {
+		t.Errorf("tail = %q, want the last 2 lines", req.OutputTail)
+	}
+	if len(req.Recoveries) != 1 || req.Recoveries[0].Error != "Claude API 5xx" || req.RecoveryAttempts != 1 {
+		t.Errorf("recoveries = %+v (%d)", req.Recoveries, req.RecoveryAttempts)
+	}
+	entries, _ := h.List()
+	if entries[0].ArchivedAt.IsZero() || !entries[1].ArchivedAt.IsZero() {
+		t.Error("only the uploaded entry should be marked archived")
+	}
+}
+
+func TestSessionLogTail(t *testing.T) {
+	withTempRoot(t)
+	start := time.Now().Add(-time.Hour)
+	e := HistoryEntry{
+		SessionMeta: SessionMeta{Name: "api", TmuxSession: "vibeflow_claude-api", CreatedAt: start},
+		EndedAt:     time.Now(),
+	}
+	if got := sessionLogTail(e, 2); got != "" {
+		t.Errorf("tail without a log = %q", got)
+	}
+	path := SessionLogPath(e.TmuxSession)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("one\n\x1b[1mtwo\x1b[0m\nthree\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := sessionLogTail(e, 2); got != "two\nthree" {
+		t.Errorf("tail = %q, want the last 2 lines of the log", got)
+	}
+	e.EndedAt = start.Add(-time.Hour)
+	if got := sessionLogTail(e, 2); got != "" {
+		t.Errorf("tail of a later launch's log = %q", got)
+	}
+}
+
+func TestSessionCommits(t *testing.T) {
+	repo := initTestRepo(t)
+	start := time.Now().Add(-time.Minute)
+	commitFile(t, repo, "a.txt", "a")
+	e := HistoryEntry{SessionMeta: SessionMeta{WorkingDir: repo, CreatedAt: start}, EndedAt: time.Now().Add(time.Minute)}
+	commits := sessionCommits(e)
+	head, _ := gitIn(repo, "rev-parse", "HEAD")
+	if len(commits) == 0 || commits[0] != head {
+		t.Errorf("commits = %v, want HEAD %s first", commits, head)
+	}
+	e.WorkingDir = filepath.Join(repo, "gone")
+	if sessionCommits(e) != nil {
+		t.Error("a missing checkout should yield no commits")
+	}
+}
@@ -0,0 +1,470 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Auth methods for AuthConfig.Method.
+const (
+	authMethodToken = "token"
+	authMethodOAuth = "oauth"
+)
+
+const (
+	// defaultOAuthClientID is the client ID the CLI registers as.
+	defaultOAuthClientID = "vibeflow-cli"
+	// defaultDevicePollInterval is the device-flow polling interval when
+	// the server doesn't name one; slow_down adds deviceSlowDownStep.
+	defaultDevicePollInterval = 5 * time.Second
+	deviceSlowDownStep        = 5 * time.Second
+	// tokenExpiryLeeway refreshes access tokens this long before they
+	// expire, so a request never goes out with a token about to lapse.
+	tokenExpiryLeeway = time.Minute
+)
+
+// ErrUnauthorized is returned when the server rejects the credentials
+// (HTTP 401) or there are none to send: no OAuth login yet, or a refresh
+// token the server no longer accepts.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// AuthConfig picks how the CLI authenticates to the VibeFlow server. With
+// "token" (the default) api_token is sent as is; with "oauth" the tokens
+// from `vibeflow login` are used and refreshed as they expire. Agents'
+// MCP_TOKEN still comes from api_token either way.
+type AuthConfig struct {
+	Method        string   `yaml:"method,omitempty"`          // "token" (default) or "oauth"
+	ClientID      string   `yaml:"client_id,omitempty"`       // default "vibeflow-cli"
+	DeviceAuthURL string   `yaml:"device_auth_url,omitempty"` // default <server_url>/oauth/device/code
+	TokenURL      string   `yaml:"token_url,omitempty"`       // default <server_url>/oauth/token
+	Scopes        []string `yaml:"scopes,omitempty"`
+}
+
+// Validate reports an unknown auth method.
+func (a AuthConfig) Validate() error {
+	switch a.Method {
+	case "", authMethodToken, authMethodOAuth:
+		return nil
+	}
+	return fmt.Errorf("invalid auth.method %q (token or oauth)", a.Method)
+}
+
+// OAuth reports whether the device-flow tokens are used.
+func (a AuthConfig) OAuth() bool { return a.Method == authMethodOAuth }
+
+func (a AuthConfig) clientID() string {
+	if a.ClientID != "" {
+		return a.ClientID
+	}
+	return defaultOAuthClientID
+}
+
+func (a AuthConfig) deviceAuthURL(serverURL string) string {
+	if a.DeviceAuthURL != "" {
+		return a.DeviceAuthURL
+	}
+	return strings.TrimRight(serverURL, "/") + "/oauth/device/code"
+}
+
+func (a AuthConfig) tokenURL(serverURL string) string {
+	if a.TokenURL != "" {
+		return a.TokenURL
+	}
+	return strings.TrimRight(serverURL, "/") + "/oauth/token"
+}
+
+// usesOAuth reports whether API requests authenticate with the OAuth
+// tokens. VIBEFLOW_TOKEN still wins, so scripts can pin a static token.
+func (c *Config) usesOAuth() bool {
+	return c.Auth.OAuth() && os.Getenv("VIBEFLOW_TOKEN") == ""
+}
+
+// hasAPIAuth reports whether API requests carry credentials of some kind.
+func (c *Config) hasAPIAuth() bool {
+	return c.APIToken != "" || c.usesOAuth()
+}
+
+// secretStore returns the secret store next to the config file.
+func (c *Config) secretStore() SecretStore {
+	dir := c.configDir
+	if dir == "" {
+		dir = RootDir()
+	}
+	return newSecretStore(dir)
+}
+
+// newAPIClient returns the API client for cfg, authenticating with the
+// OAuth tokens or the static api_token as configured.
+func newAPIClient(cfg *Config) *Client {
+	client := NewClient(cfg.ServerURL, cfg.APIToken)
+	if cfg.usesOAuth() {
+		client.tokens = newOAuthTokenSource(newOAuthClient(cfg), cfg.secretStore())
+	}
+	return client
+}
+
+// NewVibeflowAPI returns the API backend the TUI and wizard talk to. It
+// defaults to the HTTP client and can be replaced to run against another
+// backend.
+var NewVibeflowAPI = func(cfg *Config) VibeflowAPI {
+	return newAPIClient(cfg)
+}
+
+// OAuthToken is a stored OAuth login. A zero Expiry means the access token
+// doesn't expire.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// valid reports whether the access token can still be sent at now.
+func (t *OAuthToken) valid(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || now.Before(t.Expiry.Add(-tokenExpiryLeeway))
+}
+
+// oauthSecretKey is the secret-store key of the login for serverURL, so
+// logins to different servers don't overwrite each other.
+func oauthSecretKey(serverURL string) string {
+	return "oauth/" + strings.TrimRight(serverURL, "/")
+}
+
+// loadOAuthToken returns the stored login for serverURL; ErrSecretNotFound
+// means there is none.
+func loadOAuthToken(store SecretStore, serverURL string) (*OAuthToken, error) {
+	raw, err := store.Get(oauthSecretKey(serverURL))
+	if err != nil {
+		return nil, err
+	}
+	var tok OAuthToken
+	if err := json.Unmarshal([]byte(raw), &tok); err != nil {
+		return nil, fmt.Errorf("parse stored login: %w", err)
+	}
+	return &tok, nil
+}
+
+func saveOAuthToken(store SecretStore, serverURL string, tok *OAuthToken) error {
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	return store.Set(oauthSecretKey(serverURL), string(data))
+}
+
+// DeviceCode is the server's answer to a device authorization request
+// (RFC 8628): the user opens VerificationURI and enters UserCode while the
+// CLI polls with DeviceCode.
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// oauthClient talks to the server's OAuth endpoints.
+type oauthClient struct {
+	auth       AuthConfig
+	serverURL  string
+	httpClient *http.Client
+	// wait sleeps between device-flow polls; tests replace it.
+	wait func(ctx context.Context, d time.Duration) error
+	now  func() time.Time
+}
+
+func newOAuthClient(cfg *Config) *oauthClient {
+	return &oauthClient{
+		auth:       cfg.Auth,
+		serverURL:  cfg.ServerURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		wait:       sleepContext,
+		now:        time.Now,
+	}
+}
+
+// sleepContext sleeps for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// oauthError is an OAuth error response body.
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *oauthError) Error() string {
+	if e.Description != "" {
+		return e.Code + ": " + e.Description
+	}
+	return e.Code
+}
+
+// postForm posts form to endpoint and decodes a 200 response into result.
+// OAuth error responses come back as *oauthError.
+func (o *oauthClient) postForm(ctx context.Context, endpoint string, form url.Values, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var oerr oauthError
+		if json.NewDecoder(resp.Body).Decode(&oerr) == nil && oerr.Code != "" {
+			return &oerr
+		}
+		return fmt.Errorf("HTTP %d from %s", resp.StatusCode, endpoint)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// StartDeviceFlow requests a device code for the user to approve.
+func (o *oauthClient) StartDeviceFlow(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{"client_id": {o.auth.clientID()}}
+	if len(o.auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(o.auth.Scopes, " "))
+	}
+	var dc DeviceCode
+	if err := o.postForm(ctx, o.auth.deviceAuthURL(o.serverURL), form, &dc); err != nil {
+		return nil, fmt.Errorf("start device login: %w", err)
+	}
+	if dc.DeviceCode == "" || dc.UserCode == "" || dc.VerificationURI == "" {
+		return nil, fmt.Errorf("start device login: incomplete response from server")
+	}
+	return &dc, nil
+}
+
+// tokenResponse is a token endpoint success body.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func (o *oauthClient) token(ctx context.Context, form url.Values) (*OAuthToken, error) {
+	form.Set("client_id", o.auth.clientID())
+	var tr tokenResponse
+	if err := o.postForm(ctx, o.auth.tokenURL(o.serverURL), form, &tr); err != nil {
+		return nil, err
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+	tok := &OAuthToken{AccessToken: tr.AccessToken, RefreshToken: tr.RefreshToken}
+	if tr.ExpiresIn > 0 {
+		tok.Expiry = o.now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	return tok, nil
+}
+
+// PollDeviceToken polls until the user approves or denies dc, or it
+// expires, following RFC 8628's authorization_pending and slow_down.
+func (o *oauthClient) PollDeviceToken(ctx context.Context, dc *DeviceCode) (*OAuthToken, error) {
+	interval := time.Duration(dc.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	var deadline time.Time
+	if dc.ExpiresIn > 0 {
+		deadline = o.now().Add(time.Duration(dc.ExpiresIn) * time.Second)
+	}
+	for {
+		if !deadline.IsZero() && !o.now().Before(deadline) {
+			return nil, fmt.Errorf("device login expired before it was approved")
+		}
+		if err := o.wait(ctx, interval); err != nil {
+			return nil, err
+		}
+		tok, err := o.token(ctx, url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {dc.DeviceCode},
+		})
+		var oerr *oauthError
+		switch {
+		case err == nil:
+			return tok, nil
+		case !errors.As(err, &oerr):
+			return nil, fmt.Errorf("device login: %w", err)
+		case oerr.Code == "authorization_pending":
+		case oerr.Code == "slow_down":
+			interval += deviceSlowDownStep
+		case oerr.Code == "access_denied":
+			return nil, fmt.Errorf("device login was denied")
+		case oerr.Code == "expired_token":
+			return nil, fmt.Errorf("device login expired before it was approved")
+		default:
+			return nil, fmt.Errorf("device login: %w", err)
+		}
+	}
+}
+
+// Refresh exchanges a refresh token for a new access token. A rejected
+// refresh token is ErrUnauthorized: the user has to log in again.
+func (o *oauthClient) Refresh(ctx context.Context, refreshToken string) (*OAuthToken, error) {
+	tok, err := o.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	var oerr *oauthError
+	if errors.As(err, &oerr) && oerr.Code == "invalid_grant" {
+		return nil, fmt.Errorf("%w: login expired", ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("refresh login: %w", err)
+	}
+	if tok.RefreshToken == "" {
+		// Servers may keep the refresh token and only rotate access tokens.
+		tok.RefreshToken = refreshToken
+	}
+	return tok, nil
+}
+
+// tokenSource supplies the bearer token for API requests.
+type tokenSource interface {
+	// Token returns a valid access token, refreshing it if needed.
+	Token() (string, error)
+	// Refresh forces a refresh, after the server rejected the current token.
+	Refresh() (string, error)
+}
+
+// oauthTokenSource serves the stored login, refreshing it before it expires
+// and saving each refreshed token back to the secret store.
+type oauthTokenSource struct {
+	mu    sync.Mutex
+	oauth *oauthClient
+	store SecretStore
+	tok   *OAuthToken
+}
+
+func newOAuthTokenSource(oauth *oauthClient, store SecretStore) *oauthTokenSource {
+	return &oauthTokenSource{oauth: oauth, store: store}
+}
+
+// Token implements tokenSource.
+func (s *oauthTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	if s.tok.valid(s.oauth.now()) {
+		return s.tok.AccessToken, nil
+	}
+	return s.refresh()
+}
+
+// Refresh implements tokenSource.
+func (s *oauthTokenSource) Refresh() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	return s.refresh()
+}
+
+// load reads the stored login on first use.
+func (s *oauthTokenSource) load() error {
+	if s.tok != nil {
+		return nil
+	}
+	tok, err := loadOAuthToken(s.store, s.oauth.serverURL)
+	if errors.Is(err, ErrSecretNotFound) {
+		return fmt.Errorf("%w: not logged in", ErrUnauthorized)
+	}
+	if err != nil {
+		return err
+	}
+	s.tok = tok
+	return nil
+}
+
+// refresh replaces the cached token. Another process sharing the login may
+// have refreshed it already, and with rotating refresh tokens that spent
+// ours, so a stored token newer than the cached one is taken first and only
+// refreshed itself when it has expired too.
+func (s *oauthTokenSource) refresh() (string, error) {
+	if stored, err := loadOAuthToken(s.store, s.oauth.serverURL); err == nil && stored.AccessToken != s.tok.AccessToken {
+		s.tok = stored
+		if stored.valid(s.oauth.now()) {
+			return stored.AccessToken, nil
+		}
+	}
+	if s.tok.RefreshToken == "" {
+		return "", fmt.Errorf("%w: login expired", ErrUnauthorized)
+	}
+	tok, err := s.oauth.Refresh(context.Background(), s.tok.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	s.tok = tok
+	if err := saveOAuthToken(s.store, s.oauth.serverURL, tok); err != nil {
+		return "", fmt.Errorf("save refreshed login: %w", err)
+	}
+	return tok.AccessToken, nil
+}
+
+// Login runs the device flow's polling half for dc and stores the
+// resulting login.
+func (s *oauthTokenSource) Login(ctx context.Context, dc *DeviceCode) error {
+	tok, err := s.oauth.PollDeviceToken(ctx, dc)
+	if err != nil {
+		return err
+	}
+	if err := saveOAuthToken(s.store, s.oauth.serverURL, tok); err != nil {
+		return fmt.Errorf("save login: %w", err)
+	}
+	s.mu.Lock()
+	s.tok = tok
+	s.mu.Unlock()
+	return nil
+}
+
+// Logout forgets the stored login.
+func (s *oauthTokenSource) Logout() error {
+	s.mu.Lock()
+	s.tok = nil
+	s.mu.Unlock()
+	return s.store.Delete(oauthSecretKey(s.oauth.serverURL))
+}
@@ -0,0 +1,301 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeOAuthServer is a device-flow server: the device code is approved
+// after pending polls, and refresh tokens named in valid are accepted.
+type fakeOAuthServer struct {
+	pending   int
+	slowDown  bool
+	deny      bool
+	refreshes int
+	valid     map[string]bool
+	access    string // the access token the API accepts
+}
+
+func (f *fakeOAuthServer) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	oauthErr := func(w http.ResponseWriter, code string) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": code})
+	}
+	mux.HandleFunc("/oauth/device/code", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.FormValue("client_id"); got != "vibeflow-cli" {
+			t.Errorf("client_id = %q", got)
+		}
+		json.NewEncoder(w).Encode(DeviceCode{
+			DeviceCode: "dev-1", UserCode: "ABCD-EFGH",
+			VerificationURI: "https://vf.example/device", ExpiresIn: 600, Interval: 1,
+		})
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			switch {
+			case f.deny:
+				oauthErr(w, "access_denied")
+			case f.slowDown:
+				f.slowDown = false
+				oauthErr(w, "slow_down")
+			case f.pending > 0:
+				f.pending--
+				oauthErr(w, "authorization_pending")
+			default:
+				f.access = "access-1"
+				json.NewEncoder(w).Encode(map[string]any{"access_token": "access-1", "refresh_token": "refresh-1", "expires_in": 3600})
+			}
+		case "refresh_token":
+			if !f.valid[r.FormValue("refresh_token")] {
+				oauthErr(w, "invalid_grant")
+				return
+			}
+			f.refreshes++
+			f.access = "access-2"
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "access-2", "expires_in": 3600})
+		}
+	})
+	mux.HandleFunc("/rest/v1/vibeflow/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+f.access {
+			http.Error(w, "bad token", http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode([]Project{{ID: 1, Name: "p"}})
+	})
+	return mux
+}
+
+// testOAuth returns an oauthClient for srv that doesn't sleep between polls
+// and records the waits.
+func testOAuth(srv *httptest.Server, waits *[]time.Duration) *oauthClient {
+	o := newOAuthClient(&Config{ServerURL: srv.URL})
+	o.wait = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return o
+}
+
+func TestDeviceFlow_PollsUntilApproved(t *testing.T) {
+	fake := &fakeOAuthServer{pending: 2, slowDown: true}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+	var waits []time.Duration
+	o := testOAuth(srv, &waits)
+
+	dc, err := o.StartDeviceFlow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc.UserCode != "ABCD-EFGH" {
+		t.Errorf("UserCode = %q", dc.UserCode)
+	}
+	tok, err := o.PollDeviceToken(context.Background(), dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "access-1" || tok.RefreshToken != "refresh-1" || tok.Expiry.IsZero() {
+		t.Errorf("token = %+v", tok)
+	}
+	// slow_down, then two authorization_pending, then approval; slow_down
+	// adds five seconds to every later wait.
+	want := []time.Duration{time.Second, 6 * time.Second, 6 * time.Second, 6 * time.Second}
+	if len(waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", waits, want)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("waits = %v, want %v", waits, want)
+			break
+		}
+	}
+}
+
+func TestDeviceFlow_Denied(t *testing.T) {
+	srv := httptest.NewServer((&fakeOAuthServer{deny: true}).handler(t))
+	defer srv.Close()
+	var waits []time.Duration
+	o := testOAuth(srv, &waits)
+	_, err := o.PollDeviceToken(context.Background(), &DeviceCode{DeviceCode: "dev-1", ExpiresIn: 600})
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("err = %v, want denied", err)
+	}
+}
+
+func TestOAuthTokenSource_RefreshesExpiredToken(t *testing.T) {
+	fake := &fakeOAuthServer{valid: map[string]bool{"refresh-1": true}}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+	var waits []time.Duration
+	store := &fileSecretStore{dir: t.TempDir()}
+	expired := &OAuthToken{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Minute)}
+	if err := saveOAuthToken(store, srv.URL, expired); err != nil {
+		t.Fatal(err)
+	}
+
+	src := newOAuthTokenSource(testOAuth(srv, &waits), store)
+	token, err := src.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "access-2" {
+		t.Errorf("Token() = %q, want the refreshed access-2", token)
+	}
+	saved, err := loadOAuthToken(store, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken != "access-2" || saved.RefreshToken != "refresh-1" {
+		t.Errorf("saved = %+v, want access-2 keeping refresh-1", saved)
+	}
+	if token, _ := src.Token(); token != "access-2" || fake.refreshes != 1 {
+		t.Errorf("second Token() = %q after %d refreshes, want the cached token", token, fake.refreshes)
+	}
+}
+
+func TestOAuthTokenSource_RefreshPrefersStoredToken(t *testing.T) {
+	// Another process has already spent refresh-1 and stored its own login.
+	fake := &fakeOAuthServer{valid: map[string]bool{"refresh-3": true}}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+	var waits []time.Duration
+	store := &fileSecretStore{dir: t.TempDir()}
+	if err := saveOAuthToken(store, srv.URL, &OAuthToken{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	src := newOAuthTokenSource(testOAuth(srv, &waits), store)
+	if _, err := src.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := saveOAuthToken(store, srv.URL, &OAuthToken{AccessToken: "access-3", RefreshToken: "refresh-3", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := src.Refresh(); err != nil || token != "access-3" || fake.refreshes != 0 {
+		t.Errorf("Refresh() = %q, %v after %d refreshes, want the stored access-3", token, err, fake.refreshes)
+	}
+
+	// An expired stored token is refreshed with its own refresh token.
+	if err := saveOAuthToken(store, srv.URL, &OAuthToken{AccessToken: "access-4", RefreshToken: "refresh-3", Expiry: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := src.Refresh(); err != nil || token != "access-2" || fake.refreshes != 1 {
+		t.Errorf("Refresh() = %q, %v after %d refreshes, want access-2 from refresh-3", token, err, fake.refreshes)
+	}
+}
+
+func TestOAuthTokenSource_NotLoggedIn(t *testing.T) {
+	src := newOAuthTokenSource(newOAuthClient(&Config{ServerURL: "http://vf.invalid"}), &fileSecretStore{dir: t.TempDir()})
+	if _, err := src.Token(); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Token() err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestClient_RetriesOnceAfterRefreshOn401(t *testing.T) {
+	fake := &fakeOAuthServer{valid: map[string]bool{"refresh-1": true}, access: "access-2"}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+	var waits []time.Duration
+	store := &fileSecretStore{dir: t.TempDir()}
+	// Not expired locally, but revoked on the server.
+	saveOAuthToken(store, srv.URL, &OAuthToken{AccessToken: "access-1", RefreshToken: "refresh-1"})
+
+	c := NewClient(srv.URL, "")
+	c.tokens = newOAuthTokenSource(testOAuth(srv, &waits), store)
+	if _, err := c.ListProjects(); err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if fake.refreshes != 1 || c.AuthExpired() {
+		t.Errorf("refreshes = %d, AuthExpired = %v; want one refresh and a live login", fake.refreshes, c.AuthExpired())
+	}
+}
+
+func TestClient_ExpiredLoginIsUnauthorized(t *testing.T) {
+	fake := &fakeOAuthServer{access: "access-9"}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+	var waits []time.Duration
+	store := &fileSecretStore{dir: t.TempDir()}
+	saveOAuthToken(store, srv.URL, &OAuthToken{AccessToken: "access-1", RefreshToken: "revoked"})
+
+	c := NewClient(srv.URL, "")
+	c.tokens = newOAuthTokenSource(testOAuth(srv, &waits), store)
+	_, err := c.ListProjects()
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+	if !c.AuthExpired() {
+		t.Error("AuthExpired() = false after the refresh token was rejected")
+	}
+}
+
+func TestClient_StaticToken401(t *testing.T) {
+	srv := httptest.NewServer((&fakeOAuthServer{access: "good"}).handler(t))
+	defer srv.Close()
+	c := NewClient(srv.URL, "bad")
+	_, err := c.ListProjects()
+	if !errors.Is(err, ErrUnauthorized) || !strings.Contains(err.Error(), "bad token") {
+		t.Errorf("err = %v, want ErrUnauthorized with the response body", err)
+	}
+	if !c.AuthExpired() {
+		t.Error("AuthExpired() = false after a 401")
+	}
+}
+
+func TestOfferReauth_HintsWithoutOAuth(t *testing.T) {
+	old := flagConfigPath
+	flagConfigPath = t.TempDir() + "/config.yaml"
+	defer func() { flagConfigPath = old }()
+	t.Setenv("VIBEFLOW_TOKEN", "")
+
+	cmdErr := ErrUnauthorized
+	err := offerReauth(cmdErr, strings.NewReader(""), &strings.Builder{}, true)
+	if !errors.Is(err, ErrUnauthorized) || !strings.Contains(err.Error(), "vibeflow config") {
+		t.Errorf("err = %v, want the api_token hint", err)
+	}
+}
+
+func TestAuthBanner(t *testing.T) {
+	m := Model{keys: defaultKeymap()}
+	if got := m.authBanner(); got != "" {
+		t.Errorf("banner without a client = %q", got)
+	}
+	client := NewClient("http://vf.invalid", "tok")
+	m.client = client
+	client.authExpired.Store(true)
+	if got := m.authBanner(); !strings.Contains(got, "api_token") {
+		t.Errorf("static token banner = %q", got)
+	}
+	client.tokens = newOAuthTokenSource(newOAuthClient(&Config{}), &fileSecretStore{dir: t.TempDir()})
+	if got := m.authBanner(); !strings.Contains(got, "press L") {
+		t.Errorf("oauth banner = %q", got)
+	}
+	m.login = &DeviceCode{UserCode: "ABCD-EFGH", VerificationURI: "https://vf.example/device"}
+	if got := m.authBanner(); !strings.Contains(got, "ABCD-EFGH") {
+		t.Errorf("pending login banner = %q", got)
+	}
+}
@@ -0,0 +1,275 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultAutoDispatchInterval is used when auto_dispatch.interval_seconds
+	// is unset.
+	defaultAutoDispatchInterval = time.Minute
+
+	// autoDispatchItemCooldown keeps an item from being handed out twice
+	// while the agent that got it hasn't moved it out of "ready" yet.
+	autoDispatchItemCooldown = 30 * time.Minute
+
+	// autoDispatchSessionCooldown covers the lag between sending a prompt
+	// and the session's activity state leaving "idle".
+	autoDispatchSessionCooldown = 2 * time.Minute
+)
+
+// dispatchCandidate is an idle session that may be given work.
+type dispatchCandidate struct {
+	Name      string // tmux short name
+	Persona   string
+	ProjectID int64
+}
+
+// dispatchAssignment pairs a work item with the session it was sent to.
+type dispatchAssignment struct {
+	Session string
+	Item    WorkItem
+}
+
+// AutoDispatcher hands ready work items to idle sessions by typing a prompt
+// into them. It only remembers what it sent; the agent is expected to claim
+// the item on the server, after which it stops showing up as ready.
+type AutoDispatcher struct {
+	client       VibeflowAPI
+	logger       *Logger
+	interval     time.Duration
+	personas     map[string]bool
+	anyPersona   bool
+	includeTodos bool
+
+	mu        sync.Mutex
+	offered   map[string]time.Time // work item key → when it was sent
+	busyUntil map[string]time.Time // session → end of its cooldown
+	current   map[string]string    // session → last item sent, for the detail panel
+}
+
+// NewAutoDispatcher returns nil when auto-dispatch is disabled or there is
+// no server to poll.
+func NewAutoDispatcher(cfg AutoDispatchConfig, client VibeflowAPI, logger *Logger) *AutoDispatcher {
+	if !cfg.Enabled || client == nil {
+		return nil
+	}
+	d := &AutoDispatcher{
+		client:       client,
+		logger:       logger,
+		interval:     defaultAutoDispatchInterval,
+		personas:     make(map[string]bool),
+		includeTodos: cfg.IncludeTodos,
+		offered:      make(map[string]time.Time),
+		busyUntil:    make(map[string]time.Time),
+		current:      make(map[string]string),
+	}
+	if cfg.IntervalSeconds > 0 {
+		d.interval = time.Duration(cfg.IntervalSeconds) * time.Second
+	}
+	personas := cfg.Personas
+	if len(personas) == 0 {
+		personas = []string{"developer"}
+	}
+	for _, p := range personas {
+		if p == "*" {
+			d.anyPersona = true
+		}
+		d.personas[p] = true
+	}
+	return d
+}
+
+// Interval returns how often Run should be called.
+func (d *AutoDispatcher) Interval() time.Duration {
+	return d.interval
+}
+
+// CurrentWork returns a description of the last item sent to name.
+func (d *AutoDispatcher) CurrentWork(name string) string {
+	if d == nil {
+		return ""
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.current[name]
+}
+
+// Prune forgets sessions not in live.
+func (d *AutoDispatcher) Prune(live []string) {
+	keep := make(map[string]bool, len(live))
+	for _, n := range live {
+		keep[n] = true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for n := range d.busyUntil {
+		if !keep[n] {
+			delete(d.busyUntil, n)
+		}
+	}
+	for n := range d.current {
+		if !keep[n] {
+			delete(d.current, n)
+		}
+	}
+}
+
+// Run polls pending work for the candidates' projects, assigns it and sends
+// each assignment's prompt through send. It returns what was delivered.
+func (d *AutoDispatcher) Run(candidates []dispatchCandidate, send func(name, prompt string) error, now time.Time) []dispatchAssignment {
+	candidates = d.eligible(candidates, now)
+	if len(candidates) == 0 {
+		return nil
+	}
+	work := make(map[int64]*PollResult)
+	for _, c := range candidates {
+		if _, ok := work[c.ProjectID]; ok {
+			continue
+		}
+		result, err := d.client.PollPendingWork(c.ProjectID)
+		if err != nil {
+			d.logger.Warn("auto-dispatch: project %d: %v", c.ProjectID, err)
+		}
+		work[c.ProjectID] = result // nil on error: nothing to hand out
+	}
+
+	var sent []dispatchAssignment
+	for _, a := range d.plan(candidates, work, now) {
+		if err := send(a.Session, dispatchPrompt(a.Item)); err != nil {
+			d.logger.Warn("auto-dispatch: send %s #%d to %s: %v", a.Item.Type, a.Item.ID, a.Session, err)
+			continue
+		}
+		d.logger.Info("auto-dispatch: sent %s #%d to %s", a.Item.Type, a.Item.ID, a.Session)
+		d.Record(a, now)
+		sent = append(sent, a)
+	}
+	return sent
+}
+
+// Record notes that a's item was sent to its session, by Run or by hand,
+// so neither is offered work again until their cooldowns pass.
+func (d *AutoDispatcher) Record(a dispatchAssignment, now time.Time) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.offered[workItemKey(a.Item)] = now
+	d.busyUntil[a.Session] = now.Add(autoDispatchSessionCooldown)
+	d.current[a.Session] = fmt.Sprintf("%s #%d: %s", a.Item.Type, a.Item.ID, a.Item.Title)
+}
+
+// eligible keeps candidates with a project, an accepted persona and no
+// recent dispatch, sorted by name so assignment is deterministic.
+func (d *AutoDispatcher) eligible(candidates []dispatchCandidate, now time.Time) []dispatchCandidate {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var out []dispatchCandidate
+	for _, c := range candidates {
+		if c.ProjectID <= 0 || !(d.anyPersona || d.personas[c.Persona]) {
+			continue
+		}
+		if now.Before(d.busyUntil[c.Name]) {
+			continue
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// plan assigns at most one item per session, highest priority first, and
+// skips items handed out within autoDispatchItemCooldown.
+func (d *AutoDispatcher) plan(candidates []dispatchCandidate, work map[int64]*PollResult, now time.Time) []dispatchAssignment {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, at := range d.offered {
+		if now.Sub(at) >= autoDispatchItemCooldown {
+			delete(d.offered, key)
+		}
+	}
+	queues := make(map[int64][]WorkItem)
+	for projectID, r := range work {
+		if r == nil {
+			continue
+		}
+		items := append([]WorkItem{}, r.ReadyIssues...)
+		if d.includeTodos {
+			items = append(items, r.ReadyTodos...)
+		}
+		var ready []WorkItem
+		for _, it := range items {
+			if _, ok := d.offered[workItemKey(it)]; ok {
+				continue
+			}
+			ready = append(ready, it)
+		}
+		sort.SliceStable(ready, func(i, j int) bool {
+			pi, pj := priorityRank(ready[i].Priority), priorityRank(ready[j].Priority)
+			if pi != pj {
+				return pi < pj
+			}
+			return ready[i].ID < ready[j].ID
+		})
+		queues[projectID] = ready
+	}
+
+	var out []dispatchAssignment
+	for _, c := range candidates {
+		q := queues[c.ProjectID]
+		if len(q) == 0 {
+			continue
+		}
+		out = append(out, dispatchAssignment{Session: c.Name, Item: q[0]})
+		queues[c.ProjectID] = q[1:]
+	}
+	return out
+}
+
+func workItemKey(it WorkItem) string {
+	return fmt.Sprintf("%s:%d", it.Type, it.ID)
+}
+
+// priorityRank orders work item priorities; unknown values sort with medium.
+func priorityRank(p string) int {
+	switch strings.ToLower(p) {
+	case "critical", "urgent":
+		return 0
+	case "high":
+		return 1
+	case "low":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// dispatchPrompt is the line typed into a session to hand it a work item.
+func dispatchPrompt(it WorkItem) string {
+	kind := it.Type
+	if kind == "" {
+		kind = "issue"
+	}
+	return fmt.Sprintf("Pick up %s #%d: %s. Mark it in progress in VibeFlow before you start.", kind, it.ID, it.Title)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAutoDispatcher_Defaults(t *testing.T) {
+	if NewAutoDispatcher(AutoDispatchConfig{}, NewClient("http://x", ""), &Logger{}) != nil {
+		t.Error("disabled config should yield nil dispatcher")
+	}
+	d := NewAutoDispatcher(AutoDispatchConfig{Enabled: true}, NewClient("http://x", ""), &Logger{})
+	if d.Interval() != defaultAutoDispatchInterval {
+		t.Errorf("interval = %v", d.Interval())
+	}
+	got := d.eligible([]dispatchCandidate{
+		{Name: "b", Persona: "developer", ProjectID: 1},
+		{Name: "a", Persona: "qa_lead", ProjectID: 1},
+		{Name: "c", Persona: "developer"}, // no project
+	}, time.Now())
+	if len(got) != 1 || got[0].Name != "b" {
+		t.Errorf("eligible = %+v, want only the developer session with a project", got)
+	}
+
+	wildcard := NewAutoDispatcher(AutoDispatchConfig{Enabled: true, Personas: []string{"*"}}, NewClient("http://x", ""), &Logger{})
+	if got := wildcard.eligible([]dispatchCandidate{{Name: "v", ProjectID: 1}}, time.Now()); len(got) != 1 {
+		t.Error(`"*" should accept sessions without a persona`)
+	}
+	var nilD *AutoDispatcher
+	if nilD.CurrentWork("x") != "" {
+		t.Error("nil dispatcher CurrentWork should be empty")
+	}
+}
+
+func TestAutoDispatcher_PlanOrdersByPriority(t *testing.T) {
+	d := NewAutoDispatcher(AutoDispatchConfig{Enabled: true, IncludeTodos: true}, NewClient("http://x", ""), &Logger{})
+	work := map[int64]*PollResult{1: {
+		ReadyIssues: []WorkItem{{Type: "issue", ID: 5, Priority: "low"}, {Type: "issue", ID: 7, Priority: "high"}},
+		ReadyTodos:  []WorkItem{{Type: "todo", ID: 3}},
+	}}
+	cands := []dispatchCandidate{{Name: "a", ProjectID: 1}, {Name: "b", ProjectID: 1}, {Name: "c", ProjectID: 1}, {Name: "d", ProjectID: 1}}
+	got := d.plan(cands, work, time.Now())
+	var ids []string
+	for _, a := range got {
+		ids = append(ids, fmt.Sprintf("%s=%d", a.Session, a.Item.ID))
+	}
+	if strings.Join(ids, " ") != "a=7 b=3 c=5" {
+		t.Errorf("plan = %v, want a=7 b=3 c=5", ids)
+	}
+}
+
+func TestAutoDispatcher_RunSendsOnceAndCoolsDown(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/vibeflow/projects/4/poll" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(PollResult{
+			ReadyIssues: []WorkItem{{Type: "issue", ID: 12, Title: "Fix login"}},
+			ReadyTodos:  []WorkItem{{Type: "todo", ID: 13, Title: "Ignored without include_todos"}},
+		})
+	}))
+	defer srv.Close()
+
+	d := NewAutoDispatcher(AutoDispatchConfig{Enabled: true}, NewClient(srv.URL, ""), &Logger{})
+	sent := map[string]string{}
+	send := func(name, prompt string) error {
+		sent[name] = prompt
+		return nil
+	}
+	cands := []dispatchCandidate{{Name: "claude-a", Persona: "developer", ProjectID: 4}, {Name: "claude-b", Persona: "developer", ProjectID: 4}}
+	now := time.Now()
+	got := d.Run(cands, send, now)
+	if len(got) != 1 || got[0].Session != "claude-a" || got[0].Item.ID != 12 {
+		t.Fatalf("Run = %+v, want issue 12 to claude-a", got)
+	}
+	if !strings.Contains(sent["claude-a"], "issue #12: Fix login") {
+		t.Errorf("prompt = %q", sent["claude-a"])
+	}
+	if d.CurrentWork("claude-a") != "issue #12: Fix login" {
+		t.Errorf("CurrentWork = %q", d.CurrentWork("claude-a"))
+	}
+
+	// The server still lists #12 as ready; it must not go to claude-b.
+	if got := d.Run(cands, send, now.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("second run = %+v, want nothing while #12 is cooling down", got)
+	}
+	// After the item cooldown it is offered again, to the first free session.
+	got = d.Run(cands, send, now.Add(autoDispatchItemCooldown+time.Second))
+	if len(got) != 1 || got[0].Session != "claude-a" {
+		t.Errorf("after cooldown = %+v, want re-offer to claude-a", got)
+	}
+
+	d.Prune([]string{"claude-b"})
+	if d.CurrentWork("claude-a") != "" {
+		t.Error("Prune should forget dead sessions")
+	}
+}
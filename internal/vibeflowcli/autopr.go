@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// prTool picks the CLI used to open the PR: the configured one, else glab
+// when origin is hosted on GitLab and gh otherwise.
+func prTool(dir, configured string) (string, error) {
+	switch configured {
+	case "gh", "glab":
+		return configured, nil
+	case "":
+	default:
+		return "", fmt.Errorf("auto_pr.tool must be gh or glab, got %q", configured)
+	}
+	origin, err := gitIn(dir, "remote", "get-url", "origin")
+	if err != nil {
+		return "", fmt.Errorf("no origin remote to open a pull request against")
+	}
+	if strings.Contains(remoteHost(origin), "gitlab") {
+		return "glab", nil
+	}
+	return "gh", nil
+}
+
+// remoteHost returns the host of a git remote URL, in either URL or scp
+// ("git@host:owner/repo.git") form.
+func remoteHost(remote string) string {
+	if u, err := url.Parse(remote); err == nil && u.Host != "" {
+		return strings.ToLower(u.Hostname())
+	}
+	host, _, _ := strings.Cut(remote, ":")
+	if _, h, ok := strings.Cut(host, "@"); ok {
+		host = h
+	}
+	return strings.ToLower(host)
+}
+
+// prCreateArgs builds the gh or glab command line that opens a PR for
+// branch, filling the title and body from its commits.
+func prCreateArgs(tool, branch string, draft bool) []string {
+	if tool == "glab" {
+		args := []string{"mr", "create", "--fill", "--yes", "--source-branch", branch}
+		if draft {
+			args = append(args, "--draft")
+		}
+		return args
+	}
+	args := []string{"pr", "create", "--fill", "--head", branch}
+	if draft {
+		args = append(args, "--draft")
+	}
+	return args
+}
+
+var prURLPattern = regexp.MustCompile(`https?://\S+`)
+
+// OpenPullRequest commits any uncommitted work in the session's checkout,
+// pushes its branch to origin and opens a pull request for it. It returns
+// the PR's URL; a PR that already exists for the branch is returned as is.
+func OpenPullRequest(meta SessionMeta, cfg AutoPRConfig) (string, error) {
+	dir := meta.WorktreePath
+	if dir == "" {
+		dir = meta.WorkingDir
+	}
+	if dir == "" {
+		return "", fmt.Errorf("session %s has no working directory", meta.Name)
+	}
+	branch, err := gitIn(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", fmt.Errorf("%s is on a detached HEAD; check out a branch first", dir)
+	}
+	if branch == getDefaultBranch(dir) {
+		return "", fmt.Errorf("%s is on the default branch %s; launch the session with a worktree branch to open PRs", dir, branch)
+	}
+	tool, err := prTool(dir, cfg.Tool)
+	if err != nil {
+		return "", err
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return "", fmt.Errorf("%s not found on PATH", tool)
+	}
+
+	if isDirtyGit(dir) {
+		msg := cfg.CommitMessage
+		if msg == "" {
+			msg = "Work from vibeflow session " + meta.Name
+		}
+		if _, err := gitIn(dir, "add", "-A"); err != nil {
+			return "", err
+		}
+		if _, err := gitIn(dir, "commit", "-q", "-m", msg); err != nil {
+			return "", err
+		}
+	}
+	if _, err := gitIn(dir, "push", "-q", "-u", "origin", branch); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(tool, prCreateArgs(tool, branch, cfg.Draft)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	text := strings.TrimSpace(string(out))
+	if err != nil {
+		// gh and glab both name the existing PR when there already is one.
+		if strings.Contains(text, "already exists") {
+			if u := prURLPattern.FindString(text); u != "" {
+				return u, nil
+			}
+		}
+		if text == "" {
+			text = err.Error()
+		}
+		return "", fmt.Errorf("%s: %s", tool, text)
+	}
+	matches := prURLPattern.FindAllString(text, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%s did not print a pull request URL: %s", tool, text)
+	}
+	return matches[len(matches)-1], nil
+}
+
+func prCmd() *cobra.Command {
+	var draft bool
+	cmd := &cobra.Command{
+		Use:   "pr <session-name>",
+		Short: "Commit, push and open a pull request for a session's branch",
+		Long: `Commit any uncommitted work in the session's worktree, push its branch to
+origin and open a pull request with gh (or a merge request with glab for
+GitLab remotes). The TUI does this on its own when auto_pr is enabled and the
+agent signals completion; this command runs the same flow by hand.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			// Completion offers the tmux name; launched sessions are
+			// stored under their base name.
+			meta, found, err := store.GetByTmux(tmux.ensurePrefix(args[0]))
+			if err == nil && !found {
+				meta, found, err = store.Get(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("session %q not found", args[0])
+			}
+			prCfg := cfg.AutoPR
+			if draft {
+				prCfg.Draft = true
+			}
+			url, err := OpenPullRequest(meta, prCfg)
+			if err != nil {
+				return err
+			}
+			if err := store.SetPRURL(meta.Name, url); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), url)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&draft, "draft", false, "Open the pull request as a draft")
+	return cmd
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRemoteHost(t *testing.T) {
+	for remote, want := range map[string]string{
+		"https://gitlab.example.com/team/app.git": "gitlab.example.com",
+		"git@github.com:team/app.git":             "github.com",
+		"ssh://git@GitLab.com:22/team/app.git":    "gitlab.com",
+	} {
+		if got := remoteHost(remote); got != want {
+			t.Errorf("remoteHost(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
+
+func TestPRCreateArgs(t *testing.T) {
+	got := strings.Join(prCreateArgs("gh", "feat/x", true), " ")
+	if got != "pr create --fill --head feat/x --draft" {
+		t.Errorf("gh args = %q", got)
+	}
+	got = strings.Join(prCreateArgs("glab", "feat/x", false), " ")
+	if got != "mr create --fill --yes --source-branch feat/x" {
+		t.Errorf("glab args = %q", got)
+	}
+}
+
+func TestMarkReadyForReview(t *testing.T) {
+	rows := []SessionRow{
+		{Name: "a", Status: "idle", PRURL: "https://x/pull/1"},
+		{Name: "b", Status: "working", PRURL: "https://x/pull/2"},
+		{Name: "c", Status: "idle"},
+		{Name: "d", Status: "exited", PRURL: "https://x/pull/3"},
+	}
+	markReadyForReview(rows)
+	want := []string{"review", "working", "idle", "exited"}
+	for i, r := range rows {
+		if r.Status != want[i] {
+			t.Errorf("%s status = %q, want %q", r.Name, r.Status, want[i])
+		}
+	}
+}
+
+// fakeGH puts a gh script on PATH that records its arguments and prints a
+// PR URL, as gh pr create does.
+func fakeGH(t *testing.T) (argsFile string) {
+	t.Helper()
+	bin := t.TempDir()
+	argsFile = filepath.Join(bin, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\necho 'Creating pull request'\necho https://github.com/team/app/pull/7\n"
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+// initPRRepo creates a repo on branch feat/login with a bare origin to push
+// to.
+func initPRRepo(t *testing.T) (repo, origin string) {
+	t.Helper()
+	repo = initTestRepo(t)
+	origin = t.TempDir()
+	for _, args := range [][]string{
+		{"git", "init", "-q", "--bare", origin},
+		{"git", "-C", repo, "remote", "add", "origin", origin},
+		{"git", "-C", repo, "checkout", "-q", "-b", "feat/login"},
+	} {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			t.Fatalf("%v: %s", args, out)
+		}
+	}
+	return repo, origin
+}
+
+func TestOpenPullRequest(t *testing.T) {
+	repo, origin := initPRRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, "login.go"), []byte("package app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	argsFile := fakeGH(t)
+
+	meta := SessionMeta{Name: "login", WorkingDir: repo}
+	url, err := OpenPullRequest(meta, AutoPRConfig{Tool: "gh", Draft: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "https://github.com/team/app/pull/7" {
+		t.Errorf("url = %q", url)
+	}
+	if isDirtyGit(repo) {
+		t.Error("uncommitted work was not committed")
+	}
+	if subject, _ := gitIn(repo, "log", "-1", "--format=%s"); subject != "Work from vibeflow session login" {
+		t.Errorf("commit subject = %q", subject)
+	}
+	if _, err := gitIn(origin, "rev-parse", "--verify", "refs/heads/feat/login"); err != nil {
+		t.Errorf("branch not pushed: %v", err)
+	}
+	args, _ := os.ReadFile(argsFile)
+	if got := strings.TrimSpace(string(args)); got != "pr create --fill --head feat/login --draft" {
+		t.Errorf("gh args = %q", got)
+	}
+}
+
+func TestPRCmd_TmuxName(t *testing.T) {
+	withTempRoot(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := SaveConfig(DefaultConfig(), cfgPath); err != nil {
+		t.Fatal(err)
+	}
+	repo, _ := initPRRepo(t)
+	fakeGH(t)
+	store := NewStore()
+	tmuxName := (&TmuxManager{}).FullSessionName("claude", "login")
+	if err := store.Add(SessionMeta{Name: "login", TmuxSession: tmuxName, Provider: "claude", WorkingDir: repo, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &cobra.Command{Use: "vibeflow-cli"}
+	root.PersistentFlags().String("config", "", "")
+	root.AddCommand(prCmd())
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	// The short tmux name, as completion offers it.
+	root.SetArgs([]string{"pr", "claude-login", "--config", cfgPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("pr: %v\n%s", err, out.String())
+	}
+	if got := strings.TrimSpace(out.String()); got != "https://github.com/team/app/pull/7" {
+		t.Errorf("pr printed %q", got)
+	}
+	if meta, _, _ := store.Get("login"); meta.PRURL != "https://github.com/team/app/pull/7" {
+		t.Errorf("stored PRURL = %q", meta.PRURL)
+	}
+}
+
+func TestOpenPullRequest_RefusesDefaultBranch(t *testing.T) {
+	repo := initTestRepo(t)
+	branch, _ := gitIn(repo, "rev-parse", "--abbrev-ref", "HEAD")
+	if branch != "main" {
+		// getDefaultBranch falls back to "main" without an origin/HEAD.
+		if _, err := gitIn(repo, "branch", "-m", "main"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := OpenPullRequest(SessionMeta{Name: "x", WorkingDir: repo}, AutoPRConfig{Tool: "gh"})
+	if err == nil || !strings.Contains(err.Error(), "default branch") {
+		t.Errorf("err = %v, want default branch refusal", err)
+	}
+}
+
+func TestStore_SetPRURL(t *testing.T) {
+	withTempRoot(t)
+	store := NewStore()
+	if err := store.Add(SessionMeta{Name: "web", TmuxSession: "vibeflow_claude-web", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetPRURL("web", "https://x/pull/1"); err != nil {
+		t.Fatal(err)
+	}
+	meta, _, _ := store.Get("web")
+	if meta.PRURL != "https://x/pull/1" {
+		t.Errorf("PRURL = %q", meta.PRURL)
+	}
+	if err := store.SetPRURL("nope", "u"); err == nil {
+		t.Error("SetPRURL on a missing session succeeded")
+	}
+}
+
+// TestAutoPR_ProviderPrefixedRow checks a row named "claude-web" finds and
+// updates the session stored as "web".
+func TestAutoPR_ProviderPrefixedRow(t *testing.T) {
+	withTempRoot(t)
+	store := NewStore()
+	if err := store.Add(SessionMeta{Name: "web", TmuxSession: "vibeflow_claude-web", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		store:     store,
+		logger:    &Logger{},
+		config:    &Config{AutoPR: AutoPRConfig{Enabled: true}},
+		prStarted: make(map[string]bool),
+		sessions:  []SessionRow{{Name: "claude-web", Status: "idle"}},
+	}
+	m, cmd := m.startAutoPR([]string{"claude-web"})
+	if cmd == nil || !m.prStarted["claude-web"] {
+		t.Fatal("no pull request started for the row")
+	}
+	m, _ = m.handlePROpened(prOpenedMsg{name: "claude-web", session: "web", url: "https://x/pull/2"})
+	if m.sessions[0].PRURL != "https://x/pull/2" || m.sessions[0].Status != "review" {
+		t.Errorf("row = %+v", m.sessions[0])
+	}
+	if meta, _, _ := store.Get("web"); meta.PRURL != "https://x/pull/2" {
+		t.Errorf("stored PRURL = %q", meta.PRURL)
+	}
+}
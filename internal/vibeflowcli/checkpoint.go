@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checkpointRefPrefix is where recovery checkpoints are kept, one ref per
+// checkpoint under the session's name. Refs are shared by every worktree
+// of a repository, so a checkpoint outlives the worktree it was taken in.
+const checkpointRefPrefix = "refs/vibeflow/checkpoints/"
+
+// checkpointRefTime names a checkpoint ref; it sorts by time.
+const checkpointRefTime = "20060102-150405"
+
+// Checkpoint is a snapshot of a session's worktree taken before a recovery
+// attempt.
+type Checkpoint struct {
+	Ref     string    // full ref name
+	Commit  string    // abbreviated commit id
+	Created time.Time // when it was taken
+	Reason  string    // the error that triggered the recovery
+}
+
+// checkpointRefs returns the ref namespace of session's checkpoints.
+func checkpointRefs(session string) string {
+	return checkpointRefPrefix + session + "/"
+}
+
+// createCheckpoint snapshots the working tree at dir, tracked and untracked
+// files alike (ignored ones are left out), into a commit on top of HEAD and
+// keeps it under session's checkpoint refs. The checkout, its index and the
+// stash are not touched. It returns "" and no error when nothing changed
+// since HEAD, as HEAD already holds all the work. Only the newest keep
+// checkpoints of the session are kept.
+func createCheckpoint(dir, session, reason string, at time.Time, keep int) (string, error) {
+	head, err := gitIn(dir, "rev-parse", "--verify", "-q", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("no commit to checkpoint on: %w", err)
+	}
+	tmp, err := os.MkdirTemp("", "vibeflow-checkpoint-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	index := filepath.Join(tmp, "index")
+	// Starting from a copy of the real index keeps its stat cache, so only
+	// changed files are hashed again.
+	if real, err := gitIn(dir, "rev-parse", "--path-format=absolute", "--git-path", "index"); err == nil {
+		if data, err := os.ReadFile(real); err == nil {
+			_ = os.WriteFile(index, data, 0o600)
+		}
+	}
+	if _, err := os.Stat(index); err != nil {
+		if _, err := gitWithIndex(dir, index, "read-tree", "HEAD"); err != nil {
+			return "", err
+		}
+	}
+	if _, err := gitWithIndex(dir, index, "add", "-A"); err != nil {
+		return "", err
+	}
+	tree, err := gitWithIndex(dir, index, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	if headTree, err := gitIn(dir, "rev-parse", "HEAD^{tree}"); err == nil && headTree == tree {
+		return "", nil
+	}
+	commit, err := gitIn(dir, "-c", "user.name=vibeflow", "-c", "user.email=vibeflow@localhost",
+		"commit-tree", tree, "-p", head, "-m", "vibeflow checkpoint: "+reason)
+	if err != nil {
+		return "", err
+	}
+	ref := checkpointRefs(session) + at.Format(checkpointRefTime)
+	if _, err := gitIn(dir, "update-ref", ref, commit); err != nil {
+		return "", err
+	}
+	if old, err := listCheckpoints(dir, session); err == nil && keep > 0 && len(old) > keep {
+		for _, c := range old[keep:] {
+			_, _ = gitIn(dir, "update-ref", "-d", c.Ref)
+		}
+	}
+	return ref, nil
+}
+
+// gitWithIndex runs git in dir against the index file at index instead of
+// the checkout's own.
+func gitWithIndex(dir, index string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+index)
+	out, err := cmd.CombinedOutput()
+	s := strings.TrimSpace(string(out))
+	if err != nil {
+		if s == "" {
+			s = err.Error()
+		}
+		return s, fmt.Errorf("git %s: %s", args[0], s)
+	}
+	return s, nil
+}
+
+// listCheckpoints returns session's checkpoints in the repository at dir,
+// newest first.
+func listCheckpoints(dir, session string) ([]Checkpoint, error) {
+	out, err := gitIn(dir, "for-each-ref", "--sort=-refname",
+		"--format=%(refname)%00%(objectname:short)%00%(committerdate:unix)%00%(subject)", checkpointRefs(session))
+	if err != nil || out == "" {
+		return nil, err
+	}
+	var cps []Checkpoint
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, "\x00", 4)
+		if len(f) != 4 {
+			continue
+		}
+		sec, _ := strconv.ParseInt(f[2], 10, 64)
+		cps = append(cps, Checkpoint{
+			Ref:     f[0],
+			Commit:  f[1],
+			Created: time.Unix(sec, 0),
+			Reason:  strings.TrimPrefix(f[3], "vibeflow checkpoint: "),
+		})
+	}
+	return cps, nil
+}
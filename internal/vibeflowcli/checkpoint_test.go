@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCreateCheckpoint(t *testing.T) {
+	repo := initTestRepo(t)
+	commitFile(t, repo, "a.txt", "a")
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	ref, err := createCheckpoint(repo, "api", "Claude API 5xx", at, 10)
+	if err != nil || ref != "" {
+		t.Fatalf("clean tree: ref = %q, err = %v; want no checkpoint", ref, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("untracked"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gitIn(repo, "add", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	ref, err = createCheckpoint(repo, "api", "Claude API 5xx", at, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != "refs/vibeflow/checkpoints/api/20260501-120000" {
+		t.Errorf("ref = %q", ref)
+	}
+	for file, want := range map[string]string{"a.txt": "edited", "new.txt": "untracked"} {
+		if got, err := gitIn(repo, "show", ref+":"+file); err != nil || got != want {
+			t.Errorf("%s in checkpoint = %q, %v; want %q", file, got, err, want)
+		}
+	}
+	if parent, _ := gitIn(repo, "rev-parse", ref+"^"); parent != mustGit(t, repo, "rev-parse", "HEAD") {
+		t.Error("the checkpoint should sit on top of HEAD")
+	}
+	if status := mustGit(t, repo, "status", "--porcelain"); status != "M  a.txt\n?? new.txt" {
+		t.Errorf("checkout changed by the checkpoint, status:\n%s", status)
+	}
+
+	cps, err := listCheckpoints(repo, "api")
+	if err != nil || len(cps) != 1 {
+		t.Fatalf("checkpoints = %+v, %v", cps, err)
+	}
+	if cps[0].Ref != ref || cps[0].Reason != "Claude API 5xx" || cps[0].Commit == "" {
+		t.Errorf("checkpoint = %+v", cps[0])
+	}
+}
+
+func TestCreateCheckpoint_KeepsNewest(t *testing.T) {
+	repo := initTestRepo(t)
+	commitFile(t, repo, "a.txt", "a")
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte{byte('b' + i)}, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := createCheckpoint(repo, "api", "err", at.Add(time.Duration(i)*time.Minute), 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cps, _ := listCheckpoints(repo, "api")
+	if len(cps) != 2 || cps[0].Ref != "refs/vibeflow/checkpoints/api/20260501-120300" || cps[1].Ref != "refs/vibeflow/checkpoints/api/20260501-120200" {
+		t.Errorf("checkpoints = %+v, want the newest two", cps)
+	}
+	if other, _ := listCheckpoints(repo, "web"); len(other) != 0 {
+		t.Errorf("another session's checkpoints = %+v", other)
+	}
+}
+
+func TestHealthMonitor_CheckpointsBeforeRecovery(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook test uses sh")
+	}
+	repo := initTestRepo(t)
+	commitFile(t, repo, "a.txt", "a")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("work in progress"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := testStore(t)
+	if err := store.Add(SessionMeta{Name: "api", TmuxSession: "vibeflow_api", WorkingDir: repo}); err != nil {
+		t.Fatal(err)
+	}
+	hm := testHealthMonitor(t)
+	hm.registry.AddPattern(ErrorPattern{
+		Provider: "inhouse", Regex: regexp.MustCompile(`wedged`),
+		Action: ActionHook, Description: "agent wedged", Hook: "true",
+	})
+	if err := hm.Restore(store); err != nil {
+		t.Fatal(err)
+	}
+	hm.CheckOutput("vibeflow_api", "inhouse", "wedged", false)
+	if err := hm.AttemptRecovery("vibeflow_api"); err != nil {
+		t.Fatal(err)
+	}
+	if cps, _ := listCheckpoints(repo, "api"); len(cps) != 0 {
+		t.Fatalf("checkpoint taken with checkpoint off: %+v", cps)
+	}
+
+	hm.config.Checkpoint = true
+	if err := hm.AttemptRecovery("vibeflow_api"); err != nil {
+		t.Fatal(err)
+	}
+	cps, _ := listCheckpoints(repo, "api")
+	if len(cps) != 1 || cps[0].Reason != "agent wedged" {
+		t.Fatalf("checkpoints = %+v, want one for the attempt", cps)
+	}
+	if got := mustGit(t, repo, "show", cps[0].Ref+":a.txt"); got != "work in progress" {
+		t.Errorf("checkpointed a.txt = %q", got)
+	}
+}
+
+func mustGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := gitIn(dir, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
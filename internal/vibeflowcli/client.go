@@ -19,9 +19,12 @@ package vibeflowcli
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,9 +32,35 @@ import (
 type Client struct {
 	baseURL    string
 	token      string
+	tokens     tokenSource // OAuth login; token is unused when set
 	httpClient *http.Client
+	// authExpired is set while the server rejects the credentials, so the
+	// TUI can prompt for a new login.
+	authExpired atomic.Bool
 }
 
+// VibeflowAPI is the VibeFlow server API used by the TUI, the wizard and
+// the background publishers. Client is the HTTP implementation; tests and
+// alternative backends (such as an offline stub) implement it directly.
+type VibeflowAPI interface {
+	ListProjects() ([]Project, error)
+	CreateProject(name string) (*Project, error)
+	ListSessions(projectID int64) ([]Session, error)
+	PollPendingWork(projectID int64) (*PollResult, error)
+	SessionInit(req SessionInitRequest) (*SessionInitResult, error)
+	SessionRegister(req SessionRegisterRequest) error
+	SessionHeartbeat(sessionID, status string) error
+	SessionArchive(sessionID string, req SessionArchiveRequest) error
+	GetIssue(projectID, issueID int64) (*Issue, error)
+	GetSessionStatus(sessionID string) (*SessionStatus, error)
+	DispatchNext(req DispatchNextRequest) (*DispatchNextResponse, error)
+	DispatchAck(id int64, leaseOwner string) error
+	DispatchNack(id int64, leaseOwner, reason string) error
+	AuthExpired() bool
+}
+
+var _ VibeflowAPI = (*Client)(nil)
+
 // NewClient creates a new VibeFlow API client.
 func NewClient(baseURL, token string) *Client {
 	return &Client{
@@ -198,6 +227,100 @@ func (c *Client) SessionRegister(req SessionRegisterRequest) error {
 	return nil
 }
 
+// SessionHeartbeat reports that a session is alive along with its current
+// activity status ("working", "idle", "waiting", ...).
+func (c *Client) SessionHeartbeat(sessionID, status string) error {
+	var discard json.RawMessage
+	body := map[string]string{"status": status}
+	if err := c.post(fmt.Sprintf("/rest/v1/vibeflow/sessions/%s/heartbeat", url.PathEscape(sessionID)), body, &discard); err != nil {
+		return fmt.Errorf("session heartbeat: %w", err)
+	}
+	return nil
+}
+
+// SessionArchiveRequest summarizes an ended local session for the
+// project timeline on the server.
+type SessionArchiveRequest struct {
+	ProjectID        int64                    `json:"project_id"`
+	SessionName      string                   `json:"session_name"`
+	Provider         string                   `json:"provider,omitempty"`
+	Persona          string                   `json:"persona,omitempty"`
+	GitBranch        string                   `json:"git_branch,omitempty"`
+	WorkingDirectory string                   `json:"working_directory,omitempty"`
+	StartedAt        time.Time                `json:"started_at"`
+	EndedAt          time.Time                `json:"ended_at"`
+	DurationSeconds  int64                    `json:"duration_seconds"`
+	ExitReason       string                   `json:"exit_reason,omitempty"`
+	CommitSHAs       []string                 `json:"commit_shas,omitempty"`
+	OutputTail       string                   `json:"output_tail,omitempty"`
+	RecoveryAttempts int                      `json:"recovery_attempts,omitempty"`
+	Recoveries       []SessionArchiveRecovery `json:"recoveries,omitempty"`
+}
+
+// SessionArchiveRecovery is one recovery attempt in a SessionArchiveRequest.
+type SessionArchiveRecovery struct {
+	At    time.Time `json:"at"`
+	Error string    `json:"error,omitempty"`
+}
+
+// SessionArchive uploads the summary of an ended session.
+func (c *Client) SessionArchive(sessionID string, req SessionArchiveRequest) error {
+	var discard json.RawMessage
+	if err := c.post(fmt.Sprintf("/rest/v1/vibeflow/sessions/%s/archive", url.PathEscape(sessionID)), req, &discard); err != nil {
+		return fmt.Errorf("session archive: %w", err)
+	}
+	return nil
+}
+
+// Issue is a VibeFlow issue with its full description.
+type Issue struct {
+	ID          int64  `json:"id"`
+	ProjectID   int64  `json:"project_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Priority    string `json:"priority"`
+}
+
+// GetIssue returns one issue of a project.
+func (c *Client) GetIssue(projectID, issueID int64) (*Issue, error) {
+	var issue Issue
+	if err := c.get(fmt.Sprintf("/rest/v1/vibeflow/projects/%d/issues/%d", projectID, issueID), &issue); err != nil {
+		return nil, fmt.Errorf("get issue #%d: %w", issueID, err)
+	}
+	return &issue, nil
+}
+
+// SessionStatus is the server's live view of what a session is doing.
+type SessionStatus struct {
+	SessionID     string    `json:"session_id"`
+	Status        string    `json:"status"`
+	Phase         string    `json:"phase"`
+	CurrentWork   *WorkItem `json:"current_work,omitempty"`
+	LastMessage   string    `json:"last_message"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// WorkLabel describes the current work item the way the auto-dispatcher
+// does ("issue #12: Fix login"), or "" when the session has none.
+func (s SessionStatus) WorkLabel() string {
+	if s.CurrentWork == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s #%d: %s", s.CurrentWork.Type, s.CurrentWork.ID, s.CurrentWork.Title)
+}
+
+// GetSessionStatus returns the current work item, phase and last message
+// the server has for a session.
+func (c *Client) GetSessionStatus(sessionID string) (*SessionStatus, error) {
+	var status SessionStatus
+	if err := c.get(fmt.Sprintf("/rest/v1/vibeflow/sessions/%s/status", url.PathEscape(sessionID)), &status); err != nil {
+		return nil, fmt.Errorf("session status: %w", err)
+	}
+	return &status, nil
+}
+
 func (c *Client) DispatchNext(req DispatchNextRequest) (*DispatchNextResponse, error) {
 	var result DispatchNextResponse
 	if err := c.post("/rest/v1/vibeflow/dispatch/next", req, &result); err != nil {
@@ -223,28 +346,39 @@ func (c *Client) DispatchNack(id int64, leaseOwner, reason string) error {
 	return nil
 }
 
-func (c *Client) get(path string, result interface{}) error {
-	req, err := http.NewRequest("GET", c.baseURL+path, nil)
-	if err != nil {
-		return err
-	}
+// AuthExpired reports whether the last request failed because the server
+// rejected the credentials or the OAuth login could not be refreshed.
+func (c *Client) AuthExpired() bool {
+	return c.authExpired.Load()
+}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	}
+// oauthSource returns the client's OAuth login, nil with a static token.
+func (c *Client) oauthSource() *oauthTokenSource {
+	s, _ := c.tokens.(*oauthTokenSource)
+	return s
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
+// bearer returns the token to send, forcing an OAuth refresh when refresh
+// is set. An empty token sends no Authorization header.
+func (c *Client) bearer(refresh bool) (string, error) {
+	if c.tokens == nil {
+		return c.token, nil
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	var token string
+	var err error
+	if refresh {
+		token, err = c.tokens.Refresh()
+	} else {
+		token, err = c.tokens.Token()
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		c.authExpired.Store(true)
 	}
+	return token, err
+}
 
-	return json.NewDecoder(resp.Body).Decode(result)
+func (c *Client) get(path string, result interface{}) error {
+	return c.do("GET", path, nil, result)
 }
 
 func (c *Client) post(path string, body interface{}, result interface{}) error {
@@ -252,30 +386,62 @@ func (c *Client) post(path string, body interface{}, result interface{}) error {
 	if err != nil {
 		return err
 	}
+	return c.do("POST", path, data, result)
+}
 
-	req, err := http.NewRequest("POST", c.baseURL+path, bytes.NewReader(data))
+// do sends the request and decodes the response into result, if non-nil.
+// A 401 with an OAuth login is retried once with a refreshed token; one
+// that persists is ErrUnauthorized.
+func (c *Client) do(method, path string, body []byte, result interface{}) error {
+	resp, err := c.send(method, path, body, false)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
-
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
+	if resp.StatusCode == http.StatusUnauthorized && c.tokens != nil {
+		resp.Body.Close()
+		if resp, err = c.send(method, path, body, true); err != nil {
+			return err
+		}
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.authExpired.Store(true)
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w: HTTP %d: %s", ErrUnauthorized, resp.StatusCode, string(respBody))
+	}
+	if resp.StatusCode != http.StatusOK && (method != "POST" || resp.StatusCode != http.StatusCreated) {
 		respBody, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
 	}
+	c.authExpired.Store(false)
 
 	if result != nil {
 		return json.NewDecoder(resp.Body).Decode(result)
 	}
 	return nil
 }
+
+func (c *Client) send(method, path string, body []byte, refresh bool) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	token, err := c.bearer(refresh)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(req)
+}
@@ -327,3 +327,29 @@ func TestClient_PostContentType(t *testing.T) {
 	c := NewClient(srv.URL, "tok")
 	c.CreateProject("test")
 }
+
+func TestClient_GetSessionStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/vibeflow/sessions/session-1/status" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"session_id":"session-1","status":"working","phase":"implementing",
+			"current_work":{"type":"issue","id":12,"title":"Fix login"},"last_message":"running tests"}`))
+	}))
+	defer srv.Close()
+
+	status, err := NewClient(srv.URL, "").GetSessionStatus("session-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Phase != "implementing" || status.LastMessage != "running tests" {
+		t.Errorf("status = %+v", status)
+	}
+	if got := status.WorkLabel(); got != "issue #12: Fix login" {
+		t.Errorf("WorkLabel() = %q", got)
+	}
+	if got := (SessionStatus{}).WorkLabel(); got != "" {
+		t.Errorf("WorkLabel() without work = %q, want empty", got)
+	}
+}
@@ -17,6 +17,7 @@
 package vibeflowcli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -34,7 +35,10 @@ func initSubcommands(root *cobra.Command) {
 	root.AddCommand(launchCmd())
 	root.AddCommand(modelsCmd())
 	root.AddCommand(listCmd())
+	root.AddCommand(statusCmd())
+	root.AddCommand(runCmd())
 	root.AddCommand(switchCmd())
+	root.AddCommand(openCmd())
 	root.AddCommand(killCmd())
 	root.AddCommand(deleteCmd())
 	root.AddCommand(restartCmd())
@@ -42,10 +46,36 @@ func initSubcommands(root *cobra.Command) {
 	root.AddCommand(checkCmd())
 	root.AddCommand(configCmd())
 	root.AddCommand(agentDocCmd())
-	root.AddCommand(projectsCmd())
+	root.AddCommand(projectCmd())
 	root.AddCommand(bootstrapCmd())
 	root.AddCommand(uninstallCmd())
 	root.AddCommand(dispatchCmd())
+	root.AddCommand(remoteCmd())
+	root.AddCommand(logsCmd())
+	root.AddCommand(searchCmd())
+	root.AddCommand(pipeLogCmd())
+	root.AddCommand(replayCmd())
+	root.AddCommand(providerCmd())
+	root.AddCommand(profileCmd())
+	root.AddCommand(historyCmd())
+	root.AddCommand(trashCmd())
+	root.AddCommand(timeoutCmd())
+	root.AddCommand(noteCmd())
+	root.AddCommand(queueCmd())
+	root.AddCommand(costsCmd())
+	root.AddCommand(exportCmd())
+	root.AddCommand(importCmd())
+	root.AddCommand(upCmd())
+	root.AddCommand(downCmd())
+	root.AddCommand(shutdownCmd())
+	root.AddCommand(serveCmd())
+	root.AddCommand(controlCmd())
+	root.AddCommand(envCmd())
+	root.AddCommand(prCmd())
+	root.AddCommand(debugCmd())
+	root.AddCommand(loginCmd())
+	root.AddCommand(logoutCmd())
+	root.AddCommand(completionCmd())
 }
 
 // --- helpers shared by subcommands ---
@@ -64,12 +94,17 @@ func loadComponents(cfgPath string) (*Config, *TmuxManager, *Store, *WorktreeMan
 	// same socket the user's sessions actually live on.
 	cfg.TmuxSocket = ResolveTmuxSocket(flagTmuxSocket, cfg.TmuxSocket)
 	tmux := NewTmuxManager(cfg.TmuxSocket)
+	tmux.SetProjectSockets(cfg.TmuxPerProject)
 	tmux.SetLogger(NewLogger())
+	tmux.SetSessionLogging(cfg.SessionLogs)
 	store := NewStore()
 	registry := NewProviderRegistry(cfg)
 
 	cwd, _ := os.Getwd()
 	worktrees, _ := NewWorktreeManager(cwd, cfg.Worktree.BaseDir)
+	if worktrees != nil {
+		worktrees.SetSetup(cfg.Worktree.Setup)
+	}
 
 	return cfg, tmux, store, worktrees, registry, nil
 }
@@ -77,9 +112,11 @@ func loadComponents(cfgPath string) (*Config, *TmuxManager, *Store, *WorktreeMan
 // --- launch ---
 
 func launchCmd() *cobra.Command {
-	var provider, branch, worktreeName, persona, personasRaw, project, sessionType, model, modelsRaw string
+	var provider, branch, worktreeName, worktreeSparse, persona, personasRaw, project, sessionType, model, modelsRaw, profile, onConflict, onCollision string
+	var maxLifetime, maxIdle string
 	var openshellSandbox, openshellFrom, openshellPolicy, openshellProvidersRaw string
 	var worktree, skipPermissions, newBranch, llmGateway, openshell, openshellNoAutoProviders, cloudDispatch, replace, reuse bool
+	var issueID int64
 
 	cmd := &cobra.Command{
 		Use:   "launch",
@@ -90,6 +127,8 @@ func launchCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
 
 			// Ensure tmux server is running before creating sessions.
 			_ = tmux.EnsureServer()
@@ -103,6 +142,11 @@ func launchCmd() *cobra.Command {
 			if branch == "" {
 				branch = "main"
 			}
+			for _, v := range []string{maxLifetime, maxIdle} {
+				if _, err := parseTimeoutLimit(v); err != nil {
+					return err
+				}
+			}
 
 			prov, ok := registry.Get(provider)
 			if !ok {
@@ -114,14 +158,53 @@ func launchCmd() *cobra.Command {
 
 			workDir := "."
 
+			// --issue: fetch the issue and work on it on its own branch,
+			// in a new worktree.
+			var launchIssue *Issue
+			if issueID > 0 {
+				issueProject := cfg.DefaultProject
+				if project != "" {
+					issueProject = project
+				}
+				if launchIssue, err = fetchLaunchIssue(cfg, issueProject, issueID); err != nil {
+					return fmt.Errorf("fetch issue: %w", err)
+				}
+				if wm == nil {
+					return fmt.Errorf("--issue must be run inside a git repository")
+				}
+				if !cmd.Flags().Changed("branch") {
+					branch = issueBranchName(launchIssue.ID, launchIssue.Title)
+				}
+				worktree, newBranch = true, true
+			}
+
+			// --worktree-sparse: a new worktree that only checks out the
+			// given directories.
+			var sparse []string
+			if worktreeSparse != "" {
+				if sparse, err = ParseSparsePaths(worktreeSparse); err != nil {
+					return fmt.Errorf("--worktree-sparse: %w", err)
+				}
+				if wm == nil {
+					return fmt.Errorf("--worktree-sparse must be run inside a git repository")
+				}
+				worktree = true
+			}
+
+			createdBranch := false
 			if worktree && wm != nil {
 				wtName := worktreeName
 				if wtName == "" {
 					wtName = fmt.Sprintf("%s-%s-%d", provider, branch, time.Now().Unix())
 				}
-				wtPath, err := wm.CreateBranch(wtName, branch, newBranch, "")
+				creates := createsBranch(wm.RepoRoot(), branch)
+				wtPath, err := wm.CreateBranchContext(context.Background(), "", wtName, branch, newBranch, "", sparse, nil)
 				if err == nil {
-					workDir = wtPath
+					workDir, createdBranch = wtPath, creates
+				} else if launchIssue != nil {
+					return fmt.Errorf("create worktree for issue #%d: %w", launchIssue.ID, err)
+				} else if len(sparse) > 0 {
+					return fmt.Errorf("create sparse worktree: %w", err)
 				}
 			}
 
@@ -161,12 +244,21 @@ func launchCmd() *cobra.Command {
 			if replace && reuse {
 				return fmt.Errorf("--replace and --reuse are mutually exclusive")
 			}
+			if err := validateOnConflict(onConflict); err != nil {
+				return err
+			}
+			if err := validateOnCollision(onCollision); err != nil {
+				return err
+			}
+			if onCollision == "" {
+				onCollision = cfg.SessionCollision
+			}
 			if cloudDispatch {
 				if effectiveSessionType != "vibeflow" {
 					return fmt.Errorf("--cloud-dispatch requires a vibeflow session")
 				}
-				if cfg.APIToken == "" {
-					return fmt.Errorf("--cloud-dispatch requires api_token in config or VIBEFLOW_TOKEN")
+				if !cfg.hasAPIAuth() {
+					return fmt.Errorf("--cloud-dispatch requires api_token in config, VIBEFLOW_TOKEN or `vibeflow login`")
 				}
 			}
 
@@ -184,6 +276,9 @@ func launchCmd() *cobra.Command {
 					baseEnv[k] = v
 				}
 			}
+			if baseEnv, err = withProfileEnv(baseEnv, cfg, provider, profile); err != nil {
+				return err
+			}
 
 			// If LLM gateway is enabled (flag or saved config) AND the provider
 			// supports gateway routing, inject gateway env vars. Otherwise clear
@@ -253,9 +348,54 @@ func launchCmd() *cobra.Command {
 				}
 			}
 
-			// Ensure all agent-specific markdown docs exist in the working directory.
-			if effectiveSessionType == "vibeflow" {
-				EnsureAllAgentDocs(workDir)
+			// Resolve .vibeflow-session conflicts per persona: --on-conflict
+			// decides, else the user is asked when stdin is a terminal.
+			var ask conflictPrompter
+			var askCollision collisionPrompter
+			if stdinIsTerminal() {
+				ask = promptConflict(os.Stdin, os.Stdout)
+				askCollision = promptCollision(os.Stdin, os.Stdout)
+			}
+			var switchTo []string
+			skipPersona := make(map[string]bool)
+			for _, p := range personasToLaunch {
+				conflict := CheckConflict(workDir, p, tmux)
+				if conflict.Status == NoConflict {
+					continue
+				}
+				action, err := resolveLaunchConflict(conflict, onConflict, ask)
+				if err != nil {
+					return err
+				}
+				if action == ConflictSwitch {
+					switchTo = append(switchTo, conflict.TmuxSession)
+					skipPersona[p] = true
+					continue
+				}
+				if action == ConflictWorktree {
+					if wm == nil {
+						return fmt.Errorf("cannot launch in a new worktree: not in a git repository")
+					}
+					wtPath, err := wm.CreateBranch(fmt.Sprintf("%s-%s-%d", provider, branch, time.Now().Unix()), branch, false, "")
+					if err != nil {
+						return fmt.Errorf("create worktree: %w", err)
+					}
+					if b, err := gitIn(wtPath, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+						branch = b
+					}
+					fmt.Printf("Session %s is running in %s; launching in worktree %s\n", conflict.SessionID, workDir, wtPath)
+					// The new worktree has no session files, so the
+					// remaining personas can't conflict there.
+					workDir = wtPath
+					break
+				}
+				_ = CleanupStaleSession(filepath.Dir(conflict.FilePath), p)
+				if effectiveSessionType == "vibeflow" && !replace && conflict.SessionID != "" && reuseSessionIDs[p] == "" {
+					if reuseSessionIDs == nil {
+						reuseSessionIDs = make(map[string]string)
+					}
+					reuseSessionIDs[p] = conflict.SessionID
+				}
 			}
 
 			var dispatchProjectID int64
@@ -268,10 +408,35 @@ func launchCmd() *cobra.Command {
 			}
 
 			for _, p := range personasToLaunch {
+				if skipPersona[p] {
+					continue
+				}
 				sessionName := sessionid.GenerateSessionID(workDir)
 				if reusedID := reuseSessionIDs[p]; reusedID != "" {
 					sessionName = reusedID
 				}
+				// A reused ID can name a tmux session that is still running
+				// (or was just started for another persona with the same
+				// server session). Decide what to do before touching it.
+				serverID := sessionName
+				adopt := false
+				if collision, ok := detectSessionCollision(tmux, store, provider, p, sessionName); ok {
+					action, err := resolveSessionCollision(collision, onCollision, askCollision)
+					if err != nil {
+						return err
+					}
+					if sessionName, err = reconcileSessionCollision(tmux, store, NewSessionCache(), collision, action); err != nil {
+						return err
+					}
+					if action == CollisionAdopt {
+						if collision.Meta != nil {
+							fmt.Printf("Session %q is already running; adopted it.\n", collision.Meta.Name)
+							continue
+						}
+						adopt = true
+					}
+					fmt.Printf("Session %s already existed: %s (launching as %q)\n", collision.TmuxSession, action, sessionName)
+				}
 				sessionModel := modelForPersona(model, personaModels, p)
 				sessionEnv := cloneStringMap(baseEnv)
 				if provider == "qwen" && sessionModel != "" {
@@ -299,44 +464,75 @@ func launchCmd() *cobra.Command {
 				command = AppendQwenAPIFlags(command, provider, sessionEnv)
 
 				sessionCommand := command
+				var issueText string
+				if launchIssue != nil {
+					issueText = issuePrompt(launchIssue)
+				}
 
 				if effectiveSessionType == "vibeflow" && p != "" {
 					mcpName := cmd.Flags().Lookup("mcp").Value.String()
 					if mcpName == "" {
 						mcpName = DefaultMCPToolName
 					}
-					initPrompt := BuildVibeflowInitPrompt(mcpName, sessionProject, p)
-					if cloudDispatch {
-						initPrompt = BuildVibeflowCloudDispatchInitPrompt(mcpName, sessionProject, p, sessionName)
+					initPrompt, err := RenderInitPrompt(prov, PromptTemplateVars{
+						Provider:      provider,
+						Project:       sessionProject,
+						Persona:       p,
+						Branch:        branch,
+						WorkDir:       workDir,
+						ServerURL:     cfg.ServerURL,
+						SessionID:     serverID,
+						MCPToolName:   mcpName,
+						CloudDispatch: cloudDispatch,
+					})
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: %s prompt_template: %v (using the default prompt)\n", provider, err)
 					}
-					sessionCommand = AppendVibeflowInitPrompt(command, provider, initPrompt)
+					sessionCommand = AppendVibeflowInitPrompt(command, provider, joinPrompts(initPrompt, issueText))
+				} else {
+					sessionCommand = AppendVibeflowInitPrompt(command, provider, issueText)
 				}
 				sessionCommand, err = WrapOpenShellCommand(sessionCommand, openShellCfg)
 				if err != nil {
 					return err
 				}
 
+				// Ensure all agent-specific markdown docs exist in the working
+				// directory. Personas sharing a directory share its docs, so a
+				// templated section ends up rendered for the last one.
+				if effectiveSessionType == "vibeflow" {
+					docVars := AgentDocVars{Provider: provider, Project: sessionProject, Persona: p, SessionID: serverID, Branch: branch, WorkDir: workDir}
+					docVars.pendingWork = agentDocPendingWork(cfg, sessionProject)
+					if _, err := EnsureAllAgentDocsFor(workDir, cfg, docVars); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: %v (using the bundled rules)\n", err)
+					}
+				}
+
 				// Publish the new session ID before starting the provider. Reconcile
 				// flows may relaunch in a directory whose persona file still points at
 				// the session that just exited; writing after CreateSessionWithOpts
 				// lets the new agent race ahead and resume that stale API session.
 				if prov.SessionFile != "" {
-					if err := WriteSessionFileIfNeeded(workDir, p, sessionName); err != nil {
+					if err := WriteSessionFileIfNeeded(workDir, p, serverID); err != nil {
 						return fmt.Errorf("write session file for persona %q: %w", p, err)
 					}
 				}
 
-				if err := tmux.CreateSessionWithOpts(SessionOpts{
-					Name:     sessionName,
-					Provider: provider,
-					WorkDir:  workDir,
-					Command:  sessionCommand,
-					Env:      sessionEnv,
-					Branch:   branch,
-					Project:  sessionProject,
-					Persona:  p,
-				}); err != nil {
-					return err
+				queued := !adopt && shouldQueueLaunch(cfg, tmux, store)
+				if !adopt {
+					if err := tmux.CreateSessionWithOpts(SessionOpts{
+						Name:     sessionName,
+						Provider: provider,
+						WorkDir:  workDir,
+						Command:  sessionCommand,
+						Env:      sessionEnv,
+						Branch:   branch,
+						Project:  sessionProject,
+						Persona:  p,
+						Queued:   queued,
+					}); err != nil {
+						return err
+					}
 				}
 
 				tmuxName := tmux.FullSessionName(provider, sessionName)
@@ -350,20 +546,33 @@ func launchCmd() *cobra.Command {
 					Provider:          provider,
 					Project:           sessionProject,
 					ProjectID:         dispatchProjectID,
+					IssueID:           issueID,
+					IssuePrompt:       issueText,
 					Persona:           p,
 					Branch:            branch,
+					NewBranch:         createdBranch,
 					WorkingDir:        workDir,
-					VibeFlowSessionID: sessionName,
+					VibeFlowSessionID: serverID,
 					SessionType:       effectiveSessionType,
 					DispatchMode:      mapCloudDispatchMode(cloudDispatch),
 					CloudDispatch:     cloudDispatch,
 					SkipPermissions:   skipPermissions,
 					Model:             sessionModel,
+					ProviderVersion:   launchVersion(prov.Binary),
+					Profile:           profile,
+					MaxLifetime:       maxLifetime,
+					MaxIdle:           maxIdle,
 					LLMGatewayEnabled: gatewayEnabled,
 					OpenShell:         openShellMeta(openShellCfg),
+					Command:           redactCommandSecrets(sessionCommand),
+					EnvVars:           envVarNames(sessionEnv),
 					CreatedAt:         time.Now(),
 				}
+				if queued {
+					sessionMeta.QueuedAt = sessionMeta.CreatedAt
+				}
 				_ = store.Add(sessionMeta)
+				hooks.Fire(HookSessionCreate, sessionMeta, nil)
 
 				// Add to session cache for restart-without-intervention.
 				cache := NewSessionCache()
@@ -374,12 +583,22 @@ func launchCmd() *cobra.Command {
 					}
 				}
 
-				if p != "" {
+				if queued {
+					fmt.Printf("Session %q queued (max_running_sessions: %d); it starts when a slot frees, or now with `vibeflow queue start %s`.\n", sessionName, cfg.MaxRunningSessions, sessionName)
+				} else if p != "" {
 					fmt.Printf("Session %q launched (provider: %s, persona: %s, branch: %s)\n", sessionName, provider, p, branch)
 				} else {
 					fmt.Printf("Session %q launched (provider: %s, branch: %s)\n", sessionName, provider, branch)
 				}
 			}
+			if len(switchTo) > 0 {
+				if stdinIsTerminal() {
+					return tmux.AttachSession(switchTo[0])
+				}
+				for _, name := range switchTo {
+					fmt.Printf("Session %q is already running; not launching a duplicate.\n", name)
+				}
+			}
 			return nil
 		},
 	}
@@ -388,6 +607,7 @@ func launchCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&worktree, "worktree", false, "Create a new git worktree for the session")
 	cmd.Flags().StringVar(&worktreeName, "worktree-name", "", "Custom worktree directory name (default: auto-generated)")
 	cmd.Flags().BoolVar(&newBranch, "new-branch", false, "Create a new git branch (used with --worktree)")
+	cmd.Flags().StringVar(&worktreeSparse, "worktree-sparse", "", "Comma-separated directories to check out in the new worktree (sparse checkout; implies --worktree)")
 	cmd.Flags().BoolVar(&skipPermissions, "skip-permissions", false, "Skip permission prompts (autonomous mode)")
 	cmd.Flags().BoolVar(&llmGateway, "llm-gateway", false, "Route LLM requests through Axiom Cloud Gateway")
 	cmd.Flags().BoolVar(&openshell, "openshell", false, "Run the agent inside an NVIDIA OpenShell sandbox")
@@ -398,6 +618,9 @@ func launchCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&openshellNoAutoProviders, "openshell-no-auto-providers", false, "Disable OpenShell credential auto-provider discovery")
 	cmd.Flags().StringVar(&model, "model", "", "Model id to pass to each launched provider session")
 	cmd.Flags().StringVar(&modelsRaw, "models", "", "Comma-separated persona=model overrides for team launches")
+	cmd.Flags().StringVar(&profile, "profile", "", "Credential profile to run the provider under (see `vibeflow profile`)")
+	cmd.Flags().StringVar(&maxLifetime, "max-lifetime", "", "Kill or pause the session after this long, e.g. 8h (overrides timeouts.max_lifetime; off = never)")
+	cmd.Flags().StringVar(&maxIdle, "max-idle", "", "Kill or pause the session after this long without output, e.g. 2h (overrides timeouts.max_idle; off = never)")
 	cmd.Flags().StringVar(&persona, "persona", "", "Persona key for vibeflow sessions")
 	cmd.Flags().StringVar(&personasRaw, "personas", "", "Comma-separated persona keys for team mode")
 	cmd.Flags().StringVar(&project, "project", "", "Project name (overrides config default)")
@@ -405,6 +628,9 @@ func launchCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&cloudDispatch, "cloud-dispatch", false, "Let vibeflow-cli wait for AxiomCloud work and inject dispatch handoffs into the session")
 	cmd.Flags().BoolVar(&replace, "replace", false, "Stop and replace existing sessions for the selected personas")
 	cmd.Flags().BoolVar(&reuse, "reuse", false, "Relaunch selected personas using their existing session IDs")
+	cmd.Flags().Int64Var(&issueID, "issue", 0, "Work on this VibeFlow issue: new worktree on branch issue-<id>-<title>, issue text in the prompt")
+	cmd.Flags().StringVar(&onCollision, "on-collision", "", "How to handle a running tmux session with the launch's name (e.g. a reused server session): ask, adopt, kill, suffix or fail (default: session_collision, else ask if interactive, else fail)")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", "", "How to handle a .vibeflow-session conflict: switch, worktree, cleanup or fail (default: ask if interactive, else fail on running sessions)")
 	return cmd
 }
 
@@ -447,6 +673,7 @@ func preparePersonaSessions(tmux *TmuxManager, store *Store, cache *SessionCache
 			}
 			continue
 		}
+		_ = store.History().End(meta.Name, ExitReplaced, time.Now())
 		if err := store.Remove(meta.Name); err != nil {
 			return nil, fmt.Errorf("remove existing session %q: %w", meta.Name, err)
 		}
@@ -497,7 +724,7 @@ func modelsCmd() *cobra.Command {
 			if len(args) == 1 {
 				return printProviderModels(args[0])
 			}
-			for _, provider := range []string{"claude", "codex", "cursor", "gemini", "qwen"} {
+			for _, provider := range []string{"claude", "codex", "cursor", "gemini", "qwen", "aider"} {
 				if err := printProviderModels(provider); err != nil {
 					return err
 				}
@@ -525,13 +752,14 @@ func printProviderModels(provider string) error {
 }
 
 func listCmd() *cobra.Command {
-	return &cobra.Command{
+	var wide bool
+	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List active sessions",
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfgPath, _ := cmd.Flags().GetString("config")
-			_, tmux, store, _, _, err := loadComponents(cfgPath)
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
 			if err != nil {
 				return err
 			}
@@ -553,41 +781,64 @@ func listCmd() *cobra.Command {
 				}
 			}
 
-			// Print table.
-			fmt.Printf("%-24s %-12s %-16s %-10s\n", "NAME", "PROVIDER", "BRANCH", "STATUS")
-			fmt.Println(strings.Repeat("-", 66))
-			for _, s := range sessions {
-				shortName := strings.TrimPrefix(s.Name, sessionPrefix)
-				prov := "-"
-				branch := "-"
-				if meta, ok := storeMeta[s.Name]; ok {
-					prov = meta.Provider
-					branch = meta.Branch
-				}
-				status := "idle"
-				if s.Attached {
-					status = "attached"
-				}
-				fmt.Printf("%-24s %-12s %-16s %-10s\n", shortName, prov, branch, status)
+			if !wide {
+				printSessionTable(sessions, storeMeta)
+				return nil
+			}
+			registry := NewErrorPatternRegistryWithFile(DefaultErrorPatternsPath())
+			probe := func(ts TmuxSession, provider string) (string, string) {
+				health, _, output := probePaneHealth(tmux, ts, provider, registry)
+				return health, output
 			}
+			var beats map[string]time.Time
+			if cfg.hasAPIAuth() {
+				beats = serverHeartbeats(newAPIClient(cfg), storeMeta)
+			}
+			now := time.Now()
+			printWideSessionTable(cmd.OutOrStdout(), wideListRows(sessions, storeMeta, probe, beats, now), now)
 			return nil
 		},
 	}
+	cmd.Flags().BoolVarP(&wide, "wide", "w", false, "Add project, persona, worktree, uptime, health and last heartbeat columns, plus a status summary")
+	return cmd
+}
+
+// printSessionTable prints the `list` table. storeMeta is keyed by tmux
+// session name; sessions without an entry show "-" for provider/branch.
+func printSessionTable(sessions []TmuxSession, storeMeta map[string]SessionMeta) {
+	fmt.Printf("%-24s %-12s %-16s %-10s\n", "NAME", "PROVIDER", "BRANCH", "STATUS")
+	fmt.Println(strings.Repeat("-", 66))
+	for _, s := range sessions {
+		shortName := strings.TrimPrefix(s.Name, sessionPrefix)
+		prov := "-"
+		branch := "-"
+		if meta, ok := storeMeta[s.Name]; ok {
+			prov = meta.Provider
+			branch = meta.Branch
+		}
+		status := "idle"
+		if s.Attached {
+			status = "attached"
+		}
+		fmt.Printf("%-24s %-12s %-16s %-10s\n", shortName, prov, branch, status)
+	}
 }
 
 // --- switch ---
 
 func switchCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "switch <session-name>",
-		Short: "Attach to a session",
-		Args:  cobra.ExactArgs(1),
+		Use:               "switch <session-name>",
+		Short:             "Attach to a session",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfgPath, _ := cmd.Flags().GetString("config")
-			_, tmux, _, _, _, err := loadComponents(cfgPath)
+			_, tmux, store, _, _, err := loadComponents(cfgPath)
 			if err != nil {
 				return err
 			}
+			_ = store.MarkAttached(tmux.ensurePrefix(args[0]), time.Now())
 			return tmux.AttachSession(args[0])
 		},
 	}
@@ -596,26 +847,47 @@ func switchCmd() *cobra.Command {
 // --- kill ---
 
 func killCmd() *cobra.Command {
-	var cleanupWorktree bool
+	var cleanupWorktree, yes bool
 
 	cmd := &cobra.Command{
-		Use:   "kill <session-name>",
-		Short: "Kill a session",
-		Args:  cobra.ExactArgs(1),
+		Use:               "kill <session-name>",
+		Short:             "Kill a session",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfgPath, _ := cmd.Flags().GetString("config")
-			_, tmux, store, wm, _, err := loadComponents(cfgPath)
+			cfg, tmux, store, wm, _, err := loadComponents(cfgPath)
 			if err != nil {
 				return err
 			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
 			cache := NewSessionCache()
 
 			name := args[0]
+			meta, found := lookupRemovalTarget(tmux, store, name)
+			ok, err := confirmSessionRemoval(cmd.InOrStdin(), cmd.OutOrStdout(), "Kill", name, tmux, meta, found, cleanupWorktree, yes)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted.")
+				return nil
+			}
+			if found {
+				saveArchiveTail(cfg, tmux, store, meta)
+			}
 			if err := tmux.KillSession(name); err != nil {
 				return fmt.Errorf("kill session: %w", err)
 			}
 
-			if meta, found, _ := store.Get(name); found {
+			trashed := false
+			if found {
+				if err := trashSession(store, cfg, meta); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to keep session in the trash: %v\n", err)
+				} else {
+					trashed = cfg.TrashRetention() > 0
+				}
 				// Session file is intentionally kept so the session ID can
 				// be reused on next launch via stale conflict detection.
 				if cleanupWorktree && meta.WorktreePath != "" && wm != nil {
@@ -623,43 +895,72 @@ func killCmd() *cobra.Command {
 						fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree: %v\n", err)
 					}
 				}
-				_ = store.Remove(name)
+				_ = store.History().End(meta.Name, ExitKilled, time.Now())
+				_ = store.Remove(meta.Name)
+				_ = cache.Remove(meta.Name)
+				hooks.Fire(HookSessionKill, meta, nil)
 			}
 			_ = cache.Remove(name)
 
 			fmt.Printf("Session %q killed.\n", name)
+			if trashed {
+				fmt.Printf("Undo with `vibeflow trash restore %s`.\n", meta.Name)
+			}
+			startFreedSlots(cfg, tmux, store)
 			return nil
 		},
 	}
 	cmd.Flags().BoolVar(&cleanupWorktree, "cleanup-worktree", false, "Also remove the git worktree")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
 	return cmd
 }
 
 // --- delete (alias for kill) ---
 
 func deleteCmd() *cobra.Command {
-	var cleanupWorktree bool
+	var cleanupWorktree, yes bool
 
 	cmd := &cobra.Command{
-		Use:     "delete <session-name>",
-		Short:   "Delete (kill) a session",
-		Long:    "Delete a session by name. This is an alias for the 'kill' command.",
-		Args:    cobra.ExactArgs(1),
-		Aliases: []string{"rm"},
+		Use:               "delete <session-name>",
+		Short:             "Delete (kill) a session",
+		Long:              "Delete a session by name. This is an alias for the 'kill' command.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(false),
+		Aliases:           []string{"rm"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfgPath, _ := cmd.Flags().GetString("config")
-			_, tmux, store, wm, _, err := loadComponents(cfgPath)
+			cfg, tmux, store, wm, _, err := loadComponents(cfgPath)
 			if err != nil {
 				return err
 			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
 			cache := NewSessionCache()
 
 			name := args[0]
+			meta, found := lookupRemovalTarget(tmux, store, name)
+			ok, err := confirmSessionRemoval(cmd.InOrStdin(), cmd.OutOrStdout(), "Delete", name, tmux, meta, found, cleanupWorktree, yes)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted.")
+				return nil
+			}
+			if found {
+				saveArchiveTail(cfg, tmux, store, meta)
+			}
 			if err := tmux.KillSession(name); err != nil {
 				return fmt.Errorf("delete session: %w", err)
 			}
 
-			if meta, found, _ := store.Get(name); found {
+			trashed := false
+			if found {
+				if err := trashSession(store, cfg, meta); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to keep session in the trash: %v\n", err)
+				} else {
+					trashed = cfg.TrashRetention() > 0
+				}
 				// Session file is intentionally kept so the session ID can
 				// be reused on next launch via stale conflict detection.
 				if cleanupWorktree && meta.WorktreePath != "" && wm != nil {
@@ -667,15 +968,23 @@ func deleteCmd() *cobra.Command {
 						fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree: %v\n", err)
 					}
 				}
-				_ = store.Remove(name)
+				_ = store.History().End(meta.Name, ExitDeleted, time.Now())
+				_ = store.Remove(meta.Name)
+				_ = cache.Remove(meta.Name)
+				hooks.Fire(HookSessionKill, meta, nil)
 			}
 			_ = cache.Remove(name)
 
 			fmt.Printf("Session %q deleted.\n", name)
+			if trashed {
+				fmt.Printf("Undo with `vibeflow trash restore %s`.\n", meta.Name)
+			}
+			startFreedSlots(cfg, tmux, store)
 			return nil
 		},
 	}
 	cmd.Flags().BoolVar(&cleanupWorktree, "cleanup-worktree", false, "Also remove the git worktree")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
 	return cmd
 }
 
@@ -684,7 +993,14 @@ func deleteCmd() *cobra.Command {
 // RestartSession kills any existing tmux session and re-launches it using
 // the stored metadata. Used by both the CLI restart command and the TUI
 // dead-session restart popup. Returns the updated SessionMeta on success.
+// Like a launch, the agent waits for a slot under max_running_sessions.
+// Warnings go to tmux's logger, since the TUI may be on screen.
 func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Store, cache *SessionCache, registry *ProviderRegistry) (SessionMeta, error) {
+	logger := tmux.logger
+	if logger == nil {
+		logger = &Logger{}
+	}
+
 	// Kill the existing tmux session (ignore error if already dead).
 	_ = tmux.KillSession(meta.TmuxSession)
 
@@ -738,6 +1054,10 @@ func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Sto
 			sessionEnv[k] = v
 		}
 	}
+	sessionEnv, err = withProfileEnv(sessionEnv, cfg, provider, meta.Profile)
+	if err != nil {
+		return SessionMeta{}, err
+	}
 
 	// LLM gateway env vars.
 	if meta.LLMGatewayEnabled {
@@ -775,15 +1095,27 @@ func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Sto
 		projectName = cfg.DefaultProject
 	}
 	if meta.SessionType == "vibeflow" {
-		initPrompt := BuildVibeflowInitPrompt(meta.MCPToolName, projectName, meta.Persona)
-		if meta.CloudDispatch || meta.DispatchMode == "cloud_queue" {
-			sessionID := meta.VibeFlowSessionID
-			if sessionID == "" {
-				sessionID = meta.Name
-			}
-			initPrompt = BuildVibeflowCloudDispatchInitPrompt(meta.MCPToolName, projectName, meta.Persona, sessionID)
+		vars := PromptTemplateVars{
+			Provider:      provider,
+			Project:       projectName,
+			Persona:       meta.Persona,
+			Branch:        branch,
+			WorkDir:       workDir,
+			ServerURL:     cfg.ServerURL,
+			SessionID:     meta.VibeFlowSessionID,
+			MCPToolName:   meta.MCPToolName,
+			CloudDispatch: meta.CloudDispatch || meta.DispatchMode == "cloud_queue",
+		}
+		if vars.CloudDispatch && vars.SessionID == "" {
+			vars.SessionID = meta.Name
 		}
-		command = AppendVibeflowInitPrompt(command, provider, initPrompt)
+		initPrompt, err := RenderInitPrompt(prov, vars)
+		if err != nil {
+			logger.Warn("restart %s: %s prompt_template: %v (using the default prompt)", meta.Name, provider, err)
+		}
+		command = AppendVibeflowInitPrompt(command, provider, joinPrompts(initPrompt, meta.IssuePrompt))
+	} else {
+		command = AppendVibeflowInitPrompt(command, provider, meta.IssuePrompt)
 	}
 	command, err = WrapOpenShellCommand(command, openShellValue(meta.OpenShell))
 	if err != nil {
@@ -792,9 +1124,14 @@ func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Sto
 
 	// Ensure agent docs exist in the working directory.
 	if meta.SessionType == "vibeflow" {
-		EnsureAllAgentDocs(workDir)
+		docVars := AgentDocVars{Provider: provider, Project: projectName, Persona: meta.Persona, SessionID: meta.VibeFlowSessionID, Branch: branch, WorkDir: workDir}
+		docVars.pendingWork = agentDocPendingWork(cfg, projectName)
+		if _, err := EnsureAllAgentDocsFor(workDir, cfg, docVars); err != nil {
+			logger.Warn("restart %s: %v (using the bundled rules)", meta.Name, err)
+		}
 	}
 
+	queued := shouldQueueLaunch(cfg, tmux, store)
 	if err := tmux.CreateSessionWithOpts(SessionOpts{
 		Name:     meta.Name,
 		Provider: provider,
@@ -803,6 +1140,7 @@ func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Sto
 		Env:      sessionEnv,
 		Branch:   branch,
 		Project:  projectName,
+		Queued:   queued,
 	}); err != nil {
 		return SessionMeta{}, err
 	}
@@ -838,8 +1176,12 @@ func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Sto
 		Provider:          provider,
 		Project:           projectName,
 		ProjectID:         meta.ProjectID,
+		IssueID:           meta.IssueID,
+		IssuePrompt:       meta.IssuePrompt,
+		Group:             meta.Group,
 		Persona:           meta.Persona,
 		Branch:            branch,
+		NewBranch:         meta.NewBranch,
 		WorktreePath:      meta.WorktreePath,
 		WorkingDir:        workDir,
 		VibeFlowSessionID: meta.VibeFlowSessionID,
@@ -848,11 +1190,20 @@ func RestartSession(meta SessionMeta, cfg *Config, tmux *TmuxManager, store *Sto
 		CloudDispatch:     meta.CloudDispatch,
 		SkipPermissions:   meta.SkipPermissions,
 		Model:             meta.Model,
+		ProviderVersion:   launchVersion(prov.Binary),
+		Profile:           meta.Profile,
+		MaxLifetime:       meta.MaxLifetime,
+		MaxIdle:           meta.MaxIdle,
 		LLMGatewayEnabled: meta.LLMGatewayEnabled,
 		MCPToolName:       meta.MCPToolName,
 		OpenShell:         meta.OpenShell,
+		Command:           redactCommandSecrets(command),
+		EnvVars:           envVarNames(sessionEnv),
 		CreatedAt:         time.Now(),
 	}
+	if queued {
+		updated.QueuedAt = updated.CreatedAt
+	}
 
 	// Update store and cache.
 	if store != nil {
@@ -965,9 +1316,10 @@ func restartCmd() *cobra.Command {
 	var skipPermissions bool
 
 	cmd := &cobra.Command{
-		Use:   "restart <session-name>",
-		Short: "Restart a session (kill and re-launch with same settings)",
-		Args:  cobra.ExactArgs(1),
+		Use:               "restart <session-name>",
+		Short:             "Restart a session (kill and re-launch with same settings)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(true),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfgPath, _ := cmd.Flags().GetString("config")
 			cfg, tmux, store, _, registry, err := loadComponents(cfgPath)
@@ -1008,12 +1360,18 @@ func restartCmd() *cobra.Command {
 				meta.SkipPermissions = skipPermissions
 			}
 
-			_, err = RestartSession(meta, cfg, tmux, store, cache, registry)
+			updated, err := RestartSession(meta, cfg, tmux, store, cache, registry)
 			if err != nil {
 				return err
 			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			hooks.Fire(HookSessionCreate, updated, nil)
+			defer hooks.Wait()
 
 			fmt.Printf("Session %q restarted (provider: %s, branch: %s)\n", name, meta.Provider, meta.Branch)
+			if !updated.QueuedAt.IsZero() {
+				fmt.Printf("It is queued (max_running_sessions: %d); it starts when a slot frees, or now with `vibeflow queue start %s`.\n", cfg.MaxRunningSessions, updated.Name)
+			}
 			return nil
 		},
 	}
@@ -1024,7 +1382,7 @@ func restartCmd() *cobra.Command {
 // --- worktrees ---
 
 func worktreesCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "worktrees",
 		Short:   "List git worktrees",
 		Aliases: []string{"wt"},
@@ -1066,6 +1424,67 @@ func worktreesCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.AddCommand(worktreesPruneCmd())
+	return cmd
+}
+
+func worktreesPruneCmd() *cobra.Command {
+	var (
+		dryRun bool
+		days   int
+	)
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove stale worktrees under the worktree base dir",
+		Long: `Remove worktrees under worktree.base_dir that have had no commits or index
+changes for --days, are not used by any session and have no uncommitted or
+untracked changes. Branches are kept, so pruned work can be checked out again.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, _, store, wm, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			if wm == nil {
+				return fmt.Errorf("not in a git repository")
+			}
+			if !cmd.Flags().Changed("days") && cfg.Worktree.PruneAfterDays > 0 {
+				days = cfg.Worktree.PruneAfterDays
+			}
+			if days < 1 {
+				return fmt.Errorf("--days must be at least 1")
+			}
+			inUse, err := worktreesInUse(store)
+			if err != nil {
+				return err
+			}
+			stale, err := wm.FindStale(time.Duration(days)*24*time.Hour, inUse, time.Now())
+			if err != nil {
+				return err
+			}
+			if len(stale) == 0 {
+				fmt.Printf("No stale worktrees (older than %d days).\n", days)
+				return nil
+			}
+			for _, wt := range stale {
+				fmt.Printf("%-50s %-20s last active %s\n", wt.Path, truncate(wt.Branch, 20), wt.LastActivity.Format("2006-01-02"))
+			}
+			if dryRun {
+				fmt.Printf("%d worktree(s) would be removed (dry run).\n", len(stale))
+				return nil
+			}
+			removed, errs := wm.PruneStale(stale)
+			for _, e := range errs {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", e)
+			}
+			fmt.Printf("Removed %d worktree(s).\n", len(removed))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List worktrees that would be removed without removing them")
+	cmd.Flags().IntVar(&days, "days", defaultPruneAfterDays, "Minimum days without activity (default: worktree.prune_after_days, else 7)")
+	return cmd
 }
 
 // --- check ---
@@ -1129,48 +1548,28 @@ func configCmd() *cobra.Command {
 	}
 }
 
-func projectsCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "projects",
-		Short: "List available VibeFlow projects",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			cfgPath, _ := cmd.Flags().GetString("config")
-			if cfgPath == "" {
-				cfgPath = ConfigPath()
-			}
-			cfg, err := LoadConfig(cfgPath)
-			if err != nil {
-				return fmt.Errorf("load config: %w", err)
-			}
-			client := NewClient(cfg.ServerURL, cfg.APIToken)
-			projects, err := client.ListProjects()
-			if err != nil {
-				return fmt.Errorf("fetch projects: %w", err)
-			}
-			if len(projects) == 0 {
-				fmt.Println("No projects found.")
-				return nil
-			}
-			fmt.Printf("%-8s %-30s %s\n", "ID", "NAME", "STATUS")
-			fmt.Println(strings.Repeat("-", 60))
-			for _, p := range projects {
-				fmt.Printf("%-8d %-30s %s\n", p.ID, p.Name, p.Status)
-			}
-			return nil
-		},
-	}
-}
-
 // --- agent-doc ---
 
 func agentDocCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "agent-doc <provider>",
 		Short: "Print the embedded agent doc template to stdout",
 		Long:  "Print the embedded agent instruction file (CLAUDE.md, AGENTS.md, or GEMINI.md) for the given provider to stdout.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			content, err := GetAgentDoc(args[0])
+			// Custom providers resolve through their agent_doc setting, so
+			// the config is consulted only for keys that aren't built in.
+			var registry *ProviderRegistry
+			if _, builtin := providerDocFile[args[0]]; !builtin {
+				cfgPath, _ := cmd.Flags().GetString("config")
+				if cfgPath == "" {
+					cfgPath = ConfigPath()
+				}
+				if cfg, err := LoadConfig(cfgPath); err == nil {
+					registry = NewProviderRegistry(cfg)
+				}
+			}
+			content, err := GetProviderAgentDoc(args[0], registry)
 			if err != nil {
 				return err
 			}
@@ -1178,4 +1577,6 @@ func agentDocCmd() *cobra.Command {
 			return err
 		},
 	}
+	cmd.AddCommand(agentDocSyncCmd())
+	return cmd
 }
@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// completionProgName is the command name the generated script registers
+// for: the name the binary was invoked as (normally "vibeflow"), not the
+// root command's Use, so completion works whatever the install calls it.
+func completionProgName() string {
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	if name == "" || name == "." {
+		return "vibeflow"
+	}
+	return name
+}
+
+// --- completion ---
+
+func completionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Generate a shell completion script",
+		Long: `Print a completion script for bash, zsh or fish. Session-name arguments
+(switch, kill, delete, restart) complete from live tmux sessions and the
+session store.
+
+  bash:  vibeflow completion bash > ~/.local/share/bash-completion/completions/vibeflow
+  zsh:   vibeflow completion zsh > "${fpath[1]}/_vibeflow"
+  fish:  vibeflow completion fish > ~/.config/fish/completions/vibeflow.fish`,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			root.Use = completionProgName()
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			default:
+				return fmt.Errorf("unsupported shell %q (bash, zsh or fish)", args[0])
+			}
+		},
+	}
+}
+
+// sessionCompletion returns a ValidArgsFunction offering session names for
+// the first argument. By default it offers live sessions by their tmux short
+// name, which is what switch/kill/delete act on. fromStore offers the names
+// recorded in the store and the restart cache instead, matching how
+// `restart` looks sessions up, so exited sessions complete too. Each
+// candidate carries "provider, branch" as its description.
+func sessionCompletion(fromStore bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		// Completion runs through cobra's hidden __complete command, whose
+		// flags are parsed after the root PersistentPreRun has applied --root.
+		if root, _ := cmd.Flags().GetString("root"); root != "" {
+			SetRootDir(root)
+		}
+		cfgPath, _ := cmd.Flags().GetString("config")
+		_, tmux, store, _, _, err := loadComponents(cfgPath)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		metas, _ := store.List()
+		if !fromStore {
+			live, _ := tmux.ListSessions()
+			return liveSessionCandidates(live, metas, toComplete), cobra.ShellCompDirectiveNoFileComp
+		}
+		if cached, err := NewSessionCache().List(); err == nil {
+			metas = append(metas, cached...)
+		}
+		return storedSessionCandidates(metas, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// liveSessionCandidates lists running tmux sessions by short name, described
+// from their store entry when there is one.
+func liveSessionCandidates(live []TmuxSession, metas []SessionMeta, prefix string) []cobra.Completion {
+	byTmux := make(map[string]SessionMeta, len(metas))
+	for _, m := range metas {
+		byTmux[m.TmuxSession] = m
+	}
+	c := newCandidates(prefix)
+	for _, s := range live {
+		if isWorkbenchHolder(s.Name) {
+			continue
+		}
+		c.add(strings.TrimPrefix(s.Name, sessionPrefix), byTmux[s.Name])
+	}
+	return c.sorted()
+}
+
+// trashCompletion completes the names of sessions in the trash.
+func trashCompletion(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if root, _ := cmd.Flags().GetString("root"); root != "" {
+		SetRootDir(root)
+	}
+	cfgPath, _ := cmd.Flags().GetString("config")
+	cfg, _, store, _, _, err := loadComponents(cfgPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	list, _ := store.TrashList(cfg.TrashRetention())
+	metas := make([]SessionMeta, 0, len(list))
+	for _, t := range list {
+		metas = append(metas, t.Meta)
+	}
+	return storedSessionCandidates(metas, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// storedSessionCandidates lists store and cache entries by meta name.
+func storedSessionCandidates(metas []SessionMeta, prefix string) []cobra.Completion {
+	c := newCandidates(prefix)
+	for _, m := range metas {
+		c.add(m.Name, m)
+	}
+	return c.sorted()
+}
+
+// candidates collects prefix-filtered, de-duplicated completions.
+type candidates struct {
+	prefix string
+	seen   map[string]bool
+	out    []cobra.Completion
+}
+
+func newCandidates(prefix string) *candidates {
+	return &candidates{prefix: prefix, seen: make(map[string]bool)}
+}
+
+func (c *candidates) add(name string, meta SessionMeta) {
+	if name == "" || c.seen[name] || !strings.HasPrefix(name, c.prefix) {
+		return
+	}
+	c.seen[name] = true
+	var desc []string
+	for _, p := range []string{meta.Provider, meta.Branch} {
+		if p != "" {
+			desc = append(desc, p)
+		}
+	}
+	if len(desc) == 0 {
+		c.out = append(c.out, name)
+		return
+	}
+	c.out = append(c.out, cobra.CompletionWithDesc(name, strings.Join(desc, ", ")))
+}
+
+func (c *candidates) sorted() []cobra.Completion {
+	sort.Strings(c.out)
+	return c.out
+}
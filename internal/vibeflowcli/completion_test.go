@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestLiveSessionCandidates(t *testing.T) {
+	live := []TmuxSession{
+		{Name: "vibeflow_claude-api"},
+		{Name: "vibeflow_codex-web"},
+		{Name: "vibeflow_claude-auth"},
+		{Name: workbenchHolderName},
+	}
+	metas := []SessionMeta{
+		{Name: "claude-api", TmuxSession: "vibeflow_claude-api", Provider: "claude", Branch: "feat/api"},
+		{Name: "gone", TmuxSession: "vibeflow_gone", Provider: "codex"},
+	}
+	got := liveSessionCandidates(live, metas, "claude")
+	want := []string{"claude-api\tclaude, feat/api", "claude-auth"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %q, want %q", got, want)
+	}
+	if got := liveSessionCandidates(live, metas, ""); len(got) != 3 {
+		t.Errorf("unfiltered = %q, want the three agent sessions", got)
+	}
+}
+
+func TestStoredSessionCandidates_Dedupes(t *testing.T) {
+	metas := []SessionMeta{
+		{Name: "codex-web", Provider: "codex"},
+		{Name: "claude-api", Provider: "claude"},
+		{Name: "claude-api", Provider: "claude"}, // also in the restart cache
+	}
+	got := storedSessionCandidates(metas, "")
+	want := []string{"claude-api\tclaude", "codex-web\tcodex"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %q, want %q", got, want)
+	}
+}
+
+func TestCompletionCmd(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		root := &cobra.Command{Use: "vibeflow-cli"}
+		root.AddCommand(completionCmd())
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetArgs([]string{"completion", shell})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("completion %s: %v", shell, err)
+		}
+		if !strings.Contains(out.String(), completionProgName()) {
+			t.Errorf("%s script does not register %q", shell, completionProgName())
+		}
+	}
+
+	root := &cobra.Command{Use: "vibeflow-cli"}
+	root.AddCommand(completionCmd())
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"completion", "tcsh"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "unsupported shell") {
+		t.Errorf("tcsh: err = %v, want unsupported shell", err)
+	}
+}
@@ -86,6 +86,25 @@ type WorktreeConfig struct {
 	AutoCreate    bool   `yaml:"auto_create"`
 	CleanupOnKill string `yaml:"cleanup_on_kill"` // "ask", "always", "never"
 	LastCustomDir string `yaml:"last_custom_dir,omitempty"`
+	// PruneAfterDays enables background garbage collection of worktrees under
+	// base_dir that have been untouched this long, have no session and no
+	// uncommitted changes. 0 disables it; `worktrees prune` still works.
+	PruneAfterDays int `yaml:"prune_after_days,omitempty"`
+	// BranchPrefix starts the new-branch names the wizard suggests: "vf"
+	// gives "vf/dev/fix-login-timeout".
+	BranchPrefix string `yaml:"branch_prefix,omitempty"`
+	// Setup prepares each new worktree with the untracked files agents
+	// need (.env, local settings, dependencies).
+	Setup WorktreeSetupConfig `yaml:"setup,omitempty"`
+}
+
+// WorktreeSetupConfig lists the post-create steps run after `git worktree
+// add`, in order: copies, symlinks, then the command. Globs are relative to
+// the main checkout; matches already present in the worktree are skipped.
+type WorktreeSetupConfig struct {
+	Copy    []string `yaml:"copy,omitempty"`    // e.g. ".env", ".env.*", ".vscode"
+	Symlink []string `yaml:"symlink,omitempty"` // e.g. "node_modules", shared with the main checkout
+	Command string   `yaml:"command,omitempty"` // run with sh -c in the new worktree
 }
 
 // ErrorRecoveryConfig holds settings for automatic error detection and recovery.
@@ -95,6 +114,72 @@ type ErrorRecoveryConfig struct {
 	DebounceSeconds   int  `yaml:"debounce_seconds"`
 	BackoffMultiplier int  `yaml:"backoff_multiplier"`
 	MaxBackoffSeconds int  `yaml:"max_backoff_seconds"`
+	SnoozeMinutes     int  `yaml:"snooze_minutes,omitempty"` // how long the snooze key holds off recovery (default 120)
+
+	// Provider-level rate-limit cooldown: once RateLimitSessions sessions of
+	// one provider hit a rate limit within the cooldown window, recovery for
+	// all of that provider's sessions pauses for RateLimitCooldownSeconds.
+	RateLimitSessions        int  `yaml:"rate_limit_sessions,omitempty"`          // default 2; a negative value turns the cooldown off
+	RateLimitCooldownSeconds int  `yaml:"rate_limit_cooldown_seconds,omitempty"`  // default 300
+	PauseDispatchOnRateLimit bool `yaml:"pause_dispatch_on_rate_limit,omitempty"` // also stop auto-dispatch to the provider during a cooldown
+
+	// Checkpoint snapshots a session's worktree to a ref before each
+	// recovery attempt, keeping the newest CheckpointKeep per session.
+	Checkpoint     bool `yaml:"checkpoint,omitempty"`
+	CheckpointKeep int  `yaml:"checkpoint_keep,omitempty"` // default 10
+
+	// Prompts replace a matched pattern's recovery message for the
+	// providers and personas they select; the first match wins.
+	Prompts []RecoveryPrompt `yaml:"prompts,omitempty"`
+}
+
+// defaultSnoozeMinutes is the recovery snooze when snooze_minutes is unset.
+const defaultSnoozeMinutes = 120
+
+// SnoozeDuration returns how long the snooze key holds off recovery.
+func (c ErrorRecoveryConfig) SnoozeDuration() time.Duration {
+	if c.SnoozeMinutes <= 0 {
+		return defaultSnoozeMinutes * time.Minute
+	}
+	return time.Duration(c.SnoozeMinutes) * time.Minute
+}
+
+// defaultCheckpointKeep is how many recovery checkpoints of a session are
+// kept when checkpoint_keep is unset.
+const defaultCheckpointKeep = 10
+
+// CheckpointLimit returns how many recovery checkpoints of a session are kept.
+func (c ErrorRecoveryConfig) CheckpointLimit() int {
+	if c.CheckpointKeep <= 0 {
+		return defaultCheckpointKeep
+	}
+	return c.CheckpointKeep
+}
+
+// Defaults for the provider-level rate-limit cooldown.
+const (
+	defaultRateLimitSessions        = 2
+	defaultRateLimitCooldownSeconds = 300
+)
+
+// RateLimitThreshold returns how many rate-limited sessions of one provider
+// start a provider cooldown, or 0 when the cooldown is off.
+func (c ErrorRecoveryConfig) RateLimitThreshold() int {
+	switch {
+	case c.RateLimitSessions < 0:
+		return 0
+	case c.RateLimitSessions == 0:
+		return defaultRateLimitSessions
+	}
+	return c.RateLimitSessions
+}
+
+// RateLimitCooldown returns how long a provider cooldown lasts.
+func (c ErrorRecoveryConfig) RateLimitCooldown() time.Duration {
+	if c.RateLimitCooldownSeconds <= 0 {
+		return defaultRateLimitCooldownSeconds * time.Second
+	}
+	return time.Duration(c.RateLimitCooldownSeconds) * time.Second
 }
 
 // OpenShellConfig controls optional NVIDIA OpenShell sandbox wrapping for
@@ -112,6 +197,86 @@ type OpenShellConfig struct {
 	Args            []string `yaml:"args,omitempty"`
 }
 
+// SessionLogConfig controls per-session output logging. When enabled, every
+// session created by vibeflow has its pane output piped (tmux pipe-pane) to
+// RootDir/logs/<session>.log so the transcript survives the tmux session.
+// Record additionally (or instead) keeps a timed asciicast recording per
+// session start under RootDir/recordings for `vibeflow replay`.
+type SessionLogConfig struct {
+	Enabled   bool `yaml:"enabled"`
+	MaxSizeMB int  `yaml:"max_size_mb,omitempty"` // rotate once a log reaches this size (default 10)
+	MaxFiles  int  `yaml:"max_files,omitempty"`   // rotated generations kept per session (default 3)
+	Record    bool `yaml:"record,omitempty"`      // write <session>-<time>.cast recordings (not rotated)
+}
+
+// NotificationConfig controls alerts for sessions that need attention: an
+// agent blocked on an input prompt, or one whose error recovery gave up.
+type NotificationConfig struct {
+	Desktop    bool   `yaml:"desktop"`               // osascript on macOS, notify-send on Linux
+	WebhookURL string `yaml:"webhook_url,omitempty"` // JSON POST target (Slack-compatible "text" field)
+
+	// Bell alerts the terminal when a session changes status while the TUI
+	// is unfocused: off (default), bell, flash or both.
+	Bell   string   `yaml:"bell,omitempty"`
+	BellOn []string `yaml:"bell_on,omitempty"` // statuses that alert; default waiting, error, done
+}
+
+// AgentDocConfig customizes the vibeflow rules section written into agent
+// docs (CLAUDE.md, AGENTS.md, ...) for vibeflow sessions. Templates use Go
+// text/template syntax with the fields of AgentDocVars.
+type AgentDocConfig struct {
+	SectionTemplate string            `yaml:"section_template,omitempty"` // replaces the bundled section for every project
+	Projects        map[string]string `yaml:"projects,omitempty"`         // project name → section template, overriding section_template
+}
+
+// ArchiveConfig controls uploading a summary of each ended session
+// (duration, branch, commits, final output, recoveries) to the server, so
+// the project timeline shows local sessions too.
+type ArchiveConfig struct {
+	Enabled   bool `yaml:"enabled"`
+	TailLines int  `yaml:"tail_lines,omitempty"` // final output lines uploaded (default 50)
+}
+
+// HeartbeatConfig controls the loop that reports sessions started outside
+// VibeFlow's managed flow to the server, so the dashboard shows them with a
+// live heartbeat and activity status.
+type HeartbeatConfig struct {
+	Enabled         bool `yaml:"enabled"`
+	IntervalSeconds int  `yaml:"interval_seconds,omitempty"` // default 30
+}
+
+// AutoDispatchConfig controls handing ready work from the server to idle
+// sessions. Personas lists the session personas that may receive work;
+// "*" matches every session, including ones launched without a persona.
+type AutoDispatchConfig struct {
+	Enabled         bool     `yaml:"enabled"`
+	IntervalSeconds int      `yaml:"interval_seconds,omitempty"` // default 60
+	Personas        []string `yaml:"personas,omitempty"`         // default [developer]
+	IncludeTodos    bool     `yaml:"include_todos,omitempty"`    // also dispatch ready todos, not just issues
+}
+
+// PermissionsConfig controls answering provider permission prompts ("allow
+// running npm test?") in sessions launched without skipped permissions.
+// Patterns match the whole command; * matches any run of characters. Deny
+// is checked first; a command matching neither list is left for the user.
+type PermissionsConfig struct {
+	AutoRespond bool     `yaml:"auto_respond,omitempty"`
+	Allow       []string `yaml:"allow,omitempty"`
+	Deny        []string `yaml:"deny,omitempty"`
+}
+
+// AutoPRConfig controls opening a pull request when a session's agent
+// signals that it has finished: the worktree is committed, its branch pushed
+// and a PR (or GitLab merge request) opened with gh or glab.
+type AutoPRConfig struct {
+	Enabled          bool     `yaml:"enabled"`
+	CompletionMarker string   `yaml:"completion_marker,omitempty"` // regexp matched against recent pane output (default: a line reading VIBEFLOW_DONE)
+	ServerStatuses   []string `yaml:"server_statuses,omitempty"`   // server session status or phase values that count as done
+	Tool             string   `yaml:"tool,omitempty"`              // "gh" or "glab"; default picks by the origin host
+	Draft            bool     `yaml:"draft,omitempty"`
+	CommitMessage    string   `yaml:"commit_message,omitempty"` // for uncommitted work; default "Work from vibeflow session <name>"
+}
+
 // Config holds all vibeflow-cli configuration.
 type Config struct {
 	ServerURL         string              `yaml:"server_url"`
@@ -119,6 +284,7 @@ type Config struct {
 	DefaultProject    string              `yaml:"default_project"`
 	DefaultWorkDir    string              `yaml:"default_work_dir"`
 	TmuxSocket        string              `yaml:"tmux_socket"`
+	TmuxPerProject    bool                `yaml:"tmux_socket_per_project,omitempty"` // each project's sessions on their own socket (ProjectSocketName)
 	PollInterval      int                 `yaml:"poll_interval_seconds"`
 	ClaudeBinary      string              `yaml:"claude_binary"`
 	Providers         map[string]Provider `yaml:"providers"`
@@ -131,6 +297,48 @@ type Config struct {
 	SavedEnvVars      map[string]string   `yaml:"saved_env_vars,omitempty"`
 	LLMGatewayEnabled bool                `yaml:"llm_gateway_enabled,omitempty"`
 	MCPToolName       string              `yaml:"mcp_tool_name,omitempty"`
+	SessionLogs       SessionLogConfig    `yaml:"session_logs,omitempty"`
+	Notifications     NotificationConfig  `yaml:"notifications,omitempty"`
+	Heartbeat         HeartbeatConfig     `yaml:"heartbeat,omitempty"`
+	AutoDispatch      AutoDispatchConfig  `yaml:"auto_dispatch,omitempty"`
+	ResumeLastSession bool                `yaml:"resume_last_session,omitempty"` // attach to the last active session on start, like --resume
+	Plain             bool                `yaml:"plain,omitempty"`               // line-based TUI without alt screen or color, like --plain
+	AttachMode        string              `yaml:"attach_mode,omitempty"`         // inside tmux: "switch" (default), "split" or "window"
+	Editor            string              `yaml:"editor,omitempty"`              // opens a session's directory for `vibeflow open --editor` and the TUI; default $VISUAL, $EDITOR, then code
+	SessionCollision  string              `yaml:"session_collision,omitempty"`   // running tmux session with the launch's name: "ask" (default), "adopt", "kill", "suffix" or "fail"
+	Hooks             HooksConfig         `yaml:"hooks,omitempty"`
+	AutoPR            AutoPRConfig        `yaml:"auto_pr,omitempty"`
+	Permissions       PermissionsConfig   `yaml:"permissions,omitempty"`
+
+	// TrashRetentionHours is how long killed sessions stay restorable
+	// (default 24); a negative value turns the trash off.
+	TrashRetentionHours int `yaml:"trash_retention_hours,omitempty"`
+
+	// Profiles are named credential sets (profile name → profile) that let
+	// one provider run under several accounts.
+	Profiles map[string]CredentialProfile `yaml:"profiles,omitempty"`
+	// Timeouts kill or pause sessions left running too long or idle.
+	Timeouts TimeoutConfig `yaml:"timeouts,omitempty"`
+	// MaxRunningSessions caps concurrently running agents; launches past it
+	// are queued and started as slots free. 0 = no cap.
+	MaxRunningSessions int `yaml:"max_running_sessions,omitempty"`
+	// Keymap rebinds the session-list keys.
+	Keymap KeymapConfig `yaml:"keymap,omitempty"`
+	// Theme picks the TUI color palette.
+	Theme ThemeConfig `yaml:"theme,omitempty"`
+	// Auth picks static api_token or OAuth device-flow authentication.
+	Auth AuthConfig `yaml:"auth,omitempty"`
+	// Archive uploads ended sessions' summaries to the server.
+	Archive ArchiveConfig `yaml:"archive,omitempty"`
+	// AgentDocs templates the rules section written into agent docs.
+	AgentDocs AgentDocConfig `yaml:"agent_docs,omitempty"`
+	// WizardAnswers are the launch wizard's last selections per repository
+	// root, pre-selected the next time it runs there.
+	WizardAnswers map[string]WizardAnswers `yaml:"wizard_answers,omitempty"`
+
+	// configDir is the directory the config was loaded from; its secret
+	// store holds the OAuth login.
+	configDir string
 }
 
 // AddDirectoryToHistory adds a directory to the front of the history list,
@@ -184,7 +392,7 @@ func (c *Config) ResolveWorkDir(explicit string) string {
 }
 
 // DefaultConfig returns a Config with sensible defaults.
-// Three built-in providers are included; user config merges on top.
+// The built-in providers are included; user config merges on top.
 func DefaultConfig() *Config {
 	return &Config{
 		ServerURL:       "https://cloud.axiomstudio.ai",
@@ -261,6 +469,31 @@ func DefaultConfig() *Config {
 				SessionFile:        "",
 				Default:            false,
 			},
+			"aider": {
+				Name:   "Aider",
+				Binary: "aider",
+				// Aider loads no instruction file on its own; --read adds
+				// AGENTS.md to the chat read-only. Its API keys (ANTHROPIC_API_KEY,
+				// OPENAI_API_KEY, ...) come from the environment or .aider.conf.yml.
+				LaunchTemplate:     "{{.Binary}} --read AGENTS.md{{ if .SkipPermissions }} --yes-always{{ end }}{{ if .Model }} --model {{ shellQuote .Model }}{{ end }}",
+				PromptTemplate:     "",
+				Env:                map[string]string{},
+				VibeFlowIntegrated: false,
+				SessionFile:        "",
+				Default:            false,
+			},
+			"openhands": {
+				Name:   "OpenHands CLI",
+				Binary: "openhands",
+				// The model and API key are OpenHands settings (/settings in
+				// the CLI), so the template has no model flag.
+				LaunchTemplate:     "{{.Binary}}{{ if .SkipPermissions }} --always-approve{{ end }}",
+				PromptTemplate:     "",
+				Env:                map[string]string{},
+				VibeFlowIntegrated: false,
+				SessionFile:        "",
+				Default:            false,
+			},
 		},
 	}
 }
@@ -281,6 +514,7 @@ func LoadConfig(path string) (*Config, error) {
 	// a fresh custom root would inherit the "vibeflow" placeholder and lose its
 	// isolated socket.
 	cfg.TmuxSocket = ""
+	cfg.configDir = filepath.Dir(path)
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -294,6 +528,12 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
+	// Resolve secret references; plaintext secrets left by older versions are
+	// moved into the secret store by re-saving the config.
+	if resolveSecrets(cfg, newSecretStore(filepath.Dir(path))) {
+		_ = SaveConfig(cfg, path)
+	}
+
 	// Migrate built-in provider configs to current defaults.
 	migrateProviders(cfg, path)
 
@@ -359,12 +599,24 @@ func SaveConfig(cfg *Config, path string) error {
 		return fmt.Errorf("create config dir: %w", err)
 	}
 
-	data, err := yaml.Marshal(cfg)
+	// Secrets go to the secret store and the file only holds references. A
+	// secret the store rejects stays in plaintext rather than being lost; the
+	// next load retries the migration. The file being replaced tells which
+	// stored secrets are no longer referenced.
+	var previous *Config
+	if data, err := os.ReadFile(path); err == nil {
+		previous = &Config{}
+		if yaml.Unmarshal(data, previous) != nil {
+			previous = nil
+		}
+	}
+	onDisk, _ := withSecretRefs(cfg, previous, newSecretStore(filepath.Dir(path)))
+	data, err := yaml.Marshal(onDisk)
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return writeFileAtomic(path, data, 0600)
 }
 
 // ConfigFileExists reports whether the config file exists at the given path.
@@ -490,8 +742,8 @@ func ClearLLMGatewayEnv(providerKey string) map[string]string {
 // GatewayEnabledForProvider resolves whether LLM-gateway routing should be
 // active for a launch, given the explicit --llm-gateway flag, the saved config
 // setting, and the selected provider. Providers that connect directly to their
-// backend (see providerSupportsGateway — currently qwen and cursor) never route
-// through the gateway, even when routing is requested.
+// backend (see providerSupportsGateway — currently qwen, cursor, aider and
+// openhands) never route through the gateway, even when routing is requested.
 //
 // It mirrors the TUI wizard's gate (shouldShowGatewayStep), which never even
 // offers the gateway step for such providers, so the CLI flag path and the
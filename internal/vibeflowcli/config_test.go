@@ -46,11 +46,11 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("MCPToolName = %q, want %q", cfg.MCPToolName, DefaultMCPToolName)
 	}
 
-	// Five built-in providers.
-	if len(cfg.Providers) != 5 {
-		t.Fatalf("expected 5 providers, got %d", len(cfg.Providers))
+	// Seven built-in providers.
+	if len(cfg.Providers) != 7 {
+		t.Fatalf("expected 7 providers, got %d", len(cfg.Providers))
 	}
-	for _, key := range []string{"claude", "codex", "cursor", "gemini", "qwen"} {
+	for _, key := range []string{"aider", "claude", "codex", "cursor", "gemini", "openhands", "qwen"} {
 		if _, ok := cfg.Providers[key]; !ok {
 			t.Errorf("missing provider %q", key)
 		}
@@ -88,8 +88,8 @@ func TestLoadConfig_MissingFile(t *testing.T) {
 	if cfg.ServerURL != "https://cloud.axiomstudio.ai" {
 		t.Errorf("expected default ServerURL, got %q", cfg.ServerURL)
 	}
-	if len(cfg.Providers) != 5 {
-		t.Errorf("expected 5 default providers, got %d", len(cfg.Providers))
+	if len(cfg.Providers) != 7 {
+		t.Errorf("expected 7 default providers, got %d", len(cfg.Providers))
 	}
 }
 
@@ -197,6 +197,34 @@ func TestSaveConfig_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestSaveConfig_KeepsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.yaml")
+	if err := SaveConfig(DefaultConfig(), target); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.ServerURL = "https://through-link.com"
+	if err := SaveConfig(cfg, link); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config.yaml is no longer a symlink (err %v)", err)
+	}
+	loaded, err := LoadConfig(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ServerURL != "https://through-link.com" {
+		t.Errorf("target ServerURL = %q, want the saved one", loaded.ServerURL)
+	}
+}
+
 func TestConfigFileExists(t *testing.T) {
 	dir := t.TempDir()
 
@@ -19,6 +19,7 @@ package vibeflowcli
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -296,3 +297,79 @@ func TestParseSessionFile_WithPersonaLine(t *testing.T) {
 	// persona= is in the content but not returned by parseSessionFile
 	// (persona is determined by filename suffix)
 }
+
+func TestResolveLaunchConflict(t *testing.T) {
+	active := ConflictResult{Status: ActiveConflict, SessionID: "s1", TmuxSession: "vibeflow_claude-s1"}
+	stale := ConflictResult{Status: StaleConflict, SessionID: "s2"}
+	askWorktree := func(ConflictResult) (ConflictAction, error) { return ConflictWorktree, nil }
+
+	tests := []struct {
+		name     string
+		conflict ConflictResult
+		policy   string
+		ask      conflictPrompter
+		want     ConflictAction
+		wantErr  bool
+	}{
+		{"stale cleaned up by default", stale, "", nil, ConflictCleanup, false},
+		{"active fails when not interactive", active, "", nil, ConflictCancel, true},
+		{"active asks when interactive", active, "", askWorktree, ConflictWorktree, false},
+		{"explicit policy wins over asking", active, "switch", askWorktree, ConflictSwitch, false},
+		{"switch on stale falls back to cleanup", stale, "switch", nil, ConflictCleanup, false},
+		{"cleanup refuses a running session", active, "cleanup", nil, ConflictCancel, true},
+		{"fail applies to stale too", stale, "fail", nil, ConflictCancel, true},
+		{"worktree on stale", stale, "worktree", nil, ConflictWorktree, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveLaunchConflict(tt.conflict, tt.policy, tt.ask)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("resolveLaunchConflict() = %v, %v; want %v, err %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	if err := validateOnConflict("ignore"); err == nil {
+		t.Error("validateOnConflict(ignore) = nil, want an error")
+	}
+}
+
+func TestPromptConflict(t *testing.T) {
+	var out strings.Builder
+	ask := promptConflict(strings.NewReader("x\nw\n"), &out)
+	got, err := ask(ConflictResult{Status: ActiveConflict, SessionID: "s1", FilePath: "/repo/.vibeflow-session"})
+	if err != nil || got != ConflictWorktree {
+		t.Fatalf("ask() = %v, %v; want ConflictWorktree", got, err)
+	}
+	if !strings.Contains(out.String(), "[s] Switch to existing session") || strings.Count(out.String(), "Choice:") != 2 {
+		t.Errorf("prompt output = %q, want the modal's options and a re-prompt after the invalid answer", out.String())
+	}
+
+	got, _ = promptConflict(strings.NewReader(""), &out)(ConflictResult{Status: ActiveConflict})
+	if got != ConflictCancel {
+		t.Errorf("ask() at EOF = %v, want ConflictCancel", got)
+	}
+}
+
+func TestConflictModal_ShowsCoexistingPersonas(t *testing.T) {
+	dir := t.TempDir()
+	for persona, id := range map[string]string{"developer": "session-dev-1", "qa_lead": "session-qa-1"} {
+		if err := WriteSessionFile(dir, persona, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conflict := CheckConflict(dir, "developer", nil)
+	if conflict.Status == NoConflict {
+		t.Fatal("expected a conflict for the developer persona")
+	}
+	modal := NewConflictModal(conflict).WithCoexisting(CheckAllSessions(dir, nil))
+	if len(modal.coexisting) != 1 || modal.coexisting[0].Persona != "qa_lead" {
+		t.Fatalf("coexisting = %+v, want only the qa_lead session", modal.coexisting)
+	}
+	view := modal.View()
+	for _, want := range []string{"Persona:  developer", "Other personas in this directory", "qa_lead", "session-qa-1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// controlSocketName is the control socket's file name under the root dir.
+const controlSocketName = "control.sock"
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+// ControlSocketPath returns the default control socket path.
+func ControlSocketPath() string {
+	return filepath.Join(RootDir(), controlSocketName)
+}
+
+// rpcRequest is one JSON-RPC 2.0 request. A request without an id is a
+// notification and gets no response.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// invalidParams wraps a params problem as a JSON-RPC invalid params error.
+func invalidParams(format string, args ...any) error {
+	return &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// controlLaunch is the params of sessions.launch: a manifest session, with
+// the working directory required and the provider defaulting to the
+// config's.
+type controlLaunch struct {
+	Name            string `json:"name"`
+	Provider        string `json:"provider"`
+	WorkDir         string `json:"work_dir"`
+	SessionType     string `json:"session_type"`
+	Project         string `json:"project"`
+	Persona         string `json:"persona"`
+	Branch          string `json:"branch"`
+	Worktree        bool   `json:"worktree"`
+	Base            string `json:"base"`
+	Model           string `json:"model"`
+	SkipPermissions bool   `json:"skip_permissions"`
+}
+
+// controlServer answers JSON-RPC requests on the control socket with the
+// same operations the TUI offers. Calls that change sessions run one at a
+// time.
+type controlServer struct {
+	cfg      *Config
+	tmux     *TmuxManager
+	store    *Store
+	registry *ProviderRegistry
+	patterns *ErrorPatternRegistry
+	hooks    *HookRunner
+	cache    *SessionCache
+	activity *ActivityMonitor
+
+	mu sync.Mutex // serializes launch and kill
+}
+
+// call runs one method and returns its result.
+func (s *controlServer) call(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "sessions.list":
+		var p struct {
+			Lines int `json:"lines"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		d := &dashboard{tmux: s.tmux, store: s.store, activity: s.activity, outputLines: p.Lines}
+		sessions, err := d.sessions()
+		if sessions == nil {
+			sessions = []dashboardSession{}
+		}
+		return sessions, err
+	case "sessions.health":
+		var p struct {
+			Name string `json:"name"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.health(p.Name)
+	case "sessions.launch":
+		var p controlLaunch
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.launch(p)
+	case "sessions.kill":
+		var p struct {
+			Name            string `json:"name"`
+			CleanupWorktree bool   `json:"cleanup_worktree"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Name == "" {
+			return nil, invalidParams("name is required")
+		}
+		return s.kill(p.Name, p.CleanupWorktree)
+	case "sessions.send":
+		var p struct {
+			Name string `json:"name"`
+			Text string `json:"text"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Name == "" || p.Text == "" {
+			return nil, invalidParams("name and text are required")
+		}
+		if err := s.tmux.SendKeys(p.Name, p.Text); err != nil {
+			return nil, err
+		}
+		return map[string]string{"sent": p.Name}, nil
+	}
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("unknown method %q", method)}
+}
+
+// decodeParams unmarshals params into v; absent params leave v zero.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return invalidParams("params: %v", err)
+	}
+	return nil
+}
+
+// health returns the `vibeflow status` rows, or just name's.
+func (s *controlServer) health(name string) ([]statusRow, error) {
+	rows := []statusRow{}
+	if !s.tmux.ServerRunning() {
+		if name != "" {
+			return nil, fmt.Errorf("session %q is not running", name)
+		}
+		return rows, nil
+	}
+	completion, _ := newCompletionDetector(s.cfg.AutoPR, s.registry)
+	all, err := sessionStatusRows(s.tmux, s.store, s.patterns, completion)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return append(rows, all...), nil
+	}
+	short := strings.TrimPrefix(name, sessionPrefix)
+	for _, row := range all {
+		if row.Name == short {
+			return append(rows, row), nil
+		}
+	}
+	return nil, fmt.Errorf("session %q is not running", name)
+}
+
+// launch starts a session the way `vibeflow import` does.
+func (s *controlServer) launch(p controlLaunch) (map[string]string, error) {
+	if p.Name == "" || p.WorkDir == "" {
+		return nil, invalidParams("name and work_dir are required")
+	}
+	if p.SessionType != "" && p.SessionType != "vanilla" && p.SessionType != "vibeflow" {
+		return nil, invalidParams("session_type must be 'vanilla' or 'vibeflow'")
+	}
+	if p.Worktree && p.Branch == "" {
+		return nil, invalidParams("worktree needs a branch")
+	}
+	ms := ManifestSession{
+		Name:            p.Name,
+		Provider:        firstNonEmpty(p.Provider, s.cfg.DefaultProvider, "claude"),
+		SessionType:     p.SessionType,
+		Project:         p.Project,
+		Persona:         p.Persona,
+		Branch:          p.Branch,
+		WorkDir:         p.WorkDir,
+		Worktree:        p.Worktree,
+		Base:            p.Base,
+		Model:           p.Model,
+		SkipPermissions: p.SkipPermissions,
+	}
+	if ms.SessionType == "" {
+		ms.SessionType = "vanilla"
+		if ms.Persona != "" {
+			ms.SessionType = "vibeflow"
+		}
+	}
+	if _, ok := s.registry.Get(ms.Provider); !ok {
+		return nil, invalidParams("unknown provider %q", ms.Provider)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tmux.HasSession(s.tmux.FullSessionName(ms.Provider, ms.Name)) {
+		return nil, fmt.Errorf("session %q is already running", ms.Name)
+	}
+	home, _ := os.UserHomeDir()
+	meta, err := importSession(ms, s.cfg, s.tmux, s.store, s.cache, s.registry, home)
+	if err != nil {
+		return nil, err
+	}
+	s.hooks.Fire(HookSessionCreate, meta, nil)
+	return map[string]string{"name": meta.Name, "tmux_session": meta.TmuxSession, "working_dir": meta.WorkingDir}, nil
+}
+
+// kill tears a session down the way `vibeflow shutdown` does, without
+// the wrap-up.
+func (s *controlServer) kill(name string, cleanupWorktree bool) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	full := s.tmux.ensurePrefix(name)
+	if !s.tmux.HasSession(full) {
+		return nil, fmt.Errorf("session %q is not running", name)
+	}
+	if err := teardownSession(s.cfg, s.tmux, s.store, s.hooks, s.cache, full, cleanupWorktree); err != nil {
+		return nil, err
+	}
+	return map[string]string{"killed": name}, nil
+}
+
+// handle answers one request; it returns nil for notifications.
+func (s *controlServer) handle(line []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: err.Error()}}
+	}
+	resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if len(req.ID) == 0 {
+		resp.ID = json.RawMessage("null")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: rpcInvalidRequest, Message: `want "jsonrpc": "2.0" and a method`}
+		return resp
+	}
+	result, err := s.call(req.Method, req.Params)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: rpcServerError, Message: err.Error()}
+		}
+		resp.Error = rerr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+// serveConn answers newline-delimited requests on conn until it closes.
+func (s *controlServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if resp := s.handle(scanner.Bytes()); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// listenControl listens on the unix socket at path, readable only by the
+// user. A socket left behind by a server that's gone is replaced; one
+// still answering is an error.
+func listenControl(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a control server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// --- control ---
+
+func controlCmd() *cobra.Command {
+	var socket string
+	cmd := &cobra.Command{
+		Use:   "control",
+		Short: "Serve a JSON-RPC control API on a local unix socket",
+		Long: `Listen on a unix socket (default <root>/control.sock, readable only by you)
+for JSON-RPC 2.0 requests, one JSON object per line, so editors and scripts
+can drive vibeflow without scraping the TUI. Methods:
+
+  sessions.list    {"lines": N}           sessions with status, and N lines of output
+  sessions.health  {"name": "..."}        health as in 'vibeflow status'
+  sessions.launch  {"name", "work_dir", "provider", "persona", ...}
+  sessions.kill    {"name", "cleanup_worktree"}
+  sessions.send    {"name", "text"}       type text into the session and press Enter`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			if socket == "" {
+				socket = ControlSocketPath()
+			}
+			ln, err := listenControl(socket)
+			if err != nil {
+				return err
+			}
+			defer os.Remove(socket)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
+			s := &controlServer{
+				cfg:      cfg,
+				tmux:     tmux,
+				store:    store,
+				registry: registry,
+				patterns: NewErrorPatternRegistryWithFile(DefaultErrorPatternsPath()),
+				hooks:    hooks,
+				cache:    NewSessionCache(),
+				activity: NewActivityMonitor(0),
+			}
+			d := &dashboard{tmux: tmux, store: store, activity: s.activity}
+			go d.sampleLoop(ctx)
+			go func() {
+				<-ctx.Done()
+				ln.Close()
+			}()
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Control API on %s (Ctrl+C to stop)\n", socket)
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+						return nil
+					}
+					return err
+				}
+				go s.serveConn(conn)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&socket, "socket", "", "Socket path (default: <root>/control.sock)")
+	return cmd
+}
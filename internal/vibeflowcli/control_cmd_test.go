@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestControlServer_Errors(t *testing.T) {
+	s := &controlServer{cfg: &Config{}, registry: NewProviderRegistry(&Config{})}
+	tests := []struct {
+		name, req string
+		code      int
+	}{
+		{"parse", `{"jsonrpc":`, rpcParseError},
+		{"version", `{"id":1,"method":"sessions.list"}`, rpcInvalidRequest},
+		{"method", `{"jsonrpc":"2.0","id":1,"method":"sessions.rename"}`, rpcMethodNotFound},
+		{"params type", `{"jsonrpc":"2.0","id":1,"method":"sessions.list","params":{"lines":"many"}}`, rpcInvalidParams},
+		{"kill without name", `{"jsonrpc":"2.0","id":1,"method":"sessions.kill"}`, rpcInvalidParams},
+		{"send without text", `{"jsonrpc":"2.0","id":1,"method":"sessions.send","params":{"name":"claude-a"}}`, rpcInvalidParams},
+		{"launch without dir", `{"jsonrpc":"2.0","id":1,"method":"sessions.launch","params":{"name":"a"}}`, rpcInvalidParams},
+		{"launch bad provider", `{"jsonrpc":"2.0","id":1,"method":"sessions.launch","params":{"name":"a","work_dir":"/tmp","provider":"nope"}}`, rpcInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := s.handle([]byte(tt.req))
+			if resp == nil || resp.Error == nil || resp.Error.Code != tt.code {
+				t.Fatalf("response = %+v, want error code %d", resp, tt.code)
+			}
+		})
+	}
+	// Notifications get no response, even when they fail.
+	if resp := s.handle([]byte(`{"jsonrpc":"2.0","method":"sessions.rename"}`)); resp != nil {
+		t.Errorf("notification answered: %+v", resp)
+	}
+}
+
+// controlCall sends one request over conn and decodes the response.
+func controlCall(t *testing.T, conn net.Conn, r *bufio.Reader, req string) rpcResponse {
+	t.Helper()
+	if _, err := conn.Write([]byte(req + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		t.Fatalf("%s: %v", line, err)
+	}
+	return resp
+}
+
+func TestControlServer_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("VIBEFLOW_ROOT", t.TempDir())
+	tm := NewTmuxManager("vftest-control")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-ctl", "cat"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	addLaunchedSession(t, store, SessionMeta{Name: "ctl", Provider: "claude", Persona: "developer"})
+	s := &controlServer{
+		cfg:      &Config{},
+		tmux:     tm,
+		store:    store,
+		registry: NewProviderRegistry(&Config{}),
+		patterns: NewErrorPatternRegistry(),
+		hooks:    NewHookRunner(HooksConfig{}, nil, stderrWarnf),
+		cache:    NewSessionCacheWithPath(filepath.Join(t.TempDir(), "cache.json")),
+		activity: NewActivityMonitor(0),
+	}
+
+	socket := filepath.Join(t.TempDir(), controlSocketName)
+	// A socket nobody listens on any more is replaced.
+	stale, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+	ln, err := listenControl(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	if info, err := os.Stat(socket); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("socket mode = %v (err %v), want 0600", info.Mode().Perm(), err)
+	}
+	if _, err := listenControl(socket); err == nil || !strings.Contains(err.Error(), "already listening") {
+		t.Errorf("second listen err = %v, want already listening", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serveConn(conn)
+		}
+	}()
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	resp := controlCall(t, conn, r, `{"jsonrpc":"2.0","id":1,"method":"sessions.send","params":{"name":"claude-ctl","text":"hello from the editor"}}`)
+	if resp.Error != nil || string(resp.ID) != "1" {
+		t.Fatalf("send: %+v", resp)
+	}
+	waitForPane(t, tm, "claude-ctl", "hello from the editor")
+
+	resp = controlCall(t, conn, r, `{"jsonrpc":"2.0","id":"list","method":"sessions.list","params":{"lines":5}}`)
+	var sessions []dashboardSession
+	data, _ := json.Marshal(resp.Result)
+	if err := json.Unmarshal(data, &sessions); err != nil || len(sessions) != 1 {
+		t.Fatalf("list = %s (err %v)", data, err)
+	}
+	if got := sessions[0]; got.Name != "claude-ctl" || got.Persona != "developer" || !strings.Contains(got.Output, "hello from the editor") {
+		t.Errorf("list = %+v", got)
+	}
+
+	resp = controlCall(t, conn, r, `{"jsonrpc":"2.0","id":2,"method":"sessions.health","params":{"name":"claude-ctl"}}`)
+	if data, _ := json.Marshal(resp.Result); !strings.Contains(string(data), `"health":"healthy"`) {
+		t.Errorf("health = %s, error %+v", data, resp.Error)
+	}
+
+	resp = controlCall(t, conn, r, `{"jsonrpc":"2.0","id":3,"method":"sessions.kill","params":{"name":"claude-ctl"}}`)
+	if resp.Error != nil {
+		t.Fatalf("kill: %+v", resp.Error)
+	}
+	if tm.HasSession("claude-ctl") {
+		t.Error("session still running after kill")
+	}
+	if _, found, _ := store.Get("ctl"); found {
+		t.Error("store entry kept after kill")
+	}
+	resp = controlCall(t, conn, r, `{"jsonrpc":"2.0","id":4,"method":"sessions.kill","params":{"name":"claude-ctl"}}`)
+	if resp.Error == nil || resp.Error.Code != rpcServerError || !strings.Contains(resp.Error.Message, "not running") {
+		t.Errorf("second kill: %+v", resp)
+	}
+}
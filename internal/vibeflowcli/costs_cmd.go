@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// projectUsage is one row of the per-project totals.
+type projectUsage struct {
+	Project  string
+	Sessions int
+	Usage    TokenUsage
+}
+
+// usageByProject sums usage per project, most expensive first. Sessions
+// without a project are grouped under "(none)".
+func usageByProject(entries []HistoryEntry) []projectUsage {
+	byName := make(map[string]*projectUsage)
+	for _, e := range entries {
+		if e.Usage == nil {
+			continue
+		}
+		name := e.Project
+		if name == "" {
+			name = "(none)"
+		}
+		p := byName[name]
+		if p == nil {
+			p = &projectUsage{Project: name}
+			byName[name] = p
+		}
+		p.Sessions++
+		p.Usage = p.Usage.Add(*e.Usage)
+	}
+	out := make([]projectUsage, 0, len(byName))
+	for _, p := range byName {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Usage.CostUSD != out[j].Usage.CostUSD {
+			return out[i].Usage.CostUSD > out[j].Usage.CostUSD
+		}
+		if out[i].Usage.Tokens() != out[j].Usage.Tokens() {
+			return out[i].Usage.Tokens() > out[j].Usage.Tokens()
+		}
+		return out[i].Project < out[j].Project
+	})
+	return out
+}
+
+// printCosts writes the `vibeflow costs` report: one row per session run,
+// then the totals per project.
+func printCosts(out io.Writer, entries []HistoryEntry) {
+	fmt.Fprintf(out, "%-16s %-24s %-8s %-20s %8s %9s\n", "STARTED", "NAME", "PROVIDER", "PROJECT", "TOKENS", "COST")
+	fmt.Fprintln(out, strings.Repeat("-", 90))
+	for _, e := range entries {
+		fmt.Fprintf(out, "%-16s %-24s %-8s %-20s %8s %9s\n",
+			e.CreatedAt.Local().Format("2006-01-02 15:04"), truncate(e.Name, 24), truncate(e.Provider, 8),
+			truncate(e.Project, 20), formatTokens(e.Usage.Tokens()), formatCost(*e.Usage))
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%-30s %8s %8s %9s\n", "PROJECT", "SESSIONS", "TOKENS", "COST")
+	fmt.Fprintln(out, strings.Repeat("-", 58))
+	var total TokenUsage
+	for _, p := range usageByProject(entries) {
+		fmt.Fprintf(out, "%-30s %8d %8s %9s\n", truncate(p.Project, 30), p.Sessions, formatTokens(p.Usage.Tokens()), formatCost(p.Usage))
+		total = total.Add(p.Usage)
+	}
+	fmt.Fprintf(out, "%-30s %8d %8s %9s\n", "TOTAL", len(entries), formatTokens(total.Tokens()), formatCost(total))
+}
+
+// --- costs ---
+
+func costsCmd() *cobra.Command {
+	var (
+		f     historyFilter
+		since string
+	)
+	cmd := &cobra.Command{
+		Use:   "costs",
+		Short: "Show token usage and cost per session and per project",
+		Long: `Show the token usage and cost of each session, read from the summary the
+agent prints (Claude Code's cost summary on exit or /cost, codex's token
+usage line on exit), with totals per project. Live sessions are reread first;
+ended sessions keep the last summary seen while they ran. Costs marked "~"
+are estimated from the provider's input_price_per_mtok and
+output_price_per_mtok.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			cutoff, err := parseSince(since, now)
+			if err != nil {
+				return err
+			}
+			f.Since = cutoff
+			cfgPath, _ := cmd.Flags().GetString("config")
+			_, tmux, store, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			if _, err := recordUsage(store, tmux, registry); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+			}
+			entries, err := store.History().List()
+			if err != nil {
+				return err
+			}
+			var matched []HistoryEntry
+			for _, e := range filterHistory(entries, f, 0) {
+				if e.Usage != nil {
+					matched = append(matched, e)
+				}
+			}
+			if len(matched) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No token usage recorded.")
+				return nil
+			}
+			printCosts(cmd.OutOrStdout(), matched)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&f.Provider, "provider", "", "Only sessions of this provider")
+	cmd.Flags().StringVar(&f.Project, "project", "", "Only sessions whose project contains this text")
+	cmd.Flags().StringVar(&since, "since", "", "Only sessions started within this period (e.g. 12h, 7d)")
+	return cmd
+}
@@ -0,0 +1,323 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue replaces secrets in a debug bundle.
+const redactedValue = "<redacted>"
+
+// debugFile is one file of a debug bundle.
+type debugFile struct {
+	name string
+	data []byte
+}
+
+// redactConfig returns a copy of cfg that is safe to share: the API token,
+// saved env vars, profile and provider env values and the webhook URL are
+// replaced by redactedValue, and key flags in launch templates are masked.
+// Like redactSpawnArg it is deny-by-default for env values, since a provider
+// token can sit under any name.
+func redactConfig(cfg *Config) *Config {
+	out := *cfg
+	redact := func(v string) string {
+		if v == "" {
+			return v
+		}
+		return redactedValue
+	}
+	redactEnv := func(env map[string]string) map[string]string {
+		if env == nil {
+			return nil
+		}
+		m := make(map[string]string, len(env))
+		for k, v := range env {
+			m[k] = redact(v)
+		}
+		return m
+	}
+	out.APIToken = redact(cfg.APIToken)
+	out.SavedEnvVars = redactEnv(cfg.SavedEnvVars)
+	out.Notifications.WebhookURL = redact(cfg.Notifications.WebhookURL)
+	if cfg.Profiles != nil {
+		out.Profiles = make(map[string]CredentialProfile, len(cfg.Profiles))
+		for name, p := range cfg.Profiles {
+			p.Env = redactEnv(p.Env)
+			out.Profiles[name] = p
+		}
+	}
+	if cfg.Providers != nil {
+		out.Providers = make(map[string]Provider, len(cfg.Providers))
+		for key, p := range cfg.Providers {
+			p.Env = redactEnv(p.Env)
+			p.LaunchTemplate = redactCommandSecrets(p.LaunchTemplate)
+			out.Providers[key] = p
+		}
+	}
+	return &out
+}
+
+// secretAssignRE matches a KEY=value assignment in free text.
+var secretAssignRE = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)=("[^"]*"|'[^']*'|\S+)`)
+
+// textRedactor masks secrets in log lines and pane captures: assignments to
+// known secret env vars, API-key flags, and any literal secret value from
+// the config.
+type textRedactor struct {
+	values []string
+}
+
+// newTextRedactor collects cfg's secret values. Short values are skipped:
+// replacing every "1" or "true" in the output would hide more than it
+// protects.
+func newTextRedactor(cfg *Config) textRedactor {
+	var r textRedactor
+	add := func(v string) {
+		if len(v) >= 8 {
+			r.values = append(r.values, v)
+		}
+	}
+	add(cfg.APIToken)
+	add(cfg.Notifications.WebhookURL)
+	for _, v := range cfg.SavedEnvVars {
+		add(v)
+	}
+	for _, p := range cfg.Profiles {
+		for _, v := range p.Env {
+			add(v)
+		}
+	}
+	for _, p := range cfg.Providers {
+		for _, v := range p.Env {
+			add(v)
+		}
+	}
+	// Longest first, so a secret containing another is replaced whole.
+	sort.Slice(r.values, func(i, j int) bool { return len(r.values[i]) > len(r.values[j]) })
+	return r
+}
+
+// Redact returns s with its secrets masked.
+func (r textRedactor) Redact(s string) string {
+	for _, v := range r.values {
+		s = strings.ReplaceAll(s, v, redactedValue)
+	}
+	s = secretAssignRE.ReplaceAllStringFunc(s, func(m string) string {
+		if key, _, _ := strings.Cut(m, "="); isSecretEnvKey(key) {
+			return key + "=" + redactedValue
+		}
+		return m
+	})
+	return redactCommandSecrets(s)
+}
+
+// debugBundleOpts selects what goes into a debug bundle.
+type debugBundleOpts struct {
+	sessions  []string // sessions whose panes are captured
+	logLines  int      // trailing lines of vibeflow-cli.log
+	paneLines int      // lines captured per pane
+}
+
+// collectDebugBundle gathers the bundle's files. A part that can't be read
+// is still included, holding the error, so the bundle shows what was
+// missing rather than silently leaving it out.
+func collectDebugBundle(cfg *Config, tm *TmuxManager, store *Store, opts debugBundleOpts, now time.Time) []debugFile {
+	r := newTextRedactor(cfg)
+	var files []debugFile
+	add := func(name string, data []byte, err error) {
+		if err != nil {
+			data = fmt.Appendf(data, "\nerror: %v\n", err)
+		}
+		files = append(files, debugFile{name: name, data: data})
+	}
+
+	var info bytes.Buffer
+	fmt.Fprintf(&info, "vibeflow %s (commit %s, built %s)\n", buildVersion, buildCommit, buildDate)
+	fmt.Fprintf(&info, "go:       %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&info, "root:     %s\n", RootDir())
+	fmt.Fprintf(&info, "created:  %s\n", now.UTC().Format(time.RFC3339))
+	add("info.txt", info.Bytes(), nil)
+
+	data, err := yaml.Marshal(redactConfig(cfg))
+	add("config.yaml", data, err)
+
+	metas, err := store.List()
+	if err == nil {
+		data, err = json.MarshalIndent(metas, "", "  ")
+	}
+	add("store.json", data, err)
+
+	var log bytes.Buffer
+	f, err := os.Open(filepath.Join(RootDir(), "vibeflow-cli.log"))
+	if err == nil {
+		err = printTail(&log, f, opts.logLines)
+		f.Close()
+	}
+	add("vibeflow-cli.log", []byte(r.Redact(log.String())), err)
+
+	add("tmux.txt", []byte(r.Redact(tmuxServerInfo(tm))), nil)
+
+	for _, name := range opts.sessions {
+		out, err := tm.CapturePaneOutput(name, opts.paneLines)
+		add("panes/"+sanitizeBundleName(name)+".txt", []byte(r.Redact(stripANSI(out))), err)
+	}
+	return files
+}
+
+// tmuxServerInfo describes the tmux server vibeflow's sessions live on.
+func tmuxServerInfo(tm *TmuxManager) string {
+	var b strings.Builder
+	version, err := tm.run("-V")
+	if err != nil {
+		version = "unknown (" + err.Error() + ")"
+	}
+	fmt.Fprintf(&b, "version:  %s\n", strings.TrimSpace(version))
+	fmt.Fprintf(&b, "socket:   %s\n", tm.socketName)
+	running := tm.ServerRunning()
+	fmt.Fprintf(&b, "running:  %t\n", running)
+	if !running {
+		return b.String()
+	}
+	sessions, err := tm.ListSessions()
+	if err != nil {
+		fmt.Fprintf(&b, "sessions: error: %v\n", err)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "sessions: %d\n", len(sessions))
+	for _, s := range sessions {
+		state := "running"
+		if s.PaneDead {
+			state = "exited " + s.ExitStatus
+		}
+		fmt.Fprintf(&b, "  %-32s %s windows=%d attached=%t created=%s\n", s.Name, state, s.Windows, s.Attached, s.CreatedAt)
+	}
+	return b.String()
+}
+
+// sanitizeBundleName keeps a session name usable as a file name.
+func sanitizeBundleName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// writeDebugBundle writes files as a gzipped tarball under dir/.
+func writeDebugBundle(w io.Writer, dir string, files []debugFile, now time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    dir + "/" + f.name,
+			Mode:    0600,
+			Size:    int64(len(f.data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// --- debug ---
+
+func debugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Support and diagnostic tools",
+	}
+	cmd.AddCommand(debugBundleCmd())
+	return cmd
+}
+
+func debugBundleCmd() *cobra.Command {
+	var (
+		output string
+		opts   debugBundleOpts
+	)
+	cmd := &cobra.Command{
+		Use:   "bundle [session-name...]",
+		Short: "Collect a redacted diagnostic bundle to attach to bug reports",
+		Long: `Write a .tar.gz with what's needed to diagnose a problem: version and
+platform, the config with secrets stripped, the session store, the tail of
+vibeflow-cli.log, the tmux server's version and sessions, and a capture of
+each named session's pane. Known secrets are masked in logs and captures
+too, but pane output is whatever the agent printed, so look it over before
+attaching the bundle anywhere public.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			for _, name := range args {
+				if !tmux.HasSession(name) {
+					return fmt.Errorf("session %q not found in tmux", name)
+				}
+			}
+			opts.sessions = args
+			now := time.Now()
+			dir := "vibeflow-debug-" + now.Format("20060102-150405")
+			if output == "" {
+				output = dir + ".tar.gz"
+			}
+			f, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			if err := writeDebugBundle(f, dir, collectDebugBundle(cfg, tmux, store, opts, now), now); err != nil {
+				f.Close()
+				return fmt.Errorf("write bundle: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\nReview the pane captures before sharing it.\n", output)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Bundle path (default vibeflow-debug-<timestamp>.tar.gz)")
+	cmd.Flags().IntVar(&opts.logLines, "log-lines", 500, "Trailing lines of vibeflow-cli.log to include (0 = whole file)")
+	cmd.Flags().IntVar(&opts.paneLines, "pane-lines", 200, "Lines of pane output to capture per session")
+	return cmd
+}
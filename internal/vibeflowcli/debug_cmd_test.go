@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRedactConfig(t *testing.T) {
+	cfg := &Config{
+		ServerURL:     "https://vf.example.com",
+		APIToken:      "vf-token-123456",
+		SavedEnvVars:  map[string]string{"OPENAI_API_KEY": "sk-abcdef123456"},
+		Notifications: NotificationConfig{WebhookURL: "https://hooks.slack.com/services/T0/B0/xyz"},
+		Profiles:      map[string]CredentialProfile{"work": {Provider: "claude", Env: map[string]string{"ANTHROPIC_API_KEY": "sk-ant-123456"}}},
+		Providers: map[string]Provider{"custom": {
+			Binary:         "custom",
+			LaunchTemplate: "{{.Binary}} --openai-api-key sk-inline-123456",
+			Env:            map[string]string{"BEARER": "bearer-123456"},
+		}},
+	}
+	data, err := yaml.Marshal(redactConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, secret := range []string{"vf-token-123456", "sk-abcdef123456", "hooks.slack.com", "sk-ant-123456", "sk-inline-123456", "bearer-123456"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("redacted config still holds %q:\n%s", secret, out)
+		}
+	}
+	for _, kept := range []string{"https://vf.example.com", "OPENAI_API_KEY", "BEARER", "--openai-api-key <redacted>"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("redacted config lost %q:\n%s", kept, out)
+		}
+	}
+	if cfg.APIToken != "vf-token-123456" || cfg.Providers["custom"].Env["BEARER"] != "bearer-123456" || cfg.Profiles["work"].Env["ANTHROPIC_API_KEY"] != "sk-ant-123456" {
+		t.Error("redactConfig modified the original config")
+	}
+}
+
+func TestTextRedactor(t *testing.T) {
+	r := newTextRedactor(&Config{APIToken: "vf-token-123456", SavedEnvVars: map[string]string{"FLAG": "1"}})
+	in := "auth vf-token-123456 ok\nexport ANTHROPIC_API_KEY=sk-ant-xyz FLAG=1 PATH=/bin\nrun --openai-api-key 'sk-q'"
+	got := r.Redact(in)
+	want := "auth <redacted> ok\nexport ANTHROPIC_API_KEY=<redacted> FLAG=1 PATH=/bin\nrun --openai-api-key <redacted>"
+	if got != want {
+		t.Errorf("Redact =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteDebugBundle(t *testing.T) {
+	var buf bytes.Buffer
+	files := []debugFile{{name: "info.txt", data: []byte("hello")}, {name: "panes/a.txt", data: []byte("pane")}}
+	if err := writeDebugBundle(&buf, "vibeflow-debug-x", files, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	got := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(tr)
+		got[hdr.Name] = string(data)
+	}
+	if got["vibeflow-debug-x/info.txt"] != "hello" || got["vibeflow-debug-x/panes/a.txt"] != "pane" || len(got) != 2 {
+		t.Errorf("bundle contents = %v", got)
+	}
+}
+
+func TestSanitizeBundleName(t *testing.T) {
+	if got := sanitizeBundleName("vibeflow_feat/x:1"); got != "vibeflow_feat_x_1" {
+		t.Errorf("sanitizeBundleName = %q", got)
+	}
+}
+
+func TestCollectDebugBundle_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("VIBEFLOW_ROOT", t.TempDir())
+	tm := NewTmuxManager("vftest-debug")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-dbg",
+		`sh -c 'echo "ANTHROPIC_API_KEY=sk-ant-leak vf-token-123456"; sleep 30'`); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	waitForPane(t, tm, "claude-dbg", "ANTHROPIC_API_KEY")
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	if err := store.Add(SessionMeta{Name: "claude-dbg", Provider: "claude"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{APIToken: "vf-token-123456"}
+	files := collectDebugBundle(cfg, tm, store, debugBundleOpts{sessions: []string{"claude-dbg"}, logLines: 10, paneLines: 20}, time.Now())
+	got := map[string]string{}
+	for _, f := range files {
+		got[f.name] = string(f.data)
+	}
+	for _, name := range []string{"info.txt", "config.yaml", "store.json", "vibeflow-cli.log", "tmux.txt", "panes/claude-dbg.txt"} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("bundle lacks %s (has %v)", name, got)
+		}
+	}
+	if !strings.Contains(got["store.json"], `"claude-dbg"`) {
+		t.Errorf("store.json = %s", got["store.json"])
+	}
+	if !strings.Contains(got["tmux.txt"], "vibeflow_claude-dbg") {
+		t.Errorf("tmux.txt = %s", got["tmux.txt"])
+	}
+	// The log doesn't exist under the fresh root; the bundle says so.
+	if !strings.Contains(got["vibeflow-cli.log"], "error:") {
+		t.Errorf("vibeflow-cli.log = %q, want the open error", got["vibeflow-cli.log"])
+	}
+	pane := got["panes/claude-dbg.txt"]
+	if strings.Contains(pane, "sk-ant-leak") || strings.Contains(pane, "vf-token-123456") || !strings.Contains(pane, "ANTHROPIC_API_KEY=<redacted>") {
+		t.Errorf("pane capture not redacted: %q", pane)
+	}
+}
@@ -90,7 +90,7 @@ func RunCloudDispatch(ctx context.Context, cfgPath, sessionName string) error {
 		meta.ProjectID = project.ID
 		_ = store.Add(meta)
 	}
-	client := NewClient(cfg.ServerURL, cfg.APIToken)
+	client := newAPIClient(cfg)
 	leaseOwner := "vibeflow-cli:" + meta.VibeFlowSessionID
 	req := DispatchNextRequest{
 		SessionID:       meta.VibeFlowSessionID,
@@ -131,7 +131,7 @@ func ensureCloudDispatchProject(cfg *Config, projectName string) (*Project, erro
 	if projectName == "" {
 		projectName = "Default"
 	}
-	client := NewClient(cfg.ServerURL, cfg.APIToken)
+	client := newAPIClient(cfg)
 	projects, err := client.ListProjects()
 	if err != nil {
 		return nil, err
@@ -150,8 +150,12 @@ func runDispatchWebSocket(ctx context.Context, cfg *Config, client *Client, tmux
 		return err
 	}
 	header := http.Header{}
-	if cfg.APIToken != "" {
-		header.Set("Authorization", "Bearer "+cfg.APIToken)
+	token, err := client.bearer(false)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
 	}
 	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
 	if err != nil {
@@ -193,7 +197,7 @@ func dispatchWebSocketURL(base string) (string, error) {
 	return u.String(), nil
 }
 
-func pollDispatchOnce(ctx context.Context, client *Client, tmux *TmuxManager, meta SessionMeta, req DispatchNextRequest, logger *Logger) error {
+func pollDispatchOnce(ctx context.Context, client VibeflowAPI, tmux *TmuxManager, meta SessionMeta, req DispatchNextRequest, logger *Logger) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -209,7 +213,7 @@ func pollDispatchOnce(ctx context.Context, client *Client, tmux *TmuxManager, me
 	return nil
 }
 
-func handleDispatchItem(client *Client, tmux *TmuxManager, meta SessionMeta, leaseOwner string, item *DispatchQueueItem, logger *Logger) error {
+func handleDispatchItem(client VibeflowAPI, tmux *TmuxManager, meta SessionMeta, leaseOwner string, item *DispatchQueueItem, logger *Logger) error {
 	prompt := formatDispatchPrompt(item)
 	if err := tmux.SendKeys(meta.TmuxSession, prompt); err != nil {
 		_ = client.DispatchNack(item.ID, leaseOwner, err.Error())
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultCompletionMarker matches a line of pane output that reads just
+// VIBEFLOW_DONE, give or take the bullets and quotes agents wrap replies in.
+// Requiring a line of its own keeps a prompt that merely mentions the marker
+// ("print VIBEFLOW_DONE when finished") from counting as completion.
+const defaultCompletionMarker = `(?m)^\W*VIBEFLOW_DONE\W*$`
+
+// defaultProviderMarkers are built-in providers' own completion markers,
+// used when their config sets none. They apply on top of the shared marker.
+var defaultProviderMarkers = map[string]string{
+	"claude": `(?mi)^\W*task complete\W*$`,
+}
+
+// defaultDoneOnExit lists built-in providers whose agent exits once its task
+// is done, so a clean exit counts as completion.
+var defaultDoneOnExit = map[string]bool{
+	"codex": true,
+}
+
+// finalMessageLines caps how much of the agent's last output a summary keeps.
+const finalMessageLines = 8
+
+// completionDetector decides when a session's agent has finished, from its
+// pane output, its exit or the status the server reports for it.
+type completionDetector struct {
+	marker    *regexp.Regexp
+	providers map[string]*regexp.Regexp // provider key → its own marker
+	onExit    map[string]bool           // provider key → a clean exit means done
+	statuses  map[string]bool
+}
+
+// newCompletionDetector compiles the shared marker (auto_pr.completion_marker
+// or VIBEFLOW_DONE) and each provider's own marker from registry.
+func newCompletionDetector(cfg AutoPRConfig, registry *ProviderRegistry) (*completionDetector, error) {
+	pattern := cfg.CompletionMarker
+	if pattern == "" {
+		pattern = defaultCompletionMarker
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("auto_pr.completion_marker: %w", err)
+	}
+	d := &completionDetector{
+		marker:    re,
+		providers: make(map[string]*regexp.Regexp),
+		onExit:    make(map[string]bool),
+		statuses:  make(map[string]bool),
+	}
+	for _, s := range cfg.ServerStatuses {
+		d.statuses[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+	var keys []string
+	if registry != nil {
+		keys = registry.Keys()
+	}
+	for _, key := range keys {
+		p, _ := registry.Get(key)
+		marker := p.CompletionMarker
+		if marker == "" {
+			marker = defaultProviderMarkers[key]
+		}
+		if marker != "" {
+			re, err := regexp.Compile(marker)
+			if err != nil {
+				return nil, fmt.Errorf("providers.%s.completion_marker: %w", key, err)
+			}
+			d.providers[key] = re
+		}
+		d.onExit[key] = p.DoneOnExit || defaultDoneOnExit[key]
+	}
+	return d, nil
+}
+
+// OutputDone reports whether output from a provider's agent contains a
+// completion marker.
+func (d *completionDetector) OutputDone(provider, output string) bool {
+	return d.markerAt(provider, output) >= 0
+}
+
+// markerAt returns where the last completion marker in output starts, or -1.
+func (d *completionDetector) markerAt(provider, output string) int {
+	if d == nil {
+		return -1
+	}
+	at := -1
+	for _, re := range []*regexp.Regexp{d.marker, d.providers[provider]} {
+		if re == nil {
+			continue
+		}
+		if locs := re.FindAllStringIndex(output, -1); len(locs) > 0 {
+			at = max(at, locs[len(locs)-1][0])
+		}
+	}
+	return at
+}
+
+// ExitDone reports whether a provider's agent exiting with exitStatus means
+// it finished its task.
+func (d *completionDetector) ExitDone(provider, exitStatus string) bool {
+	return d != nil && exitStatus == "0" && d.onExit[provider]
+}
+
+// StatusDone reports whether the server's status or phase for a session is
+// one of the configured completion values.
+func (d *completionDetector) StatusDone(st SessionStatus) bool {
+	if d == nil {
+		return false
+	}
+	return d.statuses[strings.ToLower(st.Status)] || d.statuses[strings.ToLower(st.Phase)]
+}
+
+// FinalMessage returns the agent's last lines of output before its
+// completion marker, or the last lines of output when there is none.
+func (d *completionDetector) FinalMessage(provider, output string) string {
+	if at := d.markerAt(provider, output); at >= 0 {
+		output = output[:at]
+	}
+	lines := strings.Split(strings.TrimRight(output, " \t\n"), "\n")
+	lines = lines[max(len(lines)-finalMessageLines, 0):]
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// DoneSummary is what a finished session produced, for the summary view.
+type DoneSummary struct {
+	Duration     time.Duration // from launch (or last relaunch) until done
+	Git          bool          // the session's directory is a git checkout
+	DiffStat     string        // `git diff --stat` since launch, uncommitted work included
+	Commits      []string      // commits made since launch, newest first
+	FinalMessage string        // the agent's last output before it signalled completion
+}
+
+// loadDoneSummary builds meta's summary from its working directory and the
+// agent's recent pane output.
+func loadDoneSummary(meta SessionMeta, output string, d *completionDetector, now time.Time) DoneSummary {
+	end := meta.DoneAt
+	if end.IsZero() {
+		end = now
+	}
+	s := DoneSummary{FinalMessage: d.FinalMessage(meta.Provider, output)}
+	if !meta.CreatedAt.IsZero() && end.After(meta.CreatedAt) {
+		s.Duration = end.Sub(meta.CreatedAt)
+	}
+	dir := meta.WorktreePath
+	if dir == "" {
+		dir = meta.WorkingDir
+	}
+	if dir == "" {
+		return s
+	}
+	if _, err := gitIn(dir, "rev-parse", "--git-dir"); err != nil {
+		return s
+	}
+	s.Git = true
+	// The commit HEAD was on at launch: everything after it is the
+	// session's work.
+	start, _ := gitIn(dir, "rev-list", "-1", fmt.Sprintf("--before=@%d", meta.CreatedAt.Unix()), "HEAD")
+	logArgs := []string{"log", "--oneline", "--no-decorate"}
+	diffArgs := []string{"diff", "--stat"}
+	if start != "" {
+		logArgs = append(logArgs, start+"..HEAD")
+		diffArgs = append(diffArgs, start)
+	} else {
+		logArgs = append(logArgs, fmt.Sprintf("--since=@%d", meta.CreatedAt.Unix()))
+	}
+	if log, err := gitIn(dir, logArgs...); err == nil && log != "" {
+		s.Commits = strings.Split(log, "\n")
+	}
+	if stat, err := gitIn(dir, diffArgs...); err == nil {
+		s.DiffStat = stat
+	}
+	return s
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompletionDetector(t *testing.T) {
+	cfg := &Config{Providers: map[string]Provider{
+		"claude": {Binary: "claude"},
+		"codex":  {Binary: "codex"},
+		"custom": {Binary: "custom", CompletionMarker: `(?m)^ALL DONE$`, DoneOnExit: true},
+	}}
+	d, err := newCompletionDetector(AutoPRConfig{ServerStatuses: []string{"Completed"}}, NewProviderRegistry(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.OutputDone("codex", "some work\n⏺ VIBEFLOW_DONE\n> ") {
+		t.Error("shared marker on its own line not detected")
+	}
+	if d.OutputDone("codex", "> Fix the bug, then print VIBEFLOW_DONE when finished") {
+		t.Error("prompt mentioning the marker counted as completion")
+	}
+	if !d.OutputDone("claude", "edited 3 files\n⏺ Task complete.\n> ") {
+		t.Error("claude's own marker not detected")
+	}
+	if d.OutputDone("codex", "Task complete.") {
+		t.Error("claude's marker applied to codex")
+	}
+	if !d.OutputDone("custom", "ALL DONE") {
+		t.Error("configured provider marker not detected")
+	}
+
+	if !d.ExitDone("codex", "0") || !d.ExitDone("custom", "0") {
+		t.Error("clean exit of a done_on_exit provider not detected")
+	}
+	if d.ExitDone("codex", "1") || d.ExitDone("claude", "0") {
+		t.Error("failed exit, or exit of a provider without done_on_exit, counted as completion")
+	}
+
+	if !d.StatusDone(SessionStatus{Status: "completed"}) || !d.StatusDone(SessionStatus{Phase: "COMPLETED"}) {
+		t.Error("configured server status not detected")
+	}
+	if d.StatusDone(SessionStatus{Status: "active"}) {
+		t.Error("active status counted as completion")
+	}
+
+	var off *completionDetector
+	if off.OutputDone("claude", "VIBEFLOW_DONE") || off.StatusDone(SessionStatus{Status: "completed"}) || off.ExitDone("codex", "0") {
+		t.Error("nil detector reported completion")
+	}
+	if _, err := newCompletionDetector(AutoPRConfig{CompletionMarker: "("}, nil); err == nil {
+		t.Error("invalid marker accepted")
+	}
+	cfg.Providers["custom"] = Provider{CompletionMarker: "("}
+	if _, err := newCompletionDetector(AutoPRConfig{}, NewProviderRegistry(cfg)); err == nil || !strings.Contains(err.Error(), "providers.custom.completion_marker") {
+		t.Errorf("invalid provider marker: err = %v", err)
+	}
+}
+
+func TestCompletionDetector_FinalMessage(t *testing.T) {
+	d, err := newCompletionDetector(AutoPRConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for i := 1; i <= 12; i++ {
+		lines = append(lines, fmt.Sprintf("line %d  ", i))
+	}
+	output := strings.Join(lines, "\n") + "\nVIBEFLOW_DONE\n> \n"
+	got := d.FinalMessage("claude", output)
+	if want := "line 5\nline 6\nline 7\nline 8\nline 9\nline 10\nline 11\nline 12"; got != want {
+		t.Errorf("FinalMessage = %q, want %q", got, want)
+	}
+	if got := d.FinalMessage("claude", "just this\n\n"); got != "just this" {
+		t.Errorf("FinalMessage without a marker = %q", got)
+	}
+}
+
+func TestLoadDoneSummary(t *testing.T) {
+	_, wt := newMergeTestWorktree(t)
+	launched := time.Now().Add(time.Second)
+	// The session's commits land after launch.
+	later := launched.Add(time.Hour).Format(time.RFC3339)
+	t.Setenv("GIT_AUTHOR_DATE", later)
+	t.Setenv("GIT_COMMITTER_DATE", later)
+	commitFile(t, wt, "feature.go", "package x\n")
+	if err := os.WriteFile(filepath.Join(wt, "feature.go"), []byte("package x\n\nfunc F() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := SessionMeta{Name: "s", Provider: "claude", WorktreePath: wt, CreatedAt: launched, DoneAt: launched.Add(90 * time.Minute)}
+	s := loadDoneSummary(meta, "Added F.\nVIBEFLOW_DONE\n", nil, time.Now())
+	if !s.Git {
+		t.Fatal("worktree not recognised as a git checkout")
+	}
+	if s.Duration != 90*time.Minute {
+		t.Errorf("Duration = %v, want 1h30m", s.Duration)
+	}
+	if len(s.Commits) != 1 || !strings.Contains(s.Commits[0], "add feature.go") {
+		t.Errorf("Commits = %q, want just the session's commit", s.Commits)
+	}
+	if !strings.Contains(s.DiffStat, "feature.go") || !strings.Contains(s.DiffStat, "1 file changed") {
+		t.Errorf("DiffStat = %q", s.DiffStat)
+	}
+	if s.FinalMessage != "Added F.\nVIBEFLOW_DONE" {
+		// A nil detector has no marker to cut at.
+		t.Errorf("FinalMessage = %q", s.FinalMessage)
+	}
+
+	if s := loadDoneSummary(SessionMeta{WorkingDir: t.TempDir(), CreatedAt: launched}, "", nil, launched.Add(time.Minute)); s.Git || s.Duration != time.Minute {
+		t.Errorf("non-git summary = %+v", s)
+	}
+}
+
+func TestMarkDone(t *testing.T) {
+	rows := []SessionRow{
+		{Name: "idle", Status: "running", Done: true},
+		{Name: "exited", Status: "exited", Done: true},
+		{Name: "busy", Status: "working", Done: true},
+		{Name: "paused", Status: "paused", Done: true},
+		{Name: "plain", Status: "running"},
+	}
+	markDone(rows)
+	want := []string{"done", "done", "working", "paused", "running"}
+	for i, r := range rows {
+		if r.Status != want[i] {
+			t.Errorf("%s: status = %q, want %q", r.Name, r.Status, want[i])
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Editor families, by what they can do with a session's attach command.
+const (
+	editorGUI      = "gui"      // opens its own window; attach is up to the user
+	editorTerminal = "terminal" // takes over the terminal, no embedded terminal we drive
+	editorVim      = "vim"      // :terminal runs the attach in a split
+	editorNeovim   = "nvim"
+)
+
+// terminalEditors are editors that run in the terminal they're started
+// from, keyed by binary name.
+var terminalEditors = map[string]string{
+	"vim":   editorVim,
+	"vi":    editorVim,
+	"nvim":  editorNeovim,
+	"nano":  editorTerminal,
+	"micro": editorTerminal,
+	"hx":    editorTerminal,
+	"helix": editorTerminal,
+	"kak":   editorTerminal,
+}
+
+// editorCommand resolves the editor: the configured one, $VISUAL, $EDITOR,
+// then code. A value may carry arguments ("code --new-window").
+func editorCommand(configured string, getenv func(string) string) []string {
+	for _, v := range []string{configured, getenv("VISUAL"), getenv("EDITOR")} {
+		if fields := strings.Fields(v); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"code"}
+}
+
+// editorKind classifies an editor by its binary name.
+func editorKind(editor []string) string {
+	name := strings.TrimSuffix(filepath.Base(editor[0]), ".exe")
+	if kind, ok := terminalEditors[name]; ok {
+		return kind
+	}
+	return editorGUI
+}
+
+// editorArgs returns the arguments that open the current directory in
+// editor and, when attach is set and the editor has a terminal of its own,
+// run attach in a split below. attached reports whether it will.
+func editorArgs(editor []string, attach string) (args []string, attached bool) {
+	args = append(append([]string(nil), editor...), ".")
+	if attach == "" {
+		return args, false
+	}
+	switch editorKind(editor) {
+	case editorNeovim:
+		return append(args, "-c", "belowright split | terminal "+attach), true
+	case editorVim:
+		return append(args, "-c", "belowright terminal ++shell "+attach), true
+	}
+	return args, false
+}
+
+// editorProcess returns the command opening dir in the editor. Terminal
+// editors get the terminal's stdio and should be waited for; GUI editors
+// return at once or leave a window behind, so callers can just start them.
+func editorProcess(editor []string, dir, attach string) (cmd *exec.Cmd, foreground, attached bool) {
+	args, attached := editorArgs(editor, attach)
+	cmd = exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	if editorKind(editor) != editorGUI {
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return cmd, true, attached
+	}
+	return cmd, false, attached
+}
+
+// editorAttachCommand returns the shell command that attaches to fullName
+// from an editor's terminal. $TMUX is dropped: an editor started from tmux
+// passes it on, and tmux refuses to attach inside a session it thinks is
+// its own.
+func editorAttachCommand(tm *TmuxManager, fullName string) string {
+	return "env -u TMUX " + shellJoin(tm.command(true, attachArgs(fullName, false)...).Args)
+}
+
+// sessionDir returns where a session's code lives: its worktree, else its
+// working directory.
+func sessionDir(meta SessionMeta) string {
+	return firstNonEmpty(meta.WorktreePath, meta.WorkingDir)
+}
+
+// --- open ---
+
+func openCmd() *cobra.Command {
+	var editor, attach bool
+	cmd := &cobra.Command{
+		Use:   "open <session-name>",
+		Short: "Print a session's directory or open it in your editor",
+		Long: `Print the session's worktree (or working directory), for cd "$(vibeflow open
+<session>)". With --editor, open it in your editor instead: the editor from
+the config, else $VISUAL, $EDITOR, then code. With --attach, also attach to
+the session in the editor's terminal — a split below the editor for vim and
+neovim; for other editors the attach command is printed to run there.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			fullName := tmux.ensurePrefix(args[0])
+			meta, found, err := store.GetByTmux(fullName)
+			if err != nil {
+				return err
+			}
+			dir := sessionDir(meta)
+			if !found || dir == "" {
+				return fmt.Errorf("no directory recorded for session %q", args[0])
+			}
+			out := cmd.OutOrStdout()
+			if !editor && !attach {
+				fmt.Fprintln(out, dir)
+				return nil
+			}
+			var attachCmd string
+			if attach {
+				if !tmux.HasSession(fullName) {
+					return fmt.Errorf("session %q is not running", args[0])
+				}
+				attachCmd = editorAttachCommand(tmux, fullName)
+			}
+			proc, foreground, attached := editorProcess(editorCommand(cfg.Editor, os.Getenv), dir, attachCmd)
+			if attach && !attached {
+				fmt.Fprintf(out, "Run this in the editor's terminal to attach:\n  %s\n", attachCmd)
+			}
+			if foreground {
+				return proc.Run()
+			}
+			if err := proc.Start(); err != nil {
+				return fmt.Errorf("open editor: %w", err)
+			}
+			return proc.Process.Release()
+		},
+	}
+	cmd.Flags().BoolVar(&editor, "editor", false, "Open the directory in your editor")
+	cmd.Flags().BoolVar(&attach, "attach", false, "Also attach to the session in the editor's terminal (implies --editor)")
+	return cmd
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestEditorCommand(t *testing.T) {
+	env := map[string]string{"VISUAL": "", "EDITOR": "nvim"}
+	getenv := func(k string) string { return env[k] }
+	if got := editorCommand("code --new-window", getenv); !reflect.DeepEqual(got, []string{"code", "--new-window"}) {
+		t.Errorf("configured: %v", got)
+	}
+	if got := editorCommand("", getenv); !reflect.DeepEqual(got, []string{"nvim"}) {
+		t.Errorf("$EDITOR: %v", got)
+	}
+	env["VISUAL"] = "subl -w"
+	if got := editorCommand(" ", getenv); !reflect.DeepEqual(got, []string{"subl", "-w"}) {
+		t.Errorf("$VISUAL before $EDITOR: %v", got)
+	}
+	if got := editorCommand("", func(string) string { return "" }); !reflect.DeepEqual(got, []string{"code"}) {
+		t.Errorf("fallback: %v", got)
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	attach := "env -u TMUX tmux -L vibeflow attach-session -t vibeflow_claude-api"
+	tests := []struct {
+		editor       []string
+		attach       string
+		wantLast     string
+		wantAttached bool
+	}{
+		{[]string{"/usr/bin/nvim"}, attach, "belowright split | terminal " + attach, true},
+		{[]string{"vim"}, attach, "belowright terminal ++shell " + attach, true},
+		{[]string{"code", "--new-window"}, attach, ".", false},
+		{[]string{"nano"}, attach, ".", false},
+		{[]string{"nvim"}, "", ".", false},
+	}
+	for _, tt := range tests {
+		args, attached := editorArgs(tt.editor, tt.attach)
+		if attached != tt.wantAttached || args[len(args)-1] != tt.wantLast {
+			t.Errorf("editorArgs(%v) = %q, %v; want last arg %q, attached %v", tt.editor, args, attached, tt.wantLast, tt.wantAttached)
+		}
+		if !reflect.DeepEqual(args[:len(tt.editor)], tt.editor) {
+			t.Errorf("editorArgs(%v) = %q, want the editor command first", tt.editor, args)
+		}
+	}
+}
+
+func TestEditorProcess_Foreground(t *testing.T) {
+	dir := t.TempDir()
+	if cmd, foreground, _ := editorProcess([]string{"/opt/helix/hx.exe"}, dir, ""); !foreground || cmd.Dir != dir || cmd.Stdin == nil {
+		t.Errorf("helix: foreground = %v, dir = %q, stdin = %v", foreground, cmd.Dir, cmd.Stdin)
+	}
+	if _, foreground, _ := editorProcess([]string{"cursor"}, dir, ""); foreground {
+		t.Error("a GUI editor runs in the foreground")
+	}
+}
+
+func TestEditorAttachCommand(t *testing.T) {
+	got := editorAttachCommand(NewTmuxManager("vibeflow"), "vibeflow_claude-api")
+	if got != "env -u TMUX tmux -L vibeflow attach-session -t vibeflow_claude-api" {
+		t.Errorf("attach = %q", got)
+	}
+}
+
+func TestOpenEditor_TUI(t *testing.T) {
+	m := bulkTestModel(t)
+	nm, _ := m.openEditor()
+	if m = nm.(Model); m.err == nil || !strings.Contains(m.err.Error(), "no directory recorded for claude-a") {
+		t.Fatalf("err = %v, want no directory", m.err)
+	}
+
+	m = bulkTestModel(t)
+	dir := t.TempDir()
+	m.sessions[0].WorkingDir = "/elsewhere"
+	m.sessions[0].WorktreePath = dir
+	m.config.Editor = "true" // a GUI editor that exits at once
+	nm, cmd := m.openEditor()
+	m = nm.(Model)
+	if m.err != nil || m.notice != "Opened "+dir+" in true" || cmd == nil {
+		t.Errorf("err = %v, notice = %q", m.err, m.notice)
+	}
+}
+
+func TestOpenCmd_PrintsSessionDir(t *testing.T) {
+	withTempRoot(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := SaveConfig(DefaultConfig(), cfgPath); err != nil {
+		t.Fatal(err)
+	}
+	wt := t.TempDir()
+	addLaunchedSession(t, NewStore(), SessionMeta{Name: "x", Provider: "claude", WorkingDir: "/repo", WorktreePath: wt})
+	root := &cobra.Command{Use: "vibeflow-cli"}
+	root.PersistentFlags().String("config", "", "")
+	root.AddCommand(openCmd())
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"open", "claude-x", "--config", cfgPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("open: %v\n%s", err, out.String())
+	}
+	if got := strings.TrimSpace(out.String()); got != wt {
+		t.Errorf("open printed %q, want the worktree %q", got, wt)
+	}
+}
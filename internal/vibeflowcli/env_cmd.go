@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// interactiveShells are pane commands that accept typed `export` lines.
+// Anything else (an agent's TUI) would take the line as a prompt.
+var interactiveShells = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "fish": true, "dash": true, "ksh": true, "mksh": true,
+}
+
+// parseEnvAssignments splits KEY=VALUE arguments, rejecting invalid names.
+func parseEnvAssignments(args []string) ([][2]string, error) {
+	pairs := make([][2]string, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || !envAssignKeyRE.MatchString(key) {
+			return nil, fmt.Errorf("invalid assignment %q — want KEY=VALUE", arg)
+		}
+		pairs = append(pairs, [2]string{key, value})
+	}
+	return pairs, nil
+}
+
+// maskEnvValue hides the value of secret-bearing variables.
+func maskEnvValue(key, value string) string {
+	if isSecretEnvKey(key) {
+		return "<redacted>"
+	}
+	return value
+}
+
+// paneRunsShell reports whether the pane is sitting at an interactive
+// shell: the foreground process is a shell and the pane was started as
+// one. A shell running a script (sh -c '...; agent') doesn't count — what
+// is typed would reach whatever the script runs.
+func paneRunsShell(current, start string) bool {
+	if !interactiveShells[filepath.Base(current)] {
+		return false
+	}
+	fields := strings.Fields(strings.Trim(start, `"'`))
+	return len(fields) == 0 || (len(fields) == 1 && interactiveShells[filepath.Base(fields[0])])
+}
+
+// sendToShell types lines into the session's pane after checking it runs
+// an interactive shell. The leading space keeps the lines out of shell
+// history under HISTCONTROL=ignorespace / HIST_IGNORE_SPACE.
+func sendToShell(tm *TmuxManager, session string, lines []string) error {
+	if current, start := tm.PaneCommand(session); !paneRunsShell(current, start) {
+		return fmt.Errorf("pane is running %q, not an interactive shell — the line would be typed into the agent as a prompt; use --respawn instead", current)
+	}
+	for _, line := range lines {
+		if err := tm.SendKeys(session, " "+line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- env ---
+
+func envCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Show or change a running session's environment",
+		Long: `Show or change the tmux environment of a running session, e.g. to rotate a
+token that expired mid-session without recreating the session.
+
+The environment applies to processes started after the change. Use --respawn
+to restart the agent in the same pane so it picks the new values up, or --send
+to type export/unset lines into a pane that runs a shell. Changes last for the
+life of the tmux session; to keep them across 'vibeflow restart', also update
+saved_env_vars or the provider's env in the config.`,
+	}
+	cmd.AddCommand(envListCmd(), envSetCmd(), envUnsetCmd())
+	return cmd
+}
+
+func envListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "list <session-name>",
+		Short:             "List a session's environment (secret values are redacted)",
+		Aliases:           []string{"ls", "show"},
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			_, tmux, _, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			env, err := tmux.SessionEnv(args[0])
+			if err != nil {
+				return err
+			}
+			keys := make([]string, 0, len(env))
+			for k := range env {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			out := cmd.OutOrStdout()
+			for _, k := range keys {
+				fmt.Fprintf(out, "%s=%s\n", k, maskEnvValue(k, env[k]))
+			}
+			return nil
+		},
+	}
+}
+
+// envChange applies one env edit to the session, then sends the matching
+// shell lines or respawns the pane.
+func envChange(cmd *cobra.Command, session string, apply func(*TmuxManager) error, lines []string, send, respawn bool) error {
+	if send && respawn {
+		return fmt.Errorf("--send and --respawn are mutually exclusive")
+	}
+	cfgPath, _ := cmd.Flags().GetString("config")
+	_, tmux, store, _, _, err := loadComponents(cfgPath)
+	if err != nil {
+		return err
+	}
+	if !tmux.HasSession(tmux.ensurePrefix(session)) {
+		return fmt.Errorf("session %q is not running", session)
+	}
+	if err := apply(tmux); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	switch {
+	case send:
+		if err := sendToShell(tmux, session, lines); err != nil {
+			return fmt.Errorf("environment updated, but not sent to the pane: %w", err)
+		}
+		fmt.Fprintf(out, "Environment of %q updated and sent to its shell.\n", session)
+	case respawn:
+		if err := tmux.RespawnPane(session, false); err != nil {
+			return fmt.Errorf("environment updated, but respawn failed: %w", err)
+		}
+		// Record the relaunch as a new run, as the TUI's respawn does.
+		if metas, err := store.List(); err == nil {
+			for _, meta := range metas {
+				if meta.TmuxSession == tmux.ensurePrefix(session) {
+					meta.CreatedAt = time.Now()
+					meta.PausedAt = time.Time{}
+					meta.DoneAt = time.Time{}
+					_ = store.Add(meta)
+				}
+			}
+		}
+		fmt.Fprintf(out, "Environment of %q updated; agent restarted with it.\n", session)
+	default:
+		fmt.Fprintf(out, "Environment of %q updated. The running agent keeps its old values until it is respawned (--respawn).\n", session)
+	}
+	return nil
+}
+
+func envSetCmd() *cobra.Command {
+	var send, respawn bool
+	cmd := &cobra.Command{
+		Use:               "set <session-name> KEY=VALUE...",
+		Short:             "Set variables in a session's environment",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: sessionCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pairs, err := parseEnvAssignments(args[1:])
+			if err != nil {
+				return err
+			}
+			lines := make([]string, 0, len(pairs))
+			for _, kv := range pairs {
+				lines = append(lines, fmt.Sprintf("export %s=%s", kv[0], shellQuote(kv[1])))
+			}
+			return envChange(cmd, args[0], func(tm *TmuxManager) error {
+				for _, kv := range pairs {
+					if err := tm.SetSessionEnv(args[0], kv[0], kv[1]); err != nil {
+						return err
+					}
+				}
+				return nil
+			}, lines, send, respawn)
+		},
+	}
+	cmd.Flags().BoolVar(&send, "send", false, "Also type export lines into the pane (only when it runs a shell)")
+	cmd.Flags().BoolVar(&respawn, "respawn", false, "Restart the agent in the same pane so it picks up the new environment")
+	return cmd
+}
+
+func envUnsetCmd() *cobra.Command {
+	var send, respawn bool
+	cmd := &cobra.Command{
+		Use:               "unset <session-name> KEY...",
+		Short:             "Remove variables from a session's environment",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: sessionCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := args[1:]
+			for _, k := range keys {
+				if !envAssignKeyRE.MatchString(k) {
+					return fmt.Errorf("invalid variable name %q", k)
+				}
+			}
+			return envChange(cmd, args[0], func(tm *TmuxManager) error {
+				for _, k := range keys {
+					if err := tm.UnsetSessionEnv(args[0], k); err != nil {
+						return err
+					}
+				}
+				return nil
+			}, []string{"unset " + strings.Join(keys, " ")}, send, respawn)
+		},
+	}
+	cmd.Flags().BoolVar(&send, "send", false, "Also type an unset line into the pane (only when it runs a shell)")
+	cmd.Flags().BoolVar(&respawn, "respawn", false, "Restart the agent in the same pane without the variables")
+	return cmd
+}
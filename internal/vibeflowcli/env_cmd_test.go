@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEnvAssignments(t *testing.T) {
+	pairs, err := parseEnvAssignments([]string{"API_TOKEN=abc=def", "EMPTY="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairs[0] != [2]string{"API_TOKEN", "abc=def"} || pairs[1] != [2]string{"EMPTY", ""} {
+		t.Errorf("pairs = %v", pairs)
+	}
+	for _, bad := range []string{"NOVALUE", "1BAD=x", "=x", "A B=x"} {
+		if _, err := parseEnvAssignments([]string{bad}); err == nil {
+			t.Errorf("parseEnvAssignments(%q) = nil error, want one", bad)
+		}
+	}
+}
+
+func TestPaneRunsShell(t *testing.T) {
+	tests := []struct {
+		current, start string
+		want           bool
+	}{
+		{"zsh", "", true},
+		{"bash", `"bash"`, true},
+		{"sh", "/bin/sh", true},
+		{"claude", "claude --model opus", false},
+		{"sh", `"sh -c 'echo hi; claude'"`, false},
+		{"node", "", false},
+	}
+	for _, tt := range tests {
+		if got := paneRunsShell(tt.current, tt.start); got != tt.want {
+			t.Errorf("paneRunsShell(%q, %q) = %v, want %v", tt.current, tt.start, got, tt.want)
+		}
+	}
+}
+
+// waitForPane polls the pane until it shows want.
+func waitForPane(t *testing.T, tm *TmuxManager, session, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		out, _ := tm.CapturePaneOutput(session, 50)
+		if strings.Contains(out, want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pane never showed %q; last output:\n%s", want, out)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestSessionEnv_SetRespawnAndSend_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-env")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+
+	// An "agent" that reports the token it was started with.
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-agent", "-e", "API_TOKEN=old",
+		`sh -c 'echo "token=$API_TOKEN"; sleep 30'`); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	waitForPane(t, tm, "claude-agent", "token=old")
+	if err := tm.SetSessionEnv("claude-agent", "API_TOKEN", "rotated"); err != nil {
+		t.Fatal(err)
+	}
+	if env, err := tm.SessionEnv("claude-agent"); err != nil || env["API_TOKEN"] != "rotated" {
+		t.Fatalf("SessionEnv = %v, %v; want API_TOKEN=rotated", env, err)
+	}
+	if err := sendToShell(tm, "claude-agent", []string{"export API_TOKEN=x"}); err == nil {
+		t.Error("sendToShell into a non-shell pane succeeded, want a refusal")
+	}
+	if err := tm.RespawnPane("claude-agent", true); err != nil {
+		t.Fatal(err)
+	}
+	waitForPane(t, tm, "claude-agent", "token=rotated")
+
+	if err := tm.UnsetSessionEnv("claude-agent", "API_TOKEN"); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := tm.SessionEnvNames("claude-agent"); strings.Contains(strings.Join(names, ","), "API_TOKEN") {
+		t.Errorf("API_TOKEN still set after unset: %v", names)
+	}
+
+	// A shell pane gets the export typed in.
+	t.Setenv("DISPLAY", ":99")
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-shell", "sh"); err != nil {
+		t.Fatal(err)
+	}
+	// DISPLAY comes from tmux's update-environment, not from the launch.
+	if names, _ := tm.SessionEnvNames("claude-shell"); strings.Contains(strings.Join(names, ","), "DISPLAY") {
+		t.Errorf("SessionEnvNames lists update-environment variable DISPLAY: %v", names)
+	}
+	if err := sendToShell(tm, "claude-shell", []string{"export API_TOKEN=typed", `echo "shell=$API_TOKEN"`}); err != nil {
+		t.Fatal(err)
+	}
+	waitForPane(t, tm, "claude-shell", "shell=typed")
+}
@@ -16,7 +16,18 @@
 
 package vibeflowcli
 
-import "regexp"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
 
 // ErrorSeverity classifies how an error should be handled.
 type ErrorSeverity int
@@ -28,20 +39,48 @@ const (
 	SeverityFatal
 )
 
+// RecoveryAction is what auto-recovery does about a recoverable error.
+type RecoveryAction string
+
+const (
+	// ActionSendMessage types RecoveryMessage into the pane (the default).
+	ActionSendMessage RecoveryAction = ""
+	// ActionRestart kills the pane's process and reruns the session command.
+	ActionRestart RecoveryAction = "restart"
+	// ActionRelaunch clears the pane and its scrollback, then reruns the session command.
+	ActionRelaunch RecoveryAction = "relaunch"
+	// ActionHook runs the pattern's Hook in a shell.
+	ActionHook RecoveryAction = "hook"
+	// ActionNotify gives up straight away: the session is marked failed,
+	// which raises the "failed" notification.
+	ActionNotify RecoveryAction = "notify"
+)
+
 // ErrorPattern represents a known error signature from an agent provider.
 type ErrorPattern struct {
 	Provider        string         // Provider key ("claude", "codex", "gemini") or "*" for universal.
 	Regex           *regexp.Regexp // Compiled regex to match against captured output.
 	Severity        ErrorSeverity  // Whether the error is recoverable or fatal.
+	Action          RecoveryAction // What recovery does; ActionSendMessage by default.
 	RecoveryMessage string         // Text to inject via SendKeys for recovery.
+	Hook            string         // Shell command run by ActionHook.
 	RequiresBackoff bool           // True if rate-limit related (needs exponential backoff).
 	Description     string         // Human-readable description of the error.
 }
 
 // ErrorPatternRegistry holds a collection of error patterns for matching
-// against captured tmux pane output.
+// against captured tmux pane output. A registry created with
+// NewErrorPatternRegistryWithFile also loads user patterns from a YAML file
+// and picks up edits to it via ReloadIfChanged.
 type ErrorPatternRegistry struct {
-	patterns []ErrorPattern
+	mu       sync.Mutex
+	patterns []ErrorPattern // effective list: user patterns, built-ins, then AddPattern ones
+	extra    []ErrorPattern // added with AddPattern; kept across reloads
+
+	path    string // user pattern file; empty for built-ins only
+	modTime time.Time
+	size    int64
+	loaded  bool // path has been stat'ed at least once
 }
 
 // NewErrorPatternRegistry creates a registry with the default built-in patterns.
@@ -49,13 +88,28 @@ func NewErrorPatternRegistry() *ErrorPatternRegistry {
 	return &ErrorPatternRegistry{patterns: DefaultPatterns()}
 }
 
+// DefaultErrorPatternsPath returns the user error-pattern file under the root directory.
+func DefaultErrorPatternsPath() string {
+	return filepath.Join(RootDir(), "error-patterns.yaml")
+}
+
+// NewErrorPatternRegistryWithFile creates a registry with the built-in
+// patterns plus the user patterns in path, which need not exist yet. Load
+// errors surface from the first ReloadIfChanged call.
+func NewErrorPatternRegistryWithFile(path string) *ErrorPatternRegistry {
+	return &ErrorPatternRegistry{patterns: DefaultPatterns(), path: path}
+}
+
 // Match scans the output (typically the last few lines of captured pane output)
 // against all registered patterns for the given provider. Returns the first
 // matching pattern, or nil if no match is found. Universal patterns ("*") are
 // checked for all providers.
 func (r *ErrorPatternRegistry) Match(provider, output string) *ErrorPattern {
-	for i := range r.patterns {
-		p := &r.patterns[i]
+	r.mu.Lock()
+	patterns := r.patterns
+	r.mu.Unlock()
+	for i := range patterns {
+		p := &patterns[i]
 		if p.Provider != "*" && p.Provider != provider {
 			continue
 		}
@@ -66,11 +120,165 @@ func (r *ErrorPatternRegistry) Match(provider, output string) *ErrorPattern {
 	return nil
 }
 
+// lookup returns the provider's pattern with the given description, or nil.
+// It re-resolves a pattern saved by name after a restart.
+func (r *ErrorPatternRegistry) lookup(provider, description string) *ErrorPattern {
+	r.mu.Lock()
+	patterns := r.patterns
+	r.mu.Unlock()
+	for i := range patterns {
+		p := &patterns[i]
+		if (p.Provider == "*" || p.Provider == provider) && p.Description == description {
+			return p
+		}
+	}
+	return nil
+}
+
 // AddPattern adds a custom pattern to the registry.
 func (r *ErrorPatternRegistry) AddPattern(p ErrorPattern) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extra = append(r.extra, p)
 	r.patterns = append(r.patterns, p)
 }
 
+// ReloadIfChanged re-reads the user pattern file when its size or
+// modification time changed since the last call, or it appeared or went
+// away. It reports whether the patterns were reloaded. A file that fails to
+// parse is reported once and the previous patterns stay in effect.
+func (r *ErrorPatternRegistry) ReloadIfChanged() (bool, error) {
+	if r == nil || r.path == "" {
+		return false, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var modTime time.Time
+	var size int64
+	info, err := os.Stat(r.path)
+	switch {
+	case err == nil:
+		modTime, size = info.ModTime(), info.Size()
+	case !errors.Is(err, os.ErrNotExist):
+		return false, fmt.Errorf("stat error patterns: %w", err)
+	}
+	if r.loaded && modTime.Equal(r.modTime) && size == r.size {
+		return false, nil
+	}
+	r.loaded, r.modTime, r.size = true, modTime, size
+
+	var user []ErrorPattern
+	if !modTime.IsZero() {
+		if user, err = LoadErrorPatterns(r.path); err != nil {
+			return false, err
+		}
+	}
+	r.patterns = mergePatterns(user, DefaultPatterns(), r.extra)
+	return true, nil
+}
+
+// errorPatternFile is the on-disk format of error-patterns.yaml.
+type errorPatternFile struct {
+	Patterns []errorPatternSpec `yaml:"patterns"`
+}
+
+type errorPatternSpec struct {
+	Provider        string `yaml:"provider"`         // provider key, or "*" (default) for all
+	Regex           string `yaml:"regex"`            // Go regexp syntax
+	Severity        string `yaml:"severity"`         // "recoverable" (default) or "fatal"
+	Action          string `yaml:"action"`           // message (default), restart, relaunch, hook or notify
+	RecoveryMessage string `yaml:"recovery_message"` // typed into the pane by the message action
+	Hook            string `yaml:"hook"`             // shell command run by the hook action
+	Backoff         bool   `yaml:"backoff"`          // back off exponentially between attempts
+	Description     string `yaml:"description"`      // shown in logs; matching a built-in replaces it
+}
+
+// LoadErrorPatterns reads and compiles the user error patterns in path.
+func LoadErrorPatterns(path string) ([]ErrorPattern, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read error patterns: %w", err)
+	}
+	var file errorPatternFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	patterns := make([]ErrorPattern, 0, len(file.Patterns))
+	for i, spec := range file.Patterns {
+		p, err := spec.compile()
+		if err != nil {
+			return nil, fmt.Errorf("%s: pattern %d: %w", filepath.Base(path), i+1, err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+func (s errorPatternSpec) compile() (ErrorPattern, error) {
+	if s.Regex == "" {
+		return ErrorPattern{}, fmt.Errorf("regex is required")
+	}
+	re, err := regexp.Compile(s.Regex)
+	if err != nil {
+		return ErrorPattern{}, fmt.Errorf("invalid regex: %w", err)
+	}
+	p := ErrorPattern{
+		Provider:        s.Provider,
+		Regex:           re,
+		RecoveryMessage: s.RecoveryMessage,
+		Hook:            s.Hook,
+		RequiresBackoff: s.Backoff,
+		Description:     s.Description,
+	}
+	if p.Provider == "" {
+		p.Provider = "*"
+	}
+	if p.Description == "" {
+		p.Description = s.Regex
+	}
+	switch strings.ToLower(s.Severity) {
+	case "", "recoverable":
+		p.Severity = SeverityRecoverable
+	case "fatal":
+		p.Severity = SeverityFatal
+	default:
+		return ErrorPattern{}, fmt.Errorf("unknown severity %q (want recoverable or fatal)", s.Severity)
+	}
+	switch action := RecoveryAction(strings.ToLower(s.Action)); action {
+	case "", "message":
+		p.Action = ActionSendMessage
+	case ActionRestart, ActionRelaunch, ActionNotify:
+		p.Action = action
+	case ActionHook:
+		if strings.TrimSpace(s.Hook) == "" {
+			return ErrorPattern{}, fmt.Errorf("action hook needs a hook command")
+		}
+		p.Action = action
+	default:
+		return ErrorPattern{}, fmt.Errorf("unknown action %q (want message, restart, relaunch, hook or notify)", s.Action)
+	}
+	return p, nil
+}
+
+// mergePatterns orders user patterns ahead of the built-ins so they win
+// when both match. A user pattern with the same provider and description
+// as a built-in replaces it outright.
+func mergePatterns(user, builtin, extra []ErrorPattern) []ErrorPattern {
+	overridden := make(map[string]bool, len(user))
+	for _, p := range user {
+		overridden[p.Provider+"\x00"+p.Description] = true
+	}
+	out := make([]ErrorPattern, 0, len(user)+len(builtin)+len(extra))
+	out = append(out, user...)
+	for _, p := range builtin {
+		if !overridden[p.Provider+"\x00"+p.Description] {
+			out = append(out, p)
+		}
+	}
+	return append(out, extra...)
+}
+
 // DefaultPatterns returns the built-in error patterns for all supported providers.
 func DefaultPatterns() []ErrorPattern {
 	return []ErrorPattern{
@@ -154,6 +362,42 @@ func DefaultPatterns() []ErrorPattern {
 			Description:     "Gemini internal error",
 		},
 
+		// --- Aider (errors come through litellm) ---
+		{
+			Provider:        "aider",
+			Regex:           regexp.MustCompile(`litellm\.RateLimitError`),
+			Severity:        SeverityRecoverable,
+			RecoveryMessage: "The model provider rate limited the request. Please retry the last request.",
+			RequiresBackoff: true,
+			Description:     "Aider rate limit",
+		},
+		{
+			Provider:        "aider",
+			Regex:           regexp.MustCompile(`litellm\.(APIConnectionError|ServiceUnavailableError|InternalServerError)`),
+			Severity:        SeverityRecoverable,
+			RecoveryMessage: "The model provider request failed. Please retry the last request.",
+			RequiresBackoff: false,
+			Description:     "Aider API error",
+		},
+
+		// --- OpenHands CLI ---
+		{
+			Provider:        "openhands",
+			Regex:           regexp.MustCompile(`RateLimitError`),
+			Severity:        SeverityRecoverable,
+			RecoveryMessage: "The model provider rate limited the request. Please continue the task.",
+			RequiresBackoff: true,
+			Description:     "OpenHands rate limit",
+		},
+		{
+			Provider:        "openhands",
+			Regex:           regexp.MustCompile(`(?i)agent\s+(got\s+)?stuck\s+in\s+a\s+loop|AgentStuckInLoopError`),
+			Severity:        SeverityRecoverable,
+			RecoveryMessage: "You were stuck repeating the same action. Step back, try a different approach and continue the task.",
+			RequiresBackoff: false,
+			Description:     "OpenHands agent stuck in a loop",
+		},
+
 		// --- Universal patterns (all providers) ---
 		{
 			Provider:        "*",
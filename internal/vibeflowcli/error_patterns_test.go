@@ -17,8 +17,12 @@
 package vibeflowcli
 
 import (
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewErrorPatternRegistry(t *testing.T) {
@@ -42,7 +46,7 @@ func TestDefaultPatterns(t *testing.T) {
 	for _, p := range patterns {
 		providers[p.Provider] = true
 	}
-	for _, expected := range []string{"claude", "codex", "gemini", "*"} {
+	for _, expected := range []string{"claude", "codex", "gemini", "aider", "openhands", "*"} {
 		if !providers[expected] {
 			t.Errorf("missing patterns for provider %q", expected)
 		}
@@ -178,6 +182,28 @@ func TestErrorPatternRegistry_Match_GeminiInternalError(t *testing.T) {
 	}
 }
 
+func TestErrorPatternRegistry_Match_AiderRateLimit(t *testing.T) {
+	reg := NewErrorPatternRegistry()
+	match := reg.Match("aider", "litellm.RateLimitError: AnthropicException - rate_limit_error")
+	if match == nil {
+		t.Fatal("expected match for aider rate limit")
+	}
+	if !match.RequiresBackoff {
+		t.Error("rate limit should require backoff")
+	}
+}
+
+func TestErrorPatternRegistry_Match_OpenHandsStuckInLoop(t *testing.T) {
+	reg := NewErrorPatternRegistry()
+	match := reg.Match("openhands", "AgentStuckInLoopError: Agent got stuck in a loop")
+	if match == nil {
+		t.Fatal("expected match for OpenHands stuck loop")
+	}
+	if match.Description != "OpenHands agent stuck in a loop" {
+		t.Errorf("matched %q, want the stuck-in-loop pattern", match.Description)
+	}
+}
+
 func TestErrorPatternRegistry_Match_UniversalPanic(t *testing.T) {
 	reg := NewErrorPatternRegistry()
 	// Universal patterns should match any provider.
@@ -284,3 +310,83 @@ func TestTruncateLog(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorPatternRegistry_UserPatternsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "error-patterns.yaml")
+	reg := NewErrorPatternRegistryWithFile(path)
+
+	// No file yet: built-ins only.
+	if _, err := reg.ReloadIfChanged(); err != nil {
+		t.Fatal(err)
+	}
+	if reg.Match("inhouse", "Upstream hiccup, try again") != nil {
+		t.Fatal("unexpected match before the file exists")
+	}
+
+	writePatterns(t, path, `
+patterns:
+  - provider: inhouse
+    regex: 'Upstream hiccup'
+    recovery_message: "retry"
+    backoff: true
+  - provider: claude
+    regex: 'API Error:\s*529'
+    severity: fatal
+    description: Claude API overloaded (529)
+`)
+	if reloaded, err := reg.ReloadIfChanged(); err != nil || !reloaded {
+		t.Fatalf("ReloadIfChanged = %v, %v; want a reload", reloaded, err)
+	}
+	m := reg.Match("inhouse", "Upstream hiccup, try again")
+	if m == nil || m.RecoveryMessage != "retry" || !m.RequiresBackoff || m.Severity != SeverityRecoverable {
+		t.Fatalf("user pattern match = %+v", m)
+	}
+	// Same provider and description replaces the built-in.
+	if m := reg.Match("claude", "API Error: 529"); m == nil || m.Severity != SeverityFatal {
+		t.Errorf("override match = %+v, want fatal", m)
+	}
+	if reloaded, _ := reg.ReloadIfChanged(); reloaded {
+		t.Error("unchanged file should not reload")
+	}
+
+	// A broken edit is reported and the previous patterns stay in effect.
+	writePatterns(t, path, "patterns:\n  - regex: '('\n")
+	if _, err := reg.ReloadIfChanged(); err == nil {
+		t.Fatal("expected an error for an invalid regex")
+	}
+	if reg.Match("inhouse", "Upstream hiccup") == nil {
+		t.Error("previous patterns should survive a failed reload")
+	}
+
+	// Removing the file falls back to the built-ins.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err := reg.ReloadIfChanged(); err != nil || !reloaded {
+		t.Fatalf("ReloadIfChanged after remove = %v, %v", reloaded, err)
+	}
+	if m := reg.Match("claude", "API Error: 529"); m == nil || m.Severity != SeverityRecoverable {
+		t.Errorf("built-in should be back, got %+v", m)
+	}
+}
+
+func TestLoadErrorPatterns_UnknownSeverity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "error-patterns.yaml")
+	writePatterns(t, path, "patterns:\n  - regex: 'x'\n    severity: loud\n")
+	if _, err := LoadErrorPatterns(path); err == nil || !strings.Contains(err.Error(), "pattern 1") {
+		t.Errorf("err = %v, want an error naming pattern 1", err)
+	}
+}
+
+// writePatterns writes content with a fresh mtime so ReloadIfChanged sees
+// the change even within the filesystem's timestamp granularity.
+func writePatterns(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	next := time.Now().Add(time.Duration(len(content)) * time.Second)
+	if err := os.Chtimes(path, next, next); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,344 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestVersion is the current session manifest format.
+const manifestVersion = 1
+
+// SessionManifest is the portable description of a set of sessions written
+// by `vibeflow export` and read by `vibeflow import`. It holds no secrets:
+// environment variables are listed by name only.
+type SessionManifest struct {
+	Version    int               `yaml:"version"`
+	ExportedAt time.Time         `yaml:"exported_at"`
+	Sessions   []ManifestSession `yaml:"sessions"`
+}
+
+// ManifestSession describes one session. WorkDir is the repository (or
+// plain directory) the session runs in, with the home directory written as
+// "~" so the manifest works across machines. For worktree sessions it is
+// the main repository and Worktree is true: import creates a fresh worktree
+// of Branch there, starting a new branch from Base (default HEAD).
+type ManifestSession struct {
+	Name            string   `yaml:"name"`
+	Provider        string   `yaml:"provider"`
+	SessionType     string   `yaml:"session_type,omitempty"`
+	Project         string   `yaml:"project,omitempty"`
+	Persona         string   `yaml:"persona,omitempty"`
+	Branch          string   `yaml:"branch,omitempty"`
+	WorkDir         string   `yaml:"work_dir"`
+	RepoURL         string   `yaml:"repo_url,omitempty"`
+	Worktree        bool     `yaml:"worktree,omitempty"`
+	Base            string   `yaml:"base,omitempty"`
+	SkipPermissions bool     `yaml:"skip_permissions,omitempty"`
+	Model           string   `yaml:"model,omitempty"`
+	Profile         string   `yaml:"profile,omitempty"` // credential profile name; the importing config must define it
+	LLMGateway      bool     `yaml:"llm_gateway,omitempty"`
+	CloudDispatch   bool     `yaml:"cloud_dispatch,omitempty"`
+	MCPToolName     string   `yaml:"mcp_tool_name,omitempty"`
+	Env             []string `yaml:"env,omitempty"`
+}
+
+// homeRelative replaces a leading home directory with "~".
+func homeRelative(path, home string) string {
+	if home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return filepath.ToSlash(filepath.Join("~", rest))
+	}
+	return path
+}
+
+// expandHome is the inverse of homeRelative.
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(home, filepath.FromSlash(rest))
+	}
+	return path
+}
+
+// mainRepoDir returns the main checkout of the repository dir belongs to,
+// so a worktree maps back to the repository it was created from.
+func mainRepoDir(dir string) string {
+	common, err := gitIn(dir, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return dir
+	}
+	return filepath.Dir(common)
+}
+
+// managedEnvNames are env vars vibeflow sets itself on every launch, so they
+// are left out of the manifest.
+func managedEnvNames(provider string) map[string]bool {
+	names := map[string]bool{"MCP_TOKEN": true}
+	for k := range BuildLLMGatewayEnv(provider, "http://gateway", "token") {
+		names[k] = true
+	}
+	return names
+}
+
+// buildManifest describes the stored sessions that are live in tmux.
+func buildManifest(metas []SessionMeta, tm *TmuxManager, home string, now time.Time) SessionManifest {
+	m := SessionManifest{Version: manifestVersion, ExportedAt: now.UTC()}
+	for _, meta := range metas {
+		if !tm.HasSession(meta.TmuxSession) {
+			continue
+		}
+		dir := meta.WorkingDir
+		if meta.WorktreePath != "" {
+			dir = mainRepoDir(meta.WorktreePath)
+		}
+		s := ManifestSession{
+			Name:            meta.Name,
+			Provider:        meta.Provider,
+			SessionType:     meta.SessionType,
+			Project:         meta.Project,
+			Persona:         meta.Persona,
+			Branch:          meta.Branch,
+			WorkDir:         homeRelative(dir, home),
+			Worktree:        meta.WorktreePath != "",
+			SkipPermissions: meta.SkipPermissions,
+			Model:           meta.Model,
+			Profile:         meta.Profile,
+			LLMGateway:      meta.LLMGatewayEnabled,
+			CloudDispatch:   meta.CloudDispatch || meta.DispatchMode == "cloud_queue",
+			MCPToolName:     meta.MCPToolName,
+		}
+		if url, err := gitIn(dir, "remote", "get-url", "origin"); err == nil {
+			s.RepoURL = url
+		}
+		if names, err := tm.SessionEnvNames(meta.TmuxSession); err == nil {
+			managed := managedEnvNames(meta.Provider)
+			for _, n := range names {
+				if !managed[n] {
+					s.Env = append(s.Env, n)
+				}
+			}
+		}
+		m.Sessions = append(m.Sessions, s)
+	}
+	return m
+}
+
+// LoadSessionManifest reads and validates a manifest.
+func LoadSessionManifest(data []byte) (SessionManifest, error) {
+	var m SessionManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("parse manifest: %w", err)
+	}
+	if m.Version != manifestVersion {
+		return m, fmt.Errorf("unsupported manifest version %d (want %d)", m.Version, manifestVersion)
+	}
+	for i, s := range m.Sessions {
+		if s.Name == "" || s.WorkDir == "" {
+			return m, fmt.Errorf("manifest session %d: name and work_dir are required", i+1)
+		}
+	}
+	return m, nil
+}
+
+// missingEnv returns the manifest's env var names that can't be satisfied
+// here: not in the environment, saved_env_vars or the provider's env.
+func missingEnv(s ManifestSession, cfg *Config, prov Provider) []string {
+	var missing []string
+	for _, name := range s.Env {
+		if os.Getenv(name) != "" || cfg.SavedEnvVars[name] != "" || prov.Env[name] != "" {
+			continue
+		}
+		missing = append(missing, name)
+	}
+	return missing
+}
+
+// importSession recreates one manifest session, creating its worktree when
+// needed. A worktree already checked out on the branch, such as one `vibeflow
+// down` kept, is reused. It returns the launched session's metadata.
+func importSession(s ManifestSession, cfg *Config, tm *TmuxManager, store *Store, cache *SessionCache, registry *ProviderRegistry, home string) (SessionMeta, error) {
+	dir := expandHome(s.WorkDir, home)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		if s.RepoURL != "" {
+			return SessionMeta{}, fmt.Errorf("%s not found (clone %s there first)", dir, s.RepoURL)
+		}
+		return SessionMeta{}, fmt.Errorf("%s not found", dir)
+	}
+	meta := SessionMeta{
+		Name:              s.Name,
+		TmuxSession:       tm.FullSessionName(s.Provider, s.Name),
+		Provider:          s.Provider,
+		Project:           s.Project,
+		Persona:           s.Persona,
+		Branch:            s.Branch,
+		WorkingDir:        dir,
+		SessionType:       s.SessionType,
+		CloudDispatch:     s.CloudDispatch,
+		SkipPermissions:   s.SkipPermissions,
+		Model:             s.Model,
+		Profile:           s.Profile,
+		LLMGatewayEnabled: s.LLMGateway,
+		MCPToolName:       s.MCPToolName,
+	}
+	if s.CloudDispatch {
+		meta.DispatchMode = mapCloudDispatchMode(true)
+	}
+	if s.Worktree && s.Branch != "" {
+		wm, err := NewWorktreeManager(dir, cfg.Worktree.BaseDir)
+		if err != nil {
+			return SessionMeta{}, err
+		}
+		wm.SetSetup(cfg.Worktree.Setup)
+		wtPath, found := wm.FindByBranch(s.Branch)
+		if !found || wtPath == wm.RepoRoot() {
+			meta.NewBranch = createsBranch(wm.RepoRoot(), s.Branch)
+			if wtPath, err = wm.CreateBranch(fmt.Sprintf("%s-%s-%d", s.Provider, strings.ReplaceAll(s.Branch, "/", "-"), time.Now().Unix()), s.Branch, true, s.Base); err != nil {
+				return SessionMeta{}, fmt.Errorf("create worktree: %w", err)
+			}
+		}
+		meta.WorkingDir, meta.WorktreePath = wtPath, wtPath
+	}
+	return RestartSession(meta, cfg, tm, store, cache, registry)
+}
+
+// --- export ---
+
+func exportCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the running sessions to a portable YAML manifest",
+		Long: `Write the running sessions as a YAML manifest: provider, project, persona,
+branch, working directory (relative to your home directory), whether it ran
+in a worktree, and the names of its environment variables — never their
+values. Recreate them elsewhere with 'vibeflow import'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			_, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			metas, err := store.List()
+			if err != nil {
+				return err
+			}
+			home, _ := os.UserHomeDir()
+			data, err := yaml.Marshal(buildManifest(metas, tmux, home, time.Now()))
+			if err != nil {
+				return fmt.Errorf("marshal manifest: %w", err)
+			}
+			if output == "" || output == "-" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+			return os.WriteFile(output, data, 0644)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the manifest to this file instead of stdout")
+	return cmd
+}
+
+// --- import ---
+
+func importCmd() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "import <manifest.yaml>",
+		Short: "Recreate sessions from a manifest written by 'vibeflow export'",
+		Long: `Launch every session in the manifest. Working directories must already
+exist (clone the repositories first); worktree sessions get a new worktree of
+their branch. Sessions already running are skipped, and environment
+variables the manifest lists but this machine doesn't have are reported.
+Use '-' to read the manifest from stdin.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				data []byte
+				err  error
+			)
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			manifest, err := LoadSessionManifest(data)
+			if err != nil {
+				return err
+			}
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
+			cache := NewSessionCache()
+			home, _ := os.UserHomeDir()
+			out := cmd.OutOrStdout()
+
+			failed := 0
+			for _, s := range manifest.Sessions {
+				if tmux.HasSession(tmux.FullSessionName(s.Provider, s.Name)) {
+					fmt.Fprintf(out, "%-24s already running, skipped\n", s.Name)
+					continue
+				}
+				prov, _ := registry.Get(s.Provider)
+				if missing := missingEnv(s, cfg, prov); len(missing) > 0 {
+					fmt.Fprintf(out, "%-24s warning: not set here: %s\n", s.Name, strings.Join(missing, ", "))
+				}
+				if dryRun {
+					fmt.Fprintf(out, "%-24s would launch %s in %s\n", s.Name, s.Provider, expandHome(s.WorkDir, home))
+					continue
+				}
+				meta, err := importSession(s, cfg, tmux, store, cache, registry, home)
+				if err != nil {
+					failed++
+					fmt.Fprintf(out, "%-24s failed: %v\n", s.Name, err)
+					continue
+				}
+				hooks.Fire(HookSessionCreate, meta, nil)
+				fmt.Fprintf(out, "%-24s launched (%s, %s)\n", s.Name, meta.Provider, meta.WorkingDir)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d sessions failed to import", failed, len(manifest.Sessions))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be launched without launching anything")
+	return cmd
+}
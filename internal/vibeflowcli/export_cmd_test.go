@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHomeRelative_RoundTrip(t *testing.T) {
+	home := filepath.FromSlash("/home/dev")
+	for path, want := range map[string]string{
+		filepath.FromSlash("/home/dev/src/web"): "~/src/web",
+		home:                                    "~",
+		filepath.FromSlash("/home/developer/x"): filepath.FromSlash("/home/developer/x"),
+		filepath.FromSlash("/opt/repo"):         filepath.FromSlash("/opt/repo"),
+	} {
+		got := homeRelative(path, home)
+		if got != want {
+			t.Errorf("homeRelative(%q) = %q, want %q", path, got, want)
+		}
+		if back := expandHome(got, home); back != path {
+			t.Errorf("expandHome(%q) = %q, want %q", got, back, path)
+		}
+	}
+}
+
+func TestLoadSessionManifest_Validates(t *testing.T) {
+	if _, err := LoadSessionManifest([]byte("version: 2\nsessions: []\n")); err == nil {
+		t.Error("unknown version: want an error")
+	}
+	if _, err := LoadSessionManifest([]byte("version: 1\nsessions:\n  - name: a\n")); err == nil {
+		t.Error("missing work_dir: want an error")
+	}
+	m, err := LoadSessionManifest([]byte("version: 1\nsessions:\n  - name: a\n    provider: claude\n    work_dir: ~/src\n    env: [GEMINI_API_KEY]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Sessions) != 1 || m.Sessions[0].WorkDir != "~/src" || !reflect.DeepEqual(m.Sessions[0].Env, []string{"GEMINI_API_KEY"}) {
+		t.Errorf("manifest = %+v", m)
+	}
+}
+
+func TestMissingEnv(t *testing.T) {
+	t.Setenv("VF_TEST_FROM_SHELL", "x")
+	cfg := &Config{SavedEnvVars: map[string]string{"VF_TEST_SAVED": "y"}}
+	s := ManifestSession{Env: []string{"VF_TEST_FROM_SHELL", "VF_TEST_SAVED", "VF_TEST_PROVIDER", "VF_TEST_NOWHERE"}}
+	got := missingEnv(s, cfg, Provider{Env: map[string]string{"VF_TEST_PROVIDER": "z"}})
+	if !reflect.DeepEqual(got, []string{"VF_TEST_NOWHERE"}) {
+		t.Errorf("missingEnv = %q", got)
+	}
+}
+
+// TestExportImport_RealTmux exports a live session and imports the manifest
+// into an empty tmux server, as on a second machine.
+func TestExportImport_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	withTempRoot(t)
+	tm := NewTmuxManager("vftest-export")
+	_, _ = tm.run("kill-server")
+	t.Cleanup(func() { _, _ = tm.run("kill-server") })
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+
+	home := t.TempDir()
+	repo := filepath.Join(home, "src", "web")
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	if err := tm.CreateSessionWithOpts(SessionOpts{
+		Name: "api", Provider: "fake", WorkDir: repo, Command: "sleep 30",
+		Env: map[string]string{"FAKE_API_KEY": "secret", "MCP_TOKEN": "tok", "CLEARED": ""},
+	}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	_ = store.Add(SessionMeta{
+		Name: "api", TmuxSession: tm.FullSessionName("fake", "api"), Provider: "fake",
+		Project: "web", Persona: "developer", Branch: "main", WorkingDir: repo, SkipPermissions: true,
+	})
+	_ = store.Add(SessionMeta{Name: "gone", TmuxSession: "vibeflow_fake-gone", Provider: "fake", WorkingDir: repo})
+
+	metas, _ := store.List()
+	manifest := buildManifest(metas, tm, home, time.Now())
+	if len(manifest.Sessions) != 1 {
+		t.Fatalf("manifest sessions = %+v, want only the live one", manifest.Sessions)
+	}
+	s := manifest.Sessions[0]
+	if s.WorkDir != "~/src/web" || s.Project != "web" || !s.SkipPermissions || s.Worktree {
+		t.Errorf("manifest session = %+v", s)
+	}
+	if !reflect.DeepEqual(s.Env, []string{"FAKE_API_KEY"}) {
+		t.Errorf("Env = %q, want only FAKE_API_KEY (values and vibeflow-managed names left out)", s.Env)
+	}
+
+	// Import on a "new machine": nothing running, empty store.
+	if err := tm.KillSession(s.Provider + "-" + s.Name); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.Providers = map[string]Provider{"fake": {Name: "Fake", Binary: "/bin/sh", LaunchTemplate: "sleep 30"}}
+	newStore := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	meta, err := importSession(s, cfg, tm, newStore, NewSessionCache(), NewProviderRegistry(cfg), home)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !tm.HasSession(meta.TmuxSession) || meta.WorkingDir != repo || meta.Persona != "developer" {
+		t.Errorf("imported meta = %+v", meta)
+	}
+	if stored, ok, _ := newStore.Get("api"); !ok || !stored.SkipPermissions {
+		t.Errorf("imported session not stored: %+v", stored)
+	}
+
+	s.WorkDir = "~/src/missing"
+	s.RepoURL = "git@example.com:org/missing.git"
+	if _, err := importSession(s, cfg, tm, newStore, nil, NewProviderRegistry(cfg), home); err == nil || !strings.Contains(err.Error(), "clone git@example.com") {
+		t.Errorf("missing work dir: err = %v", err)
+	}
+}
+
+// TestImportSession_ReusesBranchWorktree imports a worktree session twice,
+// as `vibeflow down` then `vibeflow up` does: the worktree down kept is
+// reused rather than checked out again.
+func TestImportSession_ReusesBranchWorktree(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	withTempRoot(t)
+	tm := NewTmuxManager("vftest-reimport")
+	_, _ = tm.run("kill-server")
+	t.Cleanup(func() { _, _ = tm.run("kill-server") })
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	repo := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".env"), []byte("TOKEN=1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.Providers = map[string]Provider{"fake": {Name: "Fake", Binary: "/bin/sh", LaunchTemplate: "sleep 30"}}
+	cfg.Worktree.Setup = WorktreeSetupConfig{Copy: []string{".env"}}
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	s := ManifestSession{Name: "api", Provider: "fake", WorkDir: repo, Branch: "feat/api", Worktree: true}
+
+	first, err := importSession(s, cfg, tm, store, nil, NewProviderRegistry(cfg), "")
+	if err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(first.WorktreePath, ".env")); err != nil {
+		t.Errorf("worktree setup didn't run: %v", err)
+	}
+	if err := tm.KillSession(first.TmuxSession); err != nil {
+		t.Fatal(err)
+	}
+	second, err := importSession(s, cfg, tm, store, nil, NewProviderRegistry(cfg), "")
+	if err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if first.WorktreePath == "" || second.WorktreePath != first.WorktreePath {
+		t.Errorf("worktrees = %q then %q, want the first reused", first.WorktreePath, second.WorktreePath)
+	}
+}
@@ -0,0 +1,436 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// fleetFileName is the fleet file `vibeflow up` looks for.
+const fleetFileName = "vibeflow.yaml"
+
+// FleetFile describes a set of sessions for one repository, launched
+// together with `vibeflow up` and stopped with `vibeflow down`. Top-level
+// provider, project and base apply to every session that doesn't set its own.
+type FleetFile struct {
+	// Name prefixes every session name. Defaults to the directory name.
+	Name     string         `yaml:"name"`
+	Provider string         `yaml:"provider"`
+	Project  string         `yaml:"project"`
+	Base     string         `yaml:"base"`
+	Sessions []FleetSession `yaml:"sessions"`
+}
+
+// FleetSession is one entry of a fleet file. Count > 1 launches that many
+// copies, suffixed -1..-N. Worktree sessions run on Branch (default
+// "<fleet>/<name>", suffixed like the name when Count > 1) in a worktree
+// of their own; the branch is created from Base when it doesn't exist.
+// After names another session of the fleet that has to reach When (see
+// parseFleetWhen) before this one launches.
+type FleetSession struct {
+	Name            string `yaml:"name"`
+	Persona         string `yaml:"persona"`
+	Provider        string `yaml:"provider"`
+	Project         string `yaml:"project"`
+	SessionType     string `yaml:"session_type"`
+	Count           int    `yaml:"count"`
+	Worktree        bool   `yaml:"worktree"`
+	Branch          string `yaml:"branch"`
+	Base            string `yaml:"base"`
+	Model           string `yaml:"model"`
+	SkipPermissions bool   `yaml:"skip_permissions"`
+	After           string `yaml:"after"`
+	When            string `yaml:"when"`
+}
+
+// LoadFleetFile reads and validates a fleet file.
+func LoadFleetFile(path string) (FleetFile, error) {
+	var f FleetFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if f.Name == "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return f, err
+		}
+		f.Name = filepath.Base(filepath.Dir(abs))
+	}
+	if len(f.Sessions) == 0 {
+		return f, fmt.Errorf("%s: no sessions defined", path)
+	}
+	seen := make(map[string]bool)
+	for i, s := range f.Sessions {
+		if s.Name == "" {
+			return f, fmt.Errorf("%s: session %d has no name", path, i+1)
+		}
+		if seen[s.Name] {
+			return f, fmt.Errorf("%s: duplicate session name %q", path, s.Name)
+		}
+		seen[s.Name] = true
+		if s.Count < 0 {
+			return f, fmt.Errorf("%s: session %q: count must not be negative", path, s.Name)
+		}
+		if s.SessionType != "" && s.SessionType != "vanilla" && s.SessionType != "vibeflow" {
+			return f, fmt.Errorf("%s: session %q: session_type must be 'vanilla' or 'vibeflow'", path, s.Name)
+		}
+	}
+	if err := f.validateOrder(); err != nil {
+		return f, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Expand turns the fleet into one manifest entry per session to launch,
+// rooted at dir. defaultProvider applies when neither the session nor the
+// fleet names one.
+func (f FleetFile) Expand(dir, defaultProvider string) []ManifestSession {
+	var out []ManifestSession
+	for _, s := range f.Sessions {
+		names := f.sessionNames(s)
+		for i, name := range names {
+			branch := s.Branch
+			if branch == "" && s.Worktree {
+				branch = f.Name + "/" + s.Name
+			}
+			if len(names) > 1 && s.Worktree {
+				branch = fmt.Sprintf("%s-%d", branch, i+1)
+			}
+			sessionType := s.SessionType
+			if sessionType == "" {
+				sessionType = "vanilla"
+				if s.Persona != "" {
+					sessionType = "vibeflow"
+				}
+			}
+			out = append(out, ManifestSession{
+				Name:            name,
+				Provider:        firstNonEmpty(s.Provider, f.Provider, defaultProvider, "claude"),
+				SessionType:     sessionType,
+				Project:         firstNonEmpty(s.Project, f.Project),
+				Persona:         s.Persona,
+				Branch:          branch,
+				WorkDir:         dir,
+				Worktree:        s.Worktree && branch != "",
+				Base:            firstNonEmpty(s.Base, f.Base),
+				SkipPermissions: s.SkipPermissions,
+				Model:           s.Model,
+			})
+		}
+	}
+	return out
+}
+
+// sessionNames returns the names a fleet session launches under: the fleet
+// name and its own, suffixed -1..-N when Count > 1.
+func (f FleetFile) sessionNames(s FleetSession) []string {
+	name := f.Name + "-" + s.Name
+	count := max(s.Count, 1)
+	if count == 1 {
+		return []string{name}
+	}
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", name, i+1)
+	}
+	return names
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// findFleetFile resolves the fleet file: the given path, else vibeflow.yaml
+// in the current directory or at the root of its git repository.
+func findFleetFile(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	if _, err := os.Stat(fleetFileName); err == nil {
+		return fleetFileName, nil
+	}
+	if root, err := gitIn(".", "rev-parse", "--show-toplevel"); err == nil {
+		candidate := filepath.Join(root, fleetFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no %s in the current directory or repository root (use -f)", fleetFileName)
+}
+
+// loadFleet finds, loads and expands the fleet file.
+func loadFleet(path, defaultProvider string) (FleetFile, []ManifestSession, error) {
+	path, err := findFleetFile(path)
+	if err != nil {
+		return FleetFile{}, nil, err
+	}
+	fleet, err := LoadFleetFile(path)
+	if err != nil {
+		return fleet, nil, err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fleet, nil, err
+	}
+	return fleet, fleet.Expand(filepath.Dir(abs), defaultProvider), nil
+}
+
+// --- up ---
+
+func upCmd() *cobra.Command {
+	var (
+		file    string
+		dryRun  bool
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Launch the sessions described in vibeflow.yaml",
+		Long: `Launch every session in the repository's fleet file (vibeflow.yaml in the
+current directory or at the repository root, or the file given with -f).
+Sessions already running are left alone, so 'vibeflow up' can be re-run after
+editing the file to start only what's new.
+
+A session with 'after' waits for that session to reach its 'when' state
+(done, idle, exited, output:<regexp> or status:<value>); 'vibeflow up' keeps
+watching and launches it then.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			fleet, sessions, err := loadFleet(file, cfg.DefaultProvider)
+			if err != nil {
+				return err
+			}
+			deps := fleet.Dependencies()
+			if err := checkStatusWhens(cfg, deps); err != nil {
+				return err
+			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
+			cache := NewSessionCache()
+			out := cmd.OutOrStdout()
+
+			launch := func(s ManifestSession) error {
+				meta, err := importSession(s, cfg, tmux, store, cache, registry, "")
+				if err != nil {
+					fmt.Fprintf(out, "%-24s failed: %v\n", s.Name, err)
+					return err
+				}
+				hooks.Fire(HookSessionCreate, meta, nil)
+				fmt.Fprintf(out, "%-24s launched (%s, %s)\n", s.Name, meta.Provider, meta.WorkingDir)
+				return nil
+			}
+
+			failed := make(map[string]bool)
+			var held []ManifestSession
+			for _, s := range sessions {
+				if tmux.HasSession(tmux.FullSessionName(s.Provider, s.Name)) {
+					fmt.Fprintf(out, "%-24s already running\n", s.Name)
+					continue
+				}
+				dep, waits := deps[s.Name]
+				if dryRun {
+					desc := s.Provider
+					if s.Persona != "" {
+						desc += "/" + s.Persona
+					}
+					if s.Worktree {
+						desc += " in a worktree of " + s.Branch
+					}
+					if waits {
+						desc += fmt.Sprintf(" after %s (%s)", strings.Join(dep.After, ", "), dep.When)
+					}
+					fmt.Fprintf(out, "%-24s would launch %s\n", s.Name, desc)
+					continue
+				}
+				if waits {
+					held = append(held, s)
+					fmt.Fprintf(out, "%-24s waiting for %s (%s)\n", s.Name, strings.Join(dep.After, ", "), dep.When)
+					continue
+				}
+				if launch(s) != nil {
+					failed[s.Name] = true
+				}
+			}
+			notLaunched := len(failed)
+			if len(held) > 0 {
+				notLaunched += launchWhenReady(out, held, deps, failed, newFleetWatcher(cfg, store, registry, sessions),
+					fleetSampler(tmux, sessions), launch, dependencyPollInterval, timeout)
+			}
+			if notLaunched > 0 {
+				return fmt.Errorf("%d of %d sessions in fleet %q failed to launch", notLaunched, len(sessions), fleet.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Fleet file (default: vibeflow.yaml)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be launched without launching anything")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Give up on sessions still waiting for another after this long (0: no limit)")
+	return cmd
+}
+
+// checkStatusWhens refuses a fleet with status:<value> whens when there is
+// no server to ask, as those sessions would wait forever.
+func checkStatusWhens(cfg *Config, deps map[string]fleetDependency) error {
+	if cfg.ServerURL != "" && cfg.hasAPIAuth() {
+		return nil
+	}
+	for name, dep := range deps {
+		if dep.When.Kind == "status" {
+			return fmt.Errorf("session %q waits for %s, which requires server_url and api_token in config, VIBEFLOW_TOKEN or `vibeflow login`", name, dep.When)
+		}
+	}
+	return nil
+}
+
+// newFleetWatcher builds the watcher `vibeflow up` uses for sessions that
+// come after others, asking the server for status-based whens when one is
+// configured. Fleet sessions are stored under their manifest name.
+func newFleetWatcher(cfg *Config, store *Store, registry *ProviderRegistry, sessions []ManifestSession) *dependencyWatcher {
+	completion, err := newCompletionDetector(cfg.AutoPR, registry)
+	if err != nil {
+		stderrWarnf("%v; using the default completion marker", err)
+		completion, _ = newCompletionDetector(AutoPRConfig{}, nil)
+	}
+	var status func(string) (SessionStatus, bool)
+	if cfg.ServerURL != "" && cfg.hasAPIAuth() {
+		client := newAPIClient(cfg)
+		status = func(name string) (SessionStatus, bool) {
+			meta, found, _ := store.Get(name)
+			if !found || meta.VibeFlowSessionID == "" {
+				return SessionStatus{}, false
+			}
+			st, err := client.GetSessionStatus(meta.VibeFlowSessionID)
+			if err != nil {
+				return SessionStatus{}, false
+			}
+			return *st, true
+		}
+	}
+	return newDependencyWatcher(sessions, completion, status)
+}
+
+// fleetSampler returns a function sampling the panes of fleet sessions by
+// name; sessions that aren't running are absent from its result. One that
+// is running but couldn't be captured gets an empty sample rather than
+// counting as ended.
+func fleetSampler(tmux *TmuxManager, sessions []ManifestSession) func([]string) map[string]ActivitySample {
+	providers := make(map[string]string, len(sessions))
+	for _, s := range sessions {
+		providers[s.Name] = s.Provider
+	}
+	return func(names []string) map[string]ActivitySample {
+		full := make([]string, len(names))
+		for i, name := range names {
+			full[i] = tmux.FullSessionName(providers[name], name)
+		}
+		samples := tmux.CaptureActivitySamples(full, 200)
+		out := make(map[string]ActivitySample, len(names))
+		for i, name := range names {
+			if sample, ok := samples[full[i]]; ok {
+				out[name] = sample
+			} else if tmux.HasSession(full[i]) {
+				out[name] = ActivitySample{}
+			}
+		}
+		return out
+	}
+}
+
+// --- down ---
+
+func downCmd() *cobra.Command {
+	var (
+		file            string
+		cleanupWorktree bool
+	)
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Kill the sessions described in vibeflow.yaml",
+		Long: `Kill every running session of the repository's fleet file. Worktrees are
+kept unless --cleanup-worktree is given, so uncommitted work survives.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			_, sessions, err := loadFleet(file, cfg.DefaultProvider)
+			if err != nil {
+				return err
+			}
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
+			cache := NewSessionCache()
+			out := cmd.OutOrStdout()
+
+			for _, s := range sessions {
+				full := tmux.FullSessionName(s.Provider, s.Name)
+				if !tmux.HasSession(full) {
+					continue
+				}
+				if meta, found, _ := store.Get(s.Name); found {
+					saveArchiveTail(cfg, tmux, store, meta)
+				}
+				if err := tmux.KillSession(full); err != nil {
+					fmt.Fprintf(out, "%-24s kill failed: %v\n", s.Name, err)
+					continue
+				}
+				if meta, found, _ := store.Get(s.Name); found {
+					if cleanupWorktree && meta.WorktreePath != "" {
+						if wm, err := NewWorktreeManager(s.WorkDir, cfg.Worktree.BaseDir); err == nil {
+							if err := wm.Remove(meta.WorktreePath, true); err != nil {
+								fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree: %v\n", err)
+							}
+						}
+					}
+					_ = store.History().End(meta.Name, ExitKilled, time.Now())
+					_ = store.Remove(s.Name)
+					hooks.Fire(HookSessionKill, meta, nil)
+				}
+				_ = cache.Remove(s.Name)
+				fmt.Fprintf(out, "%-24s killed\n", s.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Fleet file (default: vibeflow.yaml)")
+	cmd.Flags().BoolVar(&cleanupWorktree, "cleanup-worktree", false, "Also remove the sessions' git worktrees")
+	return cmd
+}
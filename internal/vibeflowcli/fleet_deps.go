@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dependencyPollInterval is how often `vibeflow up` samples the sessions
+// that held-back sessions are waiting on.
+const dependencyPollInterval = 2 * time.Second
+
+// fleetWhen is the state a fleet session's After session has to reach
+// before `vibeflow up` launches it.
+type fleetWhen struct {
+	Kind    string         // done, idle, exited, output or status
+	Pattern *regexp.Regexp // output: what the pane has to show
+	Status  string         // status: the server status or phase to wait for
+}
+
+// parseFleetWhen parses a fleet session's `when`: done (the default: the
+// agent printed its completion marker), idle, exited, output:<regexp> or
+// status:<value> (the VibeFlow server reports that status or phase).
+func parseFleetWhen(s string) (fleetWhen, error) {
+	kind, arg, hasArg := strings.Cut(strings.TrimSpace(s), ":")
+	switch kind {
+	case "", "done", "idle", "exited":
+		if !hasArg {
+			return fleetWhen{Kind: firstNonEmpty(kind, "done")}, nil
+		}
+	case "output":
+		if arg == "" {
+			return fleetWhen{}, fmt.Errorf("when: output needs a pattern")
+		}
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return fleetWhen{}, fmt.Errorf("when: %w", err)
+		}
+		return fleetWhen{Kind: kind, Pattern: re}, nil
+	case "status":
+		if arg = strings.TrimSpace(arg); arg == "" {
+			return fleetWhen{}, fmt.Errorf("when: status needs a value")
+		}
+		return fleetWhen{Kind: kind, Status: arg}, nil
+	}
+	return fleetWhen{}, fmt.Errorf("when must be done, idle, exited, output:<regexp> or status:<value>, not %q", s)
+}
+
+// String returns the when as written in a fleet file.
+func (w fleetWhen) String() string {
+	switch w.Kind {
+	case "output":
+		return "output:" + w.Pattern.String()
+	case "status":
+		return "status:" + w.Status
+	}
+	return w.Kind
+}
+
+// validateOrder checks every After names another session of the fleet,
+// every When parses and no sessions wait on each other in a cycle.
+func (f FleetFile) validateOrder() error {
+	byName := make(map[string]FleetSession, len(f.Sessions))
+	for _, s := range f.Sessions {
+		byName[s.Name] = s
+	}
+	for _, s := range f.Sessions {
+		if s.After == "" {
+			if s.When != "" {
+				return fmt.Errorf("session %q: when needs after", s.Name)
+			}
+			continue
+		}
+		if _, ok := byName[s.After]; !ok || s.After == s.Name {
+			return fmt.Errorf("session %q: after names no other session %q", s.Name, s.After)
+		}
+		if _, err := parseFleetWhen(s.When); err != nil {
+			return fmt.Errorf("session %q: %w", s.Name, err)
+		}
+	}
+	for _, s := range f.Sessions {
+		seen := map[string]bool{s.Name: true}
+		for next := s.After; next != ""; next = byName[next].After {
+			if seen[next] {
+				return fmt.Errorf("session %q: after forms a cycle", s.Name)
+			}
+			seen[next] = true
+		}
+	}
+	return nil
+}
+
+// fleetDependency holds a session back until every session it comes after
+// (each copy, when that one has a count) reaches When.
+type fleetDependency struct {
+	After []string
+	When  fleetWhen
+}
+
+// Dependencies maps the name of each session that comes after another to
+// what it waits for. Sessions that launch straight away are absent.
+func (f FleetFile) Dependencies() map[string]fleetDependency {
+	byName := make(map[string]FleetSession, len(f.Sessions))
+	for _, s := range f.Sessions {
+		byName[s.Name] = s
+	}
+	deps := make(map[string]fleetDependency)
+	for _, s := range f.Sessions {
+		if s.After == "" {
+			continue
+		}
+		when, _ := parseFleetWhen(s.When) // validated by LoadFleetFile
+		dep := fleetDependency{After: f.sessionNames(byName[s.After]), When: when}
+		for _, name := range f.sessionNames(s) {
+			deps[name] = dep
+		}
+	}
+	return deps
+}
+
+// dependencyWatcher decides when sessions waiting on others may launch,
+// from the other sessions' pane output, their exit and the server's status.
+// A session keeps a state once it has reached it: an architect that
+// finished its plan and then took a follow-up question still finished.
+type dependencyWatcher struct {
+	completion *completionDetector
+	activity   *ActivityMonitor
+	providers  map[string]string // session name → provider key
+	reached    map[string]bool   // session name + when → reached
+
+	// status returns the server's status for a session, or false when
+	// there is none. Nil without a server.
+	status func(name string) (SessionStatus, bool)
+}
+
+func newDependencyWatcher(sessions []ManifestSession, completion *completionDetector, status func(string) (SessionStatus, bool)) *dependencyWatcher {
+	w := &dependencyWatcher{
+		completion: completion,
+		activity:   NewActivityMonitor(0),
+		providers:  make(map[string]string, len(sessions)),
+		reached:    make(map[string]bool),
+		status:     status,
+	}
+	for _, s := range sessions {
+		w.providers[s.Name] = s.Provider
+	}
+	return w
+}
+
+// check reports whether the session name has reached when, given its
+// latest pane sample; running is false once the session has ended. It
+// returns an error when the session ended without reaching it.
+func (w *dependencyWatcher) check(name string, when fleetWhen, sample ActivitySample, running bool, now time.Time) (bool, error) {
+	key := name + "\x00" + when.String()
+	if w.reached[key] {
+		return true, nil
+	}
+	var met bool
+	if !running {
+		// A provider whose agent exits once its task is done is done once
+		// it has gone.
+		met = when.Kind == "exited" || when.Kind == "done" && w.completion != nil && w.completion.onExit[w.providers[name]]
+		if !met {
+			return false, fmt.Errorf("%s ended before reaching %s", name, when)
+		}
+	} else {
+		switch when.Kind {
+		case "done":
+			met = w.completion.OutputDone(w.providers[name], sample.Output)
+		case "idle":
+			met = w.activity.Observe(name, sample.Output, sample.Cursor, now) == ActivityIdle
+		case "output":
+			met = when.Pattern.MatchString(stripANSI(sample.Output))
+		case "status":
+			if w.status != nil {
+				if st, ok := w.status(name); ok {
+					met = strings.EqualFold(st.Status, when.Status) || strings.EqualFold(st.Phase, when.Status)
+				}
+			}
+		}
+	}
+	if met {
+		w.reached[key] = true
+	}
+	return met, nil
+}
+
+// launchWhenReady launches held sessions as the sessions they come after
+// reach their state, until each has launched or can't, or until timeout
+// (0: no limit) passes. failed holds the sessions that failed to launch
+// so far; sample returns the panes of the running sessions among names,
+// keyed by name, and launch starts one session. It returns how many held
+// sessions did not launch.
+func launchWhenReady(out io.Writer, held []ManifestSession, deps map[string]fleetDependency, failed map[string]bool, w *dependencyWatcher, sample func(names []string) map[string]ActivitySample, launch func(ManifestSession) error, interval, timeout time.Duration) int {
+	notLaunched := 0
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for len(held) > 0 {
+		waiting := make(map[string]bool, len(held))
+		upstream := make(map[string]bool)
+		for _, s := range held {
+			waiting[s.Name] = true
+			for _, name := range deps[s.Name].After {
+				upstream[name] = true
+			}
+		}
+		var names []string
+		for name := range upstream {
+			if !waiting[name] && !failed[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		samples := sample(names)
+		now := time.Now()
+
+		var still []ManifestSession
+		for _, s := range held {
+			dep := deps[s.Name]
+			ready := true
+			var err error
+			for _, name := range dep.After {
+				if failed[name] {
+					err = fmt.Errorf("%s did not launch", name)
+					break
+				}
+				if waiting[name] {
+					ready = false
+					continue
+				}
+				sample, running := samples[name]
+				met, checkErr := w.check(name, dep.When, sample, running, now)
+				if checkErr != nil {
+					err = checkErr
+					break
+				}
+				ready = ready && met
+			}
+			switch {
+			case err != nil:
+				failed[s.Name] = true
+				notLaunched++
+				fmt.Fprintf(out, "%-24s skipped: %v\n", s.Name, err)
+			case ready:
+				if launch(s) != nil {
+					failed[s.Name] = true
+					notLaunched++
+				}
+			default:
+				still = append(still, s)
+			}
+		}
+		held = still
+		if len(held) == 0 {
+			break
+		}
+		if !deadline.IsZero() && !now.Before(deadline) {
+			for _, s := range held {
+				dep := deps[s.Name]
+				fmt.Fprintf(out, "%-24s not launched: timed out waiting for %s (%s)\n", s.Name, strings.Join(dep.After, ", "), dep.When)
+			}
+			return notLaunched + len(held)
+		}
+		wait := interval
+		if !deadline.IsZero() {
+			if left := time.Until(deadline); left < wait {
+				wait = left
+			}
+		}
+		time.Sleep(wait)
+	}
+	return notLaunched
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFleetWhen(t *testing.T) {
+	tests := []struct {
+		in, want, wantErr string
+	}{
+		{"", "done", ""},
+		{"done", "done", ""},
+		{"idle", "idle", ""},
+		{" exited ", "exited", ""},
+		{"output:PLAN (READY|DONE)", "output:PLAN (READY|DONE)", ""},
+		{"status:completed", "status:completed", ""},
+		{"output:", "", "needs a pattern"},
+		{"output:(", "", "missing closing"},
+		{"status: ", "", "needs a value"},
+		{"idle:5m", "", "when must be"},
+		{"finished", "", "when must be"},
+	}
+	for _, tt := range tests {
+		got, err := parseFleetWhen(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseFleetWhen(%q) err = %v, want it to mention %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("parseFleetWhen(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFleetFile_Dependencies(t *testing.T) {
+	fleet, err := LoadFleetFile(writeFleetFile(t, `sessions:
+  - name: architect
+  - name: dev
+    count: 2
+    after: architect
+    when: output:PLAN READY
+  - name: reviewer
+    after: dev
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps := fleet.Dependencies()
+	if _, ok := deps["shop-architect"]; ok {
+		t.Error("architect comes after nothing, yet has a dependency")
+	}
+	for _, name := range []string{"shop-dev-1", "shop-dev-2"} {
+		dep := deps[name]
+		if !reflect.DeepEqual(dep.After, []string{"shop-architect"}) || dep.When.String() != "output:PLAN READY" {
+			t.Errorf("deps[%s] = %v %s", name, dep.After, dep.When)
+		}
+	}
+	// A session after one with a count waits for every copy.
+	if dep := deps["shop-reviewer"]; !reflect.DeepEqual(dep.After, []string{"shop-dev-1", "shop-dev-2"}) || dep.When.Kind != "done" {
+		t.Errorf("deps[shop-reviewer] = %v %s", dep.After, dep.When)
+	}
+}
+
+func testDependencyWatcher(t *testing.T) *dependencyWatcher {
+	t.Helper()
+	cfg := &Config{Providers: map[string]Provider{
+		"claude": {Binary: "claude"},
+		"codex":  {Binary: "codex"},
+	}}
+	completion, err := newCompletionDetector(AutoPRConfig{}, NewProviderRegistry(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newDependencyWatcher([]ManifestSession{
+		{Name: "shop-architect", Provider: "claude"},
+		{Name: "shop-coder", Provider: "codex"},
+	}, completion, nil)
+}
+
+func TestDependencyWatcher_Check(t *testing.T) {
+	w := testDependencyWatcher(t)
+	now := time.Now()
+	done, _ := parseFleetWhen("done")
+	output, _ := parseFleetWhen("output:PLAN READY")
+
+	if met, err := w.check("shop-architect", done, ActivitySample{Output: "planning..."}, true, now); met || err != nil {
+		t.Errorf("still working: met = %v, err = %v", met, err)
+	}
+	if met, _ := w.check("shop-architect", done, ActivitySample{Output: "plan written\nVIBEFLOW_DONE"}, true, now); !met {
+		t.Error("completion marker not taken as done")
+	}
+	// Once done, the marker scrolling away doesn't undo it.
+	if met, _ := w.check("shop-architect", done, ActivitySample{Output: "answering a question"}, true, now); !met {
+		t.Error("done was forgotten once the marker scrolled away")
+	}
+	if met, _ := w.check("shop-architect", output, ActivitySample{Output: "\x1b[1mPLAN READY\x1b[0m"}, true, now); !met {
+		t.Error("styled output not matched")
+	}
+
+	// Codex exits when it's done; Claude ending without its marker can
+	// never be done.
+	if met, err := w.check("shop-coder", done, ActivitySample{}, false, now); !met || err != nil {
+		t.Errorf("codex exit: met = %v, err = %v", met, err)
+	}
+	w = testDependencyWatcher(t)
+	if _, err := w.check("shop-architect", done, ActivitySample{}, false, now); err == nil || !strings.Contains(err.Error(), "ended before reaching done") {
+		t.Errorf("err = %v, want the architect to have ended before it was done", err)
+	}
+	exited, _ := parseFleetWhen("exited")
+	if met, err := w.check("shop-architect", exited, ActivitySample{}, false, now); !met || err != nil {
+		t.Errorf("exited: met = %v, err = %v", met, err)
+	}
+}
+
+func TestDependencyWatcher_Idle(t *testing.T) {
+	w := testDependencyWatcher(t)
+	idle, _ := parseFleetWhen("idle")
+	start := time.Now()
+	sample := ActivitySample{Output: "plan written", Cursor: "0,1"}
+	if met, _ := w.check("shop-architect", idle, sample, true, start); met {
+		t.Error("idle on the first sample")
+	}
+	if met, _ := w.check("shop-architect", idle, sample, true, start.Add(defaultIdleAfter)); !met {
+		t.Error("not idle after the output stopped changing")
+	}
+}
+
+func TestDependencyWatcher_Status(t *testing.T) {
+	w := testDependencyWatcher(t)
+	phase := "planning"
+	w.status = func(name string) (SessionStatus, bool) {
+		return SessionStatus{Status: "active", Phase: phase}, name == "shop-architect"
+	}
+	when, _ := parseFleetWhen("status:Plan_Approved")
+	if met, _ := w.check("shop-architect", when, ActivitySample{}, true, time.Now()); met {
+		t.Error("met while the server still reports planning")
+	}
+	phase = "plan_approved"
+	if met, _ := w.check("shop-architect", when, ActivitySample{}, true, time.Now()); !met {
+		t.Error("not met once the server reports the phase")
+	}
+}
+
+func TestLaunchWhenReady(t *testing.T) {
+	fleet := FleetFile{Name: "shop", Sessions: []FleetSession{
+		{Name: "architect"},
+		{Name: "dev", After: "architect", When: "output:PLAN READY"},
+		{Name: "reviewer", After: "dev", When: "exited"},
+		{Name: "docs", After: "broken"},
+		{Name: "broken"},
+	}}
+	if err := fleet.validateOrder(); err != nil {
+		t.Fatal(err)
+	}
+	sessions := fleet.Expand(t.TempDir(), "claude")
+	deps := fleet.Dependencies()
+	w := newDependencyWatcher(sessions, nil, nil)
+
+	running := map[string]string{"shop-architect": "drafting"}
+	rounds := 0
+	sample := func(names []string) map[string]ActivitySample {
+		rounds++
+		if rounds == 2 {
+			running["shop-architect"] = "PLAN READY"
+		}
+		out := make(map[string]ActivitySample)
+		for _, name := range names {
+			if output, ok := running[name]; ok {
+				out[name] = ActivitySample{Output: output}
+			}
+		}
+		return out
+	}
+	var launched []string
+	launch := func(s ManifestSession) error {
+		launched = append(launched, s.Name)
+		if s.Name == "shop-dev" {
+			delete(running, s.Name) // dev exits straight away
+		}
+		return nil
+	}
+	var out bytes.Buffer
+	held := []ManifestSession{sessions[1], sessions[2], sessions[3]}
+	failed := map[string]bool{"shop-broken": true}
+	notLaunched := launchWhenReady(&out, held, deps, failed, w, sample, launch, time.Millisecond, 0)
+
+	if want := []string{"shop-dev", "shop-reviewer"}; !reflect.DeepEqual(launched, want) {
+		t.Errorf("launched %v, want %v", launched, want)
+	}
+	if notLaunched != 1 || !strings.Contains(out.String(), "shop-docs                skipped: shop-broken did not launch") {
+		t.Errorf("notLaunched = %d, output:\n%s", notLaunched, out.String())
+	}
+}
+
+func TestLaunchWhenReady_Timeout(t *testing.T) {
+	fleet := FleetFile{Name: "shop", Sessions: []FleetSession{
+		{Name: "architect"},
+		{Name: "dev", After: "architect"},
+	}}
+	sessions := fleet.Expand(t.TempDir(), "claude")
+	w := newDependencyWatcher(sessions, nil, nil)
+	sample := func(names []string) map[string]ActivitySample {
+		return map[string]ActivitySample{"shop-architect": {Output: "still planning"}}
+	}
+	launch := func(ManifestSession) error { return errors.New("launched") }
+	var out bytes.Buffer
+	n := launchWhenReady(&out, sessions[1:], fleet.Dependencies(), map[string]bool{}, w, sample, launch, time.Millisecond, 20*time.Millisecond)
+	if n != 1 || !strings.Contains(out.String(), "timed out waiting for shop-architect (done)") {
+		t.Errorf("n = %d, output:\n%s", n, out.String())
+	}
+}
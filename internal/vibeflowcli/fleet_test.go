@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFleetFile(t *testing.T, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "shop")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, fleetFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFleetFile_Validates(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no sessions", "name: x\n", "no sessions"},
+		{"missing name", "sessions:\n  - persona: developer\n", "has no name"},
+		{"duplicate", "sessions:\n  - name: a\n  - name: a\n", "duplicate"},
+		{"negative count", "sessions:\n  - name: a\n    count: -1\n", "negative"},
+		{"bad type", "sessions:\n  - name: a\n    session_type: cloud\n", "session_type"},
+		{"unknown after", "sessions:\n  - name: a\n    after: b\n", "no other session"},
+		{"after itself", "sessions:\n  - name: a\n    after: a\n", "no other session"},
+		{"when without after", "sessions:\n  - name: a\n    when: idle\n", "when needs after"},
+		{"bad when", "sessions:\n  - name: a\n  - name: b\n    after: a\n    when: finished\n", "when must be"},
+		{"cycle", "sessions:\n  - name: a\n    after: b\n  - name: b\n    after: a\n", "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFleetFile(writeFleetFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFleetFile_Expand(t *testing.T) {
+	path := writeFleetFile(t, `provider: codex
+project: shop
+base: main
+sessions:
+  - name: architect
+    persona: architect
+    provider: claude
+  - name: dev
+    persona: developer
+    count: 2
+    worktree: true
+  - name: scratch
+`)
+	fleet, err := LoadFleetFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fleet.Name != "shop" {
+		t.Errorf("Name = %q, want the directory name", fleet.Name)
+	}
+	got := fleet.Expand("/repo", "gemini")
+	if len(got) != 4 {
+		t.Fatalf("Expand returned %d sessions, want 4: %+v", len(got), got)
+	}
+
+	arch := got[0]
+	if arch.Name != "shop-architect" || arch.Provider != "claude" || arch.SessionType != "vibeflow" || arch.Worktree {
+		t.Errorf("architect = %+v", arch)
+	}
+	for i, s := range got[1:3] {
+		wantName := []string{"shop-dev-1", "shop-dev-2"}[i]
+		wantBranch := []string{"shop/dev-1", "shop/dev-2"}[i]
+		if s.Name != wantName || s.Branch != wantBranch || !s.Worktree {
+			t.Errorf("dev %d = name %q branch %q worktree %v, want %q %q true", i+1, s.Name, s.Branch, s.Worktree, wantName, wantBranch)
+		}
+		if s.Provider != "codex" || s.Project != "shop" || s.Base != "main" || s.WorkDir != "/repo" {
+			t.Errorf("dev %d did not inherit fleet defaults: %+v", i+1, s)
+		}
+	}
+	if scratch := got[3]; scratch.SessionType != "vanilla" || scratch.Branch != "" || scratch.Worktree {
+		t.Errorf("scratch = %+v, want a vanilla session in the repo itself", scratch)
+	}
+}
+
+func TestCheckStatusWhens(t *testing.T) {
+	status, _ := parseFleetWhen("status:plan_approved")
+	done, _ := parseFleetWhen("done")
+	deps := map[string]fleetDependency{"dev": {After: []string{"architect"}, When: status}}
+	if err := checkStatusWhens(&Config{ServerURL: "http://x"}, deps); err == nil || !strings.Contains(err.Error(), `"dev"`) {
+		t.Errorf("err = %v, want a status when without API auth refused", err)
+	}
+	if err := checkStatusWhens(&Config{ServerURL: "http://x", APIToken: "t"}, deps); err != nil {
+		t.Errorf("with a token: %v", err)
+	}
+	if err := checkStatusWhens(&Config{}, map[string]fleetDependency{"dev": {When: done}}); err != nil {
+		t.Errorf("a done when needs no server: %v", err)
+	}
+}
+
+func TestNewFleetWatcher_StatusUsesStoredSession(t *testing.T) {
+	withTempRoot(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/vibeflow/sessions/sid-1/status" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SessionStatus{SessionID: "sid-1", Status: "active", Phase: "plan_approved"})
+	}))
+	defer srv.Close()
+	store := NewStore()
+	// Fleet sessions are stored under their manifest name.
+	if err := store.Add(SessionMeta{Name: "architect", TmuxSession: "vibeflow_claude-architect", Provider: "claude", VibeFlowSessionID: "sid-1"}); err != nil {
+		t.Fatal(err)
+	}
+	sessions := []ManifestSession{{Name: "architect", Provider: "claude"}}
+	w := newFleetWatcher(&Config{ServerURL: srv.URL, APIToken: "t"}, store, nil, sessions)
+	when, _ := parseFleetWhen("status:plan_approved")
+	if met, err := w.check("architect", when, ActivitySample{}, true, time.Now()); err != nil || !met {
+		t.Errorf("check = %v, %v; want the server's phase to satisfy the when", met, err)
+	}
+}
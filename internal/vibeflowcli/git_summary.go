@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gitSummaryCommits is how many recent commits a GitSummary lists.
+const gitSummaryCommits = 3
+
+// GitSummary is what the detail panel shows about a session's working
+// directory: whether the agent has changed or committed anything.
+type GitSummary struct {
+	Changed int      // files with uncommitted changes (`git status --porcelain`)
+	Base    string   // what Ahead/Behind compare against; "" when there is nothing to compare with
+	Ahead   int      // commits on HEAD not on Base
+	Behind  int      // commits on Base not on HEAD
+	Commits []string // `git log --oneline`, newest first
+
+	Checkpoints []Checkpoint // the session's recovery checkpoints, newest first
+}
+
+// loadGitSummary collects a GitSummary for the repository checkout at dir.
+func loadGitSummary(dir string) (GitSummary, error) {
+	var g GitSummary
+	status, err := gitIn(dir, "status", "--porcelain")
+	if err != nil {
+		return g, err
+	}
+	if status != "" {
+		g.Changed = strings.Count(status, "\n") + 1
+	}
+	if log, err := gitIn(dir, "log", "--oneline", "--no-decorate", fmt.Sprintf("-%d", gitSummaryCommits)); err == nil && log != "" {
+		g.Commits = strings.Split(log, "\n")
+	}
+	if base := gitSummaryBase(dir); base != "" {
+		if counts, err := gitIn(dir, "rev-list", "--left-right", "--count", base+"...HEAD"); err == nil {
+			if f := strings.Fields(counts); len(f) == 2 {
+				g.Base = base
+				g.Behind, _ = strconv.Atoi(f[0])
+				g.Ahead, _ = strconv.Atoi(f[1])
+			}
+		}
+	}
+	return g, nil
+}
+
+// gitSummaryBase picks what dir's HEAD is compared against: its upstream,
+// else the remote default branch, else the branch checked out in the main
+// worktree — the same base MergeBaseBranch uses for merging back. It returns
+// "" for the main worktree's own branch when there is no remote.
+func gitSummaryBase(dir string) string {
+	if up, err := gitIn(dir, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"); err == nil {
+		return up
+	}
+	if def, err := gitIn(dir, "symbolic-ref", "-q", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return def
+	}
+	list, err := gitIn(dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(list, "\n") {
+		if line == "" {
+			break // end of the first (main) worktree's entry
+		}
+		if ref, ok := strings.CutPrefix(line, "branch "); ok {
+			base := strings.TrimPrefix(ref, "refs/heads/")
+			if cur, err := gitIn(dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && cur == base {
+				return ""
+			}
+			return base
+		}
+	}
+	return ""
+}
+
+// describe renders the one-line summary: "2 changed · 3 ahead, 1 behind main".
+func (g GitSummary) describe() string {
+	s := "clean"
+	if g.Changed > 0 {
+		s = fmt.Sprintf("%d changed", g.Changed)
+	}
+	if g.Base != "" {
+		s += fmt.Sprintf(" · %d ahead, %d behind %s", g.Ahead, g.Behind, g.Base)
+	}
+	return s
+}
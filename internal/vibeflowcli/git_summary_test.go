@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadGitSummary(t *testing.T) {
+	wm, wt := newMergeTestWorktree(t)
+	commitFile(t, wt, "feature.go", "package x\n")
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := loadGitSummary(wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := wm.MergeBaseBranch()
+	if g.Changed != 1 || g.Base != base || g.Ahead != 1 || g.Behind != 0 {
+		t.Errorf("summary = %+v, want 1 changed, 1 ahead of %s", g, base)
+	}
+	if len(g.Commits) != 2 || !strings.Contains(g.Commits[0], "add feature.go") {
+		t.Errorf("Commits = %q, want the feature commit first", g.Commits)
+	}
+	if got, want := g.describe(), "1 changed · 1 ahead, 0 behind "+base; got != want {
+		t.Errorf("describe() = %q, want %q", got, want)
+	}
+
+	// The main checkout has nothing to compare its own branch with.
+	g, err = loadGitSummary(wm.RepoRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Base != "" || strings.Contains(g.describe(), "ahead") {
+		t.Errorf("main checkout summary = %+v (%q), want no base", g, g.describe())
+	}
+
+	if _, err := loadGitSummary(t.TempDir()); err == nil {
+		t.Error("a directory outside git should be an error")
+	}
+}
+
+func TestGitSummary_ShownForSelectedSession(t *testing.T) {
+	_, wt := newMergeTestWorktree(t)
+	commitFile(t, wt, "feature.go", "package x\n")
+	m := Model{
+		config:   &Config{},
+		logger:   &Logger{},
+		sessions: []SessionRow{{Name: "claude-a", WorktreePath: wt}},
+	}
+
+	now := time.Now()
+	m, cmd := m.handleGitSummaryTick(now)
+	if m.gitFetchName != "claude-a" || cmd == nil {
+		t.Fatalf("first tick did not read the selected session (name %q)", m.gitFetchName)
+	}
+	if m2, _ := m.handleGitSummaryTick(now.Add(time.Second)); !m2.gitFetchedAt.Equal(now) {
+		t.Error("git summary re-read before gitSummaryRefresh elapsed")
+	}
+
+	summary, err := loadGitSummary(wt)
+	m = m.applyGitSummary(gitSummaryMsg{name: "claude-a", summary: summary, err: err})
+	panel := m.renderDetailPanel(80, 30)
+	for _, want := range []string{"Git", "1 ahead", "Commits", "add feature.go"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("detail panel missing %q:\n%s", want, panel)
+		}
+	}
+
+	m = m.applyGitSummary(gitSummaryMsg{name: "claude-a", err: os.ErrNotExist})
+	if _, ok := m.gitSummaries["claude-a"]; ok {
+		t.Error("a failed read should drop the stale summary")
+	}
+}
@@ -17,7 +17,13 @@
 package vibeflowcli
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -58,27 +64,116 @@ type SessionHealth struct {
 	LastRecoveryAt time.Time
 	BackoffUntil   time.Time
 	LastOutput     string // previous capture output for change detection
+	// Acknowledged mutes a failed session's alert until it fails again.
+	Acknowledged bool
+	// SnoozedUntil holds off recovery attempts; errors are still detected.
+	SnoozedUntil time.Time
+}
+
+// Snoozed reports whether recovery is snoozed at now.
+func (sh *SessionHealth) Snoozed(now time.Time) bool {
+	return now.Before(sh.SnoozedUntil)
+}
+
+// SavedHealth is the part of a session's health state that is persisted in
+// the store, so a restarted vibeflow remembers spent recovery attempts,
+// backoffs, acknowledgements and snoozes.
+type SavedHealth struct {
+	Provider       string       `json:"provider,omitempty"`
+	Status         HealthStatus `json:"status"`
+	Pattern        string       `json:"pattern,omitempty"` // matched pattern's description
+	LastErrorAt    time.Time    `json:"last_error_at,omitempty"`
+	RecoveryCount  int          `json:"recovery_count,omitempty"`
+	LastRecoveryAt time.Time    `json:"last_recovery_at,omitempty"`
+	BackoffUntil   time.Time    `json:"backoff_until,omitempty"`
+	Acknowledged   bool         `json:"acknowledged,omitempty"`
+	SnoozedUntil   time.Time    `json:"snoozed_until,omitempty"`
+}
+
+// saved returns the persisted part of sh.
+func (sh *SessionHealth) saved() SavedHealth {
+	h := SavedHealth{
+		Provider:       sh.Provider,
+		Status:         sh.Status,
+		LastErrorAt:    sh.LastErrorAt,
+		RecoveryCount:  sh.RecoveryCount,
+		LastRecoveryAt: sh.LastRecoveryAt,
+		BackoffUntil:   sh.BackoffUntil,
+		Acknowledged:   sh.Acknowledged,
+		SnoozedUntil:   sh.SnoozedUntil,
+	}
+	if sh.MatchedPattern != nil {
+		h.Pattern = sh.MatchedPattern.Description
+	}
+	return h
+}
+
+// idle reports whether h holds nothing worth keeping: a healthy session
+// with no spent attempts, backoff or snooze.
+func (h SavedHealth) idle() bool {
+	return h.Status == HealthHealthy && h.RecoveryCount == 0 && h.BackoffUntil.IsZero() &&
+		h.SnoozedUntil.IsZero() && !h.Acknowledged
 }
 
 // HealthMonitor manages health state for all active sessions and coordinates
-// error detection + auto-recovery via SendKeys.
+// error detection + auto-recovery via SendKeys. It is safe for concurrent
+// use; once Restore has given it a store, state changes are persisted there.
 type HealthMonitor struct {
+	mu       sync.Mutex
 	sessions map[string]*SessionHealth // keyed by tmux session name
 	registry *ErrorPatternRegistry
 	tmux     *TmuxManager
 	config   ErrorRecoveryConfig
 	logger   *Logger
+
+	cooldowns map[string]time.Time            // provider → end of its rate-limit cooldown
+	rateHits  map[string]map[string]time.Time // provider → session → when it last hit a rate limit
+
+	store *Store                 // nil until Restore; state is then kept in memory only
+	saved map[string]SavedHealth // full tmux name → state last written to (or read from) store
+	dirty map[string]bool        // full tmux name → saved state not yet written to store
+
+	// flushMu serializes store writes, which are made without holding mu
+	// so a slow disk doesn't stall the TUI's accessors.
+	flushMu sync.Mutex
+
+	attempting map[string]bool // full tmux name → recovery attempt under way
 }
 
 // NewHealthMonitor creates a health monitor wired to the given dependencies.
 func NewHealthMonitor(registry *ErrorPatternRegistry, tmux *TmuxManager, cfg ErrorRecoveryConfig, logger *Logger) *HealthMonitor {
 	return &HealthMonitor{
-		sessions: make(map[string]*SessionHealth),
-		registry: registry,
-		tmux:     tmux,
-		config:   cfg,
-		logger:   logger,
+		sessions:  make(map[string]*SessionHealth),
+		registry:  registry,
+		tmux:      tmux,
+		config:    cfg,
+		logger:    logger,
+		cooldowns: make(map[string]time.Time),
+		rateHits:  make(map[string]map[string]time.Time),
+		saved:     make(map[string]SavedHealth),
+		dirty:     make(map[string]bool),
+
+		attempting: make(map[string]bool),
+	}
+}
+
+// Restore loads the health state saved in store by an earlier vibeflow and
+// persists later changes to it. A session's saved state is picked up the
+// first time the session is checked or looked up.
+func (hm *HealthMonitor) Restore(store *Store) error {
+	saved, err := store.Health()
+	if err != nil {
+		return err
+	}
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.store = store
+	for name, h := range saved {
+		if _, ok := hm.saved[name]; !ok {
+			hm.saved[name] = h
+		}
 	}
+	return nil
 }
 
 // CheckOutput scans captured pane output for a session and updates health state.
@@ -89,14 +184,24 @@ func (hm *HealthMonitor) CheckOutput(sessionName, provider, output string, isAtt
 	if !hm.config.Enabled {
 		return false
 	}
-
+	defer hm.flush()
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
 	sh := hm.getOrCreate(sessionName, provider)
+	defer hm.persist(sh)
 
 	// If session has failed, don't do anything further (manual intervention needed).
 	if sh.Status == HealthFailed {
 		return false
 	}
 
+	// Pick up edits to error-patterns.yaml without a restart.
+	if reloaded, err := hm.registry.ReloadIfChanged(); err != nil {
+		hm.logger.Warn("health: error patterns not reloaded: %v", err)
+	} else if reloaded && hm.registry.path != "" {
+		hm.logger.Info("health: loaded error patterns from %s", hm.registry.path)
+	}
+
 	// Only scan the last 10 lines of output for error patterns.
 	tail := lastNLines(output, 10)
 	match := hm.registry.Match(provider, tail)
@@ -108,6 +213,7 @@ func (hm *HealthMonitor) CheckOutput(sessionName, provider, output string, isAtt
 			sh.Status = HealthHealthy
 			sh.RecoveryCount = 0
 			sh.MatchedPattern = nil
+			sh.BackoffUntil = time.Time{}
 		}
 		sh.LastOutput = output
 		return false
@@ -133,6 +239,9 @@ func (hm *HealthMonitor) CheckOutput(sessionName, provider, output string, isAtt
 		sh.MatchedPattern = match
 		sh.LastOutput = output
 		hm.logger.Info("health: session %s error detected: %s (debouncing)", sessionName, match.Description)
+		if match.RequiresBackoff {
+			hm.noteRateLimit(sh, now)
+		}
 		return false
 
 	case HealthErrorDetected:
@@ -170,6 +279,9 @@ func (hm *HealthMonitor) CheckOutput(sessionName, provider, output string, isAtt
 		sh.Status = HealthErrorDetected
 		sh.LastErrorAt = now
 		sh.LastOutput = output
+		if match.RequiresBackoff {
+			hm.noteRateLimit(sh, now)
+		}
 		return false
 	}
 
@@ -177,79 +289,475 @@ func (hm *HealthMonitor) CheckOutput(sessionName, provider, output string, isAtt
 	return false
 }
 
-// AttemptRecovery sends the recovery message for a session and updates state.
+// AttemptRecovery runs the matched pattern's recovery action for a session
+// (by default, sending its recovery message) and updates state. A call for
+// a session whose attempt is still under way does nothing.
 func (hm *HealthMonitor) AttemptRecovery(sessionName string) error {
-	sh, ok := hm.sessions[sessionName]
-	if !ok || sh.MatchedPattern == nil {
+	hm.mu.Lock()
+	sh := hm.lookup(sessionName)
+	if sh == nil || sh.MatchedPattern == nil || hm.attempting[sh.SessionName] {
+		hm.mu.Unlock()
 		return nil
 	}
+	key, p, snapshot := sh.SessionName, sh.MatchedPattern, *sh
+	hm.attempting[key] = true
+	hm.mu.Unlock()
+	defer func() {
+		hm.mu.Lock()
+		delete(hm.attempting, key)
+		hm.mu.Unlock()
+		hm.flush()
+	}()
+
+	// The store, git and tmux are used without holding the lock, so a slow
+	// one doesn't stall the TUI.
+	msg, checkpoint := "", true
+	switch p.Action {
+	case ActionNotify:
+		checkpoint = false
+	case ActionRestart, ActionRelaunch, ActionHook:
+	default:
+		msg = hm.recoveryMessage(&snapshot)
+		checkpoint = msg != ""
+	}
+	if checkpoint {
+		hm.checkpoint(&snapshot)
+	}
+	if !hm.update(sessionName, p, nil) {
+		return nil // the error cleared or changed meanwhile
+	}
 
-	msg := sh.MatchedPattern.RecoveryMessage
-	if msg == "" {
+	switch p.Action {
+	case ActionNotify:
+		hm.update(sessionName, p, func(sh *SessionHealth) {
+			sh.Status = HealthFailed
+			hm.logger.Warn("health: session %s escalated: %s", sessionName, p.Description)
+		})
 		return nil
+
+	case ActionRestart, ActionRelaunch:
+		hm.logger.Info("health: session %s recovery attempt %d/%d: %s",
+			sessionName, snapshot.RecoveryCount+1, hm.config.MaxRetries, p.Action)
+		if err := hm.tmux.RespawnPane(sessionName, p.Action == ActionRelaunch); err != nil {
+			hm.logger.Error("health: session %s %s failed: %v", sessionName, p.Action, err)
+			return err
+		}
+
+	case ActionHook:
+		hm.logger.Info("health: session %s recovery attempt %d/%d: running hook '%s'",
+			sessionName, snapshot.RecoveryCount+1, hm.config.MaxRetries, truncateLog(p.Hook, 60))
+		if err := hm.startHook(p.Hook, &snapshot); err != nil {
+			hm.logger.Error("health: session %s hook failed to start: %v", sessionName, err)
+			return err
+		}
+
+	default:
+		if msg == "" {
+			return nil
+		}
+		hm.logger.Info("health: session %s recovery attempt %d/%d: sending '%s'",
+			sessionName, snapshot.RecoveryCount+1, hm.config.MaxRetries, truncateLog(msg, 60))
+		if err := hm.tmux.SendKeys(sessionName, msg); err != nil {
+			hm.logger.Error("health: session %s send-keys failed: %v", sessionName, err)
+			return err
+		}
 	}
 
-	hm.logger.Info("health: session %s recovery attempt %d/%d: sending '%s'",
-		sessionName, sh.RecoveryCount+1, hm.config.MaxRetries, truncateLog(msg, 60))
+	hm.update(sessionName, p, func(sh *SessionHealth) {
+		sh.RecoveryCount++
+		sh.LastRecoveryAt = time.Now()
+		sh.Status = HealthRecovering
 
-	if err := hm.tmux.SendKeys(sessionName, msg); err != nil {
-		hm.logger.Error("health: session %s send-keys failed: %v", sessionName, err)
-		return err
+		// Calculate exponential backoff for next attempt, capped at MaxBackoffSeconds.
+		backoffBase := 30 * time.Second
+		multiplier := hm.config.BackoffMultiplier
+		if multiplier < 1 {
+			multiplier = 2
+		}
+		backoff := backoffBase
+		for i := 1; i < sh.RecoveryCount; i++ {
+			backoff *= time.Duration(multiplier)
+		}
+		maxBackoff := time.Duration(hm.config.MaxBackoffSeconds) * time.Second
+		if maxBackoff > 0 && backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		sh.BackoffUntil = sh.LastRecoveryAt.Add(backoff)
+
+		// Check if max retries exceeded.
+		if sh.RecoveryCount >= hm.config.MaxRetries {
+			sh.Status = HealthFailed
+			hm.logger.Warn("health: session %s failed after %d recovery attempts", sessionName, sh.RecoveryCount)
+		}
+	})
+	return nil
+}
+
+// update applies fn to a session's state and records it for persisting,
+// provided the session is still tracked with pattern p matched. It reports
+// whether it was; fn may be nil to only check.
+func (hm *HealthMonitor) update(sessionName string, p *ErrorPattern, fn func(sh *SessionHealth)) bool {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	sh := hm.lookup(sessionName)
+	if sh == nil || sh.MatchedPattern != p {
+		return false
 	}
+	if fn != nil {
+		fn(sh)
+		hm.persist(sh)
+	}
+	return true
+}
 
-	sh.RecoveryCount++
-	sh.LastRecoveryAt = time.Now()
-	sh.Status = HealthRecovering
+// recoveryMessage returns what to type into a session to recover it: the
+// matched pattern's message, or the configured prompt for the session's
+// provider and persona rendered with its details.
+func (hm *HealthMonitor) recoveryMessage(sh *SessionHealth) string {
+	p := sh.MatchedPattern
+	meta := hm.sessionMeta(sh.SessionName)
+	provider := sh.Provider
+	if provider == "" {
+		provider = meta.Provider
+	}
+	prompt, ok := hm.config.RecoveryPrompt(provider, meta.Persona, p.Description)
+	if !ok {
+		return p.RecoveryMessage
+	}
+	msg, err := RenderRecoveryPrompt(prompt.Message, RecoveryPromptVars{
+		Session:  strings.TrimPrefix(sh.SessionName, sessionPrefix),
+		Provider: provider,
+		Persona:  meta.Persona,
+		Project:  meta.Project,
+		Branch:   meta.Branch,
+		Error:    p.Description,
+		Message:  p.RecoveryMessage,
+		Attempt:  sh.RecoveryCount + 1,
+	})
+	if err != nil {
+		hm.logger.Warn("health: session %s: %v; sending the pattern's message", sh.SessionName, err)
+	}
+	return msg
+}
 
-	// Calculate exponential backoff for next attempt, capped at MaxBackoffSeconds.
-	backoffBase := 30 * time.Second
-	multiplier := hm.config.BackoffMultiplier
-	if multiplier < 1 {
-		multiplier = 2
+// checkpoint snapshots the session's worktree before a recovery attempt
+// when error_recovery.checkpoint is on. A failed snapshot is logged and
+// doesn't hold up the attempt.
+func (hm *HealthMonitor) checkpoint(sh *SessionHealth) {
+	if !hm.config.Checkpoint {
+		return
+	}
+	meta := hm.sessionMeta(sh.SessionName)
+	dir := meta.WorktreePath
+	if dir == "" {
+		dir = meta.WorkingDir
 	}
-	backoff := backoffBase
-	for i := 1; i < sh.RecoveryCount; i++ {
-		backoff *= time.Duration(multiplier)
+	if dir == "" {
+		return
 	}
-	maxBackoff := time.Duration(hm.config.MaxBackoffSeconds) * time.Second
-	if maxBackoff > 0 && backoff > maxBackoff {
-		backoff = maxBackoff
+	session := strings.TrimPrefix(sh.SessionName, sessionPrefix)
+	ref, err := createCheckpoint(dir, session, sh.MatchedPattern.Description, time.Now(), hm.config.CheckpointLimit())
+	switch {
+	case err != nil:
+		hm.logger.Warn("health: session %s checkpoint failed: %v", sh.SessionName, err)
+	case ref != "":
+		hm.logger.Info("health: session %s worktree checkpointed to %s", sh.SessionName, ref)
 	}
-	sh.BackoffUntil = sh.LastRecoveryAt.Add(backoff)
+}
 
-	// Check if max retries exceeded.
-	if sh.RecoveryCount >= hm.config.MaxRetries {
-		sh.Status = HealthFailed
-		hm.logger.Warn("health: session %s failed after %d recovery attempts", sessionName, sh.RecoveryCount)
+// sessionMeta returns the stored metadata of a session, or the zero value
+// when there is no lookup or the session isn't stored. The caller must not
+// hold mu.
+func (hm *HealthMonitor) sessionMeta(sessionName string) SessionMeta {
+	hm.mu.Lock()
+	store := hm.store
+	hm.mu.Unlock()
+	if store == nil {
+		return SessionMeta{}
+	}
+	meta, ok, err := store.GetByTmux(hm.tmux.ensurePrefix(sessionName))
+	if err != nil || !ok {
+		return SessionMeta{}
 	}
+	return meta
+}
 
+// recoveryHookTimeout bounds how long a recovery hook may run before it is killed.
+const recoveryHookTimeout = 2 * time.Minute
+
+// startHook runs a recovery hook in the background so a slow script doesn't
+// stall the TUI. The hook sees the session through VIBEFLOW_* variables;
+// its exit status and output only go to the log.
+func (hm *HealthMonitor) startHook(hook string, sh *SessionHealth) error {
+	ctx, cancel := context.WithTimeout(context.Background(), recoveryHookTimeout)
+	name := sh.SessionName
+	cmd := shellCommand(ctx, hook)
+	cmd.Env = append(os.Environ(),
+		"VIBEFLOW_SESSION="+sh.SessionName,
+		"VIBEFLOW_TMUX_SESSION="+hm.tmux.ensurePrefix(sh.SessionName),
+		"VIBEFLOW_TMUX_SOCKET="+hm.tmux.socketName,
+		"VIBEFLOW_PROVIDER="+sh.Provider,
+		"VIBEFLOW_ERROR="+sh.MatchedPattern.Description,
+		fmt.Sprintf("VIBEFLOW_ATTEMPT=%d", sh.RecoveryCount+1),
+	)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return err
+	}
+	go func() {
+		defer cancel()
+		if err := cmd.Wait(); err != nil {
+			hm.logger.Warn("health: session %s hook exited: %v: %s", name, err, truncateLog(strings.TrimSpace(out.String()), 200))
+		}
+	}()
 	return nil
 }
 
 // ResetSession resets health state for a session (e.g. after manual retry).
+// A snooze is kept: the user set it, not a failure.
 func (hm *HealthMonitor) ResetSession(sessionName string) {
-	if sh, ok := hm.sessions[sessionName]; ok {
+	defer hm.flush()
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	if sh := hm.lookup(sessionName); sh != nil {
 		sh.Status = HealthHealthy
 		sh.RecoveryCount = 0
 		sh.MatchedPattern = nil
 		sh.BackoffUntil = time.Time{}
+		sh.Acknowledged = false
+		hm.persist(sh)
+	}
+}
+
+// Acknowledge toggles the acknowledgement of a failed session, muting its
+// alert. It reports whether the session is now acknowledged; a session
+// that hasn't failed can't be.
+func (hm *HealthMonitor) Acknowledge(sessionName string) bool {
+	defer hm.flush()
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	sh := hm.lookup(sessionName)
+	if sh == nil || sh.Status != HealthFailed {
+		return false
+	}
+	sh.Acknowledged = !sh.Acknowledged
+	hm.persist(sh)
+	hm.logger.Info("health: session %s acknowledged=%t", sessionName, sh.Acknowledged)
+	return sh.Acknowledged
+}
+
+// Snooze holds off recovery attempts for a session until until; a zero
+// time lifts the snooze.
+func (hm *HealthMonitor) Snooze(sessionName, provider string, until time.Time) {
+	defer hm.flush()
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	sh := hm.getOrCreate(sessionName, provider)
+	sh.SnoozedUntil = until
+	hm.persist(sh)
+	if until.IsZero() {
+		hm.logger.Info("health: session %s recovery unsnoozed", sessionName)
+	} else {
+		hm.logger.Info("health: session %s recovery snoozed until %s", sessionName, until.Format(time.Kitchen))
+	}
+}
+
+// rateLimitStagger spaces out the first recovery attempts of a provider's
+// sessions after a cooldown so they don't retry in lockstep.
+const rateLimitStagger = 20 * time.Second
+
+// noteRateLimit records that a session hit a rate limit and starts a
+// cooldown for its provider once enough of the provider's sessions have hit
+// one within the cooldown window.
+func (hm *HealthMonitor) noteRateLimit(sh *SessionHealth, now time.Time) {
+	threshold := hm.config.RateLimitThreshold()
+	if threshold == 0 || sh.Provider == "" || hm.coolingDown(sh.Provider, now) {
+		return
+	}
+	hits := hm.rateHits[sh.Provider]
+	if hits == nil {
+		hits = make(map[string]time.Time)
+		hm.rateHits[sh.Provider] = hits
+	}
+	hits[sh.SessionName] = now
+	window := hm.config.RateLimitCooldown()
+	for name, at := range hits {
+		if now.Sub(at) > window {
+			delete(hits, name)
+		}
 	}
+	if len(hits) < threshold {
+		return
+	}
+
+	until := now.Add(window)
+	hm.cooldowns[sh.Provider] = until
+	delete(hm.rateHits, sh.Provider)
+	var waiting []*SessionHealth
+	for _, other := range hm.sessions {
+		if other.Provider == sh.Provider && (other.Status == HealthErrorDetected || other.Status == HealthRecovering) {
+			waiting = append(waiting, other)
+		}
+	}
+	sort.Slice(waiting, func(i, j int) bool { return waiting[i].SessionName < waiting[j].SessionName })
+	for i, other := range waiting {
+		other.BackoffUntil = until.Add(time.Duration(i) * rateLimitStagger)
+		if other != sh {
+			hm.persist(other)
+		}
+	}
+	hm.logger.Warn("health: %d %s sessions rate limited; pausing recovery for the provider until %s",
+		len(hits), sh.Provider, until.Format(time.Kitchen))
+}
+
+// CooldownUntil returns when provider's rate-limit cooldown ends, or the
+// zero time when it has none.
+func (hm *HealthMonitor) CooldownUntil(provider string) time.Time {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.cooldowns[provider]
 }
 
-// GetHealth returns the health state for a session, or nil if not tracked.
+// CoolingDown reports whether provider is in a rate-limit cooldown at now.
+func (hm *HealthMonitor) CoolingDown(provider string, now time.Time) bool {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.coolingDown(provider, now)
+}
+
+func (hm *HealthMonitor) coolingDown(provider string, now time.Time) bool {
+	return now.Before(hm.cooldowns[provider])
+}
+
+// GetHealth returns a copy of the health state for a session, or nil if
+// not tracked. Changes to the copy don't affect the monitor.
 func (hm *HealthMonitor) GetHealth(sessionName string) *SessionHealth {
-	return hm.sessions[sessionName]
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	sh := hm.lookup(sessionName)
+	if sh == nil {
+		return nil
+	}
+	cp := *sh
+	return &cp
 }
 
-// RemoveSession removes health tracking for a killed session.
+// RemoveSession removes health tracking for a killed session, including
+// its saved state.
 func (hm *HealthMonitor) RemoveSession(sessionName string) {
+	defer hm.flush()
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
 	delete(hm.sessions, sessionName)
+	for _, hits := range hm.rateHits {
+		delete(hits, sessionName)
+	}
+	key := hm.tmux.ensurePrefix(sessionName)
+	if _, ok := hm.saved[key]; ok && hm.store != nil {
+		hm.dirty[key] = true
+	}
+	delete(hm.saved, key)
 }
 
-func (hm *HealthMonitor) getOrCreate(sessionName, provider string) *SessionHealth {
+// lookup returns the tracked state for a session, restoring it from the
+// saved state on first use, or nil if there is neither.
+func (hm *HealthMonitor) lookup(sessionName string) *SessionHealth {
 	if sh, ok := hm.sessions[sessionName]; ok {
 		return sh
 	}
+	h, ok := hm.saved[hm.tmux.ensurePrefix(sessionName)]
+	if !ok {
+		return nil
+	}
+	sh := &SessionHealth{
+		SessionName:    sessionName,
+		Provider:       h.Provider,
+		Status:         h.Status,
+		LastErrorAt:    h.LastErrorAt,
+		RecoveryCount:  h.RecoveryCount,
+		LastRecoveryAt: h.LastRecoveryAt,
+		BackoffUntil:   h.BackoffUntil,
+		Acknowledged:   h.Acknowledged,
+		SnoozedUntil:   h.SnoozedUntil,
+	}
+	if h.Pattern != "" {
+		sh.MatchedPattern = hm.registry.lookup(h.Provider, h.Pattern)
+	}
+	if sh.MatchedPattern == nil && (sh.Status == HealthErrorDetected || sh.Status == HealthRecovering) {
+		// The pattern is gone from error-patterns.yaml; start over from the
+		// next capture rather than recover with nothing to send.
+		sh.Status = HealthHealthy
+	}
+	hm.sessions[sessionName] = sh
+	return sh
+}
+
+// persist records sh's state for the next flush when it changed since it
+// was last saved. A session back to an idle state has its saved state
+// dropped. The caller holds mu.
+func (hm *HealthMonitor) persist(sh *SessionHealth) {
+	if hm.store == nil {
+		return
+	}
+	key := hm.tmux.ensurePrefix(sh.SessionName)
+	h := sh.saved()
+	prev, ok := hm.saved[key]
+	if ok && prev == h || !ok && h.idle() {
+		return
+	}
+	if h.idle() {
+		delete(hm.saved, key)
+	} else {
+		hm.saved[key] = h
+	}
+	hm.dirty[key] = true
+}
+
+// flush writes the state persist recorded to the store. It is called
+// without holding mu; each write takes the latest recorded state, so
+// concurrent flushes can't leave an older one behind.
+func (hm *HealthMonitor) flush() {
+	hm.flushMu.Lock()
+	defer hm.flushMu.Unlock()
+	hm.mu.Lock()
+	store := hm.store
+	writes := make(map[string]*SavedHealth, len(hm.dirty))
+	for key := range hm.dirty {
+		if h, ok := hm.saved[key]; ok {
+			writes[key] = &h
+		} else {
+			writes[key] = nil
+		}
+	}
+	clear(hm.dirty)
+	hm.mu.Unlock()
+
+	for key, h := range writes {
+		var err error
+		if h == nil {
+			err = store.RemoveHealth(key)
+		} else {
+			err = store.SetHealth(key, *h)
+		}
+		if err != nil {
+			hm.logger.Warn("health: session %s state not saved: %v", strings.TrimPrefix(key, sessionPrefix), err)
+			hm.mu.Lock()
+			hm.dirty[key] = true // retried on the next flush
+			hm.mu.Unlock()
+		}
+	}
+}
+
+func (hm *HealthMonitor) getOrCreate(sessionName, provider string) *SessionHealth {
+	if sh := hm.lookup(sessionName); sh != nil {
+		if sh.Provider == "" {
+			sh.Provider = provider // created by Snooze before the first check
+		}
+		return sh
+	}
 	sh := &SessionHealth{
 		SessionName: sessionName,
 		Provider:    provider,
@@ -260,6 +768,10 @@ func (hm *HealthMonitor) getOrCreate(sessionName, provider string) *SessionHealt
 }
 
 func (hm *HealthMonitor) shouldRecover(sh *SessionHealth) bool {
+	now := time.Now()
+	if sh.Snoozed(now) || hm.coolingDown(sh.Provider, now) || now.Before(sh.BackoffUntil) {
+		return false
+	}
 	if sh.RecoveryCount >= hm.config.MaxRetries {
 		sh.Status = HealthFailed
 		hm.logger.Warn("health: session %s max retries reached (%d)", sh.SessionName, hm.config.MaxRetries)
@@ -17,8 +17,13 @@
 package vibeflowcli
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -145,15 +150,14 @@ func TestHealthMonitor_CheckOutput_MaxRetries(t *testing.T) {
 	}
 
 	// Simulate recovery count increase (normally done by AttemptRecovery).
-	sh := hm.GetHealth("vibeflow_test")
-	sh.RecoveryCount = 2
+	hm.sessions["vibeflow_test"].RecoveryCount = 2
 
 	// Next check: should fail (max retries).
 	shouldRecover = hm.CheckOutput("vibeflow_test", "claude", output, false)
 	if shouldRecover {
 		t.Error("should not trigger after max retries")
 	}
-	if sh.Status != HealthFailed {
+	if sh := hm.GetHealth("vibeflow_test"); sh.Status != HealthFailed {
 		t.Errorf("expected failed status, got %s", sh.Status)
 	}
 }
@@ -228,7 +232,7 @@ func TestHealthMonitor_AttemptRecovery_BackoffCap(t *testing.T) {
 	hm.CheckOutput("vibeflow_test", "claude", output, false)
 
 	// Simulate multiple recovery attempts and check backoff is capped.
-	sh := hm.GetHealth("vibeflow_test")
+	sh := hm.sessions["vibeflow_test"]
 	for i := 0; i < 10; i++ {
 		sh.Status = HealthRecovering
 		sh.MatchedPattern = &hm.registry.patterns[0] // 529 pattern (first in list).
@@ -260,3 +264,289 @@ func TestHealthStatus_String(t *testing.T) {
 		}
 	}
 }
+
+func TestHealthMonitor_AttemptRecovery_Notify(t *testing.T) {
+	hm := testHealthMonitor(t)
+	hm.registry.AddPattern(ErrorPattern{
+		Provider: "inhouse", Regex: regexp.MustCompile(`segfault`),
+		Action: ActionNotify, Description: "agent crashed",
+	})
+	hm.CheckOutput("vibeflow_test", "inhouse", "segfault", false)
+	if !hm.CheckOutput("vibeflow_test", "inhouse", "segfault", false) {
+		t.Fatal("expected recovery to trigger after debounce")
+	}
+	if err := hm.AttemptRecovery("vibeflow_test"); err != nil {
+		t.Fatal(err)
+	}
+	sh := hm.GetHealth("vibeflow_test")
+	if sh.Status != HealthFailed || sh.RecoveryCount != 0 {
+		t.Errorf("status = %s after %d attempts, want failed without attempts", sh.Status, sh.RecoveryCount)
+	}
+}
+
+func TestHealthMonitor_AttemptRecovery_SkipsWhileAttempting(t *testing.T) {
+	hm := testHealthMonitor(t)
+	hm.registry.AddPattern(ErrorPattern{
+		Provider: "inhouse", Regex: regexp.MustCompile(`segfault`),
+		Action: ActionNotify, Description: "agent crashed",
+	})
+	hm.CheckOutput("vibeflow_test", "inhouse", "segfault", false)
+	hm.attempting["vibeflow_test"] = true
+	if err := hm.AttemptRecovery("vibeflow_test"); err != nil {
+		t.Fatal(err)
+	}
+	if sh := hm.GetHealth("vibeflow_test"); sh.Status == HealthFailed {
+		t.Error("a second attempt ran while one was under way")
+	}
+}
+
+func TestHealthMonitor_AttemptRecovery_Hook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook test uses sh")
+	}
+	hm := testHealthMonitor(t)
+	out := filepath.Join(t.TempDir(), "hook.out")
+	hm.registry.AddPattern(ErrorPattern{
+		Provider: "inhouse", Regex: regexp.MustCompile(`wedged`),
+		Action: ActionHook, Description: "agent wedged",
+		Hook: `echo "$VIBEFLOW_SESSION $VIBEFLOW_PROVIDER $VIBEFLOW_ATTEMPT $VIBEFLOW_ERROR" > ` + out,
+	})
+	hm.CheckOutput("vibeflow_test", "inhouse", "wedged", false)
+	hm.CheckOutput("vibeflow_test", "inhouse", "wedged", false)
+	if err := hm.AttemptRecovery("vibeflow_test"); err != nil {
+		t.Fatal(err)
+	}
+	if sh := hm.GetHealth("vibeflow_test"); sh.Status != HealthRecovering || sh.RecoveryCount != 1 {
+		t.Errorf("status = %s, attempts = %d; want recovering after 1", sh.Status, sh.RecoveryCount)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(out)
+		if got := strings.TrimSpace(string(data)); got == "vibeflow_test inhouse 1 agent wedged" {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("hook output = %q", got)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestLoadErrorPatterns_Actions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "error-patterns.yaml")
+	writePatterns(t, path, `
+patterns:
+  - regex: 'crashed'
+    action: relaunch
+  - regex: 'stuck'
+    action: hook
+    hook: ./unstick.sh
+`)
+	patterns, err := LoadErrorPatterns(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patterns[0].Action != ActionRelaunch || patterns[1].Action != ActionHook || patterns[1].Hook != "./unstick.sh" {
+		t.Errorf("patterns = %+v", patterns)
+	}
+
+	writePatterns(t, path, "patterns:\n  - regex: 'stuck'\n    action: hook\n")
+	if _, err := LoadErrorPatterns(path); err == nil {
+		t.Error("expected an error for a hook action without a hook")
+	}
+}
+
+func TestHealthMonitor_Acknowledge(t *testing.T) {
+	hm := testHealthMonitor(t)
+	if hm.Acknowledge("vibeflow_test") {
+		t.Error("acknowledged an untracked session")
+	}
+	hm.CheckOutput("vibeflow_test", "claude", "All good", false)
+	if hm.Acknowledge("vibeflow_test") {
+		t.Error("acknowledged a healthy session")
+	}
+
+	hm.CheckOutput("vibeflow_test", "claude", "panic: runtime error", false)
+	if !hm.Acknowledge("vibeflow_test") || !hm.GetHealth("vibeflow_test").Acknowledged {
+		t.Fatal("failed session not acknowledged")
+	}
+	if hm.Acknowledge("vibeflow_test") {
+		t.Error("second acknowledge should toggle it off")
+	}
+	hm.Acknowledge("vibeflow_test")
+	hm.ResetSession("vibeflow_test")
+	if hm.GetHealth("vibeflow_test").Acknowledged {
+		t.Error("reset kept the acknowledgement; a new failure must alert again")
+	}
+}
+
+func TestHealthMonitor_SnoozeHoldsRecovery(t *testing.T) {
+	hm := testHealthMonitor(t)
+	hm.Snooze("vibeflow_test", "claude", time.Now().Add(time.Hour))
+
+	output := "Some output\nAPI Error: 500\nmore text"
+	hm.CheckOutput("vibeflow_test", "claude", output, false)
+	if hm.CheckOutput("vibeflow_test", "claude", output, false) {
+		t.Fatal("recovery triggered while snoozed")
+	}
+	sh := hm.GetHealth("vibeflow_test")
+	if sh.Status != HealthErrorDetected || sh.Provider != "claude" {
+		t.Errorf("status %s provider %q, want the error still detected for claude", sh.Status, sh.Provider)
+	}
+
+	hm.Snooze("vibeflow_test", "claude", time.Time{})
+	if !hm.CheckOutput("vibeflow_test", "claude", output, false) {
+		t.Error("recovery not triggered after the snooze was lifted")
+	}
+
+	if got := (ErrorRecoveryConfig{}).SnoozeDuration(); got != 2*time.Hour {
+		t.Errorf("default snooze = %v, want 2h", got)
+	}
+}
+
+func TestHealthMonitor_RateLimitCooldown(t *testing.T) {
+	hm := testHealthMonitor(t)
+	output := "Some output\nAPI Error: 429\nmore text"
+
+	// One rate-limited session only gets its own backoff.
+	hm.CheckOutput("vibeflow_a", "claude", output, false)
+	if hm.CoolingDown("claude", time.Now()) {
+		t.Fatal("cooldown started after a single rate-limited session")
+	}
+	if !hm.CheckOutput("vibeflow_a", "claude", output, false) {
+		t.Fatal("recovery not triggered for a lone rate-limited session")
+	}
+
+	// A second one puts the whole provider into a cooldown.
+	hm.CheckOutput("vibeflow_b", "claude", output, false)
+	now := time.Now()
+	if !hm.CoolingDown("claude", now) {
+		t.Fatal("no cooldown after two rate-limited claude sessions")
+	}
+	if hm.CoolingDown("gemini", now) {
+		t.Error("cooldown leaked to another provider")
+	}
+	if hm.CheckOutput("vibeflow_b", "claude", output, false) {
+		t.Error("recovery triggered during the provider cooldown")
+	}
+
+	// Sessions are released one at a time after the cooldown.
+	until := hm.CooldownUntil("claude")
+	a, b := hm.GetHealth("vibeflow_a"), hm.GetHealth("vibeflow_b")
+	if !a.BackoffUntil.Equal(until) || !b.BackoffUntil.Equal(until.Add(rateLimitStagger)) {
+		t.Errorf("release times a=%v b=%v, want %v and %v", a.BackoffUntil, b.BackoffUntil, until, until.Add(rateLimitStagger))
+	}
+
+	hm.cooldowns["claude"] = time.Now().Add(-time.Second)
+	hm.sessions["vibeflow_b"].BackoffUntil = time.Time{}
+	if !hm.CheckOutput("vibeflow_b", "claude", output, false) {
+		t.Error("recovery not triggered once the cooldown ended")
+	}
+}
+
+func TestErrorRecoveryConfig_RateLimitDefaults(t *testing.T) {
+	var c ErrorRecoveryConfig
+	if c.RateLimitThreshold() != 2 || c.RateLimitCooldown() != 5*time.Minute {
+		t.Errorf("defaults = %d, %v; want 2, 5m", c.RateLimitThreshold(), c.RateLimitCooldown())
+	}
+	c.RateLimitSessions = -1
+	if c.RateLimitThreshold() != 0 {
+		t.Errorf("negative rate_limit_sessions gives threshold %d, want 0 (off)", c.RateLimitThreshold())
+	}
+}
+
+func TestHealthMonitor_RestoreFromStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook test uses sh")
+	}
+	store := testStore(t)
+	wedged := ErrorPattern{
+		Provider: "inhouse", Regex: regexp.MustCompile(`wedged`),
+		Action: ActionHook, Description: "agent wedged", Hook: "true",
+	}
+	hm := testHealthMonitor(t)
+	hm.registry.AddPattern(wedged)
+	if err := hm.Restore(store); err != nil {
+		t.Fatal(err)
+	}
+	hm.CheckOutput("vibeflow_a", "inhouse", "wedged", false)
+	hm.CheckOutput("vibeflow_a", "inhouse", "wedged", false)
+	if err := hm.AttemptRecovery("vibeflow_a"); err != nil {
+		t.Fatal(err)
+	}
+	hm.CheckOutput("vibeflow_b", "claude", "panic: fatal", false)
+	hm.Acknowledge("vibeflow_b")
+	snoozed := time.Now().Add(time.Hour)
+	hm.Snooze("vibeflow_c", "claude", snoozed)
+	hm.CheckOutput("vibeflow_d", "claude", "all fine", false)
+
+	// A restarted vibeflow picks up where the last one stopped.
+	restarted := testHealthMonitor(t)
+	restarted.registry.AddPattern(wedged)
+	if err := restarted.Restore(store); err != nil {
+		t.Fatal(err)
+	}
+	a := restarted.GetHealth("vibeflow_a")
+	if a == nil || a.Status != HealthRecovering || a.RecoveryCount != 1 || a.BackoffUntil.IsZero() {
+		t.Fatalf("restored a = %+v; want recovering after 1 attempt with a backoff", a)
+	}
+	if a.MatchedPattern == nil || a.MatchedPattern.Description != "agent wedged" {
+		t.Errorf("restored pattern = %v, want agent wedged", a.MatchedPattern)
+	}
+	if b := restarted.GetHealth("vibeflow_b"); b == nil || b.Status != HealthFailed || !b.Acknowledged {
+		t.Errorf("restored b = %+v; want failed and acknowledged", b)
+	}
+	if restarted.CheckOutput("vibeflow_b", "claude", "panic: fatal", false) {
+		t.Error("recovery triggered for a session that failed before the restart")
+	}
+	if c := restarted.GetHealth("vibeflow_c"); c == nil || !c.SnoozedUntil.Equal(snoozed) {
+		t.Errorf("restored c = %+v; want snoozed until %v", c, snoozed)
+	}
+	if d := restarted.GetHealth("vibeflow_d"); d != nil {
+		t.Errorf("healthy session saved: %+v", d)
+	}
+
+	// Recovering drops the saved state; so does removing the session.
+	restarted.CheckOutput("vibeflow_a", "inhouse", "back to work", false)
+	restarted.RemoveSession("vibeflow_b")
+	saved, err := store.Health()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 || saved["vibeflow_c"].SnoozedUntil.IsZero() {
+		t.Errorf("saved health = %+v; want only vibeflow_c", saved)
+	}
+}
+
+func TestHealthMonitor_ConcurrentChecks(t *testing.T) {
+	hm := testHealthMonitor(t)
+	if err := hm.Restore(testStore(t)); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("vibeflow_%d", i%3)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				output := "working"
+				if (i+j)%2 == 0 {
+					output = "API Error: 429"
+				}
+				hm.CheckOutput(name, "claude", output, false)
+				if sh := hm.GetHealth(name); sh != nil {
+					_ = sh.Status
+				}
+				hm.CoolingDown("claude", time.Now())
+				if j%5 == 0 {
+					hm.Snooze(name, "claude", time.Now().Add(time.Minute))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if len(hm.sessions) != 3 {
+		t.Errorf("tracking %d sessions, want 3", len(hm.sessions))
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultHeartbeatInterval is used when heartbeat.interval_seconds is unset.
+const defaultHeartbeatInterval = 30 * time.Second
+
+// heartbeatSample is one session's state as reported on a heartbeat tick.
+type heartbeatSample struct {
+	Meta      SessionMeta
+	ProjectID int64
+	Status    string
+}
+
+// heartbeatSessionID is the server-side ID a session is reported under.
+// Managed sessions already have one from session_init; other sessions use
+// their store name, as cloud dispatch does.
+func heartbeatSessionID(meta SessionMeta) string {
+	if meta.VibeFlowSessionID != "" {
+		return meta.VibeFlowSessionID
+	}
+	return meta.Name
+}
+
+// HeartbeatPublisher reports local sessions to the VibeFlow server. Sessions
+// the server doesn't know about yet are registered once; every tick then
+// sends a heartbeat carrying the session's activity status.
+type HeartbeatPublisher struct {
+	client   VibeflowAPI
+	logger   *Logger
+	interval time.Duration
+
+	mu         sync.Mutex
+	registered map[string]bool
+}
+
+// NewHeartbeatPublisher returns nil when heartbeats are disabled or there is
+// no server to report to, so callers can nil-check once.
+func NewHeartbeatPublisher(cfg HeartbeatConfig, client VibeflowAPI, logger *Logger) *HeartbeatPublisher {
+	if !cfg.Enabled || client == nil {
+		return nil
+	}
+	interval := defaultHeartbeatInterval
+	if cfg.IntervalSeconds > 0 {
+		interval = time.Duration(cfg.IntervalSeconds) * time.Second
+	}
+	return &HeartbeatPublisher{
+		client:     client,
+		logger:     logger,
+		interval:   interval,
+		registered: make(map[string]bool),
+	}
+}
+
+// Interval returns how often Publish should be called.
+func (h *HeartbeatPublisher) Interval() time.Duration {
+	return h.interval
+}
+
+// Publish registers any new sessions and sends one heartbeat per sample.
+// Managed ("vibeflow") sessions were registered by session_init and are only
+// heartbeated. A failed heartbeat forgets the registration so the next tick
+// registers again, which recovers from the server having dropped the session.
+func (h *HeartbeatPublisher) Publish(samples []heartbeatSample) {
+	live := make(map[string]bool, len(samples))
+	for _, s := range samples {
+		id := heartbeatSessionID(s.Meta)
+		if id == "" || s.ProjectID <= 0 {
+			continue
+		}
+		live[id] = true
+		if s.Meta.SessionType != "vibeflow" && !h.isRegistered(id) {
+			if err := h.client.SessionRegister(heartbeatRegisterRequest(id, s)); err != nil {
+				h.logger.Warn("heartbeat: register %s: %v", id, err)
+				continue
+			}
+			h.setRegistered(id, true)
+		}
+		if err := h.client.SessionHeartbeat(id, s.Status); err != nil {
+			h.logger.Warn("heartbeat: %s: %v", id, err)
+			h.setRegistered(id, false)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id := range h.registered {
+		if !live[id] {
+			delete(h.registered, id)
+		}
+	}
+}
+
+func (h *HeartbeatPublisher) isRegistered(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.registered[id]
+}
+
+func (h *HeartbeatPublisher) setRegistered(id string, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ok {
+		h.registered[id] = true
+	} else {
+		delete(h.registered, id)
+	}
+}
+
+// heartbeatRegisterRequest describes a session for SessionRegister.
+func heartbeatRegisterRequest(id string, s heartbeatSample) SessionRegisterRequest {
+	dir := s.Meta.WorkingDir
+	if s.Meta.WorktreePath != "" {
+		dir = s.Meta.WorktreePath
+	}
+	req := SessionRegisterRequest{
+		SessionID:        id,
+		ProjectID:        s.ProjectID,
+		WorkingDirectory: dir,
+		GitBranch:        s.Meta.Branch,
+		GitWorktreePath:  s.Meta.WorktreePath,
+	}
+	if dir != "" {
+		if remote, err := gitIn(dir, "remote", "get-url", "origin"); err == nil {
+			req.GitRemoteURL = remote
+		}
+	}
+	return req
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewHeartbeatPublisher_Disabled(t *testing.T) {
+	if NewHeartbeatPublisher(HeartbeatConfig{}, NewClient("http://x", ""), &Logger{}) != nil {
+		t.Error("disabled config should yield nil publisher")
+	}
+	if NewHeartbeatPublisher(HeartbeatConfig{Enabled: true}, nil, &Logger{}) != nil {
+		t.Error("no client should yield nil publisher")
+	}
+	h := NewHeartbeatPublisher(HeartbeatConfig{Enabled: true}, NewClient("http://x", ""), &Logger{})
+	if h.Interval() != defaultHeartbeatInterval {
+		t.Errorf("interval = %v, want default", h.Interval())
+	}
+}
+
+func TestHeartbeatPublisher_RegistersOnceThenHeartbeats(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	failHeartbeat := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/rest/v1/vibeflow/sessions/register":
+			var req SessionRegisterRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			calls = append(calls, "register "+req.SessionID)
+		case strings.HasSuffix(r.URL.Path, "/heartbeat"):
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			calls = append(calls, "heartbeat "+strings.Split(r.URL.Path, "/")[5]+" "+body["status"])
+			if failHeartbeat {
+				http.Error(w, "unknown session", http.StatusNotFound)
+				return
+			}
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	h := NewHeartbeatPublisher(HeartbeatConfig{Enabled: true}, NewClient(srv.URL, ""), &Logger{})
+	samples := []heartbeatSample{
+		{Meta: SessionMeta{Name: "claude-x", Branch: "main"}, ProjectID: 3, Status: "working"},
+		{Meta: SessionMeta{Name: "codex-y", VibeFlowSessionID: "session-1", SessionType: "vibeflow"}, ProjectID: 3, Status: "idle"},
+		{Meta: SessionMeta{Name: "no-project"}, Status: "idle"},
+	}
+	h.Publish(samples)
+	h.Publish(samples)
+	want := []string{
+		"register claude-x", "heartbeat claude-x working", "heartbeat session-1 idle",
+		"heartbeat claude-x working", "heartbeat session-1 idle",
+	}
+	if strings.Join(calls, "|") != strings.Join(want, "|") {
+		t.Fatalf("calls = %q, want %q", calls, want)
+	}
+
+	// A rejected heartbeat makes the next tick register again.
+	setFail := func(v bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		failHeartbeat = v
+	}
+	mu.Lock()
+	calls = nil
+	mu.Unlock()
+	setFail(true)
+	h.Publish(samples[:1])
+	setFail(false)
+	h.Publish(samples[:1])
+	want = []string{"heartbeat claude-x working", "register claude-x", "heartbeat claude-x working"}
+	if strings.Join(calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls after failure = %q, want %q", calls, want)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// historyFilter selects history entries. Empty fields match everything;
+// project and branch match substrings, case-insensitively.
+type historyFilter struct {
+	Provider string
+	Project  string
+	Branch   string
+	Reason   string // an exit reason, or "running" for open entries
+	Since    time.Time
+}
+
+func (f historyFilter) match(e HistoryEntry) bool {
+	if f.Provider != "" && e.Provider != f.Provider {
+		return false
+	}
+	if f.Project != "" && !strings.Contains(strings.ToLower(e.Project), strings.ToLower(f.Project)) {
+		return false
+	}
+	if f.Branch != "" && !strings.Contains(strings.ToLower(e.Branch), strings.ToLower(f.Branch)) {
+		return false
+	}
+	if f.Reason != "" && historyExit(e) != f.Reason {
+		return false
+	}
+	if !f.Since.IsZero() && e.CreatedAt.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// filterHistory returns the matching entries newest first, at most limit of
+// them (limit <= 0 means all).
+func filterHistory(entries []HistoryEntry, f historyFilter, limit int) []HistoryEntry {
+	var out []HistoryEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !f.match(entries[i]) {
+			continue
+		}
+		out = append(out, entries[i])
+		if limit > 0 && len(out) == limit {
+			break
+		}
+	}
+	return out
+}
+
+// historyExit is the EXIT column value: the exit reason, or "running".
+func historyExit(e HistoryEntry) string {
+	if e.Open() {
+		return "running"
+	}
+	return e.ExitReason
+}
+
+// parseSince accepts a Go duration ("36h") or a number of days ("7d") and
+// returns the cutoff relative to now.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid --since %q", s)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use e.g. 12h or 7d)", s)
+	}
+	return now.Add(-d), nil
+}
+
+// formatSessionDuration renders a duration compactly: 45s, 12m, 3h05m, 2d4h.
+func formatSessionDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
+// printHistory writes the `vibeflow history` table.
+func printHistory(out io.Writer, entries []HistoryEntry, now time.Time) {
+	fmt.Fprintf(out, "%-16s %-24s %-8s %-24s %-8s %-9s %s\n", "STARTED", "NAME", "PROVIDER", "BRANCH", "DURATION", "EXIT", "RECOVERIES")
+	fmt.Fprintln(out, strings.Repeat("-", 104))
+	for _, e := range entries {
+		fmt.Fprintf(out, "%-16s %-24s %-8s %-24s %-8s %-9s %d\n",
+			e.CreatedAt.Local().Format("2006-01-02 15:04"), truncate(e.Name, 24), truncate(e.Provider, 8),
+			truncate(e.Branch, 24), formatSessionDuration(e.Duration(now)), historyExit(e), e.RecoveryAttempts)
+	}
+}
+
+// --- history ---
+
+func historyCmd() *cobra.Command {
+	var (
+		f     historyFilter
+		since string
+		limit int
+	)
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show every session vibeflow has launched, including ended ones",
+		Long: `Show the session history: provider, branch, how long each session ran,
+why it ended (killed, timed_out, deleted, exited, restarted, replaced, removed) and how
+many automatic error-recovery attempts it needed. Newest first.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			cutoff, err := parseSince(since, now)
+			if err != nil {
+				return err
+			}
+			f.Since = cutoff
+			entries, err := NewSessionHistory().List()
+			if err != nil {
+				return err
+			}
+			matched := filterHistory(entries, f, limit)
+			if len(matched) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No sessions in history.")
+				return nil
+			}
+			printHistory(cmd.OutOrStdout(), matched, now)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&f.Provider, "provider", "", "Only sessions of this provider")
+	cmd.Flags().StringVar(&f.Project, "project", "", "Only sessions whose project contains this text")
+	cmd.Flags().StringVar(&f.Branch, "branch", "", "Only sessions whose branch contains this text")
+	cmd.Flags().StringVar(&f.Reason, "exit", "", "Only sessions that ended this way (killed, timed_out, deleted, exited, restarted, replaced, removed) or are still running")
+	cmd.Flags().StringVar(&since, "since", "", "Only sessions started within this period (e.g. 12h, 7d)")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Maximum number of sessions to show (0 = all)")
+	return cmd
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HooksConfig names shell commands run on session lifecycle events. Each
+// runs with `sh -c` (`cmd /C` on Windows) and sees the session through
+// VIBEFLOW_* environment variables; see HookRunner.Fire.
+type HooksConfig struct {
+	OnSessionCreate string `yaml:"on_session_create,omitempty"`
+	OnSessionKill   string `yaml:"on_session_kill,omitempty"`
+	OnRecovery      string `yaml:"on_recovery,omitempty"`
+	OnFailed        string `yaml:"on_failed,omitempty"`
+}
+
+// HookEvent identifies a lifecycle event. Its value is passed to hooks as
+// VIBEFLOW_EVENT.
+type HookEvent string
+
+const (
+	HookSessionCreate HookEvent = "session_create" // a session was launched or restarted
+	HookSessionKill   HookEvent = "session_kill"   // a session was killed or deleted
+	HookRecovery      HookEvent = "recovery"       // an automatic error-recovery attempt was made
+	HookFailed        HookEvent = "failed"         // error recovery gave up, or a notify pattern matched
+)
+
+// lifecycleHookTimeout bounds a lifecycle hook so a hung script can't pile
+// up processes.
+const lifecycleHookTimeout = time.Minute
+
+// command returns the configured command for event, or "".
+func (c HooksConfig) command(event HookEvent) string {
+	switch event {
+	case HookSessionCreate:
+		return c.OnSessionCreate
+	case HookSessionKill:
+		return c.OnSessionKill
+	case HookRecovery:
+		return c.OnRecovery
+	case HookFailed:
+		return c.OnFailed
+	}
+	return ""
+}
+
+// HookRunner runs lifecycle hooks in the background. A nil runner, or one
+// without a command for an event, does nothing.
+type HookRunner struct {
+	cfg   HooksConfig
+	tmux  *TmuxManager                     // resolves VIBEFLOW_TMUX_SOCKET; may be nil
+	warnf func(format string, args ...any) // failed hooks are reported here
+	wg    sync.WaitGroup
+}
+
+// NewHookRunner creates a runner for cfg. tmux tells hooks which socket a
+// session runs on. warnf receives hook failures; the TUI passes its
+// logger, commands print to stderr.
+func NewHookRunner(cfg HooksConfig, tmux *TmuxManager, warnf func(format string, args ...any)) *HookRunner {
+	return &HookRunner{cfg: cfg, tmux: tmux, warnf: warnf}
+}
+
+// Fire runs the hook for event, if one is configured, without waiting for
+// it. The hook gets VIBEFLOW_EVENT, VIBEFLOW_SESSION, VIBEFLOW_TMUX_SESSION,
+// VIBEFLOW_TMUX_SOCKET, VIBEFLOW_PROVIDER, VIBEFLOW_PROJECT,
+// VIBEFLOW_PERSONA, VIBEFLOW_BRANCH, VIBEFLOW_WORKDIR, VIBEFLOW_WORKTREE and
+// VIBEFLOW_SESSION_ID, plus extra (e.g. VIBEFLOW_ERROR).
+func (r *HookRunner) Fire(event HookEvent, meta SessionMeta, extra map[string]string) {
+	if r == nil {
+		return
+	}
+	hook := r.cfg.command(event)
+	if hook == "" {
+		return
+	}
+	var socket string
+	if r.tmux != nil {
+		socket = r.tmux.SessionSocket(meta.TmuxSession)
+	}
+	env := append(os.Environ(),
+		"VIBEFLOW_EVENT="+string(event),
+		"VIBEFLOW_SESSION="+meta.Name,
+		"VIBEFLOW_TMUX_SESSION="+meta.TmuxSession,
+		"VIBEFLOW_TMUX_SOCKET="+socket,
+		"VIBEFLOW_PROVIDER="+meta.Provider,
+		"VIBEFLOW_PROJECT="+meta.Project,
+		"VIBEFLOW_PERSONA="+meta.Persona,
+		"VIBEFLOW_BRANCH="+meta.Branch,
+		"VIBEFLOW_WORKDIR="+meta.WorkingDir,
+		"VIBEFLOW_WORKTREE="+meta.WorktreePath,
+		"VIBEFLOW_SESSION_ID="+meta.VibeFlowSessionID,
+	)
+	for k, v := range extra {
+		env = append(env, k+"="+v)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycleHookTimeout)
+	cmd := shellCommand(ctx, hook)
+	cmd.Env = env
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		cancel()
+		r.warn("hook %s: %v", event, err)
+		return
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+		if err := cmd.Wait(); err != nil {
+			r.warn("hook %s (session %s) exited: %v: %s", event, meta.Name, err, truncateLog(strings.TrimSpace(out.String()), 200))
+		}
+	}()
+}
+
+// Wait blocks until every hook started by Fire has finished. Commands call
+// it before exiting so their hooks aren't cut short.
+func (r *HookRunner) Wait() {
+	if r != nil {
+		r.wg.Wait()
+	}
+}
+
+func (r *HookRunner) warn(format string, args ...any) {
+	if r.warnf != nil {
+		r.warnf(format, args...)
+	}
+}
+
+// stderrWarnf prints a hook warning for the headless commands.
+func stderrWarnf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+}
+
+// shellCommand runs command through the platform shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestHookRunner_FireSetsSessionEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands are sh scripts")
+	}
+	out := filepath.Join(t.TempDir(), "env")
+	cfg := HooksConfig{OnFailed: `env | grep ^VIBEFLOW_ | sort > "$OUT"`}
+	t.Setenv("OUT", out)
+	r := NewHookRunner(cfg, &TmuxManager{socketName: "vibeflow"}, nil)
+
+	meta := SessionMeta{Name: "api", TmuxSession: "vibeflow_api", Provider: "claude", Project: "web", Persona: "developer", Branch: "main", WorkingDir: "/src"}
+	r.Fire(HookFailed, meta, map[string]string{"VIBEFLOW_ERROR": "rate limited"})
+	r.Fire(HookSessionCreate, meta, nil) // not configured: no-op
+	r.Wait()
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	for _, want := range []string{
+		"VIBEFLOW_EVENT=failed", "VIBEFLOW_SESSION=api", "VIBEFLOW_TMUX_SESSION=vibeflow_api",
+		"VIBEFLOW_TMUX_SOCKET=vibeflow", "VIBEFLOW_PROVIDER=claude", "VIBEFLOW_PROJECT=web",
+		"VIBEFLOW_PERSONA=developer", "VIBEFLOW_BRANCH=main", "VIBEFLOW_WORKDIR=/src", "VIBEFLOW_ERROR=rate limited",
+	} {
+		if !strings.Contains(string(data), want+"\n") {
+			t.Errorf("hook env missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestHookRunner_ReportsFailures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands are sh scripts")
+	}
+	var warnings []string
+	r := NewHookRunner(HooksConfig{OnSessionKill: "echo boom; exit 3"}, nil, func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	})
+	r.Fire(HookSessionKill, SessionMeta{Name: "api"}, nil)
+	r.Wait()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "boom") {
+		t.Errorf("warnings = %q, want one mentioning the hook output", warnings)
+	}
+
+	var nilRunner *HookRunner
+	nilRunner.Fire(HookSessionKill, SessionMeta{}, nil) // must not panic
+	nilRunner.Wait()
+}
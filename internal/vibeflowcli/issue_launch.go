@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// branchSlugMax caps the title part of a generated branch name.
+const branchSlugMax = 40
+
+// issueBranchName builds the branch for an issue launch:
+// "issue-123-fix-login".
+func issueBranchName(id int64, title string) string {
+	if slug := branchSlug(title); slug != "" {
+		return fmt.Sprintf("issue-%d-%s", id, slug)
+	}
+	return fmt.Sprintf("issue-%d", id)
+}
+
+// branchSlug turns a title into a branch name part: "Fix login timeout!"
+// becomes "fix-login-timeout". The title is lowercased, runs of anything
+// but letters and digits become one dash, and the slug is cut at a word
+// boundary so branch names stay readable.
+func branchSlug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	slug := b.String()
+	if len(slug) > branchSlugMax {
+		slug = slug[:branchSlugMax]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return slug
+}
+
+// issuePrompt is the part of the agent prompt that hands it the issue.
+func issuePrompt(issue *Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Work on issue #%d: %s", issue.ID, issue.Title)
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		b.WriteString("\n\nIssue description:\n")
+		b.WriteString(desc)
+	}
+	b.WriteString("\n\nYou are on a dedicated branch for this issue. Commit your changes there.")
+	return b.String()
+}
+
+// joinPrompts joins non-empty prompt parts with a blank line.
+func joinPrompts(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// fetchLaunchIssue resolves the project by name and fetches the issue.
+func fetchLaunchIssue(cfg *Config, projectName string, issueID int64) (*Issue, error) {
+	if !cfg.hasAPIAuth() {
+		return nil, fmt.Errorf("--issue requires api_token in config, VIBEFLOW_TOKEN or `vibeflow login`")
+	}
+	if projectName == "" {
+		return nil, fmt.Errorf("--issue requires --project or default_project")
+	}
+	client := newAPIClient(cfg)
+	projects, err := client.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	project, err := findProject(projects, projectName)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := client.GetIssue(project.ID, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue.ProjectID == 0 {
+		issue.ProjectID = project.ID
+	}
+	return issue, nil
+}
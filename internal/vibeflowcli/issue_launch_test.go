@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIssueBranchName(t *testing.T) {
+	tests := []struct {
+		id    int64
+		title string
+		want  string
+	}{
+		{123, "Fix login", "issue-123-fix-login"},
+		{7, "  Crash when $HOME isn't set!! ", "issue-7-crash-when-home-isn-t-set"},
+		{8, "OAuth2 / SSO: support Okta", "issue-8-oauth2-sso-support-okta"},
+		{9, "???", "issue-9"},
+		{10, "Make the session list remember its scroll position across refreshes and restarts", "issue-10-make-the-session-list-remember-its"},
+	}
+	for _, tt := range tests {
+		if got := issueBranchName(tt.id, tt.title); got != tt.want {
+			t.Errorf("issueBranchName(%d, %q) = %q, want %q", tt.id, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestIssuePrompt(t *testing.T) {
+	got := joinPrompts("Init the session.", issuePrompt(&Issue{ID: 12, Title: "Fix login", Description: "Users get a 500.\n"}))
+	for _, want := range []string{"Init the session.\n\nWork on issue #12: Fix login", "Issue description:\nUsers get a 500."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt missing %q:\n%s", want, got)
+		}
+	}
+	if got := joinPrompts("", "  ", "only"); got != "only" {
+		t.Errorf("joinPrompts dropped nothing: %q", got)
+	}
+}
+
+func TestFetchLaunchIssue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/v1/vibeflow/projects":
+			json.NewEncoder(w).Encode([]Project{{ID: 3, Name: "shop"}})
+		case "/rest/v1/vibeflow/projects/3/issues/123":
+			json.NewEncoder(w).Encode(Issue{ID: 123, Title: "Fix login", Description: "500 on submit"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	cfg := &Config{ServerURL: srv.URL, APIToken: "tok"}
+
+	issue, err := fetchLaunchIssue(cfg, "shop", 123)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Title != "Fix login" || issue.ProjectID != 3 {
+		t.Errorf("issue = %+v", issue)
+	}
+	if _, err := fetchLaunchIssue(cfg, "shop", 999); err == nil {
+		t.Error("missing issue: want an error")
+	}
+	if _, err := fetchLaunchIssue(cfg, "", 123); err == nil {
+		t.Error("no project: want an error")
+	}
+	if _, err := fetchLaunchIssue(&Config{ServerURL: srv.URL}, "shop", 123); err == nil {
+		t.Error("no API token: want an error")
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// KeymapConfig rebinds the session-list keys. Keys are named as Bubble Tea
+// reports them: "d", "D", "ctrl+d", "up", "enter", "space". ctrl+c always
+// quits and the y/n of confirmation prompts is fixed.
+type KeymapConfig struct {
+	// Preset picks the movement keys: "default" (arrows and j/k), "vim"
+	// (j/k only) or "arrows" (arrow keys only, freeing j and k).
+	Preset string `yaml:"preset,omitempty"`
+	// Bindings maps an action (see keyActions) to its keys, replacing the
+	// preset's; an empty list unbinds it, e.g. `quit: []`.
+	Bindings map[string][]string `yaml:"bindings,omitempty"`
+}
+
+// keyAction is a session-list command.
+type keyAction string
+
+const (
+	actUp               keyAction = "up"
+	actDown             keyAction = "down"
+	actAttach           keyAction = "attach"
+	actObserve          keyAction = "observe"
+	actOpenEditor       keyAction = "open_editor"
+	actCopyInfo         keyAction = "copy_info"
+	actCopyOutput       keyAction = "copy_output"
+	actFollowOutput     keyAction = "follow_output"
+	actScrollOutputUp   keyAction = "scroll_output_up"
+	actScrollOutputDown keyAction = "scroll_output_down"
+	actOutput           keyAction = "output"
+	actSearch           keyAction = "search"
+	actProjectWorkbench keyAction = "project_workbench"
+	actAllWorkbench     keyAction = "all_workbench"
+	actToggleGrouped    keyAction = "toggle_grouped"
+	actNew              keyAction = "new"
+	actQuickLaunch      keyAction = "quick_launch"
+	actOpenSplit        keyAction = "open_split"
+	actOpenWindow       keyAction = "open_window"
+	actDelete           keyAction = "delete"
+	actUndoKill         keyAction = "undo_kill"
+	actMark             keyAction = "mark"
+	actClearMarks       keyAction = "clear_marks"
+	actMoveToGroup      keyAction = "move_to_group"
+	actEditNote         keyAction = "edit_note"
+	actSwitchBranch     keyAction = "switch_branch"
+	actEditGroup        keyAction = "edit_group"
+	actBroadcast        keyAction = "broadcast"
+	actDetach           keyAction = "detach"
+	actWorktrees        keyAction = "worktrees"
+	actHistory          keyAction = "history"
+	actPendingWork      keyAction = "pending_work"
+	actRestart          keyAction = "restart"
+	actRollingRestart   keyAction = "rolling_restart"
+	actSummary          keyAction = "summary"
+	actArchive          keyAction = "archive"
+	actSnoozeRecovery   keyAction = "snooze_recovery"
+	actLogin            keyAction = "login"
+	actHelp             keyAction = "help"
+	actQuit             keyAction = "quit"
+)
+
+// keyActions lists every action with its default keys.
+var keyActions = []struct {
+	action keyAction
+	keys   []string
+}{
+	{actUp, []string{"up", "k"}},
+	{actDown, []string{"down", "j"}},
+	{actAttach, []string{"enter"}},
+	{actObserve, []string{"v"}},
+	{actOpenEditor, []string{"E"}},
+	{actCopyInfo, []string{"y"}},
+	{actCopyOutput, []string{"Y"}},
+	{actFollowOutput, []string{"f"}},
+	{actScrollOutputUp, []string{"pgup"}},
+	{actScrollOutputDown, []string{"pgdown"}},
+	{actOutput, []string{"o"}},
+	{actSearch, []string{"/"}},
+	{actProjectWorkbench, []string{"m"}},
+	{actAllWorkbench, []string{"M"}},
+	{actToggleGrouped, []string{"g"}},
+	{actNew, []string{"n"}},
+	{actQuickLaunch, []string{"N"}},
+	{actOpenSplit, []string{"s"}},
+	{actOpenWindow, []string{"t"}},
+	{actDelete, []string{"d"}},
+	{actUndoKill, []string{"u"}},
+	{actMark, []string{"space"}},
+	{actClearMarks, []string{"esc"}},
+	{actMoveToGroup, []string{"G"}},
+	{actEditNote, []string{"c"}},
+	{actSwitchBranch, []string{"b"}},
+	{actEditGroup, []string{"e"}},
+	{actBroadcast, []string{"B"}},
+	{actDetach, []string{"D"}},
+	{actWorktrees, []string{"w"}},
+	{actHistory, []string{"h"}},
+	{actPendingWork, []string{"p"}},
+	{actRestart, []string{"r"}},
+	{actRollingRestart, []string{"U"}},
+	{actSummary, []string{"S"}},
+	{actArchive, []string{"a"}},
+	{actSnoozeRecovery, []string{"z"}},
+	{actLogin, []string{"L"}},
+	{actHelp, []string{"?"}},
+	{actQuit, []string{"q"}},
+}
+
+// keymap resolves keys to session-list actions and actions back to the
+// keys shown in the help bar and help screen.
+type keymap struct {
+	byKey    map[string]keyAction
+	byAction map[keyAction][]string
+}
+
+// newKeymap builds the keymap for cfg. Unknown presets or actions, and a
+// key bound to two actions, are errors; defaultKeymap is the fallback.
+func newKeymap(cfg KeymapConfig) (keymap, error) {
+	km := keymap{byKey: make(map[string]keyAction), byAction: make(map[keyAction][]string)}
+	known := make(map[keyAction]bool, len(keyActions))
+	for _, a := range keyActions {
+		known[a.action] = true
+		km.byAction[a.action] = a.keys
+	}
+	switch cfg.Preset {
+	case "", "default":
+	case "vim":
+		km.byAction[actUp], km.byAction[actDown] = []string{"k"}, []string{"j"}
+	case "arrows":
+		km.byAction[actUp], km.byAction[actDown] = []string{"up"}, []string{"down"}
+	default:
+		return keymap{}, fmt.Errorf("unknown keymap preset %q (default, vim or arrows)", cfg.Preset)
+	}
+	for name, keys := range cfg.Bindings {
+		if !known[keyAction(name)] {
+			return keymap{}, fmt.Errorf("unknown keymap action %q (one of %s)", name, strings.Join(keymapActionNames(), ", "))
+		}
+		km.byAction[keyAction(name)] = keys
+	}
+	for _, a := range keyActions {
+		for _, key := range km.byAction[a.action] {
+			if key == "ctrl+c" {
+				return keymap{}, fmt.Errorf("keymap: ctrl+c is reserved for force quit")
+			}
+			if other, ok := km.byKey[key]; ok {
+				return keymap{}, fmt.Errorf("keymap: %q is bound to both %s and %s", key, other, a.action)
+			}
+			km.byKey[key] = a.action
+		}
+	}
+	return km, nil
+}
+
+// defaultKeymap is the built-in keymap.
+func defaultKeymap() keymap {
+	km, _ := newKeymap(KeymapConfig{})
+	return km
+}
+
+// builtinKeymap stands in for a zero keymap.
+var builtinKeymap = defaultKeymap()
+
+// resolved returns k, or the built-in keymap when k was never built.
+func (k keymap) resolved() keymap {
+	if k.byKey == nil {
+		return builtinKeymap
+	}
+	return k
+}
+
+// action returns the action bound to key, or "".
+func (k keymap) action(key string) keyAction {
+	return k.resolved().byKey[key]
+}
+
+// label returns the first key bound to a, or "" when it is unbound.
+func (k keymap) label(a keyAction) string {
+	if keys := k.resolved().byAction[a]; len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// keyHint is one "key: description" entry of the help bar.
+type keyHint struct {
+	action keyAction
+	desc   string
+}
+
+// hintSpan is the column range [x0, x1) of one rendered hint.
+type hintSpan struct {
+	x0, x1 int
+	action keyAction
+}
+
+// hints renders hints as "key: desc" pairs, leaving out unbound actions.
+func (k keymap) hints(hints ...keyHint) string {
+	s, _ := k.hintSpans(0, hints...)
+	return s
+}
+
+// hintSpans is hints that also returns where each hint lands, for a line
+// starting at column x.
+func (k keymap) hintSpans(x int, hints ...keyHint) (string, []hintSpan) {
+	var b strings.Builder
+	spans := make([]hintSpan, 0, len(hints))
+	for _, h := range hints {
+		key := k.label(h.action)
+		if key == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("  ")
+		}
+		text := key + ": " + h.desc
+		start := x + lipgloss.Width(b.String())
+		b.WriteString(text)
+		spans = append(spans, hintSpan{x0: start, x1: start + lipgloss.Width(text), action: h.action})
+	}
+	return b.String(), spans
+}
+
+// keymapActionNames returns the configurable action names, sorted.
+func keymapActionNames() []string {
+	names := make([]string, 0, len(keyActions))
+	for _, a := range keyActions {
+		names = append(names, string(a.action))
+	}
+	sort.Strings(names)
+	return names
+}
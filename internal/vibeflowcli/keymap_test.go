@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestNewKeymap_Defaults(t *testing.T) {
+	km, err := newKeymap(KeymapConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]keyAction{"d": actDelete, "D": actDetach, "k": actUp, "up": actUp, "q": actQuit, "space": actMark} {
+		if got := km.action(key); got != want {
+			t.Errorf("action(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestNewKeymap_PresetsAndBindings(t *testing.T) {
+	km, err := newKeymap(KeymapConfig{
+		Preset:   "arrows",
+		Bindings: map[string][]string{"delete": {"ctrl+d"}, "quit": {}, "detach": {"ctrl+x", "X"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if km.action("k") != "" || km.action("up") != actUp {
+		t.Error("arrows preset should leave k unbound and keep up")
+	}
+	if km.action("d") != "" || km.action("ctrl+d") != actDelete {
+		t.Error("delete not moved to ctrl+d")
+	}
+	if km.action("q") != "" || km.label(actQuit) != "" {
+		t.Error("quit should be unbound")
+	}
+	if km.label(actDetach) != "ctrl+x" || km.action("X") != actDetach {
+		t.Errorf("detach keys = %v", km.byAction[actDetach])
+	}
+	if got := km.hints(keyHint{actDelete, "delete"}, keyHint{actQuit, "quit"}); got != "ctrl+d: delete" {
+		t.Errorf("hints = %q", got)
+	}
+}
+
+func TestNewKeymap_Errors(t *testing.T) {
+	for name, cfg := range map[string]KeymapConfig{
+		"unknown preset": {Preset: "emacs"},
+		"unknown action": {Bindings: map[string][]string{"explode": {"x"}}},
+		"conflict":       {Bindings: map[string][]string{"delete": {"D"}}},
+		"ctrl+c":         {Bindings: map[string][]string{"quit": {"ctrl+c"}}},
+	} {
+		if _, err := newKeymap(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestModel_RemappedKeys drives the session list with a remapped keymap:
+// the old key does nothing, the new one acts, and the help bar follows.
+func TestModel_RemappedKeys(t *testing.T) {
+	km, err := newKeymap(KeymapConfig{Bindings: map[string][]string{"delete": {"X"}, "quit": {}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		config:   &Config{},
+		hitmap:   &listHitmap{},
+		keys:     km,
+		width:    200,
+		height:   30,
+		sessions: []SessionRow{{Name: "alpha"}},
+	}
+	press := func(m Model, code rune) Model {
+		next, _ := m.Update(tea.KeyPressMsg{Code: code, Text: string(code)})
+		return next.(Model)
+	}
+	if got := press(m, 'd'); got.confirmDelete {
+		t.Error("d still deletes after remapping")
+	}
+	if got := press(m, 'q'); got.confirmQuit || got.quitting {
+		t.Error("q still quits after unbinding")
+	}
+	if got := press(m, 'X'); !got.confirmDelete {
+		t.Error("X does not delete")
+	}
+	bar := m.View().Content
+	if !strings.Contains(bar, "X: delete") || strings.Contains(bar, "q: quit") {
+		t.Errorf("help bar does not follow the keymap")
+	}
+}
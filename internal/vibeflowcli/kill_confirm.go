@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// describeRemoval lists what `vibeflow kill`/`delete` is about to remove for
+// the named session: the tmux session, its session file and its worktree,
+// with a summary of uncommitted changes that would be lost.
+func describeRemoval(name string, tmux *TmuxManager, meta SessionMeta, found, cleanupWorktree bool) string {
+	var b strings.Builder
+	state := "not running"
+	if tmux != nil && tmux.HasSession(name) {
+		state = "running"
+	}
+	fmt.Fprintf(&b, "  Session:       %s (%s)\n", name, state)
+	if !found {
+		b.WriteString("  No stored metadata: only the tmux session is affected.\n")
+		return b.String()
+	}
+	if meta.Provider != "" || meta.Branch != "" {
+		fmt.Fprintf(&b, "  Provider:      %s  branch %s\n", meta.Provider, meta.Branch)
+	}
+	if meta.WorkingDir != "" {
+		fp := filepath.Join(meta.WorkingDir, sessionFileForPersona(meta.Persona))
+		if _, err := os.Stat(fp); err == nil {
+			fmt.Fprintf(&b, "  Session file:  %s (kept, so the session ID can be reused)\n", fp)
+		}
+	}
+	if meta.WorktreePath == "" {
+		return b.String()
+	}
+	changes := uncommittedSummary(meta.WorktreePath)
+	if cleanupWorktree {
+		fmt.Fprintf(&b, "  Worktree:      %s (REMOVED, %s)\n", meta.WorktreePath, changes)
+	} else {
+		fmt.Fprintf(&b, "  Worktree:      %s (kept, %s; --cleanup-worktree removes it)\n", meta.WorktreePath, changes)
+	}
+	return b.String()
+}
+
+// uncommittedSummary describes the uncommitted changes in dir, e.g.
+// "2 modified, 1 untracked", or "no uncommitted changes".
+func uncommittedSummary(dir string) string {
+	// Porcelain v2, as gitIn trims the leading space v1 puts before an
+	// unstaged change.
+	out, err := gitIn(dir, "status", "--porcelain=v2")
+	if err != nil {
+		return "changes unknown"
+	}
+	var staged, modified, untracked int
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "? "):
+			untracked++
+		case len(line) < 4:
+			continue
+		case line[2] != '.':
+			staged++
+		default:
+			modified++
+		}
+	}
+	var parts []string
+	for _, p := range []struct {
+		n     int
+		label string
+	}{{staged, "staged"}, {modified, "modified"}, {untracked, "untracked"}} {
+		if p.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", p.n, p.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "no uncommitted changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// confirmRemoval shows the removal plan and asks for a yes on in. Anything
+// but y/yes, including end of input, declines.
+func confirmRemoval(in io.Reader, out io.Writer, verb, name, plan string) bool {
+	fmt.Fprintf(out, "%s session %q:\n%s", verb, name, plan)
+	fmt.Fprint(out, "Continue? [y/N] ")
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// confirmSessionRemoval asks before kill/delete unless --yes was given and
+// reports whether to go ahead. meta and found are the session's stored
+// metadata, from lookupRemovalTarget. Without a terminal to ask on it
+// refuses, so scripts must opt in with --yes.
+func confirmSessionRemoval(in io.Reader, out io.Writer, verb, name string, tmux *TmuxManager, meta SessionMeta, found, cleanupWorktree, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("%s %q needs confirmation; pass --yes to run without a terminal", strings.ToLower(verb), name)
+	}
+	return confirmRemoval(in, out, verb, name, describeRemoval(name, tmux, meta, found, cleanupWorktree)), nil
+}
+
+// lookupRemovalTarget finds the stored session kill/delete was given. name
+// is the tmux session name, with or without the prefix, as list and
+// completion offer it; a base name is accepted as well.
+func lookupRemovalTarget(tmux *TmuxManager, store *Store, name string) (SessionMeta, bool) {
+	meta, found, _ := store.GetByTmux(tmux.ensurePrefix(name))
+	if !found {
+		meta, found, _ = store.Get(name)
+	}
+	return meta, found
+}
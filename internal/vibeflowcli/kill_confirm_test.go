@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUncommittedSummary(t *testing.T) {
+	repo := initTestRepo(t)
+	if got := uncommittedSummary(repo); got != "no uncommitted changes" {
+		t.Errorf("clean repo = %q", got)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := uncommittedSummary(repo), "1 modified, 2 untracked"; got != want {
+		t.Errorf("dirty repo = %q, want %q", got, want)
+	}
+	if _, err := gitIn(repo, "add", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := uncommittedSummary(repo), "1 staged, 1 modified, 1 untracked"; got != want {
+		t.Errorf("after staging = %q, want %q", got, want)
+	}
+}
+
+func TestDescribeRemoval(t *testing.T) {
+	repo := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".vibeflow-session-developer"), []byte("s1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := SessionMeta{Name: "api", Provider: "claude", Branch: "feat", Persona: "developer", WorkingDir: repo, WorktreePath: repo}
+
+	plan := describeRemoval("api", nil, meta, true, true)
+	for _, want := range []string{
+		"api (not running)",
+		filepath.Join(repo, ".vibeflow-session-developer") + " (kept",
+		repo + " (REMOVED, 2 untracked)",
+	} {
+		if !strings.Contains(plan, want) {
+			t.Errorf("plan missing %q:\n%s", want, plan)
+		}
+	}
+	if plan := describeRemoval("api", nil, meta, true, false); !strings.Contains(plan, "--cleanup-worktree removes it") {
+		t.Errorf("plan without cleanup should keep the worktree:\n%s", plan)
+	}
+}
+
+func TestLookupRemovalTarget(t *testing.T) {
+	tm := &TmuxManager{}
+	store := testStore(t)
+	if err := store.Add(SessionMeta{Name: "down", TmuxSession: tm.FullSessionName("claude", "down"), Provider: "claude"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"claude-down", "vibeflow_claude-down", "down"} {
+		if meta, found := lookupRemovalTarget(tm, store, name); !found || meta.Name != "down" {
+			t.Errorf("lookup %q = %+v, %v; want the stored session", name, meta, found)
+		}
+	}
+	if _, found := lookupRemovalTarget(tm, store, "claude-other"); found {
+		t.Error("unknown session found")
+	}
+}
+
+func TestConfirmRemoval(t *testing.T) {
+	for input, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		var out bytes.Buffer
+		if got := confirmRemoval(strings.NewReader(input), &out, "Kill", "api", "  Session: api\n"); got != want {
+			t.Errorf("input %q = %v, want %v", input, got, want)
+		}
+		if !strings.Contains(out.String(), `Kill session "api"`) || !strings.Contains(out.String(), "[y/N]") {
+			t.Errorf("prompt = %q", out.String())
+		}
+	}
+}
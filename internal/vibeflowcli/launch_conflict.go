@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// onConflictActions maps `launch --on-conflict` values to the conflict
+// modal's actions.
+var onConflictActions = map[string]ConflictAction{
+	"switch":   ConflictSwitch,
+	"worktree": ConflictWorktree,
+	"cleanup":  ConflictCleanup,
+	"fail":     ConflictCancel,
+}
+
+// validateOnConflict checks an --on-conflict value. Empty means "ask".
+func validateOnConflict(policy string) error {
+	if _, ok := onConflictActions[policy]; policy != "" && !ok {
+		return fmt.Errorf("invalid --on-conflict %q — must be switch, worktree, cleanup or fail", policy)
+	}
+	return nil
+}
+
+// conflictPrompter asks the user how to resolve a conflict.
+type conflictPrompter func(ConflictResult) (ConflictAction, error)
+
+// resolveLaunchConflict decides how a CLI launch handles conflict. With no
+// policy, stale and external session files are cleaned up (as the TUI
+// does) and an active session is resolved by ask, or fails when ask is nil.
+// An explicit policy always applies; switch falls back to cleanup when
+// nothing is running to switch to, and cleanup refuses a running session.
+func resolveLaunchConflict(conflict ConflictResult, policy string, ask conflictPrompter) (ConflictAction, error) {
+	active := conflict.Status == ActiveConflict
+	action, ok := onConflictActions[policy]
+	if !ok {
+		switch {
+		case !active:
+			return ConflictCleanup, nil
+		case ask != nil:
+			var err error
+			if action, err = ask(conflict); err != nil {
+				return ConflictCancel, err
+			}
+		default:
+			action = ConflictCancel
+		}
+	}
+	switch {
+	case action == ConflictSwitch && !active:
+		return ConflictCleanup, nil
+	case action == ConflictCleanup && active:
+		return ConflictCancel, fmt.Errorf("session %s is still running in %s — use --on-conflict=switch or worktree, or kill it first", conflict.SessionID, conflict.TmuxSession)
+	case action == ConflictCancel:
+		return ConflictCancel, conflictError(conflict)
+	}
+	return action, nil
+}
+
+// conflictError describes a conflict the launch gave up on.
+func conflictError(conflict ConflictResult) error {
+	if conflict.Status == ActiveConflict {
+		return fmt.Errorf("session %s is already running in %s (%s); use --on-conflict to resolve", conflict.SessionID, conflict.TmuxSession, conflict.FilePath)
+	}
+	return fmt.Errorf("%s session file %s (session %s); use --on-conflict to resolve", conflict.Status, conflict.FilePath, conflict.SessionID)
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// promptConflict returns a prompter that offers the conflict modal's
+// options as a line-based prompt on in/out.
+func promptConflict(in io.Reader, out io.Writer) conflictPrompter {
+	reader := bufio.NewReader(in)
+	return func(conflict ConflictResult) (ConflictAction, error) {
+		modal := NewConflictModal(conflict)
+		fmt.Fprintf(out, "Session conflict in %s\n", conflict.FilePath)
+		fmt.Fprintf(out, "  Session:  %s\n  Provider: %s\n  Status:   %s\n", conflict.SessionID, conflict.Provider, conflict.Status)
+		for _, opt := range modal.options {
+			fmt.Fprintf(out, "  [%s] %s\n", opt.key, opt.label)
+		}
+		for {
+			fmt.Fprint(out, "Choice: ")
+			line, err := reader.ReadString('\n')
+			choice := strings.ToLower(strings.TrimSpace(line))
+			for _, opt := range modal.options {
+				if choice == opt.key {
+					return opt.action, nil
+				}
+			}
+			if err != nil {
+				return ConflictCancel, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envNamePattern matches a name a shell accepts as an environment variable.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// launchPlan is what a wizard launch will do on disk: where the agent runs,
+// and the worktree and branch it gets. validateLaunch checks it before
+// anything is created, so a bad value shows on the confirm step rather
+// than as an error after the wizard has closed.
+type launchPlan struct {
+	RepoDir      string // repository the launch starts from
+	Choice       WorktreeChoice
+	Branch       string
+	NewBranch    bool
+	Base         string // start point of a new branch; "" is git's default
+	WorktreeName string // "" for a generated name
+	BaseDir      string // config worktree.base_dir, relative to RepoDir
+	CustomDir    string // WorktreeCustom: where the worktree goes
+	AgentDir     string // WorktreeExisting or WorktreeSpecifyDir: where the agent runs
+}
+
+// createsWorktree reports whether the launch adds a worktree.
+func (p launchPlan) createsWorktree() bool {
+	return p.Choice == WorktreeNew || p.Choice == WorktreeCustom
+}
+
+// key identifies the plan for preflightRequest.key.
+func (p launchPlan) key() string {
+	return fmt.Sprintf("%s|%d|%s|%t|%s|%s|%s|%s|%s", p.RepoDir, p.Choice, p.Branch, p.NewBranch, p.Base, p.WorktreeName, p.BaseDir, p.CustomDir, p.AgentDir)
+}
+
+// validateLaunch checks a launch plan and the environment it passes: the
+// branch name, its start point and whether another worktree holds it, a
+// worktree name already taken, that the directory the agent (or its
+// worktree) goes in is writable, and that every variable has a valid name
+// and a value.
+func validateLaunch(p launchPlan, env map[string]string) []preflightCheck {
+	var checks []preflightCheck
+	if p.createsWorktree() {
+		checks = append(checks, validateWorktree(p)...)
+	}
+
+	dirCheck := preflightCheck{Label: "working directory writable"}
+	switch p.Choice {
+	case WorktreeNew:
+		dirCheck.Label = "worktree directory writable"
+		dirCheck.Err = checkWritable(filepath.Join(p.RepoDir, p.BaseDir), true)
+	case WorktreeCustom:
+		dirCheck.Label = "worktree directory writable"
+		dirCheck.Err = checkWritable(p.CustomDir, true)
+	case WorktreeExisting, WorktreeSpecifyDir:
+		dirCheck.Err = checkWritable(p.AgentDir, false)
+	default:
+		dirCheck.Err = checkWritable(p.RepoDir, false)
+	}
+	checks = append(checks, dirCheck)
+
+	if len(env) > 0 {
+		checks = append(checks, checkEnvVars(env))
+	}
+	return checks
+}
+
+// validateWorktree checks the branch and directory of a new worktree.
+func validateWorktree(p launchPlan) []preflightCheck {
+	branch := preflightCheck{Label: "branch " + p.Branch}
+	if p.Branch == "" {
+		branch.Label = "branch name"
+		branch.Err = errors.New("empty")
+		return []preflightCheck{branch}
+	}
+	if _, err := gitIn(p.RepoDir, "check-ref-format", "--branch", p.Branch); err != nil {
+		branch.Err = fmt.Errorf("not a valid branch name")
+		return []preflightCheck{branch}
+	}
+	// git refuses to check a branch out twice; the launch would then fall
+	// back to a branch of its own, named <branch>-wt-<time>.
+	if wm, err := NewWorktreeManager(p.RepoDir, p.BaseDir); err == nil {
+		if path, ok := wm.FindByBranch(p.Branch); ok {
+			branch.Err, branch.Warn = fmt.Errorf("already checked out in %s; the worktree gets a branch of its own", path), true
+		}
+	}
+	checks := []preflightCheck{branch}
+
+	if p.NewBranch && p.Base != "" {
+		base := preflightCheck{Label: "base " + p.Base}
+		if _, err := gitIn(p.RepoDir, "rev-parse", "--verify", "--quiet", p.Base+"^{commit}"); err != nil {
+			base.Err = errors.New("no such branch or commit")
+		}
+		checks = append(checks, base)
+	}
+
+	if p.WorktreeName != "" {
+		dir := p.CustomDir
+		if p.Choice == WorktreeNew {
+			dir = filepath.Join(p.RepoDir, p.BaseDir)
+		}
+		name := preflightCheck{Label: "worktree " + p.WorktreeName}
+		if _, err := os.Stat(filepath.Join(dir, p.WorktreeName)); err == nil {
+			name.Err, name.Warn = fmt.Errorf("%s exists; the worktree goes to %s-<time>", filepath.Join(dir, p.WorktreeName), p.WorktreeName), true
+		}
+		checks = append(checks, name)
+	}
+	return checks
+}
+
+// checkWritable reports whether files can be created in dir. With create,
+// dir may not exist yet: its nearest existing parent has to be writable.
+func checkWritable(dir string, create bool) error {
+	if dir == "" {
+		return errors.New("no directory chosen")
+	}
+	info, err := os.Stat(dir)
+	for create && os.IsNotExist(err) && filepath.Dir(dir) != dir {
+		dir = filepath.Dir(dir)
+		info, err = os.Stat(dir)
+	}
+	switch {
+	case os.IsNotExist(err):
+		return fmt.Errorf("%s does not exist", dir)
+	case err != nil:
+		return err
+	case !info.IsDir():
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	f, err := os.CreateTemp(dir, ".vibeflow-write-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable", dir)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// checkEnvVars flags variable names a shell would reject and variables
+// left without a value.
+func checkEnvVars(env map[string]string) preflightCheck {
+	c := preflightCheck{Label: "environment variables"}
+	var bad, empty []string
+	for name, value := range env {
+		switch {
+		case !envNamePattern.MatchString(name):
+			bad = append(bad, fmt.Sprintf("%q", name))
+		case value == "":
+			empty = append(empty, name)
+		}
+	}
+	sort.Strings(bad)
+	sort.Strings(empty)
+	switch {
+	case len(bad) > 0:
+		c.Err = fmt.Errorf("not valid: %s", strings.Join(bad, ", "))
+	case len(empty) > 0:
+		c.Err, c.Warn = fmt.Errorf("no value for %s", strings.Join(empty, ", ")), true
+	}
+	return c
+}
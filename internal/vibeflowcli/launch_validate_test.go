@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// checkByLabel returns the check labelled label, failing the test when
+// there is none.
+func checkByLabel(t *testing.T, checks []preflightCheck, label string) preflightCheck {
+	t.Helper()
+	for _, c := range checks {
+		if c.Label == label {
+			return c
+		}
+	}
+	t.Fatalf("no %q check in %+v", label, checks)
+	return preflightCheck{}
+}
+
+func TestValidateLaunch_Worktree(t *testing.T) {
+	repo := initTestRepo(t)
+	if out, err := exec.Command("git", "-C", repo, "branch", "-M", "main").CombinedOutput(); err != nil {
+		t.Fatalf("%s: %v", out, err)
+	}
+	plan := launchPlan{RepoDir: repo, Choice: WorktreeNew, Branch: "feature/login", NewBranch: true, Base: "main", BaseDir: ".worktrees"}
+
+	checks := validateLaunch(plan, nil)
+	for _, c := range checks {
+		if c.Err != nil {
+			t.Errorf("%s: %v", c.Label, c.Err)
+		}
+	}
+	if len(checks) != 3 {
+		t.Errorf("checks = %+v, want branch, base and directory", checks)
+	}
+
+	bad := plan
+	bad.Branch = "feature..login"
+	if c := checkByLabel(t, validateLaunch(bad, nil), "branch feature..login"); !c.failed() {
+		t.Errorf("invalid branch name passed: %+v", c)
+	}
+	bad = plan
+	bad.Branch = ""
+	if c := checkByLabel(t, validateLaunch(bad, nil), "branch name"); !c.failed() {
+		t.Errorf("empty branch name passed: %+v", c)
+	}
+	bad = plan
+	bad.Base = "release-9"
+	if c := checkByLabel(t, validateLaunch(bad, nil), "base release-9"); !c.failed() {
+		t.Errorf("missing base passed: %+v", c)
+	}
+
+	// main is checked out in the repository itself: the launch still
+	// works, on a branch of its own.
+	busy := plan
+	busy.Branch, busy.NewBranch = "main", false
+	if c := checkByLabel(t, validateLaunch(busy, nil), "branch main"); c.Err == nil || !c.Warn || !strings.Contains(c.Err.Error(), "already checked out") {
+		t.Errorf("checked-out branch: %+v", c)
+	}
+
+	taken := plan
+	taken.WorktreeName = "login"
+	if err := os.MkdirAll(filepath.Join(repo, ".worktrees", "login"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if c := checkByLabel(t, validateLaunch(taken, nil), "worktree login"); c.Err == nil || !c.Warn {
+		t.Errorf("taken worktree name: %+v", c)
+	}
+}
+
+func TestValidateLaunch_Directories(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		plan    launchPlan
+		wantErr string
+	}{
+		{"current", launchPlan{RepoDir: dir, Choice: WorktreeCurrent}, ""},
+		{"missing dir", launchPlan{RepoDir: filepath.Join(dir, "gone"), Choice: WorktreeCurrent}, "does not exist"},
+		{"specified file", launchPlan{RepoDir: dir, Choice: WorktreeSpecifyDir, AgentDir: file}, "not a directory"},
+		{"specified nothing", launchPlan{RepoDir: dir, Choice: WorktreeSpecifyDir}, "no directory chosen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := checkByLabel(t, validateLaunch(tt.plan, nil), "working directory writable")
+			if tt.wantErr == "" && c.Err != nil || tt.wantErr != "" && (c.Err == nil || !strings.Contains(c.Err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", c.Err, tt.wantErr)
+			}
+		})
+	}
+
+	// A custom worktree location that doesn't exist yet is created, so its
+	// nearest existing parent is what has to be writable.
+	if err := checkWritable(filepath.Join(dir, "a", "b"), true); err != nil {
+		t.Errorf("new custom dir: %v", err)
+	}
+	if err := checkWritable(filepath.Join(file, "sub"), true); err == nil || !strings.Contains(err.Error(), "not a directory") {
+		t.Errorf("custom dir under a file: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("write checks left files behind: %v", entries)
+	}
+}
+
+func TestCheckEnvVars(t *testing.T) {
+	if c := checkEnvVars(map[string]string{"API_TOKEN": "x", "_DEBUG": "1"}); c.Err != nil {
+		t.Errorf("valid env: %v", c.Err)
+	}
+	c := checkEnvVars(map[string]string{"API_TOKEN": "", "DEBUG": ""})
+	if c.Err == nil || !c.Warn || c.Err.Error() != "no value for API_TOKEN, DEBUG" {
+		t.Errorf("empty values: %+v", c)
+	}
+	c = checkEnvVars(map[string]string{"MY-VAR": "x", "1ST": "y", "EMPTY": ""})
+	if !c.failed() || c.Err.Error() != `not valid: "1ST", "MY-VAR"` {
+		t.Errorf("bad names: %+v", c)
+	}
+}
+
+func TestRunPreflight_ValidatesLaunch(t *testing.T) {
+	req := preflightRequest{
+		Targets: []preflightTarget{{Key: "codex", Provider: Provider{Binary: "true"}}},
+		Env:     map[string]string{"BAD NAME": "x"},
+		Launch:  &launchPlan{RepoDir: t.TempDir(), Choice: WorktreeCurrent},
+	}
+	checks := runPreflight(req)
+	var labels []string
+	for _, c := range checks {
+		labels = append(labels, c.Label)
+	}
+	if got := strings.Join(labels, ", "); got != "working directory writable, environment variables, true --version" {
+		t.Errorf("checks = %s", got)
+	}
+	if !checkByLabel(t, checks, "environment variables").failed() {
+		t.Error("a bad variable name didn't fail the preflight")
+	}
+	if other := (preflightRequest{Launch: &launchPlan{RepoDir: "/elsewhere"}}); other.key() == (preflightRequest{Launch: &launchPlan{RepoDir: "/repo"}}).key() {
+		t.Error("a different launch plan has the same key")
+	}
+}
+
+func TestWizard_ConfirmShowsLaunchProblems(t *testing.T) {
+	wm := modelWizardFixture(t, "claude")
+	wm.worktreeOpts = []string{"New worktree", "Current directory"}
+	wm.selectedWorktree = 0
+	wm.selectedBranch = 0
+	wm.newBranchName = "fix login"
+	wm.selectedWorkDir = initTestRepo(t)
+	wm.step = StepConfirm
+
+	plan := wm.launchPlan()
+	if plan.Choice != WorktreeNew || plan.Branch != "fix login" || !plan.NewBranch || plan.RepoDir != wm.selectedWorkDir {
+		t.Fatalf("plan = %+v", plan)
+	}
+	cmd := wm.loadPreflight()
+	if cmd == nil {
+		t.Fatal("confirm step did not start the checks")
+	}
+	wm.applyPreflight(cmd().(preflightMsg))
+	if v := wm.View(); !strings.Contains(v, "branch fix login: not a valid branch name") || !strings.Contains(v, "enter twice") {
+		t.Errorf("confirm view does not show the bad branch:\n%s", v)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// List states counted in the `vibeflow list --wide` footer.
+const (
+	listRunning = "running"
+	listFailed  = "failed"
+	listWaiting = "waiting"
+)
+
+// wideListRow is one session in `vibeflow list --wide`.
+type wideListRow struct {
+	Name          string
+	Provider      string
+	Project       string
+	Persona       string
+	Branch        string
+	Worktree      string
+	Status        string        // running, attached or exited
+	Health        string        // as in `vibeflow status`
+	Uptime        time.Duration // zero when the start time is unknown
+	LastHeartbeat time.Time     // zero when the server has none
+	State         string        // listRunning, listFailed or listWaiting
+}
+
+// paneProbe returns a live session's health and captured pane output, as
+// probePaneHealth does.
+type paneProbe func(ts TmuxSession, provider string) (health, output string)
+
+// wideListRows builds the `list --wide` rows. heartbeats is keyed by the
+// server-side session ID (see heartbeatSessionID). A session is failed when
+// its pane is dead or a fatal error matched, waiting when it is blocked on
+// an input prompt or queued for a slot, else running.
+func wideListRows(sessions []TmuxSession, metas map[string]SessionMeta, probe paneProbe, heartbeats map[string]time.Time, now time.Time) []wideListRow {
+	var rows []wideListRow
+	for _, ts := range sessions {
+		if isWorkbenchHolder(ts.Name) {
+			continue
+		}
+		meta, ok := metas[ts.Name]
+		if !ok {
+			meta = SessionMeta{Name: ts.Name, TmuxSession: ts.Name}
+		}
+		health, output := probe(ts, meta.Provider)
+		row := wideListRow{
+			Name:     strings.TrimPrefix(ts.Name, sessionPrefix),
+			Provider: meta.Provider,
+			Project:  meta.Project,
+			Persona:  meta.Persona,
+			Branch:   meta.Branch,
+			Status:   sessionStatus(ts.Attached, ts.PaneDead),
+			Health:   health,
+			Uptime:   sessionUptime(ts, meta, now),
+			State:    listRunning,
+		}
+		if meta.WorktreePath != "" {
+			row.Worktree = filepath.Base(meta.WorktreePath)
+		}
+		if ok {
+			row.LastHeartbeat = heartbeats[heartbeatSessionID(meta)]
+		}
+		switch {
+		case health == healthDead || health == HealthFailed.String():
+			row.State = listFailed
+		case !meta.QueuedAt.IsZero() || looksLikeInputPrompt(stripANSI(output)):
+			row.State = listWaiting
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// sessionUptime is how long the session has existed: from the stored launch
+// time, else from tmux's session_created_string (local time, ANSI C format).
+func sessionUptime(ts TmuxSession, meta SessionMeta, now time.Time) time.Duration {
+	start := meta.CreatedAt
+	if start.IsZero() {
+		t, err := time.ParseInLocation(time.ANSIC, ts.CreatedAt, time.Local)
+		if err != nil {
+			return 0
+		}
+		start = t
+	}
+	if d := now.Sub(start); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// serverHeartbeats returns the server's last heartbeat per session ID for
+// the projects in metas. It is best-effort: a project the server can't
+// list is skipped.
+func serverHeartbeats(client VibeflowAPI, metas map[string]SessionMeta) map[string]time.Time {
+	beats := make(map[string]time.Time)
+	seen := make(map[int64]bool)
+	for _, meta := range metas {
+		if meta.ProjectID == 0 || seen[meta.ProjectID] {
+			continue
+		}
+		seen[meta.ProjectID] = true
+		sessions, err := client.ListSessions(meta.ProjectID)
+		if err != nil {
+			continue
+		}
+		for _, s := range sessions {
+			if !s.LastHeartbeat.IsZero() {
+				beats[s.ID] = s.LastHeartbeat
+			}
+		}
+	}
+	return beats
+}
+
+// printWideSessionTable writes the `list --wide` table and its summary
+// footer.
+func printWideSessionTable(out io.Writer, rows []wideListRow, now time.Time) {
+	const format = "%-24s %-10s %-14s %-12s %-20s %-18s %-9s %-7s %-15s %s\n"
+	fmt.Fprintf(out, format, "NAME", "PROVIDER", "PROJECT", "PERSONA", "BRANCH", "WORKTREE", "STATUS", "UPTIME", "HEALTH", "HEARTBEAT")
+	fmt.Fprintln(out, strings.Repeat("-", 144))
+	for _, r := range rows {
+		uptime, beat := "-", "-"
+		if r.Uptime > 0 {
+			uptime = formatSessionDuration(r.Uptime)
+		}
+		if !r.LastHeartbeat.IsZero() {
+			beat = formatSessionDuration(now.Sub(r.LastHeartbeat)) + " ago"
+		}
+		fmt.Fprintf(out, format, truncate(r.Name, 24), truncate(orDash(r.Provider), 10), truncate(orDash(r.Project), 14),
+			truncate(orDash(r.Persona), 12), truncate(orDash(r.Branch), 20), truncate(orDash(r.Worktree), 18),
+			r.Status, uptime, r.Health, beat)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, listSummary(rows))
+}
+
+// listSummary is the footer line, e.g. "3 running / 1 failed / 0 waiting".
+func listSummary(rows []wideListRow) string {
+	counts := make(map[string]int)
+	for _, r := range rows {
+		counts[r.State]++
+	}
+	return fmt.Sprintf("%d %s / %d %s / %d %s",
+		counts[listRunning], listRunning, counts[listFailed], listFailed, counts[listWaiting], listWaiting)
+}
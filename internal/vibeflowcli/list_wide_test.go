@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWideListRows_StatesAndColumns(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	sessions := []TmuxSession{
+		{Name: "vibeflow_claude-a", Attached: true},
+		{Name: "vibeflow_codex-b"},
+		{Name: "vibeflow_claude-c", PaneDead: true},
+		{Name: "vibeflow_gemini-d"},
+		{Name: "vibeflow_orphan", CreatedAt: now.Add(-90 * time.Second).In(time.Local).Format(time.ANSIC)},
+		{Name: workbenchHolderName},
+	}
+	metas := map[string]SessionMeta{
+		"vibeflow_claude-a": {Name: "claude-a", Provider: "claude", Project: "web", ProjectID: 3, Persona: "developer",
+			Branch: "feat/x", WorktreePath: "/repo/.claude/worktrees/feat-x", VibeFlowSessionID: "sess-a", CreatedAt: now.Add(-2 * time.Hour)},
+		"vibeflow_codex-b":  {Name: "codex-b", Provider: "codex", QueuedAt: now},
+		"vibeflow_claude-c": {Name: "claude-c", Provider: "claude"},
+		"vibeflow_gemini-d": {Name: "gemini-d", Provider: "gemini"},
+	}
+	probe := func(ts TmuxSession, provider string) (string, string) {
+		switch ts.Name {
+		case "vibeflow_claude-c":
+			return healthDead, ""
+		case "vibeflow_gemini-d":
+			return HealthHealthy.String(), "Allow command execution?\n(y/n)"
+		}
+		return HealthHealthy.String(), "working...\n"
+	}
+	beats := map[string]time.Time{"sess-a": now.Add(-30 * time.Second)}
+
+	rows := wideListRows(sessions, metas, probe, beats, now)
+	if len(rows) != 5 {
+		t.Fatalf("rows = %d, want 5 (workbench holder skipped)", len(rows))
+	}
+	a := rows[0]
+	if a.Name != "claude-a" || a.Project != "web" || a.Persona != "developer" || a.Worktree != "feat-x" ||
+		a.Status != "attached" || a.Uptime != 2*time.Hour || !a.LastHeartbeat.Equal(beats["sess-a"]) {
+		t.Errorf("claude-a row = %+v", a)
+	}
+	var states []string
+	for _, r := range rows {
+		states = append(states, r.State)
+	}
+	if got := strings.Join(states, ","); got != "running,waiting,failed,waiting,running" {
+		t.Errorf("states = %s", got)
+	}
+	if rows[4].Uptime != 90*time.Second {
+		t.Errorf("orphan uptime = %s, want tmux creation time", rows[4].Uptime)
+	}
+	if got := listSummary(rows); got != "2 running / 1 failed / 2 waiting" {
+		t.Errorf("summary = %q", got)
+	}
+
+	var out strings.Builder
+	printWideSessionTable(&out, rows, now)
+	for _, want := range []string{"HEARTBEAT", "feat-x", "2h00m", "30s ago", "2 running / 1 failed / 2 waiting"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("table missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestServerHeartbeats(t *testing.T) {
+	beat := time.Date(2026, 5, 1, 11, 59, 0, 0, time.UTC)
+	api := &mockAPI{sessions: map[int64][]Session{
+		3: {{ID: "sess-a", LastHeartbeat: beat}, {ID: "sess-never"}},
+	}}
+	metas := map[string]SessionMeta{
+		"vibeflow_a": {ProjectID: 3},
+		"vibeflow_b": {ProjectID: 3},
+		"vibeflow_c": {ProjectID: 9},
+		"vibeflow_d": {},
+	}
+	beats := serverHeartbeats(api, metas)
+	if len(beats) != 1 || !beats["sess-a"].Equal(beat) {
+		t.Errorf("beats = %v", beats)
+	}
+	if len(api.calls) != 2 {
+		t.Errorf("calls = %q, want one ListSessions per project", api.calls)
+	}
+}
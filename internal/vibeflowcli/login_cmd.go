@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// loginCmd signs in to the server with the OAuth device flow.
+func loginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Sign in to the VibeFlow server with a browser (OAuth device flow)",
+		Long: `Sign in to the VibeFlow server without pasting an API token: open the
+printed URL, enter the code, and the CLI stores the resulting login in the
+secret store and refreshes it as it expires. Sets auth.method to oauth in
+the config. Agents' MCP_TOKEN still comes from api_token.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			if cfgPath == "" {
+				cfgPath = ConfigPath()
+			}
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if cfg.ServerURL == "" {
+				return fmt.Errorf("no server_url configured (run `vibeflow config` first)")
+			}
+			if err := cfg.Auth.Validate(); err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			out := cmd.OutOrStdout()
+			if err := deviceLogin(ctx, cfg, out); err != nil {
+				return err
+			}
+			if !cfg.Auth.OAuth() {
+				cfg.Auth.Method = authMethodOAuth
+				if err := SaveConfig(cfg, cfgPath); err != nil {
+					return fmt.Errorf("save config: %w", err)
+				}
+				fmt.Fprintln(out, "Set auth.method to oauth.")
+			}
+			return nil
+		},
+	}
+}
+
+// logoutCmd forgets the stored OAuth login.
+func logoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Forget the OAuth login for the VibeFlow server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			if cfgPath == "" {
+				cfgPath = ConfigPath()
+			}
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			err = cfg.secretStore().Delete(oauthSecretKey(cfg.ServerURL))
+			if err != nil && !errors.Is(err, ErrSecretNotFound) {
+				return fmt.Errorf("remove login: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Logged out of %s.\n", cfg.ServerURL)
+			return nil
+		},
+	}
+}
+
+// deviceLogin runs the device flow for cfg's server, printing where to
+// approve it on out, and stores the login.
+func deviceLogin(ctx context.Context, cfg *Config, out io.Writer) error {
+	oauth := newOAuthClient(cfg)
+	dc, err := oauth.StartDeviceFlow(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Open %s and enter the code %s\n", dc.VerificationURI, dc.UserCode)
+	if dc.VerificationURIComplete != "" {
+		fmt.Fprintf(out, "  (or open %s)\n", dc.VerificationURIComplete)
+	}
+	fmt.Fprintln(out, "Waiting for approval...")
+	if err := newOAuthTokenSource(oauth, cfg.secretStore()).Login(ctx, dc); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Logged in to %s.\n", cfg.ServerURL)
+	return nil
+}
+
+// offerReauth handles a command that failed with ErrUnauthorized: with an
+// OAuth login and a terminal it offers to sign in again on in/out,
+// otherwise it adds a hint on how to fix the credentials.
+func offerReauth(cmdErr error, in io.Reader, out io.Writer, interactive bool) error {
+	cfgPath := flagConfigPath
+	if cfgPath == "" {
+		cfgPath = ConfigPath()
+	}
+	cfg, err := LoadConfig(cfgPath)
+	if err != nil || !cfg.usesOAuth() {
+		return fmt.Errorf("%w (check api_token with `vibeflow config`, or sign in with `vibeflow login`)", cmdErr)
+	}
+	if !interactive {
+		return fmt.Errorf("%w (run `vibeflow login`)", cmdErr)
+	}
+	fmt.Fprintf(out, "%v\nSign in to %s again now? [Y/n] ", cmdErr, cfg.ServerURL)
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "y", "yes":
+	default:
+		return fmt.Errorf("%w (run `vibeflow login`)", cmdErr)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := deviceLogin(ctx, cfg, out); err != nil {
+		return err
+	}
+	return errors.New("signed in again; re-run the command")
+}
@@ -40,6 +40,11 @@ var builtInProviderModels = map[string][]ModelOption{
 		{ID: "gemini-2.5-pro", Description: "Gemini Pro model"},
 		{ID: "gemini-2.5-flash", Description: "Gemini Flash model"},
 	},
+	"aider": {
+		{ID: "sonnet", Description: "Claude Sonnet alias"},
+		{ID: "gpt-5", Description: "OpenAI model"},
+		{ID: "deepseek", Description: "DeepSeek chat alias"},
+	},
 	"qwen": {
 		{ID: "qwen3-coder-plus", Description: "Qwen coding model"},
 		{ID: "GLM-4.6", Description: "z.ai coding model"},
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// sessionNoteMax caps a session note, in characters. Notes are reminders
+// shown under the session's name, not a place for documentation.
+const sessionNoteMax = 200
+
+// cleanNote folds a note onto one line and checks its length.
+func cleanNote(note string) (string, error) {
+	note = strings.Join(strings.Fields(note), " ")
+	if n := utf8.RuneCountInString(note); n > sessionNoteMax {
+		return "", fmt.Errorf("note is %d characters; keep it under %d", n, sessionNoteMax+1)
+	}
+	return note, nil
+}
+
+// --- note ---
+
+func noteCmd() *cobra.Command {
+	var clear bool
+	cmd := &cobra.Command{
+		Use:   "note <session-name> [text...]",
+		Short: "Show or set a session's note",
+		Long: `Attach a short free-text note to a session, e.g. what it was started for.
+The note is shown under the session in the TUI list and in its detail panel,
+where 'c' edits it too.
+
+Without text the current note is printed. Words are joined with spaces, so
+quoting is optional; --clear removes the note.`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: sessionCompletion(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			_, _, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			meta, found, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("session %q not found", args[0])
+			}
+			out := cmd.OutOrStdout()
+			if len(args) == 1 && !clear {
+				if meta.Note == "" {
+					fmt.Fprintf(out, "%q has no note.\n", meta.Name)
+				} else {
+					fmt.Fprintln(out, meta.Note)
+				}
+				return nil
+			}
+			if clear && len(args) > 1 {
+				return fmt.Errorf("--clear takes no note text")
+			}
+			note, err := cleanNote(strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+			if err := store.SetNote(meta.Name, note); err != nil {
+				return err
+			}
+			if note == "" {
+				fmt.Fprintf(out, "Note of %q removed.\n", meta.Name)
+			} else {
+				fmt.Fprintf(out, "Note of %q updated.\n", meta.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove the session's note")
+	return cmd
+}
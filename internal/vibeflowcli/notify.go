@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NotifyKind identifies why a session needs attention.
+type NotifyKind int
+
+const (
+	// NotifyNeedsInput fires when an agent is blocked on an input prompt.
+	NotifyNeedsInput NotifyKind = iota
+	// NotifyFailed fires when error recovery gave up (HealthFailed).
+	NotifyFailed
+	// NotifyReadyForReview fires when an auto PR was opened for a session.
+	NotifyReadyForReview
+	// NotifyTimeoutSoon fires when a session nears its lifetime or idle limit.
+	NotifyTimeoutSoon
+)
+
+// String returns the event name used in webhook payloads.
+func (k NotifyKind) String() string {
+	switch k {
+	case NotifyNeedsInput:
+		return "needs_input"
+	case NotifyFailed:
+		return "failed"
+	case NotifyReadyForReview:
+		return "ready_for_review"
+	case NotifyTimeoutSoon:
+		return "timeout_soon"
+	default:
+		return "unknown"
+	}
+}
+
+// notifyPayload is the JSON body POSTed to the webhook. "text" makes it
+// directly usable as a Slack/Mattermost incoming webhook.
+type notifyPayload struct {
+	Event     string    `json:"event"`
+	Session   string    `json:"session"`
+	Message   string    `json:"message"`
+	Text      string    `json:"text"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier sends desktop and webhook notifications when a session starts
+// needing attention. Each (session, kind) fires once per transition into the
+// condition; it fires again only after the condition clears. A nil *Notifier
+// is a no-op.
+type Notifier struct {
+	cfg    NotificationConfig
+	logger *Logger
+	client *http.Client
+
+	mu     sync.Mutex
+	active map[string]map[NotifyKind]bool // session → kinds currently notified
+
+	// desktop and post are swapped out in tests.
+	desktop func(title, body string) error
+	post    func(url string, payload notifyPayload) error
+}
+
+// NewNotifier returns a notifier for cfg, or nil when no channel is enabled.
+func NewNotifier(cfg NotificationConfig, logger *Logger) *Notifier {
+	if !cfg.Desktop && cfg.WebhookURL == "" {
+		return nil
+	}
+	n := &Notifier{
+		cfg:    cfg,
+		logger: logger,
+		client: &http.Client{Timeout: 10 * time.Second},
+		active: make(map[string]map[NotifyKind]bool),
+	}
+	n.desktop = desktopNotify
+	n.post = n.postWebhook
+	return n
+}
+
+// Set records whether session is currently in the kind condition and sends a
+// notification on the false→true transition. Delivery happens in the
+// background so a slow webhook never stalls the TUI.
+func (n *Notifier) Set(session string, kind NotifyKind, active bool, detail string) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	kinds := n.active[session]
+	was := kinds[kind]
+	if active == was {
+		n.mu.Unlock()
+		return
+	}
+	if kinds == nil {
+		kinds = make(map[NotifyKind]bool)
+		n.active[session] = kinds
+	}
+	kinds[kind] = active
+	n.mu.Unlock()
+	if active {
+		go n.send(session, kind, detail)
+	}
+}
+
+// Prune forgets sessions not in live, so a recreated session with the same
+// name notifies afresh.
+func (n *Notifier) Prune(live []string) {
+	if n == nil {
+		return
+	}
+	keep := make(map[string]bool, len(live))
+	for _, name := range live {
+		keep[name] = true
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for name := range n.active {
+		if !keep[name] {
+			delete(n.active, name)
+		}
+	}
+}
+
+// notifyMessage builds the one-line human-readable notification text.
+func notifyMessage(session string, kind NotifyKind, detail string) string {
+	var msg string
+	switch kind {
+	case NotifyNeedsInput:
+		msg = fmt.Sprintf("Session %s is waiting for input", session)
+	case NotifyFailed:
+		msg = fmt.Sprintf("Session %s failed and needs attention", session)
+	case NotifyReadyForReview:
+		msg = fmt.Sprintf("Session %s is ready for review", session)
+	case NotifyTimeoutSoon:
+		msg = fmt.Sprintf("Session %s is about to time out", session)
+	default:
+		msg = fmt.Sprintf("Session %s needs attention", session)
+	}
+	if detail != "" {
+		msg += ": " + detail
+	}
+	return msg
+}
+
+func (n *Notifier) send(session string, kind NotifyKind, detail string) {
+	msg := notifyMessage(session, kind, detail)
+	if n.cfg.Desktop {
+		if err := n.desktop("VibeFlow", msg); err != nil {
+			n.logger.Warn("notify: desktop notification for %s: %v", session, err)
+		}
+	}
+	if n.cfg.WebhookURL != "" {
+		payload := notifyPayload{
+			Event:     kind.String(),
+			Session:   session,
+			Message:   msg,
+			Text:      msg,
+			Timestamp: time.Now().UTC(),
+		}
+		if err := n.post(n.cfg.WebhookURL, payload); err != nil {
+			n.logger.Warn("notify: webhook for %s: %v", session, err)
+		}
+	}
+}
+
+func (n *Notifier) postWebhook(url string, payload notifyPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// desktopNotify shows a native notification: osascript on macOS,
+// notify-send elsewhere.
+func desktopNotify(title, body string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptQuote(body), appleScriptQuote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	} else {
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return fmt.Errorf("notify-send not found (install libnotify)")
+		}
+		cmd = exec.Command("notify-send", "--app-name=vibeflow", title, body)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w (%s)", cmd.Args[0], err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// appleScriptQuote returns s as an AppleScript string literal.
+func appleScriptQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingNotifier returns a notifier whose channels report into a channel
+// instead of the desktop / network.
+func recordingNotifier(cfg NotificationConfig) (*Notifier, chan string) {
+	n := NewNotifier(cfg, &Logger{})
+	got := make(chan string, 16)
+	n.desktop = func(title, body string) error {
+		got <- "desktop:" + body
+		return nil
+	}
+	n.post = func(url string, p notifyPayload) error {
+		got <- "webhook:" + p.Event + ":" + p.Session
+		return nil
+	}
+	return n, got
+}
+
+func expectNotification(t *testing.T, got chan string, want string) {
+	t.Helper()
+	select {
+	case s := <-got:
+		if s != want {
+			t.Errorf("notification = %q, want %q", s, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no notification, want %q", want)
+	}
+}
+
+func expectNone(t *testing.T, got chan string) {
+	t.Helper()
+	select {
+	case s := <-got:
+		t.Errorf("unexpected notification %q", s)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNewNotifier_DisabledIsNil(t *testing.T) {
+	if n := NewNotifier(NotificationConfig{}, &Logger{}); n != nil {
+		t.Error("no channels configured should yield a nil notifier")
+	}
+	var n *Notifier
+	n.Set("s", NotifyFailed, true, "") // must not panic
+	n.Prune(nil)
+}
+
+func TestNotifier_FiresOncePerTransition(t *testing.T) {
+	n, got := recordingNotifier(NotificationConfig{Desktop: true})
+
+	n.Set("claude-a", NotifyNeedsInput, true, "")
+	expectNotification(t, got, "desktop:Session claude-a is waiting for input")
+	n.Set("claude-a", NotifyNeedsInput, true, "")
+	expectNone(t, got)
+
+	// Clearing and re-entering the condition notifies again.
+	n.Set("claude-a", NotifyNeedsInput, false, "")
+	n.Set("claude-a", NotifyNeedsInput, true, "")
+	expectNotification(t, got, "desktop:Session claude-a is waiting for input")
+
+	// Kinds are tracked independently.
+	n.Set("claude-a", NotifyFailed, true, "rate limited")
+	expectNotification(t, got, "desktop:Session claude-a failed and needs attention: rate limited")
+}
+
+func TestNotifier_PruneResetsState(t *testing.T) {
+	n, got := recordingNotifier(NotificationConfig{WebhookURL: "http://example.invalid"})
+	n.Set("s", NotifyFailed, true, "")
+	expectNotification(t, got, "webhook:failed:s")
+	n.Prune(nil)
+	n.Set("s", NotifyFailed, true, "")
+	expectNotification(t, got, "webhook:failed:s")
+}
+
+func TestNotifier_PostWebhook(t *testing.T) {
+	var payload notifyPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(NotificationConfig{WebhookURL: srv.URL}, &Logger{})
+	msg := notifyMessage("codex-b", NotifyNeedsInput, "")
+	if err := n.postWebhook(srv.URL, notifyPayload{Event: "needs_input", Session: "codex-b", Message: msg, Text: msg}); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != "needs_input" || payload.Session != "codex-b" || !strings.Contains(payload.Text, "waiting for input") {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer failing.Close()
+	if err := n.postWebhook(failing.URL, notifyPayload{}); err == nil {
+		t.Error("non-2xx response should be an error")
+	}
+}
+
+func TestModelNotifyAttention(t *testing.T) {
+	n, got := recordingNotifier(NotificationConfig{Desktop: true})
+	m := Model{
+		notifier: n,
+		sessions: []SessionRow{
+			{Name: "bg", Status: "waiting"},
+			{Name: "fg", Status: "waiting", TmuxAttached: true},
+			{Name: "busy", Status: "working"},
+		},
+	}
+	m.notifyAttention()
+	expectNotification(t, got, "desktop:Session bg is waiting for input")
+	expectNone(t, got)
+}
+
+func TestAppleScriptQuote(t *testing.T) {
+	if got := appleScriptQuote(`say "hi" \ bye`); got != `"say \"hi\" \\ bye"` {
+		t.Errorf("appleScriptQuote = %s", got)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"regexp"
+	"strings"
+)
+
+// paneStatusTailLines is how much of a pane the status parsers read. Tool
+// calls scroll away quickly, so only the recent screen says what the agent
+// is doing now.
+const paneStatusTailLines = 40
+
+// PaneStatus is what an agent's pane says it is doing, read from the pane
+// capture by its provider's parser. Empty fields are unknown.
+type PaneStatus struct {
+	Tool       string // current tool call as the agent shows it, e.g. "Bash(npm test)"
+	File       string // file the current tool call reads or edits
+	Tokens     int64  // tokens used so far, from the agent's live counter
+	Permission string // the question of a permission prompt at the bottom of the pane
+}
+
+// IsZero reports whether nothing was recognised.
+func (s PaneStatus) IsZero() bool {
+	return s == PaneStatus{}
+}
+
+// paneParser reads a provider's pane. tool matches a tool call line with
+// the tool's name and its argument as submatches; files lists the tools
+// whose argument names a file. tokens matches the live token counter with
+// the count as its submatch. calls is set for agents that show a tool call
+// as Name(argument).
+type paneParser struct {
+	tool   *regexp.Regexp
+	files  map[string]bool
+	tokens *regexp.Regexp
+	calls  bool
+}
+
+// paneParsers are the built-in parsers by provider key. A provider without
+// one gets genericPaneParser; a custom provider can borrow a built-in one
+// with output_parser.
+var paneParsers = map[string]paneParser{
+	// Claude Code: "⏺ Update(src/app.ts)" and
+	// "✻ Thinking… (12s · ↑ 1.2k tokens · esc to interrupt)".
+	"claude": {
+		tool:   regexp.MustCompile(`^\s*[⏺●]\s*([A-Z]\w*)\((.*?)\)?\s*$`),
+		files:  map[string]bool{"Read": true, "Edit": true, "MultiEdit": true, "Write": true, "Update": true, "Create": true, "NotebookEdit": true},
+		tokens: regexp.MustCompile(`([0-9][0-9.,]*[kKmM]?) tokens\b.*\besc to interrupt`),
+		calls:  true,
+	},
+	// codex: "• Running npm test", "• Edited src/main.rs (+3 -1)" and
+	// "12.3K tokens used" in the footer.
+	"codex": {
+		tool:   regexp.MustCompile(`^\s*[•●]\s*(Running|Ran|Edited|Editing|Added|Deleted|Read|Reading|Searched|Listed)\s+(.+?)\s*$`),
+		files:  map[string]bool{"Edited": true, "Editing": true, "Added": true, "Deleted": true, "Read": true, "Reading": true},
+		tokens: regexp.MustCompile(`([0-9][0-9.,]*[kKmM]?) tokens used`),
+	},
+	// Gemini CLI: tool boxes such as "│ ✔  ReadFile src/app.ts │" and
+	// "│ ⊷  Shell npm test │".
+	"gemini": {
+		tool:  regexp.MustCompile(`^[│|]?\s*[✔✓⊷✖✗?o]\s+(ReadFile|WriteFile|Edit|Shell|ReadManyFiles|ReadFolder|FindFiles|SearchText|WebFetch|GoogleSearch|SaveMemory)\s+(.+?)\s*[│|]?\s*$`),
+		files: map[string]bool{"ReadFile": true, "WriteFile": true, "Edit": true},
+	},
+}
+
+// genericPaneParser reads what most agents print: "Editing src/x.go",
+// "Running: make test" and "1,234 tokens".
+var genericPaneParser = paneParser{
+	tool:   regexp.MustCompile(`(?i)^\s*(?:[^\w\s]\s*)?(Editing|Writing|Reading|Creating|Running|Executing)\s*:?\s+(.+?)\s*$`),
+	files:  map[string]bool{"editing": true, "writing": true, "reading": true, "creating": true},
+	tokens: regexp.MustCompile(`(?i)([0-9][0-9.,]*[kKmM]?) tokens`),
+}
+
+// ParsePaneStatus reads a pane capture with the named parser (a provider
+// key; see paneParsers).
+func ParsePaneStatus(parser, output string) PaneStatus {
+	p, ok := paneParsers[parser]
+	if !ok {
+		p = genericPaneParser
+	}
+	tail := stripANSI(lastNLines(strings.TrimRight(output, "\n "), paneStatusTailLines))
+	lines := strings.Split(tail, "\n")
+
+	var s PaneStatus
+	for i := len(lines) - 1; i >= 0; i-- {
+		m := p.tool.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		name, arg := m[1], strings.TrimSpace(m[2])
+		if p.calls {
+			s.Tool = name + "(" + arg + ")"
+		} else {
+			s.Tool = strings.TrimSpace(name + " " + arg)
+		}
+		if p.files[name] || p.files[strings.ToLower(name)] {
+			s.File = paneStatusFile(arg)
+		}
+		break
+	}
+	if p.tokens != nil {
+		for i := len(lines) - 1; i >= 0; i-- {
+			if m := p.tokens.FindStringSubmatch(lines[i]); m != nil {
+				s.Tokens = parseTokenCount(m[1])
+				break
+			}
+		}
+	}
+	if req, ok := parsePermissionPrompt(output); ok {
+		s.Permission = strings.ReplaceAll(req.Command, "\n", "; ")
+	} else if looksLikeInputPrompt(output) {
+		s.Permission = promptQuestion(lines)
+	}
+	return s
+}
+
+// promptQuestion returns the question an input prompt at the bottom of the
+// pane asks: the last line ending in "?", else the line the prompt was
+// recognised by, else a placeholder.
+func promptQuestion(lines []string) string {
+	var tail []string
+	for i := len(lines) - 1; i >= 0 && i >= len(lines)-activityTailLines; i-- {
+		tail = append(tail, strings.Trim(lines[i], " \t│┃|╭╮╰╯─"))
+	}
+	for _, line := range tail {
+		if strings.HasSuffix(line, "?") {
+			return line
+		}
+	}
+	for _, line := range tail {
+		for _, re := range inputPromptPatterns {
+			if re.MatchString(line) {
+				return line
+			}
+		}
+	}
+	return "waiting for an answer"
+}
+
+// paneStatusFile picks the path out of a tool argument such as
+// "Writing to src/a.go", "src/a.go: old => new" or "src/a.go (+3 -1)".
+func paneStatusFile(arg string) string {
+	arg = strings.TrimPrefix(arg, "Writing to ")
+	if i := strings.Index(arg, ": "); i >= 0 {
+		arg = arg[:i]
+	}
+	if i := strings.Index(arg, " ("); i >= 0 {
+		arg = arg[:i]
+	}
+	arg = strings.Trim(strings.TrimSpace(arg), "`'\"")
+	if strings.ContainsAny(arg, " \t") {
+		return "" // prose, not a path
+	}
+	return arg
+}
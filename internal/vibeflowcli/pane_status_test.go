@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePaneStatus(t *testing.T) {
+	cases := []struct {
+		name, parser, pane string
+		want               PaneStatus
+	}{
+		{
+			name:   "claude edit with live tokens",
+			parser: "claude",
+			pane: `⏺ Read(src/app.ts)
+  ⎿  Read 120 lines
+⏺ I'll fix the handler now.
+⏺ Update(src/handler.ts)
+  ⎿  Updated src/handler.ts with 3 additions
+
+✻ Pondering… (42s · ↓ 12.3k tokens · esc to interrupt)
+
+> `,
+			want: PaneStatus{Tool: "Update(src/handler.ts)", File: "src/handler.ts", Tokens: 12300},
+		},
+		{
+			name:   "claude bash permission",
+			parser: "claude",
+			pane: `⏺ Bash(npm test)
+╭──────────────────────────────────╮
+│ Bash command                     │
+│   npm test                       │
+│ Do you want to proceed?          │
+│ ❯ 1. Yes                         │
+│   2. No                          │
+╰──────────────────────────────────╯`,
+			want: PaneStatus{Tool: "Bash(npm test)", Permission: "npm test"},
+		},
+		{
+			name:   "codex",
+			parser: "codex",
+			pane: `• Ran cargo build
+• Edited src/main.rs (+3 -1)
+
+⏎ send   ⌃C quit   48.2K tokens used   91% context left`,
+			want: PaneStatus{Tool: "Edited src/main.rs (+3 -1)", File: "src/main.rs", Tokens: 48200},
+		},
+		{
+			name:   "gemini",
+			parser: "gemini",
+			pane: `╭────────────────────────────────────╮
+│ ✔  ReadFile src/app.ts             │
+╰────────────────────────────────────╯
+╭────────────────────────────────────╮
+│ ⊷  Shell npm run lint              │
+╰────────────────────────────────────╯`,
+			want: PaneStatus{Tool: "Shell npm run lint"},
+		},
+		{
+			name:   "generic",
+			parser: "inhouse",
+			pane:   "Reading the spec first\nEditing: pkg/api/server.go\n1,024 tokens so far",
+			want:   PaneStatus{Tool: "Editing pkg/api/server.go", File: "pkg/api/server.go", Tokens: 1024},
+		},
+		{
+			name:   "nothing recognised",
+			parser: "claude",
+			pane:   "$ ls\nREADME.md",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ParsePaneStatus(c.parser, c.pane); got != c.want {
+				t.Errorf("got %+v\nwant %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestParsePaneStatus_OnlyRecentLines(t *testing.T) {
+	pane := "⏺ Edit(old.go)\n" + strings.Repeat("output\n", paneStatusTailLines)
+	if got := ParsePaneStatus("claude", pane); got.Tool != "" {
+		t.Errorf("a tool call that scrolled away was reported: %+v", got)
+	}
+}
+
+func TestDetailPanel_PaneStatus(t *testing.T) {
+	m := bulkTestModel(t)
+	m.sessions[0].Provider = "inhouse"
+	m.registry = &ProviderRegistry{providers: map[string]Provider{"inhouse": {OutputParser: "claude"}}}
+	m.paneStatus = m.parsePaneStatus(map[string]string{
+		"claude-a": "⏺ Write(docs/notes.md)\n✻ Working… (3s · ↑ 950 tokens · esc to interrupt)",
+		"claude-b": "nothing to see",
+	})
+	if _, ok := m.paneStatus["claude-b"]; ok {
+		t.Error("a pane with nothing recognised should have no status")
+	}
+	panel := ansiRe.ReplaceAllString(m.renderDetailPanel(80, 30), "")
+	for _, want := range []string{"Write(docs/notes.md)", "File", "docs/notes.md", "Tokens", "950"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("detail panel missing %q:\n%s", want, panel)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// permissionTailLines is how much of a waiting pane is searched for the
+// permission prompt. Claude's dialog box alone is about a dozen lines.
+const permissionTailLines = 20
+
+// permissionDecision is what the responder does with a permission prompt.
+type permissionDecision int
+
+const (
+	permissionAsk   permissionDecision = iota // leave it for the user
+	permissionAllow                           // approve the command
+	permissionDeny                            // refuse the command
+)
+
+// String returns the decision as written to the log.
+func (d permissionDecision) String() string {
+	switch d {
+	case permissionAllow:
+		return "allowed"
+	case permissionDeny:
+		return "denied"
+	default:
+		return "left for the user"
+	}
+}
+
+// promptStyle is how a permission prompt takes its answer.
+type promptStyle int
+
+const (
+	promptYesNo  promptStyle = iota // a typed y or n, then Enter
+	promptMenu                      // a numbered menu with "Yes" highlighted: Enter or Escape
+	promptHotkey                    // single keys shown as (y) / (n), no Enter
+)
+
+// permissionRequest is what a provider's permission prompt asks to run.
+// A multi-line command keeps its lines, separated by newlines.
+type permissionRequest struct {
+	Command string
+	Style   promptStyle
+	// Partial is set when the command may run past what the pane shows;
+	// such a prompt is always left for the user.
+	Partial bool
+}
+
+// keys returns the tmux key names that give answer d to the prompt.
+func (r permissionRequest) keys(d permissionDecision) []string {
+	switch {
+	case r.Style == promptMenu && d == permissionAllow:
+		return []string{"Enter"}
+	case r.Style == promptMenu:
+		return []string{"Escape"}
+	case r.Style == promptHotkey && d == permissionAllow:
+		return []string{"y"}
+	case r.Style == promptHotkey:
+		return []string{"n"}
+	case d == permissionAllow:
+		return []string{"y", "Enter"}
+	default:
+		return []string{"n", "Enter"}
+	}
+}
+
+var (
+	// Claude: a "Bash command" box with the command on the next lines,
+	// then a one-line description and the "Do you want to proceed?" question.
+	permissionBashHeader = regexp.MustCompile(`(?i)^bash(?: command)?$`)
+	permissionProceed    = regexp.MustCompile(`(?i)^do you want to proceed\?`)
+	// Gemini: "Allow execution of: 'npm test'?" (or "of [npm]?").
+	permissionExecution = regexp.MustCompile("(?i)allow execution(?: of)?:?\\s*['\"`\\[]?(.+?)['\"`\\]]?\\s*\\?")
+	// Generic: "allow running npm test?", "Allow command: make build?".
+	permissionRunning = regexp.MustCompile("(?i)allow (?:running|me to run|command)\\s*:?\\s*['\"`]?(.+?)['\"`]?\\s*\\?")
+	// Codex: "$ npm test" right under an "Allow command?" question.
+	permissionDollar       = regexp.MustCompile(`^\$\s+(.+)$`)
+	permissionAllowCommand = regexp.MustCompile(`(?i)^allow (?:this )?command\?$`)
+
+	permissionMenu   = regexp.MustCompile(`(?im)^\s*(?:❯|›|>|●)?\s*1\.\s*yes`)
+	permissionHotkey = regexp.MustCompile(`(?i)\(y\)`)
+)
+
+// parsePermissionPrompt finds the command a permission prompt at the end of
+// output asks about. ok is false when the pane isn't showing one, or it
+// doesn't say which command it is for.
+func parsePermissionPrompt(output string) (permissionRequest, bool) {
+	tail := lastNLines(strings.TrimRight(output, "\n "), permissionTailLines)
+	var lines []string
+	for _, line := range strings.Split(tail, "\n") {
+		lines = append(lines, strings.Trim(line, " \t│┃|╭╮╰╯─"))
+	}
+	cleaned := strings.Join(lines, "\n")
+
+	command, partial := "", false
+	for i := len(lines) - 1; i >= 0 && command == ""; i-- {
+		if permissionBashHeader.MatchString(lines[i]) {
+			command, partial = claudeBashCommand(lines[i+1:])
+		}
+	}
+	if command == "" {
+		if m := permissionExecution.FindStringSubmatch(cleaned); m != nil {
+			command = m[1]
+		} else if m := permissionRunning.FindStringSubmatch(cleaned); m != nil {
+			command = m[1]
+		}
+	}
+	if strings.TrimSpace(command) == "" {
+		command = codexCommand(lines)
+	}
+	command = normalizeCommand(command)
+	if command == "" || !looksLikeInputPrompt(output) {
+		return permissionRequest{}, false
+	}
+
+	req := permissionRequest{Command: command, Partial: partial}
+	switch {
+	case permissionMenu.MatchString(cleaned):
+		req.Style = promptMenu
+	case permissionHotkey.MatchString(cleaned):
+		req.Style = promptHotkey
+	}
+	return req, true
+}
+
+// codexCommand returns the command of Codex's prompt: the "$ " line
+// directly under the last "Allow command?" question. A "$ " line anywhere
+// else is earlier output, so without the question there is no command.
+func codexCommand(lines []string) string {
+	for i := len(lines) - 2; i >= 0; i-- {
+		if permissionAllowCommand.MatchString(lines[i]) {
+			if m := permissionDollar.FindStringSubmatch(lines[i+1]); m != nil {
+				return m[1]
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// claudeBashCommand reads the command out of the lines below the "Bash
+// command" header of Claude's box: every non-empty line up to "Do you want
+// to proceed?". A one-line block is the command. Claude may show a
+// description under the command, but a second line can't be told apart
+// from more command, so a longer block is returned whole and, spanning
+// lines, is never allowed by a pattern. partial is set when the question
+// isn't on screen, so the command may be cut off.
+func claudeBashCommand(lines []string) (command string, partial bool) {
+	var block []string
+	for _, line := range lines {
+		if permissionProceed.MatchString(line) {
+			return strings.Join(block, "\n"), false
+		}
+		if line != "" {
+			block = append(block, line)
+		}
+	}
+	return strings.Join(block, "\n"), true
+}
+
+// normalizeCommand collapses runs of spaces in each line of command and
+// drops empty lines.
+func normalizeCommand(command string) string {
+	var lines []string
+	for _, line := range strings.Split(command, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commandPattern compiles an allow/deny pattern: the whole command must
+// match, * matches a run of characters within one shell command and runs
+// of spaces are one space.
+func commandPattern(p string) *regexp.Regexp {
+	parts := strings.Split(strings.Join(strings.Fields(p), " "), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, "[^;&|<>$`\n]*") + "$")
+}
+
+// compoundCommand reports whether command chains, pipes, substitutes or
+// redirects, or spans lines. Such a command is never allowed on a pattern
+// match, since the pattern only vouches for its first part.
+func compoundCommand(command string) bool {
+	return strings.ContainsAny(command, ";&|`<>\n") || strings.Contains(command, "$(")
+}
+
+// permissionResponder answers permission prompts from the configured
+// allow and deny lists. It remembers the prompt each session was last
+// shown so every prompt is answered once. It is safe for concurrent use:
+// prompts are answered from a tea.Cmd while the detail panel reads them.
+type permissionResponder struct {
+	allow, deny []*regexp.Regexp
+
+	mu       sync.Mutex
+	seen     map[string]string // session → prompt tail last handled
+	awaiting map[string]string // session → command left for the user
+}
+
+// newPermissionResponder returns a responder for cfg, or nil when
+// auto_respond is off.
+func newPermissionResponder(cfg PermissionsConfig) *permissionResponder {
+	if !cfg.AutoRespond {
+		return nil
+	}
+	r := &permissionResponder{seen: make(map[string]string), awaiting: make(map[string]string)}
+	for _, p := range cfg.Allow {
+		r.allow = append(r.allow, commandPattern(p))
+	}
+	for _, p := range cfg.Deny {
+		r.deny = append(r.deny, commandPattern(p))
+	}
+	return r
+}
+
+// decide returns what to do with command. Deny wins over allow, and a
+// compound command is never allowed.
+func (r *permissionResponder) decide(command string) permissionDecision {
+	for _, re := range r.deny {
+		if re.MatchString(command) {
+			return permissionDeny
+		}
+	}
+	if compoundCommand(command) {
+		return permissionAsk
+	}
+	for _, re := range r.allow {
+		if re.MatchString(command) {
+			return permissionAllow
+		}
+	}
+	return permissionAsk
+}
+
+// Handle looks at the output of a session waiting for input and returns
+// the request and decision for a permission prompt that hasn't been
+// handled yet. ok is false when there is nothing to do.
+func (r *permissionResponder) Handle(name, output string) (req permissionRequest, d permissionDecision, ok bool) {
+	if r == nil {
+		return permissionRequest{}, permissionAsk, false
+	}
+	req, found := parsePermissionPrompt(output)
+	tail := lastNLines(strings.TrimRight(output, "\n "), permissionTailLines)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !found {
+		delete(r.awaiting, name)
+		return permissionRequest{}, permissionAsk, false
+	}
+	if r.seen[name] == tail {
+		return permissionRequest{}, permissionAsk, false
+	}
+	r.seen[name] = tail
+	d = permissionAsk
+	if !req.Partial {
+		d = r.decide(req.Command)
+	}
+	if d == permissionAsk {
+		r.awaiting[name] = req.Command
+	} else {
+		delete(r.awaiting, name)
+	}
+	return req, d, true
+}
+
+// Clear forgets name's prompt once the session is no longer waiting, so the
+// same command asked again later is answered again.
+func (r *permissionResponder) Clear(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.seen, name)
+	delete(r.awaiting, name)
+}
+
+// Awaiting returns the command name's prompt is waiting on the user for,
+// or "" when there is none.
+func (r *permissionResponder) Awaiting(name string) string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.awaiting[name]
+}
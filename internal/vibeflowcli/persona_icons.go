@@ -44,16 +44,21 @@ func PersonaCompactIcon(key string) string {
 // four are soft ocean-dusk tints for the roles Ocean has no token for. Each
 // persona also has a distinct glyph (personaCompactIcons), which carries the
 // primary identity; color is secondary.
-var personaColors = map[string]lipgloss.Color{
-	"principal_engineer": oceanWarning,              // sandy gold — mastery
-	"developer":          oceanPrimary,              // sky — code/tech
-	"architect":          lipgloss.Color("#a29bfe"), // ocean-dusk periwinkle — wisdom
-	"ux_designer":        lipgloss.Color("#f78fb3"), // ocean-sunset rose — creativity
-	"qa_lead":            oceanAccent,               // seafoam — verification
-	"security_lead":      oceanError,                // coral — security/alerts
-	"product_manager":    lipgloss.Color("#ffd98e"), // soft sand — innovation
-	"project_manager":    oceanSecondary,            // deep blue — organization
-	"customer":           lipgloss.Color("#7bed9f"), // ocean mint — communication
+var personaColors = themePersonaColors()
+
+// themePersonaColors builds personaColors from the active palette.
+func themePersonaColors() map[string]lipgloss.Color {
+	return map[string]lipgloss.Color{
+		"principal_engineer": oceanWarning,              // sandy gold — mastery
+		"developer":          oceanPrimary,              // sky — code/tech
+		"architect":          lipgloss.Color("#a29bfe"), // ocean-dusk periwinkle — wisdom
+		"ux_designer":        lipgloss.Color("#f78fb3"), // ocean-sunset rose — creativity
+		"qa_lead":            oceanAccent,               // seafoam — verification
+		"security_lead":      oceanError,                // coral — security/alerts
+		"product_manager":    lipgloss.Color("#ffd98e"), // soft sand — innovation
+		"project_manager":    oceanSecondary,            // deep blue — organization
+		"customer":           lipgloss.Color("#7bed9f"), // ocean mint — communication
+	}
 }
 
 // personaCompactIcons maps persona keys to small Unicode glyphs for inline display.
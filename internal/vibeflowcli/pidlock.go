@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PIDLockPath returns the PID lock file path under the root directory.
@@ -29,34 +30,89 @@ func PIDLockPath() string {
 	return filepath.Join(RootDir(), "vibeflow.pid")
 }
 
-// AcquirePIDLock checks for an existing vibeflow-cli process and writes the
-// current PID if no other instance is running. Returns the running PID and an
-// error if another instance is alive.
-func AcquirePIDLock() error {
-	path := PIDLockPath()
+// pidLockFile is the PID file while this process holds the instance lock.
+// It stays open and flocked until ReleasePIDLock, and the kernel drops the
+// flock if the process dies, so a crashed instance never blocks the next.
+var pidLockFile *os.File
 
-	// Ensure directory exists.
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("create pid dir: %w", err)
+// instanceRunningError reports that another vibeflow holds the lock.
+type instanceRunningError struct {
+	pid int
+}
+
+func (e *instanceRunningError) Error() string {
+	if e.pid == 0 {
+		return "vibeflow is already running"
 	}
+	return fmt.Sprintf("vibeflow is already running (PID: %d)", e.pid)
+}
 
-	// Check existing lock.
-	if pid, alive := readPIDLock(path); alive {
-		return fmt.Errorf("vibeflow is already running (PID: %d)", pid)
+// AcquirePIDLock makes this process the single vibeflow TUI for the root:
+// it takes an exclusive flock on the PID file and writes the current PID.
+// The flock closes the window where two instances both find no live PID
+// and both write theirs. Returns an *instanceRunningError naming the
+// holder when another instance has the lock.
+func AcquirePIDLock() error {
+	f, err := acquirePIDLockAt(PIDLockPath(), 0)
+	if err != nil {
+		return err
 	}
+	pidLockFile = f
+	return nil
+}
 
-	// Write current PID.
-	data := []byte(strconv.Itoa(os.Getpid()))
-	if err := os.WriteFile(path, data, 0600); err != nil {
-		return fmt.Errorf("write pid lock: %w", err)
+// TakeOverPIDLock asks the instance holding the lock to quit and takes the
+// lock once it has, waiting up to timeout.
+func TakeOverPIDLock(pid int, timeout time.Duration) error {
+	if err := terminateProcess(pid); err != nil {
+		return fmt.Errorf("stop vibeflow PID %d: %w", pid, err)
+	}
+	f, err := acquirePIDLockAt(PIDLockPath(), timeout)
+	if err != nil {
+		return fmt.Errorf("vibeflow PID %d did not exit within %s", pid, timeout)
 	}
+	pidLockFile = f
 	return nil
 }
 
-// ReleasePIDLock removes the PID lock file. Safe to call even if the file
-// does not exist.
+// acquirePIDLockAt flocks the PID file at path, retrying for up to
+// timeout, and writes the current PID into it.
+func acquirePIDLockAt(path string, timeout time.Duration) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create pid dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open pid lock: %w", err)
+	}
+	if err := flockWithTimeout(f, timeout); err != nil {
+		_ = f.Close()
+		pid, _ := readPIDLock(path)
+		return nil, &instanceRunningError{pid: pid}
+	}
+	if err := f.Truncate(0); err == nil {
+		_, err = f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+	if err != nil {
+		_ = flockRelease(f)
+		_ = f.Close()
+		return nil, fmt.Errorf("write pid lock: %w", err)
+	}
+	return f, nil
+}
+
+// ReleasePIDLock empties the PID file and drops the lock. The file itself
+// stays: removing it would let a waiting instance lock the unlinked file
+// while a third one locks a new file at the same path. Safe to call when
+// the lock isn't held.
 func ReleasePIDLock() {
-	_ = os.Remove(PIDLockPath())
+	if pidLockFile == nil {
+		return
+	}
+	_ = pidLockFile.Truncate(0)
+	_ = flockRelease(pidLockFile)
+	_ = pidLockFile.Close()
+	pidLockFile = nil
 }
 
 // IsVibeflowRunning reports whether another vibeflow-cli process holds the
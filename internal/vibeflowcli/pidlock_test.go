@@ -17,6 +17,7 @@
 package vibeflowcli
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -137,3 +138,40 @@ func TestProcessAlive_DeadProcess(t *testing.T) {
 		t.Error("PID 4999999 should not be alive")
 	}
 }
+
+func TestAcquirePIDLockAt_SecondInstanceRefused(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vibeflow.pid")
+	first, err := acquirePIDLockAt(path, 0)
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	_, err = acquirePIDLockAt(path, 0)
+	var running *instanceRunningError
+	if !errors.As(err, &running) || running.pid != os.Getpid() {
+		t.Fatalf("second acquire: err = %v, want the holder's PID %d", err, os.Getpid())
+	}
+
+	_ = flockRelease(first)
+	_ = first.Close()
+	second, err := acquirePIDLockAt(path, 0)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	_ = second.Close()
+}
+
+func TestReleasePIDLock_EmptiesFile(t *testing.T) {
+	withTempRoot(t)
+	if err := AcquirePIDLock(); err != nil {
+		t.Fatal(err)
+	}
+	if pid, alive := IsVibeflowRunning(); !alive || pid != os.Getpid() {
+		t.Errorf("while held: pid = %d, alive = %v", pid, alive)
+	}
+	ReleasePIDLock()
+	data, err := os.ReadFile(PIDLockPath())
+	if err != nil || len(data) != 0 {
+		t.Errorf("after release: %q, %v; want an empty file", data, err)
+	}
+	ReleasePIDLock() // not held: no-op
+}
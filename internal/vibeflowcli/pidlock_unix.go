@@ -9,3 +9,8 @@ import "syscall"
 func processAlive(pid int) bool {
 	return syscall.Kill(pid, 0) == nil
 }
+
+// terminateProcess asks the process to quit with SIGTERM.
+func terminateProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
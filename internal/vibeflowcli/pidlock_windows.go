@@ -2,8 +2,15 @@
 
 package vibeflowcli
 
+import "errors"
+
 // processAlive is a stub on Windows. vibeflow-cli requires tmux which is
 // not available on Windows, so PID lock checking is not meaningful.
 func processAlive(pid int) bool {
 	return false
 }
+
+// terminateProcess is unsupported on Windows; see processAlive.
+func terminateProcess(pid int) error {
+	return errors.New("not supported on windows")
+}
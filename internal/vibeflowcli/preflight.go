@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// preflightTimeout bounds each preflight check so a hung binary or
+// endpoint only delays the confirm step, never blocks it.
+const preflightTimeout = 5 * time.Second
+
+// preflightCheck is the outcome of one check run before a launch.
+type preflightCheck struct {
+	Label string
+	Err   error
+	// Warn marks a check that could not reach a verdict (a network error or
+	// an unexpected response); it is shown but does not hold up the launch.
+	Warn bool
+}
+
+// failed reports whether the check found a problem the agent would die on.
+func (c preflightCheck) failed() bool { return c.Err != nil && !c.Warn }
+
+// preflightTarget is one provider a launch will start.
+type preflightTarget struct {
+	Key      string
+	Provider Provider
+}
+
+// preflightRequest describes what a launch needs: the providers it starts,
+// the environment they get, and whether they talk to the vibeflow server.
+type preflightRequest struct {
+	Targets   []preflightTarget
+	Env       map[string]string
+	Gateway   bool   // LLM calls go through the gateway, not the provider's API key
+	ServerURL string // non-empty for vibeflow sessions, whose agents need MCP
+	// Launch, when set, is validated first (see validateLaunch).
+	Launch *launchPlan
+}
+
+// key identifies the request so the wizard reruns the checks only when
+// the launch it would make has changed.
+func (r preflightRequest) key() string {
+	var b strings.Builder
+	for _, t := range r.Targets {
+		fmt.Fprintf(&b, "%s=%s;", t.Key, t.Provider.Binary)
+	}
+	names := make([]string, 0, len(r.Env))
+	for k := range r.Env {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Fprintf(&b, "%s=%s;", k, r.Env[k])
+	}
+	fmt.Fprintf(&b, "gw=%t;srv=%s", r.Gateway, r.ServerURL)
+	if r.Launch != nil {
+		fmt.Fprintf(&b, ";launch=%s", r.Launch.key())
+	}
+	return b.String()
+}
+
+// runPreflight checks the launch's settings, that each provider's binary
+// runs, that the API keys the launch passes are accepted, and that the MCP
+// endpoint answers.
+func runPreflight(req preflightRequest) []preflightCheck {
+	var checks []preflightCheck
+	if req.Launch != nil {
+		checks = validateLaunch(*req.Launch, req.Env)
+	}
+	for _, t := range req.Targets {
+		checks = append(checks, preflightCheck{
+			Label: t.Provider.Binary + " --version",
+			Err:   checkBinaryVersion(t.Provider.Binary),
+		})
+		if req.Gateway && providerSupportsGateway(t.Key) {
+			continue
+		}
+		if probe, ok := apiKeyProbeFor(t.Key, t.Provider, req.Env); ok {
+			checks = append(checks, probeAPIKey(probe))
+		}
+	}
+	if req.ServerURL != "" {
+		c := preflightCheck{Label: "VibeFlow MCP server"}
+		if err := checkURLReachable(bootstrapMCPURL(req.ServerURL)); err != nil {
+			c.Err = err
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// checkBinaryVersion runs `binary --version`, which catches a missing,
+// broken or non-executable install before tmux hides the failure.
+func checkBinaryVersion(binary string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), preflightTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, binary, "--version").CombinedOutput()
+	if ctx.Err() != nil {
+		return fmt.Errorf("timed out after %s", preflightTimeout)
+	}
+	if err != nil {
+		if line := firstLine(string(out)); line != "" {
+			return fmt.Errorf("%w: %s", err, line)
+		}
+		return err
+	}
+	return nil
+}
+
+// firstLine returns the first non-empty line of s, trimmed.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// apiKeyProbe is a cheap authenticated request that fails with 401 or 403
+// when the key is wrong.
+type apiKeyProbe struct {
+	EnvVar  string
+	URL     string
+	Headers map[string]string
+}
+
+// apiKeyProbeFor returns the probe for the API key a provider will be
+// launched with. ok is false when it uses no key (for example a
+// subscription login) or its endpoint is unknown.
+func apiKeyProbeFor(key string, p Provider, env map[string]string) (apiKeyProbe, bool) {
+	lookup := func(name string) string {
+		if v, ok := env[name]; ok {
+			return v
+		}
+		if v, ok := p.Env[name]; ok {
+			return v
+		}
+		return os.Getenv(name)
+	}
+	switch key {
+	case "claude":
+		k := lookup("ANTHROPIC_API_KEY")
+		if k == "" {
+			return apiKeyProbe{}, false
+		}
+		base := strings.TrimRight(lookup("ANTHROPIC_BASE_URL"), "/")
+		if base == "" {
+			base = "https://api.anthropic.com"
+		}
+		return apiKeyProbe{
+			EnvVar:  "ANTHROPIC_API_KEY",
+			URL:     base + "/v1/models",
+			Headers: map[string]string{"x-api-key": k, "anthropic-version": "2023-06-01"},
+		}, true
+	case "gemini":
+		k := lookup("GEMINI_API_KEY")
+		if k == "" {
+			return apiKeyProbe{}, false
+		}
+		base := strings.TrimRight(lookup("GOOGLE_GEMINI_BASE_URL"), "/")
+		if base == "" {
+			base = "https://generativelanguage.googleapis.com"
+		}
+		return apiKeyProbe{
+			EnvVar:  "GEMINI_API_KEY",
+			URL:     base + "/v1beta/models",
+			Headers: map[string]string{"x-goog-api-key": k},
+		}, true
+	case "qwen":
+		k := lookup("OPENAI_API_KEY")
+		base := strings.TrimRight(lookup("OPENAI_BASE_URL"), "/")
+		if k == "" || base == "" {
+			return apiKeyProbe{}, false
+		}
+		return apiKeyProbe{
+			EnvVar:  "OPENAI_API_KEY",
+			URL:     base + "/models",
+			Headers: map[string]string{"Authorization": "Bearer " + k},
+		}, true
+	}
+	return apiKeyProbe{}, false
+}
+
+// probeAPIKey sends the probe. A rejected key fails the check; anything
+// else that isn't a success only warns, since the endpoint may not
+// implement the probe even though the agent's own calls would work.
+func probeAPIKey(probe apiKeyProbe) preflightCheck {
+	c := preflightCheck{Label: probe.EnvVar}
+	ctx, cancel := context.WithTimeout(context.Background(), preflightTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		c.Err = err
+		return c
+	}
+	for k, v := range probe.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.Err, c.Warn = fmt.Errorf("could not verify: %w", err), true
+		return c
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		c.Err = fmt.Errorf("rejected by %s (HTTP %d)", req.URL.Host, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		c.Err, c.Warn = fmt.Errorf("could not verify (HTTP %d)", resp.StatusCode), true
+	}
+	return c
+}
+
+// checkURLReachable reports whether anything answers at url. Any HTTP
+// response counts: the MCP endpoint rejects a bare HEAD but is up.
+func checkURLReachable(url string) error {
+	client := &http.Client{Timeout: preflightTimeout}
+	resp, err := client.Head(url)
+	if err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
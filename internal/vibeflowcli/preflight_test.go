@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckBinaryVersion(t *testing.T) {
+	dir := t.TempDir()
+	ok := filepath.Join(dir, "ok")
+	broken := filepath.Join(dir, "broken")
+	if err := os.WriteFile(ok, []byte("#!/bin/sh\necho 1.0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(broken, []byte("#!/bin/sh\necho 'error: libfoo.so missing' >&2\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkBinaryVersion(ok); err != nil {
+		t.Errorf("working binary: %v", err)
+	}
+	if err := checkBinaryVersion(broken); err == nil || !strings.Contains(err.Error(), "libfoo.so missing") {
+		t.Errorf("broken binary: err = %v, want its output", err)
+	}
+	if err := checkBinaryVersion(filepath.Join(dir, "missing")); err == nil {
+		t.Error("missing binary passed")
+	}
+}
+
+func TestProbeAPIKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer good":
+			w.WriteHeader(http.StatusOK)
+		case "Bearer bad":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	probe := func(key string) preflightCheck {
+		p, ok := apiKeyProbeFor("qwen", Provider{}, map[string]string{
+			"OPENAI_API_KEY":  key,
+			"OPENAI_BASE_URL": srv.URL + "/v1/",
+		})
+		if !ok || p.URL != srv.URL+"/v1/models" {
+			t.Fatalf("probe = %+v, %v", p, ok)
+		}
+		return probeAPIKey(p)
+	}
+	if c := probe("good"); c.Err != nil {
+		t.Errorf("good key: %v", c.Err)
+	}
+	if c := probe("bad"); !c.failed() || !strings.Contains(c.Err.Error(), "401") {
+		t.Errorf("bad key: %+v, want a failure naming the status", c)
+	}
+	if c := probe("other"); c.failed() || !c.Warn {
+		t.Errorf("server error: %+v, want only a warning", c)
+	}
+}
+
+func TestAPIKeyProbeFor_NoKey(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	t.Setenv("ANTHROPIC_BASE_URL", "")
+	if _, ok := apiKeyProbeFor("claude", Provider{}, nil); ok {
+		t.Error("claude without ANTHROPIC_API_KEY should not be probed (subscription login)")
+	}
+	p, ok := apiKeyProbeFor("claude", Provider{Env: map[string]string{"ANTHROPIC_API_KEY": "k"}}, nil)
+	if !ok || p.URL != "https://api.anthropic.com/v1/models" || p.Headers["x-api-key"] != "k" {
+		t.Errorf("claude probe = %+v, %v", p, ok)
+	}
+	if _, ok := apiKeyProbeFor("cursor", Provider{}, map[string]string{"OPENAI_API_KEY": "k"}); ok {
+		t.Error("provider without a known endpoint was probed")
+	}
+}
+
+func TestRunPreflight_GatewaySkipsKeyProbe(t *testing.T) {
+	req := preflightRequest{
+		Targets: []preflightTarget{{Key: "gemini", Provider: Provider{Binary: "true"}}},
+		Env:     map[string]string{"GEMINI_API_KEY": "k", "GOOGLE_GEMINI_BASE_URL": "http://127.0.0.1:1"},
+		Gateway: true,
+	}
+	checks := runPreflight(req)
+	if len(checks) != 1 || checks[0].Label != "true --version" {
+		t.Errorf("checks = %+v, want only the binary check", checks)
+	}
+}
+
+func TestWizard_PreflightHoldsConfirm(t *testing.T) {
+	wm := modelWizardFixture(t, "qwen")
+	wm.step = StepConfirm
+	if wm.loadPreflight() == nil {
+		t.Fatal("confirm step did not start the checks")
+	}
+	if wm.loadPreflight() != nil {
+		t.Fatal("checks restarted for an unchanged launch")
+	}
+
+	wm, _ = wm.advance()
+	if wm.Done() {
+		t.Fatal("launched while the checks were running")
+	}
+
+	wm.applyPreflight(preflightMsg{key: "stale", checks: nil})
+	if wm.preflightDone {
+		t.Fatal("applied results for another launch")
+	}
+	wm.applyPreflight(preflightMsg{key: wm.preflightKey, checks: []preflightCheck{
+		{Label: "sh --version", Err: errors.New("exec format error")},
+	}})
+	if v := wm.View(); !strings.Contains(v, "exec format error") || !strings.Contains(v, "enter twice") {
+		t.Errorf("confirm view does not show the failure:\n%s", v)
+	}
+
+	wm, _ = wm.advance()
+	if wm.Done() {
+		t.Fatal("first enter after a failure launched")
+	}
+	wm, _ = wm.advance()
+	if !wm.Done() {
+		t.Fatal("second enter did not launch anyway")
+	}
+}
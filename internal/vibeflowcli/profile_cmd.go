@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// profileNameRe keeps profile names usable as YAML keys and secret-store
+// key segments.
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// validateProfile checks a credential profile before it is saved.
+func validateProfile(cfg *Config, name string, p CredentialProfile) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	if _, ok := cfg.Providers[p.Provider]; !ok {
+		return fmt.Errorf("unknown provider %q", p.Provider)
+	}
+	if len(p.Env) == 0 {
+		return fmt.Errorf("a profile needs at least one --env KEY=VALUE")
+	}
+	for k := range p.Env {
+		if !envAssignKeyRE.MatchString(k) {
+			return fmt.Errorf("invalid env var name %q", k)
+		}
+	}
+	return nil
+}
+
+// --- profile ---
+
+func profileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "profile",
+		Short:   "Manage credential profiles for running a provider under several accounts",
+		Aliases: []string{"profiles"},
+		Long: `A credential profile is a named set of env vars for one provider, such as
+CLAUDE_CONFIG_DIR for a second Claude login or another ANTHROPIC_API_KEY.
+Pick one in the wizard's Account step or with "vibeflow launch --profile".
+The values are kept in the secret store, not in config.yaml.`,
+	}
+	cmd.AddCommand(profileListCmd(), profileAddCmd(), profileRemoveCmd())
+	return cmd
+}
+
+func profileListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List credential profiles",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := LoadConfig(configPathOrDefault(cmd))
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			out := cmd.OutOrStdout()
+			if len(cfg.Profiles) == 0 {
+				fmt.Fprintln(out, "No credential profiles. Add one with `vibeflow profile add`.")
+				return nil
+			}
+			names := make([]string, 0, len(cfg.Profiles))
+			for name := range cfg.Profiles {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			fmt.Fprintf(out, "%-20s %-12s %s\n", "NAME", "PROVIDER", "ENV")
+			fmt.Fprintln(out, strings.Repeat("-", 60))
+			for _, name := range names {
+				p := cfg.Profiles[name]
+				fmt.Fprintf(out, "%-20s %-12s %s\n", truncate(name, 20), p.Provider, strings.Join(envVarNames(p.Env), ", "))
+			}
+			return nil
+		},
+	}
+}
+
+func profileAddCmd() *cobra.Command {
+	var (
+		provider string
+		envPairs []string
+		force    bool
+	)
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a credential profile",
+		Long: `Add a credential profile for a provider. Each --env is set on sessions
+launched with the profile; a leading ~ in a value is expanded.
+
+Example (a second Claude account with its own login):
+  CLAUDE_CONFIG_DIR=~/.claude-work claude   # log in once
+  vibeflow profile add work --provider claude --env CLAUDE_CONFIG_DIR=~/.claude-work`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfgPath := configPathOrDefault(cmd)
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if _, exists := cfg.Profiles[name]; exists && !force {
+				return fmt.Errorf("profile %q already exists (use --force to replace it)", name)
+			}
+			env, err := parseEnvPairs(envPairs)
+			if err != nil {
+				return err
+			}
+			p := CredentialProfile{Provider: provider, Env: env}
+			if err := validateProfile(cfg, name, p); err != nil {
+				return err
+			}
+			if old, ok := cfg.Profiles[name]; ok {
+				forgetProfileSecrets(cfgPath, name, old, env)
+			}
+			if cfg.Profiles == nil {
+				cfg.Profiles = make(map[string]CredentialProfile)
+			}
+			cfg.Profiles[name] = p
+			if err := SaveConfig(cfg, cfgPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added profile %q for %s.\n", name, provider)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&provider, "provider", "claude", "Provider key the profile is for")
+	cmd.Flags().StringArrayVar(&envPairs, "env", nil, "Environment variable KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing profile with the same name")
+	return cmd
+}
+
+func profileRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Short:   "Remove a credential profile and its stored secrets",
+		Aliases: []string{"rm"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfgPath := configPathOrDefault(cmd)
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			p, ok := cfg.Profiles[name]
+			if !ok {
+				return fmt.Errorf("unknown profile %q", name)
+			}
+			delete(cfg.Profiles, name)
+			if err := SaveConfig(cfg, cfgPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			forgetProfileSecrets(cfgPath, name, p, nil)
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed profile %q.\n", name)
+			return nil
+		},
+	}
+}
+
+// forgetProfileSecrets deletes the stored values of p's env vars that are
+// not in keep, so a removed or replaced profile leaves nothing behind.
+func forgetProfileSecrets(cfgPath, name string, p CredentialProfile, keep map[string]string) {
+	store := newSecretStore(filepath.Dir(cfgPath))
+	for k := range p.Env {
+		if _, ok := keep[k]; !ok {
+			_ = store.Delete(profileSecretKey(name, k))
+		}
+	}
+}
+
+// configPathOrDefault returns the --config flag value, or the default config
+// path when it is not set.
+func configPathOrDefault(cmd *cobra.Command) string {
+	if cfgPath, _ := cmd.Flags().GetString("config"); cfgPath != "" {
+		return cfgPath
+	}
+	return ConfigPath()
+}
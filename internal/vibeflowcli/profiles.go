@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// CredentialProfile selects an account for one provider through the env vars
+// that provider reads, e.g. CLAUDE_CONFIG_DIR for a second Claude login or
+// a different ANTHROPIC_API_KEY. Values live in the secret store, like
+// saved_env_vars.
+type CredentialProfile struct {
+	Provider string            `yaml:"provider"`
+	Env      map[string]string `yaml:"env"`
+}
+
+// ProfileNames returns the names of the profiles defined for provider,
+// sorted.
+func (c *Config) ProfileNames(provider string) []string {
+	var names []string
+	for name, p := range c.Profiles {
+		if p.Provider == provider {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileEnv returns the env vars of the named profile for provider, with a
+// leading ~ in values expanded. An empty name means the provider's default
+// login and yields nil.
+func ProfileEnv(cfg *Config, provider, name string) (map[string]string, error) {
+	if name == "" {
+		return nil, nil
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("credential profile %q not found (see `vibeflow profile list`)", name)
+	}
+	if p.Provider != provider {
+		return nil, fmt.Errorf("credential profile %q is for provider %q, not %q", name, p.Provider, provider)
+	}
+	home, _ := os.UserHomeDir()
+	env := make(map[string]string, len(p.Env))
+	for k, v := range p.Env {
+		env[k] = expandHome(v, home)
+	}
+	return env, nil
+}
+
+// withProfileEnv returns a copy of env with the named profile's vars added
+// on top; env itself may be a provider's shared Env map and is not modified.
+func withProfileEnv(env map[string]string, cfg *Config, provider, name string) (map[string]string, error) {
+	penv, err := ProfileEnv(cfg, provider, name)
+	if err != nil || len(penv) == 0 {
+		return env, err
+	}
+	out := cloneStringMap(env)
+	if out == nil {
+		out = make(map[string]string, len(penv))
+	}
+	for k, v := range penv {
+		out[k] = v
+	}
+	return out, nil
+}
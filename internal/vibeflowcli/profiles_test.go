@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfileEnv(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	cfg := &Config{Profiles: map[string]CredentialProfile{
+		"work": {Provider: "claude", Env: map[string]string{"CLAUDE_CONFIG_DIR": "~/.claude-work"}},
+	}}
+
+	env, err := ProfileEnv(cfg, "claude", "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(home, ".claude-work"); env["CLAUDE_CONFIG_DIR"] != want {
+		t.Errorf("CLAUDE_CONFIG_DIR = %q, want %q", env["CLAUDE_CONFIG_DIR"], want)
+	}
+	if env, err := ProfileEnv(cfg, "claude", ""); err != nil || env != nil {
+		t.Errorf("default login = %v, %v; want nil, nil", env, err)
+	}
+	if _, err := ProfileEnv(cfg, "codex", "work"); err == nil {
+		t.Error("expected an error for a profile of another provider")
+	}
+	if _, err := ProfileEnv(cfg, "claude", "missing"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
+
+func TestWithProfileEnv_LeavesProviderEnvAlone(t *testing.T) {
+	cfg := &Config{Profiles: map[string]CredentialProfile{
+		"alt": {Provider: "claude", Env: map[string]string{"ANTHROPIC_API_KEY": "k2"}},
+	}}
+	base := map[string]string{"FOO": "1"}
+	env, err := withProfileEnv(base, cfg, "claude", "alt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env["FOO"] != "1" || env["ANTHROPIC_API_KEY"] != "k2" {
+		t.Errorf("env = %v", env)
+	}
+	if _, ok := base["ANTHROPIC_API_KEY"]; ok {
+		t.Error("withProfileEnv modified the provider's env map")
+	}
+}
+
+func TestSaveConfig_StoresProfileValuesAsSecrets(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := DefaultConfig()
+	cfg.Profiles = map[string]CredentialProfile{
+		"alt": {Provider: "claude", Env: map[string]string{"ANTHROPIC_API_KEY": "sk-alt"}},
+	}
+	if err := SaveConfig(cfg, cfgPath); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profiles["alt"].Env["ANTHROPIC_API_KEY"] != "sk-alt" {
+		t.Error("SaveConfig must not modify the in-memory profiles")
+	}
+	data, _ := os.ReadFile(cfgPath)
+	if strings.Contains(string(data), "sk-alt") || !strings.Contains(string(data), "secret:profile/alt/ANTHROPIC_API_KEY") {
+		t.Errorf("config.yaml should hold a secret reference, got:\n%s", data)
+	}
+	loaded, err := LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Profiles["alt"].Env["ANTHROPIC_API_KEY"]; got != "sk-alt" {
+		t.Errorf("loaded profile value = %q, want sk-alt", got)
+	}
+}
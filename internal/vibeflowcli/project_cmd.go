@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// projectClient loads the config named by --config and returns an API client
+// for it, failing early when no server is configured.
+func projectClient(cmd *cobra.Command) (*Config, string, *Client, error) {
+	cfgPath, _ := cmd.Flags().GetString("config")
+	if cfgPath == "" {
+		cfgPath = ConfigPath()
+	}
+	cfg, err := LoadConfig(cfgPath)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("load config: %w", err)
+	}
+	if cfg.ServerURL == "" {
+		return nil, "", nil, fmt.Errorf("no server_url configured (run `vibeflow config` first)")
+	}
+	return cfg, cfgPath, newAPIClient(cfg), nil
+}
+
+// findProject resolves ref as a numeric ID or a project name. An exact name
+// match wins over a case-insensitive one so "api" and "API" can coexist.
+func findProject(projects []Project, ref string) (*Project, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		for i := range projects {
+			if projects[i].ID == id {
+				return &projects[i], nil
+			}
+		}
+	}
+	for i := range projects {
+		if projects[i].Name == ref {
+			return &projects[i], nil
+		}
+	}
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, ref) {
+			return &projects[i], nil
+		}
+	}
+	return nil, fmt.Errorf("project %q not found", ref)
+}
+
+// projectSummary is the per-project activity shown by `project list`.
+type projectSummary struct {
+	sessions int
+	ready    int
+	stuck    int
+	err      error
+}
+
+// pendingCounts returns how many work items are ready and how many are stuck.
+func pendingCounts(r *PollResult) (ready, stuck int) {
+	if r == nil {
+		return 0, 0
+	}
+	return len(r.ReadyTodos) + len(r.ReadyIssues), len(r.StuckTodos) + len(r.StuckIssues)
+}
+
+// summarizeProjects fetches session and pending-work counts for every
+// project concurrently; one request pair per project would otherwise make
+// `project list` take seconds on a large workspace.
+func summarizeProjects(client VibeflowAPI, projects []Project) []projectSummary {
+	out := make([]projectSummary, len(projects))
+	var wg sync.WaitGroup
+	for i := range projects {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sessions, err := client.ListSessions(projects[i].ID)
+			if err != nil {
+				out[i].err = err
+				return
+			}
+			work, err := client.PollPendingWork(projects[i].ID)
+			if err != nil {
+				out[i].err = err
+				return
+			}
+			out[i].sessions = len(sessions)
+			out[i].ready, out[i].stuck = pendingCounts(work)
+		}(i)
+	}
+	wg.Wait()
+	return out
+}
+
+// --- project ---
+
+func projectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "project",
+		Short:   "List, create and inspect VibeFlow projects",
+		Aliases: []string{"projects"},
+		Args:    cobra.NoArgs,
+		// Bare `vibeflow project(s)` keeps listing projects as it always has.
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectList(cmd)
+		},
+	}
+	cmd.AddCommand(projectListCmd(), projectCreateCmd(), projectShowCmd())
+	return cmd
+}
+
+func projectListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List projects with their session and pending work counts",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectList(cmd)
+		},
+	}
+}
+
+func runProjectList(cmd *cobra.Command) error {
+	_, _, client, err := projectClient(cmd)
+	if err != nil {
+		return err
+	}
+	projects, err := client.ListProjects()
+	if err != nil {
+		return fmt.Errorf("fetch projects: %w", err)
+	}
+	out := cmd.OutOrStdout()
+	if len(projects) == 0 {
+		fmt.Fprintln(out, "No projects found.")
+		return nil
+	}
+	summaries := summarizeProjects(client, projects)
+	fmt.Fprintf(out, "%-8s %-30s %-10s %-9s %-6s %s\n", "ID", "NAME", "STATUS", "SESSIONS", "READY", "STUCK")
+	fmt.Fprintln(out, strings.Repeat("-", 76))
+	for i, p := range projects {
+		s := summaries[i]
+		if s.err != nil {
+			fmt.Fprintf(out, "%-8d %-30s %-10s %-9s %-6s %s\n", p.ID, truncate(p.Name, 30), p.Status, "?", "?", "?")
+			continue
+		}
+		fmt.Fprintf(out, "%-8d %-30s %-10s %-9d %-6d %d\n", p.ID, truncate(p.Name, 30), p.Status, s.sessions, s.ready, s.stuck)
+	}
+	return nil
+}
+
+func projectCreateCmd() *cobra.Command {
+	var setDefault bool
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a project on the VibeFlow server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return fmt.Errorf("project name cannot be empty")
+			}
+			cfg, cfgPath, client, err := projectClient(cmd)
+			if err != nil {
+				return err
+			}
+			project, err := client.CreateProject(name)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Created project %q (id %d).\n", project.Name, project.ID)
+			if setDefault {
+				cfg.DefaultProject = project.Name
+				if err := SaveConfig(cfg, cfgPath); err != nil {
+					return fmt.Errorf("save config: %w", err)
+				}
+				fmt.Fprintf(out, "Set %q as the default project.\n", project.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&setDefault, "default", false, "Also make it the default project in the config")
+	return cmd
+}
+
+func projectShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <name|id>",
+		Short: "Show a project's sessions and pending work",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, _, client, err := projectClient(cmd)
+			if err != nil {
+				return err
+			}
+			projects, err := client.ListProjects()
+			if err != nil {
+				return fmt.Errorf("fetch projects: %w", err)
+			}
+			project, err := findProject(projects, args[0])
+			if err != nil {
+				return err
+			}
+			sessions, err := client.ListSessions(project.ID)
+			if err != nil {
+				return err
+			}
+			work, err := client.PollPendingWork(project.ID)
+			if err != nil {
+				return err
+			}
+			printProject(cmd.OutOrStdout(), project, sessions, work, time.Now())
+			return nil
+		},
+	}
+}
+
+// printProject renders `project show` output.
+func printProject(out io.Writer, p *Project, sessions []Session, work *PollResult, now time.Time) {
+	fmt.Fprintf(out, "Project:  %s (id %d)\n", p.Name, p.ID)
+	fmt.Fprintf(out, "Status:   %s\n", p.Status)
+	if p.Description != "" {
+		fmt.Fprintf(out, "About:    %s\n", p.Description)
+	}
+	if !p.CreatedAt.IsZero() {
+		fmt.Fprintf(out, "Created:  %s\n", p.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	fmt.Fprintf(out, "\nSessions (%d)\n", len(sessions))
+	if len(sessions) > 0 {
+		fmt.Fprintf(out, "  %-14s %-10s %-24s %-10s %s\n", "SESSION", "AGENT", "BRANCH", "STATUS", "HEARTBEAT")
+		for _, s := range sessions {
+			heartbeat := "-"
+			if !s.LastHeartbeat.IsZero() {
+				heartbeat = now.Sub(s.LastHeartbeat).Truncate(time.Second).String() + " ago"
+			}
+			fmt.Fprintf(out, "  %-14s %-10s %-24s %-10s %s\n",
+				truncate(s.ID, 14), truncate(s.AgentType, 10), truncate(s.GitBranch, 24), s.Status, heartbeat)
+		}
+	}
+
+	ready, stuck := pendingCounts(work)
+	fmt.Fprintf(out, "\nPending work (%d ready, %d stuck)\n", ready, stuck)
+	if work == nil {
+		return
+	}
+	for _, group := range []struct {
+		label string
+		items []WorkItem
+	}{
+		{"stuck", append(append([]WorkItem{}, work.StuckTodos...), work.StuckIssues...)},
+		{"ready", append(append([]WorkItem{}, work.ReadyTodos...), work.ReadyIssues...)},
+	} {
+		for _, w := range group.items {
+			line := fmt.Sprintf("  %-6s %-6s #%-6d %s", group.label, w.Type, w.ID, w.Title)
+			if w.Priority != "" {
+				line += " [" + w.Priority + "]"
+			}
+			if w.FeatureName != "" {
+				line += " (" + w.FeatureName + ")"
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+}
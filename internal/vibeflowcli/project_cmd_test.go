@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newProjectTestServer fakes the project endpoints with two projects, the
+// first of which has one session and some pending work.
+func newProjectTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/v1/vibeflow/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(Project{ID: 9, Name: body["name"], Status: "active"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]Project{
+			{ID: 1, Name: "api", Status: "active", Description: "Backend"},
+			{ID: 2, Name: "web", Status: "active"},
+		})
+	})
+	mux.HandleFunc("/rest/v1/vibeflow/projects/1/sessions", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Session{{ID: "sess-1", AgentType: "claude", GitBranch: "main", Status: "active"}})
+	})
+	mux.HandleFunc("/rest/v1/vibeflow/projects/1/poll", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(PollResult{
+			ReadyTodos:  []WorkItem{{Type: "todo", ID: 11, Title: "Add pagination", Priority: "high"}},
+			ReadyIssues: []WorkItem{{Type: "issue", ID: 12, Title: "Fix login"}},
+			StuckTodos:  []WorkItem{{Type: "todo", ID: 13, Title: "Migrate schema"}},
+		})
+	})
+	mux.HandleFunc("/rest/v1/vibeflow/projects/2/sessions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	mux.HandleFunc("/rest/v1/vibeflow/projects/2/poll", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func runProjectCmd(t *testing.T, serverURL string, args ...string) (string, string, error) {
+	t.Helper()
+	withTempRoot(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := DefaultConfig()
+	cfg.ServerURL = serverURL
+	if err := SaveConfig(cfg, cfgPath); err != nil {
+		t.Fatal(err)
+	}
+	root := &cobra.Command{Use: "vibeflow-cli"}
+	root.PersistentFlags().String("config", "", "")
+	root.AddCommand(projectCmd())
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(append(args, "--config", cfgPath))
+	err := root.Execute()
+	return out.String(), cfgPath, err
+}
+
+func TestFindProject(t *testing.T) {
+	projects := []Project{{ID: 1, Name: "API"}, {ID: 2, Name: "api"}, {ID: 3, Name: "Web"}}
+	for ref, want := range map[string]int64{"2": 2, "api": 2, "API": 1, "web": 3} {
+		p, err := findProject(projects, ref)
+		if err != nil || p.ID != want {
+			t.Errorf("findProject(%q) = %+v, %v; want id %d", ref, p, err, want)
+		}
+	}
+	if _, err := findProject(projects, "missing"); err == nil {
+		t.Error("unknown project should error")
+	}
+}
+
+func TestProjectList_ShowsCounts(t *testing.T) {
+	srv := newProjectTestServer(t)
+	out, _, err := runProjectCmd(t, srv.URL, "project", "list")
+	if err != nil {
+		t.Fatalf("project list: %v\n%s", err, out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("want header, rule and two rows:\n%s", out)
+	}
+	if f := strings.Fields(lines[2]); strings.Join(f, " ") != "1 api active 1 2 1" {
+		t.Errorf("api row = %q", lines[2])
+	}
+	if f := strings.Fields(lines[3]); strings.Join(f, " ") != "2 web active 0 0 0" {
+		t.Errorf("web row = %q", lines[3])
+	}
+}
+
+func TestProjectShow(t *testing.T) {
+	srv := newProjectTestServer(t)
+	out, _, err := runProjectCmd(t, srv.URL, "project", "show", "api")
+	if err != nil {
+		t.Fatalf("project show: %v\n%s", err, out)
+	}
+	for _, want := range []string{"api (id 1)", "Backend", "Sessions (1)", "sess-1", "2 ready, 1 stuck", "Add pagination [high]", "stuck  todo"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProjectCreate_SetsDefault(t *testing.T) {
+	srv := newProjectTestServer(t)
+	out, cfgPath, err := runProjectCmd(t, srv.URL, "project", "create", "mobile", "--default")
+	if err != nil {
+		t.Fatalf("project create: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, `Created project "mobile" (id 9)`) {
+		t.Errorf("output = %q", out)
+	}
+	cfg, err := LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultProject != "mobile" {
+		t.Errorf("default_project = %q, want mobile", cfg.DefaultProject)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 )
 
@@ -36,6 +37,56 @@ type Provider struct {
 	VibeFlowIntegrated bool              `yaml:"vibeflow_integrated"`
 	SessionFile        string            `yaml:"session_file"`
 	Default            bool              `yaml:"default"`
+	// AgentDoc names the embedded instruction template the provider reads
+	// (CLAUDE.md, AGENTS.md, GEMINI.md or QWEN.md). Only meaningful for
+	// custom providers; built-ins are mapped in providerDocFile.
+	AgentDoc string `yaml:"agent_doc,omitempty"`
+	// InputPricePerMTok and OutputPricePerMTok (USD per million tokens)
+	// estimate a session's cost when the agent reports tokens but no cost,
+	// as codex does. Zero means no estimate.
+	InputPricePerMTok  float64 `yaml:"input_price_per_mtok,omitempty"`
+	OutputPricePerMTok float64 `yaml:"output_price_per_mtok,omitempty"`
+	// Models lists the model ids the wizard offers for this provider. Empty
+	// falls back to defaultProviderModels for built-ins; any other id can
+	// still be typed in.
+	Models []string `yaml:"models,omitempty"`
+	// CompletionMarker is a regexp that, matched in the agent's recent
+	// output, marks the session done. Empty falls back to
+	// defaultProviderMarkers for built-ins; the shared VIBEFLOW_DONE marker
+	// applies either way.
+	CompletionMarker string `yaml:"completion_marker,omitempty"`
+	// DoneOnExit counts the agent exiting with status 0 as completion, for
+	// agents that quit once their task is done (codex does by default).
+	DoneOnExit bool `yaml:"done_on_exit,omitempty"`
+	// OutputParser names the built-in pane parser ("claude", "codex" or
+	// "gemini") that reads the detail panel's tool call, file and token
+	// count from the agent's output, for custom providers that wrap one of
+	// those agents. Empty uses the provider's own key.
+	OutputParser string `yaml:"output_parser,omitempty"`
+}
+
+// defaultProviderModels are the wizard's model suggestions for built-in
+// providers whose config lists none.
+var defaultProviderModels = map[string][]string{
+	"claude": {"sonnet", "opus", "haiku"},
+	"codex":  {"gpt-5-codex", "gpt-5"},
+	"gemini": {"gemini-2.5-pro", "gemini-2.5-flash"},
+	"aider":  {"sonnet", "gpt-5", "deepseek"},
+}
+
+// ModelChoices returns the models offered for the provider registered under
+// key.
+func (p Provider) ModelChoices(key string) []string {
+	if len(p.Models) > 0 {
+		return p.Models
+	}
+	return defaultProviderModels[key]
+}
+
+// TakesModel reports whether the provider's launch template renders
+// {{.Model}}. Choosing a model for one that doesn't would have no effect.
+func (p Provider) TakesModel() bool {
+	return strings.Contains(p.LaunchTemplate, ".Model")
 }
 
 // ProviderRegistry holds configured providers and caches binary availability.
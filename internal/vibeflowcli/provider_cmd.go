@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// providerKeyRe restricts custom provider keys to what is safe inside a tmux
+// session name ("vibeflow_<key>-<name>") and a YAML map key.
+var providerKeyRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// defaultCustomLaunchTemplate is used when a custom provider is added without
+// an explicit template: just run the binary.
+const defaultCustomLaunchTemplate = "{{.Binary}}"
+
+// isBuiltinProvider reports whether key is one of the providers shipped in
+// DefaultConfig. Built-ins are tuned in config.yaml directly, never through
+// `provider add/remove`.
+func isBuiltinProvider(key string) bool {
+	_, ok := DefaultConfig().Providers[key]
+	return ok
+}
+
+// validateCustomProvider checks a provider definition before it is written to
+// the config, so a typo surfaces at `provider add` time rather than as a
+// failed launch later.
+func validateCustomProvider(key string, p Provider) error {
+	if !providerKeyRe.MatchString(key) {
+		return fmt.Errorf("invalid provider key %q: use lowercase letters, digits and underscores, starting with a letter", key)
+	}
+	if isBuiltinProvider(key) {
+		return fmt.Errorf("%q is a built-in provider; edit it under providers: in the config instead", key)
+	}
+	if strings.TrimSpace(p.Binary) == "" {
+		return fmt.Errorf("binary is required")
+	}
+	if _, err := RenderLaunchCommand(p.LaunchTemplate, LaunchTemplateVars{Binary: p.Binary}); err != nil {
+		return err
+	}
+	if p.AgentDoc != "" && !isEmbeddedAgentDoc(p.AgentDoc) {
+		return fmt.Errorf("unknown agent doc %q (valid: AGENTS.md, CLAUDE.md, GEMINI.md, QWEN.md)", p.AgentDoc)
+	}
+	for k := range p.Env {
+		if k == "" || strings.ContainsAny(k, "= ") {
+			return fmt.Errorf("invalid env var name %q", k)
+		}
+	}
+	return nil
+}
+
+// parseEnvPairs turns repeated KEY=VALUE flags into a map.
+func parseEnvPairs(pairs []string) (map[string]string, error) {
+	env := make(map[string]string, len(pairs))
+	for _, kv := range pairs {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --env %q (want KEY=VALUE)", kv)
+		}
+		env[k] = v
+	}
+	return env, nil
+}
+
+// promptLine prints label (with def shown when non-empty) and returns the
+// trimmed answer, or def when the answer is blank.
+func promptLine(out io.Writer, in *bufio.Reader, label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(out, "%s: ", label)
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read %s: %w", strings.ToLower(label), err)
+	}
+	if v := strings.TrimSpace(line); v != "" {
+		return v, nil
+	}
+	return def, nil
+}
+
+// --- provider ---
+
+func providerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider",
+		Short: "List, add or remove agent providers",
+	}
+	cmd.AddCommand(providerListCmd(), providerAddCmd(), providerRemoveCmd())
+	return cmd
+}
+
+func providerListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List configured providers",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			if cfgPath == "" {
+				cfgPath = ConfigPath()
+			}
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			registry := NewProviderRegistry(cfg)
+			fmt.Printf("%-12s %-24s %-16s %-10s %s\n", "KEY", "NAME", "BINARY", "INSTALLED", "TYPE")
+			fmt.Println(strings.Repeat("-", 76))
+			for _, key := range registry.Keys() {
+				p, _ := registry.Get(key)
+				installed := "no"
+				if registry.IsAvailable(key) {
+					installed = "yes"
+				}
+				kind := "custom"
+				if isBuiltinProvider(key) {
+					kind = "built-in"
+				}
+				fmt.Printf("%-12s %-24s %-16s %-10s %s\n", key, truncate(p.Name, 24), truncate(p.Binary, 16), installed, kind)
+			}
+			return nil
+		},
+	}
+}
+
+func providerAddCmd() *cobra.Command {
+	var (
+		name           string
+		binary         string
+		launchTemplate string
+		envPairs       []string
+		sessionFile    string
+		agentDoc       string
+		integrated     bool
+		force          bool
+	)
+	cmd := &cobra.Command{
+		Use:   "add <key>",
+		Short: "Add a custom provider",
+		Long: `Add a custom agent provider to the config so it can be picked in the wizard
+and used with "vibeflow launch --provider <key>".
+
+Fields not given as flags are prompted for interactively. The launch template
+uses the same variables as the built-in providers ({{.Binary}},
+{{.SkipPermissions}}, {{.Model}}, ...); it defaults to "{{.Binary}}".
+
+Example:
+  vibeflow provider add aider --name Aider --binary aider \
+    --launch-template '{{.Binary}}{{ if .Model }} --model {{ shellQuote .Model }}{{ end }}' \
+    --env AIDER_AUTO_COMMITS=false --agent-doc AGENTS.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			cfgPath, _ := cmd.Flags().GetString("config")
+			if cfgPath == "" {
+				cfgPath = ConfigPath()
+			}
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if _, exists := cfg.Providers[key]; exists && !force && !isBuiltinProvider(key) {
+				return fmt.Errorf("provider %q already exists (use --force to replace it)", key)
+			}
+
+			env, err := parseEnvPairs(envPairs)
+			if err != nil {
+				return err
+			}
+
+			// Prompt for whatever the flags left out.
+			if binary == "" {
+				in := bufio.NewReader(cmd.InOrStdin())
+				out := cmd.OutOrStdout()
+				if name == "" {
+					if name, err = promptLine(out, in, "Display name", key); err != nil {
+						return err
+					}
+				}
+				if binary, err = promptLine(out, in, "Binary", key); err != nil {
+					return err
+				}
+				if launchTemplate == "" {
+					if launchTemplate, err = promptLine(out, in, "Launch template", defaultCustomLaunchTemplate); err != nil {
+						return err
+					}
+				}
+			}
+			if name == "" {
+				name = key
+			}
+			if launchTemplate == "" {
+				launchTemplate = defaultCustomLaunchTemplate
+			}
+
+			p := Provider{
+				Name:               name,
+				Binary:             binary,
+				LaunchTemplate:     launchTemplate,
+				Env:                env,
+				VibeFlowIntegrated: integrated,
+				SessionFile:        sessionFile,
+				AgentDoc:           agentDoc,
+			}
+			if err := validateCustomProvider(key, p); err != nil {
+				return err
+			}
+			if cfg.Providers == nil {
+				cfg.Providers = make(map[string]Provider)
+			}
+			cfg.Providers[key] = p
+			if err := SaveConfig(cfg, cfgPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added provider %q (%s).\n", key, name)
+			if !checkBinaryAvailable(binary) {
+				fmt.Fprintf(cmd.OutOrStdout(), "Warning: %q was not found on PATH; the provider will show as unavailable until it is installed.\n", binary)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Display name (default: the key)")
+	cmd.Flags().StringVar(&binary, "binary", "", "Agent executable name or absolute path")
+	cmd.Flags().StringVar(&launchTemplate, "launch-template", "", `Go template for the launch command (default "{{.Binary}}")`)
+	cmd.Flags().StringArrayVar(&envPairs, "env", nil, "Environment variable KEY=VALUE (repeatable)")
+	cmd.Flags().StringVar(&sessionFile, "session-file", "", "Session file the agent reads (e.g. .vibeflow-session)")
+	cmd.Flags().StringVar(&agentDoc, "agent-doc", "", "Instruction template the agent reads: AGENTS.md, CLAUDE.md, GEMINI.md or QWEN.md")
+	cmd.Flags().BoolVar(&integrated, "vibeflow-integrated", false, "Agent talks to the VibeFlow MCP server")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing custom provider with the same key")
+	return cmd
+}
+
+func providerRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <key>",
+		Short:   "Remove a custom provider",
+		Aliases: []string{"rm"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			if isBuiltinProvider(key) {
+				return fmt.Errorf("%q is a built-in provider and cannot be removed", key)
+			}
+			cfgPath, _ := cmd.Flags().GetString("config")
+			if cfgPath == "" {
+				cfgPath = ConfigPath()
+			}
+			cfg, err := LoadConfig(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if _, ok := cfg.Providers[key]; !ok {
+				keys := make([]string, 0, len(cfg.Providers))
+				for k := range cfg.Providers {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				return fmt.Errorf("unknown provider %q (configured: %s)", key, strings.Join(keys, ", "))
+			}
+			delete(cfg.Providers, key)
+			if cfg.DefaultProvider == key {
+				cfg.DefaultProvider = "claude"
+			}
+			if err := SaveConfig(cfg, cfgPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed provider %q.\n", key)
+			return nil
+		},
+	}
+}
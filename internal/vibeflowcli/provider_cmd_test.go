@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newProviderTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "vibeflow-cli"}
+	root.PersistentFlags().String("config", "", "")
+	root.AddCommand(providerCmd())
+	return root
+}
+
+func runProviderCmd(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	root := newProviderTestRoot()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestValidateCustomProvider(t *testing.T) {
+	ok := Provider{Binary: "goose", LaunchTemplate: "{{.Binary}} --yes"}
+	if err := validateCustomProvider("goose", ok); err != nil {
+		t.Errorf("valid provider rejected: %v", err)
+	}
+	tests := []struct {
+		key  string
+		p    Provider
+		want string
+	}{
+		{"Goose", ok, "invalid provider key"},
+		{"my-agent", ok, "invalid provider key"},
+		{"claude", ok, "built-in"},
+		{"goose", Provider{}, "binary is required"},
+		{"goose", Provider{Binary: "goose", LaunchTemplate: "{{.Binary"}, "parse launch template"},
+		{"goose", Provider{Binary: "goose", AgentDoc: "README.md"}, "unknown agent doc"},
+		{"goose", Provider{Binary: "goose", Env: map[string]string{"A B": "x"}}, "invalid env var"},
+	}
+	for _, tt := range tests {
+		err := validateCustomProvider(tt.key, tt.p)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("validateCustomProvider(%q, %+v) = %v, want error containing %q", tt.key, tt.p, err, tt.want)
+		}
+	}
+}
+
+func TestProviderAdd_FlagsPersistAndShowInWizardKeys(t *testing.T) {
+	withTempRoot(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	out, err := runProviderCmd(t, "", "provider", "add", "goose", "--config", cfgPath,
+		"--name", "Goose", "--binary", "goose", "--env", "GOOSE_MODE=auto", "--agent-doc", "AGENTS.md")
+	if err != nil {
+		t.Fatalf("provider add: %v\n%s", err, out)
+	}
+	cfg, err := LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := cfg.Providers["goose"]
+	want := Provider{
+		Name:           "Goose",
+		Binary:         "goose",
+		LaunchTemplate: defaultCustomLaunchTemplate,
+		Env:            map[string]string{"GOOSE_MODE": "auto"},
+		AgentDoc:       "AGENTS.md",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("saved provider = %+v, want %+v", got, want)
+	}
+
+	keys := providerKeys(NewProviderRegistry(cfg))
+	found := false
+	for _, k := range keys {
+		found = found || k == "goose"
+	}
+	if !found {
+		t.Errorf("providerKeys = %v, want custom provider goose included", keys)
+	}
+
+	if _, err := runProviderCmd(t, "", "provider", "add", "goose", "--config", cfgPath, "--binary", "goose"); err == nil {
+		t.Error("re-adding without --force should fail")
+	}
+}
+
+func TestProviderAdd_PromptsForMissingFields(t *testing.T) {
+	withTempRoot(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	out, err := runProviderCmd(t, "OpenCode\nopencode\n\n", "provider", "add", "opencode", "--config", cfgPath)
+	if err != nil {
+		t.Fatalf("provider add: %v\n%s", err, out)
+	}
+	cfg, _ := LoadConfig(cfgPath)
+	p := cfg.Providers["opencode"]
+	if p.Name != "OpenCode" || p.Binary != "opencode" || p.LaunchTemplate != defaultCustomLaunchTemplate {
+		t.Errorf("prompted provider = %+v", p)
+	}
+}
+
+func TestProviderRemove(t *testing.T) {
+	withTempRoot(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if _, err := runProviderCmd(t, "", "provider", "add", "goose", "--config", cfgPath, "--binary", "goose"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runProviderCmd(t, "", "provider", "remove", "claude", "--config", cfgPath); err == nil {
+		t.Error("removing a built-in must fail")
+	}
+	if _, err := runProviderCmd(t, "", "provider", "remove", "goose", "--config", cfgPath); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	cfg, _ := LoadConfig(cfgPath)
+	if _, ok := cfg.Providers["goose"]; ok {
+		t.Error("goose still configured after remove")
+	}
+}
+
+func TestGetProviderAgentDoc_CustomProvider(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Providers["goose"] = Provider{Name: "Goose", Binary: "goose", AgentDoc: "AGENTS.md"}
+	cfg.Providers["bare"] = Provider{Name: "Bare", Binary: "bare"}
+	reg := NewProviderRegistry(cfg)
+
+	got, err := GetProviderAgentDoc("goose", reg)
+	if err != nil {
+		t.Fatalf("goose: %v", err)
+	}
+	want, _ := GetAgentDoc("codex") // codex also reads AGENTS.md
+	if !bytes.Equal(got, want) {
+		t.Error("custom provider should resolve to the AGENTS.md template")
+	}
+	if _, err := GetProviderAgentDoc("bare", reg); err == nil {
+		t.Error("provider without agent_doc should error")
+	}
+}
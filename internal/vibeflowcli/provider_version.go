@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// providerVersionTimeout bounds a `binary --version` run. Agents that check
+// for updates on startup can be slow to answer.
+const providerVersionTimeout = 5 * time.Second
+
+// versionToken picks the version number out of --version output such as
+// "1.0.83 (Claude Code)" or "codex-cli 0.41.0".
+var versionToken = regexp.MustCompile(`\d+(\.\d+)+[0-9A-Za-z.+-]*`)
+
+// binaryVersion runs `binary --version` and returns the version it reports,
+// or the first line of its output when that has no version number.
+func binaryVersion(binary string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), providerVersionTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, binary, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	line := firstLine(string(out))
+	if v := versionToken.FindString(line); v != "" {
+		return v, nil
+	}
+	return line, nil
+}
+
+// launchVersion returns the version of binary for SessionMeta.ProviderVersion;
+// it is empty when the binary doesn't report one.
+func launchVersion(binary string) string {
+	v, _ := binaryVersion(binary)
+	return v
+}
+
+// installedVersions returns the version of each available provider's
+// binary, keyed by provider key. Providers whose binary doesn't report a
+// version are left out.
+func installedVersions(registry *ProviderRegistry) map[string]string {
+	versions := make(map[string]string)
+	for _, key := range registry.Keys() {
+		p, ok := registry.Get(key)
+		if !ok || !registry.IsAvailable(key) {
+			continue
+		}
+		if v := launchVersion(p.Binary); v != "" {
+			versions[key] = v
+		}
+	}
+	return versions
+}
+
+// versionNewer reports whether version a is newer than b. Dotted numeric
+// parts are compared as numbers; a version without them is only "newer"
+// when it differs, since there is nothing to order by.
+func versionNewer(a, b string) bool {
+	pa, pb := versionParts(a), versionParts(b)
+	if pa == nil || pb == nil {
+		return a != b
+	}
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+// versionParts returns the leading dotted numbers of a version, e.g.
+// [1 0 83] for "1.0.83-beta", or nil when it doesn't start with one.
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(v, "v")
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		end := 0
+		for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, err := strconv.Atoi(s[:end])
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+		if end < len(s) {
+			break
+		}
+	}
+	return parts
+}
+
+// sessionOutdated reports whether a session launched with version launched
+// runs an older binary than the installed one. Unknown versions never count.
+func sessionOutdated(launched, installed string) bool {
+	return launched != "" && installed != "" && versionNewer(installed, launched)
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVersionNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.0.83", "1.0.80", true},
+		{"1.0.80", "1.0.83", false},
+		{"1.0.83", "1.0.83", false},
+		{"1.10.0", "1.9.9", true},
+		{"2.0", "1.99.1", true},
+		{"1.0.1", "1.0", true},
+		{"v0.41.0", "0.40.2", true},
+		{"1.0.83-beta", "1.0.83", false},
+		{"nightly", "stable", true},
+		{"nightly", "nightly", false},
+	}
+	for _, tt := range tests {
+		if got := versionNewer(tt.a, tt.b); got != tt.want {
+			t.Errorf("versionNewer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if sessionOutdated("", "1.0.0") || sessionOutdated("1.0.0", "") || sessionOutdated("1.0.1", "1.0.0") {
+		t.Error("unknown or newer launch versions count as outdated")
+	}
+	if !sessionOutdated("1.0.0", "1.0.1") {
+		t.Error("older launch version not outdated")
+	}
+}
+
+func TestBinaryVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the provider binary")
+	}
+	dir := t.TempDir()
+	script := func(name, out string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\necho '"+out+"'\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	if v, err := binaryVersion(script("claude", "1.0.83 (Claude Code)")); err != nil || v != "1.0.83" {
+		t.Errorf("claude version = %q, %v; want 1.0.83", v, err)
+	}
+	if v, err := binaryVersion(script("codex", "codex-cli 0.41.0")); err != nil || v != "0.41.0" {
+		t.Errorf("codex version = %q, %v; want 0.41.0", v, err)
+	}
+	if v, _ := binaryVersion(script("odd", "development build")); v != "development build" {
+		t.Errorf("version without a number = %q, want the first line", v)
+	}
+	if v := launchVersion(filepath.Join(dir, "missing")); v != "" {
+		t.Errorf("missing binary version = %q, want empty", v)
+	}
+}
+
+func upgradeTestModel(t *testing.T) Model {
+	t.Helper()
+	m := bulkTestModel(t)
+	m.keys = defaultKeymap()
+	m.providerVersions = map[string]string{"claude": "1.0.83"}
+	for i := range m.sessions {
+		m.sessions[i].Provider = "claude"
+		m.sessions[i].Status = "idle"
+	}
+	m.sessions[0].ProviderVersion = "1.0.80"
+	m.sessions[1].ProviderVersion = "1.0.83"
+	m.sessions[2].ProviderVersion = "1.0.79"
+	return m
+}
+
+func TestSessionVersion(t *testing.T) {
+	m := upgradeTestModel(t)
+	if v, outdated := m.sessionVersion(m.sessions[1]); outdated || v != "1.0.83" {
+		t.Errorf("current session = %q, %v", v, outdated)
+	}
+	v, outdated := m.sessionVersion(m.sessions[0])
+	if !outdated || !strings.Contains(v, "1.0.80 (outdated, 1.0.83 installed; U: rolling restart)") {
+		t.Errorf("outdated session = %q, %v", v, outdated)
+	}
+	if v, _ := m.sessionVersion(SessionRow{Provider: "claude"}); v != "" {
+		t.Errorf("unrecorded version = %q, want empty", v)
+	}
+	if got := m.outdatedSessions(); strings.Join(got, ",") != "claude-a,claude-c" {
+		t.Errorf("outdated sessions = %v", got)
+	}
+}
+
+func TestRollingRestart_OneAtATime(t *testing.T) {
+	m := upgradeTestModel(t)
+	m.sessions[0].Status = "working"
+
+	m, cmd := m.toggleRollingRestart()
+	if m.upgrade == nil || cmd == nil {
+		t.Fatal("rolling restart didn't start")
+	}
+	// The working session is passed over for the idle one.
+	if m.upgrade.current != "claude-c" || strings.Join(m.upgrade.queue, ",") != "claude-a" {
+		t.Fatalf("current = %q, queue = %v; want claude-c first", m.upgrade.current, m.upgrade.queue)
+	}
+
+	m, _ = m.handleUpgradeStep(upgradeStepMsg{name: "claude-c", meta: SessionMeta{Name: "claude-c"}})
+	if m, cmd = m.advanceRollingRestart(time.Now()); cmd != nil || m.upgrade.current != "claude-c" {
+		t.Error("next session restarted before the last one settled")
+	}
+	settled := time.Now().Add(rollingRestartSettle)
+	if m, cmd = m.advanceRollingRestart(settled); cmd != nil || m.upgrade.current != "" {
+		t.Error("restarted a session that is still working")
+	}
+
+	m.sessions[0].Status = "idle"
+	if m, cmd = m.advanceRollingRestart(settled); cmd == nil || m.upgrade.current != "claude-a" {
+		t.Fatalf("claude-a not restarted once idle: current = %q", m.upgrade.current)
+	}
+	m, _ = m.handleUpgradeStep(upgradeStepMsg{name: "claude-a", err: os.ErrNotExist})
+	m, _ = m.advanceRollingRestart(settled)
+	if m.upgrade != nil {
+		t.Fatal("rolling restart not finished")
+	}
+	if m.notice != "Rolling restart done: 1 session restarted" || m.err == nil || !strings.Contains(m.err.Error(), "claude-a") {
+		t.Errorf("notice = %q, err = %v", m.notice, m.err)
+	}
+}
+
+func TestRollingRestart_ToggleCancels(t *testing.T) {
+	m := upgradeTestModel(t)
+	for i := range m.sessions {
+		m.sessions[i].Status = "working"
+	}
+	m, _ = m.toggleRollingRestart()
+	if m.upgrade == nil || len(m.upgrade.queue) != 2 {
+		t.Fatalf("upgrade = %+v; want both outdated sessions queued", m.upgrade)
+	}
+	m, _ = m.toggleRollingRestart()
+	if m.upgrade != nil || !strings.Contains(m.notice, "cancelled") {
+		t.Errorf("second press didn't cancel: upgrade = %+v, notice = %q", m.upgrade, m.notice)
+	}
+
+	m.providerVersions = nil
+	if m, _ = m.toggleRollingRestart(); m.upgrade != nil {
+		t.Error("started with no outdated session")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// launchQueue is the state behind max_running_sessions: how many agents
+// are running and which sessions wait for a slot, oldest first. A queued
+// session already has its tmux session and worktree; only the agent waits.
+type launchQueue struct {
+	running int
+	queued  []SessionMeta
+}
+
+// loadLaunchQueue counts live agents and collects queued sessions. Exited
+// and paused panes don't take a slot; neither does the workbench holder.
+func loadLaunchQueue(tmux *TmuxManager, store *Store) (launchQueue, error) {
+	var q launchQueue
+	sessions, err := tmux.ListSessions()
+	if err != nil {
+		return q, err
+	}
+	metas, err := store.List()
+	if err != nil {
+		return q, err
+	}
+	byTmux := make(map[string]SessionMeta, len(metas))
+	for _, meta := range metas {
+		byTmux[meta.TmuxSession] = meta
+	}
+	for _, ts := range sessions {
+		if isWorkbenchHolder(ts.Name) || ts.PaneDead {
+			continue
+		}
+		if meta, ok := byTmux[ts.Name]; ok && !meta.QueuedAt.IsZero() {
+			q.queued = append(q.queued, meta)
+			continue
+		}
+		q.running++
+	}
+	sort.SliceStable(q.queued, func(i, j int) bool {
+		return q.queued[i].QueuedAt.Before(q.queued[j].QueuedAt)
+	})
+	return q, nil
+}
+
+// position returns name's 1-based place in the queue, or 0.
+func (q launchQueue) position(name string) int {
+	for i, meta := range q.queued {
+		if meta.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// shouldQueueLaunch reports whether a new session has to wait: the cap is
+// reached, or earlier launches are already waiting and go first. Lookup
+// errors launch rather than strand the session.
+func shouldQueueLaunch(cfg *Config, tmux *TmuxManager, store *Store) bool {
+	if cfg == nil || cfg.MaxRunningSessions <= 0 || store == nil {
+		return false
+	}
+	q, err := loadLaunchQueue(tmux, store)
+	if err != nil {
+		return false
+	}
+	return q.running >= cfg.MaxRunningSessions || len(q.queued) > 0
+}
+
+// startQueuedSessions starts queued sessions, oldest first, while slots are
+// free. With no cap configured (any more) every queued session starts.
+// It returns the names started.
+func startQueuedSessions(cfg *Config, tmux *TmuxManager, store *Store) ([]string, error) {
+	q, err := loadLaunchQueue(tmux, store)
+	if err != nil || len(q.queued) == 0 {
+		return nil, err
+	}
+	free := len(q.queued)
+	if cfg != nil && cfg.MaxRunningSessions > 0 {
+		free = cfg.MaxRunningSessions - q.running
+	}
+	var started []string
+	for _, meta := range q.queued {
+		if free <= 0 {
+			break
+		}
+		if err := startQueuedSession(tmux, store, meta); err != nil {
+			return started, err
+		}
+		started = append(started, meta.Name)
+		free--
+	}
+	return started, nil
+}
+
+// startQueuedSession starts one queued session's agent, ignoring the cap.
+func startQueuedSession(tmux *TmuxManager, store *Store, meta SessionMeta) error {
+	if meta.QueuedAt.IsZero() {
+		return fmt.Errorf("session %q is not queued", meta.Name)
+	}
+	if err := tmux.StartQueued(meta.TmuxSession); err != nil {
+		return err
+	}
+	return store.SetQueued(meta.Name, time.Time{})
+}
+
+// startFreedSlots starts queued sessions after a CLI kill or delete freed
+// a slot, and says which.
+func startFreedSlots(cfg *Config, tmux *TmuxManager, store *Store) {
+	started, err := startQueuedSessions(cfg, tmux, store)
+	for _, name := range started {
+		fmt.Printf("Started queued session %q.\n", name)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to start queued sessions: %v\n", err)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// printQueue writes the `vibeflow queue` table.
+func printQueue(out io.Writer, q launchQueue, limit int, now time.Time) {
+	limitText := "no limit"
+	if limit > 0 {
+		limitText = fmt.Sprintf("limit %d", limit)
+	}
+	fmt.Fprintf(out, "%d running, %s, %d queued\n\n", q.running, limitText, len(q.queued))
+	fmt.Fprintf(out, "%-3s %-24s %-8s %-24s %s\n", "#", "NAME", "PROVIDER", "BRANCH", "WAITING")
+	fmt.Fprintln(out, strings.Repeat("-", 70))
+	for i, meta := range q.queued {
+		fmt.Fprintf(out, "%-3d %-24s %-8s %-24s %s\n",
+			i+1, truncate(meta.Name, 24), truncate(meta.Provider, 8), truncate(meta.Branch, 24),
+			formatSessionDuration(now.Sub(meta.QueuedAt)))
+	}
+}
+
+// --- queue ---
+
+func queueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "List sessions waiting for a slot under max_running_sessions",
+		Long: `List sessions launched while max_running_sessions agents were running, in
+the order they start. A queued session has its tmux session and worktree; its
+agent starts when a running one is killed, deleted or exits — by the TUI, or
+by the next vibeflow kill or delete.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			q, err := loadLaunchQueue(tmux, store)
+			if err != nil {
+				return err
+			}
+			if len(q.queued) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No sessions are queued (%d running).\n", q.running)
+				return nil
+			}
+			printQueue(cmd.OutOrStdout(), q, cfg.MaxRunningSessions, time.Now())
+			return nil
+		},
+	}
+	cmd.AddCommand(queueStartCmd())
+	return cmd
+}
+
+func queueStartCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "start <session-name>",
+		Short:             "Start a queued session now, ignoring the limit",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			_, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			meta, found, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("session %q not found", args[0])
+			}
+			if err := startQueuedSession(tmux, store, meta); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Session %q started.\n", meta.Name)
+			return nil
+		},
+	}
+}
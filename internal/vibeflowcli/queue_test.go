@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLaunchQueue runs a queued session end to end: with the cap reached a
+// launch waits behind a placeholder, and killing the running session lets
+// it start with its own command and environment. Skipped when tmux is
+// absent.
+func TestLaunchQueue(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	withTempRoot(t)
+	store := NewStore()
+	tm := NewTmuxManager("vftest-queue")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	cfg := &Config{MaxRunningSessions: 1}
+	dir := t.TempDir()
+	launch := func(name, command string) bool {
+		queued := shouldQueueLaunch(cfg, tm, store)
+		if err := tm.CreateSessionWithOpts(SessionOpts{
+			Name: name, WorkDir: dir, Command: command, Queued: queued,
+			Env: map[string]string{"VF_QUEUE_TEST": "env-" + name},
+		}); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		meta := SessionMeta{Name: name, TmuxSession: sessionPrefix + name, CreatedAt: time.Now()}
+		if queued {
+			meta.QueuedAt = meta.CreatedAt
+		}
+		if err := store.Add(meta); err != nil {
+			t.Fatal(err)
+		}
+		return queued
+	}
+
+	if launch("first", "sleep 300") {
+		t.Fatal("first launch was queued under an empty queue")
+	}
+	if !launch("second", `sh -c 'echo started $VF_QUEUE_TEST; sleep 300'`) {
+		t.Fatal("second launch was not queued at the cap")
+	}
+	q, err := loadLaunchQueue(tm, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.running != 1 || q.position("second") != 1 {
+		t.Fatalf("queue = %d running, second at %d; want 1 and 1", q.running, q.position("second"))
+	}
+	if started, _ := startQueuedSessions(cfg, tm, store); len(started) != 0 {
+		t.Errorf("started %v with no free slot", started)
+	}
+
+	if err := tm.KillSession("first"); err != nil {
+		t.Fatal(err)
+	}
+	started, err := startQueuedSessions(cfg, tm, store)
+	if err != nil || len(started) != 1 || started[0] != "second" {
+		t.Fatalf("started %v, %v; want [second]", started, err)
+	}
+	var out string
+	for i := 0; i < 50; i++ {
+		if out, _ = tm.CaptureScrollback("second"); strings.Contains(out, "started env-second") {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if !strings.Contains(out, "started env-second") {
+		t.Errorf("queued command did not run with its environment:\n%s", out)
+	}
+	if meta, _, _ := store.Get("second"); !meta.QueuedAt.IsZero() {
+		t.Error("QueuedAt still set after start")
+	}
+	if err := tm.StartQueued("second"); err == nil {
+		t.Error("starting a session twice succeeded")
+	}
+}
+
+func TestShouldQueueLaunch_NoLimit(t *testing.T) {
+	if shouldQueueLaunch(&Config{}, nil, nil) || shouldQueueLaunch(nil, nil, nil) {
+		t.Error("launch queued without max_running_sessions")
+	}
+}
+
+// TestRestartSession_QueuesAtCap restarts a session while another fills
+// the only slot: the restart waits in the queue like a launch would.
+func TestRestartSession_QueuesAtCap(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	withTempRoot(t)
+	store := NewStore()
+	tm := NewTmuxManager("vftest-queue-restart")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	dir := t.TempDir()
+	if err := tm.CreateSessionWithOpts(SessionOpts{Name: "first", WorkDir: dir, Command: "sleep 300"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Add(SessionMeta{Name: "first", TmuxSession: sessionPrefix + "first", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.MaxRunningSessions = 1
+	cfg.Providers = map[string]Provider{"fake": {Name: "Fake", Binary: "/bin/sh", LaunchTemplate: "sleep 300"}}
+	meta := SessionMeta{Name: "second", TmuxSession: tm.FullSessionName("fake", "second"), Provider: "fake", WorkingDir: dir}
+
+	updated, err := RestartSession(meta, cfg, tm, store, nil, NewProviderRegistry(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.QueuedAt.IsZero() {
+		t.Error("restart at the cap was not queued")
+	}
+	if q, err := loadLaunchQueue(tm, store); err != nil || q.running != 1 || q.position("second") != 1 {
+		t.Errorf("queue = %+v (err %v); want first running and second waiting", q, err)
+	}
+}
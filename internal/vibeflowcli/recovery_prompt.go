@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// RecoveryPrompt replaces the recovery message of a matched error pattern
+// for the sessions it selects. Provider, Persona and Error narrow it down;
+// an empty one matches any session.
+type RecoveryPrompt struct {
+	Provider string `yaml:"provider,omitempty"` // provider key
+	Persona  string `yaml:"persona,omitempty"`  // persona key, e.g. qa_lead
+	Error    string `yaml:"error,omitempty"`    // part of the pattern description, case-insensitive
+	Message  string `yaml:"message"`            // text/template rendered with RecoveryPromptVars
+}
+
+// RecoveryPromptVars are the values a recovery prompt template can use.
+type RecoveryPromptVars struct {
+	Session  string // session name, without the tmux prefix
+	Provider string
+	Persona  string
+	Project  string
+	Branch   string
+	Error    string // matched pattern's description
+	Message  string // matched pattern's own recovery message
+	Attempt  int    // the attempt this message is sent for, from 1
+}
+
+// matches reports whether p applies to a session of provider and persona
+// that hit the error described by desc.
+func (p RecoveryPrompt) matches(provider, persona, desc string) bool {
+	if p.Provider != "" && p.Provider != provider {
+		return false
+	}
+	if p.Persona != "" && p.Persona != persona {
+		return false
+	}
+	return p.Error == "" || strings.Contains(strings.ToLower(desc), strings.ToLower(p.Error))
+}
+
+// RecoveryPrompt returns the first configured prompt that applies to a
+// session of provider and persona that hit the error described by desc.
+func (c ErrorRecoveryConfig) RecoveryPrompt(provider, persona, desc string) (RecoveryPrompt, bool) {
+	for _, p := range c.Prompts {
+		if p.matches(provider, persona, desc) {
+			return p, true
+		}
+	}
+	return RecoveryPrompt{}, false
+}
+
+// RenderRecoveryPrompt renders a recovery prompt template. On a template
+// error the pattern's own message is returned along with the error, so a
+// typo in config.yaml falls back to the default instead of stalling
+// recovery. A template that renders to nothing means no message is sent.
+func RenderRecoveryPrompt(tmpl string, vars RecoveryPromptVars) (string, error) {
+	t, err := template.New("recovery").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return vars.Message, fmt.Errorf("parse recovery prompt: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return vars.Message, fmt.Errorf("render recovery prompt: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestErrorRecoveryConfig_RecoveryPrompt(t *testing.T) {
+	cfg := ErrorRecoveryConfig{Prompts: []RecoveryPrompt{
+		{Persona: "qa_lead", Error: "rate limit", Message: "qa rate"},
+		{Provider: "codex", Persona: "qa_lead", Message: "codex qa"},
+		{Persona: "qa_lead", Message: "qa"},
+		{Provider: "claude", Message: "claude"},
+	}}
+	cases := []struct {
+		provider, persona, desc string
+		want                    string
+	}{
+		{"codex", "qa_lead", "Codex Rate Limit", "qa rate"},
+		{"codex", "qa_lead", "Codex 500", "codex qa"},
+		{"claude", "qa_lead", "Claude API 5xx", "qa"},
+		{"claude", "developer", "Claude API 5xx", "claude"},
+		{"gemini", "developer", "Gemini 500", ""},
+	}
+	for _, c := range cases {
+		p, ok := cfg.RecoveryPrompt(c.provider, c.persona, c.desc)
+		if ok != (c.want != "") || p.Message != c.want {
+			t.Errorf("RecoveryPrompt(%q, %q, %q) = %q, %v; want %q", c.provider, c.persona, c.desc, p.Message, ok, c.want)
+		}
+	}
+}
+
+func TestRenderRecoveryPrompt(t *testing.T) {
+	vars := RecoveryPromptVars{Session: "api", Branch: "feat/x", Error: "Claude API 5xx", Message: "Please retry.", Attempt: 2}
+	got, err := RenderRecoveryPrompt("{{.Message}} On {{.Branch}} ({{.Error}}, attempt {{.Attempt}}) re-run the failing suite.\n", vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Please retry. On feat/x (Claude API 5xx, attempt 2) re-run the failing suite."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, bad := range []string{"{{.Branch", "{{.Ticket}}"} {
+		if got, err := RenderRecoveryPrompt(bad, vars); err == nil || got != "Please retry." {
+			t.Errorf("%q: got %q, %v; want the pattern's message and an error", bad, got, err)
+		}
+	}
+}
+
+func TestHealthMonitor_RecoveryMessageUsesPrompt(t *testing.T) {
+	store := testStore(t)
+	if err := store.Add(SessionMeta{Name: "qa", TmuxSession: "vibeflow_qa", Provider: "inhouse", Persona: "qa_lead", Branch: "feat/x"}); err != nil {
+		t.Fatal(err)
+	}
+	hm := testHealthMonitor(t)
+	hm.config.Prompts = []RecoveryPrompt{{Persona: "qa_lead", Message: "{{.Session}} on {{.Branch}} hit {{.Error}}: re-run the failing suite."}}
+	hm.registry.AddPattern(ErrorPattern{
+		Provider: "inhouse", Regex: regexp.MustCompile(`upstream hiccup`),
+		RecoveryMessage: "Please retry.", Description: "upstream hiccup",
+	})
+	if err := hm.Restore(store); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"vibeflow_qa", "vibeflow_dev"} {
+		hm.CheckOutput(name, "inhouse", "upstream hiccup", false)
+	}
+	if got, want := hm.recoveryMessage(hm.GetHealth("vibeflow_qa")), "qa on feat/x hit upstream hiccup: re-run the failing suite."; got != want {
+		t.Errorf("qa_lead session: got %q, want %q", got, want)
+	}
+	if got := hm.recoveryMessage(hm.GetHealth("vibeflow_dev")); got != "Please retry." {
+		t.Errorf("session without a prompt: got %q, want the pattern's message", got)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// --- remote ---
+
+// remoteCmd groups subcommands that operate on vibeflow sessions living on
+// another machine. Every tmux call is tunnelled through ssh (see
+// NewRemoteTmuxManager), so the remote host only needs tmux and an sshd —
+// vibeflow itself does not have to be installed there.
+//
+// The socket defaults to "vibeflow", the socket a default-root vibeflow uses
+// on the remote host. The local config's tmux_socket is deliberately ignored:
+// it describes this machine, not the remote one. Use --tmux-socket to target
+// a remote instance running under a custom --root.
+func remoteCmd() *cobra.Command {
+	var host string
+
+	cmd := &cobra.Command{
+		Use:   "remote",
+		Short: "List or attach to sessions on a remote host over SSH",
+		Long: `List or attach to vibeflow tmux sessions on a remote host.
+
+All tmux commands are executed as "ssh <host> tmux -L <socket> ...", so the
+host can be anything ssh accepts (user@host or an alias from ~/.ssh/config).
+
+Examples:
+  vibeflow remote --host dev-box list
+  vibeflow remote --host dev-box attach claude-feature-x`,
+	}
+	cmd.PersistentFlags().StringVar(&host, "host", "", "SSH destination (user@host or ssh config alias)")
+	_ = cmd.MarkPersistentFlagRequired("host")
+
+	newManager := func() *TmuxManager {
+		return NewRemoteTmuxManager(host, remoteSocketName(flagTmuxSocket))
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Short:   "List vibeflow sessions on the remote host",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sessions, err := newManager().ListSessions()
+			if err != nil {
+				return fmt.Errorf("remote %s: %w", host, err)
+			}
+			if len(sessions) == 0 {
+				fmt.Printf("No active sessions on %s.\n", host)
+				return nil
+			}
+			// There is no remote store to read, so derive what we can
+			// from the tmux session name itself.
+			meta := make(map[string]SessionMeta, len(sessions))
+			for _, s := range sessions {
+				if p := ParseSessionProvider(s.Name); p != "" {
+					meta[s.Name] = SessionMeta{Provider: p, Branch: "-"}
+				}
+			}
+			printSessionTable(sessions, meta)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "attach <session-name>",
+		Short: "Attach to a session on the remote host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tmux := newManager()
+			if !tmux.HasSession(args[0]) {
+				return fmt.Errorf("session %q not found on %s", args[0], host)
+			}
+			return tmux.AttachSession(args[0])
+		},
+	})
+
+	return cmd
+}
+
+// remoteSocketName picks the tmux socket used on the remote host: an
+// explicit --tmux-socket wins, otherwise the default "vibeflow" socket.
+func remoteSocketName(flagVal string) string {
+	if flagVal != "" {
+		return flagVal
+	}
+	return "vibeflow"
+}
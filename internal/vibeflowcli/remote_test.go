@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTmuxCommand_Local(t *testing.T) {
+	tm := &TmuxManager{socketName: "vibeflow"}
+	cmd := tm.command(false, "list-sessions", "-F", "#{session_name}")
+	want := []string{"tmux", "-L", "vibeflow", "list-sessions", "-F", "#{session_name}"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("args = %q, want %q", cmd.Args, want)
+	}
+}
+
+func TestTmuxCommand_RemoteQuotesArgs(t *testing.T) {
+	tm := NewRemoteTmuxManager("me@dev-box", "vibeflow")
+	if !tm.IsRemote() {
+		t.Fatal("expected remote manager")
+	}
+	cmd := tm.command(false, "list-sessions", "-F", "#{session_name}:::#{session_id}")
+	want := []string{"ssh", "me@dev-box", "tmux", "-L", "vibeflow", "list-sessions", "-F", "'#{session_name}:::#{session_id}'"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("args = %q, want %q", cmd.Args, want)
+	}
+}
+
+func TestAttachSessionCmd_RemoteUsesTTY(t *testing.T) {
+	// Even inside a local tmux, a remote attach must not use switch-client.
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	tm := NewRemoteTmuxManager("dev-box", "")
+	cmd := tm.AttachSessionCmd("claude-x")
+	want := []string{"ssh", "-t", "dev-box", "tmux", "-L", "vibeflow", "attach-session", "-t", "vibeflow_claude-x"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("args = %q, want %q", cmd.Args, want)
+	}
+}
+
+func TestObserveSessionCmd_ReadOnly(t *testing.T) {
+	// Never switch-client -r: that would make the user's own client read-only.
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	tm := NewRemoteTmuxManager("dev-box", "")
+	cmd := tm.ObserveSessionCmd("claude-x")
+	want := []string{"ssh", "-t", "dev-box", "tmux", "-L", "vibeflow", "attach-session", "-r", "-t", "vibeflow_claude-x"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("args = %q, want %q", cmd.Args, want)
+	}
+}
+
+func TestRemoteSocketName(t *testing.T) {
+	if got := remoteSocketName(""); got != "vibeflow" {
+		t.Errorf("default socket = %q, want vibeflow", got)
+	}
+	if got := remoteSocketName("vibeflow-abc"); got != "vibeflow-abc" {
+		t.Errorf("flag socket = %q, want vibeflow-abc", got)
+	}
+}
@@ -17,6 +17,7 @@
 package vibeflowcli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -31,6 +32,8 @@ var (
 	flagProject     string
 	flagMCPToolName string
 	flagTmuxSocket  string
+	flagResume      bool
+	flagPlain       bool
 
 	buildVersion = "dev"
 	buildCommit  = "none"
@@ -44,6 +47,21 @@ func SetVersionInfo(version, commit, date string) {
 	buildDate = date
 }
 
+// resolveProjectID looks up the ID of the named project, or 0 when the
+// server can't be reached or has no such project.
+func resolveProjectID(client VibeflowAPI, name string) int64 {
+	projects, err := client.ListProjects()
+	if err != nil {
+		return 0
+	}
+	for _, p := range projects {
+		if p.Name == name {
+			return p.ID
+		}
+	}
+	return 0
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "vibeflow-cli",
 	Short: "Terminal UI for managing VibeFlow vibecoding sessions",
@@ -73,23 +91,50 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&flagTmuxSocket, "tmux-socket", "", "tmux socket name for sessions (default: 'vibeflow', or 'vibeflow-<hash>' for a custom --root)")
 	rootCmd.Flags().StringVar(&flagServerURL, "server-url", "", "VibeFlow server URL (overrides config)")
 	rootCmd.Flags().StringVar(&flagProject, "project", "", "Default project name")
+	rootCmd.Flags().BoolVar(&flagResume, "resume", false, "Attach to the most recently active session on start (config: resume_last_session)")
+	rootCmd.Flags().BoolVar(&flagPlain, "plain", false, "Line-based session list without alt screen, colors or box drawing, for screen readers and dumb terminals (config: plain)")
 
 	rootCmd.AddCommand(versionCmd)
 
+	// Replaced by completionCmd, which names the script after the installed
+	// binary rather than the root command's Use.
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
 	// Register headless subcommands.
 	initSubcommands(rootCmd)
 }
 
 // Execute runs the root command.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if errors.Is(err, ErrUnauthorized) {
+		return offerReauth(err, os.Stdin, os.Stderr, stdinIsTerminal())
+	}
+	return err
 }
 
 func runTUI(cmd *cobra.Command, args []string) error {
-	// Enforce singleton — only one TUI instance at a time.
+	// Enforce singleton — only one TUI instance at a time. A second one
+	// (say, the C-q popup racing the first) can take over or look on
+	// read-only when there is a terminal to ask on.
+	readOnly, lockHolder := false, 0
 	if err := AcquirePIDLock(); err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		return nil // Exit gracefully, not an error.
+		var running *instanceRunningError
+		if !errors.As(err, &running) || !stdinIsTerminal() {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return nil // Exit gracefully, not an error.
+		}
+		switch promptInstanceRunning(os.Stdin, os.Stderr, running.pid) {
+		case "t":
+			if err := TakeOverPIDLock(running.pid, lockTakeoverTimeout); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				return nil
+			}
+		case "r":
+			readOnly, lockHolder = true, running.pid
+		default:
+			return nil
+		}
 	}
 	defer ReleasePIDLock()
 
@@ -110,13 +155,15 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	// (rather than lower down) lets the wizard gate below see existing state.
 	cfg.TmuxSocket = ResolveTmuxSocket(flagTmuxSocket, cfg.TmuxSocket)
 	tmux := NewTmuxManager(cfg.TmuxSocket)
+	tmux.SetProjectSockets(cfg.TmuxPerProject)
+	tmuxWasRunning := tmux.ServerRunning()
 	_ = tmux.EnsureServer() // Start tmux server on the vibeflow socket if not running.
 	store := NewStore()
 
 	// First-run setup wizard only when the root is genuinely uninitialized:
 	// no config.yaml AND no existing session state. The headless spawn/dispatch
 	// path never writes config.yaml, and a relocated/copied root likewise has a
-	// sessions.json (and/or live tmux sessions) but no config — showing the
+	// stored sessions (and/or live tmux sessions) but no config — showing the
 	// fresh-install wizard there would hide the user's running sessions behind a
 	// setup screen instead of attaching. See issue #3484.
 	if !ConfigFileExists(cfgPath) && !hasExistingSessionState(store, tmux) {
@@ -143,26 +190,21 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize components
-	client := NewClient(cfg.ServerURL, cfg.APIToken)
+	client := NewVibeflowAPI(cfg)
 	registry := NewProviderRegistry(cfg)
 
 	// Initialize worktree manager (best-effort — non-fatal if not in a git repo).
 	cwd, _ := os.Getwd()
 	worktrees, _ := NewWorktreeManager(cwd, cfg.Worktree.BaseDir)
+	if worktrees != nil {
+		worktrees.SetSetup(cfg.Worktree.Setup)
+	}
 	cache := NewSessionCache()
 
 	// Resolve project ID if project name is set
 	var projectID int64
 	if cfg.DefaultProject != "" {
-		projects, err := client.ListProjects()
-		if err == nil {
-			for _, p := range projects {
-				if p.Name == cfg.DefaultProject {
-					projectID = p.ID
-					break
-				}
-			}
-		}
+		projectID = resolveProjectID(client, cfg.DefaultProject)
 	}
 
 	// Check server reachability (non-blocking — warn only).
@@ -174,14 +216,43 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	// Run TUI
 	model := NewModel(cfg, client, tmux, worktrees, store, cache, registry, projectID)
 	model.serverWarning = serverWarning
+	model.readOnly, model.lockHolder = readOnly, lockHolder
+	model.plain = flagPlain || cfg.Plain || os.Getenv("TERM") == "dumb"
+
+	// Push tmux session changes into the TUI via control mode instead of
+	// relying on the poll tick alone. Best-effort: on failure the model just
+	// polls at the configured interval.
+	if events, stopEvents, err := tmux.WatchEvents(); err == nil {
+		model.tmuxEvents = events
+		defer stopEvents()
+	} else {
+		model.logger.Warn("%v", err)
+	}
 
-	// Detect dead sessions from cache and show restart popup if any.
-	if tmuxNames, err := tmux.ListSessionNames(); err == nil {
+	// Detect dead sessions from cache and show restart popup if any. With
+	// no tmux server before this run (a reboot), offer to restore them all.
+	if tmuxNames, err := tmux.ListSessionNames(); err == nil && !readOnly {
 		if deadSessions, err := cache.DeadSessions(tmuxNames); err == nil && len(deadSessions) > 0 {
-			model.restartSelect = NewRestartSelectModel(deadSessions)
+			if tmuxWasRunning {
+				model.restartSelect = NewRestartSelectModel(deadSessions)
+			} else {
+				model.restartSelect = NewRestoreSelectModel(deadSessions)
+			}
 			model.activeView = ViewRestart
 		}
 	}
+
+	// --resume: skip the list and reattach to the session used last. The
+	// dead-session restart popup above takes precedence.
+	if (flagResume || cfg.ResumeLastSession) && model.activeView == ViewSessions {
+		if tmuxNames, err := tmux.ListSessionNames(); err == nil {
+			if meta, ok, err := store.LastActive(tmuxNames); err != nil {
+				model.logger.Warn("resume: %v", err)
+			} else if ok {
+				model.resumeSession = meta.TmuxSession
+			}
+		}
+	}
 	defer model.logger.Close()
 	// Alt-screen, focus reporting, and mouse mode are set on the View in
 	// Bubble Tea v2 (see Model.View) rather than as program options here.
@@ -201,7 +272,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 }
 
 // hasExistingSessionState reports whether the current root already holds
-// vibeflow session state — either a sessions.json with at least one entry or a
+// vibeflow session state — either a stored session entry or a
 // live tmux session on the resolved socket. It gates the first-run setup wizard
 // so a root created by the headless spawn/dispatch path (which never writes
 // config.yaml) or a relocated/copied state dir attaches to its sessions instead
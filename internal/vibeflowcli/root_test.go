@@ -57,12 +57,12 @@ func TestSetupWizardGate_StatePresentVsEmptyRoot(t *testing.T) {
 
 	// Empty root (no config.yaml, no sessions) → wizard SHOULD show.
 	SetRootDir(t.TempDir())
-	emptyStore := NewStore() // DefaultStorePath = <root>/sessions.json
+	emptyStore := NewStore() // DefaultStorePath = <root>/vibeflow.db
 	if show := !ConfigFileExists(ConfigPath()) && !hasExistingSessionState(emptyStore, nil); !show {
 		t.Fatal("empty root: expected the setup wizard to show")
 	}
 
-	// Root with sessions.json entries but no config.yaml (the reporter's case,
+	// Root with stored sessions but no config.yaml (the reporter's case,
 	// e.g. a dispatcher-created root) → wizard SUPPRESSED.
 	SetRootDir(t.TempDir())
 	stateStore := NewStore()
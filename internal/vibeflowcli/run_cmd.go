@@ -0,0 +1,366 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"vibeflow-cli/sessionid"
+
+	"github.com/spf13/cobra"
+)
+
+// runOptions holds the flags of `vibeflow run`.
+type runOptions struct {
+	Provider        string
+	Prompt          string
+	Dir             string
+	Model           string
+	Project         string
+	Persona         string
+	Profile         string
+	SkipPermissions bool
+}
+
+// args returns the `run` arguments that repeat o in the foreground. A
+// detached run starts itself again with them.
+func (o runOptions) args() []string {
+	args := []string{"run", "--provider", o.Provider, "--prompt", o.Prompt, "--dir", o.Dir}
+	for _, f := range [][2]string{{"--model", o.Model}, {"--project", o.Project}, {"--persona", o.Persona}, {"--profile", o.Profile}} {
+		if f[1] != "" {
+			args = append(args, f[0], f[1])
+		}
+	}
+	if o.SkipPermissions {
+		args = append(args, "--skip-permissions")
+	}
+	return args
+}
+
+// headlessBinary returns the binary invocation that runs providerKey
+// non-interactively: codex takes tasks through its exec subcommand, the
+// other built-ins through a print flag added by headlessCommand.
+func headlessBinary(providerKey, binary string) string {
+	if providerKey == "codex" {
+		return binary + " exec"
+	}
+	return binary
+}
+
+// headlessCommand appends prompt to a provider command so the agent works
+// on it, prints its output and exits instead of opening its UI: a
+// positional task for codex exec, --message for aider, --headless --task
+// for openhands and -p for the rest.
+func headlessCommand(command, providerKey, prompt string) string {
+	switch providerKey {
+	case "codex":
+		return command + " " + shellQuote(prompt)
+	case "aider":
+		return command + " --message " + shellQuote(prompt)
+	case "openhands":
+		return command + " --headless --task " + shellQuote(prompt)
+	default:
+		return command + " -p " + shellQuote(prompt)
+	}
+}
+
+// runExitError reports an agent that exited non-zero. main exits with the
+// same status.
+type runExitError struct {
+	provider string
+	code     int
+}
+
+func (e *runExitError) Error() string {
+	return fmt.Sprintf("%s exited with status %d", e.provider, e.code)
+}
+
+// ExitCode returns the agent's exit status.
+func (e *runExitError) ExitCode() int { return e.code }
+
+// runAgent runs command in dir in the foreground and returns its exit
+// status. Cancelling ctx stops the agent.
+func runAgent(ctx context.Context, command, dir string, env map[string]string, stdout, stderr io.Writer) (int, error) {
+	c := exec.CommandContext(ctx, "sh", "-c", command)
+	c.Dir = dir
+	c.Env = os.Environ()
+	for k, v := range env {
+		c.Env = append(c.Env, k+"="+os.Expand(v, os.Getenv))
+	}
+	c.Stdout = stdout
+	c.Stderr = stderr
+	err := c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// registerRun calls session_init for a run so it shows up on the server,
+// and returns the session ID and the server's agent prompt (persona runs
+// only). Runs without API auth, or whose registration fails, go ahead
+// unregistered with the local ID.
+func registerRun(cfg *Config, client VibeflowAPI, o runOptions, localID string, warn io.Writer) (string, string, bool) {
+	if !cfg.hasAPIAuth() {
+		return localID, "", false
+	}
+	project := o.Project
+	if project == "" {
+		project = cfg.DefaultProject
+	}
+	branch, _ := gitIn(o.Dir, "rev-parse", "--abbrev-ref", "HEAD")
+	result, err := client.SessionInit(SessionInitRequest{
+		ProjectName:      project,
+		SessionID:        localID,
+		Persona:          o.Persona,
+		GitBranch:        branch,
+		WorkingDirectory: o.Dir,
+		AgentType:        o.Provider,
+		AgentModel:       o.Model,
+	})
+	if err != nil {
+		fmt.Fprintf(warn, "Warning: running unregistered: %v\n", err)
+		return localID, "", false
+	}
+	id := result.SessionID
+	if id == "" {
+		id = localID
+	}
+	var prompt string
+	if o.Persona != "" {
+		prompt = result.Prompt
+	}
+	return id, prompt, true
+}
+
+// heartbeatRun reports the run as working every interval until ctx ends.
+func heartbeatRun(ctx context.Context, client VibeflowAPI, id string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_ = client.SessionHeartbeat(id, "working")
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startDetachedRun starts `vibeflow run` again in its own process group
+// with output appended to logPath, and returns its pid.
+func startDetachedRun(cfgPath, logPath string, o runOptions) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	if cfgPath == "" {
+		cfgPath = ConfigPath()
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return 0, err
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer logFile.Close()
+	cmd := exec.Command(exe, append([]string{"--root", RootDir(), "--config", cfgPath}, o.args()...)...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+	return pid, cmd.Process.Release()
+}
+
+// --- run ---
+
+func runCmd() *cobra.Command {
+	var (
+		o       runOptions
+		detach  bool
+		logPath string
+	)
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run a one-shot agent task without tmux",
+		Long: `Run the provider non-interactively on a single prompt, in the foreground,
+and exit with the agent's exit status. Nothing runs in tmux, so this suits
+CI jobs and scripts. With API auth the run is registered through
+session_init and sends heartbeats while it works; --persona also prepends
+the server's agent prompt. --detach starts the run in the background with
+its output appended to a log file.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, _, _, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			if o.Prompt == "-" {
+				data, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("read prompt from stdin: %w", err)
+				}
+				o.Prompt = string(data)
+			}
+			if strings.TrimSpace(o.Prompt) == "" {
+				return fmt.Errorf("--prompt is required")
+			}
+			if o.Provider == "" {
+				o.Provider = cfg.DefaultProvider
+			}
+			if o.Provider == "" {
+				o.Provider = "claude"
+			}
+			prov, ok := registry.Get(o.Provider)
+			if !ok {
+				return fmt.Errorf("unknown provider %q", o.Provider)
+			}
+			if !registry.IsAvailable(o.Provider) {
+				return fmt.Errorf("provider %q binary %q not found on PATH", o.Provider, prov.Binary)
+			}
+			if o.Dir, err = filepath.Abs(o.Dir); err != nil {
+				return err
+			}
+
+			if detach {
+				if logPath == "" {
+					logPath = filepath.Join(RootDir(), "logs", fmt.Sprintf("run-%s-%d.log", o.Provider, time.Now().Unix()))
+				}
+				pid, err := startDetachedRun(cfgPath, logPath, o)
+				if err != nil {
+					return fmt.Errorf("start detached run: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Run started in the background (pid %d); output in %s\n", pid, logPath)
+				return nil
+			}
+
+			env, missingVar := ResolveProviderEnvVars(cfg, o.Provider)
+			if missingVar != "" {
+				return fmt.Errorf("provider %q requires env var %q — set it in the environment", o.Provider, missingVar)
+			}
+			for k, v := range prov.Env {
+				if _, set := env[k]; !set {
+					env[k] = v
+				}
+			}
+			if env, err = withProfileEnv(env, cfg, o.Provider, o.Profile); err != nil {
+				return err
+			}
+			if env == nil {
+				env = make(map[string]string)
+			}
+			gatewayEnv := ClearLLMGatewayEnv(o.Provider)
+			if enabled, _ := GatewayEnabledForProvider(false, cfg.LLMGatewayEnabled, o.Provider); enabled {
+				gatewayEnv = BuildLLMGatewayEnv(o.Provider, cfg.ServerURL, cfg.APIToken)
+			}
+			for k, v := range gatewayEnv {
+				env[k] = v
+			}
+			env = WithMCPTokenEnv(env, cfg)
+			if o.Provider == "qwen" && o.Model != "" {
+				env["OPENAI_MODEL"] = o.Model
+			}
+
+			client := newAPIClient(cfg)
+			id, serverPrompt, registered := registerRun(cfg, client, o, sessionid.GenerateSessionID(o.Dir), cmd.ErrOrStderr())
+			command, err := RenderLaunchCommand(prov.LaunchTemplate, LaunchTemplateVars{
+				WorkDir:         o.Dir,
+				ServerURL:       cfg.ServerURL,
+				SessionID:       id,
+				SkipPermissions: o.SkipPermissions,
+				Model:           o.Model,
+				Binary:          headlessBinary(o.Provider, prov.Binary),
+			})
+			if err != nil || command == "" {
+				command = headlessBinary(o.Provider, prov.Binary)
+			}
+			command = AppendCodexGatewayProviderFlags(command, o.Provider, env)
+			applyQwenModelPassthrough(o.Provider, env)
+			command = AppendQwenAPIFlags(command, o.Provider, env)
+			command = headlessCommand(command, o.Provider, joinPrompts(serverPrompt, o.Prompt))
+			env = withClaudeHardeningEnv(o.Provider, env)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			stopHeartbeat := func() {}
+			if registered {
+				interval := defaultHeartbeatInterval
+				if cfg.Heartbeat.IntervalSeconds > 0 {
+					interval = time.Duration(cfg.Heartbeat.IntervalSeconds) * time.Second
+				}
+				hbCtx, hbStop := context.WithCancel(ctx)
+				hbDone := make(chan struct{})
+				go func() {
+					defer close(hbDone)
+					heartbeatRun(hbCtx, client, id, interval)
+				}()
+				stopHeartbeat = func() {
+					hbStop()
+					<-hbDone
+				}
+				defer stopHeartbeat()
+			}
+
+			started := time.Now()
+			code, err := runAgent(ctx, command, o.Dir, env, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			if registered {
+				// A working beat still on its way must not land after exited.
+				stopHeartbeat()
+				_ = client.SessionHeartbeat(id, "exited")
+			}
+			if err != nil {
+				return fmt.Errorf("run %s: %w", o.Provider, err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "vibeflow run %s: %s exited with status %d after %s\n", id, o.Provider, code, formatSessionDuration(time.Since(started)))
+			if code != 0 {
+				cmd.SilenceUsage = true
+				return &runExitError{provider: o.Provider, code: code}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&o.Provider, "provider", "", "Provider key (default: default_provider)")
+	cmd.Flags().StringVar(&o.Prompt, "prompt", "", "Task for the agent; - reads it from stdin")
+	cmd.Flags().StringVar(&o.Dir, "dir", ".", "Working directory for the agent")
+	cmd.Flags().StringVar(&o.Model, "model", "", "Model id to pass to the provider")
+	cmd.Flags().StringVar(&o.Project, "project", "", "Project to register the run under (default: default_project)")
+	cmd.Flags().StringVar(&o.Persona, "persona", "", "Persona whose server agent prompt precedes the task")
+	cmd.Flags().StringVar(&o.Profile, "profile", "", "Credential profile to run the provider under")
+	cmd.Flags().BoolVar(&o.SkipPermissions, "skip-permissions", false, "Skip permission prompts; most agents need this to edit files unattended")
+	cmd.Flags().BoolVar(&detach, "detach", false, "Run in the background with output appended to a log file")
+	cmd.Flags().StringVar(&logPath, "log", "", "Log file for --detach (default: <root>/logs/run-<provider>-<time>.log)")
+	return cmd
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestHeadlessCommand(t *testing.T) {
+	if got := headlessCommand(headlessBinary("claude", "claude")+" --model opus", "claude", "fix it"); got != "claude --model opus -p 'fix it'" {
+		t.Errorf("claude = %q", got)
+	}
+	if got := headlessCommand(headlessBinary("codex", "codex")+" --yolo", "codex", "it's broken"); got != `codex exec --yolo 'it'\''s broken'` {
+		t.Errorf("codex = %q", got)
+	}
+}
+
+func TestHeadlessCommand_BuiltInProviders(t *testing.T) {
+	want := map[string]string{
+		"aider":     "aider --message 'fix it'",
+		"claude":    "claude -p 'fix it'",
+		"codex":     "codex exec 'fix it'",
+		"cursor":    "agent -p 'fix it'",
+		"gemini":    "gemini -p 'fix it'",
+		"openhands": "openhands --headless --task 'fix it'",
+		"qwen":      "qwen -p 'fix it'",
+	}
+	reg := NewProviderRegistry(DefaultConfig())
+	for _, key := range reg.Keys() {
+		prov, _ := reg.Get(key)
+		got := headlessCommand(headlessBinary(key, prov.Binary), key, "fix it")
+		if got != want[key] {
+			t.Errorf("%s: %q, want %q", key, got, want[key])
+		}
+	}
+	if len(reg.Keys()) != len(want) {
+		t.Errorf("built-in providers = %q; add the new ones to this test", reg.Keys())
+	}
+}
+
+func TestRunOptionsArgs(t *testing.T) {
+	o := runOptions{Provider: "claude", Prompt: "fix it", Dir: "/src", Persona: "developer", SkipPermissions: true}
+	want := []string{"run", "--provider", "claude", "--prompt", "fix it", "--dir", "/src", "--persona", "developer", "--skip-permissions"}
+	if got := o.args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestRunAgent_ExitStatusAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	code, err := runAgent(context.Background(), `echo "$RUN_TEST_VAR $(pwd)"; exit 3`, dir, map[string]string{"RUN_TEST_VAR": "hello"}, &out, &out)
+	if err != nil || code != 3 {
+		t.Fatalf("runAgent = %d, %v", code, err)
+	}
+	if got := strings.TrimSpace(out.String()); !strings.HasPrefix(got, "hello ") || !strings.HasSuffix(got, filepath.Base(dir)) {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestRunCmd_RunsProviderHeadless(t *testing.T) {
+	root := withTempRoot(t)
+	t.Setenv("VIBEFLOW_TOKEN", "")
+	agent := filepath.Join(t.TempDir(), "fakeagent")
+	if err := os.WriteFile(agent, []byte("#!/bin/sh\necho \"args: $*\"\nexit 3\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "providers:\n  fake:\n    name: Fake\n    binary: " + agent + "\n    launch_template: \"{{.Binary}}\"\n"
+	if err := os.WriteFile(filepath.Join(root, "config.yaml"), []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{Use: "vibeflow-cli"}
+	cmd.PersistentFlags().String("config", "", "")
+	cmd.AddCommand(runCmd())
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"run", "--provider", "fake", "--prompt", "fix the test", "--dir", t.TempDir()})
+	err := cmd.Execute()
+
+	var exitErr *runExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("err = %v, want exit status 3", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "args: -p fix the test" {
+		t.Errorf("agent output = %q", got)
+	}
+	if !strings.Contains(stderr.String(), "fake exited with status 3") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
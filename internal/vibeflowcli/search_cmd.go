@@ -0,0 +1,309 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultSearchLines is how many lines of each session's output `search`
+// reads by default.
+const defaultSearchLines = 2000
+
+// searchHunk is a run of consecutive lines around one or more matches.
+type searchHunk struct {
+	Start   int      // 1-based line number of Lines[0]
+	Lines   []string // the matched lines with their context
+	Matches []bool   // Matches[i] reports whether Lines[i] matched
+}
+
+// searchResult is one session's matches.
+type searchResult struct {
+	Session string // short name
+	Hunks   []searchHunk
+}
+
+// matchCount is the number of matching lines.
+func (r searchResult) matchCount() int {
+	n := 0
+	for _, h := range r.Hunks {
+		for _, matched := range h.Matches {
+			if matched {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// searchSource returns the text searched for a session (short or full
+// tmux name).
+type searchSource func(name string) (string, error)
+
+// compileSearchPattern builds the matcher for pattern: a regular
+// expression, or a literal string when fixed is set.
+func compileSearchPattern(pattern string, fixed, ignoreCase bool) (*regexp.Regexp, error) {
+	if fixed {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return re, nil
+}
+
+// searchLines finds the lines of text matching re, each with up to context
+// lines either side. Matches whose context touches or overlaps share a
+// hunk, as grep prints them.
+func searchLines(text string, re *regexp.Regexp, context int) []searchHunk {
+	lines := strings.Split(text, "\n")
+	var hunks []searchHunk
+	end := 0 // index after the last line of the current hunk
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		from, to := max(i-context, 0), min(i+context+1, len(lines))
+		if len(hunks) == 0 || from > end {
+			hunks = append(hunks, searchHunk{Start: from + 1})
+		} else {
+			from = end
+		}
+		h := &hunks[len(hunks)-1]
+		for j := from; j < to; j++ {
+			h.Lines = append(h.Lines, lines[j])
+			h.Matches = append(h.Matches, false)
+		}
+		h.Matches[i-(h.Start-1)] = true
+		end = to
+	}
+	return hunks
+}
+
+// searchSessions searches each session's text from source and returns the
+// sessions with matches, in the order given. Sessions whose text can't be
+// read are skipped; the first such error is returned alongside the
+// results so a caller can mention it.
+func searchSessions(names []string, source searchSource, re *regexp.Regexp, context int) ([]searchResult, error) {
+	var results []searchResult
+	var firstErr error
+	for _, name := range names {
+		text, err := source(name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if hunks := searchLines(text, re, context); len(hunks) > 0 {
+			results = append(results, searchResult{Session: strings.TrimPrefix(name, sessionPrefix), Hunks: hunks})
+		}
+	}
+	return results, firstErr
+}
+
+// paneSearchSource reads the last lines of a session's pane scrollback, or
+// all of it when lines <= 0.
+func paneSearchSource(tm *TmuxManager, lines int) searchSource {
+	return func(name string) (string, error) {
+		if lines <= 0 {
+			return tm.CaptureScrollback(name)
+		}
+		return tm.CapturePaneOutput(name, lines)
+	}
+}
+
+// logSearchSource reads the last lines of a session's output log (all of
+// it when lines <= 0), with terminal escapes removed and each line cut to
+// what follows its last carriage return, as the terminal showed it.
+func logSearchSource(lines int) searchSource {
+	return func(name string) (string, error) {
+		f, err := os.Open(SessionLogPath(name))
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		var buf bytes.Buffer
+		if err := printTail(&buf, f, lines); err != nil {
+			return "", err
+		}
+		return cleanLogText(buf.String()), nil
+	}
+}
+
+// cleanLogText turns raw pane output into plain lines.
+func cleanLogText(s string) string {
+	s = stripANSI(s)
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		l = strings.TrimRight(l, "\r")
+		if j := strings.LastIndex(l, "\r"); j >= 0 {
+			l = l[j+1:]
+		}
+		lines[i] = l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sessionLogNames lists the sessions with an output log, including ones
+// that have ended, sorted by name.
+func sessionLogNames() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(SessionLogDir(), "*.log"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range paths {
+		name := strings.TrimSuffix(filepath.Base(p), ".log")
+		if name == "cloud-dispatch" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// printSearchResults writes results grep-style: a heading per session,
+// then its hunks with line numbers, ":" marking matches and "-" context,
+// and "--" between hunks.
+func printSearchResults(w io.Writer, results []searchResult, namesOnly bool) {
+	for i, r := range results {
+		if namesOnly {
+			fmt.Fprintln(w, r.Session)
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		matches := fmt.Sprintf("%d matches", r.matchCount())
+		if r.matchCount() == 1 {
+			matches = "1 match"
+		}
+		fmt.Fprintf(w, "== %s (%s)\n", r.Session, matches)
+		for j, h := range r.Hunks {
+			if j > 0 {
+				fmt.Fprintln(w, "--")
+			}
+			for k, line := range h.Lines {
+				sep := "-"
+				if h.Matches[k] {
+					sep = ":"
+				}
+				fmt.Fprintf(w, "%5d%s %s\n", h.Start+k, sep, line)
+			}
+		}
+	}
+}
+
+// --- search ---
+
+func searchCmd() *cobra.Command {
+	var (
+		context    int
+		lines      int
+		ignoreCase bool
+		fixed      bool
+		logs       bool
+		namesOnly  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "search <pattern>",
+		Short: "Search the recent output of all sessions",
+		Long: `Search the recent output of every session for a pattern (a Go regular
+expression) and print the matching lines with context, grouped by session.
+
+By default the live panes' scrollback is searched. --logs searches the
+session output logs instead (requires session_logs.enabled), which also
+covers sessions that have ended.`,
+		Example: `  vibeflow search payments.go
+  vibeflow search -i -C 5 'panic|fatal error'
+  vibeflow search --logs -l 'connection refused'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			re, err := compileSearchPattern(args[0], fixed, ignoreCase)
+			if err != nil {
+				return err
+			}
+			var (
+				names  []string
+				source searchSource
+			)
+			if logs {
+				if names, err = sessionLogNames(); err != nil {
+					return err
+				}
+				source = logSearchSource(lines)
+			} else {
+				cfgPath, _ := cmd.Flags().GetString("config")
+				_, tmux, _, _, _, err := loadComponents(cfgPath)
+				if err != nil {
+					return err
+				}
+				sessions, err := tmux.ListSessions()
+				if err != nil {
+					return err
+				}
+				for _, s := range sessions {
+					if !isWorkbenchHolder(s.Name) {
+						names = append(names, s.Name)
+					}
+				}
+				source = paneSearchSource(tmux, lines)
+			}
+			out := cmd.OutOrStdout()
+			if len(names) == 0 {
+				if logs {
+					fmt.Fprintln(out, "No session logs (is session_logs.enabled set?).")
+				} else {
+					fmt.Fprintln(out, "No active sessions.")
+				}
+				return nil
+			}
+			results, readErr := searchSessions(names, source, re, max(context, 0))
+			if readErr != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: some sessions could not be read: %v\n", readErr)
+			}
+			if len(results) == 0 {
+				fmt.Fprintf(out, "No matches in %s.\n", plural(len(names), "session"))
+				return nil
+			}
+			printSearchResults(out, results, namesOnly)
+			return nil
+		},
+	}
+	cmd.Flags().IntVarP(&context, "context", "C", 2, "Lines of context to show around each match")
+	cmd.Flags().IntVarP(&lines, "lines", "n", defaultSearchLines, "Lines of recent output to search per session (0 = all)")
+	cmd.Flags().BoolVarP(&ignoreCase, "ignore-case", "i", false, "Match case-insensitively")
+	cmd.Flags().BoolVarP(&fixed, "fixed-strings", "F", false, "Treat the pattern as a literal string")
+	cmd.Flags().BoolVar(&logs, "logs", false, "Search the session output logs instead of the live panes")
+	cmd.Flags().BoolVarP(&namesOnly, "sessions-only", "l", false, "Print only the names of sessions with matches")
+	return cmd
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCompileSearchPattern(t *testing.T) {
+	re, err := compileSearchPattern("payments.go", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if re.MatchString("paymentsXgo") || !re.MatchString("edit payments.go") {
+		t.Error("fixed pattern should match only the literal string")
+	}
+	re, _ = compileSearchPattern("panic|fatal", false, true)
+	if !re.MatchString("FATAL error") {
+		t.Error("ignore-case regexp should match FATAL")
+	}
+	if _, err := compileSearchPattern("(", false, false); err == nil {
+		t.Error("want an error for an invalid pattern")
+	}
+}
+
+func TestSearchLines_MergesOverlappingContext(t *testing.T) {
+	text := "a\nhit1\nb\nhit2\nc\nd\ne\nf\nhit3\ng"
+	re, _ := compileSearchPattern("hit", false, false)
+	hunks := searchLines(text, re, 1)
+	want := []searchHunk{
+		{Start: 1, Lines: []string{"a", "hit1", "b", "hit2", "c"}, Matches: []bool{false, true, false, true, false}},
+		{Start: 8, Lines: []string{"f", "hit3", "g"}, Matches: []bool{false, true, false}},
+	}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Fatalf("hunks = %+v\nwant %+v", hunks, want)
+	}
+	if got := searchLines(text, re, 0); len(got) != 3 {
+		t.Errorf("no context: %d hunks, want 3", len(got))
+	}
+	if got := searchLines(text, regexpMust(t, "nothing"), 2); got != nil {
+		t.Errorf("no match: %+v", got)
+	}
+}
+
+func regexpMust(t *testing.T, pattern string) *regexp.Regexp {
+	t.Helper()
+	re, err := compileSearchPattern(pattern, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return re
+}
+
+func TestSearchSessions_SkipsUnreadableAndUnmatched(t *testing.T) {
+	texts := map[string]string{
+		"vibeflow_alpha": "edited payments.go\ndone",
+		"vibeflow_beta":  "nothing here",
+		"vibeflow_gamma": "payments.go: undefined: Charge",
+	}
+	source := func(name string) (string, error) {
+		text, ok := texts[name]
+		if !ok {
+			return "", errors.New("no such session")
+		}
+		return text, nil
+	}
+	re := regexpMust(t, `payments\.go`)
+	results, err := searchSessions([]string{"vibeflow_alpha", "vibeflow_beta", "vibeflow_gone", "vibeflow_gamma"}, source, re, 0)
+	if err == nil {
+		t.Error("want the read error of the missing session")
+	}
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Session)
+	}
+	if !reflect.DeepEqual(names, []string{"alpha", "gamma"}) {
+		t.Fatalf("matched sessions = %v, want [alpha gamma]", names)
+	}
+}
+
+func TestCleanLogText(t *testing.T) {
+	raw := "\x1b[?25l\x1b[32mok\x1b[0m build\r\n\x1b]0;title\x07spinner 1\rspinner 2\rdone\r\n"
+	if got, want := cleanLogText(raw), "ok build\ndone"; got != want {
+		t.Fatalf("cleanLogText = %q, want %q", got, want)
+	}
+}
+
+func TestLogSearchSource_AndLogNames(t *testing.T) {
+	origRoot := rootDir
+	t.Cleanup(func() { rootDir = origRoot })
+	SetRootDir(t.TempDir())
+	if err := os.MkdirAll(SessionLogDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{
+		"claude-b.log":       "old\n\x1b[31mpanic: boom\x1b[0m\r\n",
+		"claude-a.log":       "fine\n",
+		"cloud-dispatch.log": "panic: not a session\n",
+	} {
+		if err := os.WriteFile(filepath.Join(SessionLogDir(), name), []byte(body), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names, err := sessionLogNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"claude-a", "claude-b"}) {
+		t.Fatalf("sessionLogNames = %v", names)
+	}
+	text, err := logSearchSource(1)("vibeflow_claude-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "panic: boom" {
+		t.Fatalf("last log line = %q, want the cleaned panic", text)
+	}
+}
+
+func TestPrintSearchResults(t *testing.T) {
+	results := []searchResult{
+		{Session: "alpha", Hunks: []searchHunk{
+			{Start: 3, Lines: []string{"before", "payments.go"}, Matches: []bool{false, true}},
+			{Start: 9, Lines: []string{"payments.go again"}, Matches: []bool{true}},
+		}},
+		{Session: "beta", Hunks: []searchHunk{{Start: 1, Lines: []string{"payments.go"}, Matches: []bool{true}}}},
+	}
+	var buf bytes.Buffer
+	printSearchResults(&buf, results, false)
+	want := strings.Join([]string{
+		"== alpha (2 matches)",
+		"    3- before",
+		"    4: payments.go",
+		"--",
+		"    9: payments.go again",
+		"",
+		"== beta (1 match)",
+		"    1: payments.go",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Fatalf("output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	printSearchResults(&buf, results, true)
+	if buf.String() != "alpha\nbeta\n" {
+		t.Fatalf("names only: %q", buf.String())
+	}
+}
@@ -0,0 +1,351 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// secretService is the service/target name secrets are stored under in the
+// OS keychain.
+const secretService = "vibeflow-cli"
+
+// secretRefPrefix marks a config value that lives in the secret store; the
+// rest of the value is the secret's key (e.g. "secret:api_token").
+const secretRefPrefix = "secret:"
+
+// ErrSecretNotFound is returned by SecretStore.Get for unknown keys.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore persists secrets outside the plaintext config file.
+type SecretStore interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+	Name() string
+}
+
+// newSecretStore returns the secret store for the config in dir. The OS
+// keychain is preferred; the encrypted file in dir is used when no keychain
+// is available or it fails (headless Linux without a secret service, CI).
+// VIBEFLOW_SECRET_STORE=file forces the file store. Keychain entries are
+// namespaced by dir so separate roots don't share secrets.
+func newSecretStore(dir string) SecretStore {
+	file := &fileSecretStore{dir: dir}
+	if os.Getenv("VIBEFLOW_SECRET_STORE") == "file" {
+		return file
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	if kc := newKeychainStore(abs); kc != nil {
+		return &fallbackSecretStore{primary: kc, fallback: file}
+	}
+	return file
+}
+
+// fallbackSecretStore writes to primary, falling back to the file store when
+// the keychain is unusable, and reads from whichever holds the key.
+type fallbackSecretStore struct {
+	primary  SecretStore
+	fallback SecretStore
+}
+
+func (s *fallbackSecretStore) Name() string { return s.primary.Name() }
+
+func (s *fallbackSecretStore) Get(key string) (string, error) {
+	v, err := s.primary.Get(key)
+	if err == nil {
+		return v, nil
+	}
+	return s.fallback.Get(key)
+}
+
+func (s *fallbackSecretStore) Set(key, value string) error {
+	if err := s.primary.Set(key, value); err == nil {
+		_ = s.fallback.Delete(key) // drop any older copy
+		return nil
+	}
+	return s.fallback.Set(key, value)
+}
+
+func (s *fallbackSecretStore) Delete(key string) error {
+	err1 := s.primary.Delete(key)
+	err2 := s.fallback.Delete(key)
+	if err1 != nil && err2 != nil {
+		return err2
+	}
+	return nil
+}
+
+// fileSecretStore keeps secrets in <dir>/secrets.enc, AES-256-GCM encrypted
+// with a random key in <dir>/secrets.key (both 0600). It keeps tokens out of
+// config.yaml — which gets copied, shared and backed up — but it is only as
+// private as the key file, unlike a keychain.
+type fileSecretStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func (s *fileSecretStore) Name() string { return "encrypted file" }
+
+func (s *fileSecretStore) keyPath() string  { return filepath.Join(s.dir, "secrets.key") }
+func (s *fileSecretStore) dataPath() string { return filepath.Join(s.dir, "secrets.enc") }
+
+// aead loads the encryption key, creating it when create is set.
+func (s *fileSecretStore) aead(create bool) (cipher.AEAD, error) {
+	key, err := os.ReadFile(s.keyPath())
+	if os.IsNotExist(err) && create {
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, fmt.Errorf("generate secrets key: %w", err)
+		}
+		if err := os.MkdirAll(s.dir, 0755); err != nil {
+			return nil, fmt.Errorf("create secrets dir: %w", err)
+		}
+		if err := os.WriteFile(s.keyPath(), key, 0600); err != nil {
+			return nil, fmt.Errorf("write secrets key: %w", err)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secrets key %s is corrupt", s.keyPath())
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *fileSecretStore) load(create bool) (map[string]string, cipher.AEAD, error) {
+	gcm, err := s.aead(create)
+	if err != nil {
+		return nil, nil, err
+	}
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(s.dataPath())
+	if os.IsNotExist(err) {
+		return secrets, gcm, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read secrets: %w", err)
+	}
+	n := gcm.NonceSize()
+	if len(data) < n {
+		return nil, nil, fmt.Errorf("secrets file %s is corrupt", s.dataPath())
+	}
+	plain, err := gcm.Open(nil, data[:n], data[n:], nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decrypt secrets: %w", err)
+	}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, nil, fmt.Errorf("parse secrets: %w", err)
+	}
+	return secrets, gcm, nil
+}
+
+func (s *fileSecretStore) save(secrets map[string]string, gcm cipher.AEAD) error {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.dataPath(), gcm.Seal(nonce, nonce, plain, nil), 0600); err != nil {
+		return fmt.Errorf("write secrets: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSecretStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets, _, err := s.load(false)
+	if os.IsNotExist(err) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	v, ok := secrets[key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return v, nil
+}
+
+func (s *fileSecretStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets, gcm, err := s.load(true)
+	if err != nil {
+		return err
+	}
+	secrets[key] = value
+	return s.save(secrets, gcm)
+}
+
+func (s *fileSecretStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets, gcm, err := s.load(false)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+	delete(secrets, key)
+	return s.save(secrets, gcm)
+}
+
+// --- config integration ---
+
+// apiTokenSecretKey is the secret-store key for Config.APIToken; saved env
+// vars use envSecretKey.
+const apiTokenSecretKey = "api_token"
+
+func envSecretKey(name string) string { return "env/" + name }
+
+func profileSecretKey(profile, name string) string { return "profile/" + profile + "/" + name }
+
+// isSecretRef reports whether a config value is a secret-store reference.
+func isSecretRef(v string) bool { return strings.HasPrefix(v, secretRefPrefix) }
+
+// resolveSecrets replaces secret references in cfg with their values and
+// reports whether cfg still holds plaintext secrets that should be migrated
+// into the store (by saving the config). A reference whose secret is missing
+// (keychain entry deleted, config copied to another machine) resolves to ""
+// so the CLI still starts and the value can be re-entered.
+func resolveSecrets(cfg *Config, store SecretStore) (migrate bool) {
+	resolve := func(v string) string {
+		if v == "" {
+			return v
+		}
+		if !isSecretRef(v) {
+			migrate = true
+			return v
+		}
+		s, err := store.Get(strings.TrimPrefix(v, secretRefPrefix))
+		if err != nil {
+			return ""
+		}
+		return s
+	}
+	cfg.APIToken = resolve(cfg.APIToken)
+	for name, v := range cfg.SavedEnvVars {
+		cfg.SavedEnvVars[name] = resolve(v)
+	}
+	for _, p := range cfg.Profiles {
+		for name, v := range p.Env {
+			p.Env[name] = resolve(v)
+		}
+	}
+	return migrate
+}
+
+// withSecretRefs stores cfg's secrets and returns a copy of cfg in which they
+// are replaced by references, ready to be written to disk. cfg itself is not
+// modified. Only secrets whose value changed are written, and the secrets
+// of previous (the config on disk) that are no longer referenced are
+// deleted. A secret that cannot be stored is kept in plaintext rather than
+// lost.
+func withSecretRefs(cfg, previous *Config, store SecretStore) (*Config, error) {
+	out := *cfg
+	var errs []error
+	stash := func(key, v string) string {
+		if v == "" || isSecretRef(v) {
+			return v
+		}
+		if cur, err := store.Get(key); err == nil && cur == v {
+			return secretRefPrefix + key
+		}
+		if err := store.Set(key, v); err != nil {
+			errs = append(errs, fmt.Errorf("store secret %q: %w", key, err))
+			return v
+		}
+		return secretRefPrefix + key
+	}
+	out.APIToken = stash(apiTokenSecretKey, cfg.APIToken)
+	if cfg.SavedEnvVars != nil {
+		out.SavedEnvVars = make(map[string]string, len(cfg.SavedEnvVars))
+		for name, v := range cfg.SavedEnvVars {
+			out.SavedEnvVars[name] = stash(envSecretKey(name), v)
+		}
+	}
+	if cfg.Profiles != nil {
+		out.Profiles = make(map[string]CredentialProfile, len(cfg.Profiles))
+		for profile, p := range cfg.Profiles {
+			env := make(map[string]string, len(p.Env))
+			for name, v := range p.Env {
+				env[name] = stash(profileSecretKey(profile, name), v)
+			}
+			p.Env = env
+			out.Profiles[profile] = p
+		}
+	}
+	if previous != nil {
+		kept := secretRefKeys(&out)
+		for key := range secretRefKeys(previous) {
+			if kept[key] {
+				continue
+			}
+			if err := store.Delete(key); err != nil && !errors.Is(err, ErrSecretNotFound) {
+				errs = append(errs, fmt.Errorf("delete secret %q: %w", key, err))
+			}
+		}
+	}
+	return &out, errors.Join(errs...)
+}
+
+// secretRefKeys returns the keys of the secret references in cfg.
+func secretRefKeys(cfg *Config) map[string]bool {
+	keys := make(map[string]bool)
+	add := func(v string) {
+		if isSecretRef(v) {
+			keys[strings.TrimPrefix(v, secretRefPrefix)] = true
+		}
+	}
+	add(cfg.APIToken)
+	for _, v := range cfg.SavedEnvVars {
+		add(v)
+	}
+	for _, p := range cfg.Profiles {
+		for _, v := range p.Env {
+			add(v)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,60 @@
+//go:build darwin
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// keychainStore stores secrets as generic passwords in the macOS login
+// keychain via the `security` tool.
+type keychainStore struct {
+	namespace string // prefixed to each key so roots don't collide
+}
+
+func newKeychainStore(namespace string) SecretStore {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil
+	}
+	return &keychainStore{namespace: namespace}
+}
+
+func (k *keychainStore) Name() string { return "macOS Keychain" }
+
+func (k *keychainStore) account(key string) string { return k.namespace + ":" + key }
+
+func (k *keychainStore) Get(key string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", secretService, "-a", k.account(key), "-w").Output()
+	if err != nil {
+		return "", ErrSecretNotFound
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set feeds the command to `security -i` on stdin so the secret never
+// appears in the process list.
+func (k *keychainStore) Set(key, value string) error {
+	line := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		securityQuote(secretService), securityQuote(k.account(key)), securityQuote(value))
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(line)
+	if out, err := cmd.CombinedOutput(); err != nil || len(strings.TrimSpace(string(out))) > 0 {
+		return fmt.Errorf("security add-generic-password: %s %v", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func (k *keychainStore) Delete(key string) error {
+	if err := exec.Command("security", "delete-generic-password", "-s", secretService, "-a", k.account(key)).Run(); err != nil {
+		return ErrSecretNotFound
+	}
+	return nil
+}
+
+// securityQuote quotes s for `security -i`, which splits its input like a
+// shell command line with double-quoted strings.
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// keychainStore stores secrets in the freedesktop Secret Service (GNOME
+// Keyring, KWallet) via libsecret's `secret-tool`.
+type keychainStore struct {
+	namespace string // stored as an attribute so roots don't collide
+}
+
+// newKeychainStore returns nil when secret-tool is missing or there is no
+// D-Bus session to reach a secret service on (SSH sessions, containers).
+func newKeychainStore(namespace string) SecretStore {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil
+	}
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil
+	}
+	return &keychainStore{namespace: namespace}
+}
+
+func (k *keychainStore) Name() string { return "Secret Service (libsecret)" }
+
+func (k *keychainStore) attrs(key string) []string {
+	return []string{"service", secretService, "root", k.namespace, "key", key}
+}
+
+func (k *keychainStore) Get(key string) (string, error) {
+	out, err := exec.Command("secret-tool", append([]string{"lookup"}, k.attrs(key)...)...).Output()
+	if err != nil || len(out) == 0 {
+		return "", ErrSecretNotFound
+	}
+	return string(out), nil
+}
+
+// Set passes the secret on stdin so it never appears in the process list.
+func (k *keychainStore) Set(key, value string) error {
+	args := append([]string{"store", "--label=" + secretService + " " + key}, k.attrs(key)...)
+	cmd := exec.Command("secret-tool", args...)
+	cmd.Stdin = strings.NewReader(value)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("secret-tool store: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func (k *keychainStore) Delete(key string) error {
+	return exec.Command("secret-tool", append([]string{"clear"}, k.attrs(key)...)...).Run()
+}
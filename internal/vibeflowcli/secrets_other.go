@@ -0,0 +1,9 @@
+//go:build !darwin && !linux && !windows
+
+package vibeflowcli
+
+// newKeychainStore has no keychain integration on this platform; secrets go
+// to the encrypted file store.
+func newKeychainStore(namespace string) SecretStore {
+	return nil
+}
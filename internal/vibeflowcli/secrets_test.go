@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain keeps the suite away from the developer's real keychain: every
+// SaveConfig in the tests stores secrets, and they must land in the test's
+// temp dir.
+func TestMain(m *testing.M) {
+	os.Setenv("VIBEFLOW_SECRET_STORE", "file")
+	os.Exit(m.Run())
+}
+
+func TestFileSecretStore_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s := &fileSecretStore{dir: dir}
+	if _, err := s.Get("api_token"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Get on empty store = %v, want ErrSecretNotFound", err)
+	}
+	if err := s.Set("api_token", "tok-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("env/GEMINI_API_KEY", "g-2"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := s.Get("api_token"); err != nil || v != "tok-1" {
+		t.Errorf("Get = %q, %v", v, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "secrets.enc"))
+	if strings.Contains(string(data), "tok-1") {
+		t.Error("secrets.enc must not contain plaintext")
+	}
+	for _, f := range []string{"secrets.enc", "secrets.key"} {
+		if info, err := os.Stat(filepath.Join(dir, f)); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("%s mode = %v, %v; want 0600", f, info.Mode().Perm(), err)
+		}
+	}
+	if err := s.Delete("api_token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("api_token"); !errors.Is(err, ErrSecretNotFound) {
+		t.Error("deleted secret still readable")
+	}
+	if v, _ := s.Get("env/GEMINI_API_KEY"); v != "g-2" {
+		t.Errorf("other secret = %q after delete, want g-2", v)
+	}
+}
+
+func TestSaveConfig_StoresSecretsOutsideYAML(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	cfg := DefaultConfig()
+	cfg.APIToken = "saved-token"
+	cfg.SavedEnvVars = map[string]string{"GEMINI_API_KEY": "gem-key"}
+
+	if err := SaveConfig(cfg, cfgPath); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIToken != "saved-token" || cfg.SavedEnvVars["GEMINI_API_KEY"] != "gem-key" {
+		t.Error("SaveConfig must not modify the in-memory config")
+	}
+	data, _ := os.ReadFile(cfgPath)
+	for _, plain := range []string{"saved-token", "gem-key"} {
+		if strings.Contains(string(data), plain) {
+			t.Errorf("config.yaml contains plaintext %q:\n%s", plain, data)
+		}
+	}
+	if !strings.Contains(string(data), "secret:api_token") || !strings.Contains(string(data), "secret:env/GEMINI_API_KEY") {
+		t.Errorf("config.yaml missing secret references:\n%s", data)
+	}
+
+	loaded, err := LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.APIToken != "saved-token" || loaded.SavedEnvVars["GEMINI_API_KEY"] != "gem-key" {
+		t.Errorf("loaded secrets = %q / %v", loaded.APIToken, loaded.SavedEnvVars)
+	}
+}
+
+// countingStore counts the writes that reach a file store.
+type countingStore struct {
+	*fileSecretStore
+	sets int
+}
+
+func (s *countingStore) Set(key, value string) error {
+	s.sets++
+	return s.fileSecretStore.Set(key, value)
+}
+
+func TestWithSecretRefs_WritesChangedAndDeletesUnreferenced(t *testing.T) {
+	store := &countingStore{fileSecretStore: &fileSecretStore{dir: t.TempDir()}}
+	cfg := &Config{APIToken: "tok", SavedEnvVars: map[string]string{"GEMINI_API_KEY": "gem", "QWEN_API_KEY": "qw"}}
+	first, err := withSecretRefs(cfg, nil, store)
+	if err != nil || store.sets != 3 {
+		t.Fatalf("first save: %d writes, %v", store.sets, err)
+	}
+
+	store.sets = 0
+	delete(cfg.SavedEnvVars, "GEMINI_API_KEY")
+	cfg.SavedEnvVars["QWEN_API_KEY"] = "qw-2"
+	if _, err := withSecretRefs(cfg, first, store); err != nil {
+		t.Fatal(err)
+	}
+	if store.sets != 1 {
+		t.Errorf("%d writes, want only the changed secret", store.sets)
+	}
+	if _, err := store.Get("env/GEMINI_API_KEY"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("removed secret still stored: %v", err)
+	}
+	if v, _ := store.Get("env/QWEN_API_KEY"); v != "qw-2" {
+		t.Errorf("changed secret = %q", v)
+	}
+	if v, _ := store.Get("api_token"); v != "tok" {
+		t.Errorf("unchanged secret = %q", v)
+	}
+}
+
+func TestLoadConfig_MigratesPlaintextSecrets(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	legacy := "api_token: old-plain\nsaved_env_vars:\n  QWEN_API_KEY: q-plain\n"
+	if err := os.WriteFile(cfgPath, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIToken != "old-plain" || cfg.SavedEnvVars["QWEN_API_KEY"] != "q-plain" {
+		t.Errorf("loaded = %q / %v", cfg.APIToken, cfg.SavedEnvVars)
+	}
+	data, _ := os.ReadFile(cfgPath)
+	if strings.Contains(string(data), "old-plain") || strings.Contains(string(data), "q-plain") {
+		t.Errorf("plaintext secrets not migrated:\n%s", data)
+	}
+}
+
+func TestResolveSecrets_MissingSecretIsEmpty(t *testing.T) {
+	cfg := &Config{APIToken: "secret:api_token"}
+	if migrate := resolveSecrets(cfg, &fileSecretStore{dir: t.TempDir()}); migrate {
+		t.Error("references alone need no migration")
+	}
+	if cfg.APIToken != "" {
+		t.Errorf("APIToken = %q, want empty for a missing secret", cfg.APIToken)
+	}
+}
+
+// failingStore simulates an unusable keychain.
+type failingStore struct{}
+
+func (failingStore) Get(string) (string, error) { return "", errors.New("locked") }
+func (failingStore) Set(string, string) error   { return errors.New("locked") }
+func (failingStore) Delete(string) error        { return errors.New("locked") }
+func (failingStore) Name() string               { return "failing" }
+
+func TestFallbackSecretStore_UsesFileWhenKeychainFails(t *testing.T) {
+	s := &fallbackSecretStore{primary: failingStore{}, fallback: &fileSecretStore{dir: t.TempDir()}}
+	if err := s.Set("api_token", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := s.Get("api_token"); err != nil || v != "x" {
+		t.Errorf("Get = %q, %v", v, err)
+	}
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// dashboardSampleInterval matches the TUI's activity sampling cadence.
+const dashboardSampleInterval = 3 * time.Second
+
+// dashboardSession is one session as shown by the web dashboard and
+// returned by /api/sessions.
+type dashboardSession struct {
+	Name       string `json:"name"`
+	Provider   string `json:"provider,omitempty"`
+	Project    string `json:"project,omitempty"`
+	Persona    string `json:"persona,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	WorkingDir string `json:"working_dir,omitempty"`
+	Status     string `json:"status"`
+	Attached   bool   `json:"attached"`
+	ExitStatus string `json:"exit_status,omitempty"`
+	Output     string `json:"output"`
+}
+
+// dashboard serves a read-only view of the sessions: the same tmux and
+// store data the TUI lists, with activity sampled in the background.
+type dashboard struct {
+	tmux        *TmuxManager
+	store       *Store
+	activity    *ActivityMonitor
+	token       string
+	outputLines int // 0 leaves Output empty
+	// localOnly rejects requests not addressed to localhost, so a web page
+	// can't read an unprotected dashboard through DNS rebinding.
+	localOnly bool
+}
+
+// sessions lists the live sessions with their status and output tail.
+func (d *dashboard) sessions() ([]dashboardSession, error) {
+	live, err := d.tmux.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	metas := make(map[string]SessionMeta)
+	if list, err := d.store.List(); err == nil {
+		for _, meta := range list {
+			metas[meta.TmuxSession] = meta
+		}
+	}
+	var out []dashboardSession
+	for _, ts := range live {
+		if isWorkbenchHolder(ts.Name) {
+			continue
+		}
+		s := dashboardSession{
+			Name:       strings.TrimPrefix(ts.Name, sessionPrefix),
+			Status:     sessionStatus(ts.Attached, ts.PaneDead),
+			Attached:   ts.Attached,
+			ExitStatus: ts.ExitStatus,
+		}
+		if meta, ok := metas[ts.Name]; ok {
+			s.Provider, s.Project, s.Persona = meta.Provider, meta.Project, meta.Persona
+			s.Branch, s.WorkingDir = meta.Branch, meta.WorkingDir
+		}
+		if s.Status != "exited" {
+			if st := d.activity.State(s.Name); st != ActivityUnknown {
+				s.Status = st.String()
+			}
+		}
+		if d.outputLines > 0 {
+			if output, err := d.tmux.CapturePaneOutput(ts.Name, d.outputLines); err == nil {
+				s.Output = strings.TrimRight(stripANSI(output), "\n ")
+			}
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// sample feeds one activity sample of every live pane to the monitor.
+func (d *dashboard) sample(now time.Time) {
+	live, err := d.tmux.ListSessions()
+	if err != nil {
+		return
+	}
+	names := make([]string, 0, len(live))
+	for _, ts := range live {
+		if ts.PaneDead || isWorkbenchHolder(ts.Name) {
+			continue
+		}
+		name := strings.TrimPrefix(ts.Name, sessionPrefix)
+		names = append(names, name)
+		if output, cursor, err := d.tmux.CaptureActivitySample(name, 30); err == nil {
+			d.activity.Observe(name, stripANSI(output), cursor, now)
+		}
+	}
+	d.activity.Prune(names)
+}
+
+// sampleLoop samples activity until ctx is done.
+func (d *dashboard) sampleLoop(ctx context.Context) {
+	ticker := time.NewTicker(dashboardSampleInterval)
+	defer ticker.Stop()
+	d.sample(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			d.sample(now)
+		}
+	}
+}
+
+// authorized checks the access token, given as ?token= or a bearer header.
+func (d *dashboard) authorized(r *http.Request) bool {
+	if d.token == "" {
+		return true
+	}
+	given := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		given = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(d.token)) == 1
+}
+
+// localHost reports whether the Host header of r names the local machine.
+func localHost(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = strings.Trim(r.Host, "[]")
+	}
+	return isLoopback(host)
+}
+
+// handler returns the dashboard's routes. Everything is read-only: only
+// GET and HEAD are accepted.
+func (d *dashboard) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.serveIndex)
+	mux.HandleFunc("/api/sessions", d.serveAPI)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "read-only dashboard", http.StatusMethodNotAllowed)
+			return
+		}
+		if d.localOnly && !localHost(r) {
+			http.Error(w, "dashboard only serves localhost", http.StatusForbidden)
+			return
+		}
+		if !d.authorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (d *dashboard) serveAPI(w http.ResponseWriter, r *http.Request) {
+	sessions, err := d.sessions()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sessions)
+}
+
+func (d *dashboard) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	sessions, err := d.sessions()
+	data := struct {
+		Sessions []dashboardSession
+		Err      error
+		Updated  string
+	}{sessions, err, time.Now().Format("15:04:05")}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboardTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="10">
+<title>vibeflow</title>
+<style>
+body { background: #0f1419; color: #d5dde5; font: 14px/1.4 -apple-system, system-ui, sans-serif; margin: 0; padding: 12px; }
+h1 { font-size: 18px; margin: 0 0 12px; }
+.meta { color: #7a8794; font-size: 12px; }
+.card { background: #182028; border-radius: 8px; padding: 10px 12px; margin-bottom: 12px; }
+.name { font-weight: 600; }
+.status { float: right; font-size: 12px; padding: 1px 8px; border-radius: 10px; background: #2a3440; }
+.working { color: #7fd88f; } .waiting { color: #f0c05a; } .idle { color: #7a8794; } .exited { color: #f07070; }
+pre { background: #0b0f13; border-radius: 6px; padding: 8px; margin: 8px 0 0; overflow-x: auto; font-size: 11px; white-space: pre-wrap; word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>vibeflow <span class="meta">updated {{.Updated}}</span></h1>
+{{if .Err}}<p class="exited">{{.Err}}</p>{{end}}
+{{range .Sessions}}
+<div class="card">
+<span class="status {{.Status}}">{{.Status}}{{if .ExitStatus}} ({{.ExitStatus}}){{end}}</span>
+<div class="name">{{.Name}}</div>
+<div class="meta">{{.Provider}}{{if .Persona}} · {{.Persona}}{{end}}{{if .Project}} · {{.Project}}{{end}}{{if .Branch}} · {{.Branch}}{{end}}{{if .Attached}} · attached{{end}}</div>
+{{if .Output}}<pre>{{.Output}}</pre>{{end}}
+</div>
+{{else}}
+{{if not .Err}}<p class="meta">No sessions running.</p>{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// isLoopback reports whether bind only listens on the local machine.
+func isLoopback(bind string) bool {
+	if bind == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(bind)
+	return ip != nil && ip.IsLoopback()
+}
+
+// --- serve ---
+
+func serveCmd() *cobra.Command {
+	var (
+		port  int
+		bind  string
+		token string
+		lines int
+	)
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a read-only web dashboard of the sessions",
+		Long: `Start a small HTTP server with a read-only dashboard: every session with its
+provider, persona, branch, status and the last lines of its output. The page
+refreshes itself every 10s; /api/sessions returns the same data as JSON.
+
+By default the dashboard only listens on localhost and, without a token, only
+answers requests addressed to localhost. To reach it from another
+device use --bind 0.0.0.0 together with --token (or VIBEFLOW_DASHBOARD_TOKEN),
+and open http://<host>:<port>/?token=<token>.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			_, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				token = os.Getenv("VIBEFLOW_DASHBOARD_TOKEN")
+			}
+			if token == "" && !isLoopback(bind) {
+				fmt.Fprintf(os.Stderr, "Warning: serving on %s without --token; anyone who can reach it sees your sessions' output\n", bind)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			d := &dashboard{
+				tmux:        tmux,
+				store:       store,
+				activity:    NewActivityMonitor(0),
+				token:       token,
+				outputLines: lines,
+				localOnly:   token == "" && isLoopback(bind),
+			}
+			go d.sampleLoop(ctx)
+
+			addr := net.JoinHostPort(bind, strconv.Itoa(port))
+			srv := &http.Server{Addr: addr, Handler: d.handler(), ReadHeaderTimeout: 10 * time.Second}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+			fmt.Fprintf(cmd.OutOrStdout(), "Dashboard at http://%s/ (Ctrl+C to stop)\n", addr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&port, "port", 8080, "Port to listen on")
+	cmd.Flags().StringVar(&bind, "bind", "127.0.0.1", "Address to listen on (0.0.0.0 for all interfaces)")
+	cmd.Flags().StringVar(&token, "token", "", "Require this token (?token= or Authorization: Bearer) to view the dashboard")
+	cmd.Flags().IntVar(&lines, "lines", 20, "Lines of output shown per session")
+	return cmd
+}
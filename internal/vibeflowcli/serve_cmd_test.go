@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsLoopback(t *testing.T) {
+	for bind, want := range map[string]bool{"127.0.0.1": true, "localhost": true, "::1": true, "0.0.0.0": false, "192.168.1.5": false} {
+		if got := isLoopback(bind); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", bind, got, want)
+		}
+	}
+}
+
+func TestDashboard_LocalOnly(t *testing.T) {
+	d := &dashboard{localOnly: true}
+	srv := httptest.NewServer(d.handler())
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	for host, want := range map[string]int{
+		"127.0.0.1:" + port:          http.StatusNotFound,
+		"localhost:" + port:          http.StatusNotFound,
+		"[::1]:" + port:              http.StatusNotFound,
+		"localhost":                  http.StatusNotFound,
+		"rebind.example.com:" + port: http.StatusForbidden,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/missing", nil)
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Host %q = %d, want %d", host, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestDashboard_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-serve")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-dash", "echo hello-dashboard; sleep 30"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "sessions.json"))
+	if err := store.Add(SessionMeta{Name: "claude-dash", TmuxSession: "vibeflow_claude-dash", Provider: "claude", Persona: "developer", Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	d := &dashboard{tmux: tm, store: store, activity: NewActivityMonitor(0), token: "s3cret", outputLines: 10}
+	d.sample(time.Now())
+	srv := httptest.NewServer(d.handler())
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if code, _ := get("/"); code != http.StatusUnauthorized {
+		t.Errorf("GET / without token = %d, want 401", code)
+	}
+	resp, err := http.Post(srv.URL+"/api/sessions?token=s3cret", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want 405", resp.StatusCode)
+	}
+
+	var sessions []dashboardSession
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		code, body := get("/api/sessions?token=s3cret")
+		if code != http.StatusOK {
+			t.Fatalf("GET /api/sessions = %d: %s", code, body)
+		}
+		if err := json.Unmarshal([]byte(body), &sessions); err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions) == 1 && strings.Contains(sessions[0].Output, "hello-dashboard") || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if len(sessions) != 1 || sessions[0].Name != "claude-dash" || sessions[0].Persona != "developer" {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+	if !strings.Contains(sessions[0].Output, "hello-dashboard") {
+		t.Errorf("output = %q, want the pane's output", sessions[0].Output)
+	}
+
+	code, page := get("/?token=s3cret")
+	if code != http.StatusOK || !strings.Contains(page, "claude-dash") || !strings.Contains(page, "hello-dashboard") {
+		t.Errorf("GET / = %d, page missing the session:\n%s", code, page)
+	}
+}
@@ -18,6 +18,7 @@ package vibeflowcli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -75,10 +76,11 @@ func (c *SessionCache) Remove(name string) error {
 	return err
 }
 
-// List returns all cached session entries.
+// List returns all cached session entries. It takes the lock so it never
+// reads a file mid-update, but does not write anything back.
 func (c *SessionCache) List() ([]SessionMeta, error) {
 	entries, err := c.withLock(func(entries []SessionMeta) ([]SessionMeta, error) {
-		return entries, nil
+		return entries, errUnchanged
 	})
 	if err != nil {
 		return nil, err
@@ -128,7 +130,8 @@ func (c *SessionCache) DeadSessions(activeTmux []string) ([]SessionMeta, error)
 }
 
 // withLock acquires an exclusive file lock, reads the current entries,
-// calls fn with them, and writes the result back.
+// calls fn with them, and writes the result back atomically. fn returns
+// errUnchanged to skip the write.
 func (c *SessionCache) withLock(fn func([]SessionMeta) ([]SessionMeta, error)) ([]SessionMeta, error) {
 	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
 		return nil, fmt.Errorf("create cache dir: %w", err)
@@ -152,6 +155,9 @@ func (c *SessionCache) withLock(fn func([]SessionMeta) ([]SessionMeta, error)) (
 	}
 
 	result, err := fn(entries)
+	if errors.Is(err, errUnchanged) {
+		return result, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -186,5 +192,5 @@ func (c *SessionCache) writeFile(entries []SessionMeta) error {
 	if err != nil {
 		return fmt.Errorf("marshal cache: %w", err)
 	}
-	return os.WriteFile(c.path, data, 0600)
+	return writeFileAtomic(c.path, data, 0600)
 }
@@ -17,8 +17,11 @@
 package vibeflowcli
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -305,3 +308,48 @@ func TestSessionCache_PersistsNewFields(t *testing.T) {
 		t.Errorf("OpenShell metadata not preserved: %+v", got.OpenShell)
 	}
 }
+
+func TestSessionCache_ConcurrentWritersKeepEveryEntry(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session_cache.json")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := NewSessionCacheWithPath(path)
+			if err := c.Add(SessionMeta{Name: fmt.Sprintf("s%d", i), TmuxSession: fmt.Sprintf("vibeflow_s%d", i)}); err != nil {
+				t.Error(err)
+			}
+			// A GC that sees every entry as live must not drop a concurrent Add.
+			if err := c.GC([]string{"vibeflow_s0", "vibeflow_s1", "vibeflow_s2", "vibeflow_s3", "vibeflow_s4", "vibeflow_s5", "vibeflow_s6", "vibeflow_s7"}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entries, err := NewSessionCacheWithPath(path).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 8 {
+		t.Errorf("got %d entries after concurrent writes, want 8", len(entries))
+	}
+	files, _ := os.ReadDir(dir)
+	for _, f := range files {
+		if strings.Contains(f.Name(), ".tmp-") {
+			t.Errorf("temp file %s left behind", f.Name())
+		}
+	}
+}
+
+func TestSessionCache_ListDoesNotCreateFile(t *testing.T) {
+	c := testCache(t)
+	if _, err := c.List(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(c.path); !os.IsNotExist(err) {
+		t.Errorf("List must not create the cache file; stat err=%v", err)
+	}
+}
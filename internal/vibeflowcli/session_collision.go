@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CollisionAction is how a launch resolves a tmux session that already
+// has the name it wants.
+type CollisionAction string
+
+// Session collision actions (launch --on-collision, config session_collision).
+const (
+	CollisionAdopt  CollisionAction = "adopt"  // keep the running session and treat it as this launch's
+	CollisionKill   CollisionAction = "kill"   // stop the running session, then launch
+	CollisionSuffix CollisionAction = "suffix" // launch under the next free name-2, name-3, ...
+	CollisionFail   CollisionAction = "fail"   // give up on this launch
+)
+
+// collisionActions maps --on-collision and session_collision values to
+// their actions. "ask" (or empty) prompts instead.
+var collisionActions = map[string]CollisionAction{
+	"adopt":  CollisionAdopt,
+	"kill":   CollisionKill,
+	"suffix": CollisionSuffix,
+	"fail":   CollisionFail,
+}
+
+// validateOnCollision checks an --on-collision value. Empty means "ask".
+func validateOnCollision(policy string) error {
+	if _, ok := collisionActions[policy]; policy != "" && policy != "ask" && !ok {
+		return fmt.Errorf("invalid --on-collision %q — must be ask, adopt, kill, suffix or fail", policy)
+	}
+	return nil
+}
+
+// SessionCollision describes a launch whose session name is already taken
+// by a running tmux session. This happens when session_init hands back an
+// existing server session (a reused ID) that is still running, including
+// when the same ID comes back for two personas of one team launch.
+type SessionCollision struct {
+	Name        string       // session name the launch wanted
+	TmuxSession string       // full tmux name that already exists
+	Provider    string       // provider of the launch
+	Persona     string       // persona of the launch ("" for vanilla)
+	Meta        *SessionMeta // store entry of the running session; nil if the store doesn't know it
+}
+
+// detectSessionCollision reports whether launching name for provider would
+// collide with a running tmux session.
+func detectSessionCollision(tm *TmuxManager, store *Store, provider, persona, name string) (SessionCollision, bool) {
+	fullName := tm.FullSessionName(provider, name)
+	if !tm.HasSession(fullName) {
+		return SessionCollision{}, false
+	}
+	c := SessionCollision{Name: name, TmuxSession: fullName, Provider: provider, Persona: persona}
+	if store != nil {
+		if sessions, err := store.List(); err == nil {
+			for _, meta := range sessions {
+				if meta.TmuxSession == fullName {
+					meta := meta
+					c.Meta = &meta
+					break
+				}
+			}
+		}
+	}
+	return c, true
+}
+
+// suffixedSessionName returns the first of name-2, name-3, ... that no
+// tmux session uses for provider.
+func suffixedSessionName(tm *TmuxManager, provider, name string) string {
+	for i := 2; ; i++ {
+		candidate := name + "-" + strconv.Itoa(i)
+		if !tm.HasSession(tm.FullSessionName(provider, candidate)) {
+			return candidate
+		}
+	}
+}
+
+// collisionPrompter asks the user how to resolve a session collision.
+type collisionPrompter func(SessionCollision) (CollisionAction, error)
+
+// resolveSessionCollision decides how a launch handles c. An explicit
+// policy applies as is; otherwise ask decides, or the launch fails when
+// ask is nil. A session running another persona can't be adopted, since
+// this persona would then have no session of its own.
+func resolveSessionCollision(c SessionCollision, policy string, ask collisionPrompter) (CollisionAction, error) {
+	action, ok := collisionActions[policy]
+	if !ok {
+		if ask == nil {
+			return CollisionFail, collisionError(c)
+		}
+		var err error
+		if action, err = ask(c); err != nil {
+			return CollisionFail, err
+		}
+	}
+	switch {
+	case action == CollisionAdopt && c.Meta != nil && c.Meta.Persona != c.Persona:
+		return CollisionFail, fmt.Errorf("cannot adopt %s: it runs persona %q, not %q — use kill or suffix", c.TmuxSession, c.Meta.Persona, c.Persona)
+	case action == CollisionFail:
+		return CollisionFail, collisionError(c)
+	}
+	return action, nil
+}
+
+// collisionError describes a collision the launch gave up on.
+func collisionError(c SessionCollision) error {
+	return fmt.Errorf("session %q already exists — adopt, kill or suffix it with --on-collision or session_collision in config", c.TmuxSession)
+}
+
+// reconcileSessionCollision carries out action for c and returns the
+// session name the launch should continue with. Kill stops the running
+// session and retires its store entry; suffix picks a free name; adopt
+// leaves everything in place and the caller skips creating the session.
+// The decision is logged either way.
+func reconcileSessionCollision(tm *TmuxManager, store *Store, cache *SessionCache, c SessionCollision, action CollisionAction) (string, error) {
+	name := c.Name
+	switch action {
+	case CollisionKill:
+		if err := tm.KillSession(c.TmuxSession); err != nil {
+			return "", fmt.Errorf("stop colliding session %s: %w", c.TmuxSession, err)
+		}
+		if c.Meta != nil && store != nil {
+			_ = store.History().End(c.Meta.Name, ExitReplaced, time.Now())
+			if err := store.Remove(c.Meta.Name); err != nil {
+				return "", fmt.Errorf("remove colliding session %q: %w", c.Meta.Name, err)
+			}
+			if cache != nil {
+				_ = cache.Remove(c.Meta.Name)
+			}
+		}
+	case CollisionSuffix:
+		name = suffixedSessionName(tm, c.Provider, c.Name)
+	case CollisionAdopt:
+	default:
+		return "", collisionError(c)
+	}
+	if tm.logger != nil {
+		tm.logger.Info("session collision on %s (persona=%q): %s, continuing as %q", c.TmuxSession, c.Persona, action, name)
+	}
+	return name, nil
+}
+
+// collisionSummary is the one-line description of c shown by prompts.
+func collisionSummary(c SessionCollision) string {
+	if c.Meta == nil {
+		return fmt.Sprintf("tmux session %s is already running (not tracked by vibeflow)", c.TmuxSession)
+	}
+	who := c.Meta.Persona
+	if who == "" {
+		who = "vanilla"
+	}
+	return fmt.Sprintf("tmux session %s is already running (%s, started %s ago in %s)", c.TmuxSession, who, formatSessionDuration(time.Since(c.Meta.CreatedAt)), c.Meta.WorkingDir)
+}
+
+// promptCollision returns a prompter that asks on in/out whether to adopt,
+// kill or suffix the running session.
+func promptCollision(in io.Reader, out io.Writer) collisionPrompter {
+	reader := bufio.NewReader(in)
+	return func(c SessionCollision) (CollisionAction, error) {
+		fmt.Fprintln(out, collisionSummary(c))
+		fmt.Fprintln(out, "  [a] Adopt the running session\n  [k] Kill it and launch anew\n  [s] Launch alongside it with a suffixed name\n  [f] Fail")
+		for {
+			fmt.Fprint(out, "Choice: ")
+			line, err := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(line)) {
+			case "a":
+				return CollisionAdopt, nil
+			case "k":
+				return CollisionKill, nil
+			case "s":
+				return CollisionSuffix, nil
+			case "f":
+				return CollisionFail, nil
+			}
+			if err != nil {
+				return CollisionFail, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// collisionTmux starts a tmux server with a running vibeflow_claude-abc
+// session and returns its manager and a store tracking that session.
+func collisionTmux(t *testing.T, persona string) (*TmuxManager, *Store) {
+	t.Helper()
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := &TmuxManager{socketName: "vfcollision-" + t.Name()}
+	t.Cleanup(func() { _, _ = tm.run("kill-server") })
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-abc", "sleep 30"); err != nil {
+		t.Skipf("cannot start tmux: %v", err)
+	}
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "sessions.db"))
+	if err := store.Add(SessionMeta{Name: "abc", TmuxSession: "vibeflow_claude-abc", Provider: "claude", Persona: persona, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	return tm, store
+}
+
+func TestDetectSessionCollision(t *testing.T) {
+	tm, store := collisionTmux(t, "developer")
+	if _, ok := detectSessionCollision(tm, store, "claude", "developer", "xyz"); ok {
+		t.Error("free name reported as a collision")
+	}
+	c, ok := detectSessionCollision(tm, store, "claude", "developer", "abc")
+	if !ok {
+		t.Fatal("running session not detected")
+	}
+	if c.TmuxSession != "vibeflow_claude-abc" || c.Meta == nil || c.Meta.Name != "abc" {
+		t.Errorf("collision = %+v", c)
+	}
+}
+
+func TestResolveSessionCollision(t *testing.T) {
+	c := SessionCollision{Name: "abc", TmuxSession: "vibeflow_claude-abc", Persona: "developer", Meta: &SessionMeta{Persona: "developer"}}
+	if action, err := resolveSessionCollision(c, "kill", nil); err != nil || action != CollisionKill {
+		t.Errorf("kill policy = %v, %v", action, err)
+	}
+	if _, err := resolveSessionCollision(c, "fail", nil); err == nil {
+		t.Error("fail policy should error")
+	}
+	if _, err := resolveSessionCollision(c, "", nil); err == nil {
+		t.Error("no policy and no prompt should error")
+	}
+	ask := func(SessionCollision) (CollisionAction, error) { return CollisionSuffix, nil }
+	if action, err := resolveSessionCollision(c, "ask", ask); err != nil || action != CollisionSuffix {
+		t.Errorf("ask = %v, %v", action, err)
+	}
+
+	// The same server session handed back for another persona can't be adopted.
+	c.Persona = "qa_lead"
+	if _, err := resolveSessionCollision(c, "adopt", nil); err == nil || !strings.Contains(err.Error(), "persona") {
+		t.Errorf("adopting another persona's session: err = %v", err)
+	}
+}
+
+func TestValidateOnCollision(t *testing.T) {
+	for _, policy := range []string{"", "ask", "adopt", "kill", "suffix", "fail"} {
+		if err := validateOnCollision(policy); err != nil {
+			t.Errorf("%q: %v", policy, err)
+		}
+	}
+	if err := validateOnCollision("replace"); err == nil {
+		t.Error("unknown policy accepted")
+	}
+}
+
+func TestReconcileSessionCollision_Suffix(t *testing.T) {
+	tm, store := collisionTmux(t, "developer")
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-abc-2", "sleep 30"); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := detectSessionCollision(tm, store, "claude", "qa_lead", "abc")
+	name, err := reconcileSessionCollision(tm, store, nil, c, CollisionSuffix)
+	if err != nil || name != "abc-3" {
+		t.Errorf("suffix = %q, %v; want abc-3", name, err)
+	}
+	if !tm.HasSession("vibeflow_claude-abc") {
+		t.Error("suffix stopped the running session")
+	}
+}
+
+func TestReconcileSessionCollision_Kill(t *testing.T) {
+	tm, store := collisionTmux(t, "developer")
+	c, _ := detectSessionCollision(tm, store, "claude", "developer", "abc")
+	name, err := reconcileSessionCollision(tm, store, nil, c, CollisionKill)
+	if err != nil || name != "abc" {
+		t.Fatalf("kill = %q, %v", name, err)
+	}
+	if tm.HasSession("vibeflow_claude-abc") {
+		t.Error("kill left the session running")
+	}
+	if _, ok, _ := store.Get("abc"); ok {
+		t.Error("kill left the store entry")
+	}
+}
+
+func TestPromptCollision(t *testing.T) {
+	var out bytes.Buffer
+	ask := promptCollision(strings.NewReader("x\nk\n"), &out)
+	action, err := ask(SessionCollision{TmuxSession: "vibeflow_claude-abc"})
+	if err != nil || action != CollisionKill {
+		t.Errorf("prompt = %v, %v", action, err)
+	}
+	if !strings.Contains(out.String(), "not tracked by vibeflow") {
+		t.Errorf("prompt output = %q", out.String())
+	}
+}
@@ -0,0 +1,515 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Exit reasons recorded when a session's history entry is closed.
+const (
+	ExitKilled    = "killed"    // kill from the CLI or TUI
+	ExitTimedOut  = "timed_out" // killed by the timeouts policy
+	ExitDeleted   = "deleted"   // delete from the CLI or TUI
+	ExitExited    = "exited"    // the agent process exited and the pane died
+	ExitRestarted = "restarted" // relaunched under the same name
+	ExitReplaced  = "replaced"  // stopped to make way for a new session (branch switch, --replace)
+	ExitRemoved   = "removed"   // store entry dropped any other way (purge, stale sync)
+	ExitArchived  = "archived"  // finished session archived from the TUI
+)
+
+// historyReconcileGrace protects just-launched sessions from Reconcile.
+const historyReconcileGrace = 30 * time.Second
+
+// maxHistoryEntries caps the history; the oldest closed entries go first.
+const maxHistoryEntries = 1000
+
+// HistoryEntry is one session lifetime: its launch parameters plus how and
+// when it ended. An entry with a zero EndedAt is still open.
+type HistoryEntry struct {
+	SessionMeta
+	EndedAt          time.Time `json:"ended_at,omitempty"`
+	ExitReason       string    `json:"exit_reason,omitempty"`
+	RecoveryAttempts int       `json:"recovery_attempts,omitempty"`
+	// Usage is the last token/cost summary read from the session's output.
+	Usage *TokenUsage `json:"usage,omitempty"`
+	// Recoveries lists the most recent recovery attempts, oldest first.
+	Recoveries []RecoveryEvent `json:"recoveries,omitempty"`
+	// OutputTail is the pane's last output, saved before a kill when
+	// archiving is on.
+	OutputTail string `json:"output_tail,omitempty"`
+	// ArchivedAt is when the ended session's summary reached the server.
+	ArchivedAt time.Time `json:"archived_at,omitempty"`
+}
+
+// RecoveryEvent is one automatic error-recovery attempt.
+type RecoveryEvent struct {
+	At    time.Time `json:"at"`
+	Error string    `json:"error,omitempty"` // the matched error pattern
+}
+
+// maxHistoryRecoveries caps the recovery attempts kept per entry; the
+// count in RecoveryAttempts keeps going.
+const maxHistoryRecoveries = 20
+
+// Open reports whether the session had not ended when last recorded.
+func (e HistoryEntry) Open() bool {
+	return e.EndedAt.IsZero()
+}
+
+// Duration is how long the session ran, up to now for open entries.
+func (e HistoryEntry) Duration(now time.Time) time.Duration {
+	if e.CreatedAt.IsZero() {
+		return 0
+	}
+	end := e.EndedAt
+	if e.Open() {
+		end = now
+	}
+	if end.Before(e.CreatedAt) {
+		return 0
+	}
+	return end.Sub(e.CreatedAt)
+}
+
+// SessionHistory is an append-mostly audit log of every session vibeflow has
+// created. Unlike the Store (live sessions) and the SessionCache (restart
+// parameters), entries are kept after the session is gone. It lives in the
+// "history" bucket of the state database, next to the Store's sessions.
+type SessionHistory struct {
+	path   string
+	legacy string // history.json imported on first use
+}
+
+var (
+	historyBucket     = []byte("history")      // sequence key → HistoryEntry JSON, oldest first
+	historyOpenBucket = []byte("history_open") // session name → key of its open entry
+)
+
+// NewSessionHistory creates a SessionHistory backed by the default state
+// database. Entries from a history.json written by an older vibeflow are
+// imported on first use.
+func NewSessionHistory() *SessionHistory {
+	return &SessionHistory{path: DefaultStorePath(), legacy: filepath.Join(RootDir(), "history.json")}
+}
+
+// NewSessionHistoryWithPath creates a SessionHistory backed by a custom database path.
+func NewSessionHistoryWithPath(path string) *SessionHistory {
+	return &SessionHistory{path: path}
+}
+
+// Start records a session launch. Re-recording the same launch (same name
+// and CreatedAt) refreshes its metadata; a new launch under a name that is
+// still open closes the previous entry as restarted.
+func (h *SessionHistory) Start(meta SessionMeta) error {
+	if h == nil {
+		return nil
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		return startHistoryEntry(tx, meta)
+	})
+}
+
+// End closes the open entry for name. It is a no-op when the session has
+// no open entry, so callers that know the precise reason can record it
+// before a generic path (Store.Remove) tries to.
+func (h *SessionHistory) End(name, reason string, at time.Time) error {
+	if h == nil {
+		return nil
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		ended, err := endHistoryEntry(tx, name, reason, at)
+		if err == nil && !ended {
+			return errUnchanged
+		}
+		return err
+	})
+}
+
+// RecordRecovery counts an automatic error-recovery attempt for name,
+// made at at for the error pattern described by pattern.
+func (h *SessionHistory) RecordRecovery(name, pattern string, at time.Time) error {
+	if h == nil {
+		return nil
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		key := tx.Bucket(historyOpenBucket).Get([]byte(name))
+		if key == nil {
+			return errUnchanged
+		}
+		e, err := getHistoryEntry(b, key)
+		if err != nil {
+			return err
+		}
+		e.RecoveryAttempts++
+		e.Recoveries = append(e.Recoveries, RecoveryEvent{At: at, Error: pattern})
+		if len(e.Recoveries) > maxHistoryRecoveries {
+			e.Recoveries = e.Recoveries[len(e.Recoveries)-maxHistoryRecoveries:]
+		}
+		return putHistoryEntry(b, key, e)
+	})
+}
+
+// RecordOutputTail saves the final output of the open entry for name, for
+// sessions whose pane is about to disappear.
+func (h *SessionHistory) RecordOutputTail(name, tail string) error {
+	if h == nil {
+		return nil
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		key := tx.Bucket(historyOpenBucket).Get([]byte(name))
+		if key == nil {
+			return errUnchanged
+		}
+		e, err := getHistoryEntry(b, key)
+		if err != nil {
+			return err
+		}
+		if e.OutputTail == tail {
+			return errUnchanged
+		}
+		e.OutputTail = tail
+		return putHistoryEntry(b, key, e)
+	})
+}
+
+// MarkArchived records that the ended entry for the launch of name at
+// createdAt was uploaded to the server.
+func (h *SessionHistory) MarkArchived(name string, createdAt, at time.Time) error {
+	if h == nil {
+		return nil
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		var key []byte
+		var entry HistoryEntry
+		err := b.ForEach(func(k, v []byte) error {
+			var e HistoryEntry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return fmt.Errorf("parse history: %w", err)
+			}
+			if e.Name == name && e.CreatedAt.Equal(createdAt) && !e.Open() {
+				key, entry = append([]byte(nil), k...), e
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return errUnchanged
+		}
+		entry.ArchivedAt = at
+		return putHistoryEntry(b, key, entry)
+	})
+}
+
+// RecordUsage stores the latest usage summary on the open entry for name.
+func (h *SessionHistory) RecordUsage(name string, usage TokenUsage) error {
+	if h == nil {
+		return nil
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		key := tx.Bucket(historyOpenBucket).Get([]byte(name))
+		if key == nil {
+			return errUnchanged
+		}
+		e, err := getHistoryEntry(b, key)
+		if err != nil {
+			return err
+		}
+		if e.Usage != nil && *e.Usage == usage {
+			return errUnchanged
+		}
+		e.Usage = &usage
+		return putHistoryEntry(b, key, e)
+	})
+}
+
+// Reconcile closes open entries whose session is no longer in the store,
+// e.g. killed by a path that bypasses End or removed by an older vibeflow.
+// Entries younger than historyReconcileGrace are left alone: a launch writes
+// the store before the history, so a concurrent reader can briefly see an
+// open entry without its store row.
+func (h *SessionHistory) Reconcile(stored []string, now time.Time) error {
+	if h == nil {
+		return nil
+	}
+	keep := make(map[string]bool, len(stored))
+	for _, n := range stored {
+		keep[n] = true
+	}
+	return h.update(func(tx *bolt.Tx) error {
+		b, open := tx.Bucket(historyBucket), tx.Bucket(historyOpenBucket)
+		var stale [][]byte
+		err := open.ForEach(func(name, key []byte) error {
+			if keep[string(name)] {
+				return nil
+			}
+			e, err := getHistoryEntry(b, key)
+			if err != nil {
+				return err
+			}
+			if now.Sub(e.CreatedAt) > historyReconcileGrace {
+				stale = append(stale, append([]byte(nil), name...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(stale) == 0 {
+			return errUnchanged
+		}
+		for _, name := range stale {
+			if _, err := endHistoryEntry(tx, string(name), ExitRemoved, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List returns all entries, oldest first.
+func (h *SessionHistory) List() ([]HistoryEntry, error) {
+	if h == nil {
+		return nil, nil
+	}
+	if legacyExists(h.legacy) {
+		if err := h.update(func(*bolt.Tx) error { return errUnchanged }); err != nil {
+			return nil, err
+		}
+	}
+	var out []HistoryEntry
+	err := viewStateDB(h.path, func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var e HistoryEntry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return fmt.Errorf("parse history: %w", err)
+			}
+			out = append(out, e)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// startHistoryEntry records meta as launched; see SessionHistory.Start.
+func startHistoryEntry(tx *bolt.Tx, meta SessionMeta) error {
+	b, open := tx.Bucket(historyBucket), tx.Bucket(historyOpenBucket)
+	if key := open.Get([]byte(meta.Name)); key != nil {
+		key = append([]byte(nil), key...)
+		e, err := getHistoryEntry(b, key)
+		if err != nil {
+			return err
+		}
+		if e.CreatedAt.Equal(meta.CreatedAt) {
+			e.SessionMeta = meta
+			return putHistoryEntry(b, key, e)
+		}
+		e.EndedAt = meta.CreatedAt
+		e.ExitReason = ExitRestarted
+		if err := putHistoryEntry(b, key, e); err != nil {
+			return err
+		}
+	}
+	if err := appendHistoryEntry(tx, HistoryEntry{SessionMeta: meta}); err != nil {
+		return err
+	}
+	return trimHistoryBucket(b)
+}
+
+// endHistoryEntry closes the open entry for name and reports whether there was one.
+func endHistoryEntry(tx *bolt.Tx, name, reason string, at time.Time) (bool, error) {
+	b, open := tx.Bucket(historyBucket), tx.Bucket(historyOpenBucket)
+	key := open.Get([]byte(name))
+	if key == nil {
+		return false, nil
+	}
+	key = append([]byte(nil), key...)
+	e, err := getHistoryEntry(b, key)
+	if err != nil {
+		return false, err
+	}
+	e.EndedAt = at
+	e.ExitReason = reason
+	if err := putHistoryEntry(b, key, e); err != nil {
+		return false, err
+	}
+	return true, open.Delete([]byte(name))
+}
+
+// appendHistoryEntry stores e under the next sequence key and indexes it
+// by name while it is open.
+func appendHistoryEntry(tx *bolt.Tx, e HistoryEntry) error {
+	b := tx.Bucket(historyBucket)
+	n, err := b.NextSequence()
+	if err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	key := seqKey(n)
+	if err := putHistoryEntry(b, key, e); err != nil {
+		return err
+	}
+	if e.Open() {
+		return tx.Bucket(historyOpenBucket).Put([]byte(e.Name), key)
+	}
+	return nil
+}
+
+func getHistoryEntry(b *bolt.Bucket, key []byte) (HistoryEntry, error) {
+	var e HistoryEntry
+	if err := json.Unmarshal(b.Get(key), &e); err != nil {
+		return e, fmt.Errorf("parse history: %w", err)
+	}
+	return e, nil
+}
+
+func putHistoryEntry(b *bolt.Bucket, key []byte, e HistoryEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal history: %w", err)
+	}
+	if err := b.Put(key, data); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// historyExcess returns the indexes of the oldest closed entries beyond
+// maxHistoryEntries. Open entries are never dropped.
+func historyExcess(entries []HistoryEntry) []int {
+	excess := len(entries) - maxHistoryEntries
+	var drop []int
+	for i := 0; i < len(entries) && excess > 0; i++ {
+		if !entries[i].Open() {
+			drop = append(drop, i)
+			excess--
+		}
+	}
+	return drop
+}
+
+// trimHistory drops the oldest closed entries beyond maxHistoryEntries.
+func trimHistory(entries []HistoryEntry) []HistoryEntry {
+	drop := historyExcess(entries)
+	if len(drop) == 0 {
+		return entries
+	}
+	out := make([]HistoryEntry, 0, len(entries)-len(drop))
+	for i, e := range entries {
+		if len(drop) > 0 && drop[0] == i {
+			drop = drop[1:]
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// trimHistoryBucket applies trimHistory to the history bucket.
+func trimHistoryBucket(b *bolt.Bucket) error {
+	if b.Stats().KeyN <= maxHistoryEntries {
+		return nil
+	}
+	var (
+		keys    [][]byte
+		entries []HistoryEntry
+	)
+	err := b.ForEach(func(k, v []byte) error {
+		var e HistoryEntry
+		if err := json.Unmarshal(v, &e); err != nil {
+			return fmt.Errorf("parse history: %w", err)
+		}
+		keys = append(keys, append([]byte(nil), k...))
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, i := range historyExcess(entries) {
+		if err := b.Delete(keys[i]); err != nil {
+			return fmt.Errorf("trim history: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrate creates the history buckets, importing the legacy history.json
+// the first time. It reports whether the legacy file was imported.
+func (h *SessionHistory) migrate(tx *bolt.Tx) (bool, error) {
+	imported, err := importLegacyJSON(tx, historyBucket, h.legacy, func(data []byte) error {
+		var entries []HistoryEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(historyOpenBucket); err != nil {
+			return err
+		}
+		for _, e := range trimHistory(entries) {
+			if err := appendHistoryEntry(tx, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.CreateBucketIfNotExists(historyOpenBucket); err != nil {
+		return false, fmt.Errorf("create %s bucket: %w", historyOpenBucket, err)
+	}
+	return imported, nil
+}
+
+// update runs fn in a read-write transaction on the history buckets. fn
+// may return errUnchanged to roll back a no-op, so the frequent End calls
+// from the TUI refresh don't touch the disk.
+func (h *SessionHistory) update(fn func(*bolt.Tx) error) error {
+	imported := false
+	err := updateStateDB(h.path, func(tx *bolt.Tx) error {
+		var err error
+		if imported, err = h.migrate(tx); err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil && !(errors.Is(err, errUnchanged) && imported) {
+			return err
+		}
+		return nil
+	})
+	if err == nil && imported {
+		retireLegacyJSON(h.legacy)
+	}
+	return err
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestSessionHistory_Lifecycle(t *testing.T) {
+	h := NewSessionHistoryWithPath(filepath.Join(t.TempDir(), "history.json"))
+	t0 := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	first := SessionMeta{Name: "api", Provider: "claude", Branch: "main", CreatedAt: t0}
+	if err := h.Start(first); err != nil {
+		t.Fatal(err)
+	}
+	// Re-adding the same launch (e.g. a store update) must not duplicate it.
+	first.Branch = "feat/x"
+	_ = h.Start(first)
+	_ = h.RecordRecovery("api", "Claude API 5xx", t0.Add(time.Minute))
+	_ = h.RecordRecovery("api", "Claude rate limit", t0.Add(2*time.Minute))
+
+	// A relaunch under the same name closes the first entry as restarted.
+	second := SessionMeta{Name: "api", Provider: "claude", CreatedAt: t0.Add(time.Hour)}
+	_ = h.Start(second)
+	_ = h.End("api", ExitKilled, t0.Add(2*time.Hour))
+	// No open entry left: a later generic removal changes nothing.
+	_ = h.End("api", ExitRemoved, t0.Add(3*time.Hour))
+
+	entries, err := h.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	e := entries[0]
+	if e.Branch != "feat/x" || e.RecoveryAttempts != 2 || e.ExitReason != ExitRestarted || !e.EndedAt.Equal(second.CreatedAt) {
+		t.Errorf("first entry = %+v", e)
+	}
+	if len(e.Recoveries) != 2 || e.Recoveries[1].Error != "Claude rate limit" || !e.Recoveries[1].At.Equal(t0.Add(2*time.Minute)) {
+		t.Errorf("recoveries = %+v", e.Recoveries)
+	}
+	if got := e.Duration(time.Now()); got != time.Hour {
+		t.Errorf("first duration = %v, want 1h", got)
+	}
+	if entries[1].ExitReason != ExitKilled || entries[1].RecoveryAttempts != 0 {
+		t.Errorf("second entry = %+v", entries[1])
+	}
+}
+
+func TestSessionHistory_Reconcile(t *testing.T) {
+	h := NewSessionHistoryWithPath(filepath.Join(t.TempDir(), "history.json"))
+	now := time.Now()
+	_ = h.Start(SessionMeta{Name: "gone", CreatedAt: now.Add(-time.Hour)})
+	_ = h.Start(SessionMeta{Name: "live", CreatedAt: now.Add(-time.Hour)})
+	_ = h.Start(SessionMeta{Name: "fresh", CreatedAt: now})
+	if err := h.Reconcile([]string{"live"}, now); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := h.List()
+	got := map[string]string{}
+	for _, e := range entries {
+		got[e.Name] = historyExit(e)
+	}
+	if got["gone"] != ExitRemoved || got["live"] != "running" || got["fresh"] != "running" {
+		t.Errorf("after reconcile = %v", got)
+	}
+}
+
+func TestStore_RecordsHistory(t *testing.T) {
+	withTempRoot(t)
+	store := NewStore()
+	meta := SessionMeta{Name: "web", TmuxSession: "vibeflow_claude-web", CreatedAt: time.Now()}
+	if err := store.Add(meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove("web"); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := store.History().List()
+	if len(entries) != 1 || entries[0].ExitReason != ExitRemoved {
+		t.Errorf("history = %+v, want one removed entry", entries)
+	}
+	if NewStoreWithPath(filepath.Join(t.TempDir(), "s.json")).History() != nil {
+		t.Error("custom-path stores should not record history")
+	}
+}
+
+func TestTrimHistory_KeepsOpenEntries(t *testing.T) {
+	entries := make([]HistoryEntry, maxHistoryEntries+2)
+	entries[0].Name = "open" // oldest, but still running
+	for i := 1; i < len(entries); i++ {
+		entries[i].EndedAt = time.Unix(int64(i), 0)
+	}
+	out := trimHistory(entries)
+	if len(out) != maxHistoryEntries || out[0].Name != "open" || out[1].EndedAt.Unix() != 3 {
+		t.Errorf("trim kept %d entries starting %+v", len(out), out[:2])
+	}
+}
+
+func TestFilterHistory(t *testing.T) {
+	now := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	entries := []HistoryEntry{
+		{SessionMeta: SessionMeta{Name: "a", Provider: "claude", Branch: "feat/login", CreatedAt: now.AddDate(0, 0, -10)}, EndedAt: now, ExitReason: ExitKilled},
+		{SessionMeta: SessionMeta{Name: "b", Provider: "codex", Project: "Web", CreatedAt: now.AddDate(0, 0, -2)}, EndedAt: now, ExitReason: ExitExited},
+		{SessionMeta: SessionMeta{Name: "c", Provider: "claude", Branch: "main", CreatedAt: now.Add(-time.Hour)}},
+	}
+	names := func(es []HistoryEntry) string {
+		var out []string
+		for _, e := range es {
+			out = append(out, e.Name)
+		}
+		return strings.Join(out, ",")
+	}
+	since, err := parseSince("7d", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		f     historyFilter
+		limit int
+		want  string
+	}{
+		{historyFilter{}, 0, "c,b,a"},
+		{historyFilter{}, 2, "c,b"},
+		{historyFilter{Provider: "claude"}, 0, "c,a"},
+		{historyFilter{Branch: "LOGIN"}, 0, "a"},
+		{historyFilter{Project: "web"}, 0, "b"},
+		{historyFilter{Reason: "running"}, 0, "c"},
+		{historyFilter{Since: since}, 0, "c,b"},
+	}
+	for _, tt := range tests {
+		if got := names(filterHistory(entries, tt.f, tt.limit)); got != tt.want {
+			t.Errorf("filter %+v limit %d = %q, want %q", tt.f, tt.limit, got, tt.want)
+		}
+	}
+	if _, err := parseSince("soon", now); err == nil {
+		t.Error("invalid --since should error")
+	}
+}
+
+func TestFormatSessionDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		45 * time.Second:            "45s",
+		12 * time.Minute:            "12m",
+		3*time.Hour + 5*time.Minute: "3h05m",
+		52 * time.Hour:              "2d4h",
+	} {
+		if got := formatSessionDuration(d); got != want {
+			t.Errorf("formatSessionDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestHistoryModel_FilterAndClose(t *testing.T) {
+	now := time.Now()
+	entries := []HistoryEntry{
+		{SessionMeta: SessionMeta{Name: "old-api", Provider: "claude", CreatedAt: now.Add(-2 * time.Hour)}, EndedAt: now, ExitReason: ExitKilled},
+		{SessionMeta: SessionMeta{Name: "new-web", Provider: "codex", CreatedAt: now.Add(-time.Hour)}},
+	}
+	h := NewHistoryModel(entries, nil, 120, 20, now)
+	if h.visible[0].Name != "new-web" {
+		t.Fatalf("newest entry should be first, got %q", h.visible[0].Name)
+	}
+	press := func(s string) {
+		code := rune(0)
+		if len(s) == 1 {
+			code = rune(s[0])
+		}
+		switch s {
+		case "enter":
+			code = tea.KeyEnter
+		case "esc":
+			code = tea.KeyEscape
+		}
+		msg := tea.KeyPressMsg{Code: code}
+		if len(s) == 1 {
+			msg.Text = s
+		}
+		h, _ = h.Update(msg)
+	}
+	for _, k := range []string{"/", "k", "i", "l", "l", "e", "d", "enter"} {
+		press(k)
+	}
+	if len(h.visible) != 1 || h.visible[0].Name != "old-api" {
+		t.Fatalf("filter killed = %+v", h.visible)
+	}
+	if out := ansiRe.ReplaceAllString(h.View(), ""); !strings.Contains(out, "/killed: 1 of 2") {
+		t.Errorf("view missing filter status:\n%s", out)
+	}
+	press("esc") // clears the filter first
+	if h.Done() || len(h.visible) != 2 {
+		t.Fatal("first esc should clear the filter")
+	}
+	press("esc")
+	if !h.Done() {
+		t.Error("second esc should close the view")
+	}
+}
@@ -0,0 +1,329 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultSessionLogMaxSizeMB = 10
+	defaultSessionLogMaxFiles  = 3
+)
+
+// SessionLogDir returns the directory holding per-session output logs. It is
+// shared with the cloud-dispatch log.
+func SessionLogDir() string {
+	return filepath.Join(RootDir(), "logs")
+}
+
+// SessionLogPath returns the log file for a session. name may be a short or
+// full tmux name; the file is named after the short form shown by `list`.
+func SessionLogPath(name string) string {
+	return filepath.Join(SessionLogDir(), strings.TrimPrefix(name, sessionPrefix)+".log")
+}
+
+// limits returns the effective rotation settings, filling in defaults.
+func (c SessionLogConfig) limits() (maxBytes int64, maxFiles int) {
+	mb := c.MaxSizeMB
+	if mb <= 0 {
+		mb = defaultSessionLogMaxSizeMB
+	}
+	maxFiles = c.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = defaultSessionLogMaxFiles
+	}
+	return int64(mb) << 20, maxFiles
+}
+
+// SetSessionLogging enables pipe-pane logging, recording or both for
+// sessions this manager creates from now on. The pipe runs this
+// executable's hidden `pipe-log` subcommand so rotation works without
+// relying on external tools; if the executable can't be resolved logging
+// stays off.
+func (tm *TmuxManager) SetSessionLogging(cfg SessionLogConfig) {
+	if !cfg.Enabled && !cfg.Record {
+		tm.sessionLogCmd = nil
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		if tm.logger != nil {
+			tm.logger.Warn("session logs: cannot resolve executable: %v", err)
+		}
+		return
+	}
+	maxBytes, maxFiles := cfg.limits()
+	tm.sessionLogCmd = func(fullName string) string {
+		args := []string{exe, "pipe-log"}
+		if cfg.Enabled {
+			args = append(args,
+				"--path", SessionLogPath(fullName),
+				"--max-bytes", fmt.Sprint(maxBytes),
+				"--max-files", fmt.Sprint(maxFiles))
+		}
+		if cfg.Record {
+			// tmux expands the formats to the pane's size when the pipe starts.
+			args = append(args,
+				"--cast", SessionRecordingPath(fullName, time.Now()),
+				"--cols", "#{pane_width}",
+				"--rows", "#{pane_height}")
+		}
+		return shellJoin(args)
+	}
+}
+
+// startSessionLog attaches the configured log pipe to the session's pane.
+// Best-effort: a failure is logged and the session runs unlogged.
+func (tm *TmuxManager) startSessionLog(fullName string) {
+	if tm.sessionLogCmd == nil {
+		return
+	}
+	for _, dir := range []string{SessionLogDir(), SessionRecordingDir()} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return
+		}
+	}
+	if _, err := tm.run("pipe-pane", "-o", "-t", fullName, tm.sessionLogCmd(fullName)); err != nil && tm.logger != nil {
+		tm.logger.Warn("session logs: pipe-pane %s: %v", fullName, err)
+	}
+}
+
+// rotatingWriter appends to path and rotates it (path → path.1 → path.2 …)
+// once it would grow past maxBytes, keeping at most maxFiles old generations.
+type rotatingWriter struct {
+	path     string
+	maxBytes int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+func newRotatingWriter(path string, maxBytes int64, maxFiles int) (*rotatingWriter, error) {
+	w := &rotatingWriter{path: path, maxBytes: maxBytes, maxFiles: maxFiles}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("create log dir: %w", err)
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("stat log: %w", err)
+	}
+	w.f, w.size = f, info.Size()
+	return nil
+}
+
+func (w *rotatingWriter) rotate() error {
+	_ = w.f.Close()
+	for i := w.maxFiles - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+	}
+	if w.maxFiles > 0 {
+		_ = os.Rename(w.path, w.path+".1")
+	} else {
+		_ = os.Remove(w.path)
+	}
+	return w.open()
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) Close() error { return w.f.Close() }
+
+// --- pipe-log (hidden) ---
+
+// pipeLogCmd is the pipe-pane target: it copies stdin (the pane's output) to
+// a rotating log file, an asciicast recording, or both, until tmux closes
+// the pipe.
+func pipeLogCmd() *cobra.Command {
+	var (
+		path       string
+		maxBytes   int64
+		maxFiles   int
+		cast       string
+		cols, rows int
+	)
+	cmd := &cobra.Command{
+		Use:    "pipe-log",
+		Short:  "Copy stdin to a rotating session log (used by tmux pipe-pane)",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if path == "" && cast == "" {
+				return fmt.Errorf("--path or --cast is required")
+			}
+			var writers []io.Writer
+			if path != "" {
+				w, err := newRotatingWriter(path, maxBytes, maxFiles)
+				if err != nil {
+					return err
+				}
+				defer w.Close()
+				writers = append(writers, w)
+			}
+			if cast != "" {
+				if err := os.MkdirAll(filepath.Dir(cast), 0755); err != nil {
+					return fmt.Errorf("create recording dir: %w", err)
+				}
+				f, err := os.OpenFile(cast, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+				if err != nil {
+					return fmt.Errorf("open recording: %w", err)
+				}
+				defer f.Close()
+				title := strings.TrimSuffix(filepath.Base(cast), ".cast")
+				w, err := newCastWriter(f, cols, rows, title, time.Now())
+				if err != nil {
+					return err
+				}
+				writers = append(writers, w)
+			}
+			_, err := io.Copy(io.MultiWriter(writers...), os.Stdin)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&path, "path", "", "Log file to write")
+	cmd.Flags().Int64Var(&maxBytes, "max-bytes", defaultSessionLogMaxSizeMB<<20, "Rotate once the log reaches this size")
+	cmd.Flags().IntVar(&maxFiles, "max-files", defaultSessionLogMaxFiles, "Rotated generations to keep")
+	cmd.Flags().StringVar(&cast, "cast", "", "Asciicast recording to write")
+	cmd.Flags().IntVar(&cols, "cols", 80, "Terminal width for the recording header")
+	cmd.Flags().IntVar(&rows, "rows", 24, "Terminal height for the recording header")
+	return cmd
+}
+
+// --- logs ---
+
+func logsCmd() *cobra.Command {
+	var (
+		follow bool
+		lines  int
+	)
+	cmd := &cobra.Command{
+		Use:   "logs <session-name>",
+		Short: "Print a session's output log",
+		Long: `Print the output log of a session (requires session_logs.enabled in the
+config). Logs live in ` + "`<root>/logs/<session>.log`" + ` and outlive the tmux session.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := SessionLogPath(args[0])
+			f, err := os.Open(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return fmt.Errorf("no log for session %q (is session_logs.enabled set?)", args[0])
+				}
+				return err
+			}
+			defer f.Close()
+			if err := printTail(os.Stdout, f, lines); err != nil {
+				return err
+			}
+			if !follow {
+				return nil
+			}
+			return followLog(os.Stdout, path, f)
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new output as it is written")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 100, "Number of trailing lines to print (0 = whole file)")
+	return cmd
+}
+
+// printTail writes the last n lines of r to w (all of it when n <= 0).
+func printTail(w io.Writer, r io.Reader, n int) error {
+	if n <= 0 {
+		_, err := io.Copy(w, r)
+		return err
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	ring := make([]string, 0, n)
+	for sc.Scan() {
+		if len(ring) == n {
+			ring = ring[1:]
+		}
+		ring = append(ring, sc.Text())
+	}
+	for _, l := range ring {
+		if _, err := fmt.Fprintln(w, l); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// followLog streams bytes appended to path after f's current offset, polling
+// twice a second. When the file is rotated (replaced or truncated) it reopens
+// path and continues from the start of the new file.
+func followLog(w io.Writer, path string, f *os.File) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+		cur, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		pos, _ := f.Seek(0, io.SeekCurrent)
+		latest, err := os.Stat(path)
+		if err != nil {
+			continue // mid-rotation; try again
+		}
+		if !os.SameFile(cur, latest) || latest.Size() < pos {
+			nf, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			_ = f.Close()
+			f = nf
+		}
+	}
+}
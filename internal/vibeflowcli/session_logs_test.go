@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSessionLogPath_UsesShortName(t *testing.T) {
+	origRoot := rootDir
+	t.Cleanup(func() { rootDir = origRoot })
+	SetRootDir("/tmp/vf-root")
+
+	want := filepath.Join("/tmp/vf-root", "logs", "claude-x.log")
+	for _, name := range []string{"claude-x", "vibeflow_claude-x"} {
+		if got := SessionLogPath(name); got != want {
+			t.Errorf("SessionLogPath(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSessionLogConfig_LimitsDefaults(t *testing.T) {
+	maxBytes, maxFiles := SessionLogConfig{}.limits()
+	if maxBytes != 10<<20 || maxFiles != 3 {
+		t.Errorf("defaults = %d/%d, want 10MB/3", maxBytes, maxFiles)
+	}
+	maxBytes, maxFiles = SessionLogConfig{MaxSizeMB: 1, MaxFiles: 5}.limits()
+	if maxBytes != 1<<20 || maxFiles != 5 {
+		t.Errorf("explicit = %d/%d, want 1MB/5", maxBytes, maxFiles)
+	}
+}
+
+func TestSetSessionLogging_BuildsPipeCommand(t *testing.T) {
+	tm := &TmuxManager{socketName: "vftest"}
+	tm.SetSessionLogging(SessionLogConfig{})
+	if tm.sessionLogCmd != nil {
+		t.Fatal("disabled logging must not install a pipe command")
+	}
+	tm.SetSessionLogging(SessionLogConfig{Enabled: true, MaxSizeMB: 2, MaxFiles: 4})
+	if tm.sessionLogCmd == nil {
+		t.Fatal("enabled logging should install a pipe command")
+	}
+	got := tm.sessionLogCmd("vibeflow_claude-x")
+	for _, want := range []string{" pipe-log ", "claude-x.log", "--max-bytes 2097152", "--max-files 4"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("pipe command %q missing %q", got, want)
+		}
+	}
+}
+
+func TestRotatingWriter_RotatesAndCapsGenerations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.log")
+	w, err := newRotatingWriter(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, chunk := range []string{"aaaaaaaa", "bbbbbbbb", "cccccccc", "dddddddd"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = w.Close()
+
+	read := func(p string) string {
+		data, _ := os.ReadFile(p)
+		return string(data)
+	}
+	if got := read(path); got != "dddddddd" {
+		t.Errorf("current = %q, want dddddddd", got)
+	}
+	if got := read(path + ".1"); got != "cccccccc" {
+		t.Errorf(".1 = %q, want cccccccc", got)
+	}
+	if got := read(path + ".2"); got != "bbbbbbbb" {
+		t.Errorf(".2 = %q, want bbbbbbbb", got)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("only max_files generations should be kept")
+	}
+}
+
+func TestPrintTail(t *testing.T) {
+	in := "1\n2\n3\n4\n5\n"
+	var out bytes.Buffer
+	if err := printTail(&out, strings.NewReader(in), 2); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "4\n5\n" {
+		t.Errorf("tail 2 = %q", out.String())
+	}
+	out.Reset()
+	if err := printTail(&out, strings.NewReader(in), 0); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != in {
+		t.Errorf("tail 0 = %q, want whole input", out.String())
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// recordingTimeLayout stamps recording file names; it sorts chronologically.
+const recordingTimeLayout = "20060102-150405"
+
+// SessionRecordingDir returns the directory holding session recordings.
+func SessionRecordingDir() string {
+	return filepath.Join(RootDir(), "recordings")
+}
+
+// SessionRecordingPath returns the recording file for a session started at
+// t. Like SessionLogPath it is named after the short session name.
+func SessionRecordingPath(name string, t time.Time) string {
+	short := strings.TrimPrefix(name, sessionPrefix)
+	return filepath.Join(SessionRecordingDir(), short+"-"+t.Format(recordingTimeLayout)+".cast")
+}
+
+// SessionRecordings returns a session's recordings, oldest first.
+func SessionRecordings(name string) ([]string, error) {
+	short := strings.TrimPrefix(name, sessionPrefix)
+	// Match the timestamp's shape so "api" doesn't pick up "api-v2"'s files.
+	pattern := filepath.Join(SessionRecordingDir(), short+"-[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]-[0-9][0-9][0-9][0-9][0-9][0-9].cast")
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// castWriter writes what it is given as asciicast v2 output events, timed
+// from when it was created, so `asciinema play` can replay it too.
+type castWriter struct {
+	w     io.Writer
+	start time.Time
+	now   func() time.Time
+	// pending holds the start of a UTF-8 sequence split across writes; it
+	// is sent with the next write so no event carries half a character.
+	pending []byte
+}
+
+// newCastWriter writes the header for a cols×rows terminal and returns the
+// writer for the events that follow.
+func newCastWriter(w io.Writer, cols, rows int, title string, start time.Time) (*castWriter, error) {
+	if cols <= 0 {
+		cols = 80
+	}
+	if rows <= 0 {
+		rows = 24
+	}
+	header, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     cols,
+		Height:    rows,
+		Timestamp: start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", header); err != nil {
+		return nil, err
+	}
+	return &castWriter{w: w, start: start, now: time.Now}, nil
+}
+
+func (c *castWriter) Write(p []byte) (int, error) {
+	data := append(c.pending, p...)
+	n := len(data)
+	for i := n - 1; i >= 0 && i >= n-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				n = i
+			}
+			break
+		}
+	}
+	c.pending = append([]byte(nil), data[n:]...)
+	if n == 0 {
+		return len(p), nil
+	}
+	elapsed := math.Round(c.now().Sub(c.start).Seconds()*1e6) / 1e6
+	event, err := json.Marshal([]any{elapsed, "o", string(data[:n])})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := fmt.Fprintf(c.w, "%s\n", event); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// replayCast plays an asciicast v2 stream to w, waiting between events as
+// they were recorded, divided by speed. Pauses longer than idleLimit are cut
+// to it (0 keeps them). sleep is time.Sleep outside tests.
+func replayCast(w io.Writer, r io.Reader, speed float64, idleLimit time.Duration, sleep func(time.Duration)) error {
+	if speed <= 0 {
+		speed = 1
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("empty recording")
+	}
+	var header castHeader
+	if err := json.Unmarshal(sc.Bytes(), &header); err != nil {
+		return fmt.Errorf("read recording header: %w", err)
+	}
+	if header.Version != 2 {
+		return fmt.Errorf("unsupported recording version %d (want asciicast v2)", header.Version)
+	}
+	var last float64
+	for line := 2; sc.Scan(); line++ {
+		var event []json.RawMessage
+		if err := json.Unmarshal(sc.Bytes(), &event); err != nil || len(event) != 3 {
+			return fmt.Errorf("recording line %d: malformed event", line)
+		}
+		var (
+			at   float64
+			kind string
+			data string
+		)
+		if json.Unmarshal(event[0], &at) != nil || json.Unmarshal(event[1], &kind) != nil || json.Unmarshal(event[2], &data) != nil {
+			return fmt.Errorf("recording line %d: malformed event", line)
+		}
+		if kind != "o" {
+			continue
+		}
+		wait := time.Duration((at - last) / speed * float64(time.Second))
+		if idleLimit > 0 && wait > idleLimit {
+			wait = idleLimit
+		}
+		if wait > 0 {
+			sleep(wait)
+		}
+		last = at
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// --- replay ---
+
+func replayCmd() *cobra.Command {
+	var (
+		speed     float64
+		idleLimit time.Duration
+		list      bool
+	)
+	cmd := &cobra.Command{
+		Use:   "replay <session-name | file.cast>",
+		Short: "Play back a session recording",
+		Long: `Play back a session's terminal recording (requires session_logs.record in
+the config). Recordings live in ` + "`<root>/recordings/<session>-<time>.cast`" + `, one per
+session start, and outlive the tmux session. The newest recording plays
+unless a .cast file is given; --list shows them all. The files are asciicast
+v2, so asciinema can play or upload them too.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			if !strings.HasSuffix(path, ".cast") {
+				paths, err := SessionRecordings(path)
+				if err != nil {
+					return err
+				}
+				if len(paths) == 0 {
+					return fmt.Errorf("no recording for session %q (is session_logs.record set?)", args[0])
+				}
+				if list {
+					for _, p := range paths {
+						fmt.Fprintln(cmd.OutOrStdout(), p)
+					}
+					return nil
+				}
+				path = paths[len(paths)-1]
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return replayCast(cmd.OutOrStdout(), f, speed, idleLimit, time.Sleep)
+		},
+	}
+	cmd.Flags().Float64VarP(&speed, "speed", "s", 1, "Playback speed multiplier")
+	cmd.Flags().DurationVarP(&idleLimit, "idle-limit", "i", 2*time.Second, "Cut pauses longer than this (0 = keep them)")
+	cmd.Flags().BoolVar(&list, "list", false, "List the session's recordings instead of playing one")
+	return cmd
+}
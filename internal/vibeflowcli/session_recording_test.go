@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCastWriter_TimesEventsAndKeepsRunesWhole(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Unix(1000, 0)
+	w, err := newCastWriter(&buf, 0, 0, "dev", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := start
+	w.now = func() time.Time { return clock }
+
+	clock = start.Add(500 * time.Millisecond)
+	_, _ = w.Write([]byte("h\xc3")) // é split across two reads
+	clock = start.Add(1500 * time.Millisecond)
+	_, _ = w.Write([]byte("\xa9\r\n"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		`{"version":2,"width":80,"height":24,"timestamp":1000,"title":"dev","env":{"TERM":"xterm-256color"}}`,
+		`[0.5,"o","h"]`,
+		`[1.5,"o","é\r\n"]`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("cast =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestReplayCast_SpeedAndIdleLimit(t *testing.T) {
+	cast := `{"version":2,"width":80,"height":24}
+[1.0,"o","a"]
+[1.5,"i","typed"]
+[2.0,"o","b"]
+[602.0,"o","c"]
+`
+	var out bytes.Buffer
+	var waits []time.Duration
+	err := replayCast(&out, strings.NewReader(cast), 2, 5*time.Second, func(d time.Duration) { waits = append(waits, d) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "abc" {
+		t.Errorf("output = %q, want abc (input events skipped)", out.String())
+	}
+	want := []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 5 * time.Second}
+	if len(waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", waits, want)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("wait %d = %v, want %v", i, waits[i], want[i])
+		}
+	}
+
+	err = replayCast(&out, strings.NewReader(`{"version":1}`+"\n"), 1, 0, func(time.Duration) {})
+	if err == nil || !strings.Contains(err.Error(), "version 1") {
+		t.Errorf("v1 recording: err = %v", err)
+	}
+}
+
+func TestSessionRecordings_NewestLastAndExactName(t *testing.T) {
+	withTempRoot(t)
+	dir := SessionRecordingDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 10, 15, 9, 0, 0, 0, time.Local)
+	files := []string{
+		SessionRecordingPath("vibeflow_api", day.Add(time.Hour)),
+		SessionRecordingPath("api", day),
+		SessionRecordingPath("api-v2", day),
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := SessionRecordings("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "api-20261015-090000.cast"), filepath.Join(dir, "api-20261015-100000.cast")}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("recordings = %v, want %v", got, want)
+	}
+}
+
+func TestSetSessionLogging_RecordOnly(t *testing.T) {
+	tm := &TmuxManager{socketName: "vftest"}
+	tm.SetSessionLogging(SessionLogConfig{Record: true})
+	if tm.sessionLogCmd == nil {
+		t.Fatal("recording should install a pipe command")
+	}
+	got := tm.sessionLogCmd("vibeflow_claude-x")
+	for _, want := range []string{" pipe-log ", "--cast ", "claude-x-", ".cast", "'#{pane_width}'", "'#{pane_height}'"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("pipe command %q missing %q", got, want)
+		}
+	}
+	if strings.Contains(got, "--path") {
+		t.Errorf("pipe command %q writes a log that isn't enabled", got)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultShutdownPrompt is typed into every session by `vibeflow shutdown`
+// before it is killed.
+const defaultShutdownPrompt = "We are shutting down shortly. Finish the step you are on, commit your work with a descriptive message, and then stop."
+
+// shutdownPollInterval is how often `vibeflow shutdown` samples the panes
+// while agents wrap up.
+const shutdownPollInterval = 2 * time.Second
+
+func shutdownCmd() *cobra.Command {
+	var (
+		message         string
+		timeout         time.Duration
+		idleAfter       time.Duration
+		cleanupWorktree bool
+		yes             bool
+	)
+	cmd := &cobra.Command{
+		Use:   "shutdown [session-name...]",
+		Short: "Ask agents to wrap up, then kill their sessions",
+		Long: `Shut sessions down gracefully: type a "finish and commit your work" prompt
+into each one, wait until its pane goes idle (or --timeout passes), then kill
+the session and remove its session file. Without names every session is
+shut down. Worktrees are kept unless --cleanup-worktree is given.`,
+		ValidArgsFunction: sessionCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+
+			sessions, err := shutdownTargets(tmux, args)
+			if err != nil {
+				return err
+			}
+			if len(sessions) == 0 {
+				fmt.Fprintln(out, "No active sessions.")
+				return nil
+			}
+			names := make([]string, len(sessions))
+			for i, s := range sessions {
+				names[i] = strings.TrimPrefix(s.Name, sessionPrefix)
+			}
+			if !yes {
+				if !stdinIsTerminal() {
+					return fmt.Errorf("shutdown needs confirmation; pass --yes to run without a terminal")
+				}
+				if !confirmShutdown(cmd.InOrStdin(), out, names, cleanupWorktree) {
+					fmt.Fprintln(out, "Aborted.")
+					return nil
+				}
+			}
+
+			// Agents whose pane has exited can't wrap up; they are only killed.
+			var asked []string
+			for _, s := range sessions {
+				name := strings.TrimPrefix(s.Name, sessionPrefix)
+				if s.PaneDead {
+					continue
+				}
+				if err := tmux.SendKeys(name, message); err != nil {
+					fmt.Fprintf(out, "%-24s wrap-up prompt failed: %v\n", name, err)
+					continue
+				}
+				asked = append(asked, name)
+			}
+			timedOut := map[string]bool{}
+			if len(asked) > 0 {
+				fmt.Fprintf(out, "Waiting up to %s for %d session(s) to wrap up...\n", timeout, len(asked))
+				start := time.Now()
+				left := waitForWrapUp(tmux, asked, timeout, idleAfter, shutdownPollInterval, func(name string, state ActivityState) {
+					fmt.Fprintf(out, "%-24s %s (%s)\n", name, state, formatSessionDuration(time.Since(start)))
+				})
+				for _, name := range left {
+					timedOut[name] = true
+				}
+			}
+
+			hooks := NewHookRunner(cfg.Hooks, tmux, stderrWarnf)
+			defer hooks.Wait()
+			cache := NewSessionCache()
+			for _, name := range names {
+				if err := teardownSession(cfg, tmux, store, hooks, cache, name, cleanupWorktree); err != nil {
+					fmt.Fprintf(out, "%-24s kill failed: %v\n", name, err)
+					continue
+				}
+				if timedOut[name] {
+					fmt.Fprintf(out, "%-24s killed (still busy at timeout)\n", name)
+				} else {
+					fmt.Fprintf(out, "%-24s killed\n", name)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&message, "message", "m", defaultShutdownPrompt, "Wrap-up prompt typed into each session")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 5*time.Minute, "How long to wait for agents to wrap up")
+	cmd.Flags().DurationVar(&idleAfter, "idle", defaultIdleAfter, "How long a pane must stay unchanged to count as done")
+	cmd.Flags().BoolVar(&cleanupWorktree, "cleanup-worktree", false, "Also remove the sessions' git worktrees")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	return cmd
+}
+
+// shutdownTargets returns the running sessions named in args, or every
+// running session when args is empty. Workbench holders are left out: they
+// are not agents, and killing one takes the panes joined into it along.
+func shutdownTargets(tmux *TmuxManager, args []string) ([]TmuxSession, error) {
+	all, err := tmux.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	var sessions []TmuxSession
+	for _, s := range all {
+		if !isWorkbenchHolder(s.Name) {
+			sessions = append(sessions, s)
+		}
+	}
+	if len(args) == 0 {
+		return sessions, nil
+	}
+	byName := make(map[string]TmuxSession, len(sessions))
+	for _, s := range sessions {
+		byName[strings.TrimPrefix(s.Name, sessionPrefix)] = s
+	}
+	var out []TmuxSession
+	for _, name := range args {
+		s, ok := byName[strings.TrimPrefix(name, sessionPrefix)]
+		if !ok {
+			return nil, fmt.Errorf("session %q is not running", name)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// confirmShutdown lists the sessions about to be shut down and asks for a
+// yes on in. Anything but y/yes, including end of input, declines.
+func confirmShutdown(in io.Reader, out io.Writer, names []string, cleanupWorktree bool) bool {
+	fmt.Fprintf(out, "Shut down %d session(s): %s\n", len(names), strings.Join(names, ", "))
+	if cleanupWorktree {
+		fmt.Fprintln(out, "Their worktrees will be REMOVED, including uncommitted changes.")
+	}
+	fmt.Fprint(out, "Continue? [y/N] ")
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// waitForWrapUp samples the panes of names every interval until each has
+// stayed unchanged for idleAfter or gone away, or timeout passes. report is
+// called whenever a session's activity changes. It returns the sessions
+// still busy at the timeout, sorted.
+func waitForWrapUp(tmux *TmuxManager, names []string, timeout, idleAfter, interval time.Duration, report func(name string, state ActivityState)) []string {
+	monitor := NewActivityMonitor(idleAfter)
+	last := make(map[string]ActivityState, len(names))
+	pending := append([]string(nil), names...)
+	deadline := time.Now().Add(timeout)
+	for {
+		samples := tmux.CaptureActivitySamples(pending, 30)
+		now := time.Now()
+		var busy []string
+		for _, name := range pending {
+			sample, ok := samples[name]
+			if !ok {
+				continue // the agent exited and took its session with it
+			}
+			state := monitor.Observe(name, sample.Output, sample.Cursor, now)
+			if state != last[name] && state != ActivityUnknown {
+				last[name] = state
+				report(name, state)
+			}
+			if state != ActivityIdle {
+				busy = append(busy, name)
+			}
+		}
+		pending = busy
+		if len(pending) == 0 || !now.Before(deadline) {
+			sort.Strings(pending)
+			return pending
+		}
+		wait := interval
+		if left := time.Until(deadline); left < wait {
+			wait = left
+		}
+		time.Sleep(wait)
+	}
+}
+
+// teardownSession kills a session and forgets it: the store entry, the
+// session file in its working directory and, with cleanupWorktree, its
+// worktree. name is the tmux session name, with or without the prefix; the
+// store entry is found by it, since launched sessions are stored under
+// their base name.
+func teardownSession(cfg *Config, tmux *TmuxManager, store *Store, hooks *HookRunner, cache *SessionCache, name string, cleanupWorktree bool) error {
+	meta, found, _ := store.GetByTmux(tmux.ensurePrefix(name))
+	if !found {
+		meta, found, _ = store.Get(name)
+	}
+	if tmux.HasSession(name) {
+		if found {
+			saveArchiveTail(cfg, tmux, store, meta)
+		}
+		if err := tmux.KillSession(name); err != nil {
+			return err
+		}
+	}
+	if found {
+		if meta.WorkingDir != "" {
+			RemoveSessionFile(meta.WorkingDir, meta.Persona)
+		}
+		if cleanupWorktree && meta.WorktreePath != "" {
+			if wm, err := NewWorktreeManager(mainRepoDir(meta.WorktreePath), cfg.Worktree.BaseDir); err == nil {
+				if err := wm.Remove(meta.WorktreePath, true); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree: %v\n", err)
+				}
+			}
+		}
+		_ = store.History().End(meta.Name, ExitKilled, time.Now())
+		_ = store.Remove(meta.Name)
+		_ = cache.Remove(meta.Name)
+		hooks.Fire(HookSessionKill, meta, nil)
+	}
+	_ = cache.Remove(name)
+	return nil
+}
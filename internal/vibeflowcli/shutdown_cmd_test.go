@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForWrapUp_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-shutdown")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-quiet", "sh -c 'echo ready; sleep 30'"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-busy", "sh -c 'while true; do date +%s%N; sleep 0.1; done'"); err != nil {
+		t.Fatal(err)
+	}
+	waitForPane(t, tm, "claude-quiet", "ready")
+
+	var reports []string
+	left := waitForWrapUp(tm, []string{"claude-quiet", "claude-busy"}, 1500*time.Millisecond, 300*time.Millisecond, 100*time.Millisecond,
+		func(name string, state ActivityState) { reports = append(reports, name+" "+state.String()) })
+	if len(left) != 1 || left[0] != "claude-busy" {
+		t.Errorf("still busy = %v, want [claude-busy]", left)
+	}
+	if !strings.Contains(strings.Join(reports, "\n"), "claude-quiet idle") {
+		t.Errorf("reports = %q, want claude-quiet reported idle", reports)
+	}
+}
+
+func TestTeardownSession_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("VIBEFLOW_ROOT", t.TempDir())
+	tm := NewTmuxManager("vftest-teardown")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-down", "sleep 30"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	dir := t.TempDir()
+	if err := WriteSessionFile(dir, "developer", "sess-1"); err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore()
+	addLaunchedSession(t, store, SessionMeta{Name: "down", Provider: "claude", Persona: "developer", WorkingDir: dir, CreatedAt: time.Now()})
+	hooks := NewHookRunner(HooksConfig{}, nil, stderrWarnf)
+	cache := NewSessionCacheWithPath(filepath.Join(t.TempDir(), "cache.json"))
+
+	if err := teardownSession(&Config{}, tm, store, hooks, cache, "claude-down", false); err != nil {
+		t.Fatal(err)
+	}
+	if tm.HasSession("claude-down") {
+		t.Error("tmux session still running")
+	}
+	if _, found, _ := store.Get("down"); found {
+		t.Error("store entry kept")
+	}
+	if entries, _ := store.History().List(); len(entries) != 1 || entries[0].ExitReason != ExitKilled {
+		t.Errorf("history = %+v, want the session ended as killed", entries)
+	}
+	if _, err := os.Stat(filepath.Join(dir, sessionFileForPersona("developer"))); !os.IsNotExist(err) {
+		t.Errorf("session file kept (stat err %v)", err)
+	}
+}
+
+func TestShutdownTargets_SkipsWorkbenchHolder_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-targets")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	for _, name := range []string{"vibeflow_claude-a", workbenchHolderName} {
+		if _, err := tm.run("new-session", "-d", "-s", name, "sleep 30"); err != nil {
+			t.Skipf("cannot create tmux session: %v", err)
+		}
+	}
+	sessions, err := shutdownTargets(tm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].Name != "vibeflow_claude-a" {
+		t.Errorf("targets = %+v, want only the agent session", sessions)
+	}
+	if _, err := shutdownTargets(tm, []string{strings.TrimPrefix(workbenchHolderName, sessionPrefix)}); err == nil {
+		t.Error("the workbench holder was accepted as a shutdown target")
+	}
+}
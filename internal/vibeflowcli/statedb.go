@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// stateDBTimeout bounds how long an operation waits for another vibeflow
+// process to finish its transaction on the same database.
+const stateDBTimeout = 5 * time.Second
+
+// errUnchanged is returned from an update callback that made no changes, so
+// the transaction is rolled back instead of committed and fsynced.
+var errUnchanged = errors.New("unchanged")
+
+// updateStateDB runs fn in a read-write transaction on the bbolt database at
+// path, creating it if needed. The database is opened for the duration of
+// the call only: bbolt holds an exclusive flock while a writable handle is
+// open, and the C-q popup runs a second vibeflow against the same root.
+func updateStateDB(path string, fn func(*bolt.Tx) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: stateDBTimeout})
+	if err != nil {
+		return fmt.Errorf("open state db: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.Update(fn); err != nil && !errors.Is(err, errUnchanged) {
+		return err
+	}
+	return nil
+}
+
+// viewStateDB runs fn in a read-only transaction under a shared lock, so
+// readers in different processes don't block each other. A missing or
+// empty database is left alone and fn is not called.
+func viewStateDB(path string, fn func(*bolt.Tx) error) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("stat state db: %w", err)
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: stateDBTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("open state db: %w", err)
+	}
+	defer db.Close()
+	return db.View(fn)
+}
+
+// seqKey encodes a bucket sequence number as a key that sorts in insertion order.
+func seqKey(n uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, n)
+	return k
+}
+
+// importLegacyJSON creates bucket in tx and, if it did not exist yet, loads
+// the JSON array at legacyPath into it with load. It reports whether the
+// legacy file was imported, so the caller can retire it once the
+// transaction has committed.
+func importLegacyJSON(tx *bolt.Tx, bucket []byte, legacyPath string, load func(data []byte) error) (bool, error) {
+	if tx.Bucket(bucket) != nil {
+		return false, nil
+	}
+	if _, err := tx.CreateBucket(bucket); err != nil {
+		return false, fmt.Errorf("create %s bucket: %w", bucket, err)
+	}
+	if legacyPath == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(legacyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read %s: %w", filepath.Base(legacyPath), err)
+	}
+	if len(data) > 0 {
+		if err := load(data); err != nil {
+			return false, fmt.Errorf("import %s: %w", filepath.Base(legacyPath), err)
+		}
+	}
+	return true, nil
+}
+
+// retireLegacyJSON renames an imported legacy file out of the way so it is
+// not mistaken for live state.
+func retireLegacyJSON(path string) {
+	_ = os.Rename(path, path+".migrated")
+}
+
+// legacyExists reports whether a legacy JSON file is still waiting to be imported.
+func legacyExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
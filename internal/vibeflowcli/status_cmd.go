@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// healthDead is the health of a session whose agent process has exited.
+// It sits beside the HealthStatus labels in `vibeflow status`, as do
+// healthPaused and healthDone for a dead pane that is expected: the timeout
+// policy stopped the agent, or it exited after finishing its task.
+const (
+	healthDead   = "dead"
+	healthPaused = "paused"
+	healthDone   = "done"
+)
+
+// statusRow is one session in `vibeflow status`.
+type statusRow struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider,omitempty"`
+	Persona  string `json:"persona,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Status   string `json:"status"`           // running, attached or exited
+	Health   string `json:"health"`           // healthy, error_detected, failed, dead, paused or done
+	Detail   string `json:"detail,omitempty"` // matched error pattern or exit status
+}
+
+// unhealthy reports whether the row should fail `status --exit-code`.
+func (r statusRow) unhealthy() bool {
+	return r.Health == HealthFailed.String() || r.Health == healthDead
+}
+
+// sessionStatusRows checks every live session once. Health comes from the
+// pane: a dead pane is dead, a fatal error pattern in the last lines is
+// failed, and a recoverable one is error_detected. A dead pane is paused
+// rather than dead while the timeout policy has the agent stopped, and done
+// when the agent signalled completion or exited the way completion says a
+// finished one does. A session the TUI's health monitor gave up on stays
+// failed even once its pane looks clean.
+func sessionStatusRows(tm *TmuxManager, store *Store, registry *ErrorPatternRegistry, completion *completionDetector) ([]statusRow, error) {
+	live, err := tm.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	metas := make(map[string]SessionMeta)
+	if list, err := store.List(); err == nil {
+		for _, meta := range list {
+			metas[meta.TmuxSession] = meta
+		}
+	}
+	saved, _ := store.Health()
+	var rows []statusRow
+	for _, ts := range live {
+		if isWorkbenchHolder(ts.Name) {
+			continue
+		}
+		row := statusRow{
+			Name:   strings.TrimPrefix(ts.Name, sessionPrefix),
+			Status: sessionStatus(ts.Attached, ts.PaneDead),
+		}
+		meta, ok := metas[ts.Name]
+		if ok {
+			row.Provider, row.Persona, row.Branch = meta.Provider, meta.Persona, meta.Branch
+		}
+		row.Health, row.Detail, _ = probePaneHealth(tm, ts, row.Provider, registry)
+		if ok && ts.PaneDead {
+			switch {
+			case !meta.PausedAt.IsZero():
+				row.Health = healthPaused
+			case !meta.DoneAt.IsZero() || completion.ExitDone(meta.Provider, ts.ExitStatus):
+				row.Health = healthDone
+			}
+		}
+		if h, ok := saved[ts.Name]; ok && h.Status == HealthFailed && !ts.PaneDead {
+			row.Health, row.Detail = HealthFailed.String(), h.Pattern
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// probePaneHealth reads a live session's health from its pane, as
+// described on sessionStatusRows. output is the captured pane, empty for a
+// dead pane or when the capture fails.
+func probePaneHealth(tm *TmuxManager, ts TmuxSession, provider string, registry *ErrorPatternRegistry) (health, detail, output string) {
+	if ts.PaneDead {
+		detail = "exited"
+		if ts.ExitStatus != "" {
+			detail = "exit status " + ts.ExitStatus
+		}
+		return healthDead, detail, ""
+	}
+	output, err := tm.CapturePaneOutput(ts.Name, 50)
+	if err != nil {
+		return HealthHealthy.String(), "", ""
+	}
+	if match := registry.Match(provider, lastNLines(stripANSI(output), 10)); match != nil {
+		health = HealthErrorDetected.String()
+		if match.Severity == SeverityFatal {
+			health = HealthFailed.String()
+		}
+		return health, match.Description, output
+	}
+	return HealthHealthy.String(), "", output
+}
+
+// printStatus writes the `vibeflow status` table.
+func printStatus(out io.Writer, rows []statusRow) {
+	fmt.Fprintf(out, "%-24s %-10s %-16s %-9s %-15s %s\n", "NAME", "PROVIDER", "PERSONA", "STATUS", "HEALTH", "DETAIL")
+	fmt.Fprintln(out, strings.Repeat("-", 90))
+	for _, r := range rows {
+		fmt.Fprintf(out, "%-24s %-10s %-16s %-9s %-15s %s\n", truncate(r.Name, 24), orDash(r.Provider), orDash(r.Persona), r.Status, r.Health, r.Detail)
+	}
+}
+
+// orDash returns s, or "-" when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// --- status ---
+
+func statusCmd() *cobra.Command {
+	var exitCode, asJSON bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show every session with its health, for scripts and CI",
+		Long: `Show every session with its health. Health is read from the pane right
+now: "dead" when the agent process has exited, "failed" when the last lines
+match a fatal error pattern or the TUI's auto-recovery gave up on it,
+"error_detected" for a recoverable one, else "healthy". An exited agent is
+"paused" instead while the timeout policy has it stopped, and "done" once it
+finished its task. With --exit-code the command exits 1 when any session is
+failed or dead, so a cron job or CI watchdog can alert on it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			var rows []statusRow
+			if tmux.ServerRunning() {
+				completion, err := newCompletionDetector(cfg.AutoPR, registry)
+				if err != nil {
+					stderrWarnf("%v; using the default completion marker", err)
+					completion, _ = newCompletionDetector(AutoPRConfig{}, nil)
+				}
+				if rows, err = sessionStatusRows(tmux, store, NewErrorPatternRegistryWithFile(DefaultErrorPatternsPath()), completion); err != nil {
+					return err
+				}
+			}
+			out := cmd.OutOrStdout()
+			switch {
+			case asJSON:
+				if rows == nil {
+					rows = []statusRow{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(rows); err != nil {
+					return err
+				}
+			case len(rows) == 0:
+				fmt.Fprintln(out, "No active sessions.")
+			default:
+				printStatus(out, rows)
+			}
+			if !exitCode {
+				return nil
+			}
+			var bad []string
+			for _, r := range rows {
+				if r.unhealthy() {
+					bad = append(bad, r.Name)
+				}
+			}
+			if len(bad) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d unhealthy session(s): %s", len(bad), strings.Join(bad, ", "))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit 1 when any session is failed or has a dead pane")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the sessions as JSON")
+	return cmd
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusRowUnhealthy(t *testing.T) {
+	for health, want := range map[string]bool{
+		"healthy":        false,
+		"error_detected": false,
+		"failed":         true,
+		"dead":           true,
+		"paused":         false,
+		"done":           false,
+	} {
+		if got := (statusRow{Health: health}).unhealthy(); got != want {
+			t.Errorf("%s: unhealthy = %v, want %v", health, got, want)
+		}
+	}
+}
+
+func TestSessionStatusRows_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-status")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	dir := t.TempDir()
+	for name, command := range map[string]string{
+		"ok":       "sleep 300",
+		"given-up": "sleep 300", // clean pane, but recovery gave up on it
+		"boom":     "printf 'BOOM\\n'; sleep 300",
+		"slow":     "printf 'please slow down\\n'; sleep 300",
+		"gone":     "sleep 0.5; exit 3", // outlives the remain-on-exit setup
+		"paused":   "sleep 0.5; exit 143",
+		"finished": "sleep 0.5; exit 0",
+		"exited":   "sleep 0.5; exit 0", // exits cleanly; the provider counts that as done
+	} {
+		if err := tm.CreateSession(name, dir, command); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+	waitForPane(t, tm, "boom", "BOOM")
+	waitForPane(t, tm, "slow", "slow down")
+	for _, name := range []string{"gone", "paused", "finished", "exited"} {
+		for i := 0; i < 250; i++ {
+			out, _ := tm.run("display-message", "-p", "-t", "vibeflow_"+name, "#{pane_dead}")
+			if strings.TrimSpace(out) == "1" {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "sessions.db"))
+	if err := store.Add(SessionMeta{Name: "ok", TmuxSession: "vibeflow_ok", Provider: "claude", Persona: "developer", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	for _, meta := range []SessionMeta{
+		{Name: "paused", TmuxSession: "vibeflow_paused", Provider: "claude", PausedAt: time.Now()},
+		{Name: "finished", TmuxSession: "vibeflow_finished", Provider: "claude", DoneAt: time.Now()},
+		{Name: "exited", TmuxSession: "vibeflow_exited", Provider: "fake"},
+	} {
+		if err := store.Add(meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.SetHealth("vibeflow_given-up", SavedHealth{Status: HealthFailed, Pattern: "boom"}); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewErrorPatternRegistry()
+	registry.AddPattern(ErrorPattern{Provider: "*", Regex: regexp.MustCompile(`BOOM`), Severity: SeverityFatal, Description: "boom"})
+	registry.AddPattern(ErrorPattern{Provider: "*", Regex: regexp.MustCompile(`slow down`), Description: "slow"})
+
+	cfg := DefaultConfig()
+	cfg.Providers = map[string]Provider{"fake": {Name: "Fake", Binary: "/bin/sh", DoneOnExit: true}}
+	completion, err := newCompletionDetector(AutoPRConfig{}, NewProviderRegistry(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := sessionStatusRows(tm, store, registry, completion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]statusRow)
+	for _, r := range rows {
+		got[r.Name] = r
+	}
+	want := map[string]string{"ok": "healthy", "boom": "failed", "slow": "error_detected", "gone": "dead", "given-up": "failed",
+		"paused": "paused", "finished": "done"}
+	for name, health := range want {
+		if got[name].Health != health {
+			t.Errorf("%s health = %q (%+v), want %q", name, got[name].Health, got[name], health)
+		}
+	}
+	if got["ok"].Persona != "developer" {
+		t.Errorf("ok row lost its store metadata: %+v", got["ok"])
+	}
+	// tmux doesn't always record the status of a pane that exits early.
+	if d := got["gone"].Detail; d != "exit status 3" && d != "exited" {
+		t.Errorf("gone detail = %q", got["gone"].Detail)
+	}
+	if r := got["exited"]; r.Detail == "exit status 0" && r.Health != "done" {
+		t.Errorf("clean exit of a done-on-exit provider: %+v, want done", r)
+	}
+
+	var out bytes.Buffer
+	printStatus(&out, rows)
+	if !strings.Contains(out.String(), "HEALTH") || !strings.Contains(out.String(), "boom") {
+		t.Errorf("table:\n%s", out.String())
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// statusEvent is a session moving from one status to another between two
+// refreshes.
+type statusEvent struct {
+	Session string
+	From    string
+	To      string
+}
+
+// statusTracker remembers the last status seen for each session and turns
+// successive snapshots into transition events, so consumers react to a
+// change once instead of re-deriving it from every refresh.
+type statusTracker struct {
+	last map[string]string
+}
+
+func newStatusTracker() *statusTracker {
+	return &statusTracker{last: make(map[string]string)}
+}
+
+// observe records the current status of every live session and returns the
+// transitions since the previous call, ordered by session name. A session
+// seen for the first time sets its baseline without an event, so a TUI
+// start doesn't report every session at once; sessions missing from
+// current are forgotten.
+func (t *statusTracker) observe(current map[string]string) []statusEvent {
+	var events []statusEvent
+	for name, status := range current {
+		if prev, ok := t.last[name]; ok && prev != status {
+			events = append(events, statusEvent{Session: name, From: prev, To: status})
+		}
+		t.last[name] = status
+	}
+	for name := range t.last {
+		if _, ok := current[name]; !ok {
+			delete(t.last, name)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Session < events[j].Session })
+	return events
+}
+
+// alertStatuses are the statuses notifications.bell_on accepts, and its default.
+var alertStatuses = []string{"waiting", "error", "done"}
+
+// terminalAlert is the resolved notifications.bell setting. The zero value
+// alerts on nothing.
+type terminalAlert struct {
+	bell  bool // ring the terminal bell
+	flash bool // briefly recolor the terminal background
+	on    map[string]bool
+}
+
+// newTerminalAlert validates the bell settings of cfg.
+func newTerminalAlert(cfg NotificationConfig) (terminalAlert, error) {
+	var a terminalAlert
+	switch strings.ToLower(cfg.Bell) {
+	case "", "off":
+		return a, nil
+	case "bell":
+		a.bell = true
+	case "flash":
+		a.flash = true
+	case "both":
+		a.bell, a.flash = true, true
+	default:
+		return terminalAlert{}, fmt.Errorf("notifications.bell: unknown value %q (want off, bell, flash or both)", cfg.Bell)
+	}
+	on := cfg.BellOn
+	if len(on) == 0 {
+		on = alertStatuses
+	}
+	a.on = make(map[string]bool, len(on))
+	for _, status := range on {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !slices.Contains(alertStatuses, status) {
+			return terminalAlert{}, fmt.Errorf("notifications.bell_on: unknown status %q (want %s)", status, strings.Join(alertStatuses, ", "))
+		}
+		a.on[status] = true
+	}
+	return a, nil
+}
+
+// enabled reports whether the alert does anything.
+func (a terminalAlert) enabled() bool {
+	return a.bell || a.flash
+}
+
+// matching returns the events that move a session into an alerting status.
+func (a terminalAlert) matching(events []statusEvent) []statusEvent {
+	var hits []statusEvent
+	for _, e := range events {
+		if a.on[e.To] {
+			hits = append(hits, e)
+		}
+	}
+	return hits
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"reflect"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestStatusTracker_Observe(t *testing.T) {
+	tr := newStatusTracker()
+	if ev := tr.observe(map[string]string{"a": "working", "b": "idle"}); len(ev) != 0 {
+		t.Fatalf("first snapshot reported %v; want a silent baseline", ev)
+	}
+	if ev := tr.observe(map[string]string{"a": "working", "b": "idle"}); len(ev) != 0 {
+		t.Errorf("unchanged snapshot reported %v", ev)
+	}
+	got := tr.observe(map[string]string{"a": "waiting", "b": "done", "c": "working"})
+	want := []statusEvent{{"a", "working", "waiting"}, {"b", "idle", "done"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	// A session that went away and came back starts from a new baseline.
+	tr.observe(map[string]string{"c": "working"})
+	if ev := tr.observe(map[string]string{"a": "error", "c": "working"}); len(ev) != 0 {
+		t.Errorf("returning session reported %v", ev)
+	}
+}
+
+func TestNewTerminalAlert(t *testing.T) {
+	a, err := newTerminalAlert(NotificationConfig{})
+	if err != nil || a.enabled() {
+		t.Errorf("default alert = %+v, %v; want off", a, err)
+	}
+	a, err = newTerminalAlert(NotificationConfig{Bell: "both"})
+	if err != nil || !a.bell || !a.flash || !a.on["waiting"] || !a.on["error"] || !a.on["done"] {
+		t.Errorf("both = %+v, %v; want bell and flash on every status", a, err)
+	}
+	a, err = newTerminalAlert(NotificationConfig{Bell: "flash", BellOn: []string{"Error"}})
+	if err != nil || a.bell || !a.flash || a.on["waiting"] || !a.on["error"] {
+		t.Errorf("flash on error = %+v, %v", a, err)
+	}
+	events := []statusEvent{{"a", "working", "waiting"}, {"b", "working", "error"}, {"c", "error", "working"}}
+	if hits := a.matching(events); len(hits) != 1 || hits[0].Session != "b" {
+		t.Errorf("matching = %v, want only b", hits)
+	}
+
+	for _, cfg := range []NotificationConfig{{Bell: "loud"}, {Bell: "bell", BellOn: []string{"idle"}}} {
+		if _, err := newTerminalAlert(cfg); err == nil {
+			t.Errorf("%+v: expected an error", cfg)
+		}
+	}
+}
+
+func TestModelAlertsOnlyWhileBlurred(t *testing.T) {
+	m := bulkTestModel(t)
+	m.statusEvents = newStatusTracker()
+	m.alert, _ = newTerminalAlert(NotificationConfig{Bell: "both"})
+	m, _ = m.alertStatusChanges()
+
+	nm, _ := m.Update(tea.BlurMsg{})
+	m = nm.(Model)
+	if !m.blurred {
+		t.Fatal("blur not recorded")
+	}
+	m.sessions[0].Status = "idle"
+	if m, cmd := m.alertStatusChanges(); cmd != nil || m.flashing {
+		t.Error("alerted for a status that isn't in bell_on")
+	}
+	m.sessions[1].Status = "waiting"
+	m, cmd := m.alertStatusChanges()
+	if cmd == nil || !m.flashing {
+		t.Fatal("no alert for a session that started waiting")
+	}
+	if v := m.View(); v.BackgroundColor == nil {
+		t.Error("flash doesn't recolor the background")
+	}
+	nm, _ = m.Update(flashEndMsg{})
+	m = nm.(Model)
+	if m.flashing {
+		t.Error("flash not ended")
+	}
+
+	nm, _ = m.Update(tea.FocusMsg{})
+	m = nm.(Model)
+	m.sessions[2].Status = "done"
+	if _, cmd := m.alertStatusChanges(); cmd != nil {
+		t.Error("alerted while the TUI has focus")
+	}
+}
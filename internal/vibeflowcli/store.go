@@ -18,10 +18,13 @@ package vibeflowcli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
+	"sort"
 	"time"
+
+	bolt "go.etcd.io/bbolt"
 )
 
 // SessionMeta holds metadata for a vibeflow-cli session that tmux alone
@@ -32,8 +35,14 @@ type SessionMeta struct {
 	Provider          string           `json:"provider"`
 	Project           string           `json:"project"`
 	ProjectID         int64            `json:"project_id,omitempty"`
+	IssueID           int64            `json:"issue_id,omitempty"`
+	IssuePrompt       string           `json:"issue_prompt,omitempty"`
+	PRURL             string           `json:"pr_url,omitempty"` // pull request opened from the session's branch
+	Group             string           `json:"group,omitempty"`  // user-defined group in the grouped view
+	Note              string           `json:"note,omitempty"`   // free-text reminder of what the session is doing
 	Persona           string           `json:"persona,omitempty"`
 	Branch            string           `json:"branch"`
+	NewBranch         bool             `json:"new_branch,omitempty"` // vibeflow created Branch for this session
 	WorktreePath      string           `json:"worktree_path,omitempty"`
 	WorkingDir        string           `json:"working_dir"`
 	VibeFlowSessionID string           `json:"vibeflow_session_id,omitempty"`
@@ -42,37 +51,105 @@ type SessionMeta struct {
 	CloudDispatch     bool             `json:"cloud_dispatch,omitempty"`
 	SkipPermissions   bool             `json:"skip_permissions,omitempty"`
 	Model             string           `json:"model,omitempty"`
+	ProviderVersion   string           `json:"provider_version,omitempty"`
+	Profile           string           `json:"profile,omitempty"`      // credential profile (Config.Profiles); "" = default login
+	MaxLifetime       string           `json:"max_lifetime,omitempty"` // overrides timeouts.max_lifetime ("off" = none)
+	MaxIdle           string           `json:"max_idle,omitempty"`     // overrides timeouts.max_idle ("off" = none)
+	PausedAt          time.Time        `json:"paused_at,omitzero"`     // set while the timeout policy has the agent stopped
+	QueuedAt          time.Time        `json:"queued_at,omitzero"`     // set while waiting for a slot under max_running_sessions
+	DoneAt            time.Time        `json:"done_at,omitzero"`       // when the agent signalled completion; cleared on relaunch
 	LLMGatewayEnabled bool             `json:"llm_gateway_enabled,omitempty"`
 	MCPToolName       string           `json:"mcp_tool_name,omitempty"`
 	OpenShell         *OpenShellConfig `json:"openshell,omitempty"`
+	Command           string           `json:"command,omitempty"`  // launch command, secrets redacted
+	EnvVars           []string         `json:"env_vars,omitempty"` // names of the session's env vars; values are never stored
 	CreatedAt         time.Time        `json:"created_at"`
+	LastAttachedAt    time.Time        `json:"last_attached_at,omitzero"`
+}
+
+// envVarNames returns the sorted names of env, for SessionMeta.EnvVars.
+func envVarNames(env map[string]string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(env))
+	for k := range env {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
 }
 
-// Store persists session metadata to a JSON file with file-level locking
-// for concurrency safety.
+// lastActive returns when the session was last attached, or when it was
+// launched if it has never been attached.
+func (m SessionMeta) lastActive() time.Time {
+	if m.LastAttachedAt.After(m.CreatedAt) {
+		return m.LastAttachedAt
+	}
+	return m.CreatedAt
+}
+
+// Store persists session metadata in the "sessions" bucket of the state
+// database (vibeflow.db). Every call is a single bbolt transaction, so
+// several vibeflow processes on the same root can share it safely.
 type Store struct {
-	path string
+	path    string
+	legacy  string          // sessions.json imported on first use; empty for NewStoreWithPath
+	history *SessionHistory // nil for stores created with NewStoreWithPath
 }
 
-// DefaultStorePath returns the default sessions.json path under the root directory.
+var (
+	sessionsBucket     = []byte("sessions")      // sequence key → SessionMeta JSON, in insertion order
+	sessionNamesBucket = []byte("session_names") // session name → sequence key
+	groupsBucket       = []byte("groups")        // group name → SessionGroup JSON
+	healthBucket       = []byte("health")        // tmux session name → SavedHealth JSON
+)
+
+// DefaultStorePath returns the default state database path under the root directory.
 func DefaultStorePath() string {
-	return filepath.Join(RootDir(), "sessions.json")
+	return filepath.Join(RootDir(), "vibeflow.db")
 }
 
-// NewStore creates a Store that reads/writes the default sessions file.
+// NewStore creates a Store backed by the default state database. Sessions
+// from a sessions.json written by an older vibeflow are imported on first use.
 func NewStore() *Store {
-	return &Store{path: DefaultStorePath()}
+	return &Store{
+		path:    DefaultStorePath(),
+		legacy:  filepath.Join(RootDir(), "sessions.json"),
+		history: NewSessionHistory(),
+	}
 }
 
-// NewStoreWithPath creates a Store backed by a custom file path.
+// NewStoreWithPath creates a Store backed by a custom database path.
 func NewStoreWithPath(path string) *Store {
 	return &Store{path: path}
 }
 
-// List returns all stored session metadata entries.
+// History returns the session history the store records launches and
+// removals into, or nil when it has none.
+func (s *Store) History() *SessionHistory {
+	if s == nil {
+		return nil
+	}
+	return s.history
+}
+
+// List returns all stored session metadata entries, oldest first.
 func (s *Store) List() ([]SessionMeta, error) {
-	sessions, err := s.withLock(func(sessions []SessionMeta) ([]SessionMeta, error) {
-		return sessions, nil
+	var sessions []SessionMeta
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sessionsBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var m SessionMeta
+			if err := json.Unmarshal(v, &m); err != nil {
+				return fmt.Errorf("parse store: %w", err)
+			}
+			sessions = append(sessions, m)
+			return nil
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -81,62 +158,381 @@ func (s *Store) List() ([]SessionMeta, error) {
 }
 
 // HasSessions reports whether the store currently holds at least one session
-// entry. Unlike List, it does not rewrite the file on read (List goes through
-// withLock, which always writes the result back) — so probing an empty root
-// never creates sessions.json as a side effect. A missing file counts as no
-// sessions. Safe to call lockless at startup: the TUI holds the singleton PID
-// lock by this point.
+// entry. A missing database counts as no sessions and is not created, so
+// probing an empty root never gains it state (which would stop the setup
+// wizard from showing).
 func (s *Store) HasSessions() (bool, error) {
-	sessions, err := s.readFile()
-	if err != nil {
-		return false, err
-	}
-	return len(sessions) > 0, nil
+	has := false
+	err := s.view(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(sessionsBucket); b != nil {
+			k, _ := b.Cursor().First()
+			has = k != nil
+		}
+		return nil
+	})
+	return has, err
 }
 
 // Get returns the session metadata for the given name and whether it was found.
 func (s *Store) Get(name string) (SessionMeta, bool, error) {
-	sessions, err := s.List()
+	var (
+		meta  SessionMeta
+		found bool
+	)
+	err := s.view(func(tx *bolt.Tx) error {
+		b, idx := tx.Bucket(sessionsBucket), tx.Bucket(sessionNamesBucket)
+		if b == nil || idx == nil {
+			return nil
+		}
+		key := idx.Get([]byte(name))
+		if key == nil {
+			return nil
+		}
+		data := b.Get(key)
+		if data == nil {
+			return nil
+		}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("parse store: %w", err)
+		}
+		found = true
+		return nil
+	})
 	if err != nil {
 		return SessionMeta{}, false, err
 	}
-	for _, m := range sessions {
-		if m.Name == name {
-			return m, true, nil
+	return meta, found, nil
+}
+
+// GetByTmux returns the session metadata for the given tmux session name
+// and whether it was found.
+func (s *Store) GetByTmux(tmuxSession string) (SessionMeta, bool, error) {
+	metas, err := s.List()
+	if err != nil {
+		return SessionMeta{}, false, err
+	}
+	for _, meta := range metas {
+		if meta.TmuxSession == tmuxSession {
+			return meta, true, nil
 		}
 	}
 	return SessionMeta{}, false, nil
 }
 
 // Add appends a session to the store. If a session with the same name
-// already exists it is replaced.
+// already exists it is replaced. The launch is recorded in the history in
+// the same transaction.
 func (s *Store) Add(meta SessionMeta) error {
-	_, err := s.withLock(func(sessions []SessionMeta) ([]SessionMeta, error) {
-		// Replace existing entry with the same name.
-		out := make([]SessionMeta, 0, len(sessions)+1)
-		for _, m := range sessions {
-			if m.Name != meta.Name {
-				out = append(out, m)
+	return s.update(func(tx *bolt.Tx) error {
+		if err := putSession(tx, meta); err != nil {
+			return err
+		}
+		if s.history != nil {
+			return startHistoryEntry(tx, meta)
+		}
+		return nil
+	})
+}
+
+// MarkAttached records that the session running in tmuxSession (the full
+// tmux name) was attached at the given time. Unknown sessions are ignored.
+func (s *Store) MarkAttached(tmuxSession string, at time.Time) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sessionsBucket)
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var m SessionMeta
+			if err := json.Unmarshal(v, &m); err != nil {
+				return fmt.Errorf("parse store: %w", err)
 			}
+			if m.TmuxSession != tmuxSession {
+				continue
+			}
+			m.LastAttachedAt = at
+			data, err := json.Marshal(m)
+			if err != nil {
+				return fmt.Errorf("marshal store: %w", err)
+			}
+			// Rewrite in place so the session keeps its position in List.
+			if err := b.Put(append([]byte(nil), k...), data); err != nil {
+				return fmt.Errorf("write store: %w", err)
+			}
+			return nil
+		}
+		return errUnchanged
+	})
+}
+
+// SetPRURL records the pull request opened for the named session's branch.
+func (s *Store) SetPRURL(name, url string) error {
+	return s.updateMeta(name, func(m *SessionMeta) { m.PRURL = url })
+}
+
+// SetNote sets the named session's note; an empty note removes it.
+func (s *Store) SetNote(name, note string) error {
+	return s.updateMeta(name, func(m *SessionMeta) { m.Note = note })
+}
+
+// SetTimeouts sets the named session's own lifetime and idle limits; empty
+// values fall back to the timeouts config.
+func (s *Store) SetTimeouts(name, maxLifetime, maxIdle string) error {
+	return s.updateMeta(name, func(m *SessionMeta) {
+		m.MaxLifetime, m.MaxIdle = maxLifetime, maxIdle
+	})
+}
+
+// SetPaused records that the timeout policy stopped the named session at
+// at, or clears it when at is zero.
+func (s *Store) SetPaused(name string, at time.Time) error {
+	return s.updateMeta(name, func(m *SessionMeta) { m.PausedAt = at })
+}
+
+// SetDone records that the named session's agent finished at at, or clears
+// it when at is zero.
+func (s *Store) SetDone(name string, at time.Time) error {
+	return s.updateMeta(name, func(m *SessionMeta) { m.DoneAt = at })
+}
+
+// SetQueued records that the named session was queued at at, or — with a
+// zero at — that it started, which restarts its lifetime clock.
+func (s *Store) SetQueued(name string, at time.Time) error {
+	return s.updateMeta(name, func(m *SessionMeta) {
+		if at.IsZero() && !m.QueuedAt.IsZero() {
+			m.CreatedAt = time.Now()
 		}
-		out = append(out, meta)
-		return out, nil
+		m.QueuedAt = at
 	})
-	return err
+}
+
+// updateMeta applies fn to the stored entry for the named session.
+func (s *Store) updateMeta(name string, fn func(*SessionMeta)) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sessionsBucket)
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var m SessionMeta
+			if err := json.Unmarshal(v, &m); err != nil {
+				return fmt.Errorf("parse store: %w", err)
+			}
+			if m.Name != name {
+				continue
+			}
+			fn(&m)
+			data, err := json.Marshal(m)
+			if err != nil {
+				return fmt.Errorf("marshal store: %w", err)
+			}
+			if err := b.Put(append([]byte(nil), k...), data); err != nil {
+				return fmt.Errorf("write store: %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("session %q not found", name)
+	})
+}
+
+// SessionGroup is a user-defined group of sessions ("frontend team",
+// "experiments"), shown in the grouped view ahead of the repo-root groups.
+// Membership lives on SessionMeta.Group; the record keeps the group's
+// creation order and collapse state.
+type SessionGroup struct {
+	Name      string    `json:"name"`
+	Collapsed bool      `json:"collapsed,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SetGroup moves the named session into group, or out of any group when
+// group is empty. Groups are created on first use and dropped once no
+// session is left in them.
+func (s *Store) SetGroup(name, group string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		groups, err := tx.CreateBucketIfNotExists(groupsBucket)
+		if err != nil {
+			return fmt.Errorf("create %s bucket: %w", groupsBucket, err)
+		}
+		b := tx.Bucket(sessionsBucket)
+		found := false
+		inUse := make(map[string]bool)
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var m SessionMeta
+			if err := json.Unmarshal(v, &m); err != nil {
+				return fmt.Errorf("parse store: %w", err)
+			}
+			if m.Name == name {
+				found = true
+				m.Group = group
+				data, err := json.Marshal(m)
+				if err != nil {
+					return fmt.Errorf("marshal store: %w", err)
+				}
+				if err := b.Put(append([]byte(nil), k...), data); err != nil {
+					return fmt.Errorf("write store: %w", err)
+				}
+			}
+			if m.Group != "" {
+				inUse[m.Group] = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("session %q not found", name)
+		}
+		if group != "" && groups.Get([]byte(group)) == nil {
+			data, err := json.Marshal(SessionGroup{Name: group, CreatedAt: time.Now()})
+			if err != nil {
+				return fmt.Errorf("marshal group: %w", err)
+			}
+			if err := groups.Put([]byte(group), data); err != nil {
+				return fmt.Errorf("write group: %w", err)
+			}
+		}
+		var unused [][]byte
+		_ = groups.ForEach(func(k, _ []byte) error {
+			if !inUse[string(k)] {
+				unused = append(unused, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		for _, k := range unused {
+			_ = groups.Delete(k)
+		}
+		return nil
+	})
+}
+
+// Groups returns the user-defined groups in the order they were created.
+func (s *Store) Groups() ([]SessionGroup, error) {
+	var groups []SessionGroup
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(groupsBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var g SessionGroup
+			if err := json.Unmarshal(v, &g); err != nil {
+				return fmt.Errorf("parse group: %w", err)
+			}
+			groups = append(groups, g)
+			return nil
+		})
+	})
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].CreatedAt.Before(groups[j].CreatedAt) })
+	return groups, err
+}
+
+// SetGroupCollapsed records whether group is collapsed in the grouped view.
+// Unknown groups are ignored.
+func (s *Store) SetGroupCollapsed(group string, collapsed bool) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(groupsBucket)
+		if b == nil {
+			return errUnchanged
+		}
+		v := b.Get([]byte(group))
+		if v == nil {
+			return errUnchanged
+		}
+		var g SessionGroup
+		if err := json.Unmarshal(v, &g); err != nil {
+			return fmt.Errorf("parse group: %w", err)
+		}
+		g.Collapsed = collapsed
+		data, err := json.Marshal(g)
+		if err != nil {
+			return fmt.Errorf("marshal group: %w", err)
+		}
+		return b.Put([]byte(group), data)
+	})
+}
+
+// Health returns the saved health state of every session, keyed by tmux
+// session name.
+func (s *Store) Health() (map[string]SavedHealth, error) {
+	saved := make(map[string]SavedHealth)
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(healthBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var h SavedHealth
+			if err := json.Unmarshal(v, &h); err != nil {
+				return fmt.Errorf("parse health: %w", err)
+			}
+			saved[string(k)] = h
+			return nil
+		})
+	})
+	return saved, err
+}
+
+// SetHealth saves the health state of a session by its tmux session name.
+func (s *Store) SetHealth(tmuxSession string, h SavedHealth) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("marshal health: %w", err)
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(healthBucket)
+		if err != nil {
+			return fmt.Errorf("create %s bucket: %w", healthBucket, err)
+		}
+		return b.Put([]byte(tmuxSession), data)
+	})
+}
+
+// RemoveHealth drops the saved health state of a session. Removing the
+// session from the store drops it too.
+func (s *Store) RemoveHealth(tmuxSession string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(healthBucket)
+		if b == nil || b.Get([]byte(tmuxSession)) == nil {
+			return errUnchanged
+		}
+		return b.Delete([]byte(tmuxSession))
+	})
+}
+
+// LastActive returns the most recently active session among those whose
+// tmux session is in liveTmux: the one attached last, or launched last if
+// that is later. found is false when none of the stored sessions is live.
+func (s *Store) LastActive(liveTmux []string) (meta SessionMeta, found bool, err error) {
+	sessions, err := s.List()
+	if err != nil {
+		return SessionMeta{}, false, err
+	}
+	live := make(map[string]bool, len(liveTmux))
+	for _, name := range liveTmux {
+		live[name] = true
+	}
+	for _, m := range sessions {
+		if !live[m.TmuxSession] {
+			continue
+		}
+		if !found || m.lastActive().After(meta.lastActive()) {
+			meta, found = m, true
+		}
+	}
+	return meta, found, nil
 }
 
 // Remove deletes the session with the given name from the store.
 func (s *Store) Remove(name string) error {
-	_, err := s.withLock(func(sessions []SessionMeta) ([]SessionMeta, error) {
-		out := make([]SessionMeta, 0, len(sessions))
-		for _, m := range sessions {
-			if m.Name != name {
-				out = append(out, m)
+	return s.update(func(tx *bolt.Tx) error {
+		changed := deleteSession(tx, name)
+		if s.history != nil {
+			ended, err := endHistoryEntry(tx, name, ExitRemoved, time.Now())
+			if err != nil {
+				return err
 			}
+			changed = changed || ended
+		}
+		if !changed {
+			return errUnchanged
 		}
-		return out, nil
+		return nil
 	})
-	return err
 }
 
 // Sync removes entries whose TmuxSession is not in the activeTmux list.
@@ -147,16 +543,34 @@ func (s *Store) Sync(activeTmux []string) error {
 		active[name] = true
 	}
 
-	_, err := s.withLock(func(sessions []SessionMeta) ([]SessionMeta, error) {
-		out := make([]SessionMeta, 0, len(sessions))
-		for _, m := range sessions {
-			if active[m.TmuxSession] {
-				out = append(out, m)
+	return s.update(func(tx *bolt.Tx) error {
+		var dropped []string
+		err := tx.Bucket(sessionsBucket).ForEach(func(_, v []byte) error {
+			var m SessionMeta
+			if err := json.Unmarshal(v, &m); err != nil {
+				return fmt.Errorf("parse store: %w", err)
+			}
+			if !active[m.TmuxSession] {
+				dropped = append(dropped, m.Name)
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-		return out, nil
+		if len(dropped) == 0 {
+			return errUnchanged
+		}
+		for _, name := range dropped {
+			deleteSession(tx, name)
+			if s.history != nil {
+				if _, err := endHistoryEntry(tx, name, ExitRemoved, time.Now()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
 	})
-	return err
 }
 
 // Orphans returns the stored sessions whose TmuxSession is NOT in activeTmux.
@@ -204,74 +618,111 @@ func (s *Store) Discover(liveTmuxNames []string) []string {
 	return discovered
 }
 
-// withLock acquires an exclusive file lock, reads the current sessions,
-// calls fn with them, and writes the result back. The lock is released
-// when the function returns.
-func (s *Store) withLock(fn func([]SessionMeta) ([]SessionMeta, error)) ([]SessionMeta, error) {
-	// Ensure parent directory exists.
-	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
-		return nil, fmt.Errorf("create store dir: %w", err)
-	}
-
-	// Open (or create) the lock file alongside the data file.
-	lockPath := s.path + ".lock"
-	lf, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
-	if err != nil {
-		return nil, fmt.Errorf("open lock file: %w", err)
-	}
-	defer lf.Close()
-
-	// Acquire exclusive lock with a 5-second timeout.
-	if err := flockWithTimeout(lf, 5*time.Second); err != nil {
-		return nil, fmt.Errorf("acquire lock: %w", err)
-	}
-	defer flockRelease(lf) //nolint:errcheck
-
-	// Read current data.
-	sessions, err := s.readFile()
+// update runs fn in a read-write transaction after importing any legacy
+// JSON state. fn may return errUnchanged to roll back a no-op.
+func (s *Store) update(fn func(*bolt.Tx) error) error {
+	var imported []string
+	err := updateStateDB(s.path, func(tx *bolt.Tx) error {
+		imported = imported[:0]
+		ok, err := importLegacyJSON(tx, sessionsBucket, s.legacy, func(data []byte) error {
+			var sessions []SessionMeta
+			if err := json.Unmarshal(data, &sessions); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucketIfNotExists(sessionNamesBucket); err != nil {
+				return err
+			}
+			for _, m := range sessions {
+				if err := putSession(tx, m); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if ok {
+			imported = append(imported, s.legacy)
+		}
+		if _, err := tx.CreateBucketIfNotExists(sessionNamesBucket); err != nil {
+			return fmt.Errorf("create %s bucket: %w", sessionNamesBucket, err)
+		}
+		if s.history != nil {
+			ok, err := s.history.migrate(tx)
+			if err != nil {
+				return err
+			}
+			if ok {
+				imported = append(imported, s.history.legacy)
+			}
+		}
+		// Keep an import even when fn itself changed nothing.
+		if err := fn(tx); err != nil && !(errors.Is(err, errUnchanged) && len(imported) > 0) {
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	// Apply the mutation.
-	result, err := fn(sessions)
-	if err != nil {
-		return nil, err
+	for _, path := range imported {
+		retireLegacyJSON(path)
 	}
+	return nil
+}
 
-	// Write back.
-	if err := s.writeFile(result); err != nil {
-		return nil, err
+// view runs fn in a read-only transaction. A legacy sessions.json that has
+// not been imported yet is imported first, so readers never miss it.
+func (s *Store) view(fn func(*bolt.Tx) error) error {
+	if legacyExists(s.legacy) {
+		if err := s.update(func(*bolt.Tx) error { return errUnchanged }); err != nil {
+			return err
+		}
 	}
-	return result, nil
+	return viewStateDB(s.path, fn)
 }
 
-// readFile reads and parses the JSON sessions file. Returns an empty slice
-// if the file does not exist.
-func (s *Store) readFile() ([]SessionMeta, error) {
-	data, err := os.ReadFile(s.path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
+// putSession writes meta under a new sequence key, replacing any entry
+// with the same name, so List keeps the order sessions were (re)added in.
+func putSession(tx *bolt.Tx, meta SessionMeta) error {
+	b, idx := tx.Bucket(sessionsBucket), tx.Bucket(sessionNamesBucket)
+	if old := idx.Get([]byte(meta.Name)); old != nil {
+		if err := b.Delete(old); err != nil {
+			return fmt.Errorf("replace session: %w", err)
 		}
-		return nil, fmt.Errorf("read store: %w", err)
 	}
-	if len(data) == 0 {
-		return nil, nil
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("marshal store: %w", err)
 	}
-
-	var sessions []SessionMeta
-	if err := json.Unmarshal(data, &sessions); err != nil {
-		return nil, fmt.Errorf("parse store: %w", err)
+	n, err := b.NextSequence()
+	if err != nil {
+		return fmt.Errorf("write store: %w", err)
 	}
-	return sessions, nil
+	key := seqKey(n)
+	if err := b.Put(key, data); err != nil {
+		return fmt.Errorf("write store: %w", err)
+	}
+	return idx.Put([]byte(meta.Name), key)
 }
 
-// writeFile serialises sessions to JSON and writes to disk atomically.
-func (s *Store) writeFile(sessions []SessionMeta) error {
-	data, err := json.MarshalIndent(sessions, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal store: %w", err)
+// deleteSession removes the session with the given name and reports
+// whether it existed.
+func deleteSession(tx *bolt.Tx, name string) bool {
+	idx := tx.Bucket(sessionNamesBucket)
+	key := idx.Get([]byte(name))
+	if key == nil {
+		return false
+	}
+	b := tx.Bucket(sessionsBucket)
+	var meta SessionMeta
+	if json.Unmarshal(b.Get(key), &meta) == nil && meta.TmuxSession != "" {
+		if hb := tx.Bucket(healthBucket); hb != nil {
+			_ = hb.Delete([]byte(meta.TmuxSession))
+		}
 	}
-	return os.WriteFile(s.path, data, 0600)
+	_ = b.Delete(key)
+	_ = idx.Delete([]byte(name))
+	return true
 }
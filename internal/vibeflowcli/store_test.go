@@ -18,10 +18,14 @@ package vibeflowcli
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
+
+	bolt "go.etcd.io/bbolt"
 )
 
 func TestNewStore(t *testing.T) {
@@ -43,6 +47,16 @@ func testStore(t *testing.T) *Store {
 	return NewStoreWithPath(filepath.Join(t.TempDir(), "sessions.json"))
 }
 
+// addLaunchedSession stores meta the way a launch does: under its base
+// name, with TmuxSession the full tmux name built from its provider.
+func addLaunchedSession(t *testing.T, store *Store, meta SessionMeta) {
+	t.Helper()
+	meta.TmuxSession = (&TmuxManager{}).FullSessionName(meta.Provider, meta.Name)
+	if err := store.Add(meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestStore_ListEmpty(t *testing.T) {
 	s := testStore(t)
 	sessions, err := s.List()
@@ -292,6 +306,46 @@ func TestStore_Sync(t *testing.T) {
 	}
 }
 
+func TestStore_MarkAttachedAndLastActive(t *testing.T) {
+	s := testStore(t)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, name := range []string{"a", "b", "c"} {
+		meta := SessionMeta{Name: name, TmuxSession: "vibeflow_" + name, CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		if err := s.Add(meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+	live := []string{"vibeflow_a", "vibeflow_b"}
+
+	// Nothing attached yet: the newest live launch wins; c is not live.
+	if got, ok, err := s.LastActive(live); err != nil || !ok || got.Name != "b" {
+		t.Fatalf("LastActive = %q, %v, %v; want b", got.Name, ok, err)
+	}
+
+	if err := s.MarkAttached("vibeflow_a", base.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, _ := s.LastActive(live); !ok || got.Name != "a" {
+		t.Errorf("LastActive after attaching a = %q, want a", got.Name)
+	}
+	if err := s.MarkAttached("vibeflow_unknown", base); err != nil {
+		t.Errorf("MarkAttached on an unknown session: %v", err)
+	}
+
+	// The attach is recorded in place, without reordering the store.
+	sessions, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sessions[0].Name != "a" || !sessions[0].LastAttachedAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("first session = %+v, want a with LastAttachedAt set", sessions[0])
+	}
+
+	if _, ok, _ := s.LastActive([]string{"vibeflow_other"}); ok {
+		t.Error("LastActive with no live stored session: found = true, want false")
+	}
+}
+
 func TestStore_SyncEmptyActive(t *testing.T) {
 	s := testStore(t)
 
@@ -406,14 +460,20 @@ func TestStore_WriteFileJSON(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Read the raw file and verify it's valid indented JSON.
-	data, err := os.ReadFile(s.path)
-	if err != nil {
-		t.Fatal(err)
-	}
+	// Read the raw records and verify each one is valid JSON.
 	var parsed []SessionMeta
-	if err := json.Unmarshal(data, &parsed); err != nil {
-		t.Fatalf("stored file is not valid JSON: %v", err)
+	err := viewStateDB(s.path, func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).ForEach(func(_, v []byte) error {
+			var m SessionMeta
+			if err := json.Unmarshal(v, &m); err != nil {
+				return err
+			}
+			parsed = append(parsed, m)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("stored record is not valid JSON: %v", err)
 	}
 	if len(parsed) != 1 {
 		t.Fatalf("expected 1 entry in JSON, got %d", len(parsed))
@@ -423,6 +483,70 @@ func TestStore_WriteFileJSON(t *testing.T) {
 	}
 }
 
+func TestStore_ImportsLegacyJSON(t *testing.T) {
+	withTempRoot(t)
+	legacy := []SessionMeta{
+		{Name: "a", TmuxSession: "vibeflow_a", CreatedAt: time.Now().Add(-time.Hour)},
+		{Name: "b", TmuxSession: "vibeflow_b", CreatedAt: time.Now()},
+	}
+	data, _ := json.Marshal(legacy)
+	sessionsPath := filepath.Join(RootDir(), "sessions.json")
+	if err := os.WriteFile(sessionsPath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	history, _ := json.Marshal([]HistoryEntry{{SessionMeta: legacy[0]}, {SessionMeta: legacy[1]}})
+	if err := os.WriteFile(filepath.Join(RootDir(), "history.json"), history, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStore()
+	has, err := s.HasSessions()
+	if err != nil || !has {
+		t.Fatalf("HasSessions = %v, %v; want the legacy sessions", has, err)
+	}
+	sessions, _ := s.List()
+	if len(sessions) != 2 || sessions[0].Name != "a" || sessions[1].Name != "b" {
+		t.Fatalf("imported sessions = %+v", sessions)
+	}
+	if _, err := os.Stat(sessionsPath); !os.IsNotExist(err) {
+		t.Errorf("sessions.json should be retired after import; stat err=%v", err)
+	}
+
+	// Imported open history entries are still indexed by name.
+	if err := s.Remove("a"); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := s.History().List()
+	if len(entries) != 2 || entries[0].ExitReason != ExitRemoved || !entries[1].Open() {
+		t.Errorf("history after import = %+v", entries)
+	}
+}
+
+func TestStore_ConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vibeflow.db")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Separate Store values stand in for separate vibeflow processes.
+			s := NewStoreWithPath(path)
+			if err := s.Add(SessionMeta{Name: fmt.Sprintf("s%d", i)}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	sessions, err := NewStoreWithPath(path).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 8 {
+		t.Errorf("got %d sessions after concurrent adds, want 8", len(sessions))
+	}
+}
+
 func TestStore_SessionMetaFields(t *testing.T) {
 	s := testStore(t)
 
@@ -549,3 +673,34 @@ func TestStore_OrphansNonDestructive(t *testing.T) {
 		t.Errorf("Orphans must not modify the store: got %d sessions, want 2", len(sessions))
 	}
 }
+
+func TestStore_HealthDroppedWithSession(t *testing.T) {
+	s := testStore(t)
+	if err := s.Add(SessionMeta{Name: "a", TmuxSession: "vibeflow_a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetHealth("vibeflow_a", SavedHealth{Status: HealthFailed, RecoveryCount: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetHealth("vibeflow_b", SavedHealth{RecoveryCount: 1}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := s.Health()
+	if err != nil || saved["vibeflow_a"].RecoveryCount != 3 || len(saved) != 2 {
+		t.Fatalf("Health() = %+v, %v", saved, err)
+	}
+
+	if err := s.Remove("a"); err != nil {
+		t.Fatal(err)
+	}
+	saved, _ = s.Health()
+	if _, ok := saved["vibeflow_a"]; ok || len(saved) != 1 {
+		t.Errorf("after Remove, health = %+v; want only vibeflow_b", saved)
+	}
+	if err := s.RemoveHealth("vibeflow_b"); err != nil {
+		t.Fatal(err)
+	}
+	if saved, _ = s.Health(); len(saved) != 0 {
+		t.Errorf("after RemoveHealth, health = %+v", saved)
+	}
+}
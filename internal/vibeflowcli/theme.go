@@ -16,34 +16,197 @@
 
 package vibeflowcli
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Ocean — TUI design system (design_system document #401).
 //
 // "Calm, deep, focused. The serenity of ocean depths." A deep-ocean dark
 // palette with rounded borders, diamond selection icons, and generous spacing.
 // This file is the single source of truth for TUI styling — render code must
-// consume these tokens rather than hard-coding hex values.
+// consume these tokens rather than hard-coding hex values. The config's theme
+// section swaps in another palette (light, high-contrast or custom colors).
+
+// themePalette is one set of TUI colors as "#rrggbb" strings. Providers maps
+// a provider name to its dot color; providers it leaves out take their color
+// from the palette (see themeProviderColors).
+type themePalette struct {
+	Background string // app background
+	Foreground string // body text
+	Primary    string // main accent
+	Secondary  string // supporting accent
+	Accent     string // data/values
+	Success    string
+	Warning    string
+	Error      string
+	Muted      string // captions, dim chrome
+	Surface    string // panels
+	Shallow    string // active surface / hover
+	Abyss      string // deepest background / overlays
+	Providers  map[string]string
+}
+
+// Built-in theme presets. darkTheme is the Ocean palette and the default.
+var (
+	darkTheme = themePalette{
+		Background: "#0b1929", // deep ocean
+		Foreground: "#c8d6e5", // soft blue-white
+		Primary:    "#00d4aa", // vibeflow teal
+		Secondary:  "#0abde3", // deeper blue
+		Accent:     "#55efc4", // sea foam green
+		Success:    "#00d2d3", // teal
+		Warning:    "#feca57", // sandy yellow
+		Error:      "#ff6b6b", // coral red
+		Muted:      "#576574", // storm gray
+		Surface:    "#152d45", // deeper blue surface
+		Shallow:    "#1e3a5f", // active surface
+		Abyss:      "#060f1a", // deepest background
+	}
+	// lightTheme darkens every accent enough to read on a white background.
+	lightTheme = themePalette{
+		Background: "#ffffff",
+		Foreground: "#1e272e",
+		Primary:    "#00806a",
+		Secondary:  "#0a6ea8",
+		Accent:     "#1b7f4f",
+		Success:    "#007c7d",
+		Warning:    "#a86500",
+		Error:      "#c0392b",
+		Muted:      "#6b7785",
+		Surface:    "#eef2f6",
+		Shallow:    "#dde6ef",
+		Abyss:      "#f7f9fb",
+	}
+	// highContrastTheme uses saturated colors on black and a light gray for
+	// dim text.
+	highContrastTheme = themePalette{
+		Background: "#000000",
+		Foreground: "#ffffff",
+		Primary:    "#00ffff",
+		Secondary:  "#5fafff",
+		Accent:     "#00ff87",
+		Success:    "#00ff00",
+		Warning:    "#ffff00",
+		Error:      "#ff5f5f",
+		Muted:      "#d0d0d0",
+		Surface:    "#1c1c1c",
+		Shallow:    "#303030",
+		Abyss:      "#080808",
+	}
+)
+
+// themePresets maps the preset names accepted in config to their palettes.
+var themePresets = map[string]themePalette{
+	"dark":          darkTheme,
+	"light":         lightTheme,
+	"high-contrast": highContrastTheme,
+}
+
+// ThemeConfig picks the TUI colors: a built-in preset, optionally with
+// individual colors overridden.
+type ThemeConfig struct {
+	Preset    string            `yaml:"preset,omitempty"`    // dark (default), light or high-contrast
+	Colors    map[string]string `yaml:"colors,omitempty"`    // color name (see themeColorNames) → "#rrggbb"
+	Providers map[string]string `yaml:"providers,omitempty"` // provider name → "#rrggbb"
+}
 
-// Ocean palette as raw hex strings. Used directly by tmux format strings
+// themeColorNames lists the color names accepted under theme.colors, in the
+// order they are documented.
+var themeColorNames = []string{
+	"background", "foreground", "accent", "secondary", "highlight",
+	"success", "warning", "error", "dim", "surface", "active", "overlay",
+}
+
+// field returns the palette entry for a theme.colors name.
+func (p *themePalette) field(name string) *string {
+	switch name {
+	case "background":
+		return &p.Background
+	case "foreground":
+		return &p.Foreground
+	case "accent":
+		return &p.Primary
+	case "secondary":
+		return &p.Secondary
+	case "highlight":
+		return &p.Accent
+	case "success":
+		return &p.Success
+	case "warning":
+		return &p.Warning
+	case "error":
+		return &p.Error
+	case "dim":
+		return &p.Muted
+	case "surface":
+		return &p.Surface
+	case "active":
+		return &p.Shallow
+	case "overlay":
+		return &p.Abyss
+	}
+	return nil
+}
+
+var themeHexRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// newTheme builds the palette for cfg. Unknown presets or color names, and
+// colors that are not "#rrggbb", are errors; darkTheme is the fallback.
+func newTheme(cfg ThemeConfig) (themePalette, error) {
+	preset := cfg.Preset
+	if preset == "" {
+		preset = "dark"
+	}
+	p, ok := themePresets[preset]
+	if !ok {
+		return themePalette{}, fmt.Errorf("unknown theme preset %q (dark, light or high-contrast)", cfg.Preset)
+	}
+	for name, hex := range cfg.Colors {
+		f := p.field(name)
+		if f == nil {
+			return themePalette{}, fmt.Errorf("unknown theme color %q (one of %s)", name, strings.Join(themeColorNames, ", "))
+		}
+		if !themeHexRe.MatchString(hex) {
+			return themePalette{}, fmt.Errorf("theme color %s: %q is not #rrggbb", name, hex)
+		}
+		*f = hex
+	}
+	if len(cfg.Providers) > 0 {
+		p.Providers = make(map[string]string, len(cfg.Providers))
+		for name, hex := range cfg.Providers {
+			if !themeHexRe.MatchString(hex) {
+				return themePalette{}, fmt.Errorf("theme provider color %s: %q is not #rrggbb", name, hex)
+			}
+			p.Providers[name] = hex
+		}
+	}
+	return p, nil
+}
+
+// Active palette as raw hex strings. Used directly by tmux format strings
 // (which take `fg=#rrggbb` literals) and as the backing values for the
-// lipgloss colors below.
-const (
-	oceanHexBackground = "#0b1929" // deep ocean — app background
-	oceanHexForeground = "#c8d6e5" // soft blue-white — body text
-	oceanHexPrimary    = "#00d4aa" // vibeflow teal — main accent
-	oceanHexSecondary  = "#0abde3" // deeper blue — supporting accent
-	oceanHexAccent     = "#55efc4" // sea foam green — data/values
-	oceanHexSuccess    = "#00d2d3" // teal — success
-	oceanHexWarning    = "#feca57" // sandy yellow — warning
-	oceanHexError      = "#ff6b6b" // coral red — error
-	oceanHexMuted      = "#576574" // storm gray — captions, dim chrome
-	oceanHexSurface    = "#152d45" // deeper blue surface — panels
-	oceanHexShallow    = "#1e3a5f" // active surface / hover
-	oceanHexAbyss      = "#060f1a" // deepest background / overlays
+// lipgloss colors below. applyTheme replaces them.
+var (
+	oceanHexBackground = darkTheme.Background
+	oceanHexForeground = darkTheme.Foreground
+	oceanHexPrimary    = darkTheme.Primary
+	oceanHexSecondary  = darkTheme.Secondary
+	oceanHexAccent     = darkTheme.Accent
+	oceanHexSuccess    = darkTheme.Success
+	oceanHexWarning    = darkTheme.Warning
+	oceanHexError      = darkTheme.Error
+	oceanHexMuted      = darkTheme.Muted
+	oceanHexSurface    = darkTheme.Surface
+	oceanHexShallow    = darkTheme.Shallow
+	oceanHexAbyss      = darkTheme.Abyss
 )
 
-// Ocean palette as lipgloss colors, for Go-side (Bubble Tea / Lip Gloss) styling.
+// Active palette as lipgloss colors, for Go-side (Bubble Tea / Lip Gloss) styling.
 var (
 	oceanBackground = lipgloss.Color(oceanHexBackground)
 	oceanForeground = lipgloss.Color(oceanHexForeground)
@@ -59,6 +222,26 @@ var (
 	oceanAbyss      = lipgloss.Color(oceanHexAbyss)
 )
 
+// applyTheme makes p the active palette and rebuilds every color and style
+// derived from it. The TUI calls it once at startup, before rendering.
+func applyTheme(p themePalette) {
+	oceanHexBackground, oceanBackground = p.Background, lipgloss.Color(p.Background)
+	oceanHexForeground, oceanForeground = p.Foreground, lipgloss.Color(p.Foreground)
+	oceanHexPrimary, oceanPrimary = p.Primary, lipgloss.Color(p.Primary)
+	oceanHexSecondary, oceanSecondary = p.Secondary, lipgloss.Color(p.Secondary)
+	oceanHexAccent, oceanAccent = p.Accent, lipgloss.Color(p.Accent)
+	oceanHexSuccess, oceanSuccess = p.Success, lipgloss.Color(p.Success)
+	oceanHexWarning, oceanWarning = p.Warning, lipgloss.Color(p.Warning)
+	oceanHexError, oceanError = p.Error, lipgloss.Color(p.Error)
+	oceanHexMuted, oceanMuted = p.Muted, lipgloss.Color(p.Muted)
+	oceanHexSurface, oceanSurface = p.Surface, lipgloss.Color(p.Surface)
+	oceanHexShallow, oceanShallow = p.Shallow, lipgloss.Color(p.Shallow)
+	oceanHexAbyss, oceanAbyss = p.Abyss, lipgloss.Color(p.Abyss)
+	personaColors = themePersonaColors()
+	providerColors = themeProviderColors(p.Providers)
+	setStyles()
+}
+
 // Ocean selection, status, and separator icons (doc #401 §7). Soft, rounded
 // glyphs — no sharp edges.
 const (
@@ -102,3 +102,71 @@ func TestOceanBorder_Rounded(t *testing.T) {
 		t.Errorf("oceanBorder top-left = %q, want ╭ (rounded)", got)
 	}
 }
+
+func TestThemePresets_ValidDistinctHex(t *testing.T) {
+	hexRe := regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	for name, p := range themePresets {
+		seen := map[string]string{}
+		for _, key := range themeColorNames {
+			hex := *p.field(key)
+			if !hexRe.MatchString(hex) {
+				t.Errorf("%s %s = %q, want #RRGGBB", name, key, hex)
+			}
+			if prev, ok := seen[hex]; ok {
+				t.Errorf("%s %s and %s share color %s", name, key, prev, hex)
+			}
+			seen[hex] = key
+		}
+	}
+}
+
+func TestNewTheme(t *testing.T) {
+	p, err := newTheme(ThemeConfig{})
+	if err != nil || p.Primary != darkTheme.Primary {
+		t.Fatalf("default theme = %+v, %v; want dark", p, err)
+	}
+	p, err = newTheme(ThemeConfig{
+		Preset:    "light",
+		Colors:    map[string]string{"accent": "#123456", "dim": "#abcdef"},
+		Providers: map[string]string{"claude": "#654321"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Primary != "#123456" || p.Muted != "#abcdef" || p.Error != lightTheme.Error {
+		t.Errorf("light with overrides = %+v", p)
+	}
+	if p.Providers["claude"] != "#654321" {
+		t.Errorf("provider override = %v", p.Providers)
+	}
+	if lightTheme.Providers != nil || lightTheme.Primary == "#123456" {
+		t.Error("newTheme modified the preset")
+	}
+
+	for _, cfg := range []ThemeConfig{
+		{Preset: "solarized"},
+		{Colors: map[string]string{"sparkle": "#ffffff"}},
+		{Colors: map[string]string{"accent": "teal"}},
+		{Providers: map[string]string{"claude": "#fff"}},
+	} {
+		if _, err := newTheme(cfg); err == nil {
+			t.Errorf("newTheme(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { applyTheme(darkTheme) })
+	p := lightTheme
+	p.Providers = map[string]string{"codex": "#010203"}
+	applyTheme(p)
+	if accentColor != lipgloss.Color(lightTheme.Primary) || oceanHexShallow != lightTheme.Shallow {
+		t.Errorf("accent = %q, shallow = %q; want the light palette", accentColor, oceanHexShallow)
+	}
+	if providerColors["codex"] != "#010203" || providerColors["claude"] != lipgloss.Color(lightTheme.Warning) {
+		t.Errorf("provider colors = %v", providerColors)
+	}
+	if personaColors["developer"] != lipgloss.Color(lightTheme.Primary) {
+		t.Errorf("developer persona color = %q", personaColors["developer"])
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// timeoutOverride normalizes a --max-lifetime/--max-idle value: "default"
+// clears the session's own limit so the config applies again.
+func timeoutOverride(v string) (string, error) {
+	if v == "default" {
+		return "", nil
+	}
+	if _, err := parseTimeoutLimit(v); err != nil {
+		return "", err
+	}
+	return v, nil
+}
+
+// printTimeouts shows a session's effective limits and where each comes from.
+func printTimeouts(w io.Writer, meta SessionMeta, pol TimeoutConfig) {
+	lifetime, idle := sessionTimeoutLimits(meta, pol)
+	show := func(label, own string, d time.Duration) {
+		val := "off"
+		if d > 0 {
+			val = formatSessionDuration(d)
+		}
+		src := "config"
+		if own != "" {
+			src = "session"
+		}
+		fmt.Fprintf(w, "%-13s %s (%s)\n", label+":", val, src)
+	}
+	show("Max lifetime", meta.MaxLifetime, lifetime)
+	show("Max idle", meta.MaxIdle, idle)
+	action := "kill"
+	if pol.pauses() {
+		action = "pause"
+	}
+	fmt.Fprintf(w, "%-13s %s\n", "Action:", action)
+}
+
+// --- timeout ---
+
+func timeoutCmd() *cobra.Command {
+	var maxLifetime, maxIdle string
+	cmd := &cobra.Command{
+		Use:   "timeout <session-name>",
+		Short: "Show or change a session's lifetime and idle limits",
+		Long: `Show or change how long a session may run, or sit without output, before
+the TUI warns and then kills or pauses it (see timeouts in the config).
+
+Values are durations such as 90m or 8h; "off" exempts the session from that
+limit and "default" goes back to the configured one. Without flags the
+current limits are shown.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sessionCompletion(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, _, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			meta, found, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("session %q not found", args[0])
+			}
+			out := cmd.OutOrStdout()
+			lifetimeSet, idleSet := cmd.Flags().Changed("max-lifetime"), cmd.Flags().Changed("max-idle")
+			if lifetimeSet || idleSet {
+				lifetime, idle := meta.MaxLifetime, meta.MaxIdle
+				if lifetimeSet {
+					if lifetime, err = timeoutOverride(maxLifetime); err != nil {
+						return err
+					}
+				}
+				if idleSet {
+					if idle, err = timeoutOverride(maxIdle); err != nil {
+						return err
+					}
+				}
+				if err := store.SetTimeouts(meta.Name, lifetime, idle); err != nil {
+					return err
+				}
+				meta.MaxLifetime, meta.MaxIdle = lifetime, idle
+				fmt.Fprintf(out, "Timeouts of %q updated.\n", meta.Name)
+			}
+			printTimeouts(out, meta, cfg.Timeouts)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&maxLifetime, "max-lifetime", "", "Lifetime limit, e.g. 8h (off = none, default = use the config)")
+	cmd.Flags().StringVar(&maxIdle, "max-idle", "", "Idle limit, e.g. 2h (off = none, default = use the config)")
+	return cmd
+}
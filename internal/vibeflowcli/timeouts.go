@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeoutConfig retires forgotten sessions. Limits are Go durations ("8h",
+// "90m"); empty or "off" means no limit. A session's own limits, set with
+// `launch --max-lifetime/--max-idle` or `vibeflow timeout`, take precedence.
+// The policy runs while the TUI is open; attached sessions are left alone.
+type TimeoutConfig struct {
+	MaxLifetime string `yaml:"max_lifetime,omitempty"` // since the session was (re)started
+	MaxIdle     string `yaml:"max_idle,omitempty"`     // since its pane last changed
+	Warn        string `yaml:"warn,omitempty"`         // notify this long before acting (default 10m)
+	Action      string `yaml:"action,omitempty"`       // "kill" (default; restorable from the trash) or "pause"
+}
+
+// defaultTimeoutWarn is how long before acting the timeout warning fires.
+const defaultTimeoutWarn = 10 * time.Minute
+
+// parseTimeoutLimit parses a limit value; "" and "off" mean no limit.
+func parseTimeoutLimit(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	if v == "" || v == "off" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid timeout %q (use e.g. 90m, 8h or off)", v)
+	}
+	return d, nil
+}
+
+// Validate reports the first invalid value in the policy.
+func (c TimeoutConfig) Validate() error {
+	for _, v := range []string{c.MaxLifetime, c.MaxIdle, c.Warn} {
+		if _, err := parseTimeoutLimit(v); err != nil {
+			return err
+		}
+	}
+	switch c.Action {
+	case "", "kill", "pause":
+		return nil
+	}
+	return fmt.Errorf("invalid timeouts.action %q (kill or pause)", c.Action)
+}
+
+// pauses reports whether timed-out sessions are paused rather than killed.
+func (c TimeoutConfig) pauses() bool { return c.Action == "pause" }
+
+// warnBefore returns the warning lead time.
+func (c TimeoutConfig) warnBefore() time.Duration {
+	if c.Warn == "" {
+		return defaultTimeoutWarn
+	}
+	d, _ := parseTimeoutLimit(c.Warn)
+	return d
+}
+
+// sessionTimeoutLimits returns the lifetime and idle limits for meta: its
+// own values where set, the config's otherwise. Zero means no limit;
+// invalid values count as none.
+func sessionTimeoutLimits(meta SessionMeta, cfg TimeoutConfig) (lifetime, idle time.Duration) {
+	pick := func(own, def string) time.Duration {
+		if own == "" {
+			own = def
+		}
+		d, _ := parseTimeoutLimit(own)
+		return d
+	}
+	return pick(meta.MaxLifetime, cfg.MaxLifetime), pick(meta.MaxIdle, cfg.MaxIdle)
+}
+
+// timeoutLeft returns how long meta has before its nearest limit acts, and a
+// description of that limit. ok is false when no limit applies. idleSince
+// is when the pane last changed; zero when unknown, which skips the idle
+// limit.
+func timeoutLeft(meta SessionMeta, cfg TimeoutConfig, idleSince, now time.Time) (left time.Duration, reason string, ok bool) {
+	lifetime, idle := sessionTimeoutLimits(meta, cfg)
+	if lifetime > 0 && !meta.CreatedAt.IsZero() {
+		left, reason, ok = meta.CreatedAt.Add(lifetime).Sub(now), "max lifetime "+formatSessionDuration(lifetime), true
+	}
+	if idle > 0 && !idleSince.IsZero() {
+		if l := idleSince.Add(idle).Sub(now); !ok || l < left {
+			left, reason, ok = l, "idle for "+formatSessionDuration(idle), true
+		}
+	}
+	return left, reason, ok
+}
+
+// describeTimeout is the detail-panel text for a pending timeout.
+func describeTimeout(left time.Duration, reason string, pause bool) string {
+	verb := "killed"
+	if pause {
+		verb = "paused"
+	}
+	if left <= 0 {
+		return fmt.Sprintf("%s now (%s)", verb, reason)
+	}
+	return fmt.Sprintf("%s in %s (%s)", verb, formatSessionDuration(left), reason)
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimeoutLimit(t *testing.T) {
+	for in, want := range map[string]time.Duration{"": 0, "off": 0, "90m": 90 * time.Minute, " 8h ": 8 * time.Hour} {
+		got, err := parseTimeoutLimit(in)
+		if err != nil || got != want {
+			t.Errorf("parseTimeoutLimit(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"8", "soon", "-1h"} {
+		if _, err := parseTimeoutLimit(in); err == nil {
+			t.Errorf("parseTimeoutLimit(%q) accepted an invalid value", in)
+		}
+	}
+}
+
+func TestTimeoutConfig_Validate(t *testing.T) {
+	if err := (TimeoutConfig{MaxLifetime: "8h", MaxIdle: "off", Warn: "5m", Action: "pause"}).Validate(); err != nil {
+		t.Errorf("valid config rejected: %v", err)
+	}
+	for _, c := range []TimeoutConfig{{MaxIdle: "2 hours"}, {Warn: "x"}, {Action: "stop"}} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", c)
+		}
+	}
+	if got := (TimeoutConfig{}).warnBefore(); got != defaultTimeoutWarn {
+		t.Errorf("default warn = %v, want %v", got, defaultTimeoutWarn)
+	}
+}
+
+func TestSessionTimeoutLimits_SessionOverridesConfig(t *testing.T) {
+	cfg := TimeoutConfig{MaxLifetime: "8h", MaxIdle: "2h"}
+	lifetime, idle := sessionTimeoutLimits(SessionMeta{MaxLifetime: "off", MaxIdle: "30m"}, cfg)
+	if lifetime != 0 || idle != 30*time.Minute {
+		t.Errorf("limits = %v, %v; want 0, 30m", lifetime, idle)
+	}
+	lifetime, idle = sessionTimeoutLimits(SessionMeta{}, cfg)
+	if lifetime != 8*time.Hour || idle != 2*time.Hour {
+		t.Errorf("limits = %v, %v; want the config's", lifetime, idle)
+	}
+}
+
+func TestTimeoutLeft(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	meta := SessionMeta{CreatedAt: now.Add(-7 * time.Hour)}
+	cfg := TimeoutConfig{MaxLifetime: "8h", MaxIdle: "2h"}
+
+	// The idle limit is nearer than the lifetime one.
+	left, reason, ok := timeoutLeft(meta, cfg, now.Add(-110*time.Minute), now)
+	if !ok || left != 10*time.Minute || !strings.HasPrefix(reason, "idle") {
+		t.Errorf("got %v %q %v; want 10m idle", left, reason, ok)
+	}
+	// Unknown idle time leaves only the lifetime limit.
+	left, reason, ok = timeoutLeft(meta, cfg, time.Time{}, now)
+	if !ok || left != time.Hour || !strings.HasPrefix(reason, "max lifetime") {
+		t.Errorf("got %v %q %v; want 1h lifetime", left, reason, ok)
+	}
+	// Past the limit, left goes negative.
+	if left, _, _ = timeoutLeft(meta, TimeoutConfig{MaxLifetime: "6h"}, time.Time{}, now); left >= 0 {
+		t.Errorf("left = %v, want overdue", left)
+	}
+	if _, _, ok = timeoutLeft(meta, TimeoutConfig{}, now, now); ok {
+		t.Error("no limits configured, but a timeout applied")
+	}
+}
+
+func TestDescribeTimeout(t *testing.T) {
+	if got := describeTimeout(90*time.Minute, "idle for 2h", false); got != "killed in 1h30m (idle for 2h)" {
+		t.Errorf("describeTimeout = %q", got)
+	}
+	if got := describeTimeout(-time.Second, "max lifetime 8h", true); !strings.HasPrefix(got, "paused now") {
+		t.Errorf("describeTimeout = %q", got)
+	}
+}
+
+func TestStore_SetTimeoutsAndPaused(t *testing.T) {
+	withTempRoot(t)
+	store := NewStore()
+	if err := store.Add(SessionMeta{Name: "web", TmuxSession: "vibeflow_claude-web", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetTimeouts("web", "4h", "off"); err != nil {
+		t.Fatal(err)
+	}
+	at := time.Now().Truncate(time.Second)
+	if err := store.SetPaused("web", at); err != nil {
+		t.Fatal(err)
+	}
+	meta, _, _ := store.Get("web")
+	if meta.MaxLifetime != "4h" || meta.MaxIdle != "off" || !meta.PausedAt.Equal(at) {
+		t.Errorf("meta = %+v", meta)
+	}
+	if err := store.SetTimeouts("nope", "", ""); err == nil {
+		t.Error("SetTimeouts on a missing session succeeded")
+	}
+}
+
+func TestApplyActivity_SkipsPausedRows(t *testing.T) {
+	rows := []SessionRow{{Name: "a", Status: "paused"}, {Name: "b", Status: "running"}}
+	applyActivity(rows, func(string) ActivityState { return ActivityIdle })
+	if rows[0].Status != "paused" || rows[1].Status != "idle" {
+		t.Errorf("statuses = %q, %q", rows[0].Status, rows[1].Status)
+	}
+}
+
+func TestEnforceTimeouts_SkipsSessionsBeingTimedOut(t *testing.T) {
+	m := bulkTestModel(t)
+	m.config.Timeouts = TimeoutConfig{MaxLifetime: "1h"}
+	m.sessions = m.sessions[:1]
+	if err := m.store.Add(SessionMeta{Name: "claude-a", TmuxSession: sessionPrefix + "claude-a", CreatedAt: time.Now().Add(-2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if m.enforceTimeouts() == nil || !m.timingOut["claude-a"] {
+		t.Fatalf("overdue session not timed out, timingOut=%v", m.timingOut)
+	}
+	// The next sample lands before the kill's refresh: nothing new to do.
+	if m.enforceTimeouts() != nil {
+		t.Error("session timed out again while its kill was still running")
+	}
+	nm, _ := m.Update(timedOutMsg{names: []string{"claude-a"}, refresh: sessionsMsg{sessions: m.sessions}})
+	if m = nm.(Model); m.timingOut["claude-a"] {
+		t.Error("session still marked after its refresh landed")
+	}
+}
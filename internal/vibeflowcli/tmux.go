@@ -18,13 +18,20 @@ package vibeflowcli
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 	"unicode"
 )
 
@@ -35,6 +42,30 @@ type TmuxManager struct {
 	socketName    string
 	supportsPopup bool // true if tmux >= 3.2 (display-popup support)
 	logger        *Logger
+	// remoteHost, when non-empty, routes every tmux invocation through
+	// `ssh <remoteHost> tmux -L <socket> ...` so the same manager can list
+	// and attach to sessions living on another machine.
+	remoteHost string
+	// sessionLogCmd, when set (SetSessionLogging), returns the shell command
+	// each new session's pane output is piped to.
+	sessionLogCmd func(fullName string) string
+	// projectSockets, when set (SetProjectSockets), puts each project's new
+	// sessions on a socket of their own (ProjectSocketName) and makes
+	// ListSessions aggregate the sessions of every project socket.
+	projectSockets bool
+
+	mu sync.Mutex
+	// paneDirs caches each session's active pane directory, refreshed by
+	// every ListSessions so GetPaneWorkDir needs no tmux call of its own.
+	paneDirs map[string]string
+	// keysBoundAt is when BindAllSessionKeys last bound the vibeflow keys.
+	keysBoundAt time.Time
+	// sockets maps the sessions that live on a project socket to it. The
+	// rest are on socketName.
+	sockets map[string]string
+	// scanned is set once ListSessions has looked through the project
+	// sockets, so socketFor only scans for an unknown session once.
+	scanned bool
 }
 
 // SetLogger attaches a logger to the TmuxManager for debug output.
@@ -52,6 +83,177 @@ func NewTmuxManager(socketName string) *TmuxManager {
 	return tm
 }
 
+// NewRemoteTmuxManager creates a manager whose tmux calls are executed on
+// host over ssh. host is passed verbatim to ssh, so anything ssh accepts
+// (user@host, an alias from ~/.ssh/config) works. Popup support is not
+// probed remotely — popups are only used by the local TUI.
+func NewRemoteTmuxManager(host, socketName string) *TmuxManager {
+	if socketName == "" {
+		socketName = "vibeflow"
+	}
+	return &TmuxManager{socketName: socketName, remoteHost: host}
+}
+
+// SetProjectSockets turns per-project sockets on or off (config
+// tmux_socket_per_project). They are never used for remote managers.
+func (tm *TmuxManager) SetProjectSockets(on bool) {
+	tm.projectSockets = on && !tm.IsRemote()
+}
+
+// ProjectSocketName returns the socket a project's sessions run on with
+// per-project sockets: base plus "-p" and a hash of the repository root,
+// so `tmux -L <base> kill-server` leaves every project's agents alone.
+func ProjectSocketName(base, repoRoot string) string {
+	h := sha256.Sum256([]byte(repoRoot))
+	return base + "-p" + hex.EncodeToString(h[:4])
+}
+
+// projectSocketNames returns the project sockets of this manager's base
+// socket that exist in the tmux socket directory, sorted.
+func (tm *TmuxManager) projectSocketNames() []string {
+	dir := os.Getenv("TMUX_TMPDIR")
+	if dir == "" {
+		dir = "/tmp"
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, fmt.Sprintf("tmux-%d", os.Getuid()), tm.socketName+"-p*"))
+	var names []string
+	for _, m := range matches {
+		name := filepath.Base(m)
+		if len(name) == len(tm.socketName)+10 { // "-p" + 8 hex digits
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// socketFor returns the socket a full session name lives on. With project
+// sockets, a session not seen yet triggers one ListSessions, so one-shot
+// commands (kill, send, ...) find sessions on project sockets too.
+func (tm *TmuxManager) socketFor(fullName string) string {
+	tm.mu.Lock()
+	s, ok := tm.sockets[fullName]
+	scan := !ok && tm.projectSockets && !tm.scanned
+	tm.mu.Unlock()
+	if ok {
+		return s
+	}
+	if scan {
+		_, _ = tm.ListSessions()
+		tm.mu.Lock()
+		s, ok = tm.sockets[fullName]
+		tm.mu.Unlock()
+		if ok {
+			return s
+		}
+	}
+	return tm.socketName
+}
+
+// setSocket records the socket a session lives on.
+func (tm *TmuxManager) setSocket(fullName, socket string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if socket == "" || socket == tm.socketName {
+		delete(tm.sockets, fullName)
+		return
+	}
+	if tm.sockets == nil {
+		tm.sockets = make(map[string]string)
+	}
+	tm.sockets[fullName] = socket
+}
+
+// SessionSocket returns the tmux socket the named session runs on.
+func (tm *TmuxManager) SessionSocket(name string) string {
+	return tm.socketFor(tm.ensurePrefix(name))
+}
+
+// socketForArgs picks the socket for a tmux invocation from the session
+// it targets: the first -t target, else the -s name of a new session.
+// Invocations without either go to the base socket.
+func (tm *TmuxManager) socketForArgs(args []string) string {
+	target := ""
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-t" {
+			target = args[i+1]
+			break
+		}
+		if args[i] == "-s" && target == "" && !strings.HasPrefix(args[i+1], "-") {
+			target = args[i+1]
+		}
+	}
+	if target == "" {
+		return tm.socketName
+	}
+	// "=name", "name:window" and "name:window.pane" all name the session.
+	target = strings.TrimPrefix(target, "=")
+	if i := strings.IndexAny(target, ":."); i >= 0 {
+		target = target[:i]
+	}
+	return tm.socketFor(target)
+}
+
+// onSocket returns a manager for socket alone, without project sockets.
+// Workbench compositions run on one: they address panes and windows by
+// tmux ids, which only mean something on the server that issued them.
+func (tm *TmuxManager) onSocket(socket string) *TmuxManager {
+	return &TmuxManager{
+		socketName:    socket,
+		supportsPopup: tm.supportsPopup,
+		logger:        tm.logger,
+		remoteHost:    tm.remoteHost,
+		sessionLogCmd: tm.sessionLogCmd,
+	}
+}
+
+// sharedSocket returns the socket all of fullNames live on, or an error
+// when they span project sockets: tmux can't move panes between servers.
+func (tm *TmuxManager) sharedSocket(fullNames []string) (string, error) {
+	socket := tm.socketName
+	for i, name := range fullNames {
+		s := tm.socketFor(name)
+		if i == 0 {
+			socket = s
+		} else if s != socket {
+			return "", fmt.Errorf("workbench can't combine sessions on different tmux sockets (tmux_socket_per_project)")
+		}
+	}
+	return socket, nil
+}
+
+// IsRemote reports whether this manager drives tmux on a remote host.
+func (tm *TmuxManager) IsRemote() bool {
+	return tm.remoteHost != ""
+}
+
+// command builds the *exec.Cmd for a tmux invocation on the socket of the
+// session it targets (socketForArgs). Locally that is `tmux -L <socket>
+// args...`; for a remote manager it is `ssh [-t] <host> tmux -L <socket>
+// args...`, with every argument shell-quoted because ssh joins the remote
+// argv into a single command line for the remote shell. tty requests a
+// pseudo-terminal (needed for attach).
+func (tm *TmuxManager) command(tty bool, args ...string) *exec.Cmd {
+	return tm.commandOn(tm.socketForArgs(args), tty, args...)
+}
+
+// commandOn is command on an explicit socket.
+func (tm *TmuxManager) commandOn(socket string, tty bool, args ...string) *exec.Cmd {
+	fullArgs := append([]string{"-L", socket}, args...)
+	if tm.remoteHost == "" {
+		return exec.Command("tmux", fullArgs...)
+	}
+	sshArgs := []string{}
+	if tty {
+		sshArgs = append(sshArgs, "-t")
+	}
+	sshArgs = append(sshArgs, tm.remoteHost, "tmux")
+	for _, a := range fullArgs {
+		sshArgs = append(sshArgs, shellQuote(a))
+	}
+	return exec.Command("ssh", sshArgs...)
+}
+
 // detectPopupSupport checks if the installed tmux version supports
 // display-popup (available since tmux 3.2).
 func (tm *TmuxManager) detectPopupSupport() bool {
@@ -92,6 +294,11 @@ type TmuxSession struct {
 	Attached  bool
 	PaneDead  bool
 	CreatedAt string
+	// ExitStatus is the exit code of the dead pane's process, as reported
+	// by tmux. Empty while the pane is alive or when tmux doesn't know.
+	ExitStatus string
+	// WorkDir is the current directory of the session's active pane.
+	WorkDir string
 }
 
 // SessionOpts holds parameters for creating a provider-aware tmux session.
@@ -104,6 +311,7 @@ type SessionOpts struct {
 	Branch   string            // Git branch for status bar display.
 	Project  string            // Project name for status bar display.
 	Persona  string            // Persona key for vibeflow sessions.
+	Queued   bool              // hold Command until StartQueued (launch queue full)
 }
 
 // StatusBarOpts holds display parameters for the tmux status bar.
@@ -148,7 +356,12 @@ func RenderLaunchCommand(tmpl string, vars LaunchTemplateVars) (string, error) {
 // already running. This allows the TUI and headless commands to list/create
 // sessions without hitting "no server running" errors on the first call.
 func (tm *TmuxManager) EnsureServer() error {
-	_, err := tm.run("start-server")
+	return tm.ensureServerOn(tm.socketName)
+}
+
+// ensureServerOn is EnsureServer for the given socket.
+func (tm *TmuxManager) ensureServerOn(socket string) error {
+	_, err := tm.runOn(socket, "start-server")
 	if err != nil {
 		return err
 	}
@@ -159,20 +372,37 @@ func (tm *TmuxManager) EnsureServer() error {
 		{"set-clipboard", "on"},
 		{"allow-passthrough", "on"},
 	} {
-		_, _ = tm.run("set", "-s", opt.key, opt.val)
+		_, _ = tm.runOn(socket, "set", "-s", opt.key, opt.val)
 	}
 	// Enable mouse support so users can select and copy text with the
 	// mouse. Without this, mouse selection is not available in tmux
 	// sessions. Users can hold Shift (Linux) or Option (macOS) to
 	// bypass tmux and use native terminal selection.
-	_, _ = tm.run("set", "-g", "mouse", "on")
+	_, _ = tm.runOn(socket, "set", "-g", "mouse", "on")
 	// Keep dead panes alive so the user can see why the agent command
 	// exited. Without this, sessions whose command exits immediately
 	// are destroyed and disappear from the session list.
-	_, _ = tm.run("set", "-g", "remain-on-exit", "on")
+	_, _ = tm.runOn(socket, "set", "-g", "remain-on-exit", "on")
 	return nil
 }
 
+// ServerRunning reports whether a tmux server is already listening on the
+// vibeflow socket, or on one of its project sockets. There is none after a
+// reboot until vibeflow starts one.
+func (tm *TmuxManager) ServerRunning() bool {
+	if _, err := tm.run("list-sessions"); err == nil {
+		return true
+	}
+	if tm.projectSockets {
+		for _, socket := range tm.projectSocketNames() {
+			if _, err := tm.runOn(socket, "list-sessions"); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // tmuxListDelim separates the fields ListSessions requests from tmux via the
 // list-sessions -F format string. It MUST be a printable delimiter, never a
 // control character: tmux sanitizes control characters (including TAB, 0x09)
@@ -199,11 +429,52 @@ var listSessionsFormat = strings.Join([]string{
 	"#{session_attached}",
 	"#{session_created_string}",
 	"#{pane_dead}",
+	"#{pane_dead_status}",
+	"#{pane_current_path}", // last: SplitN leaves a path containing the delimiter whole
 }, tmuxListDelim)
 
-// ListSessions returns all vibeflow-prefixed tmux sessions.
+// ListSessions returns all vibeflow-prefixed tmux sessions. With project
+// sockets it also lists every project socket's sessions and remembers
+// which socket each lives on; a project socket that can't be listed (a
+// stale socket file) is skipped.
 func (tm *TmuxManager) ListSessions() ([]TmuxSession, error) {
-	out, err := tm.run("list-sessions", "-F", listSessionsFormat)
+	sessions, err := tm.listSessionsOn(tm.socketName)
+	if err != nil {
+		return nil, err
+	}
+	if tm.projectSockets {
+		tm.mu.Lock()
+		tm.scanned = true
+		tm.mu.Unlock()
+		for _, s := range sessions {
+			tm.setSocket(s.Name, tm.socketName)
+		}
+		for _, socket := range tm.projectSocketNames() {
+			more, err := tm.listSessionsOn(socket)
+			if err != nil {
+				continue
+			}
+			for _, s := range more {
+				tm.setSocket(s.Name, socket)
+			}
+			sessions = append(sessions, more...)
+		}
+	}
+	dirs := make(map[string]string, len(sessions))
+	for _, s := range sessions {
+		if s.WorkDir != "" {
+			dirs[s.Name] = s.WorkDir
+		}
+	}
+	tm.mu.Lock()
+	tm.paneDirs = dirs
+	tm.mu.Unlock()
+	return sessions, nil
+}
+
+// listSessionsOn lists the vibeflow sessions of one socket.
+func (tm *TmuxManager) listSessionsOn(socket string) ([]TmuxSession, error) {
+	out, err := tm.runOn(socket, "list-sessions", "-F", listSessionsFormat)
 	if err != nil {
 		// tmux writes error messages to combined output; err.Error() is just "exit status 1".
 		combined := out + " " + err.Error()
@@ -225,7 +496,7 @@ func parseTmuxSessionLines(out string) []TmuxSession {
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, tmuxListDelim, 6)
+		parts := strings.SplitN(line, tmuxListDelim, 8)
 		if len(parts) < 5 {
 			continue
 		}
@@ -234,14 +505,21 @@ func parseTmuxSessionLines(out string) []TmuxSession {
 			continue
 		}
 		paneDead := len(parts) >= 6 && parts[5] == "1"
-		sessions = append(sessions, TmuxSession{
+		ts := TmuxSession{
 			Name:      name,
 			ID:        parts[1],
 			Windows:   atoi(parts[2]),
 			Attached:  parts[3] == "1",
 			PaneDead:  paneDead,
 			CreatedAt: parts[4],
-		})
+		}
+		if paneDead && len(parts) >= 7 {
+			ts.ExitStatus = parts[6]
+		}
+		if len(parts) >= 8 {
+			ts.WorkDir = parts[7]
+		}
+		sessions = append(sessions, ts)
 	}
 	return sessions
 }
@@ -268,6 +546,7 @@ var secretEnvPrefixes = []string{
 	"ANTHROPIC_CUSTOM_HEADERS=", // gateway mode embeds the API token as "x-axiom-api-key: <token>"
 	"ANTHROPIC_AUTH_TOKEN=",
 	"ANTHROPIC_API_KEY=",
+	"CLAUDE_CODE_OAUTH_TOKEN=",
 }
 
 // openaiAPIKeyFlagRe matches `--openai-api-key <value>` (or `=<value>`) inside
@@ -357,6 +636,18 @@ func withClaudeHardeningEnv(provider string, env map[string]string) map[string]s
 func (tm *TmuxManager) CreateSessionWithOpts(opts SessionOpts) error {
 	fullName := tm.FullSessionName(opts.Provider, opts.Name)
 
+	// With project sockets the session goes on its repository's socket;
+	// worktrees map back to the main checkout so they share it.
+	if tm.projectSockets && opts.WorkDir != "" {
+		socket := ProjectSocketName(tm.socketName, mainRepoDir(opts.WorkDir))
+		if err := tm.ensureServerOn(socket); err != nil {
+			return fmt.Errorf("start tmux server on %s: %w", socket, err)
+		}
+		if !tm.HasSession(fullName) {
+			tm.setSocket(fullName, socket)
+		}
+	}
+
 	// If a tmux session with the same name already exists, refuse to
 	// overwrite it. Sessions must coexist — deletion is user-initiated only.
 	if tm.HasSession(fullName) {
@@ -373,14 +664,16 @@ func (tm *TmuxManager) CreateSessionWithOpts(opts SessionOpts) error {
 		args = append(args, "-e", fmt.Sprintf("%s=%s", k, expanded))
 	}
 
-	if opts.Command != "" {
+	if opts.Queued {
+		args = append(args, queuedPaneCommand)
+	} else if opts.Command != "" {
 		args = append(args, opts.Command)
 	}
 
 	// Log the full spawn command for debugging.
 	if tm.logger != nil {
 		// Build the full command line as it would appear in a shell.
-		fullArgs := append([]string{"tmux", "-L", tm.socketName}, args...)
+		fullArgs := append([]string{"tmux", "-L", tm.socketFor(fullName)}, args...)
 		// Redact secrets (env var values and in-command key flags) before logging.
 		var redacted []string
 		for _, a := range fullArgs {
@@ -392,6 +685,7 @@ func (tm *TmuxManager) CreateSessionWithOpts(opts SessionOpts) error {
 
 	_, err := tm.run(args...)
 	if err != nil {
+		tm.setSocket(fullName, "")
 		return fmt.Errorf("create session %q: %w", fullName, err)
 	}
 
@@ -400,16 +694,116 @@ func (tm *TmuxManager) CreateSessionWithOpts(opts SessionOpts) error {
 	// global setting is lost when the server restarts (no prior sessions).
 	_, _ = tm.run("set-option", "-t", fullName, "remain-on-exit", "on")
 
+	// A queued session keeps its real command in a user option (server
+	// memory only — it may carry secrets) until StartQueued runs it.
+	if opts.Queued {
+		if _, err := tm.run("set-option", "-t", fullName, queuedCommandOption, opts.Command); err != nil {
+			_ = tm.KillSession(fullName)
+			return fmt.Errorf("queue session %q: %w", fullName, err)
+		}
+	}
+
+	// Mirror pane output into the session's log file when enabled.
+	tm.startSessionLog(fullName)
+
 	// Configure vibeflow-themed status bar for this session.
 	_ = tm.ConfigureStatusBar(fullName, StatusBarOpts{
 		Provider: opts.Provider,
 		Branch:   opts.Branch,
 		Project:  opts.Project,
 	})
+	_ = tm.SetSessionTitle(fullName, sessionTitle(strings.TrimPrefix(fullName, sessionPrefix), opts.Provider, "starting"))
+
+	return nil
+}
 
+// sessionTitle is the title shown for a session in terminal tabs and tmux
+// window lists: "name · provider · health", leaving out empty parts.
+func sessionTitle(name, provider, health string) string {
+	var parts []string
+	for _, p := range []string{name, provider, health} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// SetSessionTitle names the session's window and pane after title and
+// stores it in the session option @vftitle, which set-titles-string (see
+// buildStatusBarSettings) shows as the terminal title while the session is
+// attached. The agent may overwrite the pane title with its own OSC
+// escapes; the window name and @vftitle are immune to that.
+func (tm *TmuxManager) SetSessionTitle(name, title string) error {
+	fullName := tm.ensurePrefix(name)
+	if _, err := tm.run(titleArgs(fullName, title)...); err != nil {
+		return fmt.Errorf("set title for session %q: %w", fullName, err)
+	}
 	return nil
 }
 
+// SetSessionTitles sets the titles of several sessions in one tmux
+// invocation. tmux stops a command sequence at the first failure, so when
+// one session has gone away the rest are retried one by one.
+func (tm *TmuxManager) SetSessionTitles(titles map[string]string) error {
+	if len(titles) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(titles))
+	for name := range titles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// One invocation can only reach one tmux server.
+	if groups := tm.groupBySocket(names); len(groups) > 1 {
+		var errs []error
+		for _, group := range groups {
+			sub := make(map[string]string, len(group))
+			for _, name := range group {
+				sub[name] = titles[name]
+			}
+			errs = append(errs, tm.SetSessionTitles(sub))
+		}
+		return errors.Join(errs...)
+	}
+	var args []string
+	for _, name := range names {
+		if len(args) > 0 {
+			args = append(args, ";")
+		}
+		args = append(args, titleArgs(tm.ensurePrefix(name), titles[name])...)
+	}
+	if _, err := tm.run(args...); err == nil {
+		return nil
+	}
+	var errs []error
+	for _, name := range names {
+		if err := tm.SetSessionTitle(name, titles[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// titleArgs is the tmux command sequence that sets a session's title.
+func titleArgs(fullName, title string) []string {
+	title = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, title)
+	// tmux reads an argument ending in ';' as a command separator.
+	if strings.HasSuffix(title, ";") {
+		title = strings.TrimSuffix(title, ";") + `\;`
+	}
+	return []string{
+		"set-option", "-t", fullName, "@vftitle", title, ";",
+		"rename-window", "-t", fullName + ":", title, ";",
+		"select-pane", "-t", fullName + ":", "-T", title,
+	}
+}
+
 // FullSessionName returns the tmux session name with prefix and optional
 // provider. Format: "vibeflow_{provider}-{name}" or "vibeflow_{name}".
 func (tm *TmuxManager) FullSessionName(provider, name string) string {
@@ -425,6 +819,59 @@ func InsideTmux() bool {
 	return os.Getenv("TMUX") != ""
 }
 
+// Attach modes for opening a session from inside tmux (config attach_mode).
+const (
+	AttachSwitch = "switch" // switch-client: the session takes over the client (default)
+	AttachSplit  = "split"  // split the current window and attach in the new pane
+	AttachWindow = "window" // open a new window in the current session and attach there
+)
+
+// OpenInCurrentClient opens the named session next to what the user is
+// looking at in their own tmux client: in a new pane beside the current one
+// (AttachSplit) or in a new window (AttachWindow). The pane runs a nested
+// client attached to the session. Unlike join-pane or link-window this
+// works when the user's tmux is a different server from the vibeflow
+// socket, and it leaves the session itself untouched. Closing the pane or
+// detaching from it (prefix d) only ends the nested client.
+func (tm *TmuxManager) OpenInCurrentClient(name, mode string) error {
+	return tm.openInCurrentClient(name, mode, false)
+}
+
+// ObserveInCurrentClient is OpenInCurrentClient with a read-only nested
+// client: the session is shown live but keystrokes aren't passed to it.
+func (tm *TmuxManager) ObserveInCurrentClient(name, mode string) error {
+	return tm.openInCurrentClient(name, mode, true)
+}
+
+func (tm *TmuxManager) openInCurrentClient(name, mode string, readOnly bool) error {
+	if !InsideTmux() {
+		return fmt.Errorf("not inside tmux")
+	}
+	fullName := tm.ensurePrefix(name)
+	attach := tm.command(true, attachArgs(fullName, readOnly)...)
+	quoted := make([]string, len(attach.Args))
+	for i, a := range attach.Args {
+		quoted[i] = shellQuote(a)
+	}
+	// Clear $TMUX so tmux doesn't refuse to nest a client.
+	shell := "TMUX= " + strings.Join(quoted, " ")
+
+	var args []string
+	switch mode {
+	case AttachSplit:
+		args = []string{"split-window", "-h", shell}
+	case AttachWindow:
+		args = []string{"new-window", "-n", strings.TrimPrefix(fullName, sessionPrefix), shell}
+	default:
+		return fmt.Errorf("unknown attach mode %q", mode)
+	}
+	// No -L: the command goes to the server in $TMUX, i.e. the user's client.
+	if out, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("open %s in %s: %s", fullName, mode, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // AttachSession attaches to an existing tmux session.
 // name can be either a short name (prefix is added) or a full tmux name
 // (already prefixed with "vibeflow_").
@@ -436,13 +883,15 @@ func (tm *TmuxManager) AttachSession(name string) error {
 // session. The command has Stdin/Stdout/Stderr wired to os.Std*, ready for
 // use with tea.ExecProcess.
 // When running inside tmux, it uses switch-client instead of attach-session.
+// Remote managers always attach over `ssh -t`: the local $TMUX says nothing
+// about a client on the remote server, so switch-client would have no target.
 func (tm *TmuxManager) AttachSessionCmd(name string) *exec.Cmd {
 	fullName := tm.ensurePrefix(name)
 	var cmd *exec.Cmd
-	if InsideTmux() {
-		cmd = exec.Command("tmux", "-L", tm.socketName, "switch-client", "-t", fullName)
+	if InsideTmux() && !tm.IsRemote() {
+		cmd = tm.command(false, "switch-client", "-t", fullName)
 	} else {
-		cmd = exec.Command("tmux", "-L", tm.socketName, "attach-session", "-t", fullName)
+		cmd = tm.command(true, "attach-session", "-t", fullName)
 	}
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
@@ -450,6 +899,26 @@ func (tm *TmuxManager) AttachSessionCmd(name string) *exec.Cmd {
 	return cmd
 }
 
+// ObserveSessionCmd returns an *exec.Cmd that attaches to the named session
+// read-only (attach-session -r), wired to os.Std* like AttachSessionCmd.
+// It always starts a new client: switch-client -r would toggle the user's
+// own client to read-only rather than open the session that way.
+func (tm *TmuxManager) ObserveSessionCmd(name string) *exec.Cmd {
+	cmd := tm.command(true, attachArgs(tm.ensurePrefix(name), true)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// attachArgs returns the attach-session arguments for fullName.
+func attachArgs(fullName string, readOnly bool) []string {
+	if readOnly {
+		return []string{"attach-session", "-r", "-t", fullName}
+	}
+	return []string{"attach-session", "-t", fullName}
+}
+
 // KillSession kills a tmux session.
 // name can be either a short name (prefix is added) or a full tmux name.
 func (tm *TmuxManager) KillSession(name string) error {
@@ -502,6 +971,140 @@ func (tm *TmuxManager) CapturePaneOutput(name string, lines int) (string, error)
 	return strings.TrimRight(out, "\n"), nil
 }
 
+// CaptureScrollback returns the pane's entire scrollback history plus the
+// visible screen (`capture-pane -S -`), bounded only by the server's
+// history-limit. Used by the full-screen output viewer; the detail panel
+// keeps using the cheaper CapturePaneOutput.
+func (tm *TmuxManager) CaptureScrollback(name string) (string, error) {
+	fullName := tm.ensurePrefix(name)
+	out, err := tm.run("capture-pane", "-p", "-t", fullName, "-S", "-")
+	if err != nil {
+		return "", fmt.Errorf("capture-pane %q: %w", fullName, err)
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// CaptureActivitySample returns the last N lines of a session's pane together
+// with its cursor position ("x,y"), fetched in a single tmux invocation so the
+// activity monitor costs one process per session per sample.
+func (tm *TmuxManager) CaptureActivitySample(name string, lines int) (output, cursor string, err error) {
+	fullName := tm.ensurePrefix(name)
+	out, err := tm.run(
+		"capture-pane", "-p", "-t", fullName, "-S", fmt.Sprintf("-%d", lines), ";",
+		"display-message", "-p", "-t", fullName, "#{cursor_x},#{cursor_y}",
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("capture-pane %q: %w", fullName, err)
+	}
+	out = strings.TrimRight(out, "\n")
+	if i := strings.LastIndexByte(out, '\n'); i >= 0 {
+		return out[:i], out[i+1:], nil
+	}
+	return "", out, nil
+}
+
+// activitySampleMarker starts each session's section of a batched activity
+// sample. Like tmuxListDelim it is printable, so tmux outside a client
+// passes it through unmangled.
+const activitySampleMarker = ":::vibeflow-sample:::"
+
+// ActivitySample is one session's pane tail and cursor position.
+type ActivitySample struct {
+	Output string
+	Cursor string // "x,y"
+}
+
+// CaptureActivitySamples samples several sessions in one tmux invocation:
+// for each, a marker line carrying the session name and cursor, then its
+// pane tail. With many sessions this replaces a process per session per
+// sample with a single one. tmux stops a command sequence at the first
+// failure, so if a session has gone away the rest are sampled one by one;
+// sessions that can't be sampled are left out.
+func (tm *TmuxManager) CaptureActivitySamples(names []string, lines int) map[string]ActivitySample {
+	samples := make(map[string]ActivitySample, len(names))
+	if len(names) == 0 {
+		return samples
+	}
+	// One invocation can only reach one tmux server.
+	if groups := tm.groupBySocket(names); len(groups) > 1 {
+		for _, group := range groups {
+			for name, sample := range tm.CaptureActivitySamples(group, lines) {
+				samples[name] = sample
+			}
+		}
+		return samples
+	}
+	// Sections are keyed by index: a session name in the format string
+	// would be expanded by tmux if it contained '#'.
+	var args []string
+	for i, name := range names {
+		fullName := tm.ensurePrefix(name)
+		if len(args) > 0 {
+			args = append(args, ";")
+		}
+		args = append(args,
+			"display-message", "-p", "-t", fullName, activitySampleMarker+strconv.Itoa(i)+tmuxListDelim+"#{cursor_x},#{cursor_y}", ";",
+			"capture-pane", "-p", "-t", fullName, "-S", fmt.Sprintf("-%d", lines),
+		)
+	}
+	if out, err := tm.run(args...); err == nil {
+		return parseActivitySamples(out, names)
+	}
+	for _, name := range names {
+		if output, cursor, err := tm.CaptureActivitySample(name, lines); err == nil {
+			samples[name] = ActivitySample{Output: output, Cursor: cursor}
+		}
+	}
+	return samples
+}
+
+// groupBySocket splits session names by the socket they live on, keeping
+// the order of names within each group.
+func (tm *TmuxManager) groupBySocket(names []string) [][]string {
+	var groups [][]string
+	index := make(map[string]int)
+	for _, name := range names {
+		socket := tm.socketFor(tm.ensurePrefix(name))
+		i, ok := index[socket]
+		if !ok {
+			i = len(groups)
+			index[socket] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], name)
+	}
+	return groups
+}
+
+// parseActivitySamples splits the output of a batched activity sample of
+// names into per-session samples.
+func parseActivitySamples(out string, names []string) map[string]ActivitySample {
+	samples := make(map[string]ActivitySample, len(names))
+	var (
+		name   string
+		cursor string
+		body   []string
+	)
+	flush := func() {
+		if name != "" {
+			samples[name] = ActivitySample{Output: strings.TrimRight(strings.Join(body, "\n"), "\n"), Cursor: cursor}
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, activitySampleMarker); ok {
+			idx, c, ok := strings.Cut(rest, tmuxListDelim)
+			if i, err := strconv.Atoi(idx); ok && err == nil && i >= 0 && i < len(names) {
+				flush()
+				name, cursor, body = names[i], c, nil
+				continue
+			}
+		}
+		body = append(body, line)
+	}
+	flush()
+	return samples
+}
+
 // SendKeys sends keystrokes to a tmux session's active pane, as if the user
 // typed them. An "Enter" key is appended automatically. This is the foundational
 // primitive for programmatic input injection (e.g. error recovery prompts).
@@ -521,6 +1124,113 @@ func (tm *TmuxManager) SendKeys(name, keys string) error {
 	return nil
 }
 
+// SendKeyNames sends tmux key names (Enter, Escape, y, ...) to a session's
+// active pane without appending Enter, for answering prompts that react to
+// single keys.
+func (tm *TmuxManager) SendKeyNames(name string, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	fullName := tm.ensurePrefix(name)
+	if !tm.HasSession(fullName) {
+		return fmt.Errorf("send-keys: session %q does not exist", fullName)
+	}
+	if _, err := tm.run(append([]string{"send-keys", "-t", fullName}, keys...)...); err != nil {
+		return fmt.Errorf("send-keys %q: %w", fullName, err)
+	}
+	return nil
+}
+
+// RespawnPane kills whatever runs in the session's pane and starts the
+// command the session was created with again. With clear, the screen and
+// scrollback are wiped first so the relaunch starts on a blank pane.
+// name can be a short name or full tmux session name.
+func (tm *TmuxManager) RespawnPane(name string, clear bool) error {
+	fullName := tm.ensurePrefix(name)
+	if !tm.HasSession(fullName) {
+		return fmt.Errorf("respawn-pane: session %q does not exist", fullName)
+	}
+	if clear {
+		_, _ = tm.run("send-keys", "-R", "-t", fullName)
+		_, _ = tm.run("clear-history", "-t", fullName)
+	}
+	if _, err := tm.run("respawn-pane", "-k", "-t", fullName); err != nil {
+		return fmt.Errorf("respawn-pane %q: %w", fullName, err)
+	}
+	return nil
+}
+
+// SignalSession sends sig ("TERM", "INT", ...) to every process in the
+// session pane's process session — the agent and anything it started. With
+// remain-on-exit the pane and its output stay once they exit. SIGSTOP is no
+// use here: tmux continues a pane process as soon as it stops.
+// name can be a short name or full tmux session name.
+func (tm *TmuxManager) SignalSession(name, sig string) error {
+	fullName := tm.ensurePrefix(name)
+	out, err := tm.run("display-message", "-t", fullName, "-p", "#{pane_pid}")
+	if err != nil {
+		return fmt.Errorf("pane pid %q: %s", fullName, strings.TrimSpace(out))
+	}
+	pid := strings.TrimSpace(out)
+	if pid == "" || atoi(pid) == 0 {
+		return fmt.Errorf("pane pid %q: none", fullName)
+	}
+	args := []string{"-" + sig, "-s", pid}
+	var cmd *exec.Cmd
+	if tm.remoteHost == "" {
+		cmd = exec.Command("pkill", args...)
+	} else {
+		cmd = exec.Command("ssh", append([]string{tm.remoteHost, "pkill"}, args...)...)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("signal %s %q: %v %s", sig, fullName, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// queuedPaneCommand holds a queued session's pane until StartQueued
+// replaces it with the agent.
+const queuedPaneCommand = `echo 'Queued: starts when a session slot frees up (max_running_sessions).'; while :; do sleep 3600; done`
+
+// queuedCommandOption is the session user option holding a queued
+// session's launch command.
+const queuedCommandOption = "@vfqueued"
+
+// StartQueued starts the agent of a session created with
+// SessionOpts.Queued, in place of the placeholder in its pane. Later
+// respawns run the agent too. name can be a short name or full tmux
+// session name.
+func (tm *TmuxManager) StartQueued(name string) error {
+	fullName := tm.ensurePrefix(name)
+	out, err := tm.run("show-options", "-v", "-t", fullName, queuedCommandOption)
+	command := strings.TrimRight(out, "\n")
+	if err != nil || command == "" {
+		return fmt.Errorf("session %q is not queued", fullName)
+	}
+	if _, err := tm.run("respawn-pane", "-k", "-t", fullName, command); err != nil {
+		return fmt.Errorf("respawn-pane %q: %w", fullName, err)
+	}
+	_, _ = tm.run("set-option", "-u", "-t", fullName, queuedCommandOption)
+	return nil
+}
+
+// SendKeysResult is the outcome of sending keys to one session.
+type SendKeysResult struct {
+	Name string
+	Err  error
+}
+
+// SendKeysMulti sends the same keystrokes (plus Enter) to every named
+// session, continuing past failures so one dead session doesn't stop the
+// rest. Results are returned in the order of names.
+func (tm *TmuxManager) SendKeysMulti(names []string, keys string) []SendKeysResult {
+	results := make([]SendKeysResult, 0, len(names))
+	for _, name := range names {
+		results = append(results, SendKeysResult{Name: name, Err: tm.SendKeys(name, keys)})
+	}
+	return results
+}
+
 // --- Native multi-session workbench (tmux pane-join composition) ---
 
 // workbenchHolderName is the throwaway session that hosts the composed panes
@@ -546,6 +1256,7 @@ func isWorkbenchHolder(fullName string) bool {
 var workbenchStatusKeys = []string{
 	"status", "status-style", "status-left", "status-right",
 	"status-left-length", "status-right-length",
+	"set-titles", "set-titles-string", "@vftitle",
 }
 
 // workbenchSource records a session whose active pane was moved into the
@@ -636,6 +1347,14 @@ func (tm *TmuxManager) ComposeWorkbench(names []string, titles map[string]string
 	if len(full) < 2 {
 		return nil, fmt.Errorf("workbench needs at least 2 sessions, got %d", len(full))
 	}
+	if tm.projectSockets {
+		socket, err := tm.sharedSocket(full)
+		if err != nil {
+			return nil, err
+		}
+		tm.setSocket(workbenchHolderName, socket)
+		return tm.onSocket(socket).ComposeWorkbench(names, titles)
+	}
 
 	holder := workbenchHolderName
 	// A stale holder left by a previous crash would fail new-session; clear it.
@@ -876,6 +1595,20 @@ func (tm *TmuxManager) ComposeProjectWorkbench(projects []WorkbenchProject, sele
 	if total < 2 {
 		return nil, fmt.Errorf("workbench needs at least 2 sessions, got %d", total)
 	}
+	if tm.projectSockets {
+		var full []string
+		for _, p := range projects {
+			for _, n := range p.Sessions {
+				full = append(full, tm.ensurePrefix(n))
+			}
+		}
+		socket, err := tm.sharedSocket(full)
+		if err != nil {
+			return nil, err
+		}
+		tm.setSocket(workbenchHolderName, socket)
+		return tm.onSocket(socket).ComposeProjectWorkbench(projects, selectLabel, titles)
+	}
 
 	holder := workbenchHolderName
 	if tm.HasSession(holder) {
@@ -1014,9 +1747,11 @@ func (tm *TmuxManager) BindSessionKeys(sessionName string) error {
 		pidPath, pidPath,
 	)
 
-	// Bind both C-q and C-\ to the same action for reliability.
+	// Bind both C-q and C-\ to the same action for reliability. All binds
+	// go to tmux as one command sequence.
 	keys := []string{"C-q", `C-\`}
 
+	var args []string
 	for _, key := range keys {
 		// Build the launch command for when vibeflow is NOT running.
 		var launchCmd string
@@ -1039,34 +1774,48 @@ func (tm *TmuxManager) BindSessionKeys(sessionName string) error {
 		// if-shell: when vibeflow is running, detach-client returns the
 		// terminal to the vibeflow TUI (which is blocked on attach-session).
 		// When not running, launch vibeflow in a popup or new window.
-		_, err = tm.run("bind-key", "-T", "root", key,
-			"if-shell", pidCheck, "detach-client", launchCmd)
-		if err != nil {
-			return fmt.Errorf("bind %s for session %q: %w", key, sessionName, err)
-		}
+		args = append(args, "bind-key", "-T", "root", key,
+			"if-shell", pidCheck, "detach-client", launchCmd, ";")
 	}
 
 	// Bind C-d to detach-client so users can cleanly exit to terminal
 	// while agent sessions continue running in the background.
-	_, err = tm.run("bind-key", "-T", "root", "C-d", "detach-client")
-	if err != nil {
-		return fmt.Errorf("bind C-d for session %q: %w", sessionName, err)
+	args = append(args, "bind-key", "-T", "root", "C-d", "detach-client")
+	if out, err := tm.runOn(tm.socketFor(tm.ensurePrefix(sessionName)), args...); err != nil {
+		return fmt.Errorf("bind keys for session %q: %s: %w", sessionName, strings.TrimSpace(out), err)
 	}
-
 	return nil
 }
 
-// BindAllSessionKeys re-binds vibeflow keys for all live sessions.
-// Call this periodically (e.g. on session refresh) to ensure bindings
-// persist even after tmux configuration reloads.
-func (tm *TmuxManager) BindAllSessionKeys() {
-	sessions, err := tm.ListSessions()
-	if err != nil || len(sessions) == 0 {
+// keyRebindInterval is how often BindAllSessionKeys re-binds the vibeflow
+// keys. A tmux config reload can drop them, but checking on every session
+// refresh would cost a tmux call each poll.
+const keyRebindInterval = time.Minute
+
+// BindAllSessionKeys re-binds vibeflow keys for the live sessions, at most
+// once per keyRebindInterval. Call this periodically (e.g. on session
+// refresh) with the sessions just listed, to ensure bindings persist even
+// after tmux configuration reloads.
+func (tm *TmuxManager) BindAllSessionKeys(sessions []TmuxSession) {
+	if len(sessions) == 0 {
+		return
+	}
+	tm.mu.Lock()
+	if time.Since(tm.keysBoundAt) < keyRebindInterval {
+		tm.mu.Unlock()
 		return
 	}
-	// Bind once using the first session — bindings are global to the tmux
-	// server (root key table), not per-session.
-	_ = tm.BindSessionKeys(sessions[0].Name)
+	tm.keysBoundAt = time.Now()
+	tm.mu.Unlock()
+	// Bind once per socket — bindings are global to the tmux server (root
+	// key table), not per-session.
+	bound := make(map[string]bool)
+	for _, s := range sessions {
+		if socket := tm.socketFor(s.Name); !bound[socket] {
+			bound[socket] = true
+			_ = tm.BindSessionKeys(s.Name)
+		}
+	}
 }
 
 // sanitizeTmuxStatusValue neutralizes externally-sourced strings before they
@@ -1152,13 +1901,24 @@ func buildStatusBarSettings(opts StatusBarOpts) map[string]string {
 		"status-right":        statusRight,
 		"status-left-length":  "60",
 		"status-right-length": "60",
+		// Terminal tab / window title while attached, kept current by
+		// SetSessionTitle.
+		"set-titles":        "on",
+		"set-titles-string": "#{@vftitle}",
 	}
 }
 
 // GetPaneWorkDir returns the current working directory of the active pane
 // in the given tmux session. Used to reconstruct metadata for discovered sessions.
+// The directory ListSessions last saw is used when there is one.
 func (tm *TmuxManager) GetPaneWorkDir(sessionName string) string {
 	fullName := tm.ensurePrefix(sessionName)
+	tm.mu.Lock()
+	dir, ok := tm.paneDirs[fullName]
+	tm.mu.Unlock()
+	if ok {
+		return dir
+	}
 	out, err := tm.run("display-message", "-t", fullName, "-p", "#{pane_current_path}")
 	if err != nil {
 		return ""
@@ -1166,6 +1926,96 @@ func (tm *TmuxManager) GetPaneWorkDir(sessionName string) string {
 	return strings.TrimSpace(out)
 }
 
+// SessionEnv returns the session's environment (the -e values it was
+// created with plus any set since). Variables removed from the session or
+// cleared on purpose (e.g. gateway vars when off) are left out.
+func (tm *TmuxManager) SessionEnv(sessionName string) (map[string]string, error) {
+	out, err := tm.run("show-environment", "-t", tm.ensurePrefix(sessionName))
+	if err != nil {
+		return nil, fmt.Errorf("show-environment: %s", strings.TrimSpace(out))
+	}
+	env := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		// "-NAME" marks a variable removed from the session environment;
+		// "NAME=" one cleared on purpose.
+		name, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || name == "" || value == "" {
+			continue
+		}
+		env[name] = value
+	}
+	return env, nil
+}
+
+// defaultUpdateEnvironment is tmux's default update-environment option: the
+// variables it copies from the client into every session it creates.
+var defaultUpdateEnvironment = []string{"DISPLAY", "KRB5CCNAME", "SSH_ASKPASS", "SSH_AUTH_SOCK", "SSH_AGENT_PID", "SSH_CONNECTION", "WINDOWID", "XAUTHORITY"}
+
+// updateEnvironment returns the variables named by the update-environment
+// option of the socket's server, or tmux's default list when it can't be
+// read.
+func (tm *TmuxManager) updateEnvironment(socket string) map[string]bool {
+	names := defaultUpdateEnvironment
+	if out, err := tm.runOn(socket, "show-options", "-gv", "update-environment"); err == nil {
+		names = strings.Fields(out)
+	}
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
+// SessionEnvNames returns the names of the non-empty environment variables
+// set on the session, sorted. Values are not returned. The variables tmux
+// copies from the client (update-environment, e.g. DISPLAY and
+// SSH_AUTH_SOCK) are left out, since vibeflow didn't set them.
+func (tm *TmuxManager) SessionEnvNames(sessionName string) ([]string, error) {
+	env, err := tm.SessionEnv(sessionName)
+	if err != nil {
+		return nil, err
+	}
+	inherited := tm.updateEnvironment(tm.socketFor(tm.ensurePrefix(sessionName)))
+	names := make([]string, 0, len(env))
+	for name := range env {
+		if !inherited[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetSessionEnv sets a variable in the session environment. Processes
+// started afterwards (a respawned pane, new windows) see it; the running
+// pane does not.
+func (tm *TmuxManager) SetSessionEnv(sessionName, key, value string) error {
+	if _, err := tm.run("set-environment", "-t", tm.ensurePrefix(sessionName), key, value); err != nil {
+		return fmt.Errorf("set-environment %s: %w", key, err)
+	}
+	return nil
+}
+
+// UnsetSessionEnv removes a variable from the session environment.
+func (tm *TmuxManager) UnsetSessionEnv(sessionName, key string) error {
+	if _, err := tm.run("set-environment", "-u", "-t", tm.ensurePrefix(sessionName), key); err != nil {
+		return fmt.Errorf("set-environment -u %s: %w", key, err)
+	}
+	return nil
+}
+
+// PaneCommand returns the name of the process running in the session's
+// active pane (tmux's pane_current_command, e.g. "zsh" or "claude") and
+// the command the pane was started with ("" for the default shell).
+func (tm *TmuxManager) PaneCommand(sessionName string) (current, start string) {
+	out, err := tm.run("display-message", "-t", tm.ensurePrefix(sessionName), "-p", "#{pane_current_command}"+tmuxListDelim+"#{pane_start_command}")
+	if err != nil {
+		return "", ""
+	}
+	current, start, _ = strings.Cut(strings.TrimSpace(out), tmuxListDelim)
+	return current, start
+}
+
 // ParseSessionProvider extracts the provider key from a full tmux session name.
 // Format: "vibeflow_{provider}-{name}" → provider. Returns "" if not parseable.
 func ParseSessionProvider(tmuxName string) string {
@@ -1201,9 +2051,13 @@ func (tm *TmuxManager) ListSessionNames() ([]string, error) {
 }
 
 func (tm *TmuxManager) run(args ...string) (string, error) {
-	fullArgs := append([]string{"-L", tm.socketName}, args...)
-	cmd := exec.Command("tmux", fullArgs...)
-	out, err := cmd.CombinedOutput()
+	return tm.runOn(tm.socketForArgs(args), args...)
+}
+
+// runOn is run on an explicit socket, for commands that target a server
+// rather than a session.
+func (tm *TmuxManager) runOn(socket string, args ...string) (string, error) {
+	out, err := tm.commandOn(socket, false, args...).CombinedOutput()
 	return string(out), err
 }
 
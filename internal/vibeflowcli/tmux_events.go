@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// controlSessionName is the hidden session the control-mode client attaches
+// to. tmux requires a control client to be attached to *some* session; this
+// one deliberately lacks the "vibeflow_" prefix so ListSessions (and therefore
+// the session list, key binding and store discovery) never sees it.
+const controlSessionName = "_vibeflow_events"
+
+// TmuxEventKind classifies a control-mode notification.
+type TmuxEventKind int
+
+const (
+	// TmuxEventSessionsChanged fires when a session is created or destroyed.
+	TmuxEventSessionsChanged TmuxEventKind = iota
+	// TmuxEventSessionRenamed fires when any session is renamed.
+	TmuxEventSessionRenamed
+	// TmuxEventWindowChanged covers window add/close/rename in any session,
+	// which is how pane deaths and respawns usually surface.
+	TmuxEventWindowChanged
+	// TmuxEventExit is sent when the control client is told to exit (server
+	// shutdown, control session killed).
+	TmuxEventExit
+)
+
+// TmuxEvent is one parsed control-mode notification. Arg carries the
+// notification's arguments verbatim (e.g. "$3 newname" for a rename).
+type TmuxEvent struct {
+	Kind TmuxEventKind
+	Arg  string
+}
+
+// parseControlLine turns a single control-mode output line into an event.
+// Only the notifications that affect the session list are reported; command
+// output blocks (%begin/%end), %output and everything else return false.
+func parseControlLine(line string) (TmuxEvent, bool) {
+	if !strings.HasPrefix(line, "%") {
+		return TmuxEvent{}, false
+	}
+	name, arg, _ := strings.Cut(line, " ")
+	switch name {
+	case "%sessions-changed":
+		return TmuxEvent{Kind: TmuxEventSessionsChanged}, true
+	case "%session-renamed":
+		return TmuxEvent{Kind: TmuxEventSessionRenamed, Arg: arg}, true
+	case "%window-add", "%window-close", "%window-renamed",
+		"%unlinked-window-add", "%unlinked-window-close", "%unlinked-window-renamed":
+		return TmuxEvent{Kind: TmuxEventWindowChanged, Arg: arg}, true
+	case "%exit":
+		return TmuxEvent{Kind: TmuxEventExit, Arg: arg}, true
+	}
+	return TmuxEvent{}, false
+}
+
+// readControlEvents scans control-mode output from r and forwards relevant
+// events to ch until r is exhausted or a %exit is seen. It closes ch on return
+// so consumers can detect that the subscriber is gone.
+func readControlEvents(r io.Reader, ch chan<- TmuxEvent) {
+	defer close(ch)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		ev, ok := parseControlLine(sc.Text())
+		if !ok {
+			continue
+		}
+		ch <- ev
+		if ev.Kind == TmuxEventExit {
+			return
+		}
+	}
+}
+
+// WatchEvents starts a tmux control-mode client (`tmux -C`) on this manager's
+// socket and streams session-list notifications on the returned channel,
+// replacing the need to poll list-sessions to notice creates, kills and
+// renames. The channel is closed when the client exits. stop terminates the
+// client; it is safe to call more than once.
+//
+// The client attaches to a hidden control session (created on demand). Its
+// stdin is held open for the client's lifetime — control mode exits on EOF —
+// so the client also goes away on its own when this process dies.
+func (tm *TmuxManager) WatchEvents() (<-chan TmuxEvent, func(), error) {
+	if tm.IsRemote() {
+		return nil, nil, fmt.Errorf("tmux events: not supported for remote hosts")
+	}
+	// A control client sees one server; the poll tick covers project sockets.
+	if tm.projectSockets {
+		return nil, nil, fmt.Errorf("tmux events: not supported with tmux_socket_per_project")
+	}
+	cmd := tm.command(false, "-C", "new-session", "-A", "-D", "-s", controlSessionName)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("tmux events: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("tmux events: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("tmux events: start control client: %w", err)
+	}
+	// Buffered so a burst (e.g. a launch creating several sessions) doesn't
+	// stall tmux while the TUI is busy rendering.
+	ch := make(chan TmuxEvent, 64)
+	go func() {
+		readControlEvents(stdout, ch)
+		_ = cmd.Wait()
+	}()
+	stopped := false
+	stop := func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		_ = stdin.Close()
+		if cmd.Process != nil {
+			_ = cmd.Process.Kill()
+		}
+		_, _ = tm.run("kill-session", "-t", "="+controlSessionName)
+	}
+	if tm.logger != nil {
+		tm.logger.Info("tmux events: control-mode client started on socket %s", tm.socketName)
+	}
+	return ch, stop, nil
+}
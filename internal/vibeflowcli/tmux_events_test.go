@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseControlLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want TmuxEvent
+		ok   bool
+	}{
+		{"%sessions-changed", TmuxEvent{Kind: TmuxEventSessionsChanged}, true},
+		{"%session-renamed $3 vibeflow_claude-x", TmuxEvent{Kind: TmuxEventSessionRenamed, Arg: "$3 vibeflow_claude-x"}, true},
+		{"%unlinked-window-close @7", TmuxEvent{Kind: TmuxEventWindowChanged, Arg: "@7"}, true},
+		{"%exit", TmuxEvent{Kind: TmuxEventExit}, true},
+		{"%begin 1700000000 12 0", TmuxEvent{}, false},
+		{"%output %1 hello", TmuxEvent{}, false},
+		{"plain command output", TmuxEvent{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseControlLine(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseControlLine(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReadControlEvents_StopsAtExitAndCloses(t *testing.T) {
+	in := "%begin 1 1 0\n%end 1 1 0\n%sessions-changed\n%output %1 x\n%exit\n%sessions-changed\n"
+	ch := make(chan TmuxEvent, 8)
+	readControlEvents(strings.NewReader(in), ch)
+	var kinds []TmuxEventKind
+	for ev := range ch {
+		kinds = append(kinds, ev.Kind)
+	}
+	if len(kinds) != 2 || kinds[0] != TmuxEventSessionsChanged || kinds[1] != TmuxEventExit {
+		t.Errorf("events = %v, want [SessionsChanged Exit]", kinds)
+	}
+}
+
+func TestWaitTmuxEvent_CoalescesBurst(t *testing.T) {
+	ch := make(chan TmuxEvent, 8)
+	for i := 0; i < 5; i++ {
+		ch <- TmuxEvent{Kind: TmuxEventSessionsChanged}
+	}
+	if _, ok := waitTmuxEvent(ch)().(tmuxEventMsg); !ok {
+		t.Fatal("expected tmuxEventMsg")
+	}
+	if len(ch) != 0 {
+		t.Errorf("burst not drained, %d events left", len(ch))
+	}
+	close(ch)
+	if _, ok := waitTmuxEvent(ch)().(tmuxEventsClosedMsg); !ok {
+		t.Error("closed channel should yield tmuxEventsClosedMsg")
+	}
+}
+
+func TestPollInterval_SlowsWhileEventsFlow(t *testing.T) {
+	m := Model{config: &Config{PollInterval: 5}}
+	if got := m.pollInterval(); got != 5*time.Second {
+		t.Errorf("without events = %v, want 5s", got)
+	}
+	m.tmuxEvents = make(chan TmuxEvent)
+	if got := m.pollInterval(); got != eventFallbackPoll {
+		t.Errorf("with events = %v, want %v", got, eventFallbackPoll)
+	}
+}
+
+// TestWatchEvents_RealTmux checks that creating a session on a live server is
+// pushed through the control-mode client, and that the hidden control
+// session never shows up in ListSessions.
+func TestWatchEvents_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-events")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+
+	events, stop, err := tm.WatchEvents()
+	if err != nil {
+		t.Skipf("cannot start control client: %v", err)
+	}
+	defer stop()
+
+	// Let the control client attach before generating events.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		// Not HasSession: it would add the vibeflow_ prefix.
+		if _, err := tm.run("has-session", "-t", "="+controlSessionName); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	drain := time.After(200 * time.Millisecond)
+drainLoop:
+	for {
+		select {
+		case <-events:
+		case <-drain:
+			break drainLoop
+		}
+	}
+
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_eventtest"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				t.Fatal("event channel closed before sessions-changed")
+			}
+			if ev.Kind != TmuxEventSessionsChanged {
+				continue
+			}
+			sessions, err := tm.ListSessions()
+			if err != nil {
+				t.Fatalf("ListSessions: %v", err)
+			}
+			for _, s := range sessions {
+				if s.Name == controlSessionName {
+					t.Errorf("control session leaked into ListSessions")
+				}
+			}
+			return
+		case <-timeout:
+			t.Fatal("no sessions-changed event within 5s")
+		}
+	}
+}
@@ -51,10 +51,10 @@ func TestTmuxListDelim_PrintableCollisionSafe(t *testing.T) {
 	if !strings.Contains(tmuxListDelim, ":") {
 		t.Errorf("tmuxListDelim = %q; want a ':'-based sentinel (tmux forbids ':' in session names, so it cannot collide with a name)", tmuxListDelim)
 	}
-	// The -F format must use the delimiter for all six fields (five separators)
+	// The -F format must use the delimiter for all eight fields (seven separators)
 	// and must not carry a stray TAB.
-	if n := strings.Count(listSessionsFormat, tmuxListDelim); n != 5 {
-		t.Errorf("listSessionsFormat has %d delimiters, want 5 (six fields): %q", n, listSessionsFormat)
+	if n := strings.Count(listSessionsFormat, tmuxListDelim); n != 7 {
+		t.Errorf("listSessionsFormat has %d delimiters, want 7 (eight fields): %q", n, listSessionsFormat)
 	}
 	if strings.Contains(listSessionsFormat, "\t") {
 		t.Errorf("listSessionsFormat still contains a TAB: %q", listSessionsFormat)
@@ -84,6 +84,22 @@ func TestParseTmuxSessionLines(t *testing.T) {
 				Windows: 3, Attached: true, PaneDead: true, CreatedAt: "created",
 			}},
 		},
+		{
+			name: "dead pane carries its exit status",
+			in:   "vibeflow_codex-x:::$7:::1:::0:::created:::1:::137",
+			want: []TmuxSession{{
+				Name: "vibeflow_codex-x", ID: "$7",
+				Windows: 1, PaneDead: true, CreatedAt: "created", ExitStatus: "137",
+			}},
+		},
+		{
+			name: "live pane ignores the empty exit status field",
+			in:   "vibeflow_codex-x:::$7:::1:::0:::created:::0:::",
+			want: []TmuxSession{{
+				Name: "vibeflow_codex-x", ID: "$7",
+				Windows: 1, CreatedAt: "created",
+			}},
+		},
 		{
 			name: "empty created-time field",
 			in:   "vibeflow_p:::$1:::1:::0::::::0",
@@ -100,6 +116,13 @@ func TestParseTmuxSessionLines(t *testing.T) {
 				Windows: 2, Attached: false, PaneDead: false, CreatedAt: "created",
 			}},
 		},
+		{
+			name: "pane directory, kept whole when it contains the delimiter",
+			in:   "vibeflow_d:::$5:::1:::0:::c:::0::::::/tmp/a:::b",
+			want: []TmuxSession{{
+				Name: "vibeflow_d", ID: "$5", Windows: 1, CreatedAt: "c", WorkDir: "/tmp/a:::b",
+			}},
+		},
 		{
 			name: "non-vibeflow prefix is skipped",
 			in:   "other_session:::$4:::1:::0:::c:::0",
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestProjectSocketName(t *testing.T) {
+	a := ProjectSocketName("vibeflow", "/src/web")
+	if !strings.HasPrefix(a, "vibeflow-p") || len(a) != len("vibeflow")+10 {
+		t.Errorf("ProjectSocketName = %q", a)
+	}
+	if a != ProjectSocketName("vibeflow", "/src/web") {
+		t.Error("not deterministic")
+	}
+	if a == ProjectSocketName("vibeflow", "/src/api") {
+		t.Error("two repositories share a socket")
+	}
+}
+
+func TestSocketForArgs(t *testing.T) {
+	tm := &TmuxManager{socketName: "vibeflow"}
+	tm.setSocket("vibeflow_claude-web", "vibeflow-p1234abcd")
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"list-sessions"}, "vibeflow"},
+		{[]string{"capture-pane", "-p", "-t", "vibeflow_claude-web", "-S", "-50"}, "vibeflow-p1234abcd"},
+		{[]string{"kill-session", "-t", "=vibeflow_claude-web"}, "vibeflow-p1234abcd"},
+		{[]string{"rename-window", "-t", "vibeflow_claude-web:", "x"}, "vibeflow-p1234abcd"},
+		{[]string{"select-pane", "-t", "vibeflow_claude-web:0.1"}, "vibeflow-p1234abcd"},
+		{[]string{"new-session", "-d", "-s", "vibeflow_claude-web", "-c", "/src/web"}, "vibeflow-p1234abcd"},
+		{[]string{"list-panes", "-s", "-t", "vibeflow_claude-web"}, "vibeflow-p1234abcd"},
+		{[]string{"kill-session", "-t", "vibeflow_codex-other"}, "vibeflow"},
+	}
+	for _, c := range cases {
+		if got := tm.socketForArgs(c.args); got != c.want {
+			t.Errorf("socketForArgs(%q) = %q, want %q", c.args, got, c.want)
+		}
+	}
+	tm.setSocket("vibeflow_claude-web", "vibeflow")
+	if got := tm.SessionSocket("claude-web"); got != "vibeflow" {
+		t.Errorf("after moving back to the base socket = %q", got)
+	}
+}
+
+// TestSessionSocket_ListFails checks an unknown session falls back to the
+// base socket when the base socket can't be listed, instead of rescanning
+// forever.
+func TestSessionSocket_ListFails(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // no tmux: every listing fails
+	tm := &TmuxManager{socketName: "vibeflow", projectSockets: true}
+	if got := tm.SessionSocket("claude-web"); got != "vibeflow" {
+		t.Errorf("SessionSocket = %q, want the base socket", got)
+	}
+}
+
+func TestGroupBySocketAndSharedSocket(t *testing.T) {
+	tm := &TmuxManager{socketName: "vibeflow", projectSockets: true, scanned: true}
+	tm.setSocket("vibeflow_a", "vibeflow-p00000001")
+	tm.setSocket("vibeflow_c", "vibeflow-p00000001")
+	groups := tm.groupBySocket([]string{"a", "b", "c"})
+	if len(groups) != 2 || strings.Join(groups[0], ",") != "a,c" || strings.Join(groups[1], ",") != "b" {
+		t.Errorf("groups = %q", groups)
+	}
+	if s, err := tm.sharedSocket([]string{"vibeflow_a", "vibeflow_c"}); err != nil || s != "vibeflow-p00000001" {
+		t.Errorf("sharedSocket = %q, %v", s, err)
+	}
+	if _, err := tm.sharedSocket([]string{"vibeflow_a", "vibeflow_b"}); err == nil {
+		t.Error("sessions on two sockets should not share a workbench")
+	}
+	if _, err := tm.ComposeWorkbench([]string{"a", "b"}, nil); err == nil || !strings.Contains(err.Error(), "different tmux sockets") {
+		t.Errorf("ComposeWorkbench across sockets = %v", err)
+	}
+}
+
+// TestProjectSockets_RealTmux launches a session with project sockets on and
+// checks it lands on its project's socket, is still listed, and survives
+// the base socket's server being killed.
+func TestProjectSockets_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	tm := NewTmuxManager("vftest-proj")
+	tm.SetProjectSockets(true)
+	workDir := t.TempDir()
+	project := ProjectSocketName("vftest-proj", mainRepoDir(workDir))
+	defer func() {
+		_, _ = tm.run("kill-server")
+		_, _ = tm.runOn(project, "kill-server")
+	}()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_codex-base"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+
+	if err := tm.CreateSessionWithOpts(SessionOpts{Name: "web", Provider: "claude", WorkDir: workDir, Command: "sleep 60"}); err != nil {
+		t.Fatalf("CreateSessionWithOpts: %v", err)
+	}
+	if got := tm.SessionSocket("claude-web"); got != project {
+		t.Fatalf("SessionSocket = %q, want %q", got, project)
+	}
+	if out, _ := tm.run("list-sessions", "-F", "#{session_name}"); strings.Contains(out, "vibeflow_claude-web") {
+		t.Error("session was created on the base socket")
+	}
+
+	// One-shot commands find it without listing first.
+	fresh := NewTmuxManager("vftest-proj")
+	fresh.SetProjectSockets(true)
+	if !fresh.HasSession("claude-web") {
+		t.Error("HasSession on a fresh manager missed the project socket")
+	}
+
+	// A fresh manager finds the session by scanning the socket directory.
+	other := NewTmuxManager("vftest-proj")
+	other.SetProjectSockets(true)
+	names, err := other.ListSessionNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(names, ","); got != "vibeflow_codex-base,vibeflow_claude-web" {
+		t.Errorf("ListSessionNames = %s", got)
+	}
+	if !other.HasSession("claude-web") {
+		t.Error("HasSession does not route to the project socket")
+	}
+
+	_, _ = tm.run("kill-server")
+	if !other.HasSession("claude-web") {
+		t.Error("killing the base server took the project's session with it")
+	}
+	if !other.ServerRunning() {
+		t.Error("ServerRunning should see the project socket")
+	}
+}
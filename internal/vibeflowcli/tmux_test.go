@@ -18,9 +18,11 @@ package vibeflowcli
 
 import (
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 )
 
@@ -1001,3 +1003,302 @@ func TestWorkbenchHeader_StripsControlChars(t *testing.T) {
 		t.Errorf("workbenchPaneTitle leaked ESC: %q", got)
 	}
 }
+
+// TestRespawnPane restarts a session's command in place: the pane keeps its
+// id but runs a fresh process, and with clear the old output is gone.
+// Skipped when tmux is absent.
+func TestRespawnPane(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-respawn")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	if err := tm.CreateSession("respawn", t.TempDir(), "sh -c 'echo started $$; sleep 300'"); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	full := tm.ensurePrefix("respawn")
+	pid := func() string {
+		out, _ := tm.run("display-message", "-p", "-t", full, "#{pane_pid}")
+		return strings.TrimSpace(out)
+	}
+	waitStarted := func() string {
+		var out string
+		for i := 0; i < 50; i++ {
+			out, _ = tm.CaptureScrollback(full)
+			if strings.Contains(out, "started") {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		return out
+	}
+	waitStarted()
+	before := pid()
+
+	if err := tm.RespawnPane("respawn", true); err != nil {
+		t.Fatal(err)
+	}
+	if after := pid(); after == "" || after == before {
+		t.Errorf("pane pid %q -> %q, want a new process", before, after)
+	}
+	if out := waitStarted(); strings.Count(out, "started") != 1 {
+		t.Errorf("scrollback after relaunch should only hold the new run:\n%s", out)
+	}
+
+	if err := tm.RespawnPane("missing", false); err == nil {
+		t.Error("expected an error for a missing session")
+	}
+}
+
+// TestSignalSession ends the process running in a session's pane while
+// the pane itself stays, dead, under remain-on-exit. Skipped when tmux or
+// pkill is absent.
+func TestSignalSession(t *testing.T) {
+	for _, bin := range []string{"tmux", "pkill"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+	tm := NewTmuxManager("vftest-signal")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	if err := tm.CreateSession("signal", t.TempDir(), "sleep 300"); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	full := tm.ensurePrefix("signal")
+	if err := tm.SignalSession("signal", "TERM"); err != nil {
+		t.Fatal(err)
+	}
+	var dead string
+	for i := 0; i < 50; i++ {
+		out, _ := tm.run("display-message", "-p", "-t", full, "#{pane_dead}")
+		if dead = strings.TrimSpace(out); dead == "1" {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if dead != "1" {
+		t.Errorf("pane_dead = %q after TERM, want 1", dead)
+	}
+	if !tm.HasSession(full) {
+		t.Error("session went away; the pane should stay under remain-on-exit")
+	}
+	if err := tm.SignalSession("missing", "TERM"); err == nil {
+		t.Error("expected an error for a missing session")
+	}
+}
+
+func TestOpenInCurrentClient(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-openclient")
+	outer := NewTmuxManager("vftest-openclient-user") // stands in for the user's own tmux
+	for _, m := range []*TmuxManager{tm, outer} {
+		_, _ = m.run("kill-server")
+	}
+	defer func() {
+		for _, m := range []*TmuxManager{tm, outer} {
+			_, _ = m.run("kill-server")
+		}
+	}()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	if err := tm.CreateSession("agent", t.TempDir(), "sleep 300"); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	if _, err := outer.run("new-session", "-d", "-s", "editor", "sleep 300"); err != nil {
+		t.Skipf("cannot create outer session: %v", err)
+	}
+	sock, err := outer.run("display-message", "-p", "-t", "editor", "#{socket_path}")
+	if err != nil {
+		t.Fatalf("socket path: %v", err)
+	}
+	t.Setenv("TMUX", strings.TrimSpace(sock)+",1,0")
+
+	if err := tm.OpenInCurrentClient("agent", AttachSplit); err != nil {
+		t.Fatalf("split: %v", err)
+	}
+	if err := tm.OpenInCurrentClient("agent", AttachWindow); err != nil {
+		t.Fatalf("window: %v", err)
+	}
+	out, _ := outer.run("list-panes", "-s", "-t", "editor", "-F", "#{window_name}")
+	if panes := strings.Fields(out); len(panes) != 3 || panes[2] != "agent" {
+		t.Errorf("outer panes = %q, want the original, a split and an \"agent\" window", panes)
+	}
+	if !tm.HasSession("agent") {
+		t.Error("the agent session must stay in place on its own server")
+	}
+	if err := tm.OpenInCurrentClient("agent", "bogus"); err == nil {
+		t.Error("unknown mode: want an error")
+	}
+	t.Setenv("TMUX", "")
+	if err := tm.OpenInCurrentClient("agent", AttachSplit); err == nil {
+		t.Error("outside tmux: want an error")
+	}
+}
+
+func TestObserveInCurrentClient_ReadOnly(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-observe")
+	outer := NewTmuxManager("vftest-observe-user")
+	for _, m := range []*TmuxManager{tm, outer} {
+		_, _ = m.run("kill-server")
+	}
+	defer func() {
+		for _, m := range []*TmuxManager{tm, outer} {
+			_, _ = m.run("kill-server")
+		}
+	}()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	if err := tm.CreateSession("agent", t.TempDir(), "sleep 300"); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	if _, err := outer.run("new-session", "-d", "-s", "editor", "sleep 300"); err != nil {
+		t.Skipf("cannot create outer session: %v", err)
+	}
+	sock, err := outer.run("display-message", "-p", "-t", "editor", "#{socket_path}")
+	if err != nil {
+		t.Fatalf("socket path: %v", err)
+	}
+	t.Setenv("TMUX", strings.TrimSpace(sock)+",1,0")
+
+	if err := tm.ObserveInCurrentClient("agent", AttachWindow); err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	var clients string
+	for i := 0; i < 50; i++ {
+		clients, _ = tm.run("list-clients", "-F", "#{client_readonly}")
+		if strings.TrimSpace(clients) != "" {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if strings.TrimSpace(clients) != "1" {
+		t.Errorf("clients' read-only flags = %q, want one read-only client", clients)
+	}
+}
+
+// TestSetSessionTitle checks that the window name and the @vftitle option
+// behind set-titles-string follow SetSessionTitle. Skipped when tmux is absent.
+func TestSetSessionTitle(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-title")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+	if err := tm.CreateSessionWithOpts(SessionOpts{Name: "t", Provider: "claude", WorkDir: t.TempDir(), Command: "sleep 30"}); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	full := tm.FullSessionName("claude", "t")
+	if err := tm.SetSessionTitle(full, sessionTitle("claude-t", "claude", "waiting")); err != nil {
+		t.Fatal(err)
+	}
+	for format, want := range map[string]string{
+		"#{window_name}":       "claude-t · claude · waiting",
+		"#{@vftitle}":          "claude-t · claude · waiting",
+		"#{set-titles-string}": "#{@vftitle}",
+	} {
+		out, err := tm.run("display-message", "-t", full, "-p", format)
+		if err != nil {
+			t.Fatalf("display-message %s: %v", format, err)
+		}
+		if got := strings.TrimSpace(out); got != want {
+			t.Errorf("%s = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestParseActivitySamples(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	out := strings.Join([]string{
+		activitySampleMarker + "0" + tmuxListDelim + "3,4",
+		"line one",
+		"line two",
+		"",
+		activitySampleMarker + "1" + tmuxListDelim + "0,0",
+		activitySampleMarker + "9" + tmuxListDelim + "1,1", // out of range: pane text
+		"",
+	}, "\n")
+	got := parseActivitySamples(out, names)
+	want := map[string]ActivitySample{
+		"a": {Output: "line one\nline two", Cursor: "3,4"},
+		"b": {Output: activitySampleMarker + "9" + tmuxListDelim + "1,1", Cursor: "0,0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseActivitySamples = %+v, want %+v", got, want)
+	}
+}
+
+func TestBatchedTmuxQueries(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-batch")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	dir := t.TempDir()
+	for _, name := range []string{"one", "two"} {
+		if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-"+name, "-c", dir,
+			"sh -c 'echo hello-"+name+"; sleep 30'"); err != nil {
+			t.Skipf("cannot create tmux session: %v", err)
+		}
+		waitForPane(t, tm, "claude-"+name, "hello-"+name)
+	}
+
+	samples := tm.CaptureActivitySamples([]string{"claude-one", "claude-two"}, 10)
+	if len(samples) != 2 || !strings.Contains(samples["claude-one"].Output, "hello-one") ||
+		!strings.Contains(samples["claude-two"].Output, "hello-two") || samples["claude-two"].Cursor == "" {
+		t.Errorf("batched samples = %+v", samples)
+	}
+	// A missing session fails the batch; the rest are still sampled.
+	samples = tm.CaptureActivitySamples([]string{"claude-one", "claude-gone", "claude-two"}, 10)
+	if _, ok := samples["claude-gone"]; ok || len(samples) != 2 {
+		t.Errorf("samples with a missing session = %+v", samples)
+	}
+
+	if err := tm.SetSessionTitles(map[string]string{"claude-one": "first;", "claude-two": "second"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetSessionTitles(map[string]string{"claude-gone": "x", "claude-two": "again"}); err == nil {
+		t.Error("title for a missing session reported no error")
+	}
+	for session, want := range map[string]string{"vibeflow_claude-one": "first;", "vibeflow_claude-two": "again"} {
+		out, _ := tm.run("display-message", "-t", session, "-p", "#{@vftitle}")
+		if got := strings.TrimSpace(out); got != want {
+			t.Errorf("%s title = %q, want %q", session, got, want)
+		}
+	}
+
+	if _, err := tm.ListSessions(); err != nil {
+		t.Fatal(err)
+	}
+	resolved, _ := filepath.EvalSymlinks(dir)
+	if got, _ := filepath.EvalSymlinks(tm.paneDirs["vibeflow_claude-one"]); got != resolved {
+		t.Errorf("ListSessions cached pane dir %q, want %q", tm.paneDirs["vibeflow_claude-one"], resolved)
+	}
+	tm.paneDirs["vibeflow_claude-one"] = "/cached"
+	if got := tm.GetPaneWorkDir("claude-one"); got != "/cached" {
+		t.Errorf("GetPaneWorkDir = %q, want the cached directory", got)
+	}
+	delete(tm.paneDirs, "vibeflow_claude-one")
+	if got, _ := filepath.EvalSymlinks(tm.GetPaneWorkDir("claude-one")); got != resolved {
+		t.Errorf("uncached GetPaneWorkDir = %q, want %q", got, resolved)
+	}
+}
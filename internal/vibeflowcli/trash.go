@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// trashBucket holds recently killed sessions: session name → TrashedSession
+// JSON. A later kill of the same name replaces the entry.
+var trashBucket = []byte("trash")
+
+// defaultTrashRetention is how long a killed session stays restorable when
+// trash_retention_hours is unset.
+const defaultTrashRetention = 24 * time.Hour
+
+// TrashedSession is a killed session kept so it can be relaunched with the
+// same settings.
+type TrashedSession struct {
+	Meta SessionMeta `json:"meta"`
+	// RepoRoot is the main checkout Meta.WorktreePath was created from, so
+	// a worktree removed on kill can be recreated from its branch.
+	RepoRoot string    `json:"repo_root,omitempty"`
+	KilledAt time.Time `json:"killed_at"`
+}
+
+// TrashRetention returns how long killed sessions stay in the trash; zero
+// means the trash is disabled.
+func (c *Config) TrashRetention() time.Duration {
+	switch {
+	case c.TrashRetentionHours < 0:
+		return 0
+	case c.TrashRetentionHours == 0:
+		return defaultTrashRetention
+	}
+	return time.Duration(c.TrashRetentionHours) * time.Hour
+}
+
+// trashSession records meta in the trash, resolving the repository its
+// worktree belongs to while the worktree still exists. Call it before any
+// worktree cleanup.
+func trashSession(store *Store, cfg *Config, meta SessionMeta) error {
+	if store == nil || cfg == nil || cfg.TrashRetention() == 0 {
+		return nil
+	}
+	t := TrashedSession{Meta: meta, KilledAt: time.Now()}
+	if meta.WorktreePath != "" {
+		t.RepoRoot = mainRepoDir(meta.WorktreePath)
+	}
+	return store.Trash(t)
+}
+
+// Trash stores t under its session name.
+func (s *Store) Trash(t TrashedSession) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshal trash: %w", err)
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(trashBucket)
+		if err != nil {
+			return fmt.Errorf("create %s bucket: %w", trashBucket, err)
+		}
+		return b.Put([]byte(t.Meta.Name), data)
+	})
+}
+
+// TrashList returns the trashed sessions killed within retention, newest
+// first, and drops the older ones.
+func (s *Store) TrashList(retention time.Duration) ([]TrashedSession, error) {
+	cutoff := time.Now().Add(-retention)
+	var list []TrashedSession
+	err := s.update(func(tx *bolt.Tx) error {
+		list = list[:0]
+		b := tx.Bucket(trashBucket)
+		if b == nil {
+			return errUnchanged
+		}
+		var expired [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			var t TrashedSession
+			if err := json.Unmarshal(v, &t); err != nil {
+				return fmt.Errorf("parse trash: %w", err)
+			}
+			if t.KilledAt.Before(cutoff) {
+				expired = append(expired, append([]byte(nil), k...))
+				return nil
+			}
+			list = append(list, t)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(expired) == 0 {
+			return errUnchanged
+		}
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(list, func(i, j int) bool { return list[i].KilledAt.After(list[j].KilledAt) })
+	return list, err
+}
+
+// TakeFromTrash removes the trashed session called name and returns it.
+func (s *Store) TakeFromTrash(name string) (TrashedSession, bool, error) {
+	var t TrashedSession
+	found := false
+	err := s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(trashBucket)
+		if b == nil {
+			return errUnchanged
+		}
+		v := b.Get([]byte(name))
+		if v == nil {
+			return errUnchanged
+		}
+		if err := json.Unmarshal(v, &t); err != nil {
+			return fmt.Errorf("parse trash: %w", err)
+		}
+		found = true
+		return b.Delete([]byte(name))
+	})
+	return t, found, err
+}
+
+// restoreWorktree recreates t's worktree from its branch when it was removed
+// on kill, and returns the metadata pointing at it. A worktree that is still
+// on disk is reused as is.
+func restoreWorktree(t TrashedSession, cfg *Config) (SessionMeta, error) {
+	meta := t.Meta
+	if meta.WorktreePath == "" {
+		return meta, nil
+	}
+	if _, err := os.Stat(meta.WorktreePath); err == nil {
+		return meta, nil
+	}
+	if t.RepoRoot == "" || meta.Branch == "" {
+		return meta, fmt.Errorf("worktree %s is gone and its repository is unknown", meta.WorktreePath)
+	}
+	if !gitRefExists(t.RepoRoot, "refs/heads/"+meta.Branch) {
+		return meta, fmt.Errorf("worktree %s is gone and branch %q no longer exists", meta.WorktreePath, meta.Branch)
+	}
+	wm, err := NewWorktreeManager(t.RepoRoot, cfg.Worktree.BaseDir)
+	if err != nil {
+		return meta, err
+	}
+	wm.SetSetup(cfg.Worktree.Setup)
+	// Forget the removed worktree so its branch can be checked out again.
+	_, _ = gitIn(t.RepoRoot, "worktree", "prune")
+	path, err := wm.CreateBranchContext(context.Background(), filepath.Dir(meta.WorktreePath), filepath.Base(meta.WorktreePath), meta.Branch, false, "", nil, nil)
+	if err != nil {
+		return meta, fmt.Errorf("recreate worktree: %w", err)
+	}
+	if meta.WorkingDir == meta.WorktreePath {
+		meta.WorkingDir = path
+	} else if rel, err := filepath.Rel(meta.WorktreePath, meta.WorkingDir); err == nil && filepath.IsLocal(rel) {
+		meta.WorkingDir = filepath.Join(path, rel)
+	}
+	meta.WorktreePath = path
+	return meta, nil
+}
+
+// RestoreTrashedSession relaunches the trashed session called name with its
+// stored settings, recreating its worktree when needed, and takes it out of
+// the trash. A session name already running is refused.
+func RestoreTrashedSession(name string, cfg *Config, tmux *TmuxManager, store *Store, cache *SessionCache, registry *ProviderRegistry) (SessionMeta, error) {
+	t, found, err := store.TakeFromTrash(name)
+	if err != nil {
+		return SessionMeta{}, err
+	}
+	if !found {
+		return SessionMeta{}, fmt.Errorf("no killed session %q in the trash", name)
+	}
+	if _, live, _ := store.Get(name); live {
+		_ = store.Trash(t)
+		return SessionMeta{}, fmt.Errorf("session %q already exists", name)
+	}
+	meta, err := restoreWorktree(t, cfg)
+	if err == nil {
+		meta, err = RestartSession(meta, cfg, tmux, store, cache, registry)
+	}
+	if err != nil {
+		// Keep it restorable after fixing whatever went wrong.
+		return SessionMeta{}, errors.Join(err, store.Trash(t))
+	}
+	return meta, nil
+}
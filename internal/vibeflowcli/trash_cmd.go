@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// trashWorktreeState describes what restoring t does with its worktree:
+// "" without one, "kept" when it is still on disk, "recreate" when it can
+// be rebuilt from its branch and "lost" when the branch is gone too.
+func trashWorktreeState(t TrashedSession) string {
+	if t.Meta.WorktreePath == "" {
+		return ""
+	}
+	if _, err := os.Stat(t.Meta.WorktreePath); err == nil {
+		return "kept"
+	}
+	if t.RepoRoot != "" && t.Meta.Branch != "" && gitRefExists(t.RepoRoot, "refs/heads/"+t.Meta.Branch) {
+		return "recreate"
+	}
+	return "lost"
+}
+
+// printTrash writes the `vibeflow trash` table.
+func printTrash(out io.Writer, list []TrashedSession, now time.Time) {
+	fmt.Fprintf(out, "%-8s %-24s %-8s %-24s %s\n", "KILLED", "NAME", "PROVIDER", "BRANCH", "WORKTREE")
+	fmt.Fprintln(out, strings.Repeat("-", 76))
+	for _, t := range list {
+		state := trashWorktreeState(t)
+		if state == "" {
+			state = "-"
+		}
+		fmt.Fprintf(out, "%-8s %-24s %-8s %-24s %s\n",
+			formatSessionDuration(now.Sub(t.KilledAt))+" ago", truncate(t.Meta.Name, 24), truncate(t.Meta.Provider, 8),
+			truncate(t.Meta.Branch, 24), state)
+	}
+}
+
+// --- trash ---
+
+func trashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List recently killed sessions that can be restored",
+		Long: `List sessions killed or deleted within trash_retention_hours (default 24),
+newest first. WORKTREE shows whether a restore reuses the worktree ("kept"),
+recreates it from its branch ("recreate") or cannot ("lost").`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, _, store, _, _, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			list, err := store.TrashList(cfg.TrashRetention())
+			if err != nil {
+				return err
+			}
+			if len(list) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "The trash is empty.")
+				return nil
+			}
+			printTrash(cmd.OutOrStdout(), list, time.Now())
+			return nil
+		},
+	}
+	cmd.AddCommand(trashRestoreCmd())
+	return cmd
+}
+
+func trashRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <session-name>",
+		Short: "Relaunch a killed session with its previous settings",
+		Long: `Relaunch a session from the trash with the provider, model, persona and
+directory it had. A worktree removed on kill is recreated from its branch
+when the branch still exists.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: trashCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath, _ := cmd.Flags().GetString("config")
+			cfg, tmux, store, _, registry, err := loadComponents(cfgPath)
+			if err != nil {
+				return err
+			}
+			// Drop expired entries first so they cannot be restored.
+			if _, err := store.TrashList(cfg.TrashRetention()); err != nil {
+				return err
+			}
+			meta, err := RestoreTrashedSession(args[0], cfg, tmux, store, NewSessionCache(), registry)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Session %q restored in %s.\n", meta.Name, meta.WorkingDir)
+			return nil
+		},
+	}
+}
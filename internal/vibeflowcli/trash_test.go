@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigTrashRetention(t *testing.T) {
+	for hours, want := range map[int]time.Duration{0: defaultTrashRetention, 2: 2 * time.Hour, -1: 0} {
+		cfg := &Config{TrashRetentionHours: hours}
+		if got := cfg.TrashRetention(); got != want {
+			t.Errorf("TrashRetentionHours %d: got %v, want %v", hours, got, want)
+		}
+	}
+}
+
+func TestStoreTrash_ListPurgesExpiredAndTakes(t *testing.T) {
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	now := time.Now()
+	for _, ts := range []TrashedSession{
+		{Meta: SessionMeta{Name: "old"}, KilledAt: now.Add(-3 * time.Hour)},
+		{Meta: SessionMeta{Name: "a"}, KilledAt: now.Add(-time.Hour)},
+		{Meta: SessionMeta{Name: "b"}, KilledAt: now.Add(-time.Minute)},
+	} {
+		if err := store.Trash(ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := store.TrashList(2 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Meta.Name != "b" || list[1].Meta.Name != "a" {
+		t.Fatalf("TrashList = %+v, want b then a", list)
+	}
+	if _, found, _ := store.TakeFromTrash("old"); found {
+		t.Error("expired entry should have been purged")
+	}
+
+	got, found, err := store.TakeFromTrash("a")
+	if err != nil || !found || got.Meta.Name != "a" {
+		t.Fatalf("TakeFromTrash(a) = %+v, %v, %v", got, found, err)
+	}
+	if _, found, _ := store.TakeFromTrash("a"); found {
+		t.Error("entry should be gone after TakeFromTrash")
+	}
+}
+
+func TestTrashSession_DisabledByNegativeRetention(t *testing.T) {
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	if err := trashSession(store, &Config{TrashRetentionHours: -1}, SessionMeta{Name: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := store.TrashList(time.Hour); len(list) != 0 {
+		t.Errorf("trash should stay empty when disabled, got %+v", list)
+	}
+}
+
+func TestRestoreWorktree_RecreatesFromBranch(t *testing.T) {
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := wm.CreateBranch("feat", "feat", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(path, "svc")
+	meta := SessionMeta{Name: "feat", Branch: "feat", WorktreePath: path, WorkingDir: sub}
+
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	cfg := &Config{}
+	if err := trashSession(store, cfg, meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := wm.Remove(path, true); err != nil {
+		t.Fatal(err)
+	}
+	list, _ := store.TrashList(time.Hour)
+	if len(list) != 1 {
+		t.Fatalf("TrashList = %+v", list)
+	}
+	if state := trashWorktreeState(list[0]); state != "recreate" {
+		t.Fatalf("worktree state = %q, want recreate", state)
+	}
+
+	restored, err := restoreWorktree(list[0], cfg)
+	if err != nil {
+		t.Fatalf("restoreWorktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(restored.WorktreePath, "README.md")); err != nil {
+		t.Errorf("worktree not recreated: %v", err)
+	}
+	if want := filepath.Join(restored.WorktreePath, "svc"); restored.WorkingDir != want {
+		t.Errorf("WorkingDir = %q, want %q", restored.WorkingDir, want)
+	}
+}
+
+func TestRestoreWorktree_BranchGone(t *testing.T) {
+	repo := initTestRepo(t)
+	ts := TrashedSession{
+		Meta:     SessionMeta{Name: "x", Branch: "deleted", WorktreePath: filepath.Join(repo, ".worktrees", "x")},
+		RepoRoot: repo,
+	}
+	if state := trashWorktreeState(ts); state != "lost" {
+		t.Errorf("worktree state = %q, want lost", state)
+	}
+	if _, err := restoreWorktree(ts, &Config{}); err == nil {
+		t.Error("expected an error when the branch no longer exists")
+	}
+}
@@ -17,12 +17,15 @@
 package vibeflowcli
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -31,38 +34,65 @@ import (
 	"vibeflow-cli/sessionid"
 )
 
-var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+// ansiRe matches the escape sequences a pane writes to its output: CSI
+// (colors, cursor moves, mode switches), OSC (titles, links) and the short
+// charset and keypad selections.
+var ansiRe = regexp.MustCompile("\x1b\\[[0-9;?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(?:\x07|\x1b\\\\)|\x1b[()][0-9A-Za-z]|\x1b[=>78]")
 
 // Colors for the vibeflow theme — semantic aliases onto the Ocean design
 // system palette (see theme.go / design_system doc #401). Downstream render
-// code references these names; the concrete values live in theme.go.
+// code references these names; the concrete values live in theme.go and
+// setStyles rebuilds them whenever the palette changes.
 var (
-	accentColor  = oceanPrimary
-	dimColor     = oceanMuted
-	errorColor   = oceanError
+	accentColor  lipgloss.Color
+	dimColor     lipgloss.Color
+	errorColor   lipgloss.Color
+	warningColor lipgloss.Color
+
+	titleStyle    lipgloss.Style
+	selectedStyle lipgloss.Style
+
+	statusRunning lipgloss.Style
+	statusIdle    lipgloss.Style
+	statusWaiting lipgloss.Style
+	statusError   lipgloss.Style
+
+	helpStyle lipgloss.Style
+
+	asciiBanner    lipgloss.Color
+	copyrightStyle lipgloss.Style
+)
+
+func init() { setStyles() }
+
+// setStyles derives the shared colors and styles above from the active palette.
+func setStyles() {
+	accentColor = oceanPrimary
+	dimColor = oceanMuted
+	errorColor = oceanError
 	warningColor = oceanWarning
 
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(accentColor).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(accentColor).
+		MarginBottom(1)
 
 	// Selected: dark text on a sky-blue bar (Ocean primary).
 	selectedStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(oceanBackground).
-			Background(oceanPrimary)
+		Bold(true).
+		Foreground(oceanBackground).
+		Background(oceanPrimary)
 
 	statusRunning = lipgloss.NewStyle().Foreground(oceanSuccess)
-	statusIdle    = lipgloss.NewStyle().Foreground(dimColor)
+	statusIdle = lipgloss.NewStyle().Foreground(dimColor)
 	statusWaiting = lipgloss.NewStyle().Foreground(warningColor)
-	statusError   = lipgloss.NewStyle().Foreground(errorColor)
+	statusError = lipgloss.NewStyle().Foreground(errorColor)
 
 	helpStyle = lipgloss.NewStyle().Foreground(dimColor)
 
-	asciiBanner    = accentColor
+	asciiBanner = accentColor
 	copyrightStyle = lipgloss.NewStyle().Foreground(dimColor)
-)
+}
 
 // bannerText is the 3D ASCII art for "VibeFlow" displayed on TUI startup.
 const bannerText = `
@@ -89,10 +119,21 @@ type SessionRow struct {
 	LastHeartbeat time.Time
 	TmuxAttached  bool
 	Recovered     bool
+	ExitStatus    string // exit code of an exited session's agent, if tmux reported one
+	PaneDead      bool   // the agent process has exited; tmux keeps the dead pane
+	PRURL         string // pull request opened from the session's branch, if any
+	Done          bool   // the agent signalled completion (SessionMeta.DoneAt)
+	Group         string // user-defined group, if any
+	Note          string // free-text note (SessionMeta.Note)
+	Socket        string // tmux socket the session runs on
 
 	// LLMGatewayEnabled mirrors SessionMeta.LLMGatewayEnabled so the detail
 	// panel can re-derive the gateway env wiring for the selected session.
 	LLMGatewayEnabled bool
+
+	// ProviderVersion is the provider binary's version at launch
+	// (SessionMeta.ProviderVersion), compared against the installed one.
+	ProviderVersion string
 }
 
 // ViewState controls which sub-view is active.
@@ -105,13 +146,23 @@ const (
 	ViewWorktrees
 	ViewHelp
 	ViewRestart
+	ViewPager
+	ViewBroadcast
+	ViewHistory
+	ViewWorktreeProgress
+	ViewGroupAssign
+	ViewTrash
+	ViewSummary
+	ViewPendingWork
+	ViewSearch
+	ViewNoteEdit
 )
 
 // Model is the Bubble Tea model for vibeflow-cli.
 type Model struct {
 	sessions         []SessionRow
 	cursor           int
-	client           *Client
+	client           VibeflowAPI
 	tmux             *TmuxManager
 	worktrees        *WorktreeManager
 	store            *Store
@@ -120,26 +171,88 @@ type Model struct {
 	width            int
 	height           int
 	err              error
+	notice           string // brief confirmation such as "Copied ...", cleared with err
 	quitting         bool
 	projectID        int64
 	activeView       ViewState
 	wizard           WizardModel
 	conflictModal    ConflictModal
 	worktreeList     WorktreeListModel
-	pendingWizard    *WizardResult      // wizard result waiting for conflict resolution
-	switchMeta       *SessionMeta       // non-nil during quick branch switch flow
-	groupEditRunning []SessionMeta      // non-nil during group edit flow: the running group being reshaped
-	captureOutput    string             // last captured pane output for selected session
-	captureName      string             // tmux session name for current capture
-	confirmDelete    bool               // showing delete confirmation
-	confirmQuit      bool               // showing quit confirmation
-	confirmDetach    bool               // showing detach confirmation
-	workbenchActive  bool               // true while a pane-join workbench is composing/attached/restoring (pauses store prune)
-	serverWarning    string             // non-empty if server unreachable at startup
-	healthMonitor    *HealthMonitor     // session error detection and auto-recovery
-	logger           *Logger            // file-based logger
-	cache            *SessionCache      // session cache for restart-without-intervention
-	restartSelect    RestartSelectModel // dead-session restart multiselect
+	worktreeSizes    *sizeCache               // worktree disk usage, kept across visits to the view
+	permissions      *permissionResponder     // answers permission prompts; nil unless permissions.auto_respond
+	pendingWizard    *WizardResult            // wizard result waiting for conflict resolution
+	switchMeta       *SessionMeta             // non-nil during quick branch switch flow
+	groupEditRunning []SessionMeta            // non-nil during group edit flow: the running group being reshaped
+	captureOutput    string                   // last captured pane output for selected session
+	captureName      string                   // tmux session name for current capture
+	captureChanged   []bool                   // per captureOutput line: new since the previous capture; nil when there is none to compare
+	confirmDelete    bool                     // showing delete confirmation
+	confirmQuit      bool                     // showing quit confirmation
+	confirmDetach    bool                     // showing detach confirmation
+	marked           map[string]bool          // session names marked with space for a bulk op
+	confirmBulk      bulkAction               // bulk op awaiting confirmation (bulkNone = none)
+	workbenchActive  bool                     // true while a pane-join workbench is composing/attached/restoring (pauses store prune)
+	serverWarning    string                   // non-empty while the server is unreachable
+	serverBackoff    time.Duration            // wait before the next reachability check while unreachable
+	serverRetryAt    time.Time                // when that check runs
+	login            *DeviceCode              // device login awaiting approval in the browser
+	collisions       []pendingCollision       // launches held back by a session collision, asked about one at a time
+	readOnly         bool                     // another instance holds the PID lock: look, don't change
+	lockHolder       int                      // PID of that instance, 0 if unknown
+	healthMonitor    *HealthMonitor           // session error detection and auto-recovery
+	recoveryTicking  bool                     // a once-a-second tick is redrawing recovery countdowns
+	healthChecking   bool                     // a checkCaptures round is running
+	captures         *captureCache            // each live session's last pane capture
+	historySync      *historySync             // what refreshSessions last wrote to the session history
+	activity         *ActivityMonitor         // working/idle/waiting classification from pane changes
+	usage            map[string]TokenUsage    // last token/cost summary per short session name
+	paneStatus       map[string]PaneStatus    // tool call, file and tokens read from each session's last capture
+	serverStatus     map[string]SessionStatus // server-reported status per short session name
+	statusFetchName  string                   // session whose status was last fetched
+	statusFetchedAt  time.Time
+	gitSummaries     map[string]GitSummary // git summary of each session's working directory, by name
+	gitFetchName     string                // session whose git summary was last read
+	gitFetchedAt     time.Time
+	notifier         *Notifier           // desktop/webhook alerts; nil when notifications are off
+	statusEvents     *statusTracker      // status transitions between refreshes, for bell/flash alerts
+	alert            terminalAlert       // notifications.bell: what a transition does while unfocused
+	blurred          bool                // the terminal reported that the TUI's pane lost focus
+	flashing         bool                // a flash alert is recoloring the background
+	providerVersions map[string]string   // installed version of each provider binary, by provider key
+	upgrade          *rollingRestart     // rolling restart in progress; nil when none
+	hooks            *HookRunner         // user lifecycle hooks (config hooks.*)
+	heartbeat        *HeartbeatPublisher // reports local sessions to the server; nil when off
+	archiver         *SessionArchiver    // uploads ended sessions to the server; nil when off
+	dispatcher       *AutoDispatcher     // hands ready work to idle sessions; nil when off
+	completion       *completionDetector // spots finished agents ("done" status, auto PRs)
+	prStarted        map[string]bool     // sessions an auto PR was already attempted for
+	timeouts         map[string]string   // pending timeout per short session name, for the detail panel
+	timingOut        map[string]bool     // sessions whose timeout kill/pause is running, until its refresh lands
+	keys             keymap              // session-list key bindings (config keymap)
+	outputViews      outputViewSet       // per-session output scroll and pin; absent follows the tail
+	logger           *Logger             // file-based logger
+	cache            *SessionCache       // session cache for restart-without-intervention
+	restartSelect    RestartSelectModel  // dead-session restart multiselect
+	resumeSession    string              // tmux session Init attaches to (--resume); empty opens on the list
+	plain            bool                // --plain: line-based list, no alt screen, mouse or color
+	pager            PagerModel          // full-screen scrollback viewer (o)
+	broadcast        BroadcastModel      // group-wide prompt (B)
+	groupAssign      GroupAssignModel    // move sessions to a named group (G)
+	trash            TrashModel          // recently killed sessions (u)
+	summary          SummaryModel        // what a session produced (S)
+	history          HistoryModel        // session history (h)
+	pending          []pendingItem       // last poll of the projects' stuck and ready work
+	pendingView      PendingWorkModel    // pending-work drill-down (p)
+	search           SearchModel         // fleet-wide output search (/)
+	noteEdit         NoteEditModel       // edit the selected session's note (c)
+	tmuxEvents       <-chan TmuxEvent    // control-mode notifications; nil = poll only
+
+	// Worktree creation running in the background before a launch.
+	worktreeProgress WorktreeProgressModel
+	worktreeEvents   <-chan tea.Msg // git output, then the result; nil when none
+
+	// Titles last pushed to each session's tmux window, by row name.
+	sessionTitles map[string]string
 
 	// Grouped view state.
 	groupMode       bool              // true = grouped by repo root, false = flat
@@ -147,6 +260,7 @@ type Model struct {
 	collapsedGroups map[string]bool   // repo root → collapsed state
 	groupOrder      []string          // ordered list of repo roots
 	groupedSessions map[string][]int  // repo root → indices into m.sessions
+	sessionGroups   []SessionGroup    // user-defined groups, listed before the repo roots
 
 	// hitmap maps rendered rows of the session list to selectable cursor
 	// positions so mouse clicks resolve to the row under the pointer. It is
@@ -170,6 +284,8 @@ type listHitmap struct {
 	leftWidth  int           // width of the left column; x >= leftWidth is outside the list
 	spans      []listRowSpan // one entry per selectable row, in render order
 	top        int           // scroll offset: body line at the top of the visible window
+	helpY      int           // absolute terminal row of the help bar
+	hints      []hintSpan    // clickable help-bar hints; empty while a prompt shows
 }
 
 func (h *listHitmap) resetSpans() {
@@ -184,6 +300,13 @@ func (h *listHitmap) addSpan(startY, height, pos int) {
 	}
 }
 
+func (h *listHitmap) setHelpBar(y int, hints []hintSpan) {
+	if h != nil {
+		h.helpY = y
+		h.hints = hints
+	}
+}
+
 func (h *listHitmap) setViewport(contentTop, leftWidth int) {
 	if h != nil {
 		h.contentTop = contentTop
@@ -192,12 +315,44 @@ func (h *listHitmap) setViewport(contentTop, leftWidth int) {
 }
 
 // NewModel creates a new TUI model.
-func NewModel(cfg *Config, client *Client, tmux *TmuxManager, worktrees *WorktreeManager, store *Store, cache *SessionCache, registry *ProviderRegistry, projectID int64) Model {
+func NewModel(cfg *Config, client VibeflowAPI, tmux *TmuxManager, worktrees *WorktreeManager, store *Store, cache *SessionCache, registry *ProviderRegistry, projectID int64) Model {
 	logger := NewLogger()
 	logger.Info("vibeflow-cli started (server=%s, project=%s)", cfg.ServerURL, cfg.DefaultProject)
 	tmux.SetLogger(logger)
-	errorRegistry := NewErrorPatternRegistry()
+	tmux.SetSessionLogging(cfg.SessionLogs)
+	errorRegistry := NewErrorPatternRegistryWithFile(DefaultErrorPatternsPath())
 	healthMonitor := NewHealthMonitor(errorRegistry, tmux, cfg.ErrorRecovery, logger)
+	if store != nil {
+		if err := healthMonitor.Restore(store); err != nil {
+			logger.Warn("health: saved session health not loaded: %v", err)
+		}
+	}
+	completion, err := newCompletionDetector(cfg.AutoPR, registry)
+	if err != nil {
+		logger.Warn("%v; using the default completion marker", err)
+		completion, _ = newCompletionDetector(AutoPRConfig{}, nil)
+	}
+	if err := cfg.Timeouts.Validate(); err != nil {
+		logger.Warn("session timeouts disabled: %v", err)
+	}
+	if err := cfg.Auth.Validate(); err != nil {
+		logger.Warn("%v; using api_token", err)
+	}
+	alert, err := newTerminalAlert(cfg.Notifications)
+	if err != nil {
+		logger.Warn("%v; terminal alerts off", err)
+	}
+	keys, err := newKeymap(cfg.Keymap)
+	if err != nil {
+		logger.Warn("%v; using the default keys", err)
+		keys = defaultKeymap()
+	}
+	theme, err := newTheme(cfg.Theme)
+	if err != nil {
+		logger.Warn("%v; using the dark theme", err)
+		theme = darkTheme
+	}
+	applyTheme(theme)
 	return Model{
 		config:          cfg,
 		client:          client,
@@ -210,6 +365,22 @@ func NewModel(cfg *Config, client *Client, tmux *TmuxManager, worktrees *Worktre
 		activeView:      ViewSessions,
 		logger:          logger,
 		healthMonitor:   healthMonitor,
+		captures:        newCaptureCache(),
+		historySync:     &historySync{},
+		worktreeSizes:   newSizeCache(),
+		permissions:     newPermissionResponder(cfg.Permissions),
+		activity:        NewActivityMonitor(0),
+		usage:           make(map[string]TokenUsage),
+		notifier:        NewNotifier(cfg.Notifications, logger),
+		statusEvents:    newStatusTracker(),
+		alert:           alert,
+		hooks:           NewHookRunner(cfg.Hooks, tmux, logger.Warn),
+		heartbeat:       NewHeartbeatPublisher(cfg.Heartbeat, client, logger),
+		archiver:        NewSessionArchiver(cfg.Archive, client, logger),
+		dispatcher:      NewAutoDispatcher(cfg.AutoDispatch, client, logger),
+		completion:      completion,
+		prStarted:       make(map[string]bool),
+		keys:            keys,
 		groupMode:       cfg.ViewMode == "grouped",
 		repoRootCache:   make(map[string]string),
 		collapsedGroups: make(map[string]bool),
@@ -246,6 +417,7 @@ type tickMsg time.Time
 // sessionsMsg carries refreshed session data.
 type sessionsMsg struct {
 	sessions []SessionRow
+	groups   []SessionGroup
 	err      error
 }
 
@@ -258,10 +430,85 @@ type captureTickMsg time.Time
 // cacheGCMsg triggers periodic session cache garbage collection.
 type cacheGCMsg time.Time
 
-// captureMsg carries captured pane output.
+// worktreeGCMsg triggers background pruning of stale worktrees.
+type worktreeGCMsg time.Time
+
+// captureMsg carries captured pane output: the selected session's for the
+// detail panel, and every live session's for the health monitor.
 type captureMsg struct {
-	name   string
-	output string
+	name    string
+	output  string
+	outputs map[string]string // session → capture, fresh or cached
+}
+
+// autoDispatchTickMsg triggers a round of auto-dispatch to idle sessions.
+type autoDispatchTickMsg time.Time
+
+// heartbeatTickMsg triggers a heartbeat for every live session.
+type heartbeatTickMsg time.Time
+
+// activityTickMsg triggers a pane activity sample of every live session.
+type activityTickMsg time.Time
+
+// usageTickMsg triggers a reread of every session's usage summary.
+type usageTickMsg time.Time
+
+// usageMsg carries the usage summaries found by one sample, keyed by short
+// session name.
+type usageMsg struct {
+	usage map[string]TokenUsage
+}
+
+// activityMsg carries the activity states from one sample, keyed by short
+// session name.
+type activityMsg struct {
+	states  map[string]ActivityState
+	done    []string          // sessions whose output shows a completion marker
+	waiting map[string]string // output of sessions waiting for input
+}
+
+// tmuxEventMsg reports that one or more control-mode events arrived.
+type tmuxEventMsg struct{}
+
+// tmuxEventsClosedMsg reports that the control-mode client went away; the
+// model falls back to polling at the configured interval.
+type tmuxEventsClosedMsg struct{}
+
+// eventFallbackPoll is the refresh interval used while control-mode events
+// are flowing. Events cover tmux-side changes (create/kill/rename); this slow
+// tick still refreshes the API heartbeat enrichment and catches anything tmux
+// doesn't notify about, such as a pane dying under remain-on-exit.
+const eventFallbackPoll = 30 * time.Second
+
+// waitTmuxEvent blocks until a control-mode event arrives, then drains any
+// others already queued so a burst (a multi-persona launch, a group kill)
+// costs one refresh instead of one per event.
+func waitTmuxEvent(ch <-chan TmuxEvent) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-ch; !ok {
+			return tmuxEventsClosedMsg{}
+		}
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return tmuxEventMsg{}
+				}
+			default:
+				return tmuxEventMsg{}
+			}
+		}
+	}
+}
+
+// pollInterval returns the session refresh tick: the configured interval, or
+// the slow fallback while control-mode events drive refreshes.
+func (m Model) pollInterval() time.Duration {
+	d := time.Duration(m.config.PollInterval) * time.Second
+	if m.tmuxEvents != nil && d < eventFallbackPoll {
+		return eventFallbackPoll
+	}
+	return d
 }
 
 func tickCmd(d time.Duration) tea.Cmd {
@@ -276,20 +523,126 @@ func captureTickCmd() tea.Cmd {
 	})
 }
 
-func (m Model) refreshCapture() tea.Msg {
-	idx := m.selectedSessionIdx()
-	if idx < 0 {
-		return captureMsg{}
+func activityTickCmd() tea.Cmd {
+	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+		return activityTickMsg(t)
+	})
+}
+
+func usageTickCmd() tea.Cmd {
+	return tea.Tick(usageSampleInterval, func(t time.Time) tea.Msg {
+		return usageTickMsg(t)
+	})
+}
+
+// sampleUsage rereads the usage summary of every stored session and records
+// it in the session history.
+func (m Model) sampleUsage() tea.Msg {
+	if m.store == nil || m.tmux == nil {
+		return usageMsg{}
 	}
-	name := m.sessions[idx].Name
-	output, err := m.tmux.CapturePaneOutput(name, 20)
+	found, err := recordUsage(m.store, m.tmux, m.registry)
 	if err != nil {
-		return captureMsg{name: name, output: "(no output)"}
+		m.logger.Warn("record usage: %v", err)
 	}
-	if strings.TrimSpace(output) == "" {
-		return captureMsg{name: name, output: "(no output yet)"}
+	return usageMsg{usage: found}
+}
+
+// sampleActivity captures the tail and cursor of every live session and
+// feeds them to the activity monitor. Exited panes are skipped; their status
+// already says "exited".
+func (m Model) sampleActivity() tea.Msg {
+	if m.activity == nil || m.tmux == nil {
+		return activityMsg{}
+	}
+	now := time.Now()
+	states := make(map[string]ActivityState, len(m.sessions))
+	live := make([]string, 0, len(m.sessions))
+	var done []string
+	waiting := make(map[string]string)
+	for _, s := range m.sessions {
+		if !agentInactive(s) {
+			live = append(live, s.Name)
+		}
+	}
+	samples := m.tmux.CaptureActivitySamples(live, 30)
+	for _, s := range m.sessions {
+		sample, ok := samples[s.Name]
+		if !ok {
+			continue
+		}
+		output := stripANSI(sample.Output)
+		states[s.Name] = m.activity.Observe(s.Name, output, sample.Cursor, now)
+		if states[s.Name] == ActivityWaiting {
+			waiting[s.Name] = output
+		}
+		if m.completion.OutputDone(s.Provider, output) {
+			done = append(done, s.Name)
+		}
 	}
-	return captureMsg{name: name, output: stripANSI(output)}
+	m.activity.Prune(live)
+	return activityMsg{states: states, done: done, waiting: waiting}
+}
+
+// notifyAttention reports sessions that are blocked on input or whose error
+// recovery gave up. Attached sessions don't notify for input prompts: the
+// user is already looking at them.
+func (m Model) notifyAttention() {
+	if m.notifier == nil {
+		return
+	}
+	live := make([]string, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		live = append(live, s.Name)
+		m.notifier.Set(s.Name, NotifyNeedsInput, s.Status == "waiting" && !s.TmuxAttached, "")
+		failed, detail := false, ""
+		if m.healthMonitor != nil {
+			if sh := m.healthMonitor.GetHealth(s.Name); sh != nil && sh.Status == HealthFailed && !sh.Acknowledged {
+				failed = true
+				if sh.MatchedPattern != nil {
+					detail = sh.MatchedPattern.Description
+				}
+			}
+		}
+		m.notifier.Set(s.Name, NotifyFailed, failed, detail)
+		m.notifier.Set(s.Name, NotifyReadyForReview, s.PRURL != "", s.PRURL)
+	}
+	m.notifier.Prune(live)
+}
+
+// applyActivity replaces the tmux-derived status of live rows with the
+// activity classification, when one is known.
+func applyActivity(rows []SessionRow, state func(name string) ActivityState) {
+	for i := range rows {
+		if agentInactive(rows[i]) {
+			continue
+		}
+		if st := state(rows[i].Name); st != ActivityUnknown {
+			rows[i].Status = st.String()
+		}
+	}
+}
+
+// changedLines marks the lines of cur that were not in prev. Lines are
+// matched as a multiset rather than by position, so output that scrolled up
+// (or a screen the agent redrew in place) still counts as unchanged, and a
+// line the agent keeps repeating is only new as often as it was added.
+// Blank lines never count as new.
+func changedLines(prev, cur string) []bool {
+	seen := make(map[string]int)
+	for _, line := range strings.Split(prev, "\n") {
+		seen[line]++
+	}
+	lines := strings.Split(cur, "\n")
+	changed := make([]bool, len(lines))
+	for i, line := range lines {
+		if seen[line] > 0 {
+			seen[line]--
+			continue
+		}
+		changed[i] = strings.TrimSpace(line) != ""
+	}
+	return changed
 }
 
 // isWorktreeInUseByOthers returns true if any session other than excludeSession
@@ -330,9 +683,75 @@ func (m Model) safeRemoveWorktree(worktreePath, sessionName string) bool {
 	return true
 }
 
+// historyReconcileInterval is how often refreshSessions reconciles the
+// session history when the stored sessions haven't changed, to close
+// entries that were still within historyReconcileGrace last time.
+const historyReconcileInterval = 5 * time.Minute
+
+// historySync remembers what refreshSessions last wrote to the session
+// history, so a refresh only writes to the state database when the stored
+// or exited sessions change. It is shared by the model's copies.
+type historySync struct {
+	mu           sync.Mutex
+	stored       string          // sorted store names last reconciled, joined
+	reconciledAt time.Time       // when they were
+	exited       map[string]bool // sessions seen exited by the last refresh
+}
+
+// reconcileDue reports whether the history needs reconciling against the
+// stored session names, and if so records that it is being done.
+func (h *historySync) reconcileDue(names []string, now time.Time) bool {
+	if h == nil {
+		return true
+	}
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, "\x00")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if key == h.stored && now.Sub(h.reconciledAt) < historyReconcileInterval {
+		return false
+	}
+	h.stored, h.reconciledAt = key, now
+	return true
+}
+
+// newlyExited replaces the set of exited sessions with exited and returns
+// the ones that weren't exited at the last refresh.
+func (h *historySync) newlyExited(exited []string) []string {
+	if h == nil {
+		return exited
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var fresh []string
+	now := make(map[string]bool, len(exited))
+	for _, name := range exited {
+		now[name] = true
+		if !h.exited[name] {
+			fresh = append(fresh, name)
+		}
+	}
+	h.exited = now
+	return fresh
+}
+
 func (m Model) refreshSessions() tea.Msg {
 	var rows []SessionRow
 
+	// Fill slots freed under max_running_sessions first, so a session that
+	// starts shows as running in this refresh. Not while a workbench holds
+	// sessions out of the count.
+	if m.store != nil && !m.workbenchActive && !m.readOnly {
+		started, err := startQueuedSessions(m.config, m.tmux, m.store)
+		for _, name := range started {
+			m.logger.Info("started queued session %s", name)
+		}
+		if err != nil {
+			m.logger.Warn("start queued sessions: %v", err)
+		}
+	}
+
 	// Get tmux sessions
 	tmuxSessions, err := m.tmux.ListSessions()
 	if err != nil {
@@ -340,11 +759,13 @@ func (m Model) refreshSessions() tea.Msg {
 	}
 
 	// Re-bind vibeflow keys to ensure persistence across tmux reloads.
-	m.tmux.BindAllSessionKeys()
+	if !m.readOnly {
+		m.tmux.BindAllSessionKeys(tmuxSessions)
+	}
 
 	// Cross-reference stored sessions against live tmux. Sessions that are no
 	// longer live in tmux are NEVER pruned here — they are retained in
-	// sessions.json so they stay listed (and restartable) until the user
+	// the store so they stay listed (and restartable) until the user
 	// explicitly removes one with 'd'. Silently pruning them would wipe the
 	// store whenever the queried socket had no server (e.g. after a
 	// --root/socket change, or with the tmux server down); any stale-entry
@@ -360,7 +781,7 @@ func (m Model) refreshSessions() tea.Msg {
 	// (absent from tmux), and treating them as orphans would drop
 	// non-reconstructable metadata.
 	recoveredNames := make(map[string]bool)
-	if m.store != nil && !m.workbenchActive {
+	if m.store != nil && !m.workbenchActive && !m.readOnly {
 		discovered := m.store.Discover(tmuxNames)
 		for _, tmuxName := range discovered {
 			provider := ParseSessionProvider(tmuxName)
@@ -383,12 +804,18 @@ func (m Model) refreshSessions() tea.Msg {
 	storeMeta := make(map[string]SessionMeta)
 	if m.store != nil {
 		if metas, err := m.store.List(); err == nil {
+			names := make([]string, 0, len(metas))
 			for _, meta := range metas {
 				storeMeta[meta.TmuxSession] = meta
+				names = append(names, meta.Name)
+			}
+			if !m.workbenchActive && !m.readOnly && m.historySync.reconcileDue(names, time.Now()) {
+				_ = m.store.History().Reconcile(names, time.Now())
 			}
 		}
 	}
 
+	var exited []string
 	for _, ts := range tmuxSessions {
 		// The workbench holder is an internal composition session, not a user
 		// agent — never list it, or it shows as "workbench" and (while a
@@ -401,6 +828,9 @@ func (m Model) refreshSessions() tea.Msg {
 			Name:         shortName,
 			Status:       sessionStatus(ts.Attached, ts.PaneDead),
 			TmuxAttached: ts.Attached,
+			ExitStatus:   ts.ExitStatus,
+			PaneDead:     ts.PaneDead,
+			Socket:       m.tmux.SessionSocket(shortName),
 		}
 		// Enrich with store metadata (provider, branch, worktree, persona).
 		if meta, ok := storeMeta[ts.Name]; ok {
@@ -411,22 +841,57 @@ func (m Model) refreshSessions() tea.Msg {
 			row.Persona = meta.Persona
 			row.WorkingDir = meta.WorkingDir
 			row.LLMGatewayEnabled = meta.LLMGatewayEnabled
+			row.PRURL = meta.PRURL
+			row.ProviderVersion = meta.ProviderVersion
+			row.Group = meta.Group
+			row.Note = meta.Note
+			row.Done = !meta.DoneAt.IsZero()
 		}
 		if recoveredNames[ts.Name] {
 			row.Recovered = true
 		}
+		if meta, ok := storeMeta[ts.Name]; ok && !ts.PaneDead && !meta.QueuedAt.IsZero() {
+			row.Status = "pending"
+		}
+		if meta, ok := storeMeta[ts.Name]; ok && ts.PaneDead {
+			if !meta.PausedAt.IsZero() {
+				row.Status = "paused"
+			} else {
+				if !row.Done && m.completion.ExitDone(meta.Provider, ts.ExitStatus) {
+					if !m.readOnly {
+						_ = m.store.SetDone(meta.Name, time.Now())
+					}
+					row.Done = true
+				}
+				exited = append(exited, meta.Name)
+			}
+		}
 		rows = append(rows, row)
 	}
+	if !m.readOnly {
+		for _, name := range m.historySync.newlyExited(exited) {
+			_ = m.store.History().End(name, ExitExited, time.Now())
+		}
+	}
 
 	// Enrich with VibeFlow API data if available.
 	// Match API sessions by VibeFlowSessionID from the store, since API
 	// session IDs (e.g. "session-20260224-...") differ from tmux names.
-	if m.client != nil && m.projectID > 0 {
+	if m.client != nil && m.projectID > 0 && !m.serverDown() {
 		// Build vibeflow session ID → row index map from store metadata.
+		// Index rows rather than tmuxSessions, which still include the
+		// workbench holder. With heartbeats on, unmanaged sessions are
+		// reported under their store name, so they match too.
 		vfIDToRow := make(map[string]int)
-		for i, ts := range tmuxSessions {
-			if meta, ok := storeMeta[ts.Name]; ok && meta.VibeFlowSessionID != "" {
+		for i, row := range rows {
+			meta, ok := storeMeta[sessionPrefix+row.Name]
+			if !ok {
+				continue
+			}
+			if meta.VibeFlowSessionID != "" {
 				vfIDToRow[meta.VibeFlowSessionID] = i
+			} else if m.heartbeat != nil {
+				vfIDToRow[heartbeatSessionID(meta)] = i
 			}
 		}
 
@@ -443,7 +908,82 @@ func (m Model) refreshSessions() tea.Msg {
 		}
 	}
 
-	return sessionsMsg{sessions: rows}
+	applyActivity(rows, m.activity.State)
+	markDone(rows)
+	markReadyForReview(rows)
+	for i := range rows {
+		rows[i].CurrentWork = m.currentWork(rows[i].Name)
+	}
+	var groups []SessionGroup
+	if m.store != nil {
+		groups, _ = m.store.Groups()
+	}
+	return sessionsMsg{sessions: rows, groups: groups}
+}
+
+// exitStatusLabel describes a dead pane's exit status for the detail panel.
+// Codes above 128 are the shell convention for death by signal.
+func exitStatusLabel(status string) string {
+	code, err := strconv.Atoi(status)
+	switch {
+	case err != nil:
+		return "unknown"
+	case code == 0:
+		return "status 0 (exited normally)"
+	case code > 128 && code < 160:
+		return fmt.Sprintf("status %d (killed by signal %d)", code, code-128)
+	default:
+		return fmt.Sprintf("status %d", code)
+	}
+}
+
+// startPendingSession starts a queued session's agent now, ignoring
+// max_running_sessions.
+func (m Model) startPendingSession(row SessionRow) tea.Cmd {
+	return func() tea.Msg {
+		meta, ok := m.storeMetaForRow(row)
+		if !ok {
+			return sessionsMsg{err: fmt.Errorf("session %q not found", row.Name)}
+		}
+		if err := startQueuedSession(m.tmux, m.store, meta); err != nil {
+			return sessionsMsg{err: err}
+		}
+		m.logger.Info("started queued session %s ahead of the queue", row.Name)
+		return m.refreshSessions()
+	}
+}
+
+// respawnSession restarts an exited session's agent in its existing pane
+// with the command it was launched with (respawn-pane -k). The earlier
+// output stays in the scrollback. The relaunch is recorded as a new run in
+// the session history.
+func (m Model) respawnSession(row SessionRow) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.tmux.RespawnPane(row.Name, false); err != nil {
+			return sessionsMsg{err: err}
+		}
+		m.logger.Info("respawned exited session %s", row.Name)
+		if meta, ok := m.storeMetaForRow(row); ok {
+			meta.CreatedAt = time.Now()
+			meta.PausedAt = time.Time{}
+			meta.DoneAt = time.Time{}
+			_ = m.store.Add(meta)
+		}
+		return m.refreshSessions()
+	}
+}
+
+// paneDown reports whether a row's agent is not running: its pane died
+// (the row may show "done" rather than "exited"), or the timeouts policy
+// paused it. Both come back with `r`.
+func paneDown(s SessionRow) bool {
+	return s.PaneDead || s.Status == "exited" || s.Status == "paused"
+}
+
+// agentInactive reports whether a row has no agent running: its pane is
+// down, or it is still pending in the launch queue.
+func agentInactive(s SessionRow) bool {
+	return paneDown(s) || s.Status == "pending"
 }
 
 func sessionStatus(attached, paneDead bool) string {
@@ -479,9 +1019,28 @@ func (m *Model) buildGroups() {
 	seen := make(map[string]bool)
 	m.groupOrder = nil
 
+	// User-defined groups come first, in the order they were created.
+	for _, g := range m.sessionGroups {
+		key := namedGroupKey(g.Name)
+		for i, s := range m.sessions {
+			if s.Group == g.Name {
+				m.groupedSessions[key] = append(m.groupedSessions[key], i)
+			}
+		}
+		if len(m.groupedSessions[key]) > 0 {
+			m.groupOrder = append(m.groupOrder, key)
+			seen[key] = true
+		}
+	}
+
 	for i, s := range m.sessions {
 		root := m.getRepoRoot(s.WorkingDir)
-		if root == "" {
+		if s.Group != "" {
+			if key := namedGroupKey(s.Group); seen[key] {
+				continue
+			}
+			root = namedGroupKey(s.Group) // group record not loaded yet
+		} else if root == "" {
 			root = "(unknown)"
 		}
 		m.groupedSessions[root] = append(m.groupedSessions[root], i)
@@ -543,6 +1102,19 @@ func (m Model) selectedSessionIdx() int {
 	return m.cursor
 }
 
+// tmuxSocketLabel is the tmux socket shown in the footer and help: the
+// selected session's, which differs from the configured one with
+// tmux_socket_per_project. It is read from the row, so View runs no tmux.
+func (m Model) tmuxSocketLabel() string {
+	if idx := m.selectedSessionIdx(); idx >= 0 && m.sessions[idx].Socket != "" {
+		return m.sessions[idx].Socket
+	}
+	if m.config.TmuxSocket == "" {
+		return "vibeflow"
+	}
+	return m.config.TmuxSocket
+}
+
 // rowForGroupEdit resolves the session-list row that the `e` group-edit hotkey
 // should anchor on. When the cursor is on an individual session, that row is
 // returned. When it is on a group HEADER (grouped mode), the group's first
@@ -621,7 +1193,39 @@ func (m Model) selectedRepoRoot() (root string, ok bool) {
 	return m.getRepoRoot(m.sessions[idx].WorkingDir), true
 }
 
+// newGroupBroadcast builds the broadcast prompt for the selected group.
+// Sessions whose agent has exited are listed but not sent to.
+func (m Model) newGroupBroadcast() (BroadcastModel, bool) {
+	label, names := m.selectedProjectSessions()
+	if len(names) == 0 {
+		return BroadcastModel{}, false
+	}
+	exited := make(map[string]bool)
+	for _, s := range m.sessions {
+		exited[s.Name] = agentInactive(s)
+	}
+	var targets, skipped []string
+	for _, n := range names {
+		if exited[n] {
+			skipped = append(skipped, n)
+		} else {
+			targets = append(targets, n)
+		}
+	}
+	return NewBroadcastModel(label, targets, skipped, m.tmux.SendKeysMulti), true
+}
+
 func (m Model) selectedProjectSessions() (label string, names []string) {
+	if m.groupMode {
+		if _, key := m.groupedCursorToSession(); key != "" {
+			if group, ok := namedGroup(key); ok {
+				for _, idx := range m.groupedSessions[key] {
+					names = append(names, m.sessions[idx].Name)
+				}
+				return group, names
+			}
+		}
+	}
 	selRoot, ok := m.selectedRepoRoot()
 	if !ok {
 		return "", nil
@@ -723,14 +1327,168 @@ func cacheGCTickCmd() tea.Cmd {
 	})
 }
 
+func heartbeatTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return heartbeatTickMsg(t)
+	})
+}
+
+// publishHeartbeats reports every live session with a store entry to the
+// server, using the activity status from the last sample. Sessions without
+// a project of their own are reported under the TUI's project.
+func (m Model) publishHeartbeats() tea.Msg {
+	if m.heartbeat == nil || m.store == nil {
+		return nil
+	}
+	metas, err := m.store.List()
+	if err != nil {
+		m.logger.Warn("heartbeat: read store: %v", err)
+		return nil
+	}
+	byTmux := make(map[string]SessionMeta, len(metas))
+	for _, meta := range metas {
+		byTmux[meta.TmuxSession] = meta
+	}
+	samples := make([]heartbeatSample, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		meta, ok := byTmux[sessionPrefix+s.Name]
+		if !ok || agentInactive(s) {
+			continue
+		}
+		projectID := meta.ProjectID
+		if projectID == 0 {
+			projectID = m.projectID
+		}
+		samples = append(samples, heartbeatSample{Meta: meta, ProjectID: projectID, Status: s.Status})
+	}
+	m.heartbeat.Publish(samples)
+	return nil
+}
+
+func autoDispatchTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return autoDispatchTickMsg(t)
+	})
+}
+
+// runAutoDispatch offers ready work to sessions that are idle and not
+// attached: typing into a session the user is looking at would interleave
+// with their own input.
+func (m Model) runAutoDispatch() tea.Msg {
+	if m.dispatcher == nil || m.store == nil || m.tmux == nil {
+		return nil
+	}
+	metas, err := m.store.List()
+	if err != nil {
+		m.logger.Warn("auto-dispatch: read store: %v", err)
+		return nil
+	}
+	byTmux := make(map[string]SessionMeta, len(metas))
+	for _, meta := range metas {
+		byTmux[meta.TmuxSession] = meta
+	}
+	// A provider cooling down from rate limits gets no new work either,
+	// when error_recovery.pause_dispatch_on_rate_limit asks for it.
+	now := time.Now()
+	pauseRateLimited := m.config.ErrorRecovery.PauseDispatchOnRateLimit && m.healthMonitor != nil
+	live := make([]string, 0, len(m.sessions))
+	var candidates []dispatchCandidate
+	for _, s := range m.sessions {
+		live = append(live, s.Name)
+		meta, ok := byTmux[sessionPrefix+s.Name]
+		if !ok || s.Status != "idle" || s.TmuxAttached {
+			continue
+		}
+		if pauseRateLimited && m.healthMonitor.CoolingDown(meta.Provider, now) {
+			continue
+		}
+		projectID := meta.ProjectID
+		if projectID == 0 {
+			projectID = m.projectID
+		}
+		candidates = append(candidates, dispatchCandidate{Name: s.Name, Persona: meta.Persona, ProjectID: projectID})
+	}
+	m.dispatcher.Prune(live)
+	m.dispatcher.Run(candidates, m.tmux.SendKeys, now)
+	return nil
+}
+
+func worktreeGCTickCmd() tea.Cmd {
+	return tea.Tick(time.Hour, func(t time.Time) tea.Msg {
+		return worktreeGCMsg(t)
+	})
+}
+
+// pruneStaleWorktrees removes worktrees that have been idle for
+// worktree.prune_after_days. It runs as a tea.Cmd so the git calls stay off
+// the UI goroutine; results only go to the log.
+func (m Model) pruneStaleWorktrees() tea.Msg {
+	days := m.config.Worktree.PruneAfterDays
+	if m.worktrees == nil || days <= 0 || m.workbenchActive {
+		return nil
+	}
+	inUse, err := worktreesInUse(m.store)
+	if err != nil {
+		m.logger.Warn("worktree gc: %v", err)
+		return nil
+	}
+	stale, err := m.worktrees.FindStale(time.Duration(days)*24*time.Hour, inUse, time.Now())
+	if err != nil {
+		m.logger.Warn("worktree gc: %v", err)
+		return nil
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	removed, errs := m.worktrees.PruneStale(stale)
+	for _, p := range removed {
+		m.logger.Info("worktree gc: removed stale worktree %s", p)
+	}
+	for _, e := range errs {
+		m.logger.Warn("worktree gc: %v", e)
+	}
+	return nil
+}
+
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.refreshSessions,
 		captureTickCmd(),
-		tickCmd(time.Duration(m.config.PollInterval)*time.Second),
-		cacheGCTickCmd(),
-	)
+		activityTickCmd(),
+		tickCmd(m.pollInterval()),
+		gitSummaryTickCmd(),
+		m.checkProviderVersions,
+		providerVersionTickCmd(),
+	}
+	if m.client != nil {
+		cmds = append(cmds, serverStatusTickCmd(), m.firstServerCheck(), m.fetchPendingWork(), pendingWorkTickCmd())
+	}
+	// A read-only TUI leaves usage records, cache GC, worktree pruning,
+	// heartbeats and auto-dispatch to the instance holding the lock.
+	if !m.readOnly {
+		cmds = append(cmds, m.sampleUsage, usageTickCmd(), cacheGCTickCmd())
+		if m.config.Worktree.PruneAfterDays > 0 {
+			cmds = append(cmds, m.pruneStaleWorktrees, worktreeGCTickCmd())
+		}
+		if m.heartbeat != nil {
+			cmds = append(cmds, heartbeatTickCmd(m.heartbeat.Interval()))
+		}
+		if m.archiver != nil {
+			cmds = append(cmds, m.archiveEnded, archiveTickCmd())
+		}
+		if m.dispatcher != nil {
+			cmds = append(cmds, autoDispatchTickCmd(m.dispatcher.Interval()))
+		}
+	}
+	if m.tmuxEvents != nil {
+		cmds = append(cmds, waitTmuxEvent(m.tmuxEvents))
+	}
+	if m.resumeSession != "" {
+		name := m.resumeSession
+		cmds = append(cmds, func() tea.Msg { return autoAttachMsg{name: name} })
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages.
@@ -742,12 +1500,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.FocusMsg:
 		// Pane regained focus (e.g. tmux pane switch). Force a full repaint
 		// so the diff-based renderer doesn't skip lines it assumes are unchanged.
+		m.blurred = false
 		return m, tea.ClearScreen
+	case tea.BlurMsg:
+		m.blurred = true
+		return m, nil
+	case flashEndMsg:
+		m.flashing = false
+		return m, nil
+	case providerVersionTickMsg:
+		return m, tea.Batch(m.checkProviderVersions, providerVersionTickCmd())
+	case providerVersionsMsg:
+		m.providerVersions = msg.versions
+		return m, nil
+	case upgradeStepMsg:
+		return m.handleUpgradeStep(msg)
 	case tickMsg:
 		return m, tea.Batch(
 			m.refreshSessions,
-			tickCmd(time.Duration(m.config.PollInterval)*time.Second),
+			tickCmd(m.pollInterval()),
 		)
+	case tmuxEventMsg:
+		return m, tea.Batch(m.refreshSessions, waitTmuxEvent(m.tmuxEvents))
+	case worktreeOutputMsg:
+		m.worktreeProgress, _ = m.worktreeProgress.Update(msg)
+		return m, waitWorktreeEvent(m.worktreeEvents)
+	case worktreeSpinMsg:
+		if m.activeView != ViewWorktreeProgress {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.worktreeProgress, cmd = m.worktreeProgress.Update(msg)
+		return m, cmd
+	case worktreeCreatedMsg:
+		return m.handleWorktreeCreated(msg)
+	case tmuxEventsClosedMsg:
+		// Control client died (server restart, killed session). The next
+		// tick is already scheduled at the slow interval; refresh now so
+		// nothing is missed, and later ticks use the normal poll interval.
+		m.logger.Warn("tmux events: control-mode client exited, falling back to polling")
+		m.tmuxEvents = nil
+		return m, m.refreshSessions
 	case sessionsMsg:
 		m.err = msg.err
 		if msg.err != nil {
@@ -756,6 +1549,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Tick(10*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
 		}
 		m.sessions = msg.sessions
+		m.sessionGroups = msg.groups
+		for _, g := range msg.groups {
+			m.collapsedGroups[namedGroupKey(g.Name)] = g.Collapsed
+		}
+		m.pruneMarks()
+		m.pruneOutputViews()
 		m.buildGroups()
 		maxIdx := len(m.sessions) - 1
 		if m.groupMode {
@@ -764,31 +1563,97 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.cursor > maxIdx && maxIdx >= 0 {
 			m.cursor = maxIdx
 		}
-		return m, nil
+		return m, m.syncSessionTitles()
 	case errClearMsg:
 		m.err = nil
+		m.notice = ""
 		return m, nil
 	case captureTickMsg:
 		return m, tea.Batch(m.refreshCapture, captureTickCmd())
+	case activityTickMsg:
+		return m, tea.Batch(m.sampleActivity, activityTickCmd())
+	case usageTickMsg:
+		return m, tea.Batch(m.sampleUsage, usageTickCmd())
+	case usageMsg:
+		if msg.usage != nil {
+			m.usage = msg.usage
+		}
+		return m, nil
+	case serverStatusTickMsg:
+		return m.handleServerStatusTick(time.Now())
+	case serverCheckTickMsg:
+		return m, m.checkServer
+	case serverReachMsg:
+		return m.applyServerReach(msg, time.Now())
+	case gitSummaryTickMsg:
+		return m.handleGitSummaryTick(time.Now())
+	case gitSummaryMsg:
+		return m.applyGitSummary(msg), nil
+	case summaryMsg:
+		m.summary, _ = m.summary.Update(msg)
+		return m, nil
+	case loginCodeMsg:
+		return m.applyLoginCode(msg)
+	case loginDoneMsg:
+		return m.applyLoginDone(msg)
+	case collisionMsg:
+		return m.applyCollisions(msg)
+	case serverStatusMsg:
+		m = m.applyServerStatus(msg)
+		if msg.err == nil && m.completion.StatusDone(*msg.status) {
+			m = m.recordDone([]string{msg.name})
+			markDone(m.sessions)
+			markReadyForReview(m.sessions)
+			return m.startAutoPR([]string{msg.name})
+		}
+		return m, nil
+	case activityMsg:
+		applyActivity(m.sessions, func(name string) ActivityState {
+			if st, ok := msg.states[name]; ok {
+				return st
+			}
+			return ActivityUnknown
+		})
+		if m.readOnly {
+			// The instance holding the lock notifies, enforces and opens PRs.
+			return m.alertStatusChanges()
+		}
+		m = m.recordDone(msg.done)
+		markDone(m.sessions)
+		markReadyForReview(m.sessions)
+		m.notifyAttention()
+		m, alert := m.alertStatusChanges()
+		m, upgrade := m.advanceRollingRestart(time.Now())
+		enforce := m.enforceTimeouts()
+		answer := m.answerPermissions(msg.waiting)
+		m, autoPR := m.startAutoPR(msg.done)
+		return m, tea.Batch(alert, upgrade, enforce, answer, autoPR)
+	case timedOutMsg:
+		for _, name := range msg.names {
+			delete(m.timingOut, name)
+		}
+		return m.Update(msg.refresh)
+	case prOpenedMsg:
+		return m.handlePROpened(msg)
 	case captureMsg:
+		if msg.name != "" && msg.name == m.captureName && m.captureOutput != "" {
+			m.captureChanged = changedLines(m.captureOutput, msg.output)
+		} else {
+			m.captureChanged = nil
+		}
 		m.captureOutput = msg.output
 		m.captureName = msg.name
-		// Health monitoring: scan capture output for error patterns.
-		if m.healthMonitor != nil && msg.name != "" && msg.output != "" {
-			provider := ""
-			isAttached := false
-			for _, s := range m.sessions {
-				if s.Name == msg.name {
-					provider = s.Provider
-					isAttached = s.TmuxAttached
-					break
-				}
-			}
-			if shouldRecover := m.healthMonitor.CheckOutput(msg.name, provider, msg.output, isAttached); shouldRecover {
-				_ = m.healthMonitor.AttemptRecovery(msg.name)
-			}
-		}
-		return m, nil
+		health := m.checkCaptures(msg.outputs)
+		m.paneStatus = m.parsePaneStatus(msg.outputs)
+		return m, tea.Batch(health, m.startRecoveryCountdown())
+	case healthCheckedMsg:
+		m.healthChecking = false
+		return m, m.startRecoveryCountdown()
+	case recoveryRetriedMsg:
+		return m, m.startRecoveryCountdown()
+	case recoveryCountdownMsg:
+		m.recoveryTicking = false
+		return m, m.startRecoveryCountdown()
 	case cacheGCMsg:
 		// Periodic session cache garbage collection (every 1 minute).
 		if m.cache != nil {
@@ -797,16 +1662,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, cacheGCTickCmd()
+	case worktreeGCMsg:
+		return m, tea.Batch(m.pruneStaleWorktrees, worktreeGCTickCmd())
+	case pendingWorkTickMsg:
+		return m, tea.Batch(m.fetchPendingWork(), pendingWorkTickCmd())
+	case pendingWorkMsg:
+		m.pending = msg.items
+		m.pendingView.SetItems(msg.items)
+		return m, nil
+	case autoDispatchTickMsg:
+		if m.dispatcher == nil {
+			return m, nil
+		}
+		return m, tea.Batch(m.runAutoDispatch, autoDispatchTickCmd(m.dispatcher.Interval()))
+	case heartbeatTickMsg:
+		if m.heartbeat == nil {
+			return m, nil
+		}
+		return m, tea.Batch(m.publishHeartbeats, heartbeatTickCmd(m.heartbeat.Interval()))
+	case archiveTickMsg:
+		if m.archiver == nil {
+			return m, nil
+		}
+		return m, tea.Batch(m.archiveEnded, archiveTickCmd())
 	case restartConfirmMsg:
 		// User confirmed dead sessions to restart.
 		m.activeView = ViewSessions
+		var failed []string
 		for _, meta := range msg.sessions {
-			if _, err := RestartSession(meta, m.config, m.tmux, m.store, m.cache, m.registry); err != nil {
+			if updated, err := RestartSession(meta, m.config, m.tmux, m.store, m.cache, m.registry); err != nil {
 				m.logger.Error("restart session %s: %v", meta.Name, err)
+				failed = append(failed, meta.Name)
 			} else {
 				m.logger.Info("restarted dead session: %s", meta.Name)
+				m.hooks.Fire(HookSessionCreate, updated, nil)
 			}
 		}
+		if len(failed) > 0 {
+			err := fmt.Errorf("could not restart %s (see vibeflow-cli.log)", strings.Join(failed, ", "))
+			return m, tea.Sequence(m.refreshSessions, func() tea.Msg { return sessionsMsg{err: err} })
+		}
 		return m, m.refreshSessions
 	case restartSkipMsg:
 		// User skipped dead session restart — clean up cache.
@@ -872,10 +1767,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.refreshSessions
 	case autoAttachMsg:
 		// Auto-attach to a newly created session.
-		cmd := m.tmux.AttachSessionCmd(msg.name)
-		return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-			return attachExitMsg{err: err}
-		})
+		return m, m.attachSessionCmd(msg.name)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -900,6 +1792,115 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.restartSelect, cmd = m.restartSelect.Update(msg)
 		return m, cmd
+	case ViewWorktreeProgress:
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.String() == "ctrl+c" {
+			m.worktreeProgress, _ = m.worktreeProgress.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+			m.quitting = true
+			return m, tea.Quit
+		}
+		var cmd tea.Cmd
+		m.worktreeProgress, cmd = m.worktreeProgress.Update(msg)
+		return m, cmd
+	case ViewPager:
+		var cmd tea.Cmd
+		m.pager, cmd = m.pager.Update(msg)
+		if m.pager.Done() {
+			m.activeView = ViewSessions
+			return m, nil
+		}
+		return m, cmd
+	case ViewGroupAssign:
+		var cmd tea.Cmd
+		m.groupAssign, cmd = m.groupAssign.Update(msg)
+		if m.groupAssign.Done() {
+			m.activeView = ViewSessions
+			if group, ok := m.groupAssign.Result(); ok {
+				err := m.assignGroup(m.groupAssignTargets(), group)
+				m.marked = nil
+				if err != nil {
+					return m, tea.Sequence(m.refreshSessions, func() tea.Msg { return sessionsMsg{err: fmt.Errorf("move to group: %w", err)} })
+				}
+				return m, m.refreshSessions
+			}
+			return m, nil
+		}
+		return m, cmd
+	case ViewSummary:
+		var cmd tea.Cmd
+		m.summary, cmd = m.summary.Update(msg)
+		if m.summary.Done() {
+			m.activeView = ViewSessions
+			if m.summary.Archive() {
+				if idx := m.selectedSessionIdx(); idx >= 0 {
+					return m, m.archiveSession(m.sessions[idx])
+				}
+			}
+			return m, nil
+		}
+		return m, cmd
+	case ViewTrash:
+		var cmd tea.Cmd
+		m.trash, cmd = m.trash.Update(msg)
+		if m.trash.Done() {
+			m.activeView = ViewSessions
+			if name, ok := m.trash.Restore(); ok {
+				return m, m.restoreTrashed(name)
+			}
+			return m, nil
+		}
+		return m, cmd
+	case ViewBroadcast:
+		var cmd tea.Cmd
+		m.broadcast, cmd = m.broadcast.Update(msg)
+		if m.broadcast.Done() {
+			m.activeView = ViewSessions
+			return m, m.refreshSessions
+		}
+		return m, cmd
+	case ViewHistory:
+		var cmd tea.Cmd
+		m.history, cmd = m.history.Update(msg)
+		if m.history.Done() {
+			m.activeView = ViewSessions
+			return m, nil
+		}
+		return m, cmd
+	case ViewPendingWork:
+		var cmd tea.Cmd
+		m.pendingView, cmd = m.pendingView.Update(msg)
+		if m.pendingView.Done() {
+			m.activeView = ViewSessions
+			if session, it, ok := m.pendingView.Dispatch(); ok {
+				return m.dispatchPending(session, it)
+			}
+			return m, nil
+		}
+		return m, cmd
+	case ViewNoteEdit:
+		var cmd tea.Cmd
+		m.noteEdit, cmd = m.noteEdit.Update(msg)
+		if m.noteEdit.Done() {
+			m.activeView = ViewSessions
+			if note, ok := m.noteEdit.Result(); ok {
+				if err := m.saveNote(note); err != nil {
+					return m, tea.Sequence(m.refreshSessions, func() tea.Msg { return sessionsMsg{err: fmt.Errorf("save note: %w", err)} })
+				}
+				return m, m.refreshSessions
+			}
+			return m, nil
+		}
+		return m, cmd
+	case ViewSearch:
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		if m.search.Done() {
+			m.activeView = ViewSessions
+			if name, ok := m.search.Selected(); ok {
+				m.selectSession(name)
+			}
+			return m, nil
+		}
+		return m, cmd
 	}
 
 	switch msg := msg.(type) {
@@ -907,6 +1908,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleMouse(msg)
 	case tea.KeyPressMsg:
 		// Handle confirmation dialogs first.
+		if len(m.collisions) > 0 {
+			return m.handleCollisionKey(msg.String())
+		}
 		if m.confirmDelete {
 			switch msg.String() {
 			case "y":
@@ -925,6 +1929,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if m.confirmBulk != bulkNone {
+			action := m.confirmBulk
+			m.confirmBulk = bulkNone
+			if msg.String() == "y" {
+				m.applyBulk(action)
+				return m, m.refreshSessions
+			}
+			return m, nil
+		}
 		if m.confirmQuit {
 			switch msg.String() {
 			case "y":
@@ -947,183 +1960,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
-		case "q":
-			if len(m.sessions) > 0 {
-				m.confirmQuit = true
-				return m, nil
-			}
+		if msg.String() == "ctrl+c" {
 			m.quitting = true
 			return m, tea.Quit
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-		case "down", "j":
-			maxIdx := len(m.sessions) - 1
-			if m.groupMode {
-				maxIdx = m.groupedListLen() - 1
-			}
-			if m.cursor < maxIdx {
-				m.cursor++
-			}
-		case "enter":
-			if m.groupMode {
-				sessionIdx, groupRoot := m.groupedCursorToSession()
-				if sessionIdx == -1 && groupRoot != "" {
-					// Toggle group collapse.
-					m.collapsedGroups[groupRoot] = !m.collapsedGroups[groupRoot]
-					return m, nil
-				}
-				if sessionIdx >= 0 && sessionIdx < len(m.sessions) {
-					return m, m.attachSessionCmd(m.sessions[sessionIdx].Name)
-				}
-			} else if m.cursor < len(m.sessions) {
-				return m, m.attachSessionCmd(m.sessions[m.cursor].Name)
-			}
-		case "g":
-			m.groupMode = !m.groupMode
-			m.cursor = 0
-			// Persist view mode to config.
-			if m.groupMode {
-				m.config.ViewMode = "grouped"
-			} else {
-				m.config.ViewMode = "flat"
-			}
-			_ = SaveConfig(m.config, ConfigPath())
-			return m, nil
-		case "n":
-			repoRoot := "."
-			if m.worktrees != nil {
-				repoRoot = m.worktrees.RepoRoot()
-			}
-			m.wizard = NewWizardModel(m.registry, repoRoot, m.worktrees, m.client, m.config.DefaultProject, m.config.DirectoryHistory, m.config)
-			m.activeView = ViewWizard
-			return m, nil
-		case "d":
-			// In grouped mode, only allow delete when cursor is on a session, not a header.
-			if m.groupMode {
-				if idx, _ := m.groupedCursorToSession(); idx >= 0 {
-					m.confirmDelete = true
-				}
-			} else if m.cursor < len(m.sessions) {
-				m.confirmDelete = true
-			}
-			return m, nil
-		case "b":
-			// Quick branch switch for the selected session.
-			idx := m.selectedSessionIdx()
-			if idx < 0 || idx >= len(m.sessions) || m.store == nil {
-				return m, nil
-			}
-			meta, found := m.storeMetaForRow(m.sessions[idx])
-			if !found {
-				return m, nil
-			}
-			repoRoot := meta.WorkingDir
-			if meta.WorktreePath != "" && m.worktrees != nil {
-				repoRoot = m.worktrees.RepoRoot()
-			}
-			m.wizard = NewQuickSwitchWizard(meta, m.registry, repoRoot, m.worktrees, m.config)
-			m.switchMeta = &meta
-			m.activeView = ViewWizard
-			return m, nil
-		case "e":
-			// Edit the running group of the selected session — or, when a group
-			// header is selected, the whole group under it (#2846). Add/remove
-			// personas (with per-persona provider) reusing the group's shared
-			// repo+branch.
-			if m.store == nil {
-				return m, nil
-			}
-			anchorRow, ok := m.rowForGroupEdit()
-			if !ok {
-				return m, nil
-			}
-			anchor, found := m.storeMetaForRow(anchorRow)
-			if !found {
-				return m, nil
-			}
-			all, err := m.store.List()
-			if err != nil {
-				return m, nil
-			}
-			group := groupSessionsFor(anchor, all, m.getRepoRoot)
-			repoRoot := anchor.WorkingDir
-			if anchor.WorktreePath != "" && m.worktrees != nil {
-				repoRoot = m.worktrees.RepoRoot()
-			}
-			m.groupEditRunning = group
-			m.wizard = NewGroupEditWizard(group, anchor, m.registry, repoRoot, m.worktrees, m.config)
-			m.activeView = ViewWizard
-			return m, nil
-		case "r":
-			// Manual recovery retry for failed sessions, otherwise refresh.
-			idx := m.selectedSessionIdx()
-			if idx >= 0 && idx < len(m.sessions) && m.healthMonitor != nil {
-				if sh := m.healthMonitor.GetHealth(m.sessions[idx].Name); sh != nil && sh.Status == HealthFailed {
-					m.healthMonitor.ResetSession(m.sessions[idx].Name)
-					m.logger.Info("health: manual recovery reset for session %s", m.sessions[idx].Name)
-					return m, nil
-				}
-			}
-			return m, m.refreshSessions
-		case "m":
-			// Project workbench: compose the selected session's project (its
-			// repo-root group) into one natively interactive tmux view. One
-			// session → attach it directly; none → no-op.
-			_, names := m.selectedProjectSessions()
-			switch len(names) {
-			case 0:
-				return m, nil
-			case 1:
-				cmd := m.tmux.AttachSessionCmd(names[0])
-				return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-					return attachExitMsg{err: err}
-				})
-			default:
-				m.workbenchActive = true
-				return m, m.composeWorkbenchCmd(names, m.workbenchMetas(names), m.workbenchTitles())
-			}
-		case "M":
-			// All-projects workbench: one tmux window per project, cycled with
-			// Ctrl-b n/p. Worth composing only with ≥2 sessions total.
-			projects := m.projectGroups()
-			var allNames []string
-			for _, p := range projects {
-				allNames = append(allNames, p.Sessions...)
-			}
-			if len(allNames) < 2 {
-				return m, nil
-			}
-			selLabel, _ := m.selectedProjectSessions()
-			m.workbenchActive = true
-			return m, m.composeProjectWorkbenchCmd(projects, selLabel, m.workbenchMetas(allNames), m.workbenchTitles())
-		case "w":
-			m.worktreeList = NewWorktreeListModel(m.worktrees, m.store)
-			m.activeView = ViewWorktrees
-			return m, nil
-		case "?":
-			m.activeView = ViewHelp
-			return m, nil
-		case "D":
-			// Detach: quit TUI while sessions continue running.
-			if len(m.sessions) > 0 {
-				m.confirmDetach = true
-			} else {
-				m.quitting = true
-				return m, tea.Quit
-			}
-			return m, nil
 		}
+		return m.runAction(m.keys.action(msg.String()))
 
 	case conflictDetectedMsg:
 		result := msg.wizardResult
 		m.pendingWizard = &result
-		m.conflictModal = NewConflictModal(msg.conflict)
+		m.conflictModal = NewConflictModal(msg.conflict).WithCoexisting(msg.coexisting)
 		m.activeView = ViewConflict
 		return m, nil
 	}
@@ -1200,6 +2046,7 @@ func (m Model) updateWizard(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if m.config.Worktree.CleanupOnKill == "always" {
 						m.safeRemoveWorktree(oldMeta.WorktreePath, oldMeta.Name)
 					}
+					_ = m.store.History().End(oldMeta.Name, ExitReplaced, time.Now())
 					_ = m.store.Remove(oldMeta.Name)
 				}
 				if m.cache != nil {
@@ -1211,6 +2058,7 @@ func (m Model) updateWizard(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if result.WorktreeChoice == WorktreeSpecifyDir {
 						dir = result.SpecifiedWorkDir
 					}
+					result.BranchCreated = createsBranch(dir, result.Branch)
 					if err := gitCheckoutBranch(dir, result.Branch, result.NewBranch, result.NewBranchBase); err != nil {
 						return sessionsMsg{err: err}
 					}
@@ -1219,7 +2067,7 @@ func (m Model) updateWizard(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		return m, func() tea.Msg { return m.launchFromWizard(result) }
+		return m.startLaunch(result)
 	}
 
 	return m, cmd
@@ -1249,12 +2097,13 @@ func (m Model) updateConflict(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.refreshSessions()
 		}
 	case ConflictWorktree:
-		// Re-run wizard result with forced worktree.
+		// Re-run the wizard result with a forced worktree. Go back through
+		// launchFromWizard so every persona of a team launch moves with it.
 		if m.pendingWizard != nil {
 			result := *m.pendingWizard
 			result.WorktreeChoice = WorktreeNew
 			m.pendingWizard = nil
-			return m, func() tea.Msg { return m.executeLaunch(result) }
+			return m.startLaunch(result)
 		}
 	case ConflictCleanup:
 		// Clean up stale/external session and proceed with launch.
@@ -1268,10 +2117,15 @@ func (m Model) updateConflict(msg tea.Msg) (tea.Model, tea.Cmd) {
 		_ = CleanupStaleSession(conflictDir, conflictPersona)
 		if m.pendingWizard != nil {
 			result := *m.pendingWizard
-			if result.SessionType == "vibeflow" && oldSessionID != "" {
+			m.pendingWizard = nil
+			if len(result.Personas) > 1 {
+				// Team launch: re-check the remaining personas, which also
+				// carries the old session IDs over per persona.
+				return m, func() tea.Msg { return m.launchFromWizard(result) }
+			}
+			if result.SessionType == "vibeflow" && oldSessionID != "" && conflictPersona == result.Persona {
 				result.ReuseSessionID = oldSessionID
 			}
-			m.pendingWizard = nil
 			return m, func() tea.Msg { return m.executeLaunch(result) }
 		}
 	case ConflictCancel:
@@ -1281,6 +2135,14 @@ func (m Model) updateConflict(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openWorktreeList builds the worktree view and starts measuring the
+// worktrees' disk usage.
+func (m Model) openWorktreeList() (WorktreeListModel, tea.Cmd) {
+	wl := NewWorktreeListModel(m.worktrees, m.store)
+	wl.sizeCache = m.worktreeSizes
+	return wl, wl.Init()
+}
+
 // updateWorktreeList delegates to the worktree list sub-model.
 func (m Model) updateWorktreeList(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.String() == "ctrl+c" {
@@ -1293,9 +2155,11 @@ func (m Model) updateWorktreeList(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if wl.Deleted() && m.worktrees != nil {
 		_ = m.worktrees.Remove(wl.DeletedPath(), true)
+		m.worktreeSizes.Forget(wl.DeletedPath())
+		m.worktreeSizes.Forget(m.worktrees.BaseDirPath())
 		// Stay on worktrees view — rebuild list after deletion.
-		m.worktreeList = NewWorktreeListModel(m.worktrees, m.store)
-		return m, nil
+		m.worktreeList, cmd = m.openWorktreeList()
+		return m, cmd
 	}
 
 	if wl.Done() {
@@ -1312,12 +2176,28 @@ func (m Model) updateWorktreeList(msg tea.Msg) (tea.Model, tea.Cmd) {
 // handles cleanup and ID preservation). Shared by the `d` delete confirmation and
 // the group-edit remove path.
 func (m Model) killSessionByName(name string) {
+	m.killSessionWithReason(name, ExitKilled)
+}
+
+// killSessionWithReason is killSessionByName recording reason as the
+// history exit reason.
+func (m Model) killSessionWithReason(name, reason string) {
+	if meta, ok := m.storeMetaForRow(SessionRow{Name: name}); ok {
+		saveArchiveTail(m.config, m.tmux, m.store, meta)
+	}
 	if err := m.tmux.KillSession(name); err != nil {
 		m.logger.Error("kill session %s: %v", name, err)
 	} else {
 		m.logger.Info("session killed: %s", name)
 	}
 	if m.store != nil {
+		if meta, ok := m.storeMetaForRow(SessionRow{Name: name}); ok {
+			if err := trashSession(m.store, m.config, meta); err != nil {
+				m.logger.Warn("trash session %s: %v", meta.Name, err)
+			}
+			_ = m.store.History().End(meta.Name, reason, time.Now())
+			m.hooks.Fire(HookSessionKill, meta, nil)
+		}
 		if meta, found, _ := m.store.Get(name); found {
 			if m.config.Worktree.CleanupOnKill == "always" {
 				m.safeRemoveWorktree(meta.WorktreePath, meta.Name)
@@ -1341,17 +2221,23 @@ func (m Model) killSessionByName(name string) {
 // The on-disk session file is intentionally kept for ID reuse, matching
 // killSessionByName. Mirrors the quick-branch-switch teardown.
 func (m Model) killSessionMeta(meta SessionMeta) {
+	saveArchiveTail(m.config, m.tmux, m.store, meta)
 	if err := m.tmux.KillSession(meta.TmuxSession); err != nil {
 		m.logger.Error("kill session %s: %v", meta.TmuxSession, err)
 	} else {
 		m.logger.Info("session killed: %s", meta.TmuxSession)
 	}
 	if m.store != nil {
+		if err := trashSession(m.store, m.config, meta); err != nil {
+			m.logger.Warn("trash session %s: %v", meta.Name, err)
+		}
 		if m.config.Worktree.CleanupOnKill == "always" {
 			m.safeRemoveWorktree(meta.WorktreePath, meta.Name)
 		}
+		_ = m.store.History().End(meta.Name, ExitKilled, time.Now())
 		_ = m.store.Remove(meta.Name)
 	}
+	m.hooks.Fire(HookSessionKill, meta, nil)
 	if m.cache != nil {
 		_ = m.cache.Remove(meta.Name)
 	}
@@ -1430,7 +2316,7 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 				}
 				_ = CleanupStaleSession(workDir, result.Persona)
 			case ActiveConflict:
-				return conflictDetectedMsg{conflict: conflict, wizardResult: result}
+				return m.conflictDetected(conflict, result)
 			}
 		case WorktreeSpecifyDir:
 			if result.SpecifiedWorkDir != "" {
@@ -1442,7 +2328,7 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 					}
 					_ = CleanupStaleSession(result.SpecifiedWorkDir, result.Persona)
 				case ActiveConflict:
-					return conflictDetectedMsg{conflict: conflict, wizardResult: result}
+					return m.conflictDetected(conflict, result)
 				}
 			}
 		}
@@ -1468,13 +2354,14 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 			}
 			_ = CleanupStaleSession(workDir, persona)
 		case ActiveConflict:
-			return conflictDetectedMsg{conflict: conflict, wizardResult: result}
+			return m.conflictDetected(conflict, result)
 		}
 	}
 
 	// Spawn a session for each persona. Override result to use the pre-resolved
 	// workDir so executeLaunch doesn't try to create the worktree again.
 	var firstErr error
+	var pending []pendingCollision
 	spawned := 0
 	for _, persona := range personas {
 		r := result
@@ -1493,6 +2380,11 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 		}
 		r.Provider = provider
 		r.ProviderKey = providerKey
+		if providerKey != result.ProviderKey {
+			// The model and profile were picked for the team's provider.
+			r.Model = ""
+			r.Profile = ""
+		}
 		if rID, ok := reuseIDs[persona]; ok {
 			r.ReuseSessionID = rID
 		}
@@ -1503,6 +2395,10 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 			r.WorktreeChoice = WorktreeCurrent
 		}
 		msg := m.executeLaunch(r)
+		if held, ok := msg.(collisionMsg); ok {
+			pending = append(pending, held.pending...)
+			continue
+		}
 		if errMsg, ok := msg.(sessionsMsg); ok && errMsg.err != nil {
 			m.logger.Error("spawn persona %s: %v", persona, errMsg.err)
 			if firstErr == nil {
@@ -1516,6 +2412,9 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 	if spawned == 0 && firstErr != nil {
 		return sessionsMsg{err: fmt.Errorf("all %d persona sessions failed: %w", len(personas), firstErr)}
 	}
+	if len(pending) > 0 {
+		return collisionMsg{pending: pending}
+	}
 	return m.refreshSessions()
 }
 
@@ -1523,6 +2422,18 @@ func (m Model) launchFromWizard(result WizardResult) tea.Msg {
 type conflictDetectedMsg struct {
 	conflict     ConflictResult
 	wizardResult WizardResult
+	coexisting   []ConflictResult // Other personas' session files in the same directory.
+}
+
+// conflictDetected builds the conflict message, collecting the other
+// personas' sessions in the directory so the modal can show that they
+// are unaffected.
+func (m Model) conflictDetected(conflict ConflictResult, result WizardResult) conflictDetectedMsg {
+	return conflictDetectedMsg{
+		conflict:     conflict,
+		wizardResult: result,
+		coexisting:   CheckAllSessions(filepath.Dir(conflict.FilePath), m.tmux),
+	}
 }
 
 // autoAttachMsg signals that a newly created session should be auto-attached.
@@ -1541,6 +2452,7 @@ func (m Model) resolveSessionWorkDir(result WizardResult) (workDir, worktreePath
 	wm := m.worktrees
 	if result.WorkDir != "" && (wm == nil || wm.RepoRoot() != result.WorkDir) {
 		if newWM, wmErr := NewWorktreeManager(result.WorkDir, m.config.Worktree.BaseDir); wmErr == nil {
+			newWM.SetSetup(m.config.Worktree.Setup)
 			wm = newWM
 		}
 	}
@@ -1555,7 +2467,7 @@ func (m Model) resolveSessionWorkDir(result WizardResult) (workDir, worktreePath
 			if wtName == "" {
 				wtName = fmt.Sprintf("%s-%s-%d", provider, branch, time.Now().Unix())
 			}
-			wtPath, wtErr := wm.CreateBranch(wtName, branch, result.NewBranch, result.NewBranchBase)
+			wtPath, wtErr := wm.CreateBranchContext(context.Background(), "", wtName, branch, result.NewBranch, result.NewBranchBase, result.SparsePaths, nil)
 			if wtErr != nil {
 				return "", "", fmt.Errorf("create worktree: %w", wtErr)
 			}
@@ -1573,7 +2485,7 @@ func (m Model) resolveSessionWorkDir(result WizardResult) (workDir, worktreePath
 			if wtName == "" {
 				wtName = fmt.Sprintf("%s-%s-%d", provider, branch, time.Now().Unix())
 			}
-			wtPath, wtErr := wm.CreateBranchInDir(result.CustomBaseDir, wtName, branch, result.NewBranch, result.NewBranchBase)
+			wtPath, wtErr := wm.CreateBranchContext(context.Background(), result.CustomBaseDir, wtName, branch, result.NewBranch, result.NewBranchBase, result.SparsePaths, nil)
 			if wtErr != nil {
 				return "", "", fmt.Errorf("create worktree in custom dir: %w", wtErr)
 			}
@@ -1626,6 +2538,46 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 			m.logger.Info("generated local session ID: %s", vibeflowSessionID)
 		}
 		name = vibeflowSessionID
+	}
+
+	// A reused session ID can name a tmux session that is still running.
+	// session_collision (or the user's answer) decides what happens to it.
+	adopt := false
+	if c, ok := detectSessionCollision(m.tmux, m.store, provider, result.Persona, name); ok {
+		policy := result.OnCollision
+		if policy == "" {
+			policy = m.config.SessionCollision
+		}
+		if _, known := collisionActions[policy]; !known {
+			// Ask. Pin the resolved directory and session ID so the
+			// relaunch doesn't create a second worktree or a new ID.
+			held := result
+			held.WorkDir = workDir
+			held.ReuseSessionID = vibeflowSessionID
+			if worktreePath != "" {
+				held.WorktreeChoice = WorktreeExisting
+				held.ExistingWorktreePath = worktreePath
+			} else {
+				held.WorktreeChoice = WorktreeCurrent
+			}
+			return collisionMsg{pending: []pendingCollision{{collision: c, result: held}}}
+		}
+		action, err := resolveSessionCollision(c, policy, nil)
+		if err != nil {
+			m.logger.Error("session collision on %s: %v", c.TmuxSession, err)
+			return sessionsMsg{err: err}
+		}
+		if name, err = reconcileSessionCollision(m.tmux, m.store, m.cache, c, action); err != nil {
+			return sessionsMsg{err: err}
+		}
+		if action == CollisionAdopt {
+			if c.Meta != nil {
+				return m.refreshSessions()
+			}
+			adopt = true
+		}
+	}
+	if result.SessionType == "vibeflow" {
 		// Ensure .vibeflow-session-{persona} exists so the agent can read it on startup.
 		_ = WriteSessionFileIfNeeded(workDir, result.Persona, vibeflowSessionID)
 	}
@@ -1637,6 +2589,7 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 		ServerURL:       m.config.ServerURL,
 		SessionID:       vibeflowSessionID,
 		SkipPermissions: result.SkipPermissions,
+		Model:           result.Model,
 		Binary:          result.Provider.Binary,
 	})
 	if err == nil && cmd != "" {
@@ -1654,6 +2607,9 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 			result.Provider.Env[k] = v
 		}
 	}
+	if result.Provider.Env, err = withProfileEnv(result.Provider.Env, m.config, provider, result.Profile); err != nil {
+		return sessionsMsg{err: err}
+	}
 
 	// If LLM gateway is enabled, inject gateway env vars for the provider.
 	// Otherwise, explicitly clear gateway-related vars to prevent inheritance
@@ -1690,7 +2646,19 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 	// vibeflow sessions — even if session_init failed, the agent has MCP
 	// access and will call session_init itself on startup.
 	if result.SessionType == "vibeflow" {
-		initPrompt := BuildVibeflowInitPrompt(m.config.MCPToolName, projectName, result.Persona)
+		initPrompt, err := RenderInitPrompt(result.Provider, PromptTemplateVars{
+			Provider:    provider,
+			Project:     projectName,
+			Persona:     result.Persona,
+			Branch:      branch,
+			WorkDir:     workDir,
+			ServerURL:   m.config.ServerURL,
+			SessionID:   vibeflowSessionID,
+			MCPToolName: m.config.MCPToolName,
+		})
+		if err != nil {
+			m.logger.Warn("%s prompt_template: %v (using the default prompt)", provider, err)
+		}
 		command = AppendVibeflowInitPrompt(command, provider, initPrompt)
 	}
 	command, err = WrapOpenShellCommand(command, m.config.OpenShell)
@@ -1702,23 +2670,33 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 	// Ensure all agent-specific markdown docs exist in the working directory
 	// so any provider session picks up vibeflow session rules on startup.
 	if result.SessionType == "vibeflow" {
-		for _, docFile := range EnsureAllAgentDocs(workDir) {
+		docVars := AgentDocVars{Provider: provider, Project: projectName, Persona: result.Persona, SessionID: vibeflowSessionID, Branch: branch, WorkDir: workDir}
+		docVars.pendingWork = agentDocPendingWork(m.config, projectName)
+		docFiles, err := EnsureAllAgentDocsFor(workDir, m.config, docVars)
+		if err != nil {
+			m.logger.Warn("%v (using the bundled rules)", err)
+		}
+		for _, docFile := range docFiles {
 			m.logger.Info("copied agent doc %s to %s", docFile, workDir)
 		}
 	}
 
-	err = m.tmux.CreateSessionWithOpts(SessionOpts{
-		Name:     name,
-		Provider: provider,
-		WorkDir:  workDir,
-		Command:  command,
-		Env:      result.Provider.Env,
-		Branch:   branch,
-		Project:  projectName,
-	})
-	if err != nil {
-		m.logger.Error("create session (provider=%s, workdir=%s): %v", provider, workDir, err)
-		return sessionsMsg{err: err}
+	queued := !adopt && shouldQueueLaunch(m.config, m.tmux, m.store)
+	if !adopt {
+		err = m.tmux.CreateSessionWithOpts(SessionOpts{
+			Name:     name,
+			Provider: provider,
+			WorkDir:  workDir,
+			Command:  command,
+			Env:      result.Provider.Env,
+			Branch:   branch,
+			Project:  projectName,
+			Queued:   queued,
+		})
+		if err != nil {
+			m.logger.Error("create session (provider=%s, workdir=%s): %v", provider, workDir, err)
+			return sessionsMsg{err: err}
+		}
 	}
 
 	// Compute full tmux name for session file and metadata.
@@ -1729,7 +2707,11 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 		m.logger.Error("session %q not verified by has-session after create", tmuxName)
 		return sessionsMsg{err: fmt.Errorf("session %q was not created — tmux has-session check failed", tmuxName)}
 	}
-	m.logger.Info("session created: %s (provider=%s, workdir=%s, command=%q)", tmuxName, provider, workDir, redactCommandSecrets(command))
+	if adopt {
+		m.logger.Info("session adopted: %s (provider=%s, workdir=%s)", tmuxName, provider, workDir)
+	} else {
+		m.logger.Info("session created: %s (provider=%s, workdir=%s, command=%q)", tmuxName, provider, workDir, redactCommandSecrets(command))
+	}
 
 	// Bind Ctrl+Q to open vibeflow TUI popup inside the tmux session.
 	if bindErr := m.tmux.BindSessionKeys(tmuxName); bindErr != nil {
@@ -1754,22 +2736,33 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 		Project:           projectName,
 		Persona:           result.Persona,
 		Branch:            branch,
+		NewBranch:         result.BranchCreated,
 		WorktreePath:      worktreePath,
 		WorkingDir:        workDir,
 		VibeFlowSessionID: vibeflowSessionID,
 		SessionType:       result.SessionType,
 		SkipPermissions:   result.SkipPermissions,
+		Model:             result.Model,
+		ProviderVersion:   launchVersion(result.Provider.Binary),
+		Profile:           result.Profile,
 		LLMGatewayEnabled: result.LLMGatewayEnabled,
 		MCPToolName:       m.config.MCPToolName,
 		OpenShell:         openShellMeta(m.config.OpenShell),
+		Command:           redactCommandSecrets(command),
+		EnvVars:           envVarNames(result.Provider.Env),
 		CreatedAt:         time.Now(),
 	}
+	if queued {
+		sessionMeta.QueuedAt = sessionMeta.CreatedAt
+		m.logger.Info("session %s queued (max_running_sessions %d)", tmuxName, m.config.MaxRunningSessions)
+	}
 	if m.store != nil {
 		_ = m.store.Add(sessionMeta)
 	}
 	if m.cache != nil {
 		_ = m.cache.Add(sessionMeta)
 	}
+	m.hooks.Fire(HookSessionCreate, sessionMeta, nil)
 
 	// Save working directory to history for quick access in future sessions.
 	if result.WorkDir != "" {
@@ -1784,23 +2777,417 @@ func (m Model) executeLaunch(result WizardResult) tea.Msg {
 
 // attachSessionCmd builds the command that attaches to (or, inside tmux,
 // switches to) the named session. Shared by the Enter key and mouse clicks so
-// both activate a session identically.
+// both activate a session identically. Inside tmux, attach_mode "split" or
+// "window" opens the session beside the TUI instead. The attach is recorded
+// in the store so --resume can find the last session used.
 func (m Model) attachSessionCmd(name string) tea.Cmd {
+	if m.config != nil && InsideTmux() && !m.tmux.IsRemote() {
+		if mode := m.config.AttachMode; mode == AttachSplit || mode == AttachWindow {
+			return m.openInClientCmd(name, mode)
+		}
+	}
 	cmd := m.tmux.AttachSessionCmd(name)
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+	return tea.Sequence(m.markAttachedCmd(name), tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return attachExitMsg{err: err}
+	}))
+}
+
+// openInClientCmd opens the named session in a split pane or new window of
+// the user's tmux client (see TmuxManager.OpenInCurrentClient). The TUI keeps
+// running, so the result is reported like any other session error.
+func (m Model) openInClientCmd(name, mode string) tea.Cmd {
+	return tea.Sequence(m.markAttachedCmd(name), func() tea.Msg {
+		if err := m.tmux.OpenInCurrentClient(name, mode); err != nil {
+			return sessionsMsg{err: err}
+		}
+		return m.refreshSessions()
+	})
+}
+
+// observeSessionCmd attaches to the named session read-only, to watch an
+// agent live without typing into it. A client can't be switched to a
+// session read-only, so inside tmux it opens in a new window, or a split
+// pane with attach_mode "split". Watching isn't recorded for --resume.
+func (m Model) observeSessionCmd(name string) tea.Cmd {
+	if InsideTmux() && !m.tmux.IsRemote() {
+		mode := AttachWindow
+		if m.config != nil && m.config.AttachMode == AttachSplit {
+			mode = AttachSplit
+		}
+		return func() tea.Msg {
+			if err := m.tmux.ObserveInCurrentClient(name, mode); err != nil {
+				return sessionsMsg{err: err}
+			}
+			return m.refreshSessions()
+		}
+	}
+	return tea.ExecProcess(m.tmux.ObserveSessionCmd(name), func(err error) tea.Msg {
 		return attachExitMsg{err: err}
 	})
 }
 
+// markAttachedCmd records an attach to name for --resume, off the update
+// loop. It is nil when there is nowhere to record it.
+func (m Model) markAttachedCmd(name string) tea.Cmd {
+	if m.store == nil || m.readOnly {
+		return nil
+	}
+	at := time.Now()
+	return func() tea.Msg {
+		if err := m.store.MarkAttached(m.tmux.ensurePrefix(name), at); err != nil {
+			m.logger.Warn("record attach %s: %v", name, err)
+		}
+		return nil
+	}
+}
+
+// runAction performs a session-list action, whether it came from a key
+// (through the keymap) or a click on its help-bar hint.
+func (m Model) runAction(action keyAction) (tea.Model, tea.Cmd) {
+	if m.readOnly && action != "" && !readOnlyActions[action] {
+		return m.refuseReadOnly(action)
+	}
+	switch action {
+	case actQuit:
+		if len(m.sessions) > 0 {
+			m.confirmQuit = true
+			return m, nil
+		}
+		m.quitting = true
+		return m, tea.Quit
+	case actUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case actDown:
+		maxIdx := len(m.sessions) - 1
+		if m.groupMode {
+			maxIdx = m.groupedListLen() - 1
+		}
+		if m.cursor < maxIdx {
+			m.cursor++
+		}
+	case actAttach:
+		if m.groupMode {
+			sessionIdx, groupRoot := m.groupedCursorToSession()
+			if sessionIdx == -1 && groupRoot != "" {
+				m.toggleGroupCollapsed(groupRoot)
+				return m, nil
+			}
+			if sessionIdx >= 0 && sessionIdx < len(m.sessions) {
+				return m, m.attachSessionCmd(m.sessions[sessionIdx].Name)
+			}
+		} else if m.cursor < len(m.sessions) {
+			return m, m.attachSessionCmd(m.sessions[m.cursor].Name)
+		}
+	case actCopyInfo:
+		return m.copySessionInfo()
+	case actCopyOutput:
+		return m.copySessionOutput()
+	case actFollowOutput:
+		return m.toggleFollow()
+	case actScrollOutputUp:
+		m.scrollOutput(outputPageStep)
+		return m, nil
+	case actScrollOutputDown:
+		m.scrollOutput(-outputPageStep)
+		return m, nil
+	case actObserve:
+		if idx := m.selectedSessionIdx(); idx >= 0 {
+			return m, m.observeSessionCmd(m.sessions[idx].Name)
+		}
+	case actOpenEditor:
+		return m.openEditor()
+	case actToggleGrouped:
+		m.groupMode = !m.groupMode
+		m.cursor = 0
+		// Persist view mode to config.
+		if m.groupMode {
+			m.config.ViewMode = "grouped"
+		} else {
+			m.config.ViewMode = "flat"
+		}
+		if !m.readOnly {
+			_ = SaveConfig(m.config, ConfigPath())
+		}
+		return m, nil
+	case actNew:
+		repoRoot := "."
+		if m.worktrees != nil {
+			repoRoot = m.worktrees.RepoRoot()
+		}
+		m.wizard = NewWizardModel(m.registry, repoRoot, m.worktrees, m.client, m.config.DefaultProject, m.config.DirectoryHistory, m.config)
+		m.wizard.SetBranchIssues(m.pending)
+		m.activeView = ViewWizard
+		return m, nil
+	case actQuickLaunch:
+		return m.quickLaunch()
+	case actMark:
+		// Mark/unmark the selected session for a bulk d/r.
+		m.toggleMark()
+		return m, nil
+	case actClearMarks:
+		m.marked = nil
+		return m, nil
+	case actDelete:
+		if len(m.marked) > 0 {
+			m.confirmBulk = bulkDelete
+			return m, nil
+		}
+		// In grouped mode, only allow delete when cursor is on a session, not a header.
+		if m.groupMode {
+			if idx, _ := m.groupedCursorToSession(); idx >= 0 {
+				m.confirmDelete = true
+			}
+		} else if m.cursor < len(m.sessions) {
+			m.confirmDelete = true
+		}
+		return m, nil
+	case actSwitchBranch:
+		// Quick branch switch for the selected session.
+		idx := m.selectedSessionIdx()
+		if idx < 0 || idx >= len(m.sessions) || m.store == nil {
+			return m, nil
+		}
+		meta, found := m.storeMetaForRow(m.sessions[idx])
+		if !found {
+			return m, nil
+		}
+		repoRoot := meta.WorkingDir
+		if meta.WorktreePath != "" && m.worktrees != nil {
+			repoRoot = m.worktrees.RepoRoot()
+		}
+		m.wizard = NewQuickSwitchWizard(meta, m.registry, repoRoot, m.worktrees, m.config)
+		m.switchMeta = &meta
+		m.activeView = ViewWizard
+		return m, nil
+	case actEditGroup:
+		// Edit the running group of the selected session — or, when a group
+		// header is selected, the whole group under it (#2846). Add/remove
+		// personas (with per-persona provider) reusing the group's shared
+		// repo+branch.
+		if m.store == nil {
+			return m, nil
+		}
+		anchorRow, ok := m.rowForGroupEdit()
+		if !ok {
+			return m, nil
+		}
+		anchor, found := m.storeMetaForRow(anchorRow)
+		if !found {
+			return m, nil
+		}
+		all, err := m.store.List()
+		if err != nil {
+			return m, nil
+		}
+		group := groupSessionsFor(anchor, all, m.getRepoRoot)
+		repoRoot := anchor.WorkingDir
+		if anchor.WorktreePath != "" && m.worktrees != nil {
+			repoRoot = m.worktrees.RepoRoot()
+		}
+		m.groupEditRunning = group
+		m.wizard = NewGroupEditWizard(group, anchor, m.registry, repoRoot, m.worktrees, m.config)
+		m.activeView = ViewWizard
+		return m, nil
+	case actRestart:
+		if len(m.marked) > 0 {
+			m.confirmBulk = bulkRestart
+			return m, nil
+		}
+		// Respawn exited sessions, start pending ones past the queue,
+		// retry recovery for failed ones, otherwise refresh.
+		idx := m.selectedSessionIdx()
+		if idx >= 0 && idx < len(m.sessions) && m.sessions[idx].Status == "pending" {
+			return m, m.startPendingSession(m.sessions[idx])
+		}
+		if idx >= 0 && idx < len(m.sessions) && paneDown(m.sessions[idx]) {
+			row := m.sessions[idx]
+			if m.healthMonitor != nil {
+				m.healthMonitor.ResetSession(row.Name)
+			}
+			return m, m.respawnSession(row)
+		}
+		if idx >= 0 && idx < len(m.sessions) && m.healthMonitor != nil {
+			if sh := m.healthMonitor.GetHealth(m.sessions[idx].Name); sh != nil && sh.Status == HealthFailed {
+				m.healthMonitor.ResetSession(m.sessions[idx].Name)
+				m.logger.Info("health: manual recovery reset for session %s", m.sessions[idx].Name)
+				return m, nil
+			} else if recoveryBackoff(sh, time.Now()) > 0 {
+				return m.retryRecoveryNow(m.sessions[idx])
+			}
+		}
+		return m, m.refreshSessions
+	case actProjectWorkbench:
+		// Project workbench: compose the selected session's project (its
+		// repo-root group) into one natively interactive tmux view. One
+		// session → attach it directly; none → no-op.
+		_, names := m.selectedProjectSessions()
+		switch len(names) {
+		case 0:
+			return m, nil
+		case 1:
+			return m, m.attachSessionCmd(names[0])
+		default:
+			m.workbenchActive = true
+			return m, m.composeWorkbenchCmd(names, m.workbenchMetas(names), m.workbenchTitles())
+		}
+	case actBroadcast:
+		// Broadcast one instruction to every session in the selected
+		// session's group (works on a group header in grouped view).
+		if bm, ok := m.newGroupBroadcast(); ok {
+			m.broadcast = bm
+			m.activeView = ViewBroadcast
+		}
+		return m, nil
+	case actMoveToGroup:
+		// Move the marked (or selected) sessions into a named group.
+		if targets := m.groupAssignTargets(); len(targets) > 0 {
+			names := make([]string, len(targets))
+			for i, t := range targets {
+				names[i] = t.Name
+			}
+			m.groupAssign = NewGroupAssignModel(names, m.sessionGroups)
+			m.activeView = ViewGroupAssign
+		}
+		return m, nil
+	case actAllWorkbench:
+		// All-projects workbench: one tmux window per project, cycled with
+		// Ctrl-b n/p. Worth composing only with ≥2 sessions total.
+		projects := m.projectGroups()
+		var allNames []string
+		for _, p := range projects {
+			allNames = append(allNames, p.Sessions...)
+		}
+		if len(allNames) < 2 {
+			return m, nil
+		}
+		selLabel, _ := m.selectedProjectSessions()
+		m.workbenchActive = true
+		return m, m.composeProjectWorkbenchCmd(projects, selLabel, m.workbenchMetas(allNames), m.workbenchTitles())
+	case actOpenSplit, actOpenWindow:
+		// Inside tmux: open the selected session in a split pane or a new
+		// window of the user's client, next to the TUI.
+		idx := m.selectedSessionIdx()
+		if idx < 0 || idx >= len(m.sessions) {
+			return m, nil
+		}
+		if !InsideTmux() || m.tmux.IsRemote() {
+			m.err = fmt.Errorf("split/window attach needs vibeflow running inside a local tmux client")
+			return m, tea.Tick(10*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+		}
+		mode := AttachSplit
+		if action == actOpenWindow {
+			mode = AttachWindow
+		}
+		return m, m.openInClientCmd(m.sessions[idx].Name, mode)
+	case actOutput:
+		// Full-screen scrollback viewer for the selected session.
+		idx := m.selectedSessionIdx()
+		if idx < 0 || idx >= len(m.sessions) {
+			return m, nil
+		}
+		name := m.sessions[idx].Name
+		tmux := m.tmux
+		m.pager = NewPagerModel(name, func() (string, error) {
+			return tmux.CaptureScrollback(name)
+		}, m.width, m.height)
+		m.activeView = ViewPager
+		return m, m.pager.Init()
+	case actWorktrees:
+		var cmd tea.Cmd
+		m.worktreeList, cmd = m.openWorktreeList()
+		m.activeView = ViewWorktrees
+		return m, cmd
+	case actHistory:
+		hist := m.store.History()
+		if hist == nil {
+			hist = NewSessionHistory()
+		}
+		entries, err := hist.List()
+		m.history = NewHistoryModel(entries, err, m.width, m.height, time.Now())
+		m.activeView = ViewHistory
+		return m, nil
+	case actPendingWork:
+		return m.openPendingWork()
+	case actSearch:
+		return m.openSearch()
+	case actEditNote:
+		return m.editNote()
+	case actSummary:
+		if idx := m.selectedSessionIdx(); idx >= 0 {
+			return m.openSummary(m.sessions[idx])
+		}
+		return m, nil
+	case actArchive:
+		idx := m.selectedSessionIdx()
+		if idx < 0 {
+			return m, nil
+		}
+		row := m.sessions[idx]
+		// On a failed session the key acknowledges the failure instead.
+		if m.healthMonitor != nil {
+			if sh := m.healthMonitor.GetHealth(row.Name); sh != nil && sh.Status == HealthFailed {
+				m.healthMonitor.Acknowledge(row.Name)
+				return m, nil
+			}
+		}
+		if row.Status != "done" && row.Status != "review" {
+			m.err = fmt.Errorf("%s is not done; %s deletes it instead", row.Name, m.keys.label(actDelete))
+			return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+		}
+		return m, m.archiveSession(row)
+	case actSnoozeRecovery:
+		return m.toggleRecoverySnooze()
+	case actRollingRestart:
+		return m.toggleRollingRestart()
+	case actLogin:
+		return m.startLogin()
+	case actUndoKill:
+		// Undo a kill: pick a recently killed session to relaunch.
+		if m.store != nil {
+			entries, err := m.store.TrashList(m.config.TrashRetention())
+			m.trash = NewTrashModel(entries, err, time.Now())
+			m.activeView = ViewTrash
+		}
+		return m, nil
+	case actHelp:
+		m.activeView = ViewHelp
+		return m, nil
+	case actDetach:
+		// Detach: quit TUI while sessions continue running.
+		if len(m.sessions) > 0 {
+			m.confirmDetach = true
+		} else {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
 // handleMouse routes mouse events for the main session list: the wheel moves
-// the selection and a left click resolves to the row under the pointer. Mouse
-// input is ignored outside the session list (sub-views, confirmation dialogs).
+// the selection (or, over the detail panel, scrolls the output), a left click
+// resolves to the row under the pointer, and a click on a help-bar hint runs
+// its action. Mouse input is ignored outside the session list (sub-views,
+// confirmation dialogs).
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
-	if m.activeView != ViewSessions || m.confirmDelete || m.confirmQuit || m.confirmDetach {
+	if m.activeView != ViewSessions || m.confirmDelete || m.confirmQuit || m.confirmDetach || m.confirmBulk != bulkNone {
 		return m, nil
 	}
 	switch msg := msg.(type) {
 	case tea.MouseWheelMsg:
+		// Over the detail panel the wheel scrolls the output instead.
+		if m.hitmap != nil && m.hitmap.leftWidth > 0 && msg.X >= m.hitmap.leftWidth {
+			switch msg.Button {
+			case tea.MouseWheelUp:
+				m.scrollOutput(outputScrollStep)
+			case tea.MouseWheelDown:
+				m.scrollOutput(-outputScrollStep)
+			}
+			return m, nil
+		}
 		switch msg.Button {
 		case tea.MouseWheelUp:
 			if m.cursor > 0 {
@@ -1817,12 +3204,25 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		}
 	case tea.MouseClickMsg:
 		if msg.Button == tea.MouseLeft {
+			if m.hitmap != nil && len(m.hitmap.hints) > 0 && msg.Y == m.hitmap.helpY {
+				return m.handleHintClick(msg.X)
+			}
 			return m.handleListClick(msg.X, msg.Y)
 		}
 	}
 	return m, nil
 }
 
+// handleHintClick runs the action of the help-bar hint under column x.
+func (m Model) handleHintClick(x int) (tea.Model, tea.Cmd) {
+	for _, h := range m.hitmap.hints {
+		if x >= h.x0 && x < h.x1 {
+			return m.runAction(h.action)
+		}
+	}
+	return m, nil
+}
+
 // handleListClick resolves a left click to a session-list row via the hitmap
 // populated during the last render. The first click on a row moves the
 // selection there; clicking the already-selected session attaches to it, and
@@ -1843,7 +3243,7 @@ func (m Model) handleListClick(x, y int) (tea.Model, tea.Cmd) {
 		if m.groupMode {
 			sessionIdx, groupRoot := m.groupedCursorToSession()
 			if sessionIdx == -1 && groupRoot != "" {
-				m.collapsedGroups[groupRoot] = !m.collapsedGroups[groupRoot]
+				m.toggleGroupCollapsed(groupRoot)
 				return m, nil
 			}
 			if alreadySelected && sessionIdx >= 0 && sessionIdx < len(m.sessions) {
@@ -1864,12 +3264,20 @@ func (m Model) handleListClick(x, y int) (tea.Model, tea.Cmd) {
 // these are View fields rather than program options. MouseModeCellMotion
 // enables mouse reporting so the main list responds to clicks and the scroll
 // wheel; plain drag-to-select falls back to Shift/Option-drag in most
-// terminals (the k9s/lazygit convention).
+// terminals (the k9s/lazygit convention). Plain mode renders inline instead,
+// with colors stripped from every screen and no mouse or title escapes.
 func (m Model) View() tea.View {
+	if m.plain {
+		return tea.NewView(stripANSI(m.viewContent()))
+	}
 	v := tea.NewView(m.viewContent())
 	v.AltScreen = true
+	v.WindowTitle = m.windowTitle()
 	v.ReportFocus = true
 	v.MouseMode = tea.MouseModeCellMotion
+	if m.flashing {
+		v.BackgroundColor = warningColor
+	}
 	return v
 }
 
@@ -1891,6 +3299,26 @@ func (m Model) viewContent() string {
 		return m.renderHelpPopup()
 	case ViewRestart:
 		return m.restartSelect.View()
+	case ViewPager:
+		return m.pager.View()
+	case ViewWorktreeProgress:
+		return m.worktreeProgress.View()
+	case ViewBroadcast:
+		return m.broadcast.View()
+	case ViewGroupAssign:
+		return m.groupAssign.View()
+	case ViewTrash:
+		return m.trash.View()
+	case ViewSummary:
+		return m.summary.View()
+	case ViewHistory:
+		return m.history.View()
+	case ViewPendingWork:
+		return m.pendingView.View()
+	case ViewSearch:
+		return m.search.View()
+	case ViewNoteEdit:
+		return m.noteEdit.View()
 	}
 
 	width := m.width
@@ -1909,6 +3337,11 @@ func (m Model) viewContent() string {
 	// off-screen.
 	bannerStyle := lipgloss.NewStyle().Foreground(asciiBanner).Bold(true)
 	title := bannerStyle.Render(strings.TrimPrefix(bannerText, "\n")) + "\n" + copyrightStyle.Render("  "+copyrightText)
+	// The pending-work badge shares the copyright line, and only when it
+	// fits: a wrapped line would push the help bar off-screen.
+	if badge := m.pendingWorkBadge(); badge != "" && lipgloss.Width("  "+copyrightText+"    "+badge) <= width {
+		title += "    " + badge
+	}
 
 	// Error/warning line (optional).
 	var errLine string
@@ -1921,15 +3354,30 @@ func (m Model) viewContent() string {
 		hintStyle := lipgloss.NewStyle().Foreground(dimColor)
 		errLine = errStyle.Render("Error: "+errMsg) + "\n" +
 			hintStyle.Render("  See "+RootDir()+"/vibeflow-cli.log for details")
+	} else if m.notice != "" {
+		errLine = lipgloss.NewStyle().Foreground(oceanSuccess).Render(m.notice)
 	} else if m.serverWarning != "" {
 		warnBannerStyle := lipgloss.NewStyle().Foreground(warningColor)
 		errLine = warnBannerStyle.Render("⚠ " + m.serverWarning + " — local sessions still available")
+	} else if banner := m.authBanner(); banner != "" {
+		errLine = lipgloss.NewStyle().Foreground(warningColor).Render(banner)
+	} else if banner := m.readOnlyBanner(); banner != "" {
+		errLine = lipgloss.NewStyle().Foreground(warningColor).Render(banner)
 	}
 
 	// Help bar — context-sensitive based on confirmation state.
 	var helpBar string
+	var hints []hintSpan
 	warnStyle := lipgloss.NewStyle().Foreground(warningColor)
 	switch {
+	case len(m.collisions) > 0:
+		helpBar = warnStyle.Render(m.collisionPrompt())
+	case m.confirmBulk != bulkNone:
+		verb := "Delete"
+		if m.confirmBulk == bulkRestart {
+			verb = "Restart"
+		}
+		helpBar = warnStyle.Render(fmt.Sprintf("%s %d marked session(s)? (y/n)", verb, len(m.markedRows())))
 	case m.confirmDelete:
 		delName := ""
 		if m.groupMode {
@@ -1940,7 +3388,11 @@ func (m Model) viewContent() string {
 			delName = m.sessions[m.cursor].Name
 		}
 		if delName != "" {
-			helpBar = warnStyle.Render(fmt.Sprintf("Delete '%s'? (y/n)", delName))
+			prompt := fmt.Sprintf("Delete '%s'? (y/n)", delName)
+			if key := m.keys.label(actUndoKill); key != "" && m.config.TrashRetention() > 0 {
+				prompt += "  " + key + " undoes it later"
+			}
+			helpBar = warnStyle.Render(prompt)
 		}
 	case m.confirmQuit:
 		helpBar = warnStyle.Render(fmt.Sprintf("%d session(s) still running (will continue in background). Quit? (y/n)", len(m.sessions)))
@@ -1953,12 +3405,28 @@ func (m Model) viewContent() string {
 				enterHint = "expand/collapse"
 			}
 		}
-		keys := fmt.Sprintf("n: new  enter: %s  m: project wb  M: all wb  d: delete  b: switch  e: edit grp  D: detach  g: group  w: worktrees  ?: help  q: quit", enterHint)
-		socket := m.config.TmuxSocket
-		if socket == "" {
-			socket = "vibeflow"
+		if n := len(m.marked); n > 0 {
+			prefix := fmt.Sprintf("%d marked  ", n)
+			var keys string
+			keys, hints = m.keys.hintSpans(lipgloss.Width(prefix),
+				keyHint{actMark, "mark"}, keyHint{actDelete, "delete marked"},
+				keyHint{actRestart, "restart marked"}, keyHint{actClearMarks, "clear marks"})
+			keys = prefix + keys
+			helpBar = warnStyle.Render(keys)
+			break
+		}
+		var keys string
+		keys, hints = m.keys.hintSpans(0,
+			keyHint{actNew, "new"}, keyHint{actQuickLaunch, "quick"}, keyHint{actAttach, enterHint},
+			keyHint{actOutput, "output"}, keyHint{actBroadcast, "broadcast"}, keyHint{actProjectWorkbench, "project wb"},
+			keyHint{actAllWorkbench, "all wb"}, keyHint{actDelete, "delete"}, keyHint{actSwitchBranch, "switch"},
+			keyHint{actEditGroup, "edit grp"}, keyHint{actMoveToGroup, "to group"}, keyHint{actUndoKill, "undo kill"},
+			keyHint{actDetach, "detach"}, keyHint{actToggleGrouped, "group"}, keyHint{actWorktrees, "worktrees"},
+			keyHint{actHistory, "history"}, keyHint{actHelp, "help"}, keyHint{actQuit, "quit"})
+		tmuxInfo := helpStyle.Render("tmux -L " + m.tmuxSocketLabel())
+		if server := m.serverIndicator(time.Now()); server != "" {
+			tmuxInfo = server + helpStyle.Render("  ") + tmuxInfo
 		}
-		tmuxInfo := helpStyle.Render("tmux -L " + socket)
 		keysRendered := helpStyle.Render(keys)
 		pad := width - lipgloss.Width(keysRendered) - lipgloss.Width(tmuxInfo)
 		if pad < 2 {
@@ -1966,6 +3434,9 @@ func (m Model) viewContent() string {
 		}
 		helpBar = keysRendered + strings.Repeat(" ", pad) + tmuxInfo
 	}
+	if m.plain {
+		return m.plainView(errLine, helpBar)
+	}
 
 	// Column widths (in lipgloss v1, Width includes border + padding).
 	leftWidth := width * 35 / 100
@@ -2042,6 +3513,7 @@ func (m Model) viewContent() string {
 		parts = append(parts, errLine)
 	}
 	parts = append(parts, columns, helpBar)
+	m.hitmap.setHelpBar(lipgloss.Height(title)+errHeight+lipgloss.Height(columns), hints)
 
 	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
@@ -2193,7 +3665,7 @@ func (m Model) windowRows(rows []listRow, avail int) string {
 // or project is set. It MUST stay in sync with renderSessionRow's subtitle
 // condition so the click hitmap matches what is drawn.
 func sessionRowHeight(s SessionRow) int {
-	if s.Branch != "" || s.Persona != "" || s.Project != "" {
+	if s.Branch != "" || s.Persona != "" || s.Project != "" || s.Note != "" {
 		return 2
 	}
 	return 1
@@ -2218,6 +3690,9 @@ func (m Model) buildGroupedRows(width int) []listRow {
 		}
 		// Shorten long paths.
 		displayRoot := root
+		if name, ok := namedGroup(root); ok {
+			displayRoot = "◆ " + name
+		}
 		if len(displayRoot) > width-12 {
 			displayRoot = "..." + displayRoot[len(displayRoot)-(width-15):]
 		}
@@ -2254,15 +3729,21 @@ func (m Model) renderSessionRow(b *strings.Builder, s SessionRow, pos, cursor, w
 	indicator := "○"
 	indStyle := statusIdle
 	switch s.Status {
-	case "running", "attached":
+	case "running", "attached", "working":
 		indicator = "●"
 		indStyle = statusRunning
-	case "waiting":
+	case "waiting", "review":
 		indicator = "●"
 		indStyle = statusWaiting
+	case "done":
+		indicator = iconSuccess
+		indStyle = statusRunning
 	case "exited":
 		indicator = "●"
 		indStyle = statusError
+	case "paused", "pending":
+		indicator = "◌"
+		indStyle = statusIdle
 	case "error":
 		indicator = "●"
 		indStyle = statusError
@@ -2282,16 +3763,27 @@ func (m Model) renderSessionRow(b *strings.Builder, s SessionRow, pos, cursor, w
 		recoveredBadge = lipgloss.NewStyle().Foreground(warningColor).Render(" (recovered)")
 	}
 
-	healthBadge := ""
+	healthBadge, countdown := "", ""
 	if m.healthMonitor != nil {
 		if sh := m.healthMonitor.GetHealth(s.Name); sh != nil {
 			switch sh.Status {
 			case HealthErrorDetected:
 				healthBadge = lipgloss.NewStyle().Foreground(warningColor).Render(" [err]")
 			case HealthRecovering:
-				healthBadge = lipgloss.NewStyle().Foreground(warningColor).Render(fmt.Sprintf(" [recovering %d/%d]", sh.RecoveryCount, m.healthMonitor.config.MaxRetries))
+				label := fmt.Sprintf("recovering %d/%d", sh.RecoveryCount, m.healthMonitor.config.MaxRetries)
+				if left := recoveryBackoff(sh, time.Now()); left > 0 {
+					countdown = ", retry in " + formatCountdown(left)
+				}
+				healthBadge = lipgloss.NewStyle().Foreground(warningColor).Render(" [" + label + countdown + "]")
 			case HealthFailed:
-				healthBadge = lipgloss.NewStyle().Foreground(errorColor).Render(" [FAILED]")
+				if sh.Acknowledged {
+					healthBadge = lipgloss.NewStyle().Foreground(dimColor).Render(" [failed]")
+				} else {
+					healthBadge = lipgloss.NewStyle().Foreground(errorColor).Render(" [FAILED]")
+				}
+			}
+			if sh.Snoozed(time.Now()) && sh.Status != HealthFailed {
+				healthBadge += lipgloss.NewStyle().Foreground(dimColor).Render(" [snoozed]")
 			}
 		}
 	}
@@ -2301,13 +3793,21 @@ func (m Model) renderSessionRow(b *strings.Builder, s SessionRow, pos, cursor, w
 		nameMax -= 12
 	}
 	if healthBadge != "" {
-		nameMax -= 16
+		nameMax -= 16 + len(countdown)
 	}
 	if nameMax < 8 {
 		nameMax = 8
 	}
+	markBadge := ""
+	if m.marked[s.Name] {
+		markBadge = lipgloss.NewStyle().Foreground(accentColor).Render(iconSuccess) + " "
+		nameMax -= 2
+		if nameMax < 8 {
+			nameMax = 8
+		}
+	}
 	name := truncate(s.Name, nameMax)
-	line := fmt.Sprintf("%s %s%s%s%s", indStyle.Render(indicator), provDot, name, recoveredBadge, healthBadge)
+	line := fmt.Sprintf("%s %s%s%s%s%s", indStyle.Render(indicator), markBadge, provDot, name, recoveredBadge, healthBadge)
 
 	if pos == cursor {
 		b.WriteString(selectedStyle.Width(width).Render(iconActive + " " + indent + line))
@@ -2332,6 +3832,9 @@ func (m Model) renderSessionRow(b *strings.Builder, s SessionRow, pos, cursor, w
 	if s.Project != "" {
 		parts = append(parts, s.Project)
 	}
+	if s.Note != "" {
+		parts = append(parts, "✎ "+truncate(s.Note, 40))
+	}
 	if len(parts) > 0 {
 		subtitle := strings.Join(parts, " · ")
 		subtitleStyle := lipgloss.NewStyle().Foreground(dimColor)
@@ -2345,6 +3848,9 @@ func (m Model) renderSessionRow(b *strings.Builder, s SessionRow, pos, cursor, w
 	}
 }
 
+// detailCheckpoints is how many recovery checkpoints the detail panel lists.
+const detailCheckpoints = 3
+
 // renderDetailPanel renders the right column with metadata for the selected session.
 func (m Model) renderDetailPanel(width, height int) string {
 	var b strings.Builder
@@ -2376,6 +3882,9 @@ func (m Model) renderDetailPanel(width, height int) string {
 	// Status (uses styled render).
 	b.WriteString(labelStyle.Render("Status"))
 	b.WriteString(renderStatus(s.Status))
+	if s.TmuxAttached && s.Status != "attached" {
+		b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render(" (attached)"))
+	}
 	b.WriteString("\n")
 
 	// Provider (uses styled render with color dot).
@@ -2385,6 +3894,14 @@ func (m Model) renderDetailPanel(width, height int) string {
 		b.WriteString("\n")
 	}
 
+	if version, outdated := m.sessionVersion(s); outdated {
+		b.WriteString(labelStyle.Render("Version"))
+		b.WriteString(lipgloss.NewStyle().Foreground(warningColor).Render(truncate(version, width-14)))
+		b.WriteString("\n")
+	} else if version != "" {
+		row("Version", version)
+	}
+
 	// Project.
 	if s.Project != "" {
 		row("Project", s.Project)
@@ -2395,6 +3912,33 @@ func (m Model) renderDetailPanel(width, height int) string {
 		row("Branch", renderBranch(s.Branch, s.WorktreePath))
 	}
 
+	// Uncommitted changes, ahead/behind and the latest commits.
+	if g, ok := m.gitSummaries[s.Name]; ok {
+		valMax := width - 14
+		if valMax < 10 {
+			valMax = 10
+		}
+		row("Git", truncate(g.describe(), valMax))
+		for i, c := range g.Commits {
+			label := ""
+			if i == 0 {
+				label = "Commits"
+			}
+			row(label, truncate(c, valMax))
+		}
+		for i, c := range g.Checkpoints {
+			if i == detailCheckpoints {
+				row("", fmt.Sprintf("… %d older", len(g.Checkpoints)-i))
+				break
+			}
+			label := ""
+			if i == 0 {
+				label = "Checkpoints"
+			}
+			row(label, truncate(c.Created.Format("Jan 2 15:04")+" "+c.Commit+" "+c.Reason, valMax))
+		}
+	}
+
 	// Current work.
 	if s.CurrentWork != "" {
 		valMax := width - 14
@@ -2404,6 +3948,44 @@ func (m Model) renderDetailPanel(width, height int) string {
 		row("Current Work", truncate(s.CurrentWork, valMax))
 	}
 
+	// Phase and last message reported by the VibeFlow server.
+	if st, ok := m.serverStatus[s.Name]; ok {
+		if st.Phase != "" {
+			row("Phase", st.Phase)
+		}
+		if st.LastMessage != "" {
+			valMax := width - 14
+			if valMax < 10 {
+				valMax = 10
+			}
+			msg := strings.Join(strings.Fields(st.LastMessage), " ")
+			if !st.LastMessageAt.IsZero() {
+				msg = time.Since(st.LastMessageAt).Truncate(time.Second).String() + " ago: " + msg
+			}
+			row("Last Message", truncate(msg, valMax))
+		}
+	}
+
+	// The user's note.
+	if s.Note != "" {
+		valMax := width - 14
+		if valMax < 10 {
+			valMax = 10
+		}
+		row("Note", truncate(s.Note, valMax))
+	} else if key := m.keys.label(actEditNote); key != "" && m.store != nil && !m.readOnly {
+		row("Note", lipgloss.NewStyle().Foreground(dimColor).Render("'"+key+"' to add one"))
+	}
+
+	// Pull request opened for the session's branch.
+	if s.PRURL != "" {
+		valMax := width - 14
+		if valMax < 10 {
+			valMax = 10
+		}
+		row("PR", truncate(s.PRURL, valMax))
+	}
+
 	// Last heartbeat.
 	if !s.LastHeartbeat.IsZero() {
 		row("Heartbeat", time.Since(s.LastHeartbeat).Truncate(time.Second).String()+" ago")
@@ -2418,11 +4000,51 @@ func (m Model) renderDetailPanel(width, height int) string {
 		row("Worktree", truncate(s.WorktreePath, valMax))
 	}
 
+	// What the agent's pane shows it doing.
+	ps := m.paneStatus[s.Name]
+	if ps.Tool != "" {
+		valMax := width - 14
+		if valMax < 10 {
+			valMax = 10
+		}
+		row("Tool", truncate(ps.Tool, valMax))
+		if ps.File != "" {
+			row("File", truncate(ps.File, valMax))
+		}
+	}
+
+	// Token usage and cost, once the agent has printed a summary; until
+	// then its live token counter.
+	if u, ok := m.usage[s.Name]; ok {
+		row("Usage", formatUsage(u))
+	} else if ps.Tokens > 0 {
+		row("Tokens", formatTokens(ps.Tokens))
+	}
+
 	// Attached indicator.
 	if s.TmuxAttached {
 		row("Attached", "yes")
 	}
 
+	// Exit status of an exited agent.
+	if s.Status == "exited" || (s.PaneDead && s.Status == "done") {
+		row("Exit", exitStatusLabel(s.ExitStatus))
+	}
+	if t := m.timeouts[s.Name]; t != "" {
+		row("Timeout", t)
+	}
+	if snooze := m.recoverySnooze(s.Name); snooze != "" {
+		row("Recovery", snooze)
+	}
+	if cooldown := m.providerCooldown(s.Provider); cooldown != "" {
+		row("Rate limit", cooldown)
+	}
+	if command := m.permissions.Awaiting(s.Name); command != "" {
+		row("Permission", strings.ReplaceAll(command, "\n", "; ")+" (not in permissions.allow, waiting for you)")
+	} else if ps.Permission != "" {
+		row("Permission", truncate(ps.Permission, max(width-14, 10)))
+	}
+
 	// Gateway env wiring (gateway mode only). Re-derived from current config
 	// rather than persisted — BuildLLMGatewayEnv is deterministic per provider.
 	// Secret-bearing values are masked with the same allowlist used for
@@ -2458,13 +4080,30 @@ func (m Model) renderDetailPanel(width, height int) string {
 				b.WriteString(lipgloss.NewStyle().Foreground(warningColor).Render(
 					fmt.Sprintf("⚠ Auto-recovery in progress (attempt %d/%d)", sh.RecoveryCount, m.healthMonitor.config.MaxRetries)))
 				b.WriteString("\n")
+				if countdown := m.recoveryCountdown(sh, time.Now()); countdown != "" {
+					if key := m.keys.label(actRestart); key != "" {
+						countdown += ", '" + key + "' to retry now"
+					}
+					b.WriteString(lipgloss.NewStyle().Foreground(warningColor).Render("  " + countdown))
+					b.WriteString("\n")
+				}
 				if sh.MatchedPattern != nil {
 					b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render("  " + sh.MatchedPattern.Description))
 					b.WriteString("\n")
 				}
 			case HealthFailed:
-				b.WriteString(lipgloss.NewStyle().Foreground(errorColor).Render(
-					fmt.Sprintf("✘ Unrecoverable after %d attempts — press 'r' to retry", sh.RecoveryCount)))
+				failedMsg := fmt.Sprintf("✘ Unrecoverable after %d attempts — press 'r' to retry", sh.RecoveryCount)
+				if sh.MatchedPattern != nil && sh.MatchedPattern.Action == ActionNotify {
+					failedMsg = "✘ Needs attention — press 'r' to retry"
+				}
+				failedStyle := lipgloss.NewStyle().Foreground(errorColor)
+				if sh.Acknowledged {
+					failedMsg += " (acknowledged)"
+					failedStyle = lipgloss.NewStyle().Foreground(dimColor)
+				} else if key := m.keys.label(actArchive); key != "" {
+					failedMsg += ", '" + key + "' to acknowledge"
+				}
+				b.WriteString(failedStyle.Render(failedMsg))
 				b.WriteString("\n")
 				if sh.MatchedPattern != nil {
 					b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render("  " + sh.MatchedPattern.Description))
@@ -2479,23 +4118,52 @@ func (m Model) renderDetailPanel(width, height int) string {
 	sepStyle := lipgloss.NewStyle().Foreground(dimColor)
 	b.WriteString(sepStyle.Render(strings.Repeat("─", width)))
 	b.WriteString("\n")
-	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(dimColor).Render("Output"))
+	outputTitle := "Output"
+	if s.Status == "exited" {
+		outputTitle = "Last output  (" + m.keys.label(actRestart) + ": respawn)"
+	} else if s.Status == "paused" {
+		outputTitle = "Last output  (paused by timeout; " + m.keys.label(actRestart) + ": relaunch)"
+	} else if s.Status == "pending" {
+		outputTitle = "Output  (queued for a free slot; " + m.keys.label(actRestart) + ": start now)"
+	} else if s.Status == "done" {
+		outputTitle = "Output  (done; " + m.keys.label(actSummary) + ": summary, " + m.keys.label(actArchive) + ": archive)"
+	}
+	outputTitle += m.outputViewTitle(s.Name)
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(dimColor).Render(outputTitle))
 	b.WriteString("\n")
 
-	if m.captureName == s.Name && m.captureOutput != "" {
-		// Limit output lines to fit remaining height.
-		// metadata uses ~10 lines, separator+header 3, so remaining = height - 13.
-		maxLines := height - 13
+	if output, changed := m.shownOutput(s.Name); output != "" {
+		scroll := m.outputViews[s.Name].scroll
+		// Limit output lines to the height left below the metadata rows,
+		// which vary by session (git, server status, health, ...).
+		maxLines := height - strings.Count(b.String(), "\n")
 		if maxLines < 3 {
 			maxLines = 3
 		}
-		lines := strings.Split(m.captureOutput, "\n")
-		if len(lines) > maxLines {
-			lines = lines[len(lines)-maxLines:]
+		lines := strings.Split(output, "\n")
+		if len(changed) != len(lines) {
+			changed = nil
+		}
+		end := len(lines) - min(scroll, len(lines)-1)
+		start := max(end-maxLines, 0)
+		lines = lines[start:end]
+		if changed != nil {
+			changed = changed[start:end]
 		}
+		// With a previous capture to compare against, new lines stand out
+		// and unchanged ones fade, so a stalled or looping agent is obvious.
 		outputStyle := lipgloss.NewStyle().Foreground(oceanForeground)
-		for _, line := range lines {
-			b.WriteString(outputStyle.Render(truncate(line, width)))
+		newStyle := lipgloss.NewStyle().Bold(true).Foreground(oceanPrimary)
+		oldStyle := lipgloss.NewStyle().Foreground(dimColor)
+		for i, line := range lines {
+			style := outputStyle
+			if changed != nil {
+				style = oldStyle
+				if changed[i] {
+					style = newStyle
+				}
+			}
+			b.WriteString(style.Render(truncate(line, width)))
 			b.WriteString("\n")
 		}
 	} else {
@@ -2523,30 +4191,63 @@ func (m Model) renderHelpPopup() string {
 	dimStyle := lipgloss.NewStyle().Foreground(dimColor)
 
 	var b strings.Builder
+	line := func(desc string, actions ...keyAction) {
+		var keys []string
+		for _, a := range actions {
+			if k := m.keys.label(a); k != "" {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) > 0 {
+			b.WriteString(keyStyle.Render("  "+strings.Join(keys, " / ")) + descStyle.Render(desc) + "\n")
+		}
+	}
 	b.WriteString(catStyle.Render("Navigation"))
 	b.WriteString("\n")
-	b.WriteString(keyStyle.Render("  j / k") + descStyle.Render("Move down / up") + "\n")
-	b.WriteString(keyStyle.Render("  enter") + descStyle.Render("Attach to session") + "\n")
-	b.WriteString(keyStyle.Render("  m") + descStyle.Render("Workbench: this project's sessions, native view") + "\n")
-	b.WriteString(keyStyle.Render("  M") + descStyle.Render("Workbench: all projects (Ctrl-b n/p to switch)") + "\n")
-	b.WriteString(keyStyle.Render("  g") + descStyle.Render("Toggle flat / grouped view") + "\n")
+	line("Move down / up", actDown, actUp)
+	line("Attach to session", actAttach)
+	line("Watch session read-only (keys aren't sent to it)", actObserve)
+	line("Open session's directory in your editor", actOpenEditor)
+	line("View full output (scroll, search, follow)", actOutput)
+	line("Search the output of all sessions", actSearch)
+	line("Copy session details and attach command", actCopyInfo)
+	line("Copy session output", actCopyOutput)
+	line("Scroll the output back / forward (stays put until followed again)", actScrollOutputUp, actScrollOutputDown)
+	line("Pin the output where it is / follow new output", actFollowOutput)
+	line("Workbench: this project's sessions, native view", actProjectWorkbench)
+	line("Workbench: all projects (Ctrl-b n/p to switch)", actAllWorkbench)
+	line("Toggle flat / grouped view", actToggleGrouped)
 	b.WriteString("\n")
 
 	b.WriteString(catStyle.Render("Session Management"))
 	b.WriteString("\n")
-	b.WriteString(keyStyle.Render("  n") + descStyle.Render("New session (wizard)") + "\n")
-	b.WriteString(keyStyle.Render("  d") + descStyle.Render("Delete session") + "\n")
-	b.WriteString(keyStyle.Render("  b") + descStyle.Render("Switch branch") + "\n")
-	b.WriteString(keyStyle.Render("  e") + descStyle.Render("Edit group (add/remove personas)") + "\n")
-	b.WriteString(keyStyle.Render("  D") + descStyle.Render("Detach (quit, sessions persist)") + "\n")
-	b.WriteString(keyStyle.Render("  w") + descStyle.Render("Manage worktrees") + "\n")
-	b.WriteString(keyStyle.Render("  r") + descStyle.Render("Retry recovery / refresh") + "\n")
+	line("New session (wizard)", actNew)
+	line("Quick launch from config defaults", actQuickLaunch)
+	line("Inside tmux: open in a split pane / new window", actOpenSplit, actOpenWindow)
+	line("Delete session", actDelete)
+	line("Restore a recently killed session", actUndoKill)
+	line("Mark session (or whole group); delete / restart / move then act on all marked", actMark)
+	line("Move to a named group", actMoveToGroup)
+	line("Edit the session's note", actEditNote)
+	line("Switch branch", actSwitchBranch)
+	line("Edit group (add/remove personas)", actEditGroup)
+	line("Broadcast a prompt to the group", actBroadcast)
+	line("Detach (quit, sessions persist)", actDetach)
+	line("Manage worktrees", actWorktrees)
+	line("Session history (all sessions ever launched)", actHistory)
+	line("Pending work: stuck and ready items; dispatch one to a session", actPendingWork)
+	line("Respawn exited / start pending / retry recovery / refresh", actRestart)
+	line("Rolling restart of sessions on an outdated provider version (again to cancel)", actRollingRestart)
+	line("Summary: duration, commits, diff stat, final message", actSummary)
+	line("Archive a done session (keeps its worktree); acknowledge a failed one", actArchive)
+	line("Snooze error recovery for the session (again to resume)", actSnoozeRecovery)
 	b.WriteString("\n")
 
 	b.WriteString(catStyle.Render("Application"))
 	b.WriteString("\n")
-	b.WriteString(keyStyle.Render("  ?") + descStyle.Render("Show this help") + "\n")
-	b.WriteString(keyStyle.Render("  q") + descStyle.Render("Quit vibeflow-cli") + "\n")
+	line("Show this help", actHelp)
+	line("Sign in to the VibeFlow server again (auth.method: oauth)", actLogin)
+	line("Quit vibeflow-cli", actQuit)
 	b.WriteString(keyStyle.Render("  ctrl+c") + descStyle.Render("Force quit") + "\n")
 	b.WriteString("\n")
 
@@ -2557,10 +4258,7 @@ func (m Model) renderHelpPopup() string {
 	b.WriteString("\n")
 
 	// Info section.
-	socket := m.config.TmuxSocket
-	if socket == "" {
-		socket = "vibeflow"
-	}
+	socket := m.tmuxSocketLabel()
 	b.WriteString(catStyle.Render("Info"))
 	b.WriteString("\n")
 	b.WriteString(dimStyle.Render(fmt.Sprintf("  tmux socket:  %s", socket)) + "\n")
@@ -2585,11 +4283,21 @@ func (m Model) renderHelpPopup() string {
 
 // Provider color-coded dots — distinct hues drawn from the Ocean palette
 // (theme.go). The provider glyph plus these keep providers distinguishable.
-var providerColors = map[string]lipgloss.Color{
-	"claude": oceanWarning,   // sandy
-	"codex":  oceanAccent,    // seafoam
-	"cursor": oceanPrimary,   // sky
-	"gemini": oceanSecondary, // deep blue
+var providerColors = themeProviderColors(nil)
+
+// themeProviderColors builds providerColors from the active palette, with the
+// theme's per-provider overrides on top.
+func themeProviderColors(overrides map[string]string) map[string]lipgloss.Color {
+	colors := map[string]lipgloss.Color{
+		"claude": oceanWarning,   // sandy
+		"codex":  oceanAccent,    // seafoam
+		"cursor": oceanPrimary,   // sky
+		"gemini": oceanSecondary, // deep blue
+	}
+	for name, hex := range overrides {
+		colors[name] = lipgloss.Color(hex)
+	}
+	return colors
 }
 
 func renderProvider(provider string) string {
@@ -2620,12 +4328,22 @@ func renderStatus(status string) string {
 		return statusRunning.Render("running")
 	case "attached":
 		return statusRunning.Render("attached")
+	case "working":
+		return statusRunning.Render("working")
 	case "idle":
 		return statusIdle.Render("idle")
 	case "waiting":
 		return statusWaiting.Render("waiting")
+	case "review":
+		return statusWaiting.Render("review")
+	case "done":
+		return statusRunning.Render("done")
 	case "exited":
 		return statusError.Render("exited")
+	case "paused":
+		return statusIdle.Render("paused")
+	case "pending":
+		return statusIdle.Render("pending")
 	case "error":
 		return statusError.Render("error")
 	default:
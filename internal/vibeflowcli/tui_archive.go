@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// archiveTickMsg triggers an upload of newly ended sessions.
+type archiveTickMsg time.Time
+
+func archiveTickCmd() tea.Cmd {
+	return tea.Tick(archiveInterval, func(t time.Time) tea.Msg {
+		return archiveTickMsg(t)
+	})
+}
+
+// archiveEnded uploads the summaries of ended sessions. Sessions without a
+// project of their own are archived under the TUI's project.
+func (m Model) archiveEnded() tea.Msg {
+	if m.archiver == nil || m.store == nil {
+		return nil
+	}
+	projectID := func(e HistoryEntry) int64 {
+		if e.ProjectID > 0 {
+			return e.ProjectID
+		}
+		return m.projectID
+	}
+	m.archiver.Archive(m.store.History(), projectID, m.archiveTail, time.Now())
+	return nil
+}
+
+// archiveTail reads the final output of an ended session that has none
+// saved: from its dead pane while the row is still listed, else from its
+// session log.
+func (m Model) archiveTail(e HistoryEntry) string {
+	lines := m.config.Archive.tailLines()
+	if meta, found, _ := m.store.Get(e.Name); found && meta.CreatedAt.Equal(e.CreatedAt) && m.tmux.HasSession(meta.TmuxSession) {
+		if out, err := m.tmux.CapturePaneOutput(meta.TmuxSession, lines); err == nil {
+			return out
+		}
+	}
+	return sessionLogTail(e, lines)
+}
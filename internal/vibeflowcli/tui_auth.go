@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// loginCodeMsg carries the device code for a sign-in started from the TUI.
+type loginCodeMsg struct {
+	dc  *DeviceCode
+	err error
+}
+
+// loginDoneMsg reports the end of a TUI sign-in.
+type loginDoneMsg struct{ err error }
+
+// startLogin starts the OAuth device flow; the code to enter is shown in
+// the banner while the login is polled in the background.
+func (m Model) startLogin() (tea.Model, tea.Cmd) {
+	if m.login != nil {
+		return m, nil
+	}
+	src := m.oauthSource()
+	if src == nil {
+		m.err = fmt.Errorf("sign-in needs auth.method: oauth (run `vibeflow login`)")
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	return m, func() tea.Msg {
+		dc, err := src.oauth.StartDeviceFlow(context.Background())
+		return loginCodeMsg{dc: dc, err: err}
+	}
+}
+
+func (m Model) applyLoginCode(msg loginCodeMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	src := m.oauthSource()
+	m.login = msg.dc
+	return m, func() tea.Msg {
+		return loginDoneMsg{err: src.Login(context.Background(), msg.dc)}
+	}
+}
+
+// applyLoginDone clears the sign-in banner and, on success, refreshes the
+// session list with API data again.
+func (m Model) applyLoginDone(msg loginDoneMsg) (tea.Model, tea.Cmd) {
+	m.login = nil
+	if msg.err != nil {
+		m.err = fmt.Errorf("sign-in: %w", msg.err)
+		return m, nil
+	}
+	if c, ok := m.client.(*Client); ok {
+		c.authExpired.Store(false)
+	}
+	m.logger.Info("signed in to %s", m.config.ServerURL)
+	return m, m.refreshSessions
+}
+
+// authBanner is the warning line for a pending sign-in or credentials the
+// server rejected, empty otherwise.
+func (m Model) authBanner() string {
+	if m.login != nil {
+		return fmt.Sprintf("Sign in: open %s and enter the code %s", m.login.VerificationURI, m.login.UserCode)
+	}
+	if m.client == nil || !m.client.AuthExpired() {
+		return ""
+	}
+	if m.oauthSource() != nil {
+		return "⚠ VibeFlow login expired — press " + m.keys.label(actLogin) + " to sign in again"
+	}
+	return "⚠ VibeFlow server rejected api_token — update it with `vibeflow config`"
+}
+
+// oauthSource returns the OAuth login behind the HTTP API client, nil for a
+// static token or another backend.
+func (m Model) oauthSource() *oauthTokenSource {
+	c, ok := m.client.(*Client)
+	if !ok {
+		return nil
+	}
+	return c.oauthSource()
+}
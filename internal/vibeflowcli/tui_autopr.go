@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// prOpenedMsg carries the result of an auto PR run for one session.
+type prOpenedMsg struct {
+	name    string // session row name
+	session string // store name (SessionMeta.Name)
+	url     string
+	err     error
+}
+
+// startAutoPR opens pull requests for the named sessions whose agents
+// signalled completion. Each session gets one attempt per TUI run: a failure
+// is shown rather than retried on every sample while the marker stays on
+// screen, and `vibeflow pr` is the way to try again.
+func (m Model) startAutoPR(names []string) (Model, tea.Cmd) {
+	if !m.config.AutoPR.Enabled || m.store == nil {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	for _, name := range names {
+		if m.prStarted[name] {
+			continue
+		}
+		meta, ok := m.storeMetaForRow(SessionRow{Name: name})
+		if !ok || meta.PRURL != "" {
+			continue
+		}
+		m.prStarted[name] = true
+		m.logger.Info("session %s signalled completion; opening a pull request", name)
+		cfg := m.config.AutoPR
+		cmds = append(cmds, func() tea.Msg {
+			url, err := OpenPullRequest(meta, cfg)
+			return prOpenedMsg{name: name, session: meta.Name, url: url, err: err}
+		})
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handlePROpened records an opened PR on the session and flags its row as
+// ready for review.
+func (m Model) handlePROpened(msg prOpenedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("auto PR for %s: %v", msg.name, msg.err)
+		m.err = fmt.Errorf("auto PR for %s: %w", msg.name, msg.err)
+		return m, tea.Tick(10*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	m.logger.Info("opened %s for session %s", msg.url, msg.name)
+	if err := m.store.SetPRURL(msg.session, msg.url); err != nil {
+		m.logger.Warn("record PR for %s: %v", msg.name, err)
+	}
+	for i := range m.sessions {
+		if m.sessions[i].Name == msg.name {
+			m.sessions[i].PRURL = msg.url
+		}
+	}
+	markReadyForReview(m.sessions)
+	m.notifyAttention()
+	return m, nil
+}
+
+// markReadyForReview shows rows with an open PR as "review" while their
+// agent sits idle. A working or waiting agent, or an exited one, keeps its
+// own status.
+func markReadyForReview(rows []SessionRow) {
+	for i := range rows {
+		if rows[i].PRURL == "" {
+			continue
+		}
+		switch rows[i].Status {
+		case "exited", "paused", "pending", "working", "waiting":
+		default:
+			rows[i].Status = "review"
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// flashDuration is how long a flash alert recolors the terminal background.
+const flashDuration = 300 * time.Millisecond
+
+// flashEndMsg restores the terminal background after a flash alert.
+type flashEndMsg struct{}
+
+// alertStatus is the status a session's transitions are tracked by: the
+// row status, with a failed recovery or a non-zero exit reported as
+// "error" and a session awaiting review as "done".
+func (m Model) alertStatus(s SessionRow) string {
+	if m.healthMonitor != nil {
+		if sh := m.healthMonitor.GetHealth(s.Name); sh != nil && sh.Status == HealthFailed {
+			return "error"
+		}
+	}
+	switch s.Status {
+	case "review":
+		return "done"
+	case "exited":
+		if s.ExitStatus != "" && s.ExitStatus != "0" {
+			return "error"
+		}
+	}
+	return s.Status
+}
+
+// alertStatusChanges feeds the current session statuses to the status
+// tracker and, while the TUI is unfocused (another tmux pane or window is
+// active), rings the bell and/or flashes for sessions that just started
+// waiting, erred or finished.
+func (m Model) alertStatusChanges() (Model, tea.Cmd) {
+	if m.statusEvents == nil {
+		return m, nil
+	}
+	current := make(map[string]string, len(m.sessions))
+	for _, s := range m.sessions {
+		current[s.Name] = m.alertStatus(s)
+	}
+	events := m.statusEvents.observe(current)
+	if !m.blurred || !m.alert.enabled() {
+		return m, nil
+	}
+	hits := m.alert.matching(events)
+	if len(hits) == 0 {
+		return m, nil
+	}
+	for _, e := range hits {
+		m.logger.Info("alert: session %s %s → %s", e.Session, e.From, e.To)
+	}
+	var cmds []tea.Cmd
+	if m.alert.bell {
+		cmds = append(cmds, tea.Raw("\a"))
+	}
+	if m.alert.flash && !m.flashing {
+		m.flashing = true
+		cmds = append(cmds, tea.Tick(flashDuration, func(time.Time) tea.Msg { return flashEndMsg{} }))
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BranchListModel lists the branches vibeflow created and deletes them
+// together with their worktrees. It is opened from the worktree view.
+type BranchListModel struct {
+	rows   []ManagedBranch
+	err    error
+	cursor int
+	done   bool
+
+	wm    *WorktreeManager
+	store *Store
+
+	// confirm holds the branches awaiting y/n; prune is set when they are
+	// every merged branch rather than the selected one.
+	confirm []ManagedBranch
+	prune   bool
+	running bool
+	result  *branchDeleteMsg
+}
+
+// branchDeleteMsg reports a branch deletion or prune.
+type branchDeleteMsg struct {
+	deleted []string
+	errs    []error
+}
+
+// NewBranchListModel loads the managed branches of wm's repository.
+func NewBranchListModel(wm *WorktreeManager, store *Store) BranchListModel {
+	bl := BranchListModel{wm: wm, store: store}
+	bl.reload()
+	return bl
+}
+
+func (bl *BranchListModel) reload() {
+	bl.rows, bl.err = nil, nil
+	if bl.wm == nil {
+		return
+	}
+	var history []HistoryEntry
+	var live []SessionMeta
+	if bl.store != nil {
+		history, _ = bl.store.History().List()
+		live, _ = bl.store.List()
+	}
+	bl.rows, bl.err = bl.wm.ManagedBranches(history, live)
+	if bl.cursor >= len(bl.rows) {
+		bl.cursor = max(len(bl.rows)-1, 0)
+	}
+}
+
+// Done reports whether the user left the branch list.
+func (bl BranchListModel) Done() bool { return bl.done }
+
+// Update handles input for the branch list.
+func (bl BranchListModel) Update(msg tea.Msg) (BranchListModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if bl.running {
+			return bl, nil
+		}
+		if bl.confirm != nil {
+			switch msg.String() {
+			case "y", "Y":
+				targets := bl.confirm
+				bl.confirm, bl.running = nil, true
+				wm := bl.wm
+				return bl, func() tea.Msg { return deleteBranches(wm, targets) }
+			case "n", "N", "esc", "q":
+				bl.confirm = nil
+			}
+			return bl, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			if bl.cursor > 0 {
+				bl.cursor--
+			}
+		case "down", "j":
+			if bl.cursor < len(bl.rows)-1 {
+				bl.cursor++
+			}
+		case "d":
+			if bl.cursor < len(bl.rows) {
+				row := bl.rows[bl.cursor]
+				if row.Session != "" {
+					bl.result = &branchDeleteMsg{errs: []error{fmt.Errorf("%s is used by session %s; kill it first", row.Name, row.Session)}}
+					return bl, nil
+				}
+				bl.confirm, bl.prune, bl.result = []ManagedBranch{row}, false, nil
+			}
+		case "P":
+			var merged []ManagedBranch
+			for _, row := range bl.rows {
+				if row.Merged && row.Session == "" {
+					merged = append(merged, row)
+				}
+			}
+			if len(merged) == 0 {
+				bl.result = &branchDeleteMsg{errs: []error{fmt.Errorf("no merged branches to prune")}}
+				return bl, nil
+			}
+			bl.confirm, bl.prune, bl.result = merged, true, nil
+		case "esc", "b":
+			bl.done = true
+		}
+	case branchDeleteMsg:
+		bl.running = false
+		bl.result = &msg
+		bl.reload()
+	}
+	return bl, nil
+}
+
+// deleteBranches deletes each branch with its worktree. Worktrees with
+// uncommitted changes are kept, and so are their branches.
+func deleteBranches(wm *WorktreeManager, targets []ManagedBranch) branchDeleteMsg {
+	var msg branchDeleteMsg
+	for _, b := range targets {
+		if err := wm.DeleteBranch(b, false); err != nil {
+			msg.errs = append(msg.errs, err)
+			continue
+		}
+		msg.deleted = append(msg.deleted, b.Name)
+	}
+	return msg
+}
+
+// View renders the branch list.
+func (bl BranchListModel) View() string {
+	var b strings.Builder
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	b.WriteString(title.Render("VibeFlow Branches"))
+	if bl.wm != nil {
+		b.WriteString(dim.Render("  (merged = in " + bl.wm.MergeBaseBranch() + ")"))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case bl.err != nil:
+		b.WriteString(statusError.Render(bl.err.Error()))
+		b.WriteString("\n")
+	case len(bl.rows) == 0:
+		b.WriteString(dim.Render("No vibeflow-created branches."))
+		b.WriteString("\n")
+	default:
+		header := fmt.Sprintf("  %-36s %-8s %-10s %s", "BRANCH", "STATE", "COMMITTED", "WORKTREE / SESSION")
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(dimColor).Render(header))
+		b.WriteString("\n")
+		now := time.Now()
+		for i, row := range bl.rows {
+			cursor := "  "
+			style := lipgloss.NewStyle()
+			if i == bl.cursor {
+				cursor = "> "
+				style = selectedStyle
+			}
+			state := lipgloss.NewStyle().Foreground(warningColor).Render(fmt.Sprintf("%-8s", "unmerged"))
+			if row.Merged {
+				state = lipgloss.NewStyle().Foreground(oceanSuccess).Render(fmt.Sprintf("%-8s", "merged"))
+			}
+			name := row.Name
+			if row.Fallback {
+				name += " *"
+			}
+			age := ""
+			if !row.LastCommit.IsZero() {
+				age = formatSessionDuration(now.Sub(row.LastCommit)) + " ago"
+			}
+			where := truncate(row.Worktree, 40)
+			if row.Session != "" {
+				where = "session " + row.Session
+			}
+			line := fmt.Sprintf("%s%-36s %s %-10s %s", cursor, truncate(name, 36), state, age, where)
+			b.WriteString(style.Render(line))
+			b.WriteString("\n")
+		}
+		b.WriteString(dim.Render("  * fallback name from a worktree whose branch couldn't be used"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case bl.confirm != nil:
+		b.WriteString(bl.confirmView())
+	case bl.running:
+		b.WriteString(dim.Render("deleting..."))
+		b.WriteString("\n")
+	case bl.result != nil:
+		for _, name := range bl.result.deleted {
+			b.WriteString(lipgloss.NewStyle().Foreground(oceanSuccess).Render("  ✓ deleted " + name))
+			b.WriteString("\n")
+		}
+		for _, err := range bl.result.errs {
+			b.WriteString(statusError.Render("  ✗ " + err.Error()))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("d: delete branch + worktree  P: prune merged  j/k: navigate  esc: back to worktrees"))
+	return b.String()
+}
+
+// confirmView asks before deleting, warning about unmerged commits.
+func (bl BranchListModel) confirmView() string {
+	var b strings.Builder
+	warn := lipgloss.NewStyle().Foreground(warningColor)
+	if bl.prune {
+		b.WriteString(fmt.Sprintf("Delete %d merged branches and their worktrees?\n", len(bl.confirm)))
+	} else {
+		row := bl.confirm[0]
+		what := "branch " + row.Name
+		if row.Worktree != "" {
+			what += " and worktree " + row.Worktree
+		}
+		b.WriteString("Delete " + what + "?\n")
+		if !row.Merged {
+			b.WriteString(warn.Render("  Not merged: its commits will be lost."))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(helpStyle.Render("y: delete  n: cancel"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// broadcastResultMsg carries the per-session outcome of a broadcast.
+type broadcastResultMsg struct {
+	results []SendKeysResult
+}
+
+// BroadcastModel prompts for one instruction and sends it to every session
+// of a group (`B` on the session list), then reports which sessions got it.
+type BroadcastModel struct {
+	label   string   // group label shown in the title
+	targets []string // short session names to send to
+	skipped []string // sessions left out because their agent has exited
+	send    func(names []string, text string) []SendKeysResult
+
+	input   string
+	sending bool
+	results []SendKeysResult
+	done    bool
+}
+
+// NewBroadcastModel creates the prompt for a group. send is normally
+// TmuxManager.SendKeysMulti; it is injected so the model can be tested
+// without a tmux server.
+func NewBroadcastModel(label string, targets, skipped []string, send func([]string, string) []SendKeysResult) BroadcastModel {
+	return BroadcastModel{label: label, targets: targets, skipped: skipped, send: send}
+}
+
+// Done reports whether the view should close.
+func (bm BroadcastModel) Done() bool { return bm.done }
+
+// Update handles input for the broadcast prompt.
+func (bm BroadcastModel) Update(msg tea.Msg) (BroadcastModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case broadcastResultMsg:
+		bm.sending = false
+		bm.results = msg.results
+	case tea.PasteMsg:
+		if !bm.sending && bm.results == nil {
+			bm.input += strings.ReplaceAll(msg.Content, "\n", " ")
+		}
+	case tea.KeyPressMsg:
+		if bm.sending {
+			return bm, nil
+		}
+		if bm.results != nil {
+			bm.done = true // any key closes the report
+			return bm, nil
+		}
+		switch msg.String() {
+		case "esc":
+			bm.done = true
+		case "enter":
+			text := strings.TrimSpace(bm.input)
+			if text == "" || len(bm.targets) == 0 {
+				return bm, nil
+			}
+			bm.sending = true
+			send, targets := bm.send, bm.targets
+			return bm, func() tea.Msg {
+				return broadcastResultMsg{results: send(targets, text)}
+			}
+		case "backspace":
+			if r := []rune(bm.input); len(r) > 0 {
+				bm.input = string(r[:len(r)-1])
+			}
+		case "space":
+			bm.input += " "
+		default:
+			bm.input += msg.Text
+		}
+	}
+	return bm, nil
+}
+
+// View renders the prompt, or the per-session report after sending.
+func (bm BroadcastModel) View() string {
+	var b strings.Builder
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	b.WriteString(title.Render(fmt.Sprintf("Broadcast to %s (%d sessions)", bm.label, len(bm.targets))))
+	b.WriteString("\n\n")
+
+	if bm.results != nil {
+		failed := 0
+		for _, r := range bm.results {
+			if r.Err != nil {
+				failed++
+				b.WriteString(statusError.Render(fmt.Sprintf("  ✗ %s: %v", r.Name, r.Err)))
+			} else {
+				b.WriteString(lipgloss.NewStyle().Foreground(oceanSuccess).Render("  ✓ " + r.Name))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Sent to %d of %d sessions.\n\n", len(bm.results)-failed, len(bm.results)))
+		b.WriteString(helpStyle.Render("press any key to close"))
+		return b.String()
+	}
+
+	for _, name := range bm.targets {
+		b.WriteString("  " + name + "\n")
+	}
+	for _, name := range bm.skipped {
+		b.WriteString(dim.Render("  " + name + " (exited, skipped)"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	if bm.sending {
+		b.WriteString(dim.Render("sending..."))
+		return b.String()
+	}
+	b.WriteString("> " + bm.input + "█\n\n")
+	b.WriteString(helpStyle.Render("enter: send to all  esc: cancel"))
+	return b.String()
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func typeText(bm BroadcastModel, s string) BroadcastModel {
+	for _, r := range s {
+		if r == ' ' {
+			bm, _ = bm.Update(tea.KeyPressMsg{Code: tea.KeySpace})
+			continue
+		}
+		bm, _ = bm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return bm
+}
+
+func TestBroadcastModel_SendsToAllTargetsAndReports(t *testing.T) {
+	var gotNames []string
+	var gotText string
+	send := func(names []string, text string) []SendKeysResult {
+		gotNames, gotText = names, text
+		return []SendKeysResult{
+			{Name: "claude-a"},
+			{Name: "codex-b", Err: errors.New("send-keys: session \"vibeflow_codex-b\" does not exist")},
+		}
+	}
+	bm := NewBroadcastModel("repo", []string{"claude-a", "codex-b"}, []string{"gemini-c"}, send)
+	bm = typeText(bm, "pull main")
+	bm, _ = bm.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	bm = typeText(bm, "n")
+
+	view := ansiRe.ReplaceAllString(bm.View(), "")
+	if !strings.Contains(view, "gemini-c (exited, skipped)") || !strings.Contains(view, "> pull main") {
+		t.Errorf("prompt view missing targets or input:\n%s", view)
+	}
+
+	bm, cmd := bm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should dispatch the broadcast")
+	}
+	bm, _ = bm.Update(cmd())
+	if !reflect.DeepEqual(gotNames, []string{"claude-a", "codex-b"}) || gotText != "pull main" {
+		t.Errorf("send(%v, %q)", gotNames, gotText)
+	}
+
+	view = ansiRe.ReplaceAllString(bm.View(), "")
+	for _, want := range []string{"✓ claude-a", "✗ codex-b", "does not exist", "Sent to 1 of 2 sessions"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("report missing %q:\n%s", want, view)
+		}
+	}
+	bm, _ = bm.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if !bm.Done() {
+		t.Error("any key should close the report")
+	}
+}
+
+func TestBroadcastModel_EmptyInputDoesNotSend(t *testing.T) {
+	bm := NewBroadcastModel("repo", []string{"a"}, nil, func([]string, string) []SendKeysResult {
+		t.Error("send must not be called")
+		return nil
+	})
+	bm = typeText(bm, "   ")
+	if _, cmd := bm.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Error("blank input should not dispatch")
+	}
+	bm, _ = bm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if !bm.Done() {
+		t.Error("esc should cancel")
+	}
+}
+
+func TestModel_NewGroupBroadcastSkipsExited(t *testing.T) {
+	m := Model{
+		sessions: []SessionRow{
+			{Name: "claude-a", Status: "working", WorkingDir: "/repo"},
+			{Name: "codex-b", Status: "exited", WorkingDir: "/repo"},
+			{Name: "other", Status: "idle", WorkingDir: "/elsewhere"},
+		},
+		repoRootCache: map[string]string{"/repo": "/repo", "/elsewhere": "/elsewhere"},
+	}
+	bm, ok := m.newGroupBroadcast()
+	if !ok {
+		t.Fatal("expected a broadcast for the selected group")
+	}
+	if !reflect.DeepEqual(bm.targets, []string{"claude-a"}) || !reflect.DeepEqual(bm.skipped, []string{"codex-b"}) {
+		t.Errorf("targets=%v skipped=%v", bm.targets, bm.skipped)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+// Bulk session operations. `space` marks/unmarks the selected session; while
+// any session is marked, `d` (kill, into the trash) and `r` (restart) act on
+// the whole marked set behind a single confirmation instead of one dialog per
+// session. Kill stays on `d` because `k` is cursor-up. `esc` clears the
+// marks. Marks are keyed by SessionRow.Name (the short tmux name) and are
+// pruned on every refresh so a session that disappears never lingers in the
+// set.
+
+// bulkAction identifies the operation waiting on bulk confirmation.
+type bulkAction int
+
+const (
+	bulkNone bulkAction = iota
+	bulkDelete
+	bulkRestart
+)
+
+// toggleMark flips the mark on the selected session. On a group header it
+// marks or unmarks the whole group. Returns false when nothing is under the
+// cursor.
+func (m *Model) toggleMark() bool {
+	idx := m.selectedSessionIdx()
+	if idx < 0 && m.groupMode {
+		return m.toggleGroupMarks()
+	}
+	if idx < 0 || idx >= len(m.sessions) {
+		return false
+	}
+	name := m.sessions[idx].Name
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	if m.marked[name] {
+		delete(m.marked, name)
+	} else {
+		m.marked[name] = true
+	}
+	return true
+}
+
+// toggleGroupMarks marks every session of the group under the cursor, or
+// unmarks them all when they are all marked already, so d/r and G act on
+// the whole group.
+func (m *Model) toggleGroupMarks() bool {
+	_, key := m.groupedCursorToSession()
+	indices := m.groupedSessions[key]
+	if len(indices) == 0 {
+		return false
+	}
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	all := true
+	for _, idx := range indices {
+		all = all && m.marked[m.sessions[idx].Name]
+	}
+	for _, idx := range indices {
+		if all {
+			delete(m.marked, m.sessions[idx].Name)
+		} else {
+			m.marked[m.sessions[idx].Name] = true
+		}
+	}
+	return true
+}
+
+// markedRows returns the marked sessions in list order.
+func (m Model) markedRows() []SessionRow {
+	var rows []SessionRow
+	for _, s := range m.sessions {
+		if m.marked[s.Name] {
+			rows = append(rows, s)
+		}
+	}
+	return rows
+}
+
+// pruneMarks drops marks for sessions that are no longer listed.
+func (m *Model) pruneMarks() {
+	if len(m.marked) == 0 {
+		return
+	}
+	live := make(map[string]bool, len(m.sessions))
+	for _, s := range m.sessions {
+		live[s.Name] = true
+	}
+	for name := range m.marked {
+		if !live[name] {
+			delete(m.marked, name)
+		}
+	}
+}
+
+// applyBulk runs action against every marked session and clears the marks.
+// Failures are logged per session and do not stop the rest of the batch —
+// the point of a bulk op is that one bad session doesn't block the others.
+func (m *Model) applyBulk(action bulkAction) {
+	for _, row := range m.markedRows() {
+		switch action {
+		case bulkDelete:
+			m.killSessionByName(row.Name)
+		case bulkRestart:
+			meta, found := m.storeMetaForRow(row)
+			if !found {
+				m.logger.Warn("bulk restart: no metadata for session %s, skipping", row.Name)
+				continue
+			}
+			if updated, err := RestartSession(meta, m.config, m.tmux, m.store, m.cache, m.registry); err != nil {
+				m.logger.Error("restart session %s: %v", meta.Name, err)
+			} else {
+				m.logger.Info("restarted session: %s", meta.Name)
+				m.hooks.Fire(HookSessionCreate, updated, nil)
+			}
+		}
+	}
+	m.marked = nil
+}
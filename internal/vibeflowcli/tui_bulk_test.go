@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var spaceKey = tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+
+func bulkTestModel(t *testing.T) Model {
+	t.Helper()
+	st := NewStoreWithPath(filepath.Join(t.TempDir(), "sessions.json"))
+	for _, n := range []string{"claude-a", "claude-b", "claude-c"} {
+		if err := st.Add(SessionMeta{Name: n, TmuxSession: sessionPrefix + n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return Model{
+		config: &Config{},
+		tmux:   NewTmuxManager("vftest-bulk"),
+		store:  st,
+		logger: &Logger{},
+		sessions: []SessionRow{
+			{Name: "claude-a"}, {Name: "claude-b"}, {Name: "claude-c"},
+		},
+	}
+}
+
+func TestBulk_SpaceTogglesMark(t *testing.T) {
+	m := bulkTestModel(t)
+	nm, _ := m.Update(spaceKey)
+	m = nm.(Model)
+	if !m.marked["claude-a"] {
+		t.Fatalf("space should mark the selected session, marks=%v", m.marked)
+	}
+	nm, _ = m.Update(spaceKey)
+	m = nm.(Model)
+	if len(m.marked) != 0 {
+		t.Fatalf("second space should unmark, marks=%v", m.marked)
+	}
+}
+
+func TestBulk_DeleteMarkedNeedsSingleConfirm(t *testing.T) {
+	m := bulkTestModel(t)
+	m.marked = map[string]bool{"claude-a": true, "claude-c": true}
+
+	nm, _ := m.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	m = nm.(Model)
+	if m.confirmBulk != bulkDelete || m.confirmDelete {
+		t.Fatalf("d with marks should ask bulk confirm, got bulk=%v single=%v", m.confirmBulk, m.confirmDelete)
+	}
+
+	nm, _ = m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	m = nm.(Model)
+	if m.confirmBulk != bulkNone || len(m.marked) != 0 {
+		t.Fatalf("confirm should clear state, bulk=%v marks=%v", m.confirmBulk, m.marked)
+	}
+	metas, _ := m.store.List()
+	if len(metas) != 1 || metas[0].Name != "claude-b" {
+		t.Errorf("only the unmarked session should remain, got %+v", metas)
+	}
+}
+
+func TestBulk_CancelKeepsMarks(t *testing.T) {
+	m := bulkTestModel(t)
+	m.marked = map[string]bool{"claude-b": true}
+	nm, _ := m.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	m = nm.(Model)
+	if m.confirmBulk != bulkRestart {
+		t.Fatalf("r with marks should ask bulk restart confirm, got %v", m.confirmBulk)
+	}
+	nm, _ = m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	m = nm.(Model)
+	if m.confirmBulk != bulkNone || !m.marked["claude-b"] {
+		t.Errorf("cancel should keep marks, bulk=%v marks=%v", m.confirmBulk, m.marked)
+	}
+	if metas, _ := m.store.List(); len(metas) != 3 {
+		t.Errorf("cancel must not touch the store, got %d entries", len(metas))
+	}
+}
+
+func TestBulk_PruneMarksDropsVanishedSessions(t *testing.T) {
+	m := bulkTestModel(t)
+	m.marked = map[string]bool{"claude-a": true, "gone": true}
+	m.pruneMarks()
+	if m.marked["gone"] || !m.marked["claude-a"] {
+		t.Errorf("pruneMarks = %v, want only claude-a", m.marked)
+	}
+}
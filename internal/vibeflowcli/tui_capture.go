@@ -0,0 +1,321 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// captureLines is how much of each pane a refresh captures: the tail
+	// shown in the detail panel and scanned by the health monitor.
+	captureLines = 20
+	// captureWorkers bounds the capture-pane calls a refresh runs at once.
+	captureWorkers = 4
+	// backgroundCaptureInterval is how often sessions other than the
+	// selected one are recaptured. Between captures the health monitor
+	// rechecks their cached output, which keeps its debounce and backoff
+	// timers moving without a tmux call per session per tick.
+	backgroundCaptureInterval = 6 * time.Second
+)
+
+// captureEntry is a session's last capture.
+type captureEntry struct {
+	output string
+	at     time.Time
+}
+
+// captureCache holds each session's last pane capture. Refreshes run off the
+// UI goroutine, so access is locked. A nil cache caches nothing.
+type captureCache struct {
+	mu      sync.Mutex
+	entries map[string]captureEntry
+}
+
+func newCaptureCache() *captureCache {
+	return &captureCache{entries: make(map[string]captureEntry)}
+}
+
+// Due reports whether name's capture is older than every (or missing).
+func (c *captureCache) Due(name string, now time.Time, every time.Duration) bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[name]
+	return !ok || now.Sub(e.at) >= every
+}
+
+// Put records a capture of name taken at now.
+func (c *captureCache) Put(name, output string, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = captureEntry{output: output, at: now}
+}
+
+// Get returns name's last capture.
+func (c *captureCache) Get(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[name]
+	return e.output, ok
+}
+
+// Prune drops sessions not in live.
+func (c *captureCache) Prune(live []string) {
+	if c == nil {
+		return
+	}
+	keep := make(map[string]bool, len(live))
+	for _, name := range live {
+		keep[name] = true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name := range c.entries {
+		if !keep[name] {
+			delete(c.entries, name)
+		}
+	}
+}
+
+// paneCapture is the result of capturing one pane.
+type paneCapture struct {
+	output string
+	err    error
+}
+
+// capturePanes captures the named panes concurrently, at most workers at a
+// time, so a refresh of many sessions doesn't take one tmux round trip per
+// session in sequence.
+func capturePanes(capture func(name string) (string, error), names []string, workers int) map[string]paneCapture {
+	out := make(map[string]paneCapture, len(names))
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, max(workers, 1))
+	)
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer func() { <-sem; wg.Done() }()
+			output, err := capture(name)
+			mu.Lock()
+			out[name] = paneCapture{output: stripANSI(output), err: err}
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	return out
+}
+
+// refreshCapture captures the selected session's pane for the detail panel
+// and the panes of the other live sessions for the health monitor. The
+// selected pane is captured every tick; the rest only once their cached
+// capture is older than backgroundCaptureInterval.
+func (m Model) refreshCapture() tea.Msg {
+	if m.tmux == nil {
+		return captureMsg{}
+	}
+	now := time.Now()
+	selected := ""
+	if idx := m.selectedSessionIdx(); idx >= 0 {
+		selected = m.sessions[idx].Name
+	}
+	var due, live []string
+	if selected != "" {
+		due, live = append(due, selected), append(live, selected)
+	}
+	for _, s := range m.sessions {
+		if s.Name == selected || agentInactive(s) {
+			continue
+		}
+		live = append(live, s.Name)
+		if m.captures.Due(s.Name, now, backgroundCaptureInterval) {
+			due = append(due, s.Name)
+		}
+	}
+	results := capturePanes(func(name string) (string, error) {
+		return m.tmux.CapturePaneOutput(name, captureLines)
+	}, due, captureWorkers)
+
+	msg := captureMsg{name: selected, outputs: make(map[string]string, len(live))}
+	for _, name := range live {
+		if r, ok := results[name]; ok {
+			if r.err == nil {
+				m.captures.Put(name, r.output, now)
+				msg.outputs[name] = r.output
+			}
+		} else if output, ok := m.captures.Get(name); ok {
+			msg.outputs[name] = output
+		}
+	}
+	m.captures.Prune(live)
+
+	if selected != "" {
+		switch r := results[selected]; {
+		case r.err != nil:
+			msg.output = "(no output)"
+		case strings.TrimSpace(r.output) == "":
+			msg.output = "(no output yet)"
+		default:
+			msg.output = r.output
+		}
+	}
+	return msg
+}
+
+// healthCheckedMsg reports that a round of health checks started by
+// checkCaptures is over.
+type healthCheckedMsg struct{}
+
+// checkCaptures returns a command that runs every captured pane through the
+// health monitor, so recovery attempts, their checkpoints and the history
+// writes stay out of Update. It returns nil while the previous round is
+// still running.
+func (m *Model) checkCaptures(outputs map[string]string) tea.Cmd {
+	if m.healthMonitor == nil || m.healthChecking {
+		return nil
+	}
+	rows := make(map[string]SessionRow, len(m.sessions))
+	for _, s := range m.sessions {
+		rows[s.Name] = s
+	}
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		if outputs[name] != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	m.healthChecking = true
+	model := *m
+	return func() tea.Msg {
+		for _, name := range names {
+			row, ok := rows[name]
+			if !ok {
+				row = SessionRow{Name: name}
+			}
+			model.checkHealth(row, outputs[name])
+		}
+		return healthCheckedMsg{}
+	}
+}
+
+// checkHealth scans one session's capture for error patterns, attempting
+// recovery and firing the recovery and failure hooks as its health changes.
+// CheckOutput marks a session failed itself on a fatal pattern or once its
+// retries are used up, so the failure hook is fired here for those.
+func (m Model) checkHealth(row SessionRow, output string) {
+	wasFailed := false
+	if prev := m.healthMonitor.GetHealth(row.Name); prev != nil {
+		wasFailed = prev.Status == HealthFailed
+	}
+	recover := m.healthMonitor.CheckOutput(row.Name, row.Provider, output, row.TmuxAttached)
+	if m.readOnly {
+		return
+	}
+	if recover {
+		m.runRecovery(row.Name, row.Provider)
+		return
+	}
+	if sh := m.healthMonitor.GetHealth(row.Name); sh != nil && sh.Status == HealthFailed && !wasFailed {
+		meta, _ := m.healthHookMeta(row.Name, row.Provider)
+		m.hooks.Fire(HookFailed, meta, healthHookExtra(sh))
+	}
+}
+
+// runRecovery makes a recovery attempt for a session, recording it in the
+// session history and firing the recovery hook, and the failure hook when
+// the session gave out.
+func (m Model) runRecovery(name, provider string) {
+	prev := m.healthMonitor.GetHealth(name)
+	if prev == nil {
+		return
+	}
+	before, wasFailed := prev.RecoveryCount, prev.Status == HealthFailed
+	_ = m.healthMonitor.AttemptRecovery(name)
+	sh := m.healthMonitor.GetHealth(name)
+	meta, ok := m.healthHookMeta(name, provider)
+	extra := healthHookExtra(sh)
+	if sh.RecoveryCount > before {
+		if ok {
+			_ = m.store.History().RecordRecovery(meta.Name, extra["VIBEFLOW_ERROR"], time.Now())
+		}
+		extra["VIBEFLOW_ATTEMPT"] = strconv.Itoa(sh.RecoveryCount)
+		m.hooks.Fire(HookRecovery, meta, extra)
+	}
+	if sh.Status == HealthFailed && !wasFailed {
+		m.hooks.Fire(HookFailed, meta, extra)
+	}
+}
+
+// healthHookMeta returns the stored session for the health hooks, or a
+// stand-in built from its name when it isn't stored; ok reports which.
+func (m Model) healthHookMeta(name, provider string) (meta SessionMeta, ok bool) {
+	if meta, ok = m.storeMetaForRow(SessionRow{Name: name}); ok {
+		return meta, true
+	}
+	return SessionMeta{Name: name, TmuxSession: sessionPrefix + name, Provider: provider}, false
+}
+
+// healthHookExtra returns the hook environment describing sh's error.
+func healthHookExtra(sh *SessionHealth) map[string]string {
+	extra := map[string]string{}
+	if sh.MatchedPattern != nil {
+		extra["VIBEFLOW_ERROR"] = sh.MatchedPattern.Description
+	}
+	return extra
+}
+
+// parsePaneStatus reads each captured pane with its provider's parser.
+func (m Model) parsePaneStatus(outputs map[string]string) map[string]PaneStatus {
+	providers := make(map[string]string, len(m.sessions))
+	for _, s := range m.sessions {
+		providers[s.Name] = s.Provider
+	}
+	status := make(map[string]PaneStatus, len(outputs))
+	for name, output := range outputs {
+		parser := providers[name]
+		if m.registry != nil {
+			if prov, ok := m.registry.Get(parser); ok && prov.OutputParser != "" {
+				parser = prov.OutputParser
+			}
+		}
+		if s := ParsePaneStatus(parser, output); !s.IsZero() {
+			status[name] = s
+		}
+	}
+	return status
+}
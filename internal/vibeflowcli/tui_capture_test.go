@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCapturePanes_BoundedConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	capture := func(name string) (string, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		if name == "bad" {
+			return "", errors.New("no pane")
+		}
+		return "\x1b[31mout " + name + "\x1b[0m", nil
+	}
+	names := []string{"a", "b", "c", "d", "e", "f", "bad"}
+	got := capturePanes(capture, names, 3)
+	if p := peak.Load(); p > 3 || p < 2 {
+		t.Errorf("peak concurrency = %d, want 2..3", p)
+	}
+	if len(got) != len(names) {
+		t.Fatalf("captured %d panes, want %d", len(got), len(names))
+	}
+	if got["c"].output != "out c" || got["c"].err != nil {
+		t.Errorf("c = %+v, want ANSI-stripped output", got["c"])
+	}
+	if got["bad"].err == nil {
+		t.Error("capture error lost")
+	}
+}
+
+func TestCaptureCache(t *testing.T) {
+	c := newCaptureCache()
+	now := time.Now()
+	if !c.Due("a", now, time.Minute) {
+		t.Error("uncached session not due")
+	}
+	c.Put("a", "out", now)
+	c.Put("b", "other", now)
+	if c.Due("a", now.Add(30*time.Second), time.Minute) {
+		t.Error("fresh capture due again")
+	}
+	if !c.Due("a", now.Add(time.Minute), time.Minute) {
+		t.Error("stale capture not due")
+	}
+	c.Prune([]string{"a"})
+	if out, ok := c.Get("a"); !ok || out != "out" {
+		t.Errorf("Get(a) = %q, %v", out, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("pruned session still cached")
+	}
+
+	var off *captureCache
+	off.Put("a", "x", now)
+	if _, ok := off.Get("a"); ok || !off.Due("a", now, time.Minute) {
+		t.Error("nil cache cached")
+	}
+}
+
+func TestUpdate_CaptureMsg_ChecksUnselectedSessions(t *testing.T) {
+	hm := testHealthMonitor(t)
+	m := Model{logger: &Logger{}, healthMonitor: hm, sessions: []SessionRow{
+		{Name: "sel", Provider: "claude"},
+		{Name: "bg", Provider: "claude"},
+	}}
+	nm, cmd := m.Update(captureMsg{name: "sel", output: "all fine", outputs: map[string]string{
+		"sel": "all fine",
+		"bg":  "panic: runtime error",
+	}})
+	m = nm.(Model)
+	if m.captureOutput != "all fine" {
+		t.Errorf("captureOutput = %q", m.captureOutput)
+	}
+	if cmd == nil || !m.healthChecking {
+		t.Fatal("the capture should start a round of health checks")
+	}
+	if hm.GetHealth("bg") != nil {
+		t.Fatal("health was checked in Update, not in the returned command")
+	}
+	if _, again := m.Update(captureMsg{outputs: map[string]string{"bg": "x"}}); again != nil {
+		t.Error("a second round was started while one is running")
+	}
+	if _, ok := cmd().(healthCheckedMsg); !ok {
+		t.Fatal("the round should end with a healthCheckedMsg")
+	}
+	if nm, _ := m.Update(healthCheckedMsg{}); nm.(Model).healthChecking {
+		t.Error("healthChecking still set after the round ended")
+	}
+	if sh := hm.GetHealth("bg"); sh == nil || sh.Status != HealthFailed {
+		t.Errorf("unselected session's fatal error not detected: %+v", sh)
+	}
+	if sh := hm.GetHealth("sel"); sh == nil || sh.Status != HealthHealthy {
+		t.Errorf("selected session health = %+v", sh)
+	}
+}
+
+func TestCheckHealth_FiresFailedHookOnFatalPattern(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands are sh scripts")
+	}
+	out := filepath.Join(t.TempDir(), "failed")
+	t.Setenv("OUT", out)
+	m := bulkTestModel(t)
+	m.healthMonitor = testHealthMonitor(t)
+	m.hooks = NewHookRunner(HooksConfig{OnFailed: `echo "$VIBEFLOW_SESSION" >> "$OUT"`}, m.tmux, nil)
+
+	row := SessionRow{Name: "claude-a", Provider: "claude"}
+	m.checkHealth(row, "panic: runtime error")
+	m.checkHealth(row, "panic: runtime error") // still failed: no second hook
+	m.hooks.Wait()
+	if data, err := os.ReadFile(out); err != nil || string(data) != "claude-a\n" {
+		t.Errorf("failed hook ran for %q (err %v), want once for claude-a", data, err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// clipboardOutputLines is how much of a pane is copied when no capture is
+// cached for the session yet.
+const clipboardOutputLines = 200
+
+// clipboardCommand returns the command that copies its stdin to the system
+// clipboard, or nil when OSC 52 should be used instead: over SSH the local
+// clipboard is on the other end, and only the terminal can reach it.
+func clipboardCommand(goos string, getenv func(string) string, lookPath func(string) (string, error)) []string {
+	if getenv("SSH_TTY") != "" || getenv("SSH_CONNECTION") != "" {
+		return nil
+	}
+	var candidates [][]string
+	switch goos {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if getenv("DISPLAY") != "" {
+			candidates = append(candidates,
+				[]string{"xclip", "-selection", "clipboard"},
+				[]string{"xsel", "--clipboard", "--input"})
+		}
+	}
+	for _, c := range candidates {
+		if _, err := lookPath(c[0]); err == nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// copyToClipboard copies text with the platform's clipboard tool, falling
+// back to OSC 52 (which most terminals, and tmux with set-clipboard, honour)
+// when there is none or it fails.
+func copyToClipboard(text string) tea.Cmd {
+	args := clipboardCommand(runtime.GOOS, os.Getenv, exec.LookPath)
+	if args == nil {
+		return tea.SetClipboard(text)
+	}
+	return func() tea.Msg {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+		return tea.SetClipboard(text)()
+	}
+}
+
+// sessionInfoText describes a session for pasting elsewhere, ending with
+// the command that attaches to it from another terminal.
+func sessionInfoText(row SessionRow, tm *TmuxManager) string {
+	fullName := tm.ensurePrefix(row.Name)
+	var b strings.Builder
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%-9s %s\n", label+":", value)
+		}
+	}
+	field("session", row.Name)
+	field("tmux", fullName)
+	field("provider", row.Provider)
+	field("project", row.Project)
+	field("branch", row.Branch)
+	field("workdir", row.WorkingDir)
+	field("worktree", row.WorktreePath)
+	field("attach", shellJoin(tm.command(true, attachArgs(fullName, false)...).Args))
+	return b.String()
+}
+
+// copySessionInfo copies the selected session's details and attach command.
+func (m Model) copySessionInfo() (tea.Model, tea.Cmd) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 {
+		return m, nil
+	}
+	row := m.sessions[idx]
+	m.notice = fmt.Sprintf("Copied %s's details and attach command", row.Name)
+	return m, tea.Batch(copyToClipboard(sessionInfoText(row, m.tmux)), clearNoticeCmd())
+}
+
+// copySessionOutput copies the selected session's last captured output,
+// capturing the pane when nothing is cached yet.
+func (m Model) copySessionOutput() (tea.Model, tea.Cmd) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 {
+		return m, nil
+	}
+	name := m.sessions[idx].Name
+	output, ok := m.captures.Get(name)
+	if !ok {
+		var err error
+		if output, err = m.tmux.CapturePaneOutput(name, clipboardOutputLines); err != nil {
+			m.err = fmt.Errorf("copy output of %s: %w", name, err)
+			return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+		}
+	}
+	output = strings.TrimRight(stripANSI(output), "\n ") + "\n"
+	m.notice = fmt.Sprintf("Copied %s's output (%d lines)", name, strings.Count(output, "\n"))
+	return m, tea.Batch(copyToClipboard(output), clearNoticeCmd())
+}
+
+// clearNoticeCmd clears the notice line after a few seconds.
+func clearNoticeCmd() tea.Cmd {
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+}
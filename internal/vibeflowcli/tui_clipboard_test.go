@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestClipboardCommand(t *testing.T) {
+	installed := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	for _, tc := range []struct {
+		name  string
+		goos  string
+		vars  map[string]string
+		tools []string
+		want  []string
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, []string{"pbcopy"}},
+		{"wayland first", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, []string{"wl-copy"}},
+		{"x11 xsel", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, []string{"xsel", "--clipboard", "--input"}},
+		{"no display", "linux", nil, []string{"xclip"}, nil},
+		{"ssh uses OSC 52", "darwin", map[string]string{"SSH_TTY": "/dev/ttys001"}, []string{"pbcopy"}, nil},
+	} {
+		got := clipboardCommand(tc.goos, env(tc.vars), installed(tc.tools...))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSessionInfoText(t *testing.T) {
+	tm := NewTmuxManager("vibeflow")
+	text := sessionInfoText(SessionRow{Name: "claude-feat", Provider: "claude", Branch: "feat", WorkingDir: "/src/app"}, tm)
+	for _, want := range []string{
+		"session:  claude-feat\n",
+		"branch:   feat\n",
+		"workdir:  /src/app\n",
+		"attach:   tmux -L vibeflow attach-session -t vibeflow_claude-feat\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("info missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "worktree:") {
+		t.Errorf("empty fields should be left out:\n%s", text)
+	}
+}
+
+func TestCopyKeys_SetNotice(t *testing.T) {
+	m := bulkTestModel(t)
+	m.captures = newCaptureCache()
+	m.captures.Put("claude-a", "line one\nline two\n\n", time.Now())
+
+	nm, cmd := m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	m = nm.(Model)
+	if cmd == nil || !strings.Contains(m.notice, "claude-a") {
+		t.Errorf("y: notice %q, cmd %v; want a copy of claude-a's details", m.notice, cmd)
+	}
+
+	nm, cmd = m.Update(tea.KeyPressMsg{Code: 'Y', Text: "Y"})
+	m = nm.(Model)
+	if cmd == nil || m.notice != "Copied claude-a's output (2 lines)" {
+		t.Errorf("Y: notice %q, cmd %v", m.notice, cmd)
+	}
+
+	nm, _ = m.Update(errClearMsg{})
+	if m = nm.(Model); m.notice != "" {
+		t.Errorf("notice not cleared: %q", m.notice)
+	}
+}
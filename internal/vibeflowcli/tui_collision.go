@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// pendingCollision is a TUI launch held back by a session collision until
+// the user picks what to do with the running session.
+type pendingCollision struct {
+	collision SessionCollision
+	result    WizardResult
+}
+
+// collisionMsg carries launches that stopped on a session collision while
+// session_collision is "ask".
+type collisionMsg struct {
+	pending []pendingCollision
+}
+
+// collisionKeys maps the prompt's keys to their actions.
+var collisionKeys = map[string]CollisionAction{
+	"a": CollisionAdopt,
+	"k": CollisionKill,
+	"s": CollisionSuffix,
+}
+
+// applyCollisions queues the held launches for the prompt and refreshes
+// the list, since a team launch may have started its other personas.
+func (m Model) applyCollisions(msg collisionMsg) (tea.Model, tea.Cmd) {
+	m.collisions = append(m.collisions, msg.pending...)
+	return m, m.refreshSessions
+}
+
+// handleCollisionKey answers the prompt for the first held launch: a, k or
+// s relaunch it with that action, any other key cancels it.
+func (m Model) handleCollisionKey(key string) (tea.Model, tea.Cmd) {
+	held := m.collisions[0]
+	m.collisions = m.collisions[1:]
+	action, ok := collisionKeys[key]
+	if !ok {
+		m.logger.Info("session collision on %s: launch cancelled", held.collision.TmuxSession)
+		return m, nil
+	}
+	result := held.result
+	result.OnCollision = string(action)
+	return m, func() tea.Msg { return m.executeLaunch(result) }
+}
+
+// collisionPrompt is the help bar question for the first held launch.
+func (m Model) collisionPrompt() string {
+	return collisionSummary(m.collisions[0].collision) + " — [a]dopt, [k]ill, [s]uffix or esc"
+}
@@ -37,11 +37,12 @@ const (
 // ConflictModal is a Bubble Tea sub-model that displays when a session
 // conflict is detected in the target directory.
 type ConflictModal struct {
-	conflict ConflictResult
-	options  []conflictOption
-	cursor   int
-	done     bool
-	action   ConflictAction
+	conflict   ConflictResult
+	coexisting []ConflictResult
+	options    []conflictOption
+	cursor     int
+	done       bool
+	action     ConflictAction
 }
 
 type conflictOption struct {
@@ -81,6 +82,19 @@ func NewConflictModal(conflict ConflictResult) ConflictModal {
 	}
 }
 
+// WithCoexisting records the directory's session files for other personas.
+// They don't block the launch — each persona has its own file — and are
+// listed for context. The conflicting persona's own entry is dropped.
+func (cm ConflictModal) WithCoexisting(sessions []ConflictResult) ConflictModal {
+	cm.coexisting = nil
+	for _, s := range sessions {
+		if s.Persona != cm.conflict.Persona {
+			cm.coexisting = append(cm.coexisting, s)
+		}
+	}
+	return cm
+}
+
 // Done returns true when the user has made a selection.
 func (cm ConflictModal) Done() bool { return cm.done }
 
@@ -149,11 +163,27 @@ func (cm ConflictModal) View() string {
 
 	b.WriteString(fmt.Sprintf("  Session:  %s\n", cm.conflict.SessionID))
 	b.WriteString(fmt.Sprintf("  Provider: %s\n", cm.conflict.Provider))
+	if cm.conflict.Persona != "" {
+		b.WriteString(fmt.Sprintf("  Persona:  %s\n", cm.conflict.Persona))
+	}
 	b.WriteString(fmt.Sprintf("  Status:   %s\n",
 		lipgloss.NewStyle().Foreground(statusColor).Render(statusLabel)))
 	b.WriteString(fmt.Sprintf("  File:     %s\n", cm.conflict.FilePath))
 	b.WriteString("\n")
 
+	if len(cm.coexisting) > 0 {
+		b.WriteString(helpStyle.Render("  Other personas in this directory (not affected):"))
+		b.WriteString("\n")
+		for _, other := range cm.coexisting {
+			persona := other.Persona
+			if persona == "" {
+				persona = "(no persona)"
+			}
+			b.WriteString(fmt.Sprintf("    %-14s %s  %s\n", persona, other.SessionID, other.Status))
+		}
+		b.WriteString("\n")
+	}
+
 	// Options
 	for i, opt := range cm.options {
 		cursor := "  "
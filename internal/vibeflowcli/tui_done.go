@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// summaryOutputLines is how much pane output the summary searches for the
+// agent's final message.
+const summaryOutputLines = 200
+
+// recordDone flags the named sessions as done, recording in the store when
+// each first signalled completion.
+func (m Model) recordDone(names []string) Model {
+	now := time.Now()
+	for _, name := range names {
+		for i := range m.sessions {
+			if m.sessions[i].Name != name || m.sessions[i].Done {
+				continue
+			}
+			m.sessions[i].Done = true
+			if meta, ok := m.storeMetaForRow(m.sessions[i]); ok && meta.DoneAt.IsZero() {
+				if err := m.store.SetDone(meta.Name, now); err != nil {
+					m.logger.Warn("record done for %s: %v", meta.Name, err)
+				}
+				m.logger.Info("session %s signalled completion", name)
+			}
+		}
+	}
+	return m
+}
+
+// markDone shows rows whose agent signalled completion as "done" while it
+// sits idle or has exited. An agent given more work shows as working again.
+func markDone(rows []SessionRow) {
+	for i := range rows {
+		if !rows[i].Done {
+			continue
+		}
+		switch rows[i].Status {
+		case "paused", "pending", "working", "waiting":
+		default:
+			rows[i].Status = "done"
+		}
+	}
+}
+
+// summaryMsg carries a loaded summary for the summary view.
+type summaryMsg struct {
+	name    string
+	summary DoneSummary
+}
+
+// SummaryModel shows what a session produced (`S` on the session list):
+// how long it ran, the commits and diff stat since launch, and the agent's
+// final message. `a` archives a done session from here.
+type SummaryModel struct {
+	meta    SessionMeta
+	status  string
+	summary DoneSummary
+	loaded  bool
+	done    bool
+	archive bool
+}
+
+// NewSummaryModel creates the view for meta, whose row shows status.
+func NewSummaryModel(meta SessionMeta, status string) SummaryModel {
+	return SummaryModel{meta: meta, status: status}
+}
+
+// Done reports whether the view should close.
+func (s SummaryModel) Done() bool { return s.done }
+
+// Archive reports whether the user chose to archive the session.
+func (s SummaryModel) Archive() bool { return s.archive }
+
+// Update handles the summary arriving and the view's keys.
+func (s SummaryModel) Update(msg tea.Msg) (SummaryModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case summaryMsg:
+		if msg.name == s.meta.Name {
+			s.summary, s.loaded = msg.summary, true
+		}
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc", "q", "S":
+			s.done = true
+		case "a":
+			if s.status == "done" || s.status == "review" {
+				s.archive, s.done = true, true
+			}
+		}
+	}
+	return s, nil
+}
+
+// View renders the summary.
+func (s SummaryModel) View() string {
+	var b strings.Builder
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	label := lipgloss.NewStyle().Foreground(dimColor).Width(14)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	row := func(l, v string) {
+		b.WriteString(label.Render(l) + v + "\n")
+	}
+
+	b.WriteString(title.Render("Summary: " + s.meta.Name))
+	b.WriteString("\n\n")
+	row("Status", renderStatus(s.status))
+	if !s.meta.DoneAt.IsZero() {
+		row("Done", s.meta.DoneAt.Local().Format("2006-01-02 15:04"))
+	}
+	if s.meta.Branch != "" {
+		row("Branch", s.meta.Branch)
+	}
+	if s.meta.PRURL != "" {
+		row("PR", s.meta.PRURL)
+	}
+	if !s.loaded {
+		b.WriteString("\n" + dim.Render("Loading...") + "\n")
+	} else {
+		row("Duration", formatSessionDuration(s.summary.Duration))
+		if !s.summary.Git {
+			row("Commits", dim.Render("(not a git checkout)"))
+		} else {
+			row("Commits", fmt.Sprintf("%d since launch", len(s.summary.Commits)))
+			for _, c := range s.summary.Commits {
+				b.WriteString("  " + c + "\n")
+			}
+			if s.summary.DiffStat != "" {
+				b.WriteString("\n" + title.Render("Changes") + "\n")
+				b.WriteString(s.summary.DiffStat + "\n")
+			}
+		}
+		if s.summary.FinalMessage != "" {
+			b.WriteString("\n" + title.Render("Final message") + "\n")
+			b.WriteString(s.summary.FinalMessage + "\n")
+		}
+	}
+	b.WriteString("\n")
+	hint := "esc: close"
+	if s.status == "done" || s.status == "review" {
+		hint = "a: archive  " + hint
+	}
+	b.WriteString(helpStyle.Render(hint))
+	return b.String()
+}
+
+// openSummary opens the summary view for row and loads its summary.
+func (m Model) openSummary(row SessionRow) (Model, tea.Cmd) {
+	meta, ok := m.storeMetaForRow(row)
+	if !ok {
+		meta = SessionMeta{Name: row.Name, Provider: row.Provider, Branch: row.Branch, WorktreePath: row.WorktreePath, WorkingDir: row.WorkingDir}
+	}
+	m.summary = NewSummaryModel(meta, row.Status)
+	m.activeView = ViewSummary
+	tmux, completion := m.tmux, m.completion
+	return m, func() tea.Msg {
+		output := ""
+		if tmux != nil {
+			output, _ = tmux.CapturePaneOutput(row.Name, summaryOutputLines)
+		}
+		return summaryMsg{name: meta.Name, summary: loadDoneSummary(meta, stripANSI(output), completion, time.Now())}
+	}
+}
+
+// archiveSession ends a finished session: its tmux session is killed and its
+// history entry closed as archived. Unlike a kill it skips the trash and
+// keeps the worktree, which holds the finished work.
+func (m Model) archiveSession(row SessionRow) tea.Cmd {
+	return func() tea.Msg {
+		meta, ok := m.storeMetaForRow(row)
+		if ok {
+			saveArchiveTail(m.config, m.tmux, m.store, meta)
+		}
+		if err := m.tmux.KillSession(row.Name); err != nil {
+			return sessionsMsg{err: fmt.Errorf("archive %s: %w", row.Name, err)}
+		}
+		m.logger.Info("session archived: %s", row.Name)
+		if ok {
+			_ = m.store.History().End(meta.Name, ExitArchived, time.Now())
+			_ = m.store.Remove(meta.Name)
+			m.hooks.Fire(HookSessionKill, meta, nil)
+		}
+		if m.cache != nil {
+			_ = m.cache.Remove(row.Name)
+		}
+		return m.refreshSessions()
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// openEditor opens the selected session's worktree or working directory in
+// the editor (see editorCommand). A terminal editor takes over the screen
+// until it exits, like an attach; a GUI editor opens beside the TUI.
+func (m Model) openEditor() (tea.Model, tea.Cmd) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 {
+		return m, nil
+	}
+	row := m.sessions[idx]
+	dir := firstNonEmpty(row.WorktreePath, row.WorkingDir)
+	if m.tmux.IsRemote() || dir == "" {
+		m.err = fmt.Errorf("no directory recorded for %s", row.Name)
+		if m.tmux.IsRemote() {
+			m.err = fmt.Errorf("%s runs on another host; open its directory there", row.Name)
+		}
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	var configured string
+	if m.config != nil {
+		configured = m.config.Editor
+	}
+	editor := editorCommand(configured, os.Getenv)
+	proc, foreground, _ := editorProcess(editor, dir, "")
+	if foreground {
+		return m, tea.ExecProcess(proc, func(err error) tea.Msg {
+			return attachExitMsg{err: err}
+		})
+	}
+	if err := proc.Start(); err != nil {
+		m.err = fmt.Errorf("open editor: %w", err)
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	_ = proc.Process.Release()
+	m.notice = fmt.Sprintf("Opened %s in %s", dir, filepath.Base(editor[0]))
+	return m, clearNoticeCmd()
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"maps"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// gitSummaryCheckInterval is how often the TUI checks whether the
+	// selected session's git summary needs refreshing.
+	gitSummaryCheckInterval = 2 * time.Second
+	// gitSummaryRefresh is how often the selected session's git summary is
+	// re-read while it stays selected.
+	gitSummaryRefresh = 10 * time.Second
+)
+
+// gitSummaryTickMsg drives the selected-session git summary.
+type gitSummaryTickMsg struct{}
+
+// gitSummaryMsg carries a git summary for a session.
+type gitSummaryMsg struct {
+	name    string
+	summary GitSummary
+	err     error
+}
+
+func gitSummaryTickCmd() tea.Cmd {
+	return tea.Tick(gitSummaryCheckInterval, func(time.Time) tea.Msg {
+		return gitSummaryTickMsg{}
+	})
+}
+
+// selectedSessionDir returns the selected row's name and the directory its
+// agent works in: the worktree, else the working directory.
+func (m Model) selectedSessionDir() (name, dir string, ok bool) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 {
+		return "", "", false
+	}
+	s := m.sessions[idx]
+	dir = s.WorktreePath
+	if dir == "" {
+		dir = s.WorkingDir
+	}
+	return s.Name, dir, dir != ""
+}
+
+// handleGitSummaryTick reads the selected session's git summary when it was
+// just selected or its last read is older than gitSummaryRefresh. git runs in
+// the command, off the update loop.
+func (m Model) handleGitSummaryTick(now time.Time) (Model, tea.Cmd) {
+	next := gitSummaryTickCmd()
+	name, dir, ok := m.selectedSessionDir()
+	if !ok || (name == m.gitFetchName && now.Sub(m.gitFetchedAt) < gitSummaryRefresh) {
+		return m, next
+	}
+	m.gitFetchName, m.gitFetchedAt = name, now
+	load := func() tea.Msg {
+		summary, err := loadGitSummary(dir)
+		if err == nil {
+			summary.Checkpoints, _ = listCheckpoints(dir, name)
+		}
+		return gitSummaryMsg{name: name, summary: summary, err: err}
+	}
+	return m, tea.Batch(next, load)
+}
+
+// applyGitSummary records a git summary. A failed read (the directory is not
+// a git checkout, or is gone) drops the session's entry so the panel shows
+// nothing rather than stale data.
+func (m Model) applyGitSummary(msg gitSummaryMsg) Model {
+	summaries := make(map[string]GitSummary, len(m.gitSummaries)+1)
+	maps.Copy(summaries, m.gitSummaries)
+	if msg.err != nil {
+		m.logger.Debug("git summary for %s: %v", msg.name, msg.err)
+		delete(summaries, msg.name)
+	} else {
+		summaries[msg.name] = msg.summary
+	}
+	m.gitSummaries = summaries
+	return m
+}
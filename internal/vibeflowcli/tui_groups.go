@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// groupKeyPrefix marks a grouped-view key as a user-defined group rather
+// than a repo root. Repo roots are absolute paths (or "(unknown)"), so the
+// two never collide.
+const groupKeyPrefix = "group:"
+
+// namedGroupKey returns the grouped-view key of a user-defined group.
+func namedGroupKey(name string) string { return groupKeyPrefix + name }
+
+// namedGroup returns the group name for a grouped-view key, and whether the
+// key is a user-defined group at all.
+func namedGroup(key string) (string, bool) {
+	return strings.CutPrefix(key, groupKeyPrefix)
+}
+
+// toggleGroupCollapsed flips a group's collapse state. For user-defined
+// groups the state is saved in the store so it survives restarts.
+func (m *Model) toggleGroupCollapsed(key string) {
+	m.collapsedGroups[key] = !m.collapsedGroups[key]
+	if name, ok := namedGroup(key); ok && m.store != nil {
+		if err := m.store.SetGroupCollapsed(name, m.collapsedGroups[key]); err != nil {
+			m.logger.Warn("save group %q collapse state: %v", name, err)
+		}
+	}
+}
+
+// groupAssignTargets returns the sessions `G` moves: the marked ones, or
+// the one under the cursor.
+func (m Model) groupAssignTargets() []SessionRow {
+	if rows := m.markedRows(); len(rows) > 0 {
+		return rows
+	}
+	if idx := m.selectedSessionIdx(); idx >= 0 && idx < len(m.sessions) {
+		return []SessionRow{m.sessions[idx]}
+	}
+	return nil
+}
+
+// assignGroup moves rows into group (out of any group when it is empty).
+func (m Model) assignGroup(rows []SessionRow, group string) error {
+	if m.store == nil {
+		return fmt.Errorf("no session store")
+	}
+	for _, row := range rows {
+		meta, ok := m.storeMetaForRow(row)
+		if !ok {
+			return fmt.Errorf("session %q has no stored metadata", row.Name)
+		}
+		if err := m.store.SetGroup(meta.Name, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GroupAssignModel prompts for the group to move sessions into (`G` on the
+// session list). Existing groups matching the typed text are listed and can
+// be picked with up/down; otherwise the typed name is used, creating the
+// group. An empty name removes the sessions from their group.
+type GroupAssignModel struct {
+	targets []string // short session names being moved
+	groups  []string // existing group names
+	input   string
+	cursor  int // index into matches(); -1 = use the typed name
+	done    bool
+	group   string // chosen group once confirmed
+	ok      bool   // false when cancelled
+}
+
+// NewGroupAssignModel creates the prompt for moving targets into a group.
+func NewGroupAssignModel(targets []string, groups []SessionGroup) GroupAssignModel {
+	ga := GroupAssignModel{targets: targets, cursor: -1}
+	for _, g := range groups {
+		ga.groups = append(ga.groups, g.Name)
+	}
+	return ga
+}
+
+// Done reports whether the prompt should close.
+func (ga GroupAssignModel) Done() bool { return ga.done }
+
+// Result returns the chosen group and whether the user confirmed.
+func (ga GroupAssignModel) Result() (group string, ok bool) { return ga.group, ga.ok }
+
+// matches returns the existing groups containing the typed text.
+func (ga GroupAssignModel) matches() []string {
+	needle := strings.ToLower(strings.TrimSpace(ga.input))
+	var out []string
+	for _, g := range ga.groups {
+		if strings.Contains(strings.ToLower(g), needle) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// Update handles input for the group prompt.
+func (ga GroupAssignModel) Update(msg tea.Msg) (GroupAssignModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.PasteMsg:
+		ga.input += strings.ReplaceAll(msg.Content, "\n", " ")
+		ga.cursor = -1
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc":
+			ga.done = true
+		case "enter":
+			if m := ga.matches(); ga.cursor >= 0 && ga.cursor < len(m) {
+				ga.group = m[ga.cursor]
+			} else {
+				ga.group = strings.TrimSpace(ga.input)
+			}
+			ga.done, ga.ok = true, true
+		case "up":
+			if ga.cursor >= 0 {
+				ga.cursor--
+			}
+		case "down":
+			if ga.cursor < len(ga.matches())-1 {
+				ga.cursor++
+			}
+		case "backspace":
+			if r := []rune(ga.input); len(r) > 0 {
+				ga.input = string(r[:len(r)-1])
+			}
+			ga.cursor = -1
+		case "space":
+			ga.input += " "
+			ga.cursor = -1
+		default:
+			ga.input += msg.Text
+			ga.cursor = -1
+		}
+	}
+	return ga, nil
+}
+
+// View renders the group prompt.
+func (ga GroupAssignModel) View() string {
+	var b strings.Builder
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	b.WriteString(title.Render(fmt.Sprintf("Move %d session(s) to group", len(ga.targets))))
+	b.WriteString("\n\n")
+	for _, name := range ga.targets {
+		b.WriteString(dim.Render("  "+name) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("> " + ga.input + "█\n\n")
+	for i, g := range ga.matches() {
+		line := "  " + g
+		if i == ga.cursor {
+			line = selectedStyle.Render("▸ " + g)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("enter: move  ↑/↓: pick existing group  empty name: remove from group  esc: cancel"))
+	return b.String()
+}
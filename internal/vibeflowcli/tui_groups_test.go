@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestStore_SetGroup(t *testing.T) {
+	st := NewStoreWithPath(filepath.Join(t.TempDir(), "vibeflow.db"))
+	for _, name := range []string{"a", "b", "c"} {
+		if err := st.Add(SessionMeta{Name: name, TmuxSession: sessionPrefix + name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = st.SetGroup("a", "frontend team")
+	_ = st.SetGroup("b", "experiments")
+	_ = st.SetGroup("c", "frontend team")
+	if err := st.SetGroup("missing", "x"); err == nil {
+		t.Error("SetGroup on an unknown session should fail")
+	}
+
+	groups, err := st.Groups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	if want := []string{"frontend team", "experiments"}; !slices.Equal(names, want) {
+		t.Fatalf("groups = %q, want %q (creation order)", names, want)
+	}
+
+	if err := st.SetGroupCollapsed("experiments", true); err != nil {
+		t.Fatal(err)
+	}
+	groups, _ = st.Groups()
+	if !groups[1].Collapsed {
+		t.Error("collapse state not saved")
+	}
+
+	// Emptying a group drops it.
+	_ = st.SetGroup("b", "")
+	groups, _ = st.Groups()
+	if len(groups) != 1 || groups[0].Name != "frontend team" {
+		t.Errorf("groups after emptying experiments = %+v", groups)
+	}
+	if meta, _, _ := st.Get("b"); meta.Group != "" {
+		t.Errorf("b still in group %q", meta.Group)
+	}
+}
+
+func namedGroupsModel() Model {
+	m := Model{
+		groupMode:       true,
+		repoRootCache:   map[string]string{"/work/alpha": "/work/alpha"},
+		collapsedGroups: map[string]bool{},
+		sessions: []SessionRow{
+			{Name: "claude-a", WorkingDir: "/work/alpha"},
+			{Name: "codex-b", WorkingDir: "/work/alpha", Group: "frontend"},
+			{Name: "gemini-c", WorkingDir: "/work/alpha", Group: "frontend"},
+		},
+		sessionGroups: []SessionGroup{{Name: "frontend"}, {Name: "stale"}},
+	}
+	m.buildGroups()
+	return m
+}
+
+func TestBuildGroups_NamedGroupsFirst(t *testing.T) {
+	m := namedGroupsModel()
+	if want := []string{namedGroupKey("frontend"), "/work/alpha"}; !slices.Equal(m.groupOrder, want) {
+		t.Fatalf("groupOrder = %q, want %q", m.groupOrder, want)
+	}
+	if got := m.groupedSessions["/work/alpha"]; !slices.Equal(got, []int{0}) {
+		t.Errorf("repo group = %v, want only the ungrouped session", got)
+	}
+
+	m.cursor = 0
+	label, names := m.selectedProjectSessions()
+	if label != "frontend" || !slices.Equal(names, []string{"codex-b", "gemini-c"}) {
+		t.Errorf("named group selection = %q %v", label, names)
+	}
+}
+
+func TestToggleMark_GroupHeaderMarksWholeGroup(t *testing.T) {
+	m := namedGroupsModel()
+	m.cursor = 0
+	if !m.toggleMark() {
+		t.Fatal("toggleMark on a header did nothing")
+	}
+	if !m.marked["codex-b"] || !m.marked["gemini-c"] || m.marked["claude-a"] {
+		t.Fatalf("marked = %v, want the frontend group", m.marked)
+	}
+	if rows := m.groupAssignTargets(); len(rows) != 2 {
+		t.Errorf("G targets = %d sessions, want the 2 marked", len(rows))
+	}
+	m.toggleMark()
+	if len(m.marked) != 0 {
+		t.Errorf("second toggle left marks %v", m.marked)
+	}
+}
+
+func TestGroupAssignModel(t *testing.T) {
+	groups := []SessionGroup{{Name: "frontend team"}, {Name: "experiments"}}
+
+	ga := NewGroupAssignModel([]string{"claude-a"}, groups)
+	for _, r := range "exp" {
+		ga, _ = ga.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	if got := ga.matches(); !slices.Equal(got, []string{"experiments"}) {
+		t.Fatalf("matches = %q", got)
+	}
+	ga, _ = ga.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	ga, _ = ga.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if group, ok := ga.Result(); !ga.Done() || !ok || group != "experiments" {
+		t.Errorf("picked %q ok=%v, want experiments", group, ok)
+	}
+
+	ga = NewGroupAssignModel([]string{"claude-a"}, groups)
+	for _, r := range "new" {
+		ga, _ = ga.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	ga, _ = ga.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if group, ok := ga.Result(); !ok || group != "new" {
+		t.Errorf("typed %q ok=%v, want new", group, ok)
+	}
+
+	ga = NewGroupAssignModel([]string{"claude-a"}, groups)
+	ga, _ = ga.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if _, ok := ga.Result(); !ga.Done() || ok {
+		t.Error("esc should cancel")
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HistoryModel is the full-screen session history view (`h` on the session
+// list): every session ever launched, newest first, with a / filter over
+// name, provider, project, branch and exit reason.
+type HistoryModel struct {
+	entries []HistoryEntry // newest first
+	visible []HistoryEntry // entries matching query
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	now     time.Time
+	err     error
+
+	filtering bool
+	input     string
+	query     string
+
+	done bool
+}
+
+// NewHistoryModel creates the view over entries (oldest first, as stored).
+func NewHistoryModel(entries []HistoryEntry, err error, width, height int, now time.Time) HistoryModel {
+	h := HistoryModel{
+		entries: filterHistory(entries, historyFilter{}, 0),
+		width:   width,
+		height:  height,
+		now:     now,
+		err:     err,
+	}
+	h.applyQuery()
+	return h
+}
+
+// Done reports whether the user closed the view.
+func (h HistoryModel) Done() bool { return h.done }
+
+// matchesQuery reports whether e contains every word of query in one of its
+// displayed fields.
+func matchesQuery(e HistoryEntry, query string) bool {
+	hay := strings.ToLower(strings.Join([]string{e.Name, e.Provider, e.Project, e.Branch, e.Persona, historyExit(e)}, " "))
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(hay, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *HistoryModel) applyQuery() {
+	h.visible = h.visible[:0]
+	for _, e := range h.entries {
+		if matchesQuery(e, h.query) {
+			h.visible = append(h.visible, e)
+		}
+	}
+	h.cursor, h.offset = 0, 0
+}
+
+// bodyHeight is the number of table rows visible between the header lines
+// and the detail/footer lines.
+func (h HistoryModel) bodyHeight() int {
+	n := h.height - 6
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func (h *HistoryModel) moveTo(i int) {
+	if i >= len(h.visible) {
+		i = len(h.visible) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	h.cursor = i
+	if h.cursor < h.offset {
+		h.offset = h.cursor
+	}
+	if h.cursor >= h.offset+h.bodyHeight() {
+		h.offset = h.cursor - h.bodyHeight() + 1
+	}
+}
+
+// Update handles navigation and the filter prompt.
+func (h HistoryModel) Update(msg tea.Msg) (HistoryModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h.width, h.height = msg.Width, msg.Height
+		h.moveTo(h.cursor)
+	case tea.KeyPressMsg:
+		if h.filtering {
+			switch msg.String() {
+			case "esc":
+				h.filtering = false
+				h.input = ""
+			case "enter":
+				h.filtering = false
+				h.query = h.input
+				h.applyQuery()
+			case "backspace":
+				if r := []rune(h.input); len(r) > 0 {
+					h.input = string(r[:len(r)-1])
+				}
+			default:
+				h.input += msg.Text
+			}
+			return h, nil
+		}
+		page := h.bodyHeight()
+		switch msg.String() {
+		case "esc", "q", "h":
+			if h.query != "" && msg.String() == "esc" {
+				h.query = ""
+				h.applyQuery()
+				return h, nil
+			}
+			h.done = true
+		case "up", "k":
+			h.moveTo(h.cursor - 1)
+		case "down", "j":
+			h.moveTo(h.cursor + 1)
+		case "pgup", "ctrl+b":
+			h.moveTo(h.cursor - page)
+		case "pgdown", "ctrl+f":
+			h.moveTo(h.cursor + page)
+		case "g", "home":
+			h.moveTo(0)
+		case "G", "end":
+			h.moveTo(len(h.visible) - 1)
+		case "/":
+			h.filtering = true
+			h.input = h.query
+		}
+	}
+	return h, nil
+}
+
+// View renders the table, a detail line for the selected entry and the
+// footer.
+func (h HistoryModel) View() string {
+	width := h.width
+	if width < 20 {
+		width = 80
+	}
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("Session history")
+	status := fmt.Sprintf("%d sessions", len(h.visible))
+	if h.query != "" {
+		status = fmt.Sprintf("/%s: %d of %d", h.query, len(h.visible), len(h.entries))
+	}
+	b.WriteString(title + "  " + helpStyle.Render(status) + "\n")
+	header := fmt.Sprintf("  %-16s %-24s %-8s %-24s %-8s %-9s %s", "STARTED", "NAME", "PROVIDER", "BRANCH", "DURATION", "EXIT", "RECOV")
+	b.WriteString(dim.MaxWidth(width).Render(header) + "\n")
+
+	body := h.bodyHeight()
+	switch {
+	case h.err != nil:
+		b.WriteString(statusError.Render("read history: "+h.err.Error()) + "\n")
+		body--
+	case len(h.visible) == 0:
+		b.WriteString(dim.Render("(no sessions)") + "\n")
+		body--
+	}
+	for i := 0; i < body; i++ {
+		idx := h.offset + i
+		if idx < len(h.visible) {
+			e := h.visible[idx]
+			line := fmt.Sprintf("%-16s %-24s %-8s %-24s %-8s %-9s %d",
+				e.CreatedAt.Local().Format("2006-01-02 15:04"), truncate(e.Name, 24), truncate(e.Provider, 8),
+				truncate(e.Branch, 24), formatSessionDuration(e.Duration(h.now)), historyExit(e), e.RecoveryAttempts)
+			if idx == h.cursor {
+				b.WriteString(selectedStyle.MaxWidth(width).Render("> " + line))
+			} else {
+				b.WriteString(lipgloss.NewStyle().MaxWidth(width).Render("  " + line))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if h.cursor < len(h.visible) {
+		b.WriteString(dim.MaxWidth(width).Render(historyDetail(h.visible[h.cursor])))
+	}
+	b.WriteString("\n")
+	if h.filtering {
+		b.WriteString(lipgloss.NewStyle().Foreground(accentColor).Render("/") + h.input + "█")
+	} else {
+		b.WriteString(helpStyle.Render("j/k: move  pgup/pgdn: page  g/G: first/last  /: filter  esc: back"))
+	}
+	return b.String()
+}
+
+// historyDetail summarises the fields that don't fit in the table.
+func historyDetail(e HistoryEntry) string {
+	var parts []string
+	if e.Project != "" {
+		parts = append(parts, "project "+e.Project)
+	}
+	if e.Persona != "" {
+		parts = append(parts, "persona "+e.Persona)
+	}
+	dir := e.WorkingDir
+	if e.WorktreePath != "" {
+		dir = e.WorktreePath + " (worktree)"
+	}
+	if dir != "" {
+		parts = append(parts, dir)
+	}
+	if !e.Open() {
+		parts = append(parts, "ended "+e.EndedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return "  " + strings.Join(parts, " · ")
+}
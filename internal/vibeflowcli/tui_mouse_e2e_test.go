@@ -19,6 +19,8 @@ package vibeflowcli
 import (
 	"strings"
 	"testing"
+
+	tea "charm.land/bubbletea/v2"
 )
 
 // TestView_HitmapOffsetMatchesRender drives the real View() render and checks
@@ -70,3 +72,56 @@ func TestView_HitmapOffsetMatchesRender(t *testing.T) {
 			m.hitmap.contentTop+span0.startY, updated.(Model).cursor)
 	}
 }
+
+// TestView_HelpBarHintClickRunsAction clicks the "?" hint in the rendered help
+// bar and checks that it opens the help view, as pressing the key would.
+func TestView_HelpBarHintClickRunsAction(t *testing.T) {
+	m := Model{
+		config:   &Config{},
+		hitmap:   &listHitmap{},
+		width:    200,
+		height:   30,
+		sessions: []SessionRow{{Name: "alpha"}},
+	}
+	lines := strings.Split(m.View().Content, "\n")
+	if m.hitmap.helpY >= len(lines) || !strings.Contains(lines[m.hitmap.helpY], "help") {
+		t.Fatalf("hitmap.helpY=%d does not point at the help bar", m.hitmap.helpY)
+	}
+	var help *hintSpan
+	for i := range m.hitmap.hints {
+		if m.hitmap.hints[i].action == actHelp {
+			help = &m.hitmap.hints[i]
+		}
+	}
+	if help == nil {
+		t.Fatalf("no help hint in hitmap; hints=%+v", m.hitmap.hints)
+	}
+	updated, _ := m.handleMouse(tea.MouseClickMsg{X: help.x0, Y: m.hitmap.helpY, Button: tea.MouseLeft})
+	if got := updated.(Model).activeView; got != ViewHelp {
+		t.Fatalf("clicking the help hint: activeView = %v, want ViewHelp", got)
+	}
+}
+
+// TestHandleMouse_WheelOverOutputScrolls checks that the wheel over the detail
+// panel scrolls the captured output instead of moving the list cursor.
+func TestHandleMouse_WheelOverOutputScrolls(t *testing.T) {
+	m := Model{
+		config:        &Config{},
+		hitmap:        &listHitmap{leftWidth: 40},
+		sessions:      []SessionRow{{Name: "alpha"}, {Name: "beta"}},
+		captureName:   "alpha",
+		captureOutput: strings.Repeat("line\n", 10),
+	}
+	updated, _ := m.handleMouse(tea.MouseWheelMsg{X: 60, Button: tea.MouseWheelUp})
+	got := updated.(Model)
+	if v := got.outputViews["alpha"]; v.scroll != outputScrollStep || v.pinned == "" {
+		t.Fatalf("wheel up over output: view=%+v", v)
+	}
+	if got.cursor != 0 {
+		t.Fatalf("wheel over output moved the cursor to %d", got.cursor)
+	}
+	updated, _ = got.handleMouse(tea.MouseWheelMsg{X: 60, Button: tea.MouseWheelDown})
+	if v, ok := updated.(Model).outputViews["alpha"]; ok {
+		t.Fatalf("wheel down back to the tail: view=%+v, want following", v)
+	}
+}
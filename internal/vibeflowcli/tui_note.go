@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NoteEditModel edits the note of one session (`c` on the session list).
+// The input starts with the current note; an empty note removes it.
+type NoteEditModel struct {
+	session string // short session name
+	input   string
+	done    bool
+	ok      bool // false when cancelled
+}
+
+// NewNoteEditModel creates the note editor for session, holding note.
+func NewNoteEditModel(session, note string) NoteEditModel {
+	return NoteEditModel{session: session, input: note}
+}
+
+// Done reports whether the editor should close.
+func (ne NoteEditModel) Done() bool { return ne.done }
+
+// Result returns the edited note and whether the user confirmed.
+func (ne NoteEditModel) Result() (note string, ok bool) {
+	return strings.Join(strings.Fields(ne.input), " "), ne.ok
+}
+
+// add appends s to the input, up to sessionNoteMax characters.
+func (ne *NoteEditModel) add(s string) {
+	for _, r := range s {
+		if utf8.RuneCountInString(ne.input) >= sessionNoteMax {
+			return
+		}
+		ne.input += string(r)
+	}
+}
+
+// Update handles input for the note editor.
+func (ne NoteEditModel) Update(msg tea.Msg) (NoteEditModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.PasteMsg:
+		ne.add(strings.ReplaceAll(msg.Content, "\n", " "))
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc":
+			ne.done = true
+		case "enter":
+			ne.done, ne.ok = true, true
+		case "ctrl+u":
+			ne.input = ""
+		case "backspace":
+			if r := []rune(ne.input); len(r) > 0 {
+				ne.input = string(r[:len(r)-1])
+			}
+		case "space":
+			ne.add(" ")
+		default:
+			ne.add(msg.Text)
+		}
+	}
+	return ne, nil
+}
+
+// View renders the note editor.
+func (ne NoteEditModel) View() string {
+	var b strings.Builder
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	b.WriteString(title.Render("Note for " + ne.session))
+	b.WriteString("\n\n")
+	b.WriteString("> " + ne.input + "█\n")
+	b.WriteString(dim.Render(fmt.Sprintf("  %d/%d", utf8.RuneCountInString(ne.input), sessionNoteMax)))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("enter: save  ctrl+u: clear  empty note: remove  esc: cancel"))
+	return b.String()
+}
+
+// editNote opens the note editor for the selected session.
+func (m Model) editNote() (tea.Model, tea.Cmd) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 || idx >= len(m.sessions) {
+		return m, nil
+	}
+	row := m.sessions[idx]
+	if _, ok := m.storeMetaForRow(row); !ok {
+		m.err = fmt.Errorf("session %q has no stored metadata", row.Name)
+		return m, nil
+	}
+	m.noteEdit = NewNoteEditModel(row.Name, row.Note)
+	m.activeView = ViewNoteEdit
+	return m, nil
+}
+
+// saveNote stores note on the session the editor was opened for.
+func (m Model) saveNote(note string) error {
+	meta, ok := m.storeMetaForRow(SessionRow{Name: m.noteEdit.session})
+	if !ok {
+		return fmt.Errorf("session %q has no stored metadata", m.noteEdit.session)
+	}
+	return m.store.SetNote(meta.Name, note)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestCleanNote(t *testing.T) {
+	got, err := cleanNote("  fixing the\n flaky  login test ")
+	if err != nil || got != "fixing the flaky login test" {
+		t.Errorf("cleanNote = %q, %v", got, err)
+	}
+	if _, err := cleanNote(strings.Repeat("é", sessionNoteMax)); err != nil {
+		t.Errorf("a note of exactly %d characters was refused: %v", sessionNoteMax, err)
+	}
+	if _, err := cleanNote(strings.Repeat("x", sessionNoteMax+1)); err == nil {
+		t.Error("an over-long note was accepted")
+	}
+}
+
+func TestStore_SetNote(t *testing.T) {
+	store := testStore(t)
+	if err := store.Add(SessionMeta{Name: "web", TmuxSession: "vibeflow_web"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetNote("web", "chasing the CSS regression"); err != nil {
+		t.Fatal(err)
+	}
+	if meta, _, _ := store.Get("web"); meta.Note != "chasing the CSS regression" {
+		t.Errorf("note = %q", meta.Note)
+	}
+	if err := store.SetNote("nope", "x"); err == nil {
+		t.Error("SetNote on a missing session succeeded")
+	}
+}
+
+func TestNoteEdit_SavesOnEnter(t *testing.T) {
+	m := bulkTestModel(t)
+	m.sessions[0].Note = "old"
+	nm, _ := m.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	m = nm.(Model)
+	if m.activeView != ViewNoteEdit {
+		t.Fatalf("c should open the note editor, view = %v", m.activeView)
+	}
+	if !strings.Contains(m.View().Content, "> old") {
+		t.Error("the editor should start with the current note")
+	}
+	m.noteEdit, _ = m.noteEdit.Update(tea.KeyPressMsg{Code: 'u', Mod: tea.ModCtrl})
+	m.noteEdit, _ = m.noteEdit.Update(tea.PasteMsg{Content: "review\nthe  PR"})
+	nm, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = nm.(Model)
+	if m.activeView != ViewSessions {
+		t.Fatalf("enter should close the editor, view = %v", m.activeView)
+	}
+	if meta, _, _ := m.store.Get("claude-a"); meta.Note != "review the PR" {
+		t.Errorf("stored note = %q, want %q", meta.Note, "review the PR")
+	}
+}
+
+func TestNoteEdit_EscCancels(t *testing.T) {
+	m := bulkTestModel(t)
+	m.noteEdit = NewNoteEditModel("claude-a", "")
+	m.activeView = ViewNoteEdit
+	m.noteEdit, _ = m.noteEdit.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	nm, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = nm.(Model)
+	if m.activeView != ViewSessions {
+		t.Fatalf("esc should close the editor, view = %v", m.activeView)
+	}
+	if meta, _, _ := m.store.Get("claude-a"); meta.Note != "" {
+		t.Errorf("a cancelled edit was saved: %q", meta.Note)
+	}
+}
+
+func TestNoteEdit_LimitsLength(t *testing.T) {
+	ne := NewNoteEditModel("a", "")
+	ne, _ = ne.Update(tea.PasteMsg{Content: strings.Repeat("x", sessionNoteMax+50)})
+	if note, _ := ne.Result(); len(note) != sessionNoteMax {
+		t.Errorf("note length = %d, want %d", len(note), sessionNoteMax)
+	}
+}
+
+func TestRenderSessionRow_ShowsNote(t *testing.T) {
+	m := bulkTestModel(t)
+	s := SessionRow{Name: "claude-a", Note: "waiting on design review"}
+	if sessionRowHeight(s) != 2 {
+		t.Error("a note should give the row a subtitle line")
+	}
+	var b strings.Builder
+	m.renderSessionRow(&b, s, 0, 1, 80, "")
+	if !strings.Contains(b.String(), "✎ waiting on design review") {
+		t.Errorf("row should show the note:\n%s", b.String())
+	}
+}
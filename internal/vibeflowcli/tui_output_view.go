@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// outputScrollStep is how many lines one wheel notch scrolls the output.
+const outputScrollStep = 3
+
+// outputPageStep is how many lines the scroll keys move the output.
+const outputPageStep = 10
+
+// outputView is a session's place in the detail panel's output.
+type outputView struct {
+	scroll int    // lines scrolled back from the end of the shown output
+	pinned string // output frozen when the view stopped following
+}
+
+// outputViewSet holds the output views by session name. Sessions without
+// one follow the live pane.
+type outputViewSet map[string]outputView
+
+// shownOutput returns the output the detail panel shows for name and which
+// of its lines changed since the previous capture (nil when pinned or
+// unknown).
+func (m Model) shownOutput(name string) (output string, changed []bool) {
+	if v, ok := m.outputViews[name]; ok && v.pinned != "" {
+		return v.pinned, nil
+	}
+	if m.captureName == name {
+		return m.captureOutput, m.captureChanged
+	}
+	return "", nil
+}
+
+// scrollOutput moves the selected session's output delta lines back
+// (negative: forward), within the shown lines. Scrolling back stops
+// following, so the lines stay put while the agent keeps writing;
+// scrolling forward to the end follows again. Each session keeps its own
+// position.
+func (m *Model) scrollOutput(delta int) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 || idx >= len(m.sessions) {
+		return
+	}
+	name := m.sessions[idx].Name
+	output, _ := m.shownOutput(name)
+	if output == "" {
+		return
+	}
+	v := m.outputViews[name]
+	v.scroll = min(max(v.scroll+delta, 0), strings.Count(output, "\n"))
+	if v.scroll == 0 && delta < 0 {
+		delete(m.outputViews, name)
+		return
+	}
+	if v.scroll > 0 && v.pinned == "" {
+		v.pinned = output
+	}
+	if v.pinned == "" {
+		return
+	}
+	if m.outputViews == nil {
+		m.outputViews = make(outputViewSet)
+	}
+	m.outputViews[name] = v
+}
+
+// toggleFollow pins the selected session's output where it is, or follows
+// the live pane again.
+func (m Model) toggleFollow() (tea.Model, tea.Cmd) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 || idx >= len(m.sessions) {
+		return m, nil
+	}
+	name := m.sessions[idx].Name
+	if _, ok := m.outputViews[name]; ok {
+		delete(m.outputViews, name)
+		return m, nil
+	}
+	if output, _ := m.shownOutput(name); output != "" {
+		if m.outputViews == nil {
+			m.outputViews = make(outputViewSet)
+		}
+		m.outputViews[name] = outputView{pinned: output}
+	}
+	return m, nil
+}
+
+// outputViewTitle is the note after the output heading for a pinned view.
+func (m Model) outputViewTitle(name string) string {
+	v, ok := m.outputViews[name]
+	if !ok {
+		return ""
+	}
+	title := "  pinned"
+	if v.scroll > 0 {
+		title += fmt.Sprintf(" ↑%d", v.scroll)
+	}
+	if key := m.keys.label(actFollowOutput); key != "" {
+		title += " (" + key + ": follow)"
+	}
+	return title
+}
+
+// pruneOutputViews forgets the views of sessions that are gone.
+func (m *Model) pruneOutputViews() {
+	if len(m.outputViews) == 0 {
+		return
+	}
+	live := make(map[string]bool, len(m.sessions))
+	for _, s := range m.sessions {
+		live[s.Name] = true
+	}
+	for name := range m.outputViews {
+		if !live[name] {
+			delete(m.outputViews, name)
+		}
+	}
+}
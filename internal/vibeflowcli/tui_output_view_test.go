@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+)
+
+func outputViewModel() Model {
+	return Model{
+		config:        &Config{},
+		keys:          defaultKeymap(),
+		sessions:      []SessionRow{{Name: "alpha"}, {Name: "beta"}},
+		captureName:   "alpha",
+		captureOutput: strings.Repeat("line\n", 20),
+	}
+}
+
+func TestScrollOutput_RemembersPositionPerSession(t *testing.T) {
+	m := outputViewModel()
+	m.scrollOutput(outputPageStep)
+	if v := m.outputViews["alpha"]; v.scroll != outputPageStep || v.pinned == "" {
+		t.Fatalf("alpha after scrolling: %+v", v)
+	}
+
+	// Select beta; its capture arrives and it starts at the tail.
+	m.cursor = 1
+	updated, _ := m.Update(captureMsg{name: "beta", output: "b1\nb2\n"})
+	m = updated.(Model)
+	if _, ok := m.outputViews["beta"]; ok {
+		t.Fatalf("beta should follow the tail, got %+v", m.outputViews["beta"])
+	}
+
+	// Back on alpha, the earlier position is still there.
+	m.cursor = 0
+	if v := m.outputViews["alpha"]; v.scroll != outputPageStep {
+		t.Fatalf("alpha scroll = %d, want %d", v.scroll, outputPageStep)
+	}
+	if out, _ := m.shownOutput("alpha"); out != strings.Repeat("line\n", 20) {
+		t.Fatalf("alpha shows %q, want its pinned output", out)
+	}
+}
+
+func TestScrollOutput_PinnedOutputIgnoresNewCaptures(t *testing.T) {
+	m := outputViewModel()
+	m.scrollOutput(outputScrollStep)
+	updated, _ := m.Update(captureMsg{name: "alpha", output: "new\n"})
+	m = updated.(Model)
+	if out, changed := m.shownOutput("alpha"); out != strings.Repeat("line\n", 20) || changed != nil {
+		t.Fatalf("pinned output replaced by capture: %q %v", out, changed)
+	}
+
+	// Scrolling forward to the end follows the live pane again.
+	m.scrollOutput(-outputPageStep)
+	if _, ok := m.outputViews["alpha"]; ok {
+		t.Fatal("scrolling to the end should resume following")
+	}
+	if out, _ := m.shownOutput("alpha"); out != "new\n" {
+		t.Fatalf("following shows %q, want the latest capture", out)
+	}
+}
+
+func TestScrollOutput_ClampsToOutput(t *testing.T) {
+	m := outputViewModel()
+	m.scrollOutput(100)
+	if v := m.outputViews["alpha"]; v.scroll != 20 {
+		t.Fatalf("scroll = %d, want clamped to 20", v.scroll)
+	}
+
+	m.captureOutput = ""
+	m.cursor = 1
+	m.scrollOutput(outputScrollStep)
+	if _, ok := m.outputViews["beta"]; ok {
+		t.Fatal("scrolling with no output should not create a view")
+	}
+}
+
+func TestToggleFollow(t *testing.T) {
+	m := outputViewModel()
+	updated, _ := m.toggleFollow()
+	m = updated.(Model)
+	v, ok := m.outputViews["alpha"]
+	if !ok || v.scroll != 0 || v.pinned == "" {
+		t.Fatalf("toggle from following should pin at the tail, got %+v", v)
+	}
+	if title := m.outputViewTitle("alpha"); !strings.Contains(title, "pinned") || !strings.Contains(title, "f: follow") {
+		t.Fatalf("title = %q", title)
+	}
+
+	m.scrollOutput(outputScrollStep)
+	if title := m.outputViewTitle("alpha"); !strings.Contains(title, "↑3") {
+		t.Fatalf("title = %q, want the scroll offset", title)
+	}
+
+	updated, _ = m.toggleFollow()
+	m = updated.(Model)
+	if _, ok := m.outputViews["alpha"]; ok {
+		t.Fatal("second toggle should follow again")
+	}
+	if title := m.outputViewTitle("alpha"); title != "" {
+		t.Fatalf("following title = %q, want empty", title)
+	}
+}
+
+func TestPruneOutputViews(t *testing.T) {
+	m := outputViewModel()
+	m.outputViews = outputViewSet{
+		"alpha": {scroll: 1, pinned: "a\n"},
+		"gone":  {scroll: 2, pinned: "g\n"},
+	}
+	m.pruneOutputViews()
+	if _, ok := m.outputViews["gone"]; ok {
+		t.Fatal("view of a removed session was kept")
+	}
+	if _, ok := m.outputViews["alpha"]; !ok {
+		t.Fatal("view of a live session was dropped")
+	}
+}
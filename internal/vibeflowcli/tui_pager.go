@@ -0,0 +1,362 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pagerRefreshInterval is how often the viewer re-captures the pane while
+// open. Faster than captureTickCmd because the viewer is the thing the user
+// is actively watching.
+const pagerRefreshInterval = time.Second
+
+// pagerContentMsg carries a fresh scrollback capture for the viewer.
+type pagerContentMsg struct {
+	name   string
+	output string
+	err    error
+}
+
+// pagerTickMsg triggers a periodic re-capture while the viewer is open.
+type pagerTickMsg struct{}
+
+// PagerModel is a full-screen, read-only viewer over a session's complete
+// scrollback (`o` on the session list). It supports scrolling, incremental
+// search (/ then n/N) and a follow mode that keeps the view pinned to the
+// bottom as new output arrives — a way to read agent output without
+// attaching and risking stray keystrokes into the agent.
+type PagerModel struct {
+	name    string
+	capture func() (string, error)
+	lines   []string
+	err     error
+	offset  int // index of the first visible line
+	width   int
+	height  int
+	follow  bool
+
+	searching bool   // true while the / prompt is open
+	input     string // text typed at the / prompt
+	query     string // committed search query
+	matches   []int  // line indices containing query
+	matchIdx  int    // index into matches of the current hit
+
+	done bool
+}
+
+// NewPagerModel creates a viewer for the named session. capture fetches the
+// full scrollback (normally TmuxManager.CaptureScrollback); it is injected so
+// the model can be exercised without a tmux server. The viewer starts in
+// follow mode, showing the most recent output.
+func NewPagerModel(name string, capture func() (string, error), width, height int) PagerModel {
+	return PagerModel{
+		name:    name,
+		capture: capture,
+		width:   width,
+		height:  height,
+		follow:  true,
+	}
+}
+
+// Init kicks off the first capture and the refresh ticker.
+func (p PagerModel) Init() tea.Cmd {
+	return tea.Batch(p.captureCmd(), pagerTickCmd())
+}
+
+// Done reports whether the user closed the viewer.
+func (p PagerModel) Done() bool { return p.done }
+
+func pagerTickCmd() tea.Cmd {
+	return tea.Tick(pagerRefreshInterval, func(time.Time) tea.Msg { return pagerTickMsg{} })
+}
+
+func (p PagerModel) captureCmd() tea.Cmd {
+	name, capture := p.name, p.capture
+	return func() tea.Msg {
+		if capture == nil {
+			return pagerContentMsg{name: name}
+		}
+		out, err := capture()
+		return pagerContentMsg{name: name, output: out, err: err}
+	}
+}
+
+// bodyHeight is the number of output lines visible between the header and
+// footer.
+func (p PagerModel) bodyHeight() int {
+	h := p.height - 2
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+func (p PagerModel) maxOffset() int {
+	n := len(p.lines) - p.bodyHeight()
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func (p *PagerModel) scrollTo(offset int) {
+	if offset > p.maxOffset() {
+		offset = p.maxOffset()
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	p.offset = offset
+}
+
+// setContent replaces the captured lines, keeping the view pinned to the
+// bottom in follow mode and otherwise preserving the scroll position.
+func (p *PagerModel) setContent(output string) {
+	if output == "" {
+		p.lines = nil
+	} else {
+		p.lines = strings.Split(output, "\n")
+	}
+	if p.query != "" {
+		p.matches = findMatches(p.lines, p.query)
+		if p.matchIdx >= len(p.matches) {
+			p.matchIdx = 0
+		}
+	}
+	if p.follow {
+		p.offset = p.maxOffset()
+	} else {
+		p.scrollTo(p.offset)
+	}
+}
+
+// findMatches returns the indices of lines containing query, case-insensitively.
+func findMatches(lines []string, query string) []int {
+	q := strings.ToLower(query)
+	var out []int
+	for i, l := range lines {
+		if strings.Contains(strings.ToLower(l), q) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// jumpToMatch centres the current match in the viewport and leaves follow
+// mode so the next refresh doesn't yank the view away from it.
+func (p *PagerModel) jumpToMatch() {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.follow = false
+	p.scrollTo(p.matches[p.matchIdx] - p.bodyHeight()/2)
+}
+
+// Update handles viewer input and refresh messages.
+func (p PagerModel) Update(msg tea.Msg) (PagerModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+		if p.follow {
+			p.offset = p.maxOffset()
+		} else {
+			p.scrollTo(p.offset)
+		}
+		return p, nil
+	case pagerTickMsg:
+		if p.done {
+			return p, nil
+		}
+		return p, tea.Batch(p.captureCmd(), pagerTickCmd())
+	case pagerContentMsg:
+		if msg.name != p.name {
+			return p, nil
+		}
+		p.err = msg.err
+		if msg.err == nil {
+			p.setContent(msg.output)
+		}
+		return p, nil
+	case tea.KeyPressMsg:
+		if p.searching {
+			return p.updateSearchInput(msg), nil
+		}
+		page := p.bodyHeight()
+		switch msg.String() {
+		case "esc", "q", "o":
+			p.done = true
+		case "up", "k":
+			p.follow = false
+			p.scrollTo(p.offset - 1)
+		case "down", "j":
+			p.scrollTo(p.offset + 1)
+		case "pgup", "ctrl+b":
+			p.follow = false
+			p.scrollTo(p.offset - page)
+		case "pgdown", "ctrl+f", "space":
+			p.scrollTo(p.offset + page)
+		case "ctrl+u":
+			p.follow = false
+			p.scrollTo(p.offset - page/2)
+		case "ctrl+d":
+			p.scrollTo(p.offset + page/2)
+		case "g", "home":
+			p.follow = false
+			p.scrollTo(0)
+		case "G", "end":
+			p.follow = true
+			p.scrollTo(p.maxOffset())
+		case "f":
+			p.follow = !p.follow
+			if p.follow {
+				p.scrollTo(p.maxOffset())
+			}
+		case "/":
+			p.searching = true
+			p.input = ""
+		case "n":
+			if len(p.matches) > 0 {
+				p.matchIdx = (p.matchIdx + 1) % len(p.matches)
+				p.jumpToMatch()
+			}
+		case "N":
+			if len(p.matches) > 0 {
+				p.matchIdx = (p.matchIdx - 1 + len(p.matches)) % len(p.matches)
+				p.jumpToMatch()
+			}
+		}
+	}
+	return p, nil
+}
+
+// updateSearchInput edits the / prompt. Enter commits the query and jumps to
+// the first match at or below the current viewport top; esc abandons it.
+func (p PagerModel) updateSearchInput(msg tea.KeyPressMsg) PagerModel {
+	switch msg.String() {
+	case "esc":
+		p.searching = false
+		p.input = ""
+	case "enter":
+		p.searching = false
+		p.query = p.input
+		p.matches = nil
+		p.matchIdx = 0
+		if p.query == "" {
+			return p
+		}
+		p.matches = findMatches(p.lines, p.query)
+		for i, line := range p.matches {
+			if line >= p.offset {
+				p.matchIdx = i
+				break
+			}
+		}
+		p.jumpToMatch()
+	case "backspace":
+		if len(p.input) > 0 {
+			r := []rune(p.input)
+			p.input = string(r[:len(r)-1])
+		}
+	default:
+		if msg.Text != "" {
+			p.input += msg.Text
+		}
+	}
+	return p
+}
+
+// View renders the viewer: a header with position/mode, the visible slice of
+// scrollback (matching lines highlighted), and a footer with either the
+// search prompt or the key help.
+func (p PagerModel) View() string {
+	width := p.width
+	if width < 20 {
+		width = 80
+	}
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("Output: " + p.name)
+	var status []string
+	if p.follow {
+		status = append(status, lipgloss.NewStyle().Foreground(oceanSuccess).Render("FOLLOW"))
+	}
+	if len(p.lines) > 0 {
+		last := p.offset + p.bodyHeight()
+		if last > len(p.lines) {
+			last = len(p.lines)
+		}
+		status = append(status, fmt.Sprintf("%d-%d/%d", p.offset+1, last, len(p.lines)))
+	}
+	if p.query != "" {
+		if len(p.matches) == 0 {
+			status = append(status, fmt.Sprintf("/%s: no matches", p.query))
+		} else {
+			status = append(status, fmt.Sprintf("/%s: %d/%d", p.query, p.matchIdx+1, len(p.matches)))
+		}
+	}
+	b.WriteString(title + "  " + helpStyle.Render(strings.Join(status, "  ")) + "\n")
+
+	matchSet := make(map[int]bool, len(p.matches))
+	for _, i := range p.matches {
+		matchSet[i] = true
+	}
+	current := -1
+	if len(p.matches) > 0 {
+		current = p.matches[p.matchIdx]
+	}
+	lineStyle := lipgloss.NewStyle().MaxWidth(width)
+	hitStyle := lineStyle.Foreground(warningColor)
+
+	body := p.bodyHeight()
+	switch {
+	case p.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(errorColor).Render("capture failed: "+p.err.Error()) + "\n")
+		body--
+	case len(p.lines) == 0:
+		b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render("(no output)") + "\n")
+		body--
+	}
+	for i := 0; i < body; i++ {
+		idx := p.offset + i
+		if p.err == nil && idx < len(p.lines) {
+			line := p.lines[idx]
+			switch {
+			case idx == current:
+				b.WriteString(selectedStyle.MaxWidth(width).Render(line))
+			case matchSet[idx]:
+				b.WriteString(hitStyle.Render(line))
+			default:
+				b.WriteString(lineStyle.Render(line))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if p.searching {
+		b.WriteString(lipgloss.NewStyle().Foreground(accentColor).Render("/") + p.input + "█")
+	} else {
+		b.WriteString(helpStyle.Render("j/k: scroll  pgup/pgdn: page  g/G: top/bottom  f: follow  /: search  n/N: next/prev  esc: back"))
+	}
+	return b.String()
+}
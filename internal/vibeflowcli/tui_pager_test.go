@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func numberedOutput(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func loadedPager(t *testing.T, output string) PagerModel {
+	t.Helper()
+	p := NewPagerModel("claude-a", nil, 80, 12) // body height 10
+	p, _ = p.Update(pagerContentMsg{name: "claude-a", output: output})
+	return p
+}
+
+func pagerKey(p PagerModel, keys ...string) PagerModel {
+	for _, k := range keys {
+		var msg tea.KeyPressMsg
+		switch k {
+		case "esc":
+			msg = tea.KeyPressMsg{Code: tea.KeyEscape}
+		case "enter":
+			msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+		default:
+			msg = tea.KeyPressMsg{Code: rune(k[0]), Text: k}
+		}
+		p, _ = p.Update(msg)
+	}
+	return p
+}
+
+func TestPager_FollowPinsToBottom(t *testing.T) {
+	p := loadedPager(t, numberedOutput(50))
+	if p.offset != 40 {
+		t.Fatalf("offset = %d, want 40 (bottom)", p.offset)
+	}
+	p, _ = p.Update(pagerContentMsg{name: "claude-a", output: numberedOutput(60)})
+	if p.offset != 50 {
+		t.Errorf("follow mode should track new output, offset = %d, want 50", p.offset)
+	}
+}
+
+func TestPager_ScrollUpLeavesFollow(t *testing.T) {
+	p := loadedPager(t, numberedOutput(50))
+	p = pagerKey(p, "k")
+	if p.follow || p.offset != 39 {
+		t.Fatalf("after k: follow=%v offset=%d, want false/39", p.follow, p.offset)
+	}
+	p, _ = p.Update(pagerContentMsg{name: "claude-a", output: numberedOutput(60)})
+	if p.offset != 39 {
+		t.Errorf("refresh must not move a scrolled view, offset = %d", p.offset)
+	}
+	p = pagerKey(p, "G")
+	if !p.follow || p.offset != 50 {
+		t.Errorf("G should re-enter follow at bottom: follow=%v offset=%d", p.follow, p.offset)
+	}
+}
+
+func TestPager_SearchJumpsAndCycles(t *testing.T) {
+	p := loadedPager(t, "alpha\nERROR one\nbeta\n"+numberedOutput(20)+"\nerror two")
+	p = pagerKey(p, "g", "/", "e", "r", "r", "o", "r", "enter")
+	if p.query != "error" || len(p.matches) != 2 {
+		t.Fatalf("query=%q matches=%v", p.query, p.matches)
+	}
+	if p.matches[p.matchIdx] != 1 {
+		t.Errorf("first hit should be line 1, got %d", p.matches[p.matchIdx])
+	}
+	p = pagerKey(p, "n")
+	if p.matches[p.matchIdx] != 23 {
+		t.Errorf("n should move to line 23, got %d", p.matches[p.matchIdx])
+	}
+	p = pagerKey(p, "n")
+	if p.matchIdx != 0 {
+		t.Errorf("n should wrap to the first match, got idx %d", p.matchIdx)
+	}
+	if p.follow {
+		t.Error("jumping to a match should leave follow mode")
+	}
+}
+
+func TestPager_EscClosesAndIgnoresOtherSessions(t *testing.T) {
+	p := loadedPager(t, "x")
+	p, _ = p.Update(pagerContentMsg{name: "other", output: "y"})
+	if len(p.lines) != 1 || p.lines[0] != "x" {
+		t.Errorf("capture for another session must be ignored, lines=%v", p.lines)
+	}
+	p = pagerKey(p, "/", "esc")
+	if p.Done() || p.searching {
+		t.Fatalf("esc in search should only close the prompt")
+	}
+	p = pagerKey(p, "esc")
+	if !p.Done() {
+		t.Error("esc should close the viewer")
+	}
+}
@@ -0,0 +1,361 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pendingWorkInterval is how often the TUI polls the server's pending work.
+const pendingWorkInterval = time.Minute
+
+// pendingWorkTickMsg triggers a pending-work poll.
+type pendingWorkTickMsg struct{}
+
+// pendingWorkMsg carries a poll of every project's pending work.
+type pendingWorkMsg struct {
+	items []pendingItem
+}
+
+// pendingItem is a work item with why it is listed.
+type pendingItem struct {
+	Stuck bool // in progress too long, rather than ready to start
+	Item  WorkItem
+}
+
+// label is the item's kind as the header and drill-down show it.
+func (p pendingItem) label() string {
+	state := "ready"
+	if p.Stuck {
+		state = "stuck"
+	}
+	return state + " " + p.Item.Type
+}
+
+func pendingWorkTickCmd() tea.Cmd {
+	return tea.Tick(pendingWorkInterval, func(time.Time) tea.Msg {
+		return pendingWorkTickMsg{}
+	})
+}
+
+// pendingProjects are the projects whose work is summarised: the
+// configured default and those of vibeflow sessions in the store.
+func (m Model) pendingProjects() []int64 {
+	seen := make(map[int64]bool)
+	var ids []int64
+	add := func(id int64) {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	add(m.projectID)
+	if m.store != nil {
+		if metas, err := m.store.List(); err == nil {
+			for _, meta := range metas {
+				add(meta.ProjectID)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// fetchPendingWork polls the projects' pending work off the UI goroutine.
+// It returns nil when there is no server to ask.
+func (m Model) fetchPendingWork() tea.Cmd {
+	if m.client == nil || m.serverDown() {
+		return nil
+	}
+	projects := m.pendingProjects()
+	if len(projects) == 0 {
+		return nil
+	}
+	client, logger := m.client, m.logger
+	return func() tea.Msg {
+		results := make(map[int64]*PollResult, len(projects))
+		for _, id := range projects {
+			r, err := client.PollPendingWork(id)
+			if err != nil {
+				logger.Debug("pending work for project %d: %v", id, err)
+				continue
+			}
+			results[id] = r
+		}
+		return pendingWorkMsg{items: pendingItems(projects, results)}
+	}
+}
+
+// pendingItems flattens poll results into the drill-down's order: stuck
+// before ready, issues before todos, then by priority and ID.
+func pendingItems(projects []int64, results map[int64]*PollResult) []pendingItem {
+	var items []pendingItem
+	for _, id := range projects {
+		r := results[id]
+		if r == nil {
+			continue
+		}
+		for _, group := range []struct {
+			stuck bool
+			items []WorkItem
+		}{
+			{true, r.StuckIssues}, {true, r.StuckTodos},
+			{false, r.ReadyIssues}, {false, r.ReadyTodos},
+		} {
+			for _, it := range group.items {
+				items = append(items, pendingItem{Stuck: group.stuck, Item: it})
+			}
+		}
+	}
+	rank := func(p pendingItem) int {
+		r := 0
+		if !p.Stuck {
+			r += 2
+		}
+		if p.Item.Type == "todo" {
+			r++
+		}
+		return r
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if ri, rj := rank(items[i]), rank(items[j]); ri != rj {
+			return ri < rj
+		}
+		if pi, pj := priorityRank(items[i].Item.Priority), priorityRank(items[j].Item.Priority); pi != pj {
+			return pi < pj
+		}
+		return items[i].Item.ID < items[j].Item.ID
+	})
+	return items
+}
+
+// pendingWorkBadge is the header's summary, e.g. "2 stuck todos · 5 ready
+// issues", or "" before the first poll and when there is nothing pending.
+func (m Model) pendingWorkBadge() string {
+	var stuck, ready int
+	for _, p := range m.pending {
+		switch {
+		case p.Stuck && p.Item.Type == "todo":
+			stuck++
+		case !p.Stuck && p.Item.Type == "issue":
+			ready++
+		}
+	}
+	var parts []string
+	if stuck > 0 {
+		parts = append(parts, statusWaiting.Render(plural(stuck, "stuck todo")))
+	}
+	if ready > 0 {
+		parts = append(parts, statusRunning.Render(plural(ready, "ready issue")))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	badge := strings.Join(parts, helpStyle.Render(" · "))
+	if key := m.keys.label(actPendingWork); key != "" {
+		badge += helpStyle.Render(" (" + key + ")")
+	}
+	return badge
+}
+
+// plural formats n with noun, adding an s unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// openPendingWork shows the drill-down, targeting the selected session.
+func (m Model) openPendingWork() (tea.Model, tea.Cmd) {
+	targets := make([]string, 0, len(m.sessions))
+	target := 0
+	selected := ""
+	if idx := m.selectedSessionIdx(); idx >= 0 {
+		selected = m.sessions[idx].Name
+	}
+	for _, s := range m.sessions {
+		if s.Name == selected {
+			target = len(targets)
+		}
+		targets = append(targets, s.Name)
+	}
+	m.pendingView = NewPendingWorkModel(m.pending, targets, target, m.width, m.height)
+	m.activeView = ViewPendingWork
+	return m, m.fetchPendingWork()
+}
+
+// dispatchPending types a work item's prompt into session, as the
+// auto-dispatcher would.
+func (m Model) dispatchPending(session string, it WorkItem) (tea.Model, tea.Cmd) {
+	if m.readOnly {
+		return m.refuseReadOnly(actPendingWork)
+	}
+	if err := m.tmux.SendKeys(session, dispatchPrompt(it)); err != nil {
+		m.err = fmt.Errorf("dispatch %s #%d to %s: %w", it.Type, it.ID, session, err)
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	m.logger.Info("dispatched %s #%d to %s", it.Type, it.ID, session)
+	m.dispatcher.Record(dispatchAssignment{Session: session, Item: it}, time.Now())
+	return m, m.fetchPendingWork()
+}
+
+// PendingWorkModel is the pending-work drill-down (`p` on the session
+// list): the polled work items and the session a chosen one is sent to.
+type PendingWorkModel struct {
+	items   []pendingItem
+	targets []string // session short names
+	target  int
+	cursor  int
+	offset  int
+	width   int
+	height  int
+
+	done     bool
+	dispatch bool
+}
+
+// NewPendingWorkModel creates the view; target indexes targets.
+func NewPendingWorkModel(items []pendingItem, targets []string, target, width, height int) PendingWorkModel {
+	return PendingWorkModel{items: items, targets: targets, target: target, width: width, height: height}
+}
+
+// Done reports whether the view was closed.
+func (p PendingWorkModel) Done() bool { return p.done }
+
+// Dispatch returns the item to send and its session, when the view was
+// closed by choosing one.
+func (p PendingWorkModel) Dispatch() (session string, it WorkItem, ok bool) {
+	if !p.dispatch || p.cursor >= len(p.items) || p.target >= len(p.targets) {
+		return "", WorkItem{}, false
+	}
+	return p.targets[p.target], p.items[p.cursor].Item, true
+}
+
+// SetItems replaces the items after a poll, keeping the cursor in range.
+func (p *PendingWorkModel) SetItems(items []pendingItem) {
+	p.items = items
+	p.moveTo(p.cursor)
+}
+
+// bodyHeight is the number of item rows visible.
+func (p PendingWorkModel) bodyHeight() int {
+	return max(p.height-6, 1)
+}
+
+func (p *PendingWorkModel) moveTo(i int) {
+	i = min(i, len(p.items)-1)
+	i = max(i, 0)
+	p.cursor = i
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+p.bodyHeight() {
+		p.offset = p.cursor - p.bodyHeight() + 1
+	}
+}
+
+// Update handles navigation, target cycling and dispatch.
+func (p PendingWorkModel) Update(msg tea.Msg) (PendingWorkModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+		p.moveTo(p.cursor)
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc", "q", "p":
+			p.done = true
+		case "up", "k":
+			p.moveTo(p.cursor - 1)
+		case "down", "j":
+			p.moveTo(p.cursor + 1)
+		case "g", "home":
+			p.moveTo(0)
+		case "G", "end":
+			p.moveTo(len(p.items) - 1)
+		case "tab", "right", "l":
+			if n := len(p.targets); n > 0 {
+				p.target = (p.target + 1) % n
+			}
+		case "shift+tab", "left", "h":
+			if n := len(p.targets); n > 0 {
+				p.target = (p.target + n - 1) % n
+			}
+		case "enter", "d":
+			if len(p.items) > 0 && len(p.targets) > 0 {
+				p.dispatch, p.done = true, true
+			}
+		}
+	}
+	return p, nil
+}
+
+// View renders the target line, the item table and the footer.
+func (p PendingWorkModel) View() string {
+	width := p.width
+	if width < 20 {
+		width = 80
+	}
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("Pending work")
+	b.WriteString(title + "  " + helpStyle.Render(plural(len(p.items), "item")) + "\n")
+	target := dim.Render("(no sessions)")
+	if p.target < len(p.targets) {
+		target = lipgloss.NewStyle().Foreground(accentColor).Render(p.targets[p.target])
+	}
+	b.WriteString("Dispatch to: " + target + "\n")
+	header := fmt.Sprintf("  %-12s %-7s %-8s %-12s %s", "KIND", "ID", "PRIORITY", "STATUS", "TITLE")
+	b.WriteString(dim.MaxWidth(width).Render(header) + "\n")
+
+	body := p.bodyHeight()
+	if len(p.items) == 0 {
+		b.WriteString(dim.Render("(nothing stuck or ready)") + "\n")
+		body--
+	}
+	for i := 0; i < body; i++ {
+		idx := p.offset + i
+		if idx < len(p.items) {
+			it := p.items[idx]
+			title := it.Item.Title
+			if it.Item.FeatureName != "" {
+				title += " [" + it.Item.FeatureName + "]"
+			}
+			line := fmt.Sprintf("%-12s %-7s %-8s %-12s %s", it.label(), fmt.Sprintf("#%d", it.Item.ID),
+				truncate(orDash(it.Item.Priority), 8), truncate(orDash(it.Item.Status), 12), title)
+			switch {
+			case idx == p.cursor:
+				b.WriteString(selectedStyle.MaxWidth(width).Render("> " + line))
+			case it.Stuck:
+				b.WriteString(statusWaiting.MaxWidth(width).Render("  " + line))
+			default:
+				b.WriteString(lipgloss.NewStyle().MaxWidth(width).Render("  " + line))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("j/k: move  tab: next session  enter: dispatch to session  esc: back"))
+	return b.String()
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestFetchPendingWork_OrdersAndSummarises(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/vibeflow/projects/4/poll" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(PollResult{
+			StuckTodos:  []WorkItem{{Type: "todo", ID: 9, Title: "Half-done migration"}},
+			ReadyTodos:  []WorkItem{{Type: "todo", ID: 3}},
+			ReadyIssues: []WorkItem{{Type: "issue", ID: 5, Priority: "low"}, {Type: "issue", ID: 7, Priority: "high"}},
+		})
+	}))
+	defer srv.Close()
+
+	m := Model{config: &Config{}, client: NewClient(srv.URL, ""), projectID: 4, logger: &Logger{}}
+	if m.pendingWorkBadge() != "" {
+		t.Error("badge shown before the first poll")
+	}
+	cmd := m.fetchPendingWork()
+	if cmd == nil {
+		t.Fatal("no poll with a client and a project")
+	}
+	nm, _ := m.Update(cmd())
+	m = nm.(Model)
+
+	var order []string
+	for _, p := range m.pending {
+		order = append(order, fmt.Sprintf("%s #%d", p.label(), p.Item.ID))
+	}
+	want := "stuck todo #9,ready issue #7,ready issue #5,ready todo #3"
+	if got := strings.Join(order, ","); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+	if got := stripANSI(m.pendingWorkBadge()); got != "1 stuck todo · 2 ready issues (p)" {
+		t.Errorf("badge = %q", got)
+	}
+
+	if (Model{config: &Config{}, logger: &Logger{}, projectID: 4}).fetchPendingWork() != nil {
+		t.Error("polled without a server")
+	}
+}
+
+func TestPendingWorkModel_TargetAndDispatch(t *testing.T) {
+	items := []pendingItem{{Item: WorkItem{Type: "issue", ID: 1}}, {Item: WorkItem{Type: "issue", ID: 2}}}
+	p := NewPendingWorkModel(items, []string{"a", "b", "c"}, 2, 100, 30)
+	press := func(key string, code rune) {
+		p, _ = p.Update(tea.KeyPressMsg{Code: code, Text: key})
+	}
+	press("j", 'j')
+	press("", tea.KeyTab) // wraps to the first session
+	if !strings.Contains(p.View(), "Dispatch to: a") {
+		t.Errorf("view does not target a:\n%s", p.View())
+	}
+	press("", tea.KeyEnter)
+	session, it, ok := p.Dispatch()
+	if !p.Done() || !ok || session != "a" || it.ID != 2 {
+		t.Errorf("dispatch = %q #%d %v, want a #2", session, it.ID, ok)
+	}
+
+	empty := NewPendingWorkModel(nil, []string{"a"}, 0, 100, 30)
+	empty, _ = empty.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if _, _, ok := empty.Dispatch(); ok || empty.Done() {
+		t.Error("enter with nothing listed dispatched or closed the view")
+	}
+}
+
+func TestDispatchPending_TypesPrompt_RealTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-pending")
+	_, _ = tm.run("kill-server")
+	defer func() { _, _ = tm.run("kill-server") }()
+	if _, err := tm.run("new-session", "-d", "-s", "vibeflow_claude-dev", "cat"); err != nil {
+		t.Skipf("cannot create tmux session: %v", err)
+	}
+	d := NewAutoDispatcher(AutoDispatchConfig{Enabled: true}, NewClient("http://x", ""), &Logger{})
+	m := Model{config: &Config{}, tmux: tm, logger: &Logger{}, dispatcher: d}
+
+	item := WorkItem{Type: "issue", ID: 12, Title: "Fix login"}
+	nm, _ := m.dispatchPending("claude-dev", item)
+	if m = nm.(Model); m.err != nil {
+		t.Fatal(m.err)
+	}
+	waitForPane(t, tm, "claude-dev", "Pick up issue #12: Fix login")
+	if d.CurrentWork("claude-dev") != "issue #12: Fix login" {
+		t.Errorf("dispatcher did not record the manual dispatch: %q", d.CurrentWork("claude-dev"))
+	}
+
+	m.readOnly = true
+	nm, _ = m.dispatchPending("claude-dev", item)
+	if m = nm.(Model); m.err == nil || !strings.Contains(m.err.Error(), "read-only") {
+		t.Errorf("read-only dispatch: err = %v", m.err)
+	}
+}
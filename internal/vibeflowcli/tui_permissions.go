@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// answerPermissions answers the permission prompts of waiting sessions from
+// the permissions allow and deny lists; waiting maps each waiting session to
+// its pane output. Attached sessions are left alone: the user is there to
+// answer them.
+func (m Model) answerPermissions(waiting map[string]string) tea.Cmd {
+	if m.permissions == nil || m.tmux == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, s := range m.sessions {
+		output, ok := waiting[s.Name]
+		if !ok {
+			m.permissions.Clear(s.Name)
+			continue
+		}
+		if s.TmuxAttached {
+			continue
+		}
+		req, decision, ok := m.permissions.Handle(s.Name, output)
+		if !ok {
+			continue
+		}
+		m.logger.Info("permissions: %s asks to run %q: %s", s.Name, req.Command, decision)
+		if decision == permissionAsk {
+			continue
+		}
+		name, keys, tmux, logger := s.Name, req.keys(decision), m.tmux, m.logger
+		cmds = append(cmds, func() tea.Msg {
+			if err := tmux.SendKeyNames(name, keys...); err != nil {
+				logger.Warn("permissions: answer %s: %v", name, err)
+			}
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// plainView renders the session list for plain mode (--plain): one line per
+// row with no banner, borders or color, so screen readers and dumb terminals
+// get text they can follow. The selected row starts with ">". errLine and
+// helpBar are the ones the full view would show.
+func (m Model) plainView(errLine, helpBar string) string {
+	mode := "flat"
+	if m.groupMode {
+		mode = "grouped"
+	}
+	header := []string{fmt.Sprintf("vibeflow: %d session(s), %s view", len(m.sessions), mode)}
+	if badge := m.pendingWorkBadge(); badge != "" {
+		header[0] += " · " + stripANSI(badge)
+	}
+	if errLine != "" {
+		header = append(header, strings.Split(stripANSI(errLine), "\n")...)
+	}
+	footer := []string{"", strings.TrimRight(stripANSI(helpBar), " ")}
+
+	rows, cursorRow := m.plainRows()
+	if len(rows) == 0 {
+		empty := "No active sessions."
+		if key := m.keys.label(actNew); key != "" {
+			empty += fmt.Sprintf(" Press %s to create one.", key)
+		}
+		rows = []string{empty}
+	}
+	// Keep the selected row on screen when the list is taller than the
+	// terminal; inline rendering has no scrollback of its own.
+	if avail := m.height - len(header) - len(footer); m.height > 0 && len(rows) > avail && avail > 0 {
+		start := max(cursorRow-avail+1, 0)
+		rows = rows[start : start+avail]
+	}
+
+	lines := append(header, rows...)
+	return strings.Join(append(lines, footer...), "\n")
+}
+
+// plainRows returns one line per list row, in the order and positions
+// m.cursor walks, and the index of the cursor's row.
+func (m Model) plainRows() ([]string, int) {
+	var rows []string
+	cursorRow := 0
+	add := func(pos int, text string) {
+		prefix := "  "
+		if pos == m.cursor {
+			prefix = "> "
+			cursorRow = len(rows)
+		}
+		rows = append(rows, prefix+text)
+	}
+	if !m.groupMode {
+		for i, s := range m.sessions {
+			add(i, m.plainSessionRow(s))
+		}
+		return rows, cursorRow
+	}
+	pos := 0
+	for _, root := range m.groupOrder {
+		indices := m.groupedSessions[root]
+		label := "group " + root
+		if name, ok := namedGroup(root); ok {
+			label = "group " + name
+		}
+		label += fmt.Sprintf(", %d session(s)", len(indices))
+		collapsed := m.collapsedGroups[root]
+		if collapsed {
+			label += ", collapsed"
+		}
+		add(pos, label)
+		pos++
+		if collapsed {
+			continue
+		}
+		for _, idx := range indices {
+			add(pos, "  "+m.plainSessionRow(m.sessions[idx]))
+			pos++
+		}
+	}
+	return rows, cursorRow
+}
+
+// plainSessionRow describes one session as "name: status, provider, ...",
+// spelling out what the full view shows with color and icons.
+func (m Model) plainSessionRow(s SessionRow) string {
+	status := s.Status
+	if status == "" {
+		status = "unknown"
+	}
+	parts := []string{status}
+	if s.Provider != "" {
+		parts = append(parts, s.Provider)
+	}
+	if s.Branch != "" {
+		parts = append(parts, "branch "+s.Branch)
+	}
+	if s.Persona != "" {
+		parts = append(parts, "persona "+s.Persona)
+	}
+	if s.Project != "" {
+		parts = append(parts, "project "+s.Project)
+	}
+	if m.marked[s.Name] {
+		parts = append(parts, "marked")
+	}
+	if s.Recovered {
+		parts = append(parts, "recovered")
+	}
+	if m.healthMonitor != nil {
+		if sh := m.healthMonitor.GetHealth(s.Name); sh != nil {
+			switch sh.Status {
+			case HealthErrorDetected:
+				parts = append(parts, "error detected")
+			case HealthRecovering:
+				recovering := fmt.Sprintf("recovering %d/%d", sh.RecoveryCount, m.healthMonitor.config.MaxRetries)
+				if countdown := m.recoveryCountdown(sh, time.Now()); countdown != "" {
+					recovering += ", " + countdown
+				}
+				parts = append(parts, recovering)
+			case HealthFailed:
+				if sh.Acknowledged {
+					parts = append(parts, "recovery failed (acknowledged)")
+				} else {
+					parts = append(parts, "recovery failed")
+				}
+			}
+			if sh.Snoozed(time.Now()) {
+				parts = append(parts, "recovery snoozed")
+			}
+		}
+		if m.healthMonitor.CoolingDown(s.Provider, time.Now()) {
+			parts = append(parts, s.Provider+" rate-limit cooldown")
+		}
+	}
+	return s.Name + ": " + strings.Join(parts, ", ")
+}
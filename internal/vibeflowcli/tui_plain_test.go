@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestView_PlainMode(t *testing.T) {
+	m := Model{
+		config: &Config{},
+		hitmap: &listHitmap{},
+		width:  100,
+		height: 30,
+		plain:  true,
+		cursor: 1,
+		sessions: []SessionRow{
+			{Name: "alpha", Status: "working", Provider: "claude", Branch: "feat/x"},
+			{Name: "beta", Status: "waiting", Provider: "codex"},
+		},
+	}
+	v := m.View()
+	if v.AltScreen || v.MouseMode != tea.MouseModeNone {
+		t.Errorf("plain view: AltScreen=%v MouseMode=%v, want neither", v.AltScreen, v.MouseMode)
+	}
+	if strings.Contains(v.Content, "\x1b[") {
+		t.Errorf("plain view contains escape sequences:\n%q", v.Content)
+	}
+	for _, border := range []string{"╭", "│", "█"} {
+		if strings.Contains(v.Content, border) {
+			t.Errorf("plain view contains box drawing %q:\n%s", border, v.Content)
+		}
+	}
+	lines := strings.Split(v.Content, "\n")
+	want := []string{
+		"vibeflow: 2 session(s), flat view",
+		"  alpha: working, claude, branch feat/x",
+		"> beta: waiting, codex",
+	}
+	for i, w := range want {
+		if i >= len(lines) || lines[i] != w {
+			t.Fatalf("plain view line %d = %q, want %q\n%s", i, lines[i], w, v.Content)
+		}
+	}
+	if last := lines[len(lines)-1]; !strings.Contains(last, "q: quit") {
+		t.Errorf("plain view should end with the help bar, got %q", last)
+	}
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if got := updated.(Model).View().Content; !strings.Contains(got, "> alpha:") {
+		t.Errorf("k in plain mode did not move the selection:\n%s", got)
+	}
+}
+
+func TestPlainView_GroupedAndScrolled(t *testing.T) {
+	m := Model{
+		config:          &Config{},
+		plain:           true,
+		groupMode:       true,
+		sessions:        []SessionRow{{Name: "a", Status: "idle"}, {Name: "b", Status: "exited"}},
+		groupOrder:      []string{"/repo", "/other"},
+		groupedSessions: map[string][]int{"/repo": {0}, "/other": {1}},
+		collapsedGroups: map[string]bool{"/other": true},
+		cursor:          2,
+	}
+	rows, cursorRow := m.plainRows()
+	want := []string{"  group /repo, 1 session(s)", "    a: idle", "> group /other, 1 session(s), collapsed"}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") || cursorRow != 2 {
+		t.Fatalf("grouped rows = %q (cursor row %d), want %q", rows, cursorRow, want)
+	}
+
+	m = Model{config: &Config{}, plain: true, height: 6, cursor: 5}
+	for _, n := range []string{"s0", "s1", "s2", "s3", "s4", "s5"} {
+		m.sessions = append(m.sessions, SessionRow{Name: n, Status: "idle"})
+	}
+	got := m.plainView("", "help")
+	if !strings.Contains(got, "> s5:") || strings.Contains(got, "s0:") {
+		t.Errorf("a list taller than the terminal should scroll to the cursor:\n%s", got)
+	}
+}
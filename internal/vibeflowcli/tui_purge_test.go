@@ -19,7 +19,9 @@ package vibeflowcli
 import (
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestRefreshSessions_RetainsDeadStoredSessions is the core guarantee after the
@@ -137,3 +139,71 @@ func TestRefreshSessions_DeadRetainedAlongsideLive(t *testing.T) {
 		t.Error("live stored session must remain in the store after refresh")
 	}
 }
+
+// TestRefreshSessions_ReadOnlyLeavesStoreAlone proves a read-only TUI shows a
+// finished agent as done without writing DoneAt or ending its history entry:
+// the TUI that owns the sessions records those.
+func TestRefreshSessions_ReadOnlyLeavesStoreAlone(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxManager("vftest-readonly-refresh")
+	_, _ = tm.run("kill-server")
+	t.Cleanup(func() { _, _ = tm.run("kill-server") })
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+
+	dir := withTempRoot(t)
+	store := NewStore()
+	if err := tm.CreateSessionWithOpts(SessionOpts{
+		Name: "fin", Provider: "codex", WorkDir: dir, Command: "sleep 0.5; exit 0",
+	}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	full := tm.FullSessionName("codex", "fin")
+	if err := store.Add(SessionMeta{Name: "fin", TmuxSession: full, Provider: "codex", WorkingDir: dir, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 250; i++ {
+		out, _ := tm.run("display-message", "-p", "-t", full, "#{pane_dead}:#{pane_dead_status}")
+		if strings.TrimSpace(out) == "1:0" {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	completion, err := newCompletionDetector(AutoPRConfig{}, NewProviderRegistry(DefaultConfig()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		tmux:        tm,
+		store:       store,
+		cache:       NewSessionCacheWithPath(filepath.Join(dir, "cache.json")),
+		logger:      NewLogger(),
+		config:      &Config{},
+		completion:  completion,
+		historySync: &historySync{},
+		readOnly:    true,
+	}
+	sm, ok := m.refreshSessions().(sessionsMsg)
+	if !ok || sm.err != nil {
+		t.Fatalf("refreshSessions = %+v", sm)
+	}
+	if len(sm.sessions) != 1 || !sm.sessions[0].Done {
+		t.Fatalf("rows = %+v, want fin shown as done", sm.sessions)
+	}
+	if meta, _, _ := store.Get("fin"); !meta.DoneAt.IsZero() {
+		t.Errorf("read-only refresh set DoneAt = %v", meta.DoneAt)
+	}
+	entries, err := store.History().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name == "fin" && !e.EndedAt.IsZero() {
+			t.Errorf("read-only refresh ended the history entry: %+v", e)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Quick launch. `N` starts a session straight from config defaults instead of
+// walking the wizard: default provider, the current directory, the default
+// project with the developer persona, skip-permissions, and — when
+// worktree.auto_create is on and the directory is a git repository — a new
+// worktree on a fresh auto-named branch. Anything the defaults can't decide
+// (a missing provider binary or API key) is reported as an error pointing
+// back to the full wizard.
+
+// quickLaunchPersona is the persona used for quick-launched vibeflow sessions.
+const quickLaunchPersona = "developer"
+
+// quickLaunchResult builds the WizardResult a quick launch would submit for
+// dir. now names the branch so repeated quick launches don't collide.
+func quickLaunchResult(cfg *Config, registry *ProviderRegistry, dir string, now time.Time) (WizardResult, error) {
+	key := cfg.DefaultProvider
+	if key == "" {
+		key = "claude"
+	}
+	prov, ok := registry.Get(key)
+	if !ok {
+		return WizardResult{}, fmt.Errorf("quick launch: default provider %q is not configured", key)
+	}
+	if !registry.IsAvailable(key) {
+		return WizardResult{}, fmt.Errorf("quick launch: %s binary %q not found — press n to set its path", key, prov.Binary)
+	}
+	env, missing := ResolveProviderEnvVars(cfg, key)
+	if missing != "" {
+		return WizardResult{}, fmt.Errorf("quick launch: %s needs %s — press n to enter it", key, missing)
+	}
+
+	result := WizardResult{
+		SessionType:     "vanilla",
+		Provider:        prov,
+		ProviderKey:     key,
+		SkipPermissions: true,
+		WorkDir:         dir,
+		EnvVars:         env,
+		WorktreeChoice:  WorktreeCurrent,
+		Branch:          GetGitBranch(dir),
+	}
+	if prov.VibeFlowIntegrated && cfg.DefaultProject != "" && cfg.APIToken != "" {
+		result.SessionType = "vibeflow"
+		result.ProjectName = cfg.DefaultProject
+		result.Persona = quickLaunchPersona
+		result.Personas = []string{quickLaunchPersona}
+		result.LLMGatewayEnabled = cfg.LLMGatewayEnabled
+	}
+	if cfg.Worktree.AutoCreate && result.Branch != "" {
+		result.WorktreeChoice = WorktreeNew
+		result.NewBranch = true
+		result.NewBranchBase = result.Branch
+		result.Branch = "quick-" + now.Format("20060102-150405")
+	}
+	return result, nil
+}
+
+// quickLaunch resolves the current directory and launches a session from
+// config defaults, going through the same conflict checks as the wizard.
+func (m Model) quickLaunch() (Model, tea.Cmd) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return m, func() tea.Msg { return sessionsMsg{err: fmt.Errorf("quick launch: %w", err)} }
+	}
+	result, err := quickLaunchResult(m.config, m.registry, dir, time.Now())
+	if err != nil {
+		return m, func() tea.Msg { return sessionsMsg{err: err} }
+	}
+	m.logger.Info("quick launch: provider=%s type=%s dir=%s branch=%s", result.ProviderKey, result.SessionType, dir, result.Branch)
+	return m.startLaunch(result)
+}
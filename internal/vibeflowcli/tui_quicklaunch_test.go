@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func quickLaunchConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.DefaultProvider = "fake"
+	cfg.Providers = map[string]Provider{
+		"fake": {Name: "Fake", Binary: "/bin/sh", VibeFlowIntegrated: true},
+	}
+	return cfg
+}
+
+func TestQuickLaunchResult_VanillaInCurrentDir(t *testing.T) {
+	cfg := quickLaunchConfig()
+	cfg.Worktree.AutoCreate = false
+	dir := t.TempDir()
+
+	got, err := quickLaunchResult(cfg, NewProviderRegistry(cfg), dir, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SessionType != "vanilla" || got.ProviderKey != "fake" || got.WorkDir != dir {
+		t.Errorf("got type=%q provider=%q dir=%q", got.SessionType, got.ProviderKey, got.WorkDir)
+	}
+	if got.WorktreeChoice != WorktreeCurrent || !got.SkipPermissions {
+		t.Errorf("WorktreeChoice = %v, SkipPermissions = %v; want current dir, skip", got.WorktreeChoice, got.SkipPermissions)
+	}
+}
+
+func TestQuickLaunchResult_VibeflowWithAutoWorktree(t *testing.T) {
+	cfg := quickLaunchConfig()
+	cfg.Worktree.AutoCreate = true
+	cfg.DefaultProject = "demo"
+	cfg.APIToken = "tok"
+	dir := initTestRepo(t)
+	base := GetGitBranch(dir)
+	now := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+
+	got, err := quickLaunchResult(cfg, NewProviderRegistry(cfg), dir, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SessionType != "vibeflow" || got.ProjectName != "demo" || got.Persona != "developer" {
+		t.Errorf("got type=%q project=%q persona=%q", got.SessionType, got.ProjectName, got.Persona)
+	}
+	if got.WorktreeChoice != WorktreeNew || !got.NewBranch || got.WorktreeName != "" {
+		t.Errorf("want a new auto-named worktree on a new branch, got %+v", got)
+	}
+	if got.Branch != "quick-20261015-093000" || got.NewBranchBase != base {
+		t.Errorf("Branch = %q from %q, want quick-20261015-093000 from %q", got.Branch, got.NewBranchBase, base)
+	}
+}
+
+func TestQuickLaunchResult_Errors(t *testing.T) {
+	cfg := quickLaunchConfig()
+	cfg.DefaultProvider = "missing"
+	if _, err := quickLaunchResult(cfg, NewProviderRegistry(cfg), t.TempDir(), time.Now()); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("unknown provider: err = %v", err)
+	}
+
+	cfg = quickLaunchConfig()
+	cfg.Providers["fake"] = Provider{Name: "Fake", Binary: "/nonexistent/fake-agent"}
+	if _, err := quickLaunchResult(cfg, NewProviderRegistry(cfg), t.TempDir(), time.Now()); err == nil || !strings.Contains(err.Error(), "press n") {
+		t.Errorf("missing binary: err = %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// lockTakeoverTimeout is how long a takeover waits for the running
+// instance to quit.
+const lockTakeoverTimeout = 5 * time.Second
+
+// promptInstanceRunning asks a second TUI what to do about the instance
+// holding the lock: "t" to take over, "r" to look on read-only, "q" to
+// quit. Takeover is only offered when the holder's PID is known.
+func promptInstanceRunning(in io.Reader, out io.Writer, pid int) string {
+	reader := bufio.NewReader(in)
+	fmt.Fprintf(out, "%v on this root.\n", &instanceRunningError{pid: pid})
+	if pid > 0 {
+		fmt.Fprintln(out, "  [t] Take over: quit that instance and start here")
+	}
+	fmt.Fprintln(out, "  [r] Read-only: view sessions and attach, change nothing\n  [q] Quit")
+	for {
+		fmt.Fprint(out, "Choice: ")
+		line, err := reader.ReadString('\n')
+		switch choice := strings.ToLower(strings.TrimSpace(line)); {
+		case choice == "t" && pid > 0, choice == "r", choice == "q":
+			return choice
+		}
+		if err != nil {
+			return "q"
+		}
+	}
+}
+
+// readOnlyActions are the session-list actions a read-only TUI allows:
+// everything that looks, nothing that changes sessions or the store.
+var readOnlyActions = map[keyAction]bool{
+	actUp:            true,
+	actDown:          true,
+	actAttach:        true,
+	actObserve:       true,
+	actCopyInfo:      true,
+	actCopyOutput:    true,
+	actOutput:        true,
+	actOpenSplit:     true,
+	actOpenWindow:    true,
+	actToggleGrouped: true,
+	actSummary:       true,
+	actPendingWork:   true, // browsing only; dispatching is refused
+	actHelp:          true,
+	actQuit:          true,
+}
+
+// refuseReadOnly reports that action isn't available in a read-only TUI.
+func (m Model) refuseReadOnly(action keyAction) (tea.Model, tea.Cmd) {
+	m.err = fmt.Errorf("read-only: %s is disabled while another vibeflow owns this root", action)
+	return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+}
+
+// readOnlyBanner explains a read-only TUI.
+func (m Model) readOnlyBanner() string {
+	if !m.readOnly {
+		return ""
+	}
+	if m.lockHolder > 0 {
+		return fmt.Sprintf("Read-only: vibeflow PID %d owns this root — quit it to make changes here", m.lockHolder)
+	}
+	return "Read-only: another vibeflow owns this root — quit it to make changes here"
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestPromptInstanceRunning(t *testing.T) {
+	var out bytes.Buffer
+	if got := promptInstanceRunning(strings.NewReader("x\nt\n"), &out, 4242); got != "t" {
+		t.Errorf("choice = %q, want t", got)
+	}
+	if !strings.Contains(out.String(), "PID: 4242") {
+		t.Errorf("prompt = %q", out.String())
+	}
+
+	// Without a known PID there is nothing to take over.
+	out.Reset()
+	if got := promptInstanceRunning(strings.NewReader("t\nr\n"), &out, 0); got != "r" {
+		t.Errorf("choice = %q, want r", got)
+	}
+	if strings.Contains(out.String(), "Take over") {
+		t.Errorf("takeover offered without a PID: %q", out.String())
+	}
+	if got := promptInstanceRunning(strings.NewReader(""), &out, 1); got != "q" {
+		t.Errorf("EOF choice = %q, want q", got)
+	}
+}
+
+func TestReadOnlyModel_RefusesChanges(t *testing.T) {
+	m := bulkTestModel(t)
+	m.readOnly, m.lockHolder = true, 4242
+
+	nm, _ := m.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	m = nm.(Model)
+	if m.confirmDelete {
+		t.Error("d asked to delete in a read-only TUI")
+	}
+	if m.err == nil || !strings.Contains(m.err.Error(), "read-only") {
+		t.Errorf("err = %v, want a read-only notice", m.err)
+	}
+
+	nm, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m = nm.(Model); m.cursor != 1 {
+		t.Errorf("navigation blocked: cursor = %d", m.cursor)
+	}
+	if !strings.Contains(m.readOnlyBanner(), "4242") {
+		t.Errorf("banner = %q", m.readOnlyBanner())
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// recoveryCountdownMsg redraws the recovery countdowns once a second.
+type recoveryCountdownMsg struct{}
+
+func recoveryCountdownCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return recoveryCountdownMsg{}
+	})
+}
+
+// recoveryBackoff returns how long sh waits before its next recovery
+// attempt, or 0 when it isn't recovering or its backoff has run out.
+func recoveryBackoff(sh *SessionHealth, now time.Time) time.Duration {
+	if sh == nil || sh.Status != HealthRecovering || !now.Before(sh.BackoffUntil) {
+		return 0
+	}
+	return sh.BackoffUntil.Sub(now)
+}
+
+// formatCountdown formats a wait to the second, rounding up so it reaches
+// 0s only when the wait is over: "45s", "1m32s", "1h05m".
+func formatCountdown(d time.Duration) string {
+	secs := int((d + time.Second - 1) / time.Second)
+	switch {
+	case secs < 60:
+		return fmt.Sprintf("%ds", secs)
+	case secs < 3600:
+		return fmt.Sprintf("%dm%02ds", secs/60, secs%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", secs/3600, secs%3600/60)
+	}
+}
+
+// recoveryCountdown is the "next retry in 1m32s" note for a session waiting
+// out a recovery backoff, or "" when it isn't.
+func (m Model) recoveryCountdown(sh *SessionHealth, now time.Time) string {
+	if left := recoveryBackoff(sh, now); left > 0 {
+		return "next retry in " + formatCountdown(left)
+	}
+	return ""
+}
+
+// anyRecoveryBackoff reports whether a listed session is waiting out a
+// recovery backoff.
+func (m Model) anyRecoveryBackoff(now time.Time) bool {
+	if m.healthMonitor == nil {
+		return false
+	}
+	for _, s := range m.sessions {
+		if recoveryBackoff(m.healthMonitor.GetHealth(s.Name), now) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// startRecoveryCountdown starts the once-a-second redraw while a countdown
+// is showing; it returns nil when one is already running or none shows.
+func (m *Model) startRecoveryCountdown() tea.Cmd {
+	if m.recoveryTicking || !m.anyRecoveryBackoff(time.Now()) {
+		return nil
+	}
+	m.recoveryTicking = true
+	return recoveryCountdownCmd()
+}
+
+// recoveryRetriedMsg reports that a retry started by retryRecoveryNow is over.
+type recoveryRetriedMsg struct{}
+
+// retryRecoveryNow makes a recovering session's next recovery attempt
+// without waiting for its backoff. It counts as one of its attempts.
+func (m Model) retryRecoveryNow(row SessionRow) (tea.Model, tea.Cmd) {
+	if m.readOnly {
+		return m.refuseReadOnly(actRestart)
+	}
+	m.logger.Info("health: session %s recovery retried ahead of its backoff", row.Name)
+	return m, func() tea.Msg {
+		m.runRecovery(row.Name, row.Provider)
+		return recoveryRetriedMsg{}
+	}
+}
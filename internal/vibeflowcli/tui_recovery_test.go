@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{400 * time.Millisecond, "1s"},
+		{45 * time.Second, "45s"},
+		{92 * time.Second, "1m32s"},
+		{time.Hour + 5*time.Minute, "1h05m"},
+	}
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+// recoveringTestModel has claude-a recovering, with a hook recovery action
+// that needs no tmux server and a backoff of about 90 seconds.
+func recoveringTestModel(t *testing.T) Model {
+	t.Helper()
+	m := bulkTestModel(t)
+	m.width, m.height = 160, 40
+	m.healthMonitor = testHealthMonitor(t)
+	m.config.ErrorRecovery = m.healthMonitor.config
+	m.healthMonitor.sessions["claude-a"] = &SessionHealth{
+		SessionName:    "claude-a",
+		Status:         HealthRecovering,
+		MatchedPattern: &ErrorPattern{Description: "API overloaded", Action: ActionHook, Hook: "true"},
+		RecoveryCount:  2,
+		LastRecoveryAt: time.Now(),
+		BackoffUntil:   time.Now().Add(90 * time.Second),
+	}
+	return m
+}
+
+func TestRecoveryCountdown_ShownInListAndDetail(t *testing.T) {
+	m := recoveringTestModel(t)
+	view := ansiRe.ReplaceAllString(m.View().Content, "")
+	if !strings.Contains(view, "[recovering 2/10, retry in 1m") {
+		t.Errorf("list badge has no countdown:\n%s", view)
+	}
+	if !strings.Contains(view, "next retry in 1m") || !strings.Contains(view, "'r' to retry now") {
+		t.Errorf("detail panel has no countdown:\n%s", view)
+	}
+
+	m.healthMonitor.sessions["claude-a"].BackoffUntil = time.Now().Add(-time.Second)
+	view = ansiRe.ReplaceAllString(m.View().Content, "")
+	if strings.Contains(view, "retry in") {
+		t.Errorf("countdown shown after the backoff ran out:\n%s", view)
+	}
+}
+
+func TestRecoveryCountdown_TicksWhileBackingOff(t *testing.T) {
+	m := recoveringTestModel(t)
+	nm, cmd := m.Update(captureMsg{})
+	m = nm.(Model)
+	if cmd == nil || !m.recoveryTicking {
+		t.Fatal("a capture with a session in backoff should start the countdown tick")
+	}
+	if _, cmd := m.Update(captureMsg{}); cmd != nil {
+		t.Error("a second tick was started while one is running")
+	}
+
+	m.healthMonitor.sessions["claude-a"].BackoffUntil = time.Time{}
+	nm, cmd = m.Update(recoveryCountdownMsg{})
+	if cmd != nil || nm.(Model).recoveryTicking {
+		t.Error("the tick should stop once no countdown shows")
+	}
+}
+
+func TestRestartKey_RetriesRecoveryNow(t *testing.T) {
+	m := recoveringTestModel(t)
+	m.store.history = NewSessionHistoryWithPath(filepath.Join(t.TempDir(), "state.db"))
+	if err := m.store.History().Start(SessionMeta{Name: "claude-a", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	nm, cmd := m.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	m = nm.(Model)
+	if cmd == nil || m.healthMonitor.GetHealth("claude-a").RecoveryCount != 2 {
+		t.Fatal("the retry should run in the returned command, not in Update")
+	}
+	if _, ok := cmd().(recoveryRetriedMsg); !ok {
+		t.Fatal("the retry should end with a recoveryRetriedMsg")
+	}
+	sh := m.healthMonitor.GetHealth("claude-a")
+	if sh.RecoveryCount != 3 {
+		t.Fatalf("RecoveryCount = %d, want 3 after a forced retry", sh.RecoveryCount)
+	}
+	if !sh.BackoffUntil.After(time.Now().Add(90 * time.Second)) {
+		t.Errorf("backoff after the third attempt = %v, want the next, longer one", time.Until(sh.BackoffUntil))
+	}
+	entries, err := m.store.History().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range entries {
+		if e.Name == "claude-a" {
+			found = true
+			if e.RecoveryAttempts != 1 {
+				t.Errorf("history recovery attempts = %d, want 1", e.RecoveryAttempts)
+			}
+		}
+	}
+	if !found {
+		t.Error("the forced retry was not recorded in the session history")
+	}
+}
+
+func TestRestartKey_ReadOnlyRefusesRetry(t *testing.T) {
+	m := recoveringTestModel(t)
+	m.readOnly = true
+	nm, _ := m.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	m = nm.(Model)
+	if m.err == nil || m.healthMonitor.GetHealth("claude-a").RecoveryCount != 2 {
+		t.Fatalf("read-only retry: err=%v count=%d", m.err, m.healthMonitor.GetHealth("claude-a").RecoveryCount)
+	}
+}
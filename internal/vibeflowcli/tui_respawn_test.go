@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExitStatusLabel(t *testing.T) {
+	for status, want := range map[string]string{
+		"":    "unknown",
+		"0":   "status 0 (exited normally)",
+		"3":   "status 3",
+		"137": "status 137 (killed by signal 9)",
+	} {
+		if got := exitStatusLabel(status); got != want {
+			t.Errorf("exitStatusLabel(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+// TestRespawnSession_RelaunchesExitedAgent runs an agent that exits on its
+// first run and stays up on the second: the row shows the exit status, and
+// a respawn brings the same session back with a new history entry.
+func TestRespawnSession_RelaunchesExitedAgent(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	withTempRoot(t)
+	tm := NewTmuxManager("vftest-respawn-tui")
+	_, _ = tm.run("kill-server")
+	t.Cleanup(func() { _, _ = tm.run("kill-server") })
+	if err := tm.EnsureServer(); err != nil {
+		t.Skipf("cannot start tmux server: %v", err)
+	}
+
+	dir := t.TempDir()
+	store := NewStore()
+	meta := SessionMeta{Name: "flaky", TmuxSession: "vibeflow_flaky", WorkingDir: dir, CreatedAt: time.Now().Add(-time.Minute)}
+	if err := store.Add(meta); err != nil {
+		t.Fatal(err)
+	}
+	// The first run lingers briefly so it outlives the remain-on-exit setup.
+	cmd := "sh -c 'if [ -f ran ]; then sleep 300; else touch ran; sleep 0.3; exit 3; fi'"
+	if err := tm.CreateSession("flaky", dir, cmd); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	m := Model{
+		tmux:   tm,
+		store:  store,
+		cache:  NewSessionCacheWithPath(filepath.Join(dir, "cache.json")),
+		logger: &Logger{},
+		config: &Config{},
+	}
+
+	row := waitForRow(t, m, "flaky", "exited")
+	// tmux only knows the status once it has reaped the child, which can
+	// lag the pane closing (or not happen in some sandboxes).
+	if row.ExitStatus != "3" && row.ExitStatus != "" {
+		t.Errorf("ExitStatus = %q, want 3", row.ExitStatus)
+	}
+
+	msg := m.respawnSession(row)()
+	if sm, ok := msg.(sessionsMsg); !ok || sm.err != nil {
+		t.Fatalf("respawn returned %#v", msg)
+	}
+	waitForRow(t, m, "flaky", "running")
+
+	entries, _ := store.History().List()
+	if len(entries) != 2 || entries[0].ExitReason != ExitExited || !entries[1].Open() {
+		t.Errorf("history = %+v, want the exited run and an open relaunch", entries)
+	}
+}
+
+func waitForRow(t *testing.T, m Model, name, status string) SessionRow {
+	t.Helper()
+	var last []SessionRow
+	for i := 0; i < 100; i++ {
+		if sm, ok := m.refreshSessions().(sessionsMsg); ok {
+			last = sm.sessions
+			for _, r := range sm.sessions {
+				if r.Name == name && r.Status == status {
+					return r
+				}
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("session %s never reached %s; rows = %+v", name, status, last)
+	return SessionRow{}
+}
@@ -18,6 +18,7 @@ package vibeflowcli
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
@@ -32,6 +33,7 @@ type RestartSelectModel struct {
 	cursor   int
 	done     bool
 	skipped  bool
+	restore  bool // tmux server was down at startup (e.g. after a reboot)
 }
 
 // NewRestartSelectModel creates a restart selector for the given dead sessions.
@@ -43,6 +45,18 @@ func NewRestartSelectModel(dead []SessionMeta) RestartSelectModel {
 	}
 }
 
+// NewRestoreSelectModel creates the selector shown when the tmux server was
+// not running at startup, so every cached session is gone — typically after
+// a reboot. All sessions start selected: one enter restores them all.
+func NewRestoreSelectModel(dead []SessionMeta) RestartSelectModel {
+	r := NewRestartSelectModel(dead)
+	r.restore = true
+	for i := range dead {
+		r.selected[i] = true
+	}
+	return r
+}
+
 // restartConfirmMsg signals that the user confirmed their restart selection.
 type restartConfirmMsg struct {
 	sessions []SessionMeta
@@ -105,7 +119,11 @@ func (r RestartSelectModel) View() string {
 	var b strings.Builder
 
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(warningColor)
-	b.WriteString(headerStyle.Render("  Dead sessions detected — restart?"))
+	if r.restore {
+		b.WriteString(headerStyle.Render(fmt.Sprintf("  tmux was not running — restore %d previous session(s)?", len(r.sessions))))
+	} else {
+		b.WriteString(headerStyle.Render("  Dead sessions detected — restart?"))
+	}
 	b.WriteString("\n\n")
 
 	for i, s := range r.sessions {
@@ -142,11 +160,38 @@ func (r RestartSelectModel) View() string {
 		}
 		b.WriteString(line)
 		b.WriteString("\n")
+		if i == r.cursor {
+			b.WriteString(restartDetails(s))
+		}
 	}
 
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("  space: toggle • a: select all • enter: restart selected • esc: skip"))
+	if r.restore {
+		b.WriteString(helpStyle.Render("  enter: restore selected • space: toggle • a: select all • esc: skip"))
+	} else {
+		b.WriteString(helpStyle.Render("  space: toggle • a: select all • enter: restart selected • esc: skip"))
+	}
 	b.WriteString("\n")
 
 	return b.String()
 }
+
+// restartDetails renders the working directory and env var names of the
+// session under the cursor, flagging a directory that no longer exists
+// (common for worktrees under /tmp after a reboot).
+func restartDetails(s SessionMeta) string {
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	warn := lipgloss.NewStyle().Foreground(warningColor)
+	var b strings.Builder
+	if s.WorkingDir != "" {
+		b.WriteString("      " + dim.Render("dir: "+s.WorkingDir))
+		if _, err := os.Stat(s.WorkingDir); err != nil {
+			b.WriteString(warn.Render("  (missing)"))
+		}
+		b.WriteString("\n")
+	}
+	if len(s.EnvVars) > 0 {
+		b.WriteString("      " + dim.Render("env: "+strings.Join(s.EnvVars, ", ")) + "\n")
+	}
+	return b.String()
+}
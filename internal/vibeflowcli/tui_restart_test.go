@@ -207,3 +207,47 @@ func TestRestartSelectModel_View(t *testing.T) {
 		t.Error("view should contain help text")
 	}
 }
+
+func TestRestoreSelectModel_EnterRestoresAll(t *testing.T) {
+	dead := []SessionMeta{
+		{Name: "session-a", Provider: "claude"},
+		{Name: "session-b", Provider: "codex"},
+	}
+	r := NewRestoreSelectModel(dead)
+
+	r, cmd := r.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !r.done || cmd == nil {
+		t.Fatal("enter should confirm the preselected sessions")
+	}
+	confirm, ok := cmd().(restartConfirmMsg)
+	if !ok {
+		t.Fatalf("expected restartConfirmMsg")
+	}
+	if len(confirm.sessions) != 2 {
+		t.Errorf("expected both sessions to be restored, got %d", len(confirm.sessions))
+	}
+}
+
+func TestRestoreSelectModel_View(t *testing.T) {
+	dir := t.TempDir()
+	dead := []SessionMeta{
+		{Name: "session-a", Provider: "claude", WorkingDir: dir, EnvVars: []string{"ANTHROPIC_API_KEY"}},
+		{Name: "session-b", Provider: "codex", WorkingDir: dir + "/gone"},
+	}
+	r := NewRestoreSelectModel(dead)
+
+	view := r.View()
+	for _, want := range []string{"restore 2 previous session(s)", "dir: " + dir, "env: ANTHROPIC_API_KEY", "enter: restore selected"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "(missing)") {
+		t.Error("existing dir flagged as missing")
+	}
+
+	r, _ = r.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if !strings.Contains(r.View(), "(missing)") {
+		t.Error("missing working dir not flagged")
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchContext is how many lines either side of a match the search view
+// shows.
+const searchContext = 2
+
+// searchResultMsg carries a finished fleet-wide search.
+type searchResultMsg struct {
+	results  []searchResult
+	searched int // sessions searched
+	err      error
+}
+
+// SearchModel searches the output of every session for a pattern (`/` on
+// the session list) and lists the sessions that matched, with context.
+type SearchModel struct {
+	search func(pattern string) searchResultMsg
+
+	input     string
+	searching bool
+	ran       bool // a search has finished; the results are showing
+	results   []searchResult
+	searched  int
+	err       error
+	cursor    int // selected result
+	selected  string
+	width     int
+	height    int
+	done      bool
+}
+
+// NewSearchModel creates the view. search is normally a pane scrollback
+// search over the listed sessions; it is injected so the model can be
+// tested without a tmux server.
+func NewSearchModel(search func(string) searchResultMsg, width, height int) SearchModel {
+	return SearchModel{search: search, width: width, height: height}
+}
+
+// Done reports whether the view was closed.
+func (sm SearchModel) Done() bool { return sm.done }
+
+// Selected returns the session chosen with enter, if any.
+func (sm SearchModel) Selected() (string, bool) {
+	return sm.selected, sm.selected != ""
+}
+
+// Update handles the pattern prompt and the result list.
+func (sm SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		sm.width, sm.height = msg.Width, msg.Height
+	case searchResultMsg:
+		sm.searching, sm.ran = false, true
+		sm.results, sm.searched, sm.err = msg.results, msg.searched, msg.err
+		sm.cursor = 0
+	case tea.PasteMsg:
+		if !sm.searching && !sm.ran {
+			sm.input += strings.ReplaceAll(msg.Content, "\n", " ")
+		}
+	case tea.KeyPressMsg:
+		if sm.searching {
+			return sm, nil
+		}
+		if sm.ran {
+			return sm.updateResults(msg)
+		}
+		switch msg.String() {
+		case "esc":
+			sm.done = true
+		case "enter":
+			pattern := sm.input
+			if strings.TrimSpace(pattern) == "" {
+				return sm, nil
+			}
+			sm.searching = true
+			search := sm.search
+			return sm, func() tea.Msg { return search(pattern) }
+		case "backspace":
+			if r := []rune(sm.input); len(r) > 0 {
+				sm.input = string(r[:len(r)-1])
+			}
+		case "space":
+			sm.input += " "
+		default:
+			sm.input += msg.Text
+		}
+	}
+	return sm, nil
+}
+
+// updateResults handles keys while the results are showing.
+func (sm SearchModel) updateResults(msg tea.KeyPressMsg) (SearchModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		sm.done = true
+	case "/":
+		sm.ran = false // edit the pattern and search again
+	case "up", "k":
+		sm.cursor = max(sm.cursor-1, 0)
+	case "down", "j":
+		sm.cursor = max(min(sm.cursor+1, len(sm.results)-1), 0)
+	case "enter":
+		if sm.cursor < len(sm.results) {
+			sm.selected, sm.done = sm.results[sm.cursor].Session, true
+		}
+	}
+	return sm, nil
+}
+
+// View renders the prompt, or the matching sessions with their hunks.
+func (sm SearchModel) View() string {
+	width := sm.width
+	if width < 20 {
+		width = 80
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	var b strings.Builder
+
+	if !sm.ran {
+		b.WriteString(title.Render("Search session output") + "\n\n")
+		b.WriteString(dim.Render("Regular expression, case-insensitive, over each session's recent scrollback.") + "\n\n")
+		if sm.searching {
+			b.WriteString(dim.Render("searching..."))
+			return b.String()
+		}
+		b.WriteString("/ " + sm.input + "█\n\n")
+		b.WriteString(helpStyle.Render("enter: search all sessions  esc: cancel"))
+		return b.String()
+	}
+
+	summary := fmt.Sprintf("%d of %d sessions match", len(sm.results), sm.searched)
+	b.WriteString(title.Render("Search: "+sm.input) + "  " + helpStyle.Render(summary) + "\n")
+	if sm.err != nil {
+		b.WriteString(statusError.MaxWidth(width).Render("  "+sm.err.Error()) + "\n")
+	}
+	b.WriteString("\n")
+
+	lines, selectedAt := sm.resultLines(width)
+	body := max(sm.height-5, 3)
+	offset := 0
+	if selectedAt >= body {
+		offset = selectedAt - body/3
+	}
+	if len(lines) == 0 {
+		b.WriteString(dim.Render("(no matches)") + "\n")
+	}
+	for i := offset; i < len(lines) && i < offset+body; i++ {
+		b.WriteString(lines[i] + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("j/k: move  enter: select session  /: new search  esc: back"))
+	return b.String()
+}
+
+// resultLines renders every result and returns the index of the selected
+// result's heading.
+func (sm SearchModel) resultLines(width int) (lines []string, selectedAt int) {
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	match := lipgloss.NewStyle().Foreground(oceanSuccess)
+	for i, r := range sm.results {
+		heading := fmt.Sprintf("%s (%d)", r.Session, r.matchCount())
+		if i == sm.cursor {
+			selectedAt = len(lines)
+			lines = append(lines, selectedStyle.MaxWidth(width).Render("> "+heading))
+		} else {
+			lines = append(lines, lipgloss.NewStyle().Bold(true).MaxWidth(width).Render("  "+heading))
+		}
+		for j, h := range r.Hunks {
+			if j > 0 {
+				lines = append(lines, dim.Render("    --"))
+			}
+			for k, line := range h.Lines {
+				text := fmt.Sprintf("    %5d  %s", h.Start+k, line)
+				if h.Matches[k] {
+					lines = append(lines, match.MaxWidth(width).Render(text))
+				} else {
+					lines = append(lines, dim.MaxWidth(width).Render(text))
+				}
+			}
+		}
+	}
+	return lines, selectedAt
+}
+
+// openSearch shows the search prompt over the listed sessions.
+func (m Model) openSearch() (tea.Model, tea.Cmd) {
+	names := make([]string, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		names = append(names, s.Name)
+	}
+	tm := m.tmux
+	search := func(pattern string) searchResultMsg {
+		re, err := compileSearchPattern(pattern, false, true)
+		if err != nil {
+			return searchResultMsg{err: err}
+		}
+		results, err := searchSessions(names, paneSearchSource(tm, defaultSearchLines), re, searchContext)
+		return searchResultMsg{results: results, searched: len(names), err: err}
+	}
+	m.search = NewSearchModel(search, m.width, m.height)
+	m.activeView = ViewSearch
+	return m, nil
+}
+
+// selectSession moves the cursor to the named session. In grouped mode a
+// session inside a collapsed group selects the group's header.
+func (m *Model) selectSession(name string) {
+	short := strings.TrimPrefix(name, sessionPrefix)
+	idx := -1
+	for i, s := range m.sessions {
+		if strings.TrimPrefix(s.Name, sessionPrefix) == short {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	if !m.groupMode {
+		m.cursor = idx
+		return
+	}
+	pos := 0
+	for _, root := range m.groupOrder {
+		header := pos
+		pos++
+		for _, i := range m.groupedSessions[root] {
+			if i == idx {
+				if m.collapsedGroups[root] {
+					m.cursor = header
+				} else {
+					m.cursor = pos
+				}
+				return
+			}
+			if !m.collapsedGroups[root] {
+				pos++
+			}
+		}
+	}
+}
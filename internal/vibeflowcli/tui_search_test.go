@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func typeSearch(sm SearchModel, s string) SearchModel {
+	for _, r := range s {
+		sm, _ = sm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return sm
+}
+
+func TestSearchModel_SearchesAndSelectsSession(t *testing.T) {
+	var gotPattern string
+	search := func(pattern string) searchResultMsg {
+		gotPattern = pattern
+		return searchResultMsg{searched: 3, results: []searchResult{
+			{Session: "claude-a", Hunks: []searchHunk{{Start: 7, Lines: []string{"edit payments.go"}, Matches: []bool{true}}}},
+			{Session: "codex-b", Hunks: []searchHunk{{Start: 2, Lines: []string{"payments.go:12: undefined"}, Matches: []bool{true}}}},
+		}}
+	}
+	sm := NewSearchModel(search, 100, 30)
+	sm = typeSearch(sm, "payments")
+	sm, cmd := sm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should start the search")
+	}
+	sm, _ = sm.Update(cmd())
+	if gotPattern != "payments" {
+		t.Errorf("searched %q, want payments", gotPattern)
+	}
+
+	view := ansiRe.ReplaceAllString(sm.View(), "")
+	for _, want := range []string{"2 of 3 sessions match", "> claude-a (1)", "7  edit payments.go", "codex-b (1)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("results view missing %q:\n%s", want, view)
+		}
+	}
+
+	sm, _ = sm.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	sm, _ = sm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if name, ok := sm.Selected(); !sm.Done() || !ok || name != "codex-b" {
+		t.Fatalf("enter on the second result: done=%v selected=%q", sm.Done(), name)
+	}
+}
+
+func TestSearchModel_NewSearchAndCancel(t *testing.T) {
+	sm := NewSearchModel(func(string) searchResultMsg { return searchResultMsg{searched: 1} }, 80, 20)
+	sm, cmd := sm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatal("enter with an empty pattern should not search")
+	}
+	sm = typeSearch(sm, "x")
+	_, cmd = sm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	sm, _ = sm.Update(cmd())
+	if view := ansiRe.ReplaceAllString(sm.View(), ""); !strings.Contains(view, "(no matches)") {
+		t.Errorf("empty results view:\n%s", view)
+	}
+	sm, _ = sm.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	if sm.ran || sm.input != "x" {
+		t.Fatalf("/ should reopen the prompt with the pattern, got ran=%v input=%q", sm.ran, sm.input)
+	}
+	sm, _ = sm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if _, ok := sm.Selected(); !sm.Done() || ok {
+		t.Fatal("esc should close without a selection")
+	}
+}
+
+func TestSelectSession(t *testing.T) {
+	m := Model{sessions: []SessionRow{{Name: "alpha"}, {Name: "beta"}}}
+	m.selectSession("vibeflow_beta")
+	if m.cursor != 1 {
+		t.Fatalf("flat: cursor = %d, want 1", m.cursor)
+	}
+
+	m = namedGroupsModel()
+	m.selectSession("gemini-c")
+	if idx := m.selectedSessionIdx(); idx < 0 || m.sessions[idx].Name != "gemini-c" {
+		t.Fatalf("grouped: selected %d, want gemini-c", idx)
+	}
+	m.selectSession("claude-a")
+	if idx := m.selectedSessionIdx(); idx < 0 || m.sessions[idx].Name != "claude-a" {
+		t.Fatalf("grouped: selected %d, want claude-a", idx)
+	}
+	m.collapsedGroups = map[string]bool{namedGroupKey("frontend"): true}
+	m.selectSession("claude-a")
+	if idx := m.selectedSessionIdx(); idx < 0 || m.sessions[idx].Name != "claude-a" {
+		t.Fatalf("after a collapsed group: selected %d, want claude-a", idx)
+	}
+	m.selectSession("gemini-c")
+	if m.cursor != 0 {
+		t.Fatalf("collapsed group: cursor = %d, want its header", m.cursor)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// serverCheckInterval is how often a reachable server is re-checked.
+	serverCheckInterval = 30 * time.Second
+	// serverRetryMin and serverRetryMax bound the exponential backoff
+	// between checks while the server is unreachable.
+	serverRetryMin = 5 * time.Second
+	serverRetryMax = 5 * time.Minute
+)
+
+// serverCheckTickMsg triggers a server reachability check.
+type serverCheckTickMsg struct{}
+
+// serverReachMsg carries the result of a reachability check. projectID is
+// the default project's ID when it had to be resolved again.
+type serverReachMsg struct {
+	err       error
+	projectID int64
+}
+
+func serverCheckTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return serverCheckTickMsg{} })
+}
+
+// serverDown reports whether the server was unreachable at the last check.
+// API enrichment is skipped meanwhile, so a dead server doesn't stall every
+// refresh on its request timeout.
+func (m Model) serverDown() bool {
+	return m.serverWarning != ""
+}
+
+// firstServerCheck schedules the first background check: soon when the
+// startup check failed, at the normal interval otherwise.
+func (m Model) firstServerCheck() tea.Cmd {
+	if m.config.ServerURL == "" {
+		return nil
+	}
+	if m.serverDown() {
+		return serverCheckTickCmd(serverRetryMin)
+	}
+	return serverCheckTickCmd(serverCheckInterval)
+}
+
+// checkServer checks the server in the background. A default project that
+// couldn't be resolved at startup is resolved once the server answers.
+func (m Model) checkServer() tea.Msg {
+	if err := CheckServerReachable(m.config.ServerURL); err != nil {
+		return serverReachMsg{err: err}
+	}
+	var projectID int64
+	if m.projectID == 0 && m.client != nil && m.config.DefaultProject != "" {
+		projectID = resolveProjectID(m.client, m.config.DefaultProject)
+	}
+	return serverReachMsg{projectID: projectID}
+}
+
+// applyServerReach records a check's result and schedules the next one,
+// backing off exponentially while the server stays unreachable. When it
+// comes back the warning clears and the session list is refreshed with
+// API data again.
+func (m Model) applyServerReach(msg serverReachMsg, now time.Time) (Model, tea.Cmd) {
+	if msg.err != nil {
+		if !m.serverDown() {
+			m.logger.Warn("server %s unreachable: %v", m.config.ServerURL, msg.err)
+		}
+		m.serverWarning = fmt.Sprintf("Server unreachable (%s)", m.config.ServerURL)
+		switch {
+		case m.serverBackoff < serverRetryMin:
+			m.serverBackoff = serverRetryMin
+		case m.serverBackoff*2 > serverRetryMax:
+			m.serverBackoff = serverRetryMax
+		default:
+			m.serverBackoff *= 2
+		}
+		m.serverRetryAt = now.Add(m.serverBackoff)
+		return m, serverCheckTickCmd(m.serverBackoff)
+	}
+	if msg.projectID > 0 {
+		m.projectID = msg.projectID
+	}
+	m.serverBackoff, m.serverRetryAt = 0, time.Time{}
+	if !m.serverDown() {
+		return m, serverCheckTickCmd(serverCheckInterval)
+	}
+	m.serverWarning = ""
+	m.logger.Info("server %s reachable again", m.config.ServerURL)
+	return m, tea.Batch(serverCheckTickCmd(serverCheckInterval), m.refreshSessions)
+}
+
+// serverIndicator is the help bar's live server status, empty when no
+// server is configured.
+func (m Model) serverIndicator(now time.Time) string {
+	if m.config == nil || m.config.ServerURL == "" {
+		return ""
+	}
+	if !m.serverDown() {
+		return statusRunning.Render("●") + helpStyle.Render(" server")
+	}
+	label := " server offline"
+	if wait := m.serverRetryAt.Sub(now).Round(time.Second); wait > 0 {
+		label += fmt.Sprintf(", retry in %s", wait)
+	}
+	return statusError.Render("○") + lipgloss.NewStyle().Foreground(warningColor).Render(label)
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyServerReach_BacksOffAndRecovers(t *testing.T) {
+	m := Model{config: &Config{ServerURL: "http://vf.test"}, logger: &Logger{}}
+	now := time.Now()
+	down := serverReachMsg{err: errors.New("connection refused")}
+
+	var waits []time.Duration
+	for range 9 {
+		m, _ = m.applyServerReach(down, now)
+		waits = append(waits, m.serverBackoff)
+	}
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second, 160 * time.Second, 5 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("backoff = %v, want %v", waits, want)
+		}
+	}
+	if !m.serverDown() || !strings.Contains(m.serverWarning, "http://vf.test") {
+		t.Errorf("serverWarning = %q", m.serverWarning)
+	}
+	if got := stripANSI(m.serverIndicator(now)); !strings.Contains(got, "server offline, retry in 5m0s") {
+		t.Errorf("indicator while down = %q", got)
+	}
+
+	m, cmd := m.applyServerReach(serverReachMsg{projectID: 7}, now)
+	if m.serverDown() || m.serverBackoff != 0 || m.projectID != 7 {
+		t.Errorf("after recovery: warning %q, backoff %v, project %d", m.serverWarning, m.serverBackoff, m.projectID)
+	}
+	if cmd == nil {
+		t.Error("recovery scheduled nothing")
+	}
+	if got := stripANSI(m.serverIndicator(now)); got != "● server" {
+		t.Errorf("indicator while up = %q", got)
+	}
+
+	if (Model{config: &Config{}}).serverIndicator(now) != "" {
+		t.Error("indicator shown without a server configured")
+	}
+}
+
+func TestCheckServer_ResolvesProjectOnceReachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"id": 3, "name": "other"}, {"id": 9, "name": "shop"}]`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &Config{ServerURL: srv.URL, DefaultProject: "shop"}
+	m := Model{config: cfg, client: NewClient(srv.URL, "token")}
+	msg := m.checkServer().(serverReachMsg)
+	if msg.err != nil || msg.projectID != 9 {
+		t.Errorf("checkServer = %+v, want project 9", msg)
+	}
+
+	srv.Close()
+	if msg := m.checkServer().(serverReachMsg); msg.err == nil {
+		t.Error("closed server reported reachable")
+	}
+}
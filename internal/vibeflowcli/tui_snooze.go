@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// toggleRecoverySnooze snoozes error recovery for the selected session for
+// error_recovery.snooze_minutes, or lifts a snooze already in place.
+func (m Model) toggleRecoverySnooze() (tea.Model, tea.Cmd) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 {
+		return m, nil
+	}
+	if m.healthMonitor == nil || !m.config.ErrorRecovery.Enabled {
+		m.err = fmt.Errorf("error recovery is off (error_recovery.enabled); there is nothing to snooze")
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	row := m.sessions[idx]
+	now := time.Now()
+	if sh := m.healthMonitor.GetHealth(row.Name); sh != nil && sh.Snoozed(now) {
+		m.healthMonitor.Snooze(row.Name, row.Provider, time.Time{})
+		return m, nil
+	}
+	m.healthMonitor.Snooze(row.Name, row.Provider, now.Add(m.config.ErrorRecovery.SnoozeDuration()))
+	return m, nil
+}
+
+// recoverySnooze describes a session's snooze for the detail panel, or ""
+// when it has none.
+func (m Model) recoverySnooze(name string) string {
+	if m.healthMonitor == nil {
+		return ""
+	}
+	sh := m.healthMonitor.GetHealth(name)
+	now := time.Now()
+	if sh == nil || !sh.Snoozed(now) {
+		return ""
+	}
+	desc := fmt.Sprintf("snoozed until %s (%s left)", sh.SnoozedUntil.Format("15:04"), formatSessionDuration(sh.SnoozedUntil.Sub(now)))
+	if key := m.keys.label(actSnoozeRecovery); key != "" {
+		desc += ", " + key + " resumes"
+	}
+	return desc
+}
+
+// providerCooldown describes a provider's rate-limit cooldown for the
+// detail panel, or "" when it has none.
+func (m Model) providerCooldown(provider string) string {
+	if m.healthMonitor == nil {
+		return ""
+	}
+	now := time.Now()
+	if !m.healthMonitor.CoolingDown(provider, now) {
+		return ""
+	}
+	until := m.healthMonitor.CooldownUntil(provider)
+	desc := fmt.Sprintf("%s cooling down until %s (%s left)", provider, until.Format("15:04"), formatSessionDuration(until.Sub(now)))
+	if m.config.ErrorRecovery.PauseDispatchOnRateLimit && m.dispatcher != nil {
+		desc += ", dispatch paused"
+	}
+	return desc
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"maps"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// serverStatusCheckInterval is how often the TUI checks whether the
+	// selected session's server status needs fetching; it bounds how long
+	// a newly selected session waits for its details.
+	serverStatusCheckInterval = 2 * time.Second
+	// serverStatusRefresh is how often the selected session's status is
+	// re-fetched while it stays selected.
+	serverStatusRefresh = 15 * time.Second
+)
+
+// serverStatusTickMsg drives the selected-session status fetch.
+type serverStatusTickMsg struct{}
+
+// serverStatusMsg carries a fetched server status for a short session name.
+type serverStatusMsg struct {
+	name   string
+	status *SessionStatus
+	err    error
+}
+
+func serverStatusTickCmd() tea.Cmd {
+	return tea.Tick(serverStatusCheckInterval, func(time.Time) tea.Msg {
+		return serverStatusTickMsg{}
+	})
+}
+
+// selectedServerSession returns the selected row's short name and its
+// VibeFlow session ID, when it has one the server can report on.
+func (m Model) selectedServerSession() (name, sessionID string, ok bool) {
+	idx := m.selectedSessionIdx()
+	if idx < 0 || m.client == nil || m.store == nil {
+		return "", "", false
+	}
+	row := m.sessions[idx]
+	meta, found := m.storeMetaForRow(row)
+	if !found || meta.VibeFlowSessionID == "" {
+		return "", "", false
+	}
+	return row.Name, meta.VibeFlowSessionID, true
+}
+
+// handleServerStatusTick fetches the selected session's status when it was
+// just selected or its last fetch is older than serverStatusRefresh.
+func (m Model) handleServerStatusTick(now time.Time) (Model, tea.Cmd) {
+	next := serverStatusTickCmd()
+	name, sessionID, ok := m.selectedServerSession()
+	if !ok || m.serverDown() || (name == m.statusFetchName && now.Sub(m.statusFetchedAt) < serverStatusRefresh) {
+		return m, next
+	}
+	m.statusFetchName, m.statusFetchedAt = name, now
+	client := m.client
+	fetch := func() tea.Msg {
+		status, err := client.GetSessionStatus(sessionID)
+		return serverStatusMsg{name: name, status: status, err: err}
+	}
+	return m, tea.Batch(next, fetch)
+}
+
+// applyServerStatus records a fetched status. The map is replaced rather
+// than mutated because refreshSessions reads it from its own goroutine.
+func (m Model) applyServerStatus(msg serverStatusMsg) Model {
+	if msg.err != nil {
+		m.logger.Debug("session status for %s: %v", msg.name, msg.err)
+		return m
+	}
+	statuses := make(map[string]SessionStatus, len(m.serverStatus)+1)
+	maps.Copy(statuses, m.serverStatus)
+	statuses[msg.name] = *msg.status
+	m.serverStatus = statuses
+	for i := range m.sessions {
+		if m.sessions[i].Name == msg.name && m.dispatcher.CurrentWork(msg.name) == "" {
+			m.sessions[i].CurrentWork = msg.status.WorkLabel()
+		}
+	}
+	return m
+}
+
+// currentWork is what a row shows as its current work: the item the
+// auto-dispatcher last sent, else what the server last reported.
+func (m Model) currentWork(name string) string {
+	if work := m.dispatcher.CurrentWork(name); work != "" {
+		return work
+	}
+	return m.serverStatus[name].WorkLabel()
+}
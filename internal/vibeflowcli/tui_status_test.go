@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerStatus_FetchesSelectedSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/vibeflow/sessions/session-vf-1/status" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"phase":"reviewing","current_work":{"type":"todo","id":7,"title":"Add tests"},"last_message":"opened PR"}`))
+	}))
+	defer srv.Close()
+
+	st := NewStoreWithPath(filepath.Join(t.TempDir(), "sessions.json"))
+	if err := st.Add(SessionMeta{Name: "claude-a", TmuxSession: sessionPrefix + "claude-a", VibeFlowSessionID: "session-vf-1"}); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		config:   &Config{},
+		client:   NewClient(srv.URL, ""),
+		store:    st,
+		logger:   &Logger{},
+		sessions: []SessionRow{{Name: "claude-a"}},
+	}
+
+	now := time.Now()
+	m, cmd := m.handleServerStatusTick(now)
+	if m.statusFetchName != "claude-a" || cmd == nil {
+		t.Fatalf("first tick did not fetch the selected session (fetch name %q)", m.statusFetchName)
+	}
+	if m2, _ := m.handleServerStatusTick(now.Add(time.Second)); !m2.statusFetchedAt.Equal(now) {
+		t.Error("status re-fetched before serverStatusRefresh elapsed")
+	}
+
+	status, err := m.client.GetSessionStatus("session-vf-1")
+	m = m.applyServerStatus(serverStatusMsg{name: "claude-a", status: status, err: err})
+	if got := m.sessions[0].CurrentWork; got != "todo #7: Add tests" {
+		t.Errorf("CurrentWork = %q, want the server's work item", got)
+	}
+	m.width, m.height = 120, 40
+	panel := m.renderDetailPanel(60, 30)
+	for _, want := range []string{"reviewing", "opened PR"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("detail panel missing %q:\n%s", want, panel)
+		}
+	}
+}
@@ -19,8 +19,10 @@ package vibeflowcli
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 )
@@ -34,6 +36,13 @@ func detailPanelModel(row SessionRow, cfg *Config) Model {
 	}
 }
 
+func TestStripANSI(t *testing.T) {
+	raw := "\x1b[?25l\x1b[1;32mok\x1b[0m \x1b]8;;https://x\x1b\\link\x1b]8;;\x1b\\ \x1b(Bdone\x1b="
+	if got, want := stripANSI(raw), "ok link done"; got != want {
+		t.Errorf("stripANSI = %q, want %q", got, want)
+	}
+}
+
 func TestRenderDetailPanel_GatewayEnv_ClaudeMasksSecrets(t *testing.T) {
 	cfg := &Config{ServerURL: "https://cloud.example.com", APIToken: "secret-jwt-token"}
 	m := detailPanelModel(SessionRow{
@@ -351,3 +360,117 @@ func TestRenderDetailPanel_GatewayDisabled_NoEnvSection(t *testing.T) {
 		t.Errorf("detail panel must not show gateway env vars when gateway is disabled:\n%s", out)
 	}
 }
+
+func TestChangedLines(t *testing.T) {
+	prev := "$ make\nbuilding a\nbuilding b\n"
+	cur := "building a\nbuilding b\nbuilding c\n\ndone"
+	got := changedLines(prev, cur)
+	want := []bool{false, false, true, false, true}
+	if !slices.Equal(got, want) {
+		t.Errorf("changedLines = %v, want %v", got, want)
+	}
+
+	// A line repeated more often than before is new only for the extra copies.
+	got = changedLines("retrying\n", "retrying\nretrying")
+	if !slices.Equal(got, []bool{false, true}) {
+		t.Errorf("repeated line = %v", got)
+	}
+}
+
+func TestUpdate_CaptureMsg_TracksChangedLines(t *testing.T) {
+	m := Model{logger: &Logger{}}
+	nm, _ := m.Update(captureMsg{name: "a", output: "one\ntwo"})
+	m = nm.(Model)
+	if m.captureChanged != nil {
+		t.Fatalf("first capture has nothing to compare, got %v", m.captureChanged)
+	}
+	nm, _ = m.Update(captureMsg{name: "a", output: "two\nthree"})
+	m = nm.(Model)
+	if !slices.Equal(m.captureChanged, []bool{false, true}) {
+		t.Errorf("captureChanged = %v, want [false true]", m.captureChanged)
+	}
+	nm, _ = m.Update(captureMsg{name: "b", output: "other"})
+	if nm.(Model).captureChanged != nil {
+		t.Error("switching sessions must reset the comparison")
+	}
+}
+
+func TestHealthKeys_AcknowledgeAndSnooze(t *testing.T) {
+	m := bulkTestModel(t)
+	m.healthMonitor = testHealthMonitor(t)
+	m.config.ErrorRecovery = m.healthMonitor.config
+	m.healthMonitor.CheckOutput("claude-a", "claude", "panic: runtime error", false)
+
+	press := func(key rune) {
+		t.Helper()
+		nm, _ := m.Update(tea.KeyPressMsg{Code: key, Text: string(key)})
+		m = nm.(Model)
+	}
+	press('a')
+	if m.err != nil || !m.healthMonitor.GetHealth("claude-a").Acknowledged {
+		t.Fatalf("a on a failed session: err %v, want it acknowledged", m.err)
+	}
+	if list := m.renderSessionList(60, 10); strings.Contains(list, "[FAILED]") || !strings.Contains(list, "[failed]") {
+		t.Errorf("acknowledged session still shows the alert badge:\n%s", list)
+	}
+
+	press('j')
+	press('z')
+	if sh := m.healthMonitor.GetHealth("claude-b"); sh == nil || sh.SnoozedUntil.IsZero() {
+		t.Fatal("z did not snooze the selected session")
+	}
+	if got := m.recoverySnooze("claude-b"); !strings.Contains(got, "z resumes") {
+		t.Errorf("detail = %q", got)
+	}
+	press('z')
+	if m.recoverySnooze("claude-b") != "" {
+		t.Error("second z did not lift the snooze")
+	}
+}
+
+func TestHistorySync_WritesOnlyOnChange(t *testing.T) {
+	h := &historySync{}
+	now := time.Now()
+	if !h.reconcileDue([]string{"b", "a"}, now) {
+		t.Error("first refresh should reconcile")
+	}
+	if h.reconcileDue([]string{"a", "b"}, now.Add(time.Second)) {
+		t.Error("reconciled again with the same stored sessions")
+	}
+	if !h.reconcileDue([]string{"a"}, now.Add(2*time.Second)) {
+		t.Error("a removed session should trigger a reconcile")
+	}
+	if !h.reconcileDue([]string{"a"}, now.Add(2*time.Second+historyReconcileInterval)) {
+		t.Error("no reconcile after historyReconcileInterval")
+	}
+
+	if got := h.newlyExited([]string{"a"}); len(got) != 1 || got[0] != "a" {
+		t.Errorf("first exit = %q", got)
+	}
+	if got := h.newlyExited([]string{"a"}); len(got) != 0 {
+		t.Errorf("exit recorded again: %q", got)
+	}
+	h.newlyExited(nil) // respawned
+	if got := h.newlyExited([]string{"a"}); len(got) != 1 {
+		t.Errorf("exit after a respawn = %q", got)
+	}
+}
+
+func TestMarkAttachedCmd_WritesWhenRun(t *testing.T) {
+	m := bulkTestModel(t)
+	cmd := m.markAttachedCmd("claude-a")
+	if cmd == nil {
+		t.Fatal("markAttachedCmd = nil with a store")
+	}
+	if meta, _, _ := m.store.Get("claude-a"); !meta.LastAttachedAt.IsZero() {
+		t.Fatal("attach recorded before the command ran")
+	}
+	cmd()
+	if meta, _, _ := m.store.Get("claude-a"); meta.LastAttachedAt.IsZero() {
+		t.Error("attach not recorded by the command")
+	}
+	m.readOnly = true
+	if m.markAttachedCmd("claude-a") != nil {
+		t.Error("read-only instance records attaches")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// enforceTimeouts applies the timeouts policy after each activity sample:
+// it records the countdown shown in the detail panel, notifies once a
+// session is within the warning window, and kills or pauses the sessions
+// past their limit. Attached sessions are left alone — someone is using
+// them — as are exited, paused and pending ones, and ones whose kill or
+// pause is still running.
+func (m *Model) enforceTimeouts() tea.Cmd {
+	m.timeouts = make(map[string]string)
+	pol := m.config.Timeouts
+	if m.store == nil || m.tmux == nil || pol.Validate() != nil {
+		return nil
+	}
+	metas, err := m.store.List()
+	if err != nil {
+		return nil
+	}
+	byTmux := make(map[string]SessionMeta, len(metas))
+	for _, meta := range metas {
+		byTmux[meta.TmuxSession] = meta
+	}
+	now := time.Now()
+	var due []SessionMeta
+	for _, s := range m.sessions {
+		meta, ok := byTmux[sessionPrefix+s.Name]
+		var left time.Duration
+		var reason string
+		if ok && !agentInactive(s) && !s.TmuxAttached && !m.timingOut[s.Name] {
+			left, reason, ok = timeoutLeft(meta, pol, m.activity.IdleSince(s.Name), now)
+		} else {
+			ok = false
+		}
+		if !ok {
+			m.notifier.Set(s.Name, NotifyTimeoutSoon, false, "")
+			continue
+		}
+		m.timeouts[s.Name] = describeTimeout(left, reason, pol.pauses())
+		m.notifier.Set(s.Name, NotifyTimeoutSoon, left <= pol.warnBefore(), m.timeouts[s.Name])
+		if left <= 0 {
+			m.logger.Info("session %s timed out (%s)", s.Name, reason)
+			due = append(due, meta)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	if m.timingOut == nil {
+		m.timingOut = make(map[string]bool)
+	}
+	names := make([]string, len(due))
+	for i, meta := range due {
+		names[i] = strings.TrimPrefix(meta.TmuxSession, sessionPrefix)
+		m.timingOut[names[i]] = true
+	}
+	mm := *m
+	return func() tea.Msg {
+		for _, meta := range due {
+			mm.timeOutSession(meta, pol.pauses())
+		}
+		return timedOutMsg{names: names, refresh: mm.refreshSessions()}
+	}
+}
+
+// timedOutMsg is sent once enforceTimeouts has killed or paused names,
+// carrying the session refresh that shows it.
+type timedOutMsg struct {
+	names   []string
+	refresh tea.Msg
+}
+
+// timeOutSession kills a timed-out session (into the trash, so `u` brings
+// it back) or, with pause, ends its agent process but keeps the pane,
+// worktree and store entry so `r` relaunches it in place.
+func (m Model) timeOutSession(meta SessionMeta, pause bool) {
+	if !pause {
+		m.killSessionWithReason(strings.TrimPrefix(meta.TmuxSession, sessionPrefix), ExitTimedOut)
+		return
+	}
+	if err := m.tmux.SignalSession(meta.TmuxSession, "TERM"); err != nil {
+		m.logger.Error("pause session %s: %v", meta.Name, err)
+		return
+	}
+	if err := m.store.SetPaused(meta.Name, time.Now()); err != nil {
+		m.logger.Warn("pause session %s: %v", meta.Name, err)
+	}
+	m.logger.Info("session paused: %s", meta.Name)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// windowTitle is the terminal title while the TUI is showing: the selected
+// session with its provider and health, plus how many sessions are waiting
+// for input, so the right tab stands out among several terminals.
+func (m Model) windowTitle() string {
+	title := "vibeflow"
+	waiting := 0
+	for _, s := range m.sessions {
+		if s.Status == "waiting" {
+			waiting++
+		}
+	}
+	if waiting > 0 {
+		title += fmt.Sprintf(" (%d waiting)", waiting)
+	}
+	if idx := m.selectedSessionIdx(); idx >= 0 && idx < len(m.sessions) {
+		s := m.sessions[idx]
+		title += " — " + sessionTitle(s.Name, s.Provider, s.Status)
+	}
+	return title
+}
+
+// syncSessionTitles pushes each session's name, provider and health to its
+// tmux window and pane title when they changed since the last refresh, so
+// tmux window switching and terminal tabs outside vibeflow show them too.
+func (m *Model) syncSessionTitles() tea.Cmd {
+	if m.tmux == nil {
+		return nil
+	}
+	titles := make(map[string]string, len(m.sessions))
+	var changed []string
+	for _, s := range m.sessions {
+		title := sessionTitle(s.Name, s.Provider, s.Status)
+		titles[s.Name] = title
+		if m.sessionTitles[s.Name] != title {
+			changed = append(changed, s.Name)
+		}
+	}
+	m.sessionTitles = titles
+	if len(changed) == 0 {
+		return nil
+	}
+	tmux := m.tmux
+	return func() tea.Msg {
+		batch := make(map[string]string, len(changed))
+		for _, name := range changed {
+			batch[name] = titles[name]
+		}
+		_ = tmux.SetSessionTitles(batch)
+		return nil
+	}
+}
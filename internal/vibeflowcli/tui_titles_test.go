@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import "testing"
+
+func TestWindowTitle(t *testing.T) {
+	m := Model{sessions: []SessionRow{
+		{Name: "claude-api", Provider: "claude", Status: "working"},
+		{Name: "codex-web", Provider: "codex", Status: "waiting"},
+	}}
+	if got, want := m.windowTitle(), "vibeflow (1 waiting) — claude-api · claude · working"; got != want {
+		t.Errorf("windowTitle = %q, want %q", got, want)
+	}
+	if got := (Model{}).windowTitle(); got != "vibeflow" {
+		t.Errorf("empty windowTitle = %q, want vibeflow", got)
+	}
+}
+
+func TestSyncSessionTitles_OnlyChanged(t *testing.T) {
+	m := Model{
+		tmux: NewTmuxManager("vftest-titles-unused"),
+		sessions: []SessionRow{
+			{Name: "claude-api", Provider: "claude", Status: "working"},
+		},
+	}
+	if m.syncSessionTitles() == nil {
+		t.Fatal("first sync should push the title")
+	}
+	if m.syncSessionTitles() != nil {
+		t.Error("unchanged titles should not be pushed again")
+	}
+	m.sessions[0].Status = "waiting"
+	if m.syncSessionTitles() == nil {
+		t.Error("a health change should push the title")
+	}
+	if got := m.sessionTitles["claude-api"]; got != "claude-api · claude · waiting" {
+		t.Errorf("recorded title = %q", got)
+	}
+}
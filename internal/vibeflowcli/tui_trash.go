@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TrashModel lists recently killed sessions (`u` on the session list) so a
+// mistaken delete can be undone: enter relaunches the selected one.
+type TrashModel struct {
+	entries []TrashedSession // newest first
+	states  []string         // trashWorktreeState per entry
+	cursor  int
+	now     time.Time
+	err     error
+	done    bool
+	restore string // session chosen for restore once done
+}
+
+// NewTrashModel creates the view over entries.
+func NewTrashModel(entries []TrashedSession, err error, now time.Time) TrashModel {
+	t := TrashModel{entries: entries, now: now, err: err}
+	for _, e := range entries {
+		t.states = append(t.states, trashWorktreeState(e))
+	}
+	return t
+}
+
+// Done reports whether the view should close.
+func (t TrashModel) Done() bool { return t.done }
+
+// Restore returns the session chosen for restore, if any.
+func (t TrashModel) Restore() (name string, ok bool) { return t.restore, t.restore != "" }
+
+// Update handles navigation and selection.
+func (t TrashModel) Update(msg tea.Msg) (TrashModel, tea.Cmd) {
+	key, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return t, nil
+	}
+	switch key.String() {
+	case "esc", "q", "u":
+		t.done = true
+	case "up", "k":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case "down", "j":
+		if t.cursor < len(t.entries)-1 {
+			t.cursor++
+		}
+	case "enter":
+		if t.cursor < len(t.entries) && t.states[t.cursor] != "lost" {
+			t.restore = t.entries[t.cursor].Meta.Name
+			t.done = true
+		}
+	}
+	return t, nil
+}
+
+// View renders the list.
+func (t TrashModel) View() string {
+	var b strings.Builder
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	b.WriteString(title.Render("Recently killed sessions"))
+	b.WriteString("\n\n")
+	switch {
+	case t.err != nil:
+		b.WriteString(statusError.Render("read trash: "+t.err.Error()) + "\n")
+	case len(t.entries) == 0:
+		b.WriteString(dim.Render("(nothing killed recently)") + "\n")
+	}
+	for i, e := range t.entries {
+		line := fmt.Sprintf("%-8s %-24s %-8s %-24s", formatSessionDuration(t.now.Sub(e.KilledAt))+" ago",
+			truncate(e.Meta.Name, 24), truncate(e.Meta.Provider, 8), truncate(e.Meta.Branch, 24))
+		switch t.states[i] {
+		case "recreate":
+			line += " worktree will be recreated"
+		case "lost":
+			line += " worktree and branch gone"
+		}
+		if i == t.cursor {
+			b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("enter: restore  ↑/↓: select  esc: close"))
+	return b.String()
+}
+
+// restoreTrashed relaunches the trashed session name and refreshes the list.
+func (m Model) restoreTrashed(name string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := RestoreTrashedSession(name, m.config, m.tmux, m.store, m.cache, m.registry); err != nil {
+			return sessionsMsg{err: fmt.Errorf("restore %s: %w", name, err)}
+		}
+		m.logger.Info("session restored from trash: %s", name)
+		return m.refreshSessions()
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// providerVersionInterval is how often the installed provider versions
+	// are re-read, so an upgrade made while the TUI runs is noticed.
+	providerVersionInterval = 10 * time.Minute
+	// rollingRestartSettle is how long a restarted session gets to come up
+	// before a rolling restart moves on to the next one.
+	rollingRestartSettle = 15 * time.Second
+)
+
+// providerVersionTickMsg drives the installed provider version check.
+type providerVersionTickMsg struct{}
+
+// providerVersionsMsg carries the installed version of each provider.
+type providerVersionsMsg struct {
+	versions map[string]string
+}
+
+func providerVersionTickCmd() tea.Cmd {
+	return tea.Tick(providerVersionInterval, func(time.Time) tea.Msg {
+		return providerVersionTickMsg{}
+	})
+}
+
+// checkProviderVersions runs each available provider's --version.
+func (m Model) checkProviderVersions() tea.Msg {
+	if m.registry == nil {
+		return providerVersionsMsg{}
+	}
+	return providerVersionsMsg{versions: installedVersions(m.registry)}
+}
+
+// sessionVersion describes the provider version a session was launched
+// with, and whether a newer one is installed since. It is empty when the
+// launch version wasn't recorded.
+func (m Model) sessionVersion(s SessionRow) (label string, outdated bool) {
+	if s.ProviderVersion == "" {
+		return "", false
+	}
+	installed := m.providerVersions[s.Provider]
+	if !sessionOutdated(s.ProviderVersion, installed) {
+		return s.ProviderVersion, false
+	}
+	return fmt.Sprintf("%s (outdated, %s installed; %s: rolling restart)",
+		s.ProviderVersion, installed, m.keys.label(actRollingRestart)), true
+}
+
+// outdatedSessions returns the live sessions running an older provider
+// binary than the installed one.
+func (m Model) outdatedSessions() []string {
+	var names []string
+	for _, s := range m.sessions {
+		if s.Status == "pending" || s.Status == "paused" {
+			continue
+		}
+		if _, outdated := m.sessionVersion(s); outdated {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// rollingRestart restarts outdated sessions one at a time, so only one
+// agent is down at once and a broken upgrade shows up on the first.
+type rollingRestart struct {
+	queue     []string  // sessions still to restart, in list order
+	current   string    // session restarted last, given time to come up
+	since     time.Time // when current was restarted
+	restarted []string
+	failed    []string
+}
+
+// upgradeStepMsg reports one rolling-restart step.
+type upgradeStepMsg struct {
+	name string
+	meta SessionMeta
+	err  error
+}
+
+// toggleRollingRestart starts a rolling restart of the outdated sessions,
+// or cancels the one in progress.
+func (m Model) toggleRollingRestart() (Model, tea.Cmd) {
+	if m.upgrade != nil {
+		m.notice = fmt.Sprintf("Rolling restart cancelled; %s restarted", plural(len(m.upgrade.restarted), "session"))
+		m.upgrade = nil
+		return m, clearNoticeCmd()
+	}
+	queue := m.outdatedSessions()
+	if len(queue) == 0 {
+		m.notice = "No session runs an outdated provider version"
+		return m, clearNoticeCmd()
+	}
+	m.upgrade = &rollingRestart{queue: queue}
+	m.logger.Info("rolling restart of %s: %s", plural(len(queue), "outdated session"), strings.Join(queue, ", "))
+	return m.advanceRollingRestart(time.Now())
+}
+
+// advanceRollingRestart restarts the next queued session once the previous
+// one has had rollingRestartSettle to come up. A session that is working
+// or attached is passed over until it isn't, so no agent is cut off
+// mid-task.
+func (m Model) advanceRollingRestart(now time.Time) (Model, tea.Cmd) {
+	u := m.upgrade
+	if u == nil || (u.current != "" && now.Sub(u.since) < rollingRestartSettle) {
+		return m, nil
+	}
+	u.current = ""
+	rows := make(map[string]SessionRow, len(m.sessions))
+	for _, s := range m.sessions {
+		rows[s.Name] = s
+	}
+	for i, name := range u.queue {
+		s, ok := rows[name]
+		if ok && (s.Status == "working" || s.TmuxAttached) {
+			continue
+		}
+		u.queue = append(u.queue[:i:i], u.queue[i+1:]...)
+		if !ok {
+			// Killed since the restart started.
+			return m.advanceRollingRestart(now)
+		}
+		u.current, u.since = name, now
+		m.notice = fmt.Sprintf("Rolling restart: restarting %s (%d left)", name, len(u.queue))
+		restart := func() tea.Msg {
+			meta, found := m.storeMetaForRow(s)
+			if !found {
+				return upgradeStepMsg{name: name, err: fmt.Errorf("session %q not found", name)}
+			}
+			updated, err := RestartSession(meta, m.config, m.tmux, m.store, m.cache, m.registry)
+			return upgradeStepMsg{name: name, meta: updated, err: err}
+		}
+		return m, restart
+	}
+	if len(u.queue) > 0 {
+		return m, nil // the rest are busy; try again on the next refresh
+	}
+	m.upgrade = nil
+	m.notice = fmt.Sprintf("Rolling restart done: %s restarted", plural(len(u.restarted), "session"))
+	if len(u.failed) > 0 {
+		m.err = fmt.Errorf("rolling restart could not restart %s (see vibeflow-cli.log)", strings.Join(u.failed, ", "))
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return errClearMsg{} })
+	}
+	return m, clearNoticeCmd()
+}
+
+// handleUpgradeStep records the outcome of a rolling-restart step.
+func (m Model) handleUpgradeStep(msg upgradeStepMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Error("rolling restart of %s: %v", msg.name, msg.err)
+	} else {
+		m.logger.Info("rolling restart: %s relaunched with %s %s", msg.name, msg.meta.Provider, msg.meta.ProviderVersion)
+		m.hooks.Fire(HookSessionCreate, msg.meta, nil)
+	}
+	if m.upgrade != nil {
+		if msg.err != nil {
+			m.upgrade.failed = append(m.upgrade.failed, msg.name)
+			m.upgrade.current = "" // nothing to wait for
+		} else {
+			m.upgrade.restarted = append(m.upgrade.restarted, msg.name)
+		}
+	}
+	return m, m.refreshSessions
+}
@@ -22,6 +22,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
@@ -47,6 +48,14 @@ const (
 	// position is between StepLLMGateway and StepBranch when active — see
 	// postProviderConfigStep() — but the iota index is end-of-list.
 	StepQwenLaunchConfig
+	// StepModel picks the agent model; it sits between StepPermissions and
+	// StepConfirm and is skipped for providers whose launch template has no
+	// {{.Model}}.
+	StepModel
+	// StepProfile picks the credential profile (account) the provider runs
+	// under; it follows StepModel and is skipped when the provider has no
+	// profiles.
+	StepProfile
 )
 
 // WorktreeChoice represents the user's worktree selection.
@@ -72,6 +81,7 @@ type WizardResult struct {
 	PersonaProviders     map[string]string // Optional persona key → provider key override for team mode. Missing/empty value means inherit ProviderKey.
 	Branch               string
 	NewBranch            bool // True if user chose to create a new branch.
+	BranchCreated        bool // Set by the launch once it created Branch; recorded as SessionMeta.NewBranch.
 	WorktreeChoice       WorktreeChoice
 	SkipPermissions      bool
 	WorktreeName         string            // Custom worktree directory name, or "" for auto-generated.
@@ -83,7 +93,11 @@ type WizardResult struct {
 	ReuseSessionID       string            // Session ID from a previous conflict to reuse via session_init.
 	WorkDir              string            // Project root directory selected in StepWorkDir.
 	EnvVars              map[string]string // Extra env vars to set on the tmux session.
+	Model                string            // Model id rendered into the launch template as {{.Model}}; empty uses the provider's default.
+	SparsePaths          []string          // Directories a new worktree is limited to (sparse checkout); empty checks out everything.
+	Profile              string            // Credential profile (Config.Profiles) the provider runs under; empty uses its default login.
 	LLMGatewayEnabled    bool              // True if user opted to route LLM requests through the gateway.
+	OnCollision          string            // Collision action picked at the prompt; "" follows session_collision.
 }
 
 // WizardModel is a Bubble Tea sub-model for multi-step session creation.
@@ -115,8 +129,10 @@ type WizardModel struct {
 	workDirInput    string            // Text input for new directory.
 	workDirErr      string            // Validation error for directory.
 	repoRoot        string            // Initial repo root from caller.
+	answersKey      string            // Repository the remembered answers are keyed by.
+	remembered      WizardAnswers     // Answers from the last run in that repository.
 	registry        *ProviderRegistry // Provider registry for re-loading on dir change.
-	client          *Client           // API client (may be nil).
+	client          VibeflowAPI       // API client (may be nil).
 	config          *Config           // Config for saved env vars and persisting.
 
 	// Selections.
@@ -130,6 +146,17 @@ type WizardModel struct {
 	selectedWorktree    int
 	selectedPermission  int
 
+	// Model selection.
+	modelOpts     []string // "Provider default", suggested models, custom entry
+	model         string   // chosen model id; "" = provider default
+	modelProvider string   // provider key the model was chosen for
+	modelInput    string   // custom model id being typed
+	editingModel  bool     // True when text input for a custom model is active.
+
+	// Credential profile selection.
+	profileOpts []string // "Default login" followed by the provider's profiles
+	profile     string   // chosen profile; "" = the provider's default login
+
 	// Project filtering.
 	projectFilter       string
 	projectFilterActive bool
@@ -141,19 +168,23 @@ type WizardModel struct {
 	filteredBranches   []int // indices into branches slice (always includes index 0 = "[+] Create new")
 
 	// Text input state.
-	worktreeName        string // Custom name entered by user.
-	editingName         bool   // True when text input for worktree name is active.
-	newBranchName       string // New branch name entered by user.
-	editingBranch       bool   // True when text input for new branch name is active.
-	binaryPath          string // Custom binary path entered by user.
-	editingBinary       bool   // True when text input for binary path is active.
-	binaryPathErr       string // Validation error for binary path.
-	customBaseDir       string // Custom base directory for worktree.
-	editingCustomDir    bool   // True when text input for custom dir is active.
-	customDirErr        string // Validation error for custom dir.
-	specifiedWorkDir    string // User-specified working directory path.
-	editingSpecWorkDir  bool   // True when text input for specified work dir is active.
-	specifiedWorkDirErr string // Validation error for specified work dir.
+	worktreeName        string   // Custom name entered by user.
+	editingName         bool     // True when text input for worktree name is active.
+	sparseInput         string   // Sparse checkout directories being typed.
+	editingSparse       bool     // True when text input for sparse checkout paths is active.
+	sparseErr           string   // Validation error for sparse paths.
+	sparsePaths         []string // Parsed sparse checkout directories; empty = full checkout.
+	newBranchName       string   // New branch name entered by user.
+	editingBranch       bool     // True when text input for new branch name is active.
+	binaryPath          string   // Custom binary path entered by user.
+	editingBinary       bool     // True when text input for binary path is active.
+	binaryPathErr       string   // Validation error for binary path.
+	customBaseDir       string   // Custom base directory for worktree.
+	editingCustomDir    bool     // True when text input for custom dir is active.
+	customDirErr        string   // Validation error for custom dir.
+	specifiedWorkDir    string   // User-specified working directory path.
+	editingSpecWorkDir  bool     // True when text input for specified work dir is active.
+	specifiedWorkDirErr string   // Validation error for specified work dir.
 
 	// Env token input (StepEnvToken).
 	envTokenVarName string            // Name of the env var to prompt for (e.g. "MCP_TOKEN").
@@ -180,6 +211,20 @@ type WizardModel struct {
 	newBranchBase     string // Base branch for new branch creation.
 	editingBranchBase bool   // True when editing the base branch field.
 
+	// Branch step annotations, loaded as branches scroll into view.
+	branchStats map[string]branchStat
+
+	// New-branch name suggestions: the pending issues they are made from
+	// and which one is in the name (Tab moves to the next).
+	branchIssues     []WorkItem
+	branchSuggestion int
+
+	// Preflight checks for the launch shown on the confirm step.
+	preflightKey  string           // launch the checks were started for
+	preflight     []preflightCheck // results, once preflightDone
+	preflightDone bool
+	preflightAck  bool // failures were shown; the next enter launches anyway
+
 	// Quick branch switch mode.
 	quickSwitch  bool         // True when wizard is running as a 2-step branch switch.
 	switchSource *SessionMeta // Original session metadata for quick switch.
@@ -237,7 +282,7 @@ func isCodeAgentPersona(key string) bool { return codeAgentKeys[key] }
 
 // NewWizardModel creates a wizard pre-loaded with providers and branches.
 // wm may be nil if not in a git repository. client may be nil if API is unavailable.
-func NewWizardModel(registry *ProviderRegistry, repoRoot string, wm *WorktreeManager, client *Client, defaultProject string, dirHistory []string, cfg *Config) WizardModel {
+func NewWizardModel(registry *ProviderRegistry, repoRoot string, wm *WorktreeManager, client VibeflowAPI, defaultProject string, dirHistory []string, cfg *Config) WizardModel {
 	// Build provider list.
 	allProviders := registry.List()
 	entries := make([]providerEntry, 0, len(allProviders))
@@ -312,7 +357,7 @@ func NewWizardModel(registry *ProviderRegistry, repoRoot string, wm *WorktreeMan
 	for i := range personaProviderIdx {
 		personaProviderIdx[i] = -1 // -1 = inherit team default
 	}
-	return WizardModel{
+	w := WizardModel{
 		step:               StepWorkDir,
 		sessionTypeOpts:    []string{"Vanilla", "VibeFlow"},
 		projects:           projects,
@@ -340,6 +385,8 @@ func NewWizardModel(registry *ProviderRegistry, repoRoot string, wm *WorktreeMan
 		currentBranch:      GetGitBranch(repoRoot),
 		defaultBranch:      getDefaultBranch(repoRoot),
 	}
+	w.selectedProvider = w.defaultProviderIndex()
+	return w
 }
 
 // NewQuickSwitchWizard creates a wizard pre-filled from an existing session,
@@ -489,6 +536,8 @@ func (w WizardModel) buildQuickSwitchResult() (WizardModel, tea.Cmd) {
 		WorkDir:              w.selectedWorkDir,
 		EnvVars:              env,
 		LLMGatewayEnabled:    w.switchSource.LLMGatewayEnabled,
+		Model:                w.switchSource.Model,
+		Profile:              w.switchSource.Profile,
 	}
 	w.done = true
 	return w, nil
@@ -653,6 +702,9 @@ func (w WizardModel) buildGroupEditResult() (WizardModel, tea.Cmd) {
 		EnvVars:           env,
 		LLMGatewayEnabled: a.LLMGatewayEnabled,
 	}
+	if pe.key == a.Provider {
+		w.result.Model = a.Model
+	}
 	w.done = true
 	return w, nil
 }
@@ -763,8 +815,28 @@ func (w WizardModel) Cancelled() bool { return w.cancelled }
 // Result returns the wizard's selections.
 func (w WizardModel) Result() WizardResult { return w.result }
 
-// Update handles input for the wizard.
+// Update handles input for the wizard and loads the stats of branches
+// scrolled into view.
 func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case branchStatsMsg:
+		w.applyBranchStats(msg)
+		return w, nil
+	case preflightMsg:
+		w.applyPreflight(msg)
+		return w, nil
+	}
+	w, cmd := w.update(msg)
+	if load := w.loadBranchStats(); load != nil {
+		cmd = tea.Batch(cmd, load)
+	}
+	if check := w.loadPreflight(); check != nil {
+		cmd = tea.Batch(cmd, check)
+	}
+	return w, cmd
+}
+
+func (w WizardModel) update(msg tea.Msg) (WizardModel, tea.Cmd) {
 	// Bubble Tea v2 delivers bracketed paste as its own message type; route it
 	// through the key path so text inputs receive pasted characters (v1 parity).
 	if p, ok := msg.(tea.PasteMsg); ok {
@@ -804,8 +876,9 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 				w.editingWorkDir = false
 				w.selectedWorkDir = dir
 				w.reloadBranchesForDir(dir)
+				w.loadAnswers(dir)
 				w.step = StepSessionType
-				w.cursor = 0
+				w.cursor = w.rememberedSessionType()
 			case "esc":
 				w.editingWorkDir = false
 				w.workDirInput = ""
@@ -844,6 +917,10 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 				w.editingBranch = false
 				w.newBranchName = ""
 				// Stay on branch step.
+			case "tab":
+				w.nextBranchSuggestion()
+			case "ctrl+u":
+				w.newBranchName = ""
 			case "backspace":
 				if len(w.newBranchName) > 0 {
 					w.newBranchName = w.newBranchName[:len(w.newBranchName)-1]
@@ -868,7 +945,7 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 					w.editingBranchBase = false
 					w.rebuildWorktreeOpts()
 					w.step = StepWorktree
-					w.cursor = 0
+					w.cursor = w.rememberedWorktree()
 				}
 			case "esc":
 				// Go back to editing branch name.
@@ -979,6 +1056,63 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 			return w, nil
 		}
 
+		// Text input mode for a custom model id.
+		if w.editingModel {
+			switch msg.String() {
+			case "enter":
+				w.editingModel = false
+				w.model = strings.TrimSpace(w.modelInput)
+				w.leaveModelStep()
+			case "esc":
+				w.editingModel = false
+				// Stay on model step.
+			case "backspace":
+				if len(w.modelInput) > 0 {
+					w.modelInput = w.modelInput[:len(w.modelInput)-1]
+				}
+			default:
+				for _, r := range msg.Text {
+					if r > ' ' && r <= '~' {
+						w.modelInput += string(r)
+					}
+				}
+			}
+			return w, nil
+		}
+
+		// Text input mode for sparse checkout paths of a new worktree.
+		if w.editingSparse {
+			switch msg.String() {
+			case "enter":
+				paths, err := ParseSparsePaths(w.sparseInput)
+				if err != nil {
+					w.sparseErr = err.Error()
+					return w, nil
+				}
+				w.sparsePaths = paths
+				w.editingSparse = false
+				w.step = StepPermissions
+				w.cursor = w.rememberedPermission()
+			case "esc":
+				w.editingSparse = false
+				w.sparseErr = ""
+				// Stay on worktree step.
+			case "backspace":
+				if len(w.sparseInput) > 0 {
+					w.sparseInput = w.sparseInput[:len(w.sparseInput)-1]
+				}
+			case "space":
+				w.sparseInput += " "
+			default:
+				for _, r := range msg.Text {
+					if r >= ' ' && r <= '~' {
+						w.sparseInput += string(r)
+					}
+				}
+			}
+			return w, nil
+		}
+
 		// Text input mode for worktree name.
 		if w.editingName {
 			switch msg.String() {
@@ -987,8 +1121,8 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 				if w.quickSwitch {
 					return w.buildQuickSwitchResult()
 				}
-				w.step = StepPermissions
-				w.cursor = 0
+				w.startSparseInput()
+				return w, nil
 			case "esc":
 				w.editingName = false
 				w.worktreeName = ""
@@ -1038,8 +1172,8 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 				if w.quickSwitch {
 					return w.buildQuickSwitchResult()
 				}
-				w.step = StepPermissions
-				w.cursor = 0
+				w.startSparseInput()
+				return w, nil
 			case "esc":
 				w.editingCustomDir = false
 				w.customBaseDir = ""
@@ -1093,7 +1227,7 @@ func (w WizardModel) Update(msg tea.Msg) (WizardModel, tea.Cmd) {
 					return w.buildQuickSwitchResult()
 				}
 				w.step = StepPermissions
-				w.cursor = 0
+				w.cursor = w.rememberedPermission()
 			case "esc":
 				w.editingSpecWorkDir = false
 				w.specifiedWorkDir = ""
@@ -1415,11 +1549,8 @@ func (w WizardModel) View() string {
 		steps = []string{"Branch", "Worktree"}
 		stepMapping = []WizardStep{StepBranch, StepWorktree}
 	} else {
-		steps = []string{"Directory", "Type", "Project", "Team", "Provider", "Env", "Branch", "Worktree", "Permissions", "Confirm"}
-		stepMapping = make([]WizardStep, len(steps))
-		for i := range steps {
-			stepMapping[i] = WizardStep(i)
-		}
+		steps = []string{"Directory", "Type", "Project", "Team", "Provider", "Env", "Branch", "Worktree", "Permissions", "Model", "Account", "Confirm"}
+		stepMapping = []WizardStep{StepWorkDir, StepSessionType, StepProject, StepTeam, StepProvider, StepEnvToken, StepBranch, StepWorktree, StepPermissions, StepModel, StepProfile, StepConfirm}
 	}
 	var stepLine strings.Builder
 	for i, s := range steps {
@@ -1743,8 +1874,15 @@ func (w WizardModel) View() string {
 				b.WriteString(dim.Render(nameLabel) + "\n")
 				b.WriteString(baseLabel + cursor + "\n")
 			}
+			if source := w.branchSuggestionSource(); source != "" {
+				b.WriteString(dim.Render("  Suggested from "+source) + "\n")
+			}
 			b.WriteString("\n")
-			b.WriteString(helpStyle.Render("enter: confirm  esc: back"))
+			hint := "enter: confirm  esc: back"
+			if w.editingBranch && len(w.branchSuggestions()) > 0 {
+				hint = "enter: confirm  tab: next suggestion  ctrl+u: clear  esc: back"
+			}
+			b.WriteString(helpStyle.Render(hint))
 		} else {
 			// Count branches with worktrees for header annotation.
 			wtCount := 0
@@ -1774,21 +1912,9 @@ func (w WizardModel) View() string {
 			b.WriteString("\n")
 
 			// Scrolling viewport: show at most 15 items centered on cursor.
-			const maxVisible = 15
 			total := len(w.filteredBranches)
-			startIdx := 0
-			endIdx := total
-			if total > maxVisible {
-				startIdx = w.cursor - maxVisible/2
-				if startIdx < 0 {
-					startIdx = 0
-				}
-				endIdx = startIdx + maxVisible
-				if endIdx > total {
-					endIdx = total
-					startIdx = endIdx - maxVisible
-				}
-			}
+			startIdx, endIdx := w.branchWindow()
+			now := time.Now()
 
 			if startIdx > 0 {
 				b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render(fmt.Sprintf("  ▲ %d more above\n", startIdx)))
@@ -1816,6 +1942,9 @@ func (w WizardModel) View() string {
 				if branchIdx > 0 && br == w.currentBranch {
 					label += " " + lipgloss.NewStyle().Foreground(accentColor).Render("← current")
 				}
+				if note := w.branchAnnotation(br, now); branchIdx > 0 && note != "" {
+					label += "  " + lipgloss.NewStyle().Foreground(dimColor).Render(note)
+				}
 				b.WriteString(fmt.Sprintf("%s%s\n", cursor, label))
 			}
 
@@ -1825,7 +1954,17 @@ func (w WizardModel) View() string {
 		}
 
 	case StepWorktree:
-		if w.editingName {
+		if w.editingSparse {
+			b.WriteString("Sparse checkout (optional):\n\n")
+			b.WriteString(fmt.Sprintf("  Paths: %s", w.sparseInput))
+			b.WriteString(lipgloss.NewStyle().Foreground(accentColor).Render("█"))
+			if w.sparseErr != "" {
+				b.WriteString("\n")
+				b.WriteString(lipgloss.NewStyle().Foreground(errorColor).Render("  " + w.sparseErr))
+			}
+			b.WriteString("\n\n")
+			b.WriteString(helpStyle.Render("enter: confirm  esc: cancel  (directories from the repo root, comma-separated; empty = full checkout)"))
+		} else if w.editingName {
 			b.WriteString("Worktree name:\n\n")
 			b.WriteString(fmt.Sprintf("  Name: %s", w.worktreeName))
 			b.WriteString(lipgloss.NewStyle().Foreground(accentColor).Render("█"))
@@ -1880,6 +2019,34 @@ func (w WizardModel) View() string {
 			b.WriteString(fmt.Sprintf("%s%s\n", cursor, opt))
 		}
 
+	case StepModel:
+		if w.editingModel {
+			b.WriteString("Model id:\n\n")
+			b.WriteString(fmt.Sprintf("  Model: %s", w.modelInput))
+			b.WriteString(lipgloss.NewStyle().Foreground(accentColor).Render("█"))
+			b.WriteString("\n\n")
+			b.WriteString(helpStyle.Render("enter: confirm  esc: cancel  (empty = provider default)"))
+		} else {
+			b.WriteString(fmt.Sprintf("Model for %s:\n\n", w.providers[w.selectedProvider].provider.Name))
+			for i, opt := range w.modelOpts {
+				cursor := "  "
+				if i == w.cursor {
+					cursor = "> "
+				}
+				b.WriteString(fmt.Sprintf("%s%s\n", cursor, opt))
+			}
+		}
+
+	case StepProfile:
+		b.WriteString(fmt.Sprintf("Account for %s:\n\n", w.providers[w.selectedProvider].provider.Name))
+		for i, opt := range w.profileOpts {
+			cursor := "  "
+			if i == w.cursor {
+				cursor = "> "
+			}
+			b.WriteString(fmt.Sprintf("%s%s\n", cursor, opt))
+		}
+
 	case StepConfirm:
 		if w.groupEdit {
 			return w.groupEditConfirmView()
@@ -1944,6 +2111,9 @@ func (w WizardModel) View() string {
 			case opt == "Specify directory":
 				wt = fmt.Sprintf("Directory (%s)", w.specifiedWorkDir)
 			}
+			if (opt == "New worktree" || opt == "Custom location") && len(w.sparsePaths) > 0 {
+				wt += fmt.Sprintf(" [sparse: %s]", strings.Join(w.sparsePaths, ", "))
+			}
 		}
 		b.WriteString(fmt.Sprintf("  Worktree:      %s\n", wt))
 		perm := "Interactive"
@@ -1951,6 +2121,20 @@ func (w WizardModel) View() string {
 			perm = "Skip permissions"
 		}
 		b.WriteString(fmt.Sprintf("  Permissions:   %s\n", perm))
+		if w.takesModel() {
+			model := w.model
+			if model == "" {
+				model = "Provider default"
+			}
+			b.WriteString(fmt.Sprintf("  Model:         %s\n", model))
+		}
+		if w.hasProfiles() {
+			profile := w.profile
+			if profile == "" {
+				profile = "Default login"
+			}
+			b.WriteString(fmt.Sprintf("  Account:       %s\n", profile))
+		}
 		if w.selectedSessionType == 1 {
 			gw := "Direct (no proxy)"
 			if w.llmGatewayEnabled {
@@ -1974,8 +2158,9 @@ func (w WizardModel) View() string {
 				b.WriteString(fmt.Sprintf("  Qwen Base URL: %s\n", w.qwenBaseURLInput))
 			}
 		}
+		b.WriteString(w.preflightView())
 		b.WriteString("\n")
-		b.WriteString(helpStyle.Render("enter: create  esc: back"))
+		b.WriteString(helpStyle.Render(w.preflightHelp()))
 		return b.String()
 	}
 
@@ -2017,6 +2202,10 @@ func (w WizardModel) listLen() int {
 		return len(w.worktreeOpts)
 	case StepPermissions:
 		return len(w.permissionOpts)
+	case StepModel:
+		return len(w.modelOpts)
+	case StepProfile:
+		return len(w.profileOpts)
 	case StepConfirm:
 		return 1 // Single "Create" action; prevents cursor going negative.
 	default:
@@ -2043,8 +2232,9 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 		}
 		w.selectedWorkDir = dir
 		w.reloadBranchesForDir(dir)
+		w.loadAnswers(dir)
 		w.step = StepSessionType
-		w.cursor = 0
+		w.cursor = w.rememberedSessionType()
 	case StepSessionType:
 		w.selectedSessionType = w.cursor
 		if w.cursor == 1 { // VibeFlow
@@ -2063,7 +2253,7 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 			w.projectFilterActive = true
 		} else { // Vanilla
 			w.step = StepProvider
-			w.cursor = 0
+			w.cursor = w.providerCursor()
 		}
 	case StepProject:
 		if len(w.filteredProjects) > 0 && w.cursor < len(w.filteredProjects) {
@@ -2086,7 +2276,7 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 			w.selectedPersona = 0 // fallback
 		}
 		w.step = StepProvider
-		w.cursor = 0
+		w.cursor = w.providerCursor()
 	case StepProvider:
 		teamMode := w.teamModeProvider()
 		if !teamMode {
@@ -2191,8 +2381,9 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 		actualIdx := w.filteredBranches[w.cursor]
 		w.selectedBranch = actualIdx
 		if actualIdx == 0 {
-			// "[+] Create new branch" selected — prompt for branch name.
-			w.newBranchName = ""
+			// "[+] Create new branch" selected — prompt for branch name,
+			// starting from a suggested one.
+			w.suggestNewBranch()
 			w.editingBranch = true
 			return w, nil
 		}
@@ -2202,7 +2393,7 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 		// Rebuild worktree options based on selected branch.
 		w.rebuildWorktreeOpts()
 		w.step = StepWorktree
-		w.cursor = 0
+		w.cursor = w.rememberedWorktree()
 	case StepWorktree:
 		w.selectedWorktree = w.cursor
 		opt := w.worktreeOpts[w.cursor]
@@ -2213,7 +2404,7 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 				return w.buildQuickSwitchResult()
 			}
 			w.step = StepPermissions
-			w.cursor = 0
+			w.cursor = w.rememberedPermission()
 		case opt == "New worktree":
 			// Prompt for custom name.
 			pe := w.providers[w.selectedProvider]
@@ -2241,34 +2432,44 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 				return w.buildQuickSwitchResult()
 			}
 			w.step = StepPermissions
-			w.cursor = 0
+			w.cursor = w.rememberedPermission()
 		}
 	case StepPermissions:
 		w.selectedPermission = w.cursor
+		if w.takesModel() {
+			w.enterModelStep()
+		} else {
+			w.leaveModelStep()
+		}
+	case StepModel:
+		switch {
+		case w.cursor == len(w.modelOpts)-1:
+			// Custom model id.
+			w.modelInput = w.model
+			w.editingModel = true
+			return w, nil
+		case w.cursor == 0:
+			w.model = ""
+		default:
+			w.model = w.modelOpts[w.cursor]
+		}
+		w.leaveModelStep()
+	case StepProfile:
+		w.profile = ""
+		if w.cursor > 0 && w.cursor < len(w.profileOpts) {
+			w.profile = w.profileOpts[w.cursor]
+		}
 		w.step = StepConfirm
 		w.cursor = 0
 	case StepConfirm:
 		if w.groupEdit {
 			return w.buildGroupEditResult()
 		}
-		pe := w.providers[w.selectedProvider]
-		// Determine worktree choice from selected option text.
-		wtChoice := WorktreeCurrent
-		var existingPath string
-		if w.selectedWorktree < len(w.worktreeOpts) {
-			opt := w.worktreeOpts[w.selectedWorktree]
-			switch {
-			case strings.HasPrefix(opt, "Use existing:"):
-				wtChoice = WorktreeExisting
-				existingPath = w.findWorktreeForBranch(w.resolvedBranch())
-			case opt == "New worktree":
-				wtChoice = WorktreeNew
-			case opt == "Custom location":
-				wtChoice = WorktreeCustom
-			case opt == "Specify directory":
-				wtChoice = WorktreeSpecifyDir
-			}
+		if w.preflightHoldsLaunch() {
+			return w, nil
 		}
+		pe := w.providers[w.selectedProvider]
+		wtChoice, existingPath := w.worktreeChoice()
 		prov := pe.provider
 		if w.binaryPath != "" {
 			prov.Binary = w.binaryPath
@@ -2329,20 +2530,44 @@ func (w WizardModel) advance() (WizardModel, tea.Cmd) {
 			NewBranchBase:        w.newBranchBase,
 			WorktreeChoice:       wtChoice,
 			SkipPermissions:      w.selectedPermission == 0,
+			Model:                w.resultModel(),
+			Profile:              w.resultProfile(),
 			WorktreeName:         w.worktreeName,
 			CustomBinaryPath:     w.binaryPath,
 			ExistingWorktreePath: existingPath,
 			CustomBaseDir:        w.customBaseDir,
+			SparsePaths:          w.resultSparsePaths(wtChoice),
 			SpecifiedWorkDir:     w.specifiedWorkDir,
 			WorkDir:              w.selectedWorkDir,
 			EnvVars:              w.envVars,
 			LLMGatewayEnabled:    w.llmGatewayEnabled,
 		}
 		w.done = true
+		w.saveAnswers()
 	}
 	return w, nil
 }
 
+// worktreeChoice returns the worktree option picked, from its text, and
+// the path of the existing worktree when that is the one.
+func (w WizardModel) worktreeChoice() (WorktreeChoice, string) {
+	if w.selectedWorktree >= len(w.worktreeOpts) {
+		return WorktreeCurrent, ""
+	}
+	opt := w.worktreeOpts[w.selectedWorktree]
+	switch {
+	case strings.HasPrefix(opt, "Use existing:"):
+		return WorktreeExisting, w.findWorktreeForBranch(w.resolvedBranch())
+	case opt == "New worktree":
+		return WorktreeNew, ""
+	case opt == "Custom location":
+		return WorktreeCustom, ""
+	case opt == "Specify directory":
+		return WorktreeSpecifyDir, ""
+	}
+	return WorktreeCurrent, ""
+}
+
 // rebuildProjectFilter updates filteredProjects based on the current projectFilter text.
 func (w *WizardModel) rebuildProjectFilter() {
 	if w.projectFilter == "" {
@@ -2505,6 +2730,16 @@ func (w WizardModel) goBack() (WizardModel, tea.Cmd) {
 	case StepPermissions:
 		w.step = StepWorktree
 		w.cursor = w.selectedWorktree
+	case StepModel:
+		w.step = StepPermissions
+		w.cursor = w.selectedPermission
+	case StepProfile:
+		if w.takesModel() {
+			w.enterModelStep()
+			return w, nil
+		}
+		w.step = StepPermissions
+		w.cursor = w.selectedPermission
 	case StepConfirm:
 		if w.groupEdit {
 			// Group edit skips the permissions step — go back to provider.
@@ -2512,12 +2747,123 @@ func (w WizardModel) goBack() (WizardModel, tea.Cmd) {
 			w.cursor = 0
 			return w, nil
 		}
+		if w.hasProfiles() {
+			w.enterProfileStep()
+			return w, nil
+		}
+		if w.takesModel() {
+			w.enterModelStep()
+			return w, nil
+		}
 		w.step = StepPermissions
 		w.cursor = w.selectedPermission
 	}
 	return w, nil
 }
 
+// takesModel reports whether the wizard asks for a model: the selected
+// provider's launch template must render {{.Model}}.
+func (w WizardModel) takesModel() bool {
+	if w.quickSwitch || w.groupEdit || w.selectedProvider < 0 || w.selectedProvider >= len(w.providers) {
+		return false
+	}
+	return w.providers[w.selectedProvider].provider.TakesModel()
+}
+
+// resultModel is the model for the wizard result: the chosen one, if the
+// provider takes a model at all.
+func (w WizardModel) resultModel() string {
+	if !w.takesModel() {
+		return ""
+	}
+	return w.model
+}
+
+// hasProfiles reports whether the wizard asks for a credential profile: the
+// selected provider must have at least one in the config.
+func (w WizardModel) hasProfiles() bool {
+	if w.quickSwitch || w.groupEdit || w.config == nil || w.selectedProvider < 0 || w.selectedProvider >= len(w.providers) {
+		return false
+	}
+	return len(w.config.ProfileNames(w.providers[w.selectedProvider].key)) > 0
+}
+
+// leaveModelStep moves past the model choice: to the profile step when the
+// provider has profiles, otherwise to confirm.
+func (w *WizardModel) leaveModelStep() {
+	if w.hasProfiles() {
+		w.enterProfileStep()
+		return
+	}
+	w.step = StepConfirm
+	w.cursor = 0
+}
+
+// enterProfileStep shows the selected provider's profiles with the cursor on
+// the current choice. A profile of another provider is dropped.
+func (w *WizardModel) enterProfileStep() {
+	names := w.config.ProfileNames(w.providers[w.selectedProvider].key)
+	w.profileOpts = append([]string{"Default login"}, names...)
+	w.step = StepProfile
+	w.cursor = 0
+	for i, name := range names {
+		if name == w.profile {
+			w.cursor = i + 1
+		}
+	}
+	if w.cursor == 0 {
+		w.profile = ""
+	}
+}
+
+// resultProfile is the credential profile for the wizard result.
+func (w WizardModel) resultProfile() string {
+	if !w.hasProfiles() {
+		return ""
+	}
+	return w.profile
+}
+
+// startSparseInput opens the optional sparse-checkout prompt for a new
+// worktree, pre-filled with any paths entered earlier.
+func (w *WizardModel) startSparseInput() {
+	w.editingSparse = true
+	w.sparseErr = ""
+	w.sparseInput = strings.Join(w.sparsePaths, ",")
+}
+
+// resultSparsePaths returns the sparse-checkout paths when the wizard is
+// creating a worktree; they mean nothing for existing or plain directories.
+func (w WizardModel) resultSparsePaths(choice WorktreeChoice) []string {
+	if choice != WorktreeNew && choice != WorktreeCustom {
+		return nil
+	}
+	return w.sparsePaths
+}
+
+// enterModelStep shows the model choices for the selected provider with the
+// cursor on the current choice. A model picked for a different provider is
+// dropped, since model ids don't carry across providers.
+func (w *WizardModel) enterModelStep() {
+	pe := w.providers[w.selectedProvider]
+	if w.modelProvider != pe.key {
+		w.model = ""
+		w.modelProvider = pe.key
+	}
+	w.modelOpts = append([]string{"Provider default"}, pe.provider.ModelChoices(pe.key)...)
+	w.modelOpts = append(w.modelOpts, "[+] Enter model id")
+	w.step = StepModel
+	w.cursor = 0
+	if w.model != "" {
+		w.cursor = len(w.modelOpts) - 1
+		for i, opt := range w.modelOpts[1 : len(w.modelOpts)-1] {
+			if opt == w.model {
+				w.cursor = i + 1
+			}
+		}
+	}
+}
+
 // selectedPersonaIndices returns indices into w.personas for personas the user
 // toggled on, in display order (matches the order rendered in StepTeam).
 func (w WizardModel) selectedPersonaIndices() []int {
@@ -2652,6 +2998,8 @@ func (w *WizardModel) reloadBranchesForDir(dir string) {
 		w.existingWorktrees = nil
 	}
 
+	w.branchStats = nil
+
 	// Re-detect current branch for the new directory.
 	w.currentBranch = GetGitBranch(dir)
 	w.defaultBranch = getDefaultBranch(dir)
@@ -2663,34 +3011,10 @@ func isGitRepo(dir string) bool {
 	return cmd.Run() == nil
 }
 
-// providerKeys returns sorted provider keys from the registry.
+// providerKeys returns sorted provider keys from the registry, including
+// custom providers added under `providers:` in the config.
 func providerKeys(r *ProviderRegistry) []string {
-	list := r.List()
-	keys := make([]string, 0, len(list))
-	// List() returns sorted by name; we need keys.
-	// Re-derive by matching names — or just iterate the map via a method.
-	// Since ProviderRegistry doesn't expose keys directly, we check known keys.
-	// Better approach: iterate all and collect.
-	seen := make(map[string]bool)
-	for _, p := range list {
-		for _, candidate := range []string{"claude", "codex", "cursor", "gemini", "qwen"} {
-			if got, ok := r.Get(candidate); ok && got.Name == p.Name && !seen[candidate] {
-				keys = append(keys, candidate)
-				seen[candidate] = true
-				break
-			}
-		}
-		// Fallback for custom providers — use name as key.
-		if !seen[p.Name] {
-			// Try lowercase name.
-			lower := strings.ToLower(p.Name)
-			if _, ok := r.Get(lower); ok && !seen[lower] {
-				keys = append(keys, lower)
-				seen[lower] = true
-			}
-		}
-	}
-	return keys
+	return r.Keys()
 }
 
 // listGitBranches returns local and unique remote branch names via git CLI.
@@ -2779,7 +3103,7 @@ func (w *WizardModel) applyQwenPreset() {
 // provider, so the wizard never offers them the gateway routing choice.
 func providerSupportsGateway(providerKey string) bool {
 	switch providerKey {
-	case "qwen", "cursor":
+	case "qwen", "cursor", "aider", "openhands":
 		return false
 	default:
 		return true
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// branchSuggestion is a new-branch name the wizard offers, and what it was
+// made from.
+type branchSuggestion struct {
+	Name   string
+	Source string // e.g. "issue #42: Fix login timeout"
+}
+
+// SetBranchIssues gives the wizard the pending issues to suggest new-branch
+// names from; those of the chosen project are offered.
+func (w *WizardModel) SetBranchIssues(items []pendingItem) {
+	w.branchIssues = nil
+	for _, p := range items {
+		if p.Item.Type == "issue" {
+			w.branchIssues = append(w.branchIssues, p.Item)
+		}
+	}
+}
+
+// branchSuggestions returns the new-branch names for the chosen project
+// and persona: one per pending issue of the project, then one named after
+// the project itself.
+func (w WizardModel) branchSuggestions() []branchSuggestion {
+	prefix := ""
+	if w.config != nil {
+		prefix = w.config.Worktree.BranchPrefix
+	}
+	var project Project
+	if w.selectedSessionType == 1 && w.selectedProject < len(w.projects) {
+		project = w.projects[w.selectedProject]
+	}
+	persona := w.branchPersona()
+
+	var out []branchSuggestion
+	seen := make(map[string]bool)
+	add := func(title, source string) {
+		if name := suggestBranchName(prefix, persona, title); name != "" && !seen[name] {
+			seen[name] = true
+			out = append(out, branchSuggestion{Name: name, Source: source})
+		}
+	}
+	for _, it := range w.branchIssues {
+		if project.ID != 0 && it.ProjectID == project.ID {
+			add(it.Title, fmt.Sprintf("issue #%d: %s", it.ID, it.Title))
+		}
+	}
+	if project.Name != "" {
+		add(project.Name, "project "+project.Name)
+	}
+	return out
+}
+
+// branchPersona is the persona the new branch is for, chosen as the
+// launch's persona is.
+func (w WizardModel) branchPersona() string {
+	if w.selectedSessionType != 1 {
+		return ""
+	}
+	if w.selectedPersona >= 0 && w.selectedPersona < len(w.personas) {
+		return w.personas[w.selectedPersona].key
+	}
+	if idx := w.selectedPersonaIndices(); len(idx) > 0 {
+		return w.personas[idx[0]].key
+	}
+	return ""
+}
+
+// suggestBranchName joins the prefix, the persona's short name and the
+// title's slug: "vf/dev/fix-login-timeout". Empty parts are left out; it
+// returns "" when the title has nothing to slug.
+func suggestBranchName(prefix, persona, title string) string {
+	slug := branchSlug(title)
+	if slug == "" {
+		return ""
+	}
+	var parts []string
+	if p := strings.Trim(prefix, "/"); p != "" {
+		parts = append(parts, p)
+	}
+	if persona != "" {
+		parts = append(parts, personaBranchPart(persona))
+	}
+	return strings.Join(append(parts, slug), "/")
+}
+
+// personaBranchPart is a persona's segment of a suggested branch name:
+// the first word of its key ("qa_lead" → "qa"), with "developer"
+// shortened to "dev".
+func personaBranchPart(persona string) string {
+	part, _, _ := strings.Cut(persona, "_")
+	if part == "developer" {
+		return "dev"
+	}
+	return branchSlug(part)
+}
+
+// suggestNewBranch fills the new-branch name with the first suggestion.
+func (w *WizardModel) suggestNewBranch() {
+	w.newBranchName, w.branchSuggestion = "", -1
+	w.nextBranchSuggestion()
+}
+
+// nextBranchSuggestion replaces the new-branch name with the next
+// suggestion, wrapping around (so with one it restores an edited name);
+// it does nothing when there are none.
+func (w *WizardModel) nextBranchSuggestion() {
+	suggestions := w.branchSuggestions()
+	if len(suggestions) == 0 {
+		return
+	}
+	w.branchSuggestion = (w.branchSuggestion + 1) % len(suggestions)
+	w.newBranchName = suggestions[w.branchSuggestion].Name
+}
+
+// branchSuggestionSource describes where the new-branch name came from,
+// or "" once it has been edited.
+func (w WizardModel) branchSuggestionSource() string {
+	suggestions := w.branchSuggestions()
+	if w.branchSuggestion < 0 || w.branchSuggestion >= len(suggestions) {
+		return ""
+	}
+	if s := suggestions[w.branchSuggestion]; s.Name == w.newBranchName {
+		return s.Source
+	}
+	return ""
+}
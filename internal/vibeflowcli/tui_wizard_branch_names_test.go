@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestSuggestBranchName(t *testing.T) {
+	tests := []struct {
+		prefix, persona, title, want string
+	}{
+		{"vf", "developer", "Fix login timeout!", "vf/dev/fix-login-timeout"},
+		{"/vf/", "qa_lead", "Flaky checkout test", "vf/qa/flaky-checkout-test"},
+		{"", "architect", "Payments v2", "architect/payments-v2"},
+		{"vf", "", "Payments", "vf/payments"},
+		{"vf", "developer", "!!!", ""},
+	}
+	for _, tt := range tests {
+		if got := suggestBranchName(tt.prefix, tt.persona, tt.title); got != tt.want {
+			t.Errorf("suggestBranchName(%q, %q, %q) = %q, want %q", tt.prefix, tt.persona, tt.title, got, tt.want)
+		}
+	}
+}
+
+func branchSuggestionWizard() WizardModel {
+	cfg := DefaultConfig()
+	cfg.Worktree.BranchPrefix = "vf"
+	w := WizardModel{
+		config:              cfg,
+		step:                StepBranch,
+		branches:            []string{"[+] Create new branch", "main"},
+		filteredBranches:    []int{0, 1},
+		selectedSessionType: 1,
+		projects:            []Project{{ID: 1, Name: "Shop"}, {ID: 2, Name: "Other"}},
+		personas:            defaultPersonas(),
+		selectedPersonas:    map[int]bool{0: true},
+	}
+	w.SetBranchIssues([]pendingItem{
+		{Stuck: true, Item: WorkItem{Type: "issue", ID: 7, Title: "Fix login timeout", ProjectID: 1}},
+		{Item: WorkItem{Type: "todo", ID: 8, Title: "Write docs", ProjectID: 1}},
+		{Item: WorkItem{Type: "issue", ID: 9, Title: "Elsewhere", ProjectID: 2}},
+		{Item: WorkItem{Type: "issue", ID: 10, Title: "Refund emails", ProjectID: 1}},
+	})
+	return w
+}
+
+func TestBranchSuggestions_FromProjectIssues(t *testing.T) {
+	w := branchSuggestionWizard()
+	var names []string
+	for _, s := range w.branchSuggestions() {
+		names = append(names, s.Name)
+	}
+	want := []string{"vf/dev/fix-login-timeout", "vf/dev/refund-emails", "vf/dev/shop"}
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Fatalf("suggestions = %v, want %v", names, want)
+	}
+
+	w.selectedSessionType = 0 // vanilla: no project or persona to go on
+	if got := w.branchSuggestions(); len(got) != 0 {
+		t.Fatalf("vanilla suggestions = %v, want none", got)
+	}
+}
+
+func TestNewBranch_PrefillsAndCyclesSuggestions(t *testing.T) {
+	w := branchSuggestionWizard()
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !w.editingBranch || w.newBranchName != "vf/dev/fix-login-timeout" {
+		t.Fatalf("create new branch: editing=%v name=%q", w.editingBranch, w.newBranchName)
+	}
+	if view := ansiRe.ReplaceAllString(w.View(), ""); !strings.Contains(view, "Suggested from issue #7: Fix login timeout") {
+		t.Errorf("view does not say where the name came from:\n%s", view)
+	}
+
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if w.newBranchName != "vf/dev/refund-emails" {
+		t.Fatalf("tab: name = %q", w.newBranchName)
+	}
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	if w.branchSuggestionSource() != "" {
+		t.Error("an edited name should not claim a source")
+	}
+	w, _ = w.Update(tea.KeyPressMsg{Code: 'u', Mod: tea.ModCtrl})
+	if w.newBranchName != "" {
+		t.Fatalf("ctrl+u: name = %q, want cleared", w.newBranchName)
+	}
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if w.newBranchName != "vf/dev/shop" {
+		t.Fatalf("tab after clearing: name = %q", w.newBranchName)
+	}
+}
+
+func TestNewBranch_NoSuggestionsStartsEmpty(t *testing.T) {
+	w := WizardModel{
+		step:             StepBranch,
+		branches:         []string{"[+] Create new branch", "main"},
+		filteredBranches: []int{0, 1},
+	}
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if !w.editingBranch || w.newBranchName != "" {
+		t.Fatalf("editing=%v name=%q, want an empty name", w.editingBranch, w.newBranchName)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// branchListVisible is how many branches the branch step shows at once.
+const branchListVisible = 15
+
+// branchStat annotates a branch in the branch step: when it was last
+// committed to and how far it is ahead of and behind the default branch.
+type branchStat struct {
+	loaded    bool // false while the git queries are running
+	committed time.Time
+	ahead     int
+	behind    int
+	compared  bool // ahead/behind are known
+}
+
+// branchStatsMsg carries the stats for a batch of branches in dir.
+type branchStatsMsg struct {
+	dir   string
+	stats map[string]branchStat
+}
+
+// branchDir is the repository the branch list was read from.
+func (w WizardModel) branchDir() string {
+	if w.selectedWorkDir != "" {
+		return w.selectedWorkDir
+	}
+	return w.repoRoot
+}
+
+// branchWindow returns the range of filteredBranches shown, centered on
+// the cursor.
+func (w WizardModel) branchWindow() (start, end int) {
+	total := len(w.filteredBranches)
+	if total <= branchListVisible {
+		return 0, total
+	}
+	start = w.cursor - branchListVisible/2
+	if start < 0 {
+		start = 0
+	}
+	end = start + branchListVisible
+	if end > total {
+		end = total
+		start = end - branchListVisible
+	}
+	return start, end
+}
+
+// loadBranchStats starts loading the stats of visible branches that have
+// none yet, so a long branch list only costs git calls for what is on
+// screen. It returns nil when there is nothing to load.
+func (w *WizardModel) loadBranchStats() tea.Cmd {
+	if w.step != StepBranch || w.editingBranch || w.editingBranchBase {
+		return nil
+	}
+	start, end := w.branchWindow()
+	var names []string
+	for _, idx := range w.filteredBranches[start:end] {
+		if idx == 0 || idx >= len(w.branches) {
+			continue // "[+] Create new branch"
+		}
+		if _, ok := w.branchStats[w.branches[idx]]; !ok {
+			names = append(names, w.branches[idx])
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if w.branchStats == nil {
+		w.branchStats = make(map[string]branchStat)
+	}
+	for _, name := range names {
+		w.branchStats[name] = branchStat{}
+	}
+	dir, base := w.branchDir(), w.defaultBranch
+	return func() tea.Msg {
+		return branchStatsMsg{dir: dir, stats: branchStats(dir, base, names)}
+	}
+}
+
+// applyBranchStats records loaded stats, dropping those for a directory
+// the wizard has since moved away from.
+func (w *WizardModel) applyBranchStats(msg branchStatsMsg) {
+	if msg.dir != w.branchDir() || w.branchStats == nil {
+		return
+	}
+	for name, st := range msg.stats {
+		w.branchStats[name] = st
+	}
+}
+
+// branchStats reads the last commit time of each branch in dir and its
+// ahead/behind counts against base. base is compared locally when it
+// exists, as origin/<base> otherwise.
+func branchStats(dir, base string, names []string) map[string]branchStat {
+	baseRef := base
+	if !gitRefExists(dir, baseRef) {
+		baseRef = "origin/" + base
+	}
+	stats := make(map[string]branchStat, len(names))
+	for _, name := range names {
+		st := branchStat{loaded: true}
+		if out, err := gitIn(dir, "log", "-1", "--format=%ct", name, "--"); err == nil {
+			if sec, err := strconv.ParseInt(out, 10, 64); err == nil {
+				st.committed = time.Unix(sec, 0)
+			}
+		}
+		if out, err := gitIn(dir, "rev-list", "--left-right", "--count", baseRef+"..."+name, "--"); err == nil {
+			if f := strings.Fields(out); len(f) == 2 {
+				st.behind, _ = strconv.Atoi(f[0])
+				st.ahead, _ = strconv.Atoi(f[1])
+				st.compared = true
+			}
+		}
+		stats[name] = st
+	}
+	return stats
+}
+
+// branchAnnotation renders a branch's stats for the branch list: its age
+// and, when it differs from the default branch, ↑ahead ↓behind.
+func (w WizardModel) branchAnnotation(name string, now time.Time) string {
+	st, ok := w.branchStats[name]
+	if !ok || !st.loaded {
+		return ""
+	}
+	var parts []string
+	if !st.committed.IsZero() {
+		parts = append(parts, formatSessionDuration(now.Sub(st.committed))+" ago")
+	}
+	if st.compared && (st.ahead > 0 || st.behind > 0) {
+		parts = append(parts, fmt.Sprintf("↑%d ↓%d", st.ahead, st.behind))
+	}
+	return strings.Join(parts, "  ")
+}
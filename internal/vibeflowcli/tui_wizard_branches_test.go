@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBranchStats_AheadBehindAndAge(t *testing.T) {
+	repo := initTestRepo(t)
+	base := GetGitBranch(repo)
+	if _, err := gitIn(repo, "checkout", "-q", "-b", "feat"); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, repo, "a.txt", "a")
+	commitFile(t, repo, "b.txt", "b")
+	if _, err := gitIn(repo, "checkout", "-q", base); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, repo, "c.txt", "c")
+
+	stats := branchStats(repo, base, []string{"feat", base, "missing"})
+	feat := stats["feat"]
+	if !feat.loaded || !feat.compared || feat.ahead != 2 || feat.behind != 1 {
+		t.Errorf("feat = %+v, want 2 ahead and 1 behind", feat)
+	}
+	if feat.committed.IsZero() || time.Since(feat.committed) > time.Hour {
+		t.Errorf("feat committed = %v, want just now", feat.committed)
+	}
+	if st := stats[base]; !st.compared || st.ahead != 0 || st.behind != 0 {
+		t.Errorf("%s = %+v, want even with itself", base, st)
+	}
+	if st := stats["missing"]; !st.loaded || st.compared || !st.committed.IsZero() {
+		t.Errorf("missing = %+v, want loaded with nothing known", st)
+	}
+}
+
+func TestLoadBranchStats_OnlyVisibleAndOnce(t *testing.T) {
+	w := WizardModel{step: StepBranch, repoRoot: t.TempDir(), branches: []string{"[+] Create new branch"}}
+	for i := 0; i < 40; i++ {
+		w.branches = append(w.branches, fmt.Sprintf("feature-%02d", i))
+	}
+	w.filteredBranches = make([]int, len(w.branches))
+	for i := range w.branches {
+		w.filteredBranches[i] = i
+	}
+
+	if w.loadBranchStats() == nil {
+		t.Fatal("entering the branch step should load the visible branches")
+	}
+	if len(w.branchStats) != branchListVisible-1 {
+		t.Errorf("requested %d branches, want the %d visible ones", len(w.branchStats), branchListVisible-1)
+	}
+	if w.loadBranchStats() != nil {
+		t.Error("branches already requested were loaded again")
+	}
+	w.cursor = 30
+	if w.loadBranchStats() == nil {
+		t.Error("scrolling should load the branches that came into view")
+	}
+	if _, ok := w.branchStats["feature-39"]; ok {
+		t.Error("a branch below the window was requested")
+	}
+
+	// Results for another directory (the user went back and picked a
+	// different one) are dropped.
+	w.applyBranchStats(branchStatsMsg{dir: "/elsewhere", stats: map[string]branchStat{"feature-00": {loaded: true, ahead: 1}}})
+	if w.branchStats["feature-00"].loaded {
+		t.Error("stats for another directory were applied")
+	}
+}
+
+func TestBranchAnnotation(t *testing.T) {
+	now := time.Now()
+	w := WizardModel{branchStats: map[string]branchStat{
+		"old":     {loaded: true, committed: now.Add(-50 * time.Hour), compared: true, ahead: 3, behind: 12},
+		"even":    {loaded: true, committed: now.Add(-5 * time.Minute), compared: true},
+		"pending": {},
+	}}
+	for name, want := range map[string]string{
+		"old":     "2d2h ago  ↑3 ↓12",
+		"even":    "5m ago",
+		"pending": "",
+		"unknown": "",
+	} {
+		if got := w.branchAnnotation(name, now); got != want {
+			t.Errorf("branchAnnotation(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
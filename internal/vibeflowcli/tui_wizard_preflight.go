@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// preflightMsg carries the checks run for the launch identified by key.
+type preflightMsg struct {
+	key    string
+	checks []preflightCheck
+}
+
+// preflightRequest describes the launch the confirm step would make.
+func (w WizardModel) preflightRequest() preflightRequest {
+	req := preflightRequest{
+		Env:     w.envVars,
+		Gateway: w.selectedSessionType == 1 && w.llmGatewayEnabled,
+	}
+	if w.selectedSessionType == 1 && w.config != nil {
+		req.ServerURL = w.config.ServerURL
+	}
+	plan := w.launchPlan()
+	req.Launch = &plan
+	add := func(idx int) {
+		if idx < 0 || idx >= len(w.providers) {
+			return
+		}
+		pe := w.providers[idx]
+		for _, t := range req.Targets {
+			if t.Key == pe.key {
+				return
+			}
+		}
+		prov := pe.provider
+		if idx == w.selectedProvider && w.binaryPath != "" {
+			prov.Binary = w.binaryPath
+		}
+		req.Targets = append(req.Targets, preflightTarget{Key: pe.key, Provider: prov})
+	}
+	if w.teamModeProvider() {
+		for _, personaIdx := range w.selectedPersonaIndices() {
+			add(w.resolvedProviderForPersona(personaIdx))
+		}
+	} else {
+		add(w.selectedProvider)
+	}
+	return req
+}
+
+// launchPlan describes where the launch the confirm step would make puts
+// the agent, for validateLaunch.
+func (w WizardModel) launchPlan() launchPlan {
+	choice, existing := w.worktreeChoice()
+	plan := launchPlan{
+		RepoDir:      w.selectedWorkDir,
+		Choice:       choice,
+		NewBranch:    w.selectedBranch == 0,
+		Base:         w.newBranchBase,
+		WorktreeName: w.worktreeName,
+		CustomDir:    w.customBaseDir,
+		AgentDir:     firstNonEmpty(existing, w.specifiedWorkDir),
+	}
+	if w.selectedBranch < len(w.branches) || w.selectedBranch == 0 {
+		plan.Branch = w.resolvedBranch()
+	}
+	if w.config != nil {
+		plan.BaseDir = w.config.Worktree.BaseDir
+		if plan.RepoDir == "" {
+			plan.RepoDir = w.config.ResolveWorkDir("")
+		}
+	}
+	return plan
+}
+
+// loadPreflight starts the preflight checks when the confirm step shows a
+// launch they haven't run for yet. It returns nil when there is nothing
+// to run.
+func (w *WizardModel) loadPreflight() tea.Cmd {
+	if w.step != StepConfirm || w.groupEdit || w.done {
+		return nil
+	}
+	req := w.preflightRequest()
+	key := req.key()
+	if key == w.preflightKey {
+		return nil
+	}
+	w.preflightKey = key
+	w.preflight = nil
+	w.preflightDone = false
+	w.preflightAck = false
+	return func() tea.Msg {
+		return preflightMsg{key: key, checks: runPreflight(req)}
+	}
+}
+
+// applyPreflight records finished checks, dropping those for a launch the
+// user has since changed.
+func (w *WizardModel) applyPreflight(msg preflightMsg) {
+	if msg.key != w.preflightKey {
+		return
+	}
+	w.preflight = msg.checks
+	w.preflightDone = true
+}
+
+// preflightFailed reports whether a finished check found a problem.
+func (w WizardModel) preflightFailed() bool {
+	for _, c := range w.preflight {
+		if c.failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// preflightHoldsLaunch reports whether enter on the confirm step should
+// wait: while the checks run, and once after they fail so the user sees
+// the failure before launching anyway. A launch with no checks started
+// goes ahead.
+func (w *WizardModel) preflightHoldsLaunch() bool {
+	if w.groupEdit || w.preflightKey == "" {
+		return false
+	}
+	if !w.preflightDone {
+		return true
+	}
+	if w.preflightFailed() && !w.preflightAck {
+		w.preflightAck = true
+		return true
+	}
+	return false
+}
+
+// preflightView renders the confirm step's check results.
+func (w WizardModel) preflightView() string {
+	var b strings.Builder
+	b.WriteString("\n  Preflight:\n")
+	if !w.preflightDone {
+		b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render("    checking…") + "\n")
+		return b.String()
+	}
+	if len(w.preflight) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render("    nothing to check") + "\n")
+		return b.String()
+	}
+	for _, c := range w.preflight {
+		switch {
+		case c.Err == nil:
+			b.WriteString(fmt.Sprintf("    %s %s\n", statusRunning.Render("✓"), c.Label))
+		case c.Warn:
+			b.WriteString(fmt.Sprintf("    %s %s: %v\n", statusWaiting.Render("?"), c.Label, c.Err))
+		default:
+			b.WriteString(fmt.Sprintf("    %s %s: %v\n", statusError.Render("✗"), c.Label, c.Err))
+		}
+	}
+	return b.String()
+}
+
+// preflightHelp is the confirm step's key hint.
+func (w WizardModel) preflightHelp() string {
+	switch {
+	case !w.preflightDone:
+		return "checking…  esc: back"
+	case w.preflightFailed() && w.preflightAck:
+		return "enter: launch anyway  esc: back"
+	case w.preflightFailed():
+		return "preflight failed — enter twice to launch anyway  esc: back"
+	}
+	return "enter: create  esc: back"
+}
@@ -17,6 +17,7 @@
 package vibeflowcli
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -1197,3 +1198,176 @@ func TestWizardStepTeam_PersonaRowsAlignConsistently(t *testing.T) {
 		}
 	}
 }
+
+// modelWizardFixture returns a wizard at the permissions step with claude
+// (which takes {{.Model}}) and qwen (which doesn't) to choose from.
+func modelWizardFixture(t *testing.T, key string) WizardModel {
+	t.Helper()
+	defaults := DefaultConfig().Providers
+	claude, qwen := defaults["claude"], defaults["qwen"]
+	claude.Binary, qwen.Binary = "sh", "sh"
+	cfg := &Config{Providers: map[string]Provider{"claude": claude, "qwen": qwen}}
+	wm := NewWizardModel(NewProviderRegistry(cfg), ".", nil, nil, "", nil, cfg)
+	wm.selectedProvider = providerIdxByKey(t, wm, key)
+	if len(wm.branches) < 2 {
+		wm.branches = []string{"[+] Create new branch", "main"}
+		wm.filteredBranches = []int{0, 1}
+	}
+	wm.selectedBranch = 1
+	wm.selectedWorktree = len(wm.worktreeOpts) - 1 // "Current directory"
+	wm.step = StepPermissions
+	wm.cursor = 0
+	return wm
+}
+
+func TestWizard_ModelStep(t *testing.T) {
+	wm := modelWizardFixture(t, "claude")
+	wm, _ = wm.advance()
+	if wm.step != StepModel {
+		t.Fatalf("after permissions step = %v, want StepModel", wm.step)
+	}
+	want := []string{"Provider default", "sonnet", "opus", "haiku", "[+] Enter model id"}
+	if !slices.Equal(wm.modelOpts, want) {
+		t.Fatalf("modelOpts = %v, want %v", wm.modelOpts, want)
+	}
+	wm.cursor = 2
+	wm, _ = wm.advance()
+	if wm.step != StepConfirm {
+		t.Fatalf("after model step = %v, want StepConfirm", wm.step)
+	}
+	if !strings.Contains(wm.View(), "opus") {
+		t.Error("confirm view does not show the chosen model")
+	}
+
+	// Back to the model step keeps the choice selected.
+	wm, _ = wm.goBack()
+	if wm.step != StepModel || wm.cursor != 2 {
+		t.Fatalf("back from confirm: step %v cursor %d, want StepModel cursor 2", wm.step, wm.cursor)
+	}
+	wm, _ = wm.advance()
+	wm, _ = wm.advance()
+	if got := wm.Result().Model; got != "opus" {
+		t.Errorf("Result().Model = %q, want opus", got)
+	}
+}
+
+func TestWizard_ModelStepCustomID(t *testing.T) {
+	wm := modelWizardFixture(t, "claude")
+	wm, _ = wm.advance()
+	wm.cursor = len(wm.modelOpts) - 1
+	wm, _ = wm.advance()
+	if !wm.editingModel {
+		t.Fatal("custom entry did not open the model input")
+	}
+	for _, r := range "claude-opus-4-1" {
+		wm, _ = wm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	wm, _ = wm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if wm.step != StepConfirm || wm.model != "claude-opus-4-1" {
+		t.Fatalf("step %v model %q, want StepConfirm claude-opus-4-1", wm.step, wm.model)
+	}
+}
+
+func TestWizard_ModelStepSkippedWithoutModelTemplate(t *testing.T) {
+	wm := modelWizardFixture(t, "qwen")
+	wm, _ = wm.advance()
+	if wm.step != StepConfirm {
+		t.Fatalf("after permissions step = %v, want StepConfirm for qwen", wm.step)
+	}
+	wm.model = "stale"
+	wm, _ = wm.advance()
+	if got := wm.Result().Model; got != "" {
+		t.Errorf("Result().Model = %q, want empty for a provider without {{.Model}}", got)
+	}
+}
+
+func TestProviderModelChoices(t *testing.T) {
+	if got := (Provider{Models: []string{"m1"}}).ModelChoices("claude"); !slices.Equal(got, []string{"m1"}) {
+		t.Errorf("configured models = %v", got)
+	}
+	if got := (Provider{}).ModelChoices("gemini"); len(got) == 0 {
+		t.Error("built-in gemini has no default models")
+	}
+	if got := (Provider{}).ModelChoices("custom"); got != nil {
+		t.Errorf("custom provider models = %v, want none", got)
+	}
+}
+
+func TestWizard_SparsePathsForNewWorktree(t *testing.T) {
+	wm := modelWizardFixture(t, "qwen")
+	wm.selectedWorktree = slices.Index(wm.worktreeOpts, "New worktree")
+	wm.step = StepWorktree
+	wm.editingName = true
+	wm.worktreeName = "qwen-main"
+	wm, _ = wm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !wm.editingSparse || wm.step != StepWorktree {
+		t.Fatalf("worktree name did not open the sparse prompt (step %v)", wm.step)
+	}
+	for _, r := range "../x" {
+		wm, _ = wm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	wm, _ = wm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !wm.editingSparse || wm.sparseErr == "" {
+		t.Fatal("invalid sparse path was accepted")
+	}
+	wm.sparseInput = ""
+	for _, r := range "services/api,libs" {
+		wm, _ = wm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	wm, _ = wm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if wm.step != StepPermissions {
+		t.Fatalf("after sparse prompt step = %v, want StepPermissions", wm.step)
+	}
+	wm, _ = wm.advance()
+	if !strings.Contains(wm.View(), "sparse: services/api, libs") {
+		t.Error("confirm view does not show the sparse paths")
+	}
+	wm, _ = wm.advance()
+	if want := []string{"services/api", "libs"}; !slices.Equal(wm.result.SparsePaths, want) {
+		t.Errorf("SparsePaths = %q, want %q", wm.result.SparsePaths, want)
+	}
+}
+
+func TestWizard_ProfileStep(t *testing.T) {
+	wm := modelWizardFixture(t, "qwen")
+	wm.config.Profiles = map[string]CredentialProfile{
+		"work":     {Provider: "qwen", Env: map[string]string{"OPENAI_API_KEY": "k"}},
+		"personal": {Provider: "qwen", Env: map[string]string{"OPENAI_API_KEY": "k2"}},
+		"other":    {Provider: "claude", Env: map[string]string{"ANTHROPIC_API_KEY": "k3"}},
+	}
+	wm, _ = wm.advance()
+	if wm.step != StepProfile {
+		t.Fatalf("after permissions step = %v, want StepProfile", wm.step)
+	}
+	want := []string{"Default login", "personal", "work"}
+	if !slices.Equal(wm.profileOpts, want) {
+		t.Fatalf("profileOpts = %v, want %v", wm.profileOpts, want)
+	}
+	wm.cursor = 2
+	wm, _ = wm.advance()
+	if wm.step != StepConfirm || !strings.Contains(wm.View(), "Account:       work") {
+		t.Fatalf("step %v; confirm view should show the chosen account", wm.step)
+	}
+	wm, _ = wm.goBack()
+	if wm.step != StepProfile || wm.cursor != 2 {
+		t.Fatalf("back from confirm: step %v cursor %d, want StepProfile cursor 2", wm.step, wm.cursor)
+	}
+	wm, _ = wm.advance()
+	wm, _ = wm.advance()
+	if got := wm.Result().Profile; got != "work" {
+		t.Errorf("Result().Profile = %q, want work", got)
+	}
+}
+
+func TestWizard_ProfileStepSkippedWithoutProfiles(t *testing.T) {
+	wm := modelWizardFixture(t, "qwen")
+	wm.profile = "stale"
+	wm, _ = wm.advance()
+	if wm.step != StepConfirm {
+		t.Fatalf("after permissions step = %v, want StepConfirm", wm.step)
+	}
+	wm, _ = wm.advance()
+	if got := wm.Result().Profile; got != "" {
+		t.Errorf("Result().Profile = %q, want empty without profiles", got)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// worktreeProgressLines is how much of git's output the progress view keeps.
+const worktreeProgressLines = 8
+
+// worktreeSpinnerFrames animate the progress view while git runs.
+var worktreeSpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// worktreeOutputMsg carries one line git printed while creating a worktree.
+type worktreeOutputMsg struct{ line string }
+
+// worktreeCreatedMsg reports the end of a worktree creation started by
+// startLaunch: the launch it was created for and the new worktree's path.
+type worktreeCreatedMsg struct {
+	result WizardResult
+	path   string
+	err    error
+}
+
+// worktreeSpinMsg advances the progress view's spinner.
+type worktreeSpinMsg struct{}
+
+func worktreeSpinCmd() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return worktreeSpinMsg{} })
+}
+
+// waitWorktreeEvent delivers the next message from a running worktree
+// creation; the channel is closed after its worktreeCreatedMsg.
+func waitWorktreeEvent(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// WorktreeProgressModel shows a worktree being created: a spinner, the
+// elapsed time and git's latest output. Esc cancels the creation.
+type WorktreeProgressModel struct {
+	branch     string
+	dir        string // where the worktree goes
+	started    time.Time
+	lines      []string
+	frame      int
+	cancel     context.CancelFunc
+	cancelling bool
+}
+
+// NewWorktreeProgressModel creates the view for a creation that cancel stops.
+func NewWorktreeProgressModel(branch, dir string, cancel context.CancelFunc) WorktreeProgressModel {
+	return WorktreeProgressModel{branch: branch, dir: dir, started: time.Now(), cancel: cancel}
+}
+
+// Update handles git output, spinner ticks and the cancel key.
+func (pm WorktreeProgressModel) Update(msg tea.Msg) (WorktreeProgressModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case worktreeOutputMsg:
+		pm.lines = append(pm.lines, msg.line)
+		if len(pm.lines) > worktreeProgressLines {
+			pm.lines = pm.lines[len(pm.lines)-worktreeProgressLines:]
+		}
+	case worktreeSpinMsg:
+		pm.frame = (pm.frame + 1) % len(worktreeSpinnerFrames)
+		return pm, worktreeSpinCmd()
+	case tea.KeyPressMsg:
+		if msg.String() == "esc" && !pm.cancelling {
+			pm.cancelling = true
+			pm.cancel()
+		}
+	}
+	return pm, nil
+}
+
+// View renders the progress screen.
+func (pm WorktreeProgressModel) View() string {
+	var b strings.Builder
+	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	b.WriteString(title.Render("Creating worktree"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("  Branch:  %s\n", pm.branch))
+	if pm.dir != "" {
+		b.WriteString(fmt.Sprintf("  In:      %s\n", pm.dir))
+	}
+	b.WriteString("\n")
+	status := "running git worktree add"
+	if pm.cancelling {
+		status = "cancelling"
+	}
+	elapsed := time.Since(pm.started).Truncate(time.Second)
+	b.WriteString(lipgloss.NewStyle().Foreground(accentColor).Render(worktreeSpinnerFrames[pm.frame]))
+	b.WriteString(fmt.Sprintf(" %s... %s\n\n", status, elapsed))
+	for _, line := range pm.lines {
+		b.WriteString(dim.Render("  " + line))
+		b.WriteString("\n")
+	}
+	if len(pm.lines) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(helpStyle.Render("esc: cancel"))
+	return b.String()
+}
+
+// needsWorktree reports whether launching result creates a worktree first.
+func needsWorktree(result WizardResult) bool {
+	return result.WorktreeChoice == WorktreeNew ||
+		(result.WorktreeChoice == WorktreeCustom && result.CustomBaseDir != "")
+}
+
+// startLaunch launches a wizard result. One that needs a new worktree gets
+// it created first, in the background with the progress view showing git's
+// output, and launches into it once it exists; everything else goes
+// straight to launchFromWizard.
+func (m Model) startLaunch(result WizardResult) (Model, tea.Cmd) {
+	wm := m.worktrees
+	if result.WorkDir != "" && (wm == nil || wm.RepoRoot() != result.WorkDir) {
+		if newWM, err := NewWorktreeManager(result.WorkDir, m.config.Worktree.BaseDir); err == nil {
+			newWM.SetSetup(m.config.Worktree.Setup)
+			wm = newWM
+		}
+	}
+	if !needsWorktree(result) || wm == nil {
+		return m, func() tea.Msg { return m.launchFromWizard(result) }
+	}
+
+	name := result.WorktreeName
+	if name == "" {
+		name = fmt.Sprintf("%s-%s-%d", result.ProviderKey, result.Branch, time.Now().Unix())
+	}
+	customDir := ""
+	dir := wm.RepoRoot()
+	if result.WorktreeChoice == WorktreeCustom {
+		customDir, dir = result.CustomBaseDir, result.CustomBaseDir
+	}
+
+	result.BranchCreated = createsBranch(wm.RepoRoot(), result.Branch)
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan tea.Msg, 16)
+	go func() {
+		defer close(events)
+		defer cancel()
+		path, err := wm.CreateBranchContext(ctx, customDir, name, result.Branch, result.NewBranch, result.NewBranchBase, result.SparsePaths, func(line string) {
+			events <- worktreeOutputMsg{line: line}
+		})
+		events <- worktreeCreatedMsg{result: result, path: path, err: err}
+	}()
+
+	m.logger.Info("creating worktree %s for branch %s", name, result.Branch)
+	m.worktreeProgress = NewWorktreeProgressModel(result.Branch, dir, cancel)
+	m.worktreeEvents = events
+	m.activeView = ViewWorktreeProgress
+	return m, tea.Batch(waitWorktreeEvent(events), worktreeSpinCmd())
+}
+
+// handleWorktreeCreated continues a launch once its worktree exists. The
+// launch then reuses the worktree like one picked in the wizard.
+func (m Model) handleWorktreeCreated(msg worktreeCreatedMsg) (Model, tea.Cmd) {
+	m.worktreeEvents = nil
+	if m.activeView == ViewWorktreeProgress {
+		m.activeView = ViewSessions
+	}
+	if errors.Is(msg.err, context.Canceled) {
+		m.logger.Info("worktree creation for %s cancelled", msg.result.Branch)
+		return m, nil
+	}
+	if msg.err != nil {
+		err := fmt.Errorf("create worktree: %w", msg.err)
+		return m, func() tea.Msg { return sessionsMsg{err: err} }
+	}
+	result := msg.result
+	if result.WorktreeChoice == WorktreeCustom {
+		// Persist last-used custom dir for convenience.
+		m.config.Worktree.LastCustomDir = result.CustomBaseDir
+		_ = SaveConfig(m.config, ConfigPath())
+	}
+	result.WorktreeChoice = WorktreeExisting
+	result.ExistingWorktreePath = msg.path
+	return m, func() tea.Msg { return m.launchFromWizard(result) }
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestWorktreeProgressModel(t *testing.T) {
+	cancels := 0
+	pm := NewWorktreeProgressModel("feat/x", "/repo", func() { cancels++ })
+	for i := range worktreeProgressLines + 3 {
+		pm, _ = pm.Update(worktreeOutputMsg{line: fmt.Sprintf("line %d", i)})
+	}
+	if len(pm.lines) != worktreeProgressLines || pm.lines[0] != "line 3" {
+		t.Errorf("lines = %q, want the last %d", pm.lines, worktreeProgressLines)
+	}
+	if !strings.Contains(pm.View(), "feat/x") {
+		t.Error("view does not show the branch")
+	}
+
+	pm, _ = pm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	pm, _ = pm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if cancels != 1 || !pm.cancelling {
+		t.Errorf("cancels = %d cancelling = %v, want one cancel", cancels, pm.cancelling)
+	}
+	if !strings.Contains(pm.View(), "cancelling") {
+		t.Error("view does not show the cancellation")
+	}
+}
+
+func TestStartLaunch_CreatesWorktreeInBackground(t *testing.T) {
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{config: &Config{}, worktrees: wm, logger: &Logger{}}
+	result := WizardResult{
+		ProviderKey:    "claude",
+		Branch:         "feat-bg",
+		NewBranch:      true,
+		WorktreeChoice: WorktreeNew,
+		WorktreeName:   "bg",
+	}
+	m, _ = m.startLaunch(result)
+	if m.activeView != ViewWorktreeProgress || m.worktreeEvents == nil {
+		t.Fatalf("view = %v, want the worktree progress view", m.activeView)
+	}
+
+	var created worktreeCreatedMsg
+	for msg := range m.worktreeEvents {
+		if c, ok := msg.(worktreeCreatedMsg); ok {
+			created = c
+		}
+	}
+	if created.err != nil {
+		t.Fatal(created.err)
+	}
+	if want := filepath.Join(wm.RepoRoot(), ".worktrees", "bg"); created.path != want {
+		t.Errorf("path = %q, want %q", created.path, want)
+	}
+	if _, err := os.Stat(created.path); err != nil {
+		t.Errorf("worktree missing: %v", err)
+	}
+
+	m, cmd := m.handleWorktreeCreated(worktreeCreatedMsg{result: result, err: context.Canceled})
+	if m.activeView != ViewSessions || cmd != nil {
+		t.Errorf("cancelled: view %v cmd %v, want back on the list with nothing to run", m.activeView, cmd != nil)
+	}
+	_, cmd = m.handleWorktreeCreated(worktreeCreatedMsg{result: result, err: fmt.Errorf("boom")})
+	if msg, ok := cmd().(sessionsMsg); !ok || msg.err == nil {
+		t.Errorf("failure produced %v, want a sessionsMsg error", msg)
+	}
+}
+
+func TestStartLaunch_NoWorktreeLaunchesDirectly(t *testing.T) {
+	m := Model{config: &Config{}, logger: &Logger{}}
+	m, cmd := m.startLaunch(WizardResult{WorktreeChoice: WorktreeCurrent})
+	if m.activeView == ViewWorktreeProgress || cmd == nil {
+		t.Errorf("view %v, want a direct launch command", m.activeView)
+	}
+}
@@ -19,7 +19,9 @@ package vibeflowcli
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss"
@@ -41,6 +43,52 @@ type WorktreeListModel struct {
 	done      bool
 	deleted   bool // set when a delete occurred (triggers refresh)
 	deletedWt string
+
+	wm    *WorktreeManager
+	store *Store
+
+	// Changes panel (s).
+	changesFor string // worktree path the panel describes; "" = hidden
+	changes    *WorktreeChanges
+	changesErr error
+
+	// Guided merge (m).
+	merging     bool         // merge options dialog open
+	mergeOpts   MergeOptions // options being edited
+	mergeRun    bool         // merge in progress
+	mergeResult *worktreeMergeMsg
+
+	// Branch list (b), shown instead of the worktrees while open.
+	branches *BranchListModel
+
+	// Disk usage, measured in the background by Init; z sorts by it.
+	sizeCache  *sizeCache
+	sizes      map[string]int64 // path → bytes; missing until measured
+	sizeErrs   map[string]bool  // paths that couldn't be measured
+	basePath   string           // worktree base dir, measured for the total
+	sortBySize bool
+	listOrder  map[string]int // path → position in git's list, to undo the sort
+}
+
+// worktreeChangesMsg carries the status/diff summary for one worktree.
+type worktreeChangesMsg struct {
+	path    string
+	changes WorktreeChanges
+	err     error
+}
+
+// worktreeSizeMsg carries the measured disk usage of one directory.
+type worktreeSizeMsg struct {
+	path  string
+	bytes int64
+	err   error
+}
+
+// worktreeMergeMsg reports the outcome of a guided merge.
+type worktreeMergeMsg struct {
+	path string
+	log  []string
+	err  error
 }
 
 // NewWorktreeListModel creates a worktree list from live data.
@@ -92,7 +140,96 @@ func NewWorktreeListModel(wm *WorktreeManager, store *Store) WorktreeListModel {
 		})
 	}
 
-	return WorktreeListModel{rows: rows}
+	listOrder := make(map[string]int, len(rows))
+	for i, row := range rows {
+		listOrder[row.Path] = i
+	}
+	return WorktreeListModel{
+		rows:      rows,
+		wm:        wm,
+		store:     store,
+		sizes:     make(map[string]int64),
+		sizeErrs:  make(map[string]bool),
+		basePath:  wm.BaseDirPath(),
+		listOrder: listOrder,
+	}
+}
+
+// Init measures the disk usage of every worktree and of the base dir, one
+// directory at a time so a slow disk isn't hit with every walk at once.
+func (wl WorktreeListModel) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, row := range wl.rows {
+		cmds = append(cmds, wl.measure(row.Path))
+	}
+	if wl.basePath != "" {
+		cmds = append(cmds, wl.measure(wl.basePath))
+	}
+	return tea.Sequence(cmds...)
+}
+
+// measure returns a command that reports path's size, from the cache when
+// it is fresh. Other worktrees nested inside path, such as the base dir
+// inside the main checkout, are left out so no space is counted twice.
+func (wl WorktreeListModel) measure(path string) tea.Cmd {
+	skip := make(map[string]bool)
+	for _, row := range wl.rows {
+		if row.Path != path {
+			skip[filepath.Clean(row.Path)] = true
+		}
+	}
+	if wl.basePath != "" && path != wl.basePath {
+		skip[filepath.Clean(wl.basePath)] = true
+	}
+	cache := wl.sizeCache
+	return func() tea.Msg {
+		now := time.Now()
+		if n, ok := cache.Get(path, now); ok {
+			return worktreeSizeMsg{path: path, bytes: n}
+		}
+		n, err := dirSize(path, skip)
+		if err == nil {
+			cache.Put(path, n, now)
+		}
+		return worktreeSizeMsg{path: path, bytes: n, err: err}
+	}
+}
+
+// remeasureBase refreshes the base dir total after worktrees were removed.
+func (wl WorktreeListModel) remeasureBase() tea.Cmd {
+	if wl.basePath == "" {
+		return nil
+	}
+	wl.sizeCache.Forget(wl.basePath)
+	return wl.measure(wl.basePath)
+}
+
+// sortRows orders the rows by size, largest first with unmeasured ones
+// last, or back in git's order. The cursor stays on the same worktree.
+func (wl *WorktreeListModel) sortRows() {
+	current := ""
+	if wl.cursor < len(wl.rows) {
+		current = wl.rows[wl.cursor].Path
+	}
+	sort.SliceStable(wl.rows, func(i, j int) bool {
+		a, b := wl.rows[i].Path, wl.rows[j].Path
+		if wl.sortBySize {
+			sa, okA := wl.sizes[a]
+			sb, okB := wl.sizes[b]
+			if okA != okB {
+				return okA
+			}
+			if sa != sb {
+				return sa > sb
+			}
+		}
+		return wl.listOrder[a] < wl.listOrder[b]
+	})
+	for i, row := range wl.rows {
+		if row.Path == current {
+			wl.cursor = i
+		}
+	}
 }
 
 // Done returns true when the user is done with the worktree view.
@@ -106,8 +243,25 @@ func (wl WorktreeListModel) DeletedPath() string { return wl.deletedWt }
 
 // Update handles input for the worktree list.
 func (wl WorktreeListModel) Update(msg tea.Msg) (WorktreeListModel, tea.Cmd) {
+	if wl.branches != nil {
+		bl, cmd := wl.branches.Update(msg)
+		if bl.Done() {
+			// Deleting branches may have removed worktrees.
+			wl.branches = nil
+			wl.reload()
+			return wl, wl.remeasureBase()
+		}
+		wl.branches = &bl
+		return wl, cmd
+	}
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		if wl.merging {
+			return wl.updateMerge(msg)
+		}
+		if wl.mergeRun {
+			return wl, nil // wait for the merge to finish
+		}
 		switch msg.String() {
 		case "up", "k":
 			if wl.cursor > 0 {
@@ -126,15 +280,120 @@ func (wl WorktreeListModel) Update(msg tea.Msg) (WorktreeListModel, tea.Cmd) {
 				}
 				// Active worktrees can't be deleted from here — kill session first.
 			}
+		case "s":
+			if wl.cursor < len(wl.rows) && wl.wm != nil {
+				path := wl.rows[wl.cursor].Path
+				if wl.changesFor == path {
+					wl.changesFor = "" // toggle off
+					return wl, nil
+				}
+				wl.changesFor, wl.changes, wl.changesErr = path, nil, nil
+				return wl, wl.loadChanges(path)
+			}
+		case "m":
+			if wl.cursor < len(wl.rows) && wl.wm != nil {
+				// Default: rebase + fast-forward, no push, keep the worktree.
+				// Removal is only offered for worktrees no session is using.
+				wl.merging = true
+				wl.mergeOpts = MergeOptions{Rebase: true}
+				wl.mergeResult = nil
+			}
+		case "z":
+			wl.sortBySize = !wl.sortBySize
+			wl.sortRows()
+		case "b":
+			if wl.wm != nil {
+				bl := NewBranchListModel(wl.wm, wl.store)
+				wl.branches = &bl
+			}
 		case "esc":
 			wl.done = true
 		}
+	case worktreeChangesMsg:
+		if msg.path == wl.changesFor {
+			wl.changes, wl.changesErr = &msg.changes, msg.err
+		}
+	case worktreeSizeMsg:
+		if wl.sizes == nil {
+			wl.sizes, wl.sizeErrs = make(map[string]int64), make(map[string]bool)
+		}
+		if msg.err != nil {
+			wl.sizeErrs[msg.path] = true
+		} else {
+			wl.sizes[msg.path] = msg.bytes
+		}
+		if wl.sortBySize {
+			wl.sortRows()
+		}
+	case worktreeMergeMsg:
+		wl.mergeRun = false
+		wl.mergeResult = &msg
+		if msg.err == nil {
+			wl.reload()
+			if wl.mergeOpts.RemoveWorktree {
+				wl.sizeCache.Forget(msg.path)
+				return wl, wl.remeasureBase()
+			}
+		}
+	}
+	return wl, nil
+}
+
+// updateMerge handles keys while the merge dialog is open.
+func (wl WorktreeListModel) updateMerge(msg tea.KeyPressMsg) (WorktreeListModel, tea.Cmd) {
+	if wl.cursor >= len(wl.rows) {
+		wl.merging = false
+		return wl, nil
+	}
+	row := wl.rows[wl.cursor]
+	switch msg.String() {
+	case "r":
+		wl.mergeOpts.Rebase = !wl.mergeOpts.Rebase
+	case "p":
+		wl.mergeOpts.Push = !wl.mergeOpts.Push
+	case "x":
+		if row.Status == "orphaned" {
+			wl.mergeOpts.RemoveWorktree = !wl.mergeOpts.RemoveWorktree
+		}
+	case "enter", "y":
+		wl.merging = false
+		wl.mergeRun = true
+		wm, opts := wl.wm, wl.mergeOpts
+		return wl, func() tea.Msg {
+			log, err := wm.MergeBack(row.Path, row.Branch, opts)
+			return worktreeMergeMsg{path: row.Path, log: log, err: err}
+		}
+	case "esc", "n", "q":
+		wl.merging = false
 	}
 	return wl, nil
 }
 
+func (wl WorktreeListModel) loadChanges(path string) tea.Cmd {
+	wm := wl.wm
+	return func() tea.Msg {
+		c, err := wm.Changes(path)
+		return worktreeChangesMsg{path: path, changes: c, err: err}
+	}
+}
+
+// reload rebuilds the rows after a merge (which may have removed a worktree),
+// keeping the cursor and the merge result on screen.
+func (wl *WorktreeListModel) reload() {
+	fresh := NewWorktreeListModel(wl.wm, wl.store)
+	wl.rows, wl.listOrder = fresh.rows, fresh.listOrder
+	wl.sortRows()
+	if wl.cursor >= len(wl.rows) {
+		wl.cursor = max(len(wl.rows)-1, 0)
+	}
+	wl.changesFor, wl.changes, wl.changesErr = "", nil, nil
+}
+
 // View renders the worktree list.
 func (wl WorktreeListModel) View() string {
+	if wl.branches != nil {
+		return wl.branches.View()
+	}
 	var b strings.Builder
 
 	title := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
@@ -146,7 +405,7 @@ func (wl WorktreeListModel) View() string {
 		b.WriteString("\n")
 	} else {
 		// Header
-		header := fmt.Sprintf("  %-40s %-16s %-20s %-10s", "PATH", "BRANCH", "SESSION", "STATUS")
+		header := fmt.Sprintf("  %-40s %-16s %-20s %8s  %-10s", "PATH", "BRANCH", "SESSION", "SIZE", "STATUS")
 		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(dimColor).Render(header))
 		b.WriteString("\n")
 
@@ -170,11 +429,12 @@ func (wl WorktreeListModel) View() string {
 				statusStyle = lipgloss.NewStyle().Foreground(warningColor)
 			}
 
-			line := fmt.Sprintf("%s%-40s %-16s %-20s %s",
+			line := fmt.Sprintf("%s%-40s %-16s %-20s %8s  %s",
 				cursor,
 				truncate(row.Path, 40),
 				truncate(row.Branch, 16),
 				truncate(session, 20),
+				wl.sizeLabel(row.Path),
 				statusStyle.Render(row.Status),
 			)
 			b.WriteString(style.Render(line))
@@ -182,8 +442,122 @@ func (wl WorktreeListModel) View() string {
 		}
 	}
 
+	if total := wl.totalLine(); total != "" {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(dimColor).Render(total))
+		b.WriteString("\n")
+	}
+	b.WriteString(wl.viewPanel())
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("d: delete orphaned  j/k: navigate  esc: back"))
+	switch {
+	case wl.merging:
+		b.WriteString(helpStyle.Render("r: rebase/merge  p: push  x: remove worktree  enter: run  esc: cancel"))
+	case wl.mergeRun:
+		b.WriteString(helpStyle.Render("merging..."))
+	default:
+		b.WriteString(helpStyle.Render("s: status/diff  m: merge to base  d: delete orphaned  b: branches  z: sort by size  j/k: navigate  esc: back"))
+	}
+
+	return b.String()
+}
 
+// sizeLabel renders path's disk usage for the SIZE column.
+func (wl WorktreeListModel) sizeLabel(path string) string {
+	if n, ok := wl.sizes[path]; ok {
+		return formatBytes(n)
+	}
+	if wl.sizeErrs[path] {
+		return "?"
+	}
+	return "..."
+}
+
+// totalLine describes the space used under the base dir, or "" until it
+// has been measured.
+func (wl WorktreeListModel) totalLine() string {
+	n, ok := wl.sizes[wl.basePath]
+	if !ok {
+		return ""
+	}
+	base := wl.basePath
+	if rel, err := filepath.Rel(wl.wm.RepoRoot(), base); err == nil && !strings.HasPrefix(rel, "..") {
+		base = rel
+	}
+	line := fmt.Sprintf("%s used under %s", formatBytes(n), base)
+	if wl.sortBySize {
+		line += " · sorted by size"
+	}
+	return line
+}
+
+// viewPanel renders the merge dialog, the last merge result or the changes
+// panel beneath the list — at most one of them.
+func (wl WorktreeListModel) viewPanel() string {
+	var b strings.Builder
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	heading := lipgloss.NewStyle().Bold(true)
+	b.WriteString("\n")
+
+	switch {
+	case wl.merging && wl.cursor < len(wl.rows):
+		row := wl.rows[wl.cursor]
+		check := func(on bool) string {
+			if on {
+				return "[x]"
+			}
+			return "[ ]"
+		}
+		strategy := "merge commit (--no-ff)"
+		if wl.mergeOpts.Rebase {
+			strategy = "rebase onto base, then fast-forward"
+		}
+		b.WriteString(heading.Render(fmt.Sprintf("Merge %s into %s", row.Branch, wl.wm.MergeBaseBranch())))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("  strategy: %s\n", strategy))
+		b.WriteString(fmt.Sprintf("  %s push base to origin\n", check(wl.mergeOpts.Push)))
+		if row.Status == "orphaned" {
+			b.WriteString(fmt.Sprintf("  %s remove worktree afterwards\n", check(wl.mergeOpts.RemoveWorktree)))
+		} else {
+			b.WriteString(dim.Render(fmt.Sprintf("  worktree is used by %s and will be kept", row.Session)))
+			b.WriteString("\n")
+		}
+	case wl.mergeResult != nil:
+		r := wl.mergeResult
+		for _, line := range r.log {
+			b.WriteString(lipgloss.NewStyle().Foreground(oceanSuccess).Render("  ✓ " + line))
+			b.WriteString("\n")
+		}
+		if r.err != nil {
+			b.WriteString(statusError.Render("  ✗ " + r.err.Error()))
+		} else {
+			b.WriteString(lipgloss.NewStyle().Foreground(oceanSuccess).Render("Merge complete."))
+		}
+		b.WriteString("\n")
+	case wl.changesFor != "":
+		b.WriteString(heading.Render("Changes in " + wl.changesFor))
+		b.WriteString("\n")
+		switch {
+		case wl.changesErr != nil:
+			b.WriteString(statusError.Render(wl.changesErr.Error()))
+			b.WriteString("\n")
+		case wl.changes == nil:
+			b.WriteString(dim.Render("loading..."))
+			b.WriteString("\n")
+		default:
+			c := wl.changes
+			b.WriteString(fmt.Sprintf("%d ahead, %d behind %s\n", c.Ahead, c.Behind, c.Base))
+			if c.Status == "" {
+				b.WriteString(dim.Render("No uncommitted changes."))
+			} else {
+				b.WriteString("Uncommitted:\n" + c.Status)
+			}
+			b.WriteString("\n")
+			if c.DiffStat != "" {
+				b.WriteString("Committed since " + c.Base + ":\n" + c.DiffStat + "\n")
+			}
+		}
+	default:
+		return ""
+	}
 	return b.String()
 }
@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenUsage is the token count and cost of one session run, as reported
+// by the agent itself. Claude Code prints a cost summary on exit and for
+// /cost; codex prints a token usage line on exit. Both are cumulative for
+// the run, so the last summary in the output is the current total.
+type TokenUsage struct {
+	Input      int64   `json:"input,omitempty"`
+	Output     int64   `json:"output,omitempty"`
+	CacheRead  int64   `json:"cache_read,omitempty"`
+	CacheWrite int64   `json:"cache_write,omitempty"`
+	CostUSD    float64 `json:"cost_usd,omitempty"`
+	// Estimated is true when CostUSD was computed from the provider's
+	// configured prices rather than reported by the agent.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// Tokens is the total number of tokens, cache traffic included.
+func (u TokenUsage) Tokens() int64 {
+	return u.Input + u.Output + u.CacheRead + u.CacheWrite
+}
+
+// IsZero reports whether no usage was recorded.
+func (u TokenUsage) IsZero() bool {
+	return u == TokenUsage{}
+}
+
+// Add returns the sum of u and o. The sum is estimated if either part is.
+func (u TokenUsage) Add(o TokenUsage) TokenUsage {
+	return TokenUsage{
+		Input:      u.Input + o.Input,
+		Output:     u.Output + o.Output,
+		CacheRead:  u.CacheRead + o.CacheRead,
+		CacheWrite: u.CacheWrite + o.CacheWrite,
+		CostUSD:    u.CostUSD + o.CostUSD,
+		Estimated:  u.Estimated || o.Estimated,
+	}
+}
+
+var (
+	// Claude Code: "Total cost:            $0.0563"
+	claudeCostRe = regexp.MustCompile(`Total cost:\s+\$([0-9][0-9,]*(?:\.[0-9]+)?)`)
+	// Claude Code, under "Usage by model:":
+	//   "claude-sonnet:  5 input, 507 output, 44.0k cache read, 6.8k cache write ($0.05)"
+	claudeModelRe = regexp.MustCompile(`^\s*[\w.\-@\[\]/]+:\s+([0-9.,]+[kmKM]?) input, ([0-9.,]+[kmKM]?) output(?:, ([0-9.,]+[kmKM]?) cache read)?(?:, ([0-9.,]+[kmKM]?) cache write)?`)
+	// codex: "Token usage: total=12,345 input=10,000 (+ 5,000 cached) output=2,345 (reasoning 100)"
+	codexUsageRe = regexp.MustCompile(`Token usage: total=([0-9,]+) input=([0-9,]+)(?: \(\+ ([0-9,]+) cached\))? output=([0-9,]+)`)
+)
+
+// ParseUsage extracts the most recent usage summary from agent output. ok is
+// false when the output holds no summary.
+func ParseUsage(output string) (usage TokenUsage, ok bool) {
+	claudeAt := strings.LastIndex(output, "Total cost:")
+	codexAt := -1
+	if locs := codexUsageRe.FindAllStringIndex(output, -1); len(locs) > 0 {
+		codexAt = locs[len(locs)-1][0]
+	}
+	switch {
+	case claudeAt >= 0 && claudeAt > codexAt:
+		return parseClaudeUsage(output[claudeAt:])
+	case codexAt >= 0:
+		m := codexUsageRe.FindStringSubmatch(output[codexAt:])
+		cached := parseTokenCount(m[3])
+		return TokenUsage{
+			// input= includes the cached part; split it out like Claude does.
+			Input:     parseTokenCount(m[2]) - cached,
+			Output:    parseTokenCount(m[4]),
+			CacheRead: cached,
+		}, true
+	}
+	return TokenUsage{}, false
+}
+
+// parseClaudeUsage parses a Claude Code cost summary starting at its
+// "Total cost:" line. Per-model token lines are summed.
+func parseClaudeUsage(block string) (TokenUsage, bool) {
+	m := claudeCostRe.FindStringSubmatch(block)
+	if m == nil {
+		return TokenUsage{}, false
+	}
+	var u TokenUsage
+	u.CostUSD, _ = strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+	lines := strings.Split(block, "\n")
+	inModels := false
+	for _, line := range lines[1:] {
+		if strings.Contains(line, "Usage by model:") {
+			inModels = true
+			continue
+		}
+		if !inModels {
+			continue
+		}
+		mm := claudeModelRe.FindStringSubmatch(line)
+		if mm == nil {
+			break
+		}
+		u.Input += parseTokenCount(mm[1])
+		u.Output += parseTokenCount(mm[2])
+		u.CacheRead += parseTokenCount(mm[3])
+		u.CacheWrite += parseTokenCount(mm[4])
+	}
+	return u, true
+}
+
+// parseTokenCount parses "12,345", "44.0k" or "1.2m". Unparseable input
+// counts as zero.
+func parseTokenCount(s string) int64 {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(strings.ToLower(s), "k"):
+		mult, s = 1e3, s[:len(s)-1]
+	case strings.HasSuffix(strings.ToLower(s), "m"):
+		mult, s = 1e6, s[:len(s)-1]
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(f*mult + 0.5)
+}
+
+// withEstimatedCost fills in CostUSD from the provider's per-million-token
+// prices when the agent didn't report a cost. Cache reads are billed as
+// input.
+func (u TokenUsage) withEstimatedCost(prov Provider) TokenUsage {
+	if u.CostUSD > 0 || (prov.InputPricePerMTok == 0 && prov.OutputPricePerMTok == 0) {
+		return u
+	}
+	u.CostUSD = float64(u.Input+u.CacheRead+u.CacheWrite)*prov.InputPricePerMTok/1e6 +
+		float64(u.Output)*prov.OutputPricePerMTok/1e6
+	u.Estimated = u.CostUSD > 0
+	return u
+}
+
+// formatTokens renders a token count compactly: 950, 12.3k, 4.1M.
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1e6:
+		return strconv.FormatFloat(float64(n)/1e6, 'f', 1, 64) + "M"
+	case n >= 1e3:
+		return strconv.FormatFloat(float64(n)/1e3, 'f', 1, 64) + "k"
+	default:
+		return strconv.FormatInt(n, 10)
+	}
+}
+
+// formatCost renders a cost in dollars, with "~" for estimates and "-"
+// when unknown.
+func formatCost(u TokenUsage) string {
+	if u.CostUSD == 0 {
+		return "-"
+	}
+	s := "$" + strconv.FormatFloat(u.CostUSD, 'f', 2, 64)
+	if u.Estimated {
+		s = "~" + s
+	}
+	return s
+}
+
+// formatUsage is the detail panel value: "12.3k tokens · $0.42".
+func formatUsage(u TokenUsage) string {
+	s := formatTokens(u.Tokens()) + " tokens"
+	if u.CostUSD > 0 {
+		s += " · " + formatCost(u)
+	}
+	return s
+}
+
+// usageLogTail is how much of the end of a session log sessionUsage reads.
+// The summaries are cumulative, so the last one is all it needs.
+const usageLogTail = 1 << 20
+
+// readFileTail returns up to n bytes from the end of the file at path.
+func readFileTail(path string, n int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if off := info.Size() - n; off > 0 {
+		if _, err := f.Seek(off, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(io.LimitReader(f, n))
+}
+
+// sessionUsage reads the current usage of a stored session from its pane
+// scrollback, falling back to the tail of its session log (which survives
+// the tmux session) when the pane has no summary.
+func sessionUsage(tm *TmuxManager, meta SessionMeta, prov Provider) (TokenUsage, bool) {
+	if tm != nil && tm.HasSession(meta.TmuxSession) {
+		if out, err := tm.CaptureScrollback(meta.TmuxSession); err == nil {
+			if u, ok := ParseUsage(out); ok {
+				return u.withEstimatedCost(prov), true
+			}
+		}
+	}
+	if data, err := readFileTail(SessionLogPath(meta.TmuxSession), usageLogTail); err == nil {
+		if u, ok := ParseUsage(string(data)); ok {
+			return u.withEstimatedCost(prov), true
+		}
+	}
+	return TokenUsage{}, false
+}
+
+// recordUsage reads the usage of every stored session and records it on the
+// session's open history entry, so the totals outlive the tmux session. It
+// returns the usage found, keyed by short session name.
+func recordUsage(store *Store, tm *TmuxManager, registry *ProviderRegistry) (map[string]TokenUsage, error) {
+	metas, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]TokenUsage)
+	var firstErr error
+	for _, meta := range metas {
+		var prov Provider
+		if registry != nil {
+			prov, _ = registry.Get(meta.Provider)
+		}
+		u, ok := sessionUsage(tm, meta, prov)
+		if !ok {
+			continue
+		}
+		found[strings.TrimPrefix(meta.TmuxSession, sessionPrefix)] = u
+		if err := store.History().RecordUsage(meta.Name, u); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("record usage %s: %w", meta.Name, err)
+		}
+	}
+	return found, firstErr
+}
+
+// usageSampleInterval is how often the TUI rereads usage summaries. It
+// captures whole scrollbacks, so it runs far less often than the activity
+// sample.
+const usageSampleInterval = time.Minute
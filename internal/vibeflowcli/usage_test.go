@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want TokenUsage
+		ok   bool
+	}{
+		{
+			name: "claude cost summary sums every model",
+			in: `> /cost
+  ⎿  Total cost:            $1,234.0563
+     Total duration (API):  19.8s
+     Total duration (wall): 1m 12.4s
+     Total code changes:    0 lines added, 0 lines removed
+     Usage by model:
+         claude-3-5-haiku:  1.1k input, 75 output, 0 cache read, 0 cache write ($0.0010)
+            claude-sonnet:  5 input, 507 output, 44.0k cache read, 6.8k cache write ($0.0553)
+> `,
+			want: TokenUsage{Input: 1105, Output: 582, CacheRead: 44000, CacheWrite: 6800, CostUSD: 1234.0563},
+			ok:   true,
+		},
+		{
+			name: "claude summary without a model breakdown",
+			in:   "Total cost: $0.50\nTotal duration (API): 3s\n",
+			want: TokenUsage{CostUSD: 0.5},
+			ok:   true,
+		},
+		{
+			name: "the last summary wins",
+			in:   "Total cost: $0.10\n...\nTotal cost: $0.25\n",
+			want: TokenUsage{CostUSD: 0.25},
+			ok:   true,
+		},
+		{
+			name: "codex token usage splits out cached input",
+			in:   "some output\nToken usage: total=12,345 input=10,000 (+ 4,000 cached) output=2,345 (reasoning 100)\n",
+			want: TokenUsage{Input: 6000, CacheRead: 4000, Output: 2345},
+			ok:   true,
+		},
+		{
+			name: "codex without cached tokens",
+			in:   "Token usage: total=150 input=100 output=50",
+			want: TokenUsage{Input: 100, Output: 50},
+			ok:   true,
+		},
+		{
+			name: "no summary",
+			in:   "just some agent output mentioning the cost of things",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseUsage(tt.in)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParseUsage() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestReadFileTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.log")
+	summary := "Token usage: total=1,500 input=1,000 output=500\n"
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 3*usageLogTail)+"\n"+summary), 0600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := readFileTail(path, usageLogTail)
+	if err != nil || len(data) != usageLogTail {
+		t.Fatalf("read %d bytes, %v; want %d", len(data), err, usageLogTail)
+	}
+	if !strings.HasSuffix(string(data), summary) {
+		t.Error("the tail should end with the file's last line")
+	}
+	if data, _ := readFileTail(path, 1<<30); len(data) != 3*usageLogTail+1+len(summary) {
+		t.Errorf("short file: read %d bytes", len(data))
+	}
+}
+
+func TestTokenUsage_EstimatedCost(t *testing.T) {
+	prov := Provider{InputPricePerMTok: 2, OutputPricePerMTok: 10}
+	got := TokenUsage{Input: 500_000, CacheRead: 500_000, Output: 100_000}.withEstimatedCost(prov)
+	if got.CostUSD != 3 || !got.Estimated {
+		t.Errorf("estimate = %+v, want $3 estimated", got)
+	}
+	if formatCost(got) != "~$3.00" {
+		t.Errorf("formatCost = %q", formatCost(got))
+	}
+	// A reported cost is kept as is.
+	reported := TokenUsage{Input: 1000, CostUSD: 0.42}.withEstimatedCost(prov)
+	if reported.CostUSD != 0.42 || reported.Estimated {
+		t.Errorf("reported cost changed: %+v", reported)
+	}
+	// No prices, no estimate.
+	if u := (TokenUsage{Input: 1000}).withEstimatedCost(Provider{}); u.CostUSD != 0 || formatCost(u) != "-" {
+		t.Errorf("unpriced = %+v", u)
+	}
+}
+
+func TestFormatTokens(t *testing.T) {
+	for n, want := range map[int64]string{0: "0", 950: "950", 12_345: "12.3k", 4_100_000: "4.1M"} {
+		if got := formatTokens(n); got != want {
+			t.Errorf("formatTokens(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestSessionHistory_RecordUsage(t *testing.T) {
+	h := NewSessionHistoryWithPath(filepath.Join(t.TempDir(), "history.json"))
+	_ = h.Start(SessionMeta{Name: "api", CreatedAt: time.Now()})
+	u := TokenUsage{Input: 10, Output: 20, CostUSD: 0.01}
+	if err := h.RecordUsage("api", u); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RecordUsage("gone", u); err != nil {
+		t.Errorf("RecordUsage on a session without an open entry: %v", err)
+	}
+	entries, _ := h.List()
+	if len(entries) != 1 || entries[0].Usage == nil || *entries[0].Usage != u {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestPrintCosts_TotalsPerProject(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	entry := func(name, project string, u TokenUsage) HistoryEntry {
+		return HistoryEntry{SessionMeta: SessionMeta{Name: name, Provider: "claude", Project: project, CreatedAt: at}, Usage: &u}
+	}
+	entries := []HistoryEntry{
+		entry("a", "web", TokenUsage{Input: 1000, CostUSD: 1}),
+		entry("b", "api", TokenUsage{Input: 500, CostUSD: 3}),
+		entry("c", "web", TokenUsage{Output: 1000, CostUSD: 0.5, Estimated: true}),
+	}
+
+	projects := usageByProject(entries)
+	if len(projects) != 2 || projects[0].Project != "api" || projects[1].Project != "web" {
+		t.Fatalf("projects = %+v, want api then web", projects)
+	}
+	if web := projects[1]; web.Sessions != 2 || web.Usage.CostUSD != 1.5 || !web.Usage.Estimated {
+		t.Errorf("web = %+v", web)
+	}
+
+	var buf bytes.Buffer
+	printCosts(&buf, entries)
+	out := buf.String()
+	for _, want := range []string{"web", "~$1.50", "TOTAL", "~$4.50", "2.5k"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"strings"
+)
+
+// WizardAnswers are the wizard selections remembered for one repository and
+// pre-selected the next time the wizard runs in it.
+type WizardAnswers struct {
+	SessionType string   `yaml:"session_type,omitempty"` // "vanilla" or "vibeflow"
+	Provider    string   `yaml:"provider,omitempty"`
+	Personas    []string `yaml:"personas,omitempty"`
+	Worktree    string   `yaml:"worktree,omitempty"`    // "new", "existing", "custom", "specify" or "current"
+	Permissions string   `yaml:"permissions,omitempty"` // "skip" or "keep"
+}
+
+// worktreeOptionModes maps the worktree step's options to the names stored
+// in WizardAnswers.Worktree. "Use existing: <path>" is matched by prefix.
+var worktreeOptionModes = map[string]string{
+	"New worktree":      "new",
+	"Custom location":   "custom",
+	"Specify directory": "specify",
+	"Current directory": "current",
+}
+
+func worktreeOptionMode(opt string) string {
+	if strings.HasPrefix(opt, "Use existing:") {
+		return "existing"
+	}
+	return worktreeOptionModes[opt]
+}
+
+// wizardRepoKey returns the repository root dir belongs to, so answers given
+// in a subdirectory apply to the whole repository. Outside git it is dir.
+func wizardRepoKey(dir string) string {
+	root, err := gitIn(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return dir
+	}
+	return root
+}
+
+// loadAnswers looks up the answers remembered for dir's repository and
+// pre-selects its personas and provider; the other steps pick them up as
+// they are entered.
+func (w *WizardModel) loadAnswers(dir string) {
+	w.answersKey = wizardRepoKey(dir)
+	w.remembered = WizardAnswers{}
+	if w.config == nil {
+		return
+	}
+	a, ok := w.config.WizardAnswers[w.answersKey]
+	if !ok {
+		return
+	}
+	w.remembered = a
+	if len(a.Personas) > 0 {
+		selected := make(map[int]bool)
+		for _, key := range a.Personas {
+			for i, p := range w.personas {
+				if p.key == key {
+					selected[i] = true
+				}
+			}
+		}
+		if len(selected) > 0 {
+			w.selectedPersonas = selected
+		}
+	}
+	w.selectedProvider = w.rememberedProvider()
+}
+
+// rememberedSessionType is the session-type cursor to start on.
+func (w WizardModel) rememberedSessionType() int {
+	if w.remembered.SessionType == "vibeflow" {
+		return 1
+	}
+	return 0
+}
+
+// rememberedProvider is the provider cursor to start on: the remembered
+// provider while it is still installed, the first one otherwise.
+func (w WizardModel) rememberedProvider() int {
+	for i, pe := range w.providers {
+		if pe.key == w.remembered.Provider && pe.available {
+			return i
+		}
+	}
+	return w.defaultProviderIndex()
+}
+
+// defaultProviderIndex is the provider row to start on when nothing is
+// remembered: the configured default_provider, then a provider marked
+// default, then claude. Built-in keys sort alphabetically, so index 0 is
+// not necessarily claude.
+func (w WizardModel) defaultProviderIndex() int {
+	want := ""
+	if w.config != nil {
+		want = w.config.DefaultProvider
+	}
+	for i, pe := range w.providers {
+		if want != "" && pe.key == want {
+			return i
+		}
+	}
+	for i, pe := range w.providers {
+		if pe.provider.Default {
+			return i
+		}
+	}
+	for i, pe := range w.providers {
+		if pe.key == "claude" {
+			return i
+		}
+	}
+	return 0
+}
+
+// providerCursor is the cursor the provider step starts on. In team mode
+// the cursor walks rows instead, and loadAnswers already set the team
+// default.
+func (w WizardModel) providerCursor() int {
+	if w.teamModeProvider() {
+		return 0
+	}
+	return w.rememberedProvider()
+}
+
+// rememberedWorktree is the worktree cursor to start on. A remembered
+// "existing" only applies when the branch has a worktree to reuse.
+func (w WizardModel) rememberedWorktree() int {
+	for i, opt := range w.worktreeOpts {
+		if mode := worktreeOptionMode(opt); mode != "" && mode == w.remembered.Worktree {
+			return i
+		}
+	}
+	return 0
+}
+
+// rememberedPermission is the permissions cursor to start on.
+func (w WizardModel) rememberedPermission() int {
+	if w.remembered.Permissions == "keep" {
+		return 1
+	}
+	return 0
+}
+
+// saveAnswers remembers the finished wizard's selections for its
+// repository.
+func (w WizardModel) saveAnswers() {
+	if w.config == nil || w.answersKey == "" {
+		return
+	}
+	r := w.result
+	a := WizardAnswers{
+		SessionType: r.SessionType,
+		Provider:    r.ProviderKey,
+		Personas:    r.Personas,
+		Permissions: "skip",
+	}
+	if r.SessionType != "vibeflow" {
+		// Vanilla sessions skip the persona step; keep the last team.
+		a.Personas = w.remembered.Personas
+	}
+	if !r.SkipPermissions {
+		a.Permissions = "keep"
+	}
+	if w.selectedWorktree < len(w.worktreeOpts) {
+		a.Worktree = worktreeOptionMode(w.worktreeOpts[w.selectedWorktree])
+	}
+	if w.config.WizardAnswers == nil {
+		w.config.WizardAnswers = make(map[string]WizardAnswers)
+	}
+	w.config.WizardAnswers[w.answersKey] = a
+	_ = SaveConfig(w.config, ConfigPath())
+}
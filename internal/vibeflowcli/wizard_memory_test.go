@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// answersWizard returns a wizard with every provider installed, as if the
+// user had just picked a directory in a fresh git repository.
+func answersWizard(t *testing.T, cfg *Config) (WizardModel, string) {
+	t.Helper()
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "-C", repo, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	w := NewWizardModel(NewProviderRegistry(cfg), repo, nil, nil, "", nil, cfg)
+	w.providers = []providerEntry{
+		{key: "claude", available: true},
+		{key: "codex", available: true},
+		{key: "gemini", available: true},
+	}
+	return w, repo
+}
+
+func TestWizardAnswers_RoundTrip(t *testing.T) {
+	root := withTempRoot(t)
+	cfg := DefaultConfig()
+	w, repo := answersWizard(t, cfg)
+	w.loadAnswers(repo)
+	if w.rememberedSessionType() != 0 || w.providerCursor() != 0 || w.rememberedPermission() != 0 {
+		t.Fatal("a repository without answers should start on the first options")
+	}
+
+	w.worktreeOpts = []string{"New worktree", "Custom location", "Specify directory", "Current directory"}
+	w.selectedWorktree = 3
+	w.result = WizardResult{
+		SessionType: "vibeflow",
+		ProviderKey: "codex",
+		Personas:    []string{"developer", "qa_lead"},
+	}
+	w.saveAnswers()
+
+	saved, err := LoadConfig(filepath.Join(root, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Answers are keyed by the repository root, so a subdirectory finds them.
+	sub := filepath.Join(repo, "pkg")
+	if err := exec.Command("mkdir", "-p", sub).Run(); err != nil {
+		t.Fatal(err)
+	}
+	next, _ := answersWizard(t, saved)
+	next.loadAnswers(sub)
+	if got := next.rememberedSessionType(); got != 1 {
+		t.Errorf("session type cursor = %d, want 1 (VibeFlow)", got)
+	}
+	if got := next.providerCursor(); got != 1 {
+		t.Errorf("provider cursor = %d, want 1 (codex)", got)
+	}
+	if got := next.rememberedPermission(); got != 1 {
+		t.Errorf("permission cursor = %d, want 1 (keep permissions)", got)
+	}
+	next.rebuildWorktreeOpts()
+	if got := next.worktreeOpts[next.rememberedWorktree()]; got != "Current directory" {
+		t.Errorf("worktree option = %q, want Current directory", got)
+	}
+	var personas []string
+	for _, i := range next.selectedPersonaIndices() {
+		personas = append(personas, next.personas[i].key)
+	}
+	if !slices.Equal(personas, []string{"developer", "qa_lead"}) {
+		t.Errorf("personas = %v, want developer and qa_lead", personas)
+	}
+}
+
+func TestWizardAnswers_PerRepository(t *testing.T) {
+	withTempRoot(t)
+	cfg := DefaultConfig()
+	w, repo := answersWizard(t, cfg)
+	cfg.WizardAnswers = map[string]WizardAnswers{wizardRepoKey(repo): {Provider: "gemini"}}
+	w.loadAnswers(repo)
+	if got := w.providerCursor(); got != 2 {
+		t.Errorf("provider cursor = %d, want 2 (gemini)", got)
+	}
+
+	other, otherRepo := answersWizard(t, cfg)
+	other.loadAnswers(otherRepo)
+	if got := other.providerCursor(); got != 0 {
+		t.Errorf("another repository's provider cursor = %d, want 0", got)
+	}
+}
+
+func TestWizardAnswers_SkipsUninstalledProvider(t *testing.T) {
+	cfg := DefaultConfig()
+	w, _ := answersWizard(t, cfg)
+	w.providers[1].available = false
+	w.remembered = WizardAnswers{Provider: "codex", Worktree: "existing"}
+	if got := w.rememberedProvider(); got != 0 {
+		t.Errorf("provider cursor = %d, want 0 when codex is not installed", got)
+	}
+	w.worktreeOpts = []string{"New worktree", "Current directory"}
+	if got := w.rememberedWorktree(); got != 0 {
+		t.Errorf("worktree cursor = %d, want 0 without a worktree to reuse", got)
+	}
+}
+
+func TestWizard_PreselectsDefaultProvider(t *testing.T) {
+	cfg := DefaultConfig()
+	w := NewWizardModel(NewProviderRegistry(cfg), t.TempDir(), nil, nil, "", nil, cfg)
+	if got := w.providers[w.selectedProvider].key; got != "claude" {
+		t.Errorf("preselected provider = %q, want claude ahead of alphabetically earlier aider", got)
+	}
+
+	cfg.DefaultProvider = "gemini"
+	w = NewWizardModel(NewProviderRegistry(cfg), t.TempDir(), nil, nil, "", nil, cfg)
+	if got := w.providers[w.selectedProvider].key; got != "gemini" {
+		t.Errorf("preselected provider = %q, want default_provider gemini", got)
+	}
+}
@@ -17,7 +17,11 @@
 package vibeflowcli
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -38,6 +42,7 @@ type Worktree struct {
 type WorktreeManager struct {
 	repoRoot string
 	baseDir  string // relative to repoRoot, e.g. ".claude/worktrees"
+	setup    WorktreeSetupConfig
 }
 
 // NewWorktreeManager creates a manager rooted at the given repository.
@@ -72,9 +77,33 @@ func (wm *WorktreeManager) Create(name, branch string) (string, error) {
 // creating a new one. baseBranch specifies the start-point for new branches
 // (e.g. "main"); empty means git's default (HEAD).
 func (wm *WorktreeManager) CreateBranch(name, branch string, newBranch bool, baseBranch string) (string, error) {
-	dir := filepath.Join(wm.repoRoot, wm.baseDir)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return "", fmt.Errorf("create base dir: %w", err)
+	return wm.CreateBranchContext(context.Background(), "", name, branch, newBranch, baseBranch, nil, nil)
+}
+
+// CreateBranchInDir is like CreateBranch but places the worktree under a
+// custom absolute directory instead of {repoRoot}/{baseDir}.
+func (wm *WorktreeManager) CreateBranchInDir(customDir, name, branch string, newBranch bool, baseBranch string) (string, error) {
+	return wm.CreateBranchContext(context.Background(), customDir, name, branch, newBranch, baseBranch, nil, nil)
+}
+
+// CreateBranchContext is CreateBranch, or CreateBranchInDir when customDir
+// is set, with cancellation, live output and an optional sparse checkout.
+// git runs under ctx, and every line it prints (checkout progress, hook
+// output, errors) is passed to progress when that is non-nil. With sparse
+// paths the worktree is added without a checkout, limited to those
+// directories with sparse-checkout, and only then checked out, so a
+// monorepo worktree never writes the files outside them. A cancelled or
+// failed sparse creation removes the partial worktree and any branch it had
+// created; cancellation returns ctx's error.
+func (wm *WorktreeManager) CreateBranchContext(ctx context.Context, customDir, name, branch string, newBranch bool, baseBranch string, sparse []string, progress func(line string)) (string, error) {
+	dir := customDir
+	if dir == "" {
+		dir = filepath.Join(wm.repoRoot, wm.baseDir)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("create base dir: %w", err)
+		}
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create custom dir: %w", err)
 	}
 
 	wtPath := filepath.Join(dir, name)
@@ -84,26 +113,101 @@ func (wm *WorktreeManager) CreateBranch(name, branch string, newBranch bool, bas
 		wtPath = fmt.Sprintf("%s-%d", wtPath, time.Now().Unix())
 	}
 
+	branchExisted := gitRefExists(wm.repoRoot, "refs/heads/"+branch)
+	git := func(args ...string) ([]byte, error) {
+		return runGitStreaming(ctx, progress, append([]string{"-C", wm.repoRoot}, args...)...)
+	}
+	path, err := addWorktree(git, wtPath, branch, newBranch, baseBranch, wm.repoRoot, len(sparse) > 0)
+	added := err == nil
+	if added && len(sparse) > 0 && ctx.Err() == nil {
+		err = sparseCheckout(ctx, path, sparse, progress)
+	}
+	if err == nil && ctx.Err() == nil {
+		err = wm.setupWorktree(ctx, path, progress)
+	}
+	if ctx.Err() != nil || (err != nil && added) {
+		// Don't leave a half-set-up worktree (or the branch made for it)
+		// behind when the user gave up waiting or a step after the add
+		// failed.
+		_, _ = gitIn(wm.repoRoot, "worktree", "remove", "--force", wtPath)
+		_ = os.RemoveAll(wtPath)
+		_, _ = gitIn(wm.repoRoot, "worktree", "prune")
+		if !branchExisted && gitRefExists(wm.repoRoot, "refs/heads/"+branch) {
+			_, _ = gitIn(wm.repoRoot, "branch", "-D", branch)
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+	return path, err
+}
+
+// sparseCheckout limits the unchecked-out worktree at wtPath to paths and
+// checks them out. The sparse patterns live in the worktree's own config,
+// so the main checkout and other worktrees stay full.
+func sparseCheckout(ctx context.Context, wtPath string, paths []string, progress func(line string)) error {
+	args := append([]string{"-C", wtPath, "sparse-checkout", "set", "--"}, paths...)
+	if out, err := runGitStreaming(ctx, progress, args...); err != nil {
+		return fmt.Errorf("sparse-checkout: %s", strings.TrimSpace(string(out)))
+	}
+	if out, err := runGitStreaming(ctx, progress, "-C", wtPath, "checkout", "--progress"); err != nil {
+		return fmt.Errorf("checkout: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ParseSparsePaths splits a comma- or space-separated list of directories
+// for a sparse worktree. Paths are relative to the repository root; "./"
+// prefixes and trailing slashes are dropped.
+func ParseSparsePaths(s string) ([]string, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	var paths []string
+	for _, f := range fields {
+		p := strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(filepath.Clean(f)), "./"), "/")
+		if filepath.IsAbs(f) || p == ".." || strings.HasPrefix(p, "../") {
+			return nil, fmt.Errorf("sparse path %q must be inside the repository", f)
+		}
+		if p == "." {
+			return nil, fmt.Errorf("sparse path %q is the whole repository; leave it out for a full checkout", f)
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// addWorktree runs the git worktree add attempts shared by the Create
+// variants: track a same-named remote branch, create the branch, or check
+// out an existing one, falling back to a unique branch name as a last
+// resort when newBranch is false. noCheckout adds the worktree without
+// checking any files out.
+func addWorktree(git func(args ...string) ([]byte, error), wtPath, branch string, newBranch bool, baseBranch, repoRoot string, noCheckout bool) (string, error) {
+	// add runs git worktree add <wtPath> <args...>.
+	add := func(args ...string) ([]byte, error) {
+		cmd := []string{"worktree", "add"}
+		if noCheckout {
+			cmd = append(cmd, "--no-checkout")
+		}
+		return git(append(append(cmd, wtPath), args...)...)
+	}
+
 	if newBranch {
 		// If a same-named remote branch exists, track it instead of creating a divergent local.
-		if hasRemoteBranch(wm.repoRoot, branch) {
-			cmd := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, branch)
-			if _, err := cmd.CombinedOutput(); err == nil {
+		if hasRemoteBranch(repoRoot, branch) {
+			if _, err := add(branch); err == nil {
 				return wtPath, nil
 			}
 			// Fall through to -b if tracking fails.
 		}
 		// Explicitly create a new branch with optional base.
 		// git worktree add <path> -b <branch> [<start-point>]
-		args := []string{"-C", wm.repoRoot, "worktree", "add", wtPath, "-b", branch}
+		args := []string{"-b", branch}
 		if baseBranch != "" {
 			args = append(args, baseBranch)
 		}
-		cmd := exec.Command("git", args...)
-		if out, err := cmd.CombinedOutput(); err != nil {
+		if out, err := add(args...); err != nil {
 			// If -b fails (branch exists), fall back to plain checkout.
-			cmd2 := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, branch)
-			if _, err2 := cmd2.CombinedOutput(); err2 != nil {
+			if _, err2 := add(branch); err2 != nil {
 				return "", fmt.Errorf("create worktree with new branch %q: %s: %w", branch, strings.TrimSpace(string(out)), err)
 			}
 		}
@@ -111,82 +215,83 @@ func (wm *WorktreeManager) CreateBranch(name, branch string, newBranch bool, bas
 	}
 
 	// Try checking out existing branch first.
-	cmd := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, branch)
-	if _, err := cmd.CombinedOutput(); err == nil {
+	if _, err := add(branch); err == nil {
 		return wtPath, nil
 	}
 
 	// Branch might not exist — try creating it.
-	args := []string{"-C", wm.repoRoot, "worktree", "add", wtPath, "-b", branch}
+	args := []string{"-b", branch}
 	if baseBranch != "" {
 		args = append(args, baseBranch)
 	}
-	cmd2 := exec.Command("git", args...)
-	if out2, err2 := cmd2.CombinedOutput(); err2 != nil {
+	if out2, err2 := add(args...); err2 != nil {
 		// Last resort: use a unique branch name to avoid conflicts.
 		uniqueBranch := fmt.Sprintf("%s-wt-%d", branch, time.Now().Unix())
-		cmd3 := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, "-b", uniqueBranch)
-		if out3, err3 := cmd3.CombinedOutput(); err3 != nil {
+		if out3, err3 := add("-b", uniqueBranch); err3 != nil {
 			return "", fmt.Errorf("create worktree: %s: %w", combineErrors(out2, out3), err3)
 		}
 	}
 	return wtPath, nil
 }
 
-// CreateBranchInDir creates a git worktree for the given branch inside a custom
-// base directory (instead of the default baseDir). Used for the "Custom location"
-// wizard option.
-func (wm *WorktreeManager) CreateBranchInDir(customDir, name, branch string, newBranch bool, baseBranch string) (string, error) {
-	if err := os.MkdirAll(customDir, 0755); err != nil {
-		return "", fmt.Errorf("create custom dir: %w", err)
-	}
-
-	wtPath := filepath.Join(customDir, name)
+// gitRefExists reports whether ref resolves in the repository at dir.
+func gitRefExists(dir, ref string) bool {
+	_, err := gitIn(dir, "rev-parse", "--verify", "--quiet", ref)
+	return err == nil
+}
 
-	if _, statErr := os.Stat(wtPath); statErr == nil {
-		wtPath = fmt.Sprintf("%s-%d", wtPath, time.Now().Unix())
-	}
+// gitWaitDelay bounds how long a cancelled git keeps its output pipe open:
+// worktree add runs the checkout in a child process that outlives the kill.
+const gitWaitDelay = 2 * time.Second
+
+// runGitStreaming runs git under ctx and returns its combined output. With
+// a progress func, each output line is also passed to it as git prints it;
+// carriage returns count as line ends so progress meters come through.
+func runGitStreaming(ctx context.Context, progress func(line string), args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.WaitDelay = gitWaitDelay
+	return runStreaming(cmd, progress)
+}
 
-	if newBranch {
-		// Check for remote tracking first.
-		if hasRemoteBranch(wm.repoRoot, branch) {
-			cmd := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, branch)
-			if _, err := cmd.CombinedOutput(); err == nil {
-				return wtPath, nil
-			}
-		}
-		args := []string{"-C", wm.repoRoot, "worktree", "add", wtPath, "-b", branch}
-		if baseBranch != "" {
-			args = append(args, baseBranch)
-		}
-		cmd := exec.Command("git", args...)
-		if out, err := cmd.CombinedOutput(); err != nil {
-			cmd2 := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, branch)
-			if _, err2 := cmd2.CombinedOutput(); err2 != nil {
-				return "", fmt.Errorf("create worktree with new branch %q: %s: %w", branch, strings.TrimSpace(string(out)), err)
+// runStreaming runs cmd and returns its combined output, passing each line
+// to progress (if set) as it is printed.
+func runStreaming(cmd *exec.Cmd, progress func(line string)) ([]byte, error) {
+	if progress == nil {
+		return cmd.CombinedOutput()
+	}
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sc := bufio.NewScanner(pr)
+		sc.Split(scanLinesCR)
+		for sc.Scan() {
+			out.WriteString(sc.Text())
+			out.WriteByte('\n')
+			if line := strings.TrimSpace(sc.Text()); line != "" {
+				progress(line)
 			}
 		}
-		return wtPath, nil
-	}
-
-	cmd := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, branch)
-	if _, err := cmd.CombinedOutput(); err == nil {
-		return wtPath, nil
-	}
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+	err := cmd.Run()
+	pw.Close()
+	<-done
+	return out.Bytes(), err
+}
 
-	args := []string{"-C", wm.repoRoot, "worktree", "add", wtPath, "-b", branch}
-	if baseBranch != "" {
-		args = append(args, baseBranch)
+// scanLinesCR is bufio.ScanLines that also splits on a bare '\r'.
+func scanLinesCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
 	}
-	cmd2 := exec.Command("git", args...)
-	if out2, err2 := cmd2.CombinedOutput(); err2 != nil {
-		uniqueBranch := fmt.Sprintf("%s-wt-%d", branch, time.Now().Unix())
-		cmd3 := exec.Command("git", "-C", wm.repoRoot, "worktree", "add", wtPath, "-b", uniqueBranch)
-		if out3, err3 := cmd3.CombinedOutput(); err3 != nil {
-			return "", fmt.Errorf("create worktree: %s: %w", combineErrors(out2, out3), err3)
-		}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
 	}
-	return wtPath, nil
+	return 0, nil, nil
 }
 
 // List returns all worktrees for the repository by parsing git's porcelain
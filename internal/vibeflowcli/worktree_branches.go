@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// fallbackBranchRe matches the <branch>-wt-<unix time> names addWorktree
+	// falls back to when the branch can't be checked out or created.
+	fallbackBranchRe = regexp.MustCompile(`-wt-\d+$`)
+	// quickBranchRe matches quick-launch branches.
+	quickBranchRe = regexp.MustCompile(`^quick-\d{8}-\d{6}$`)
+)
+
+// ManagedBranch is a local branch vibeflow created.
+type ManagedBranch struct {
+	Name       string
+	Worktree   string // worktree checked out on it, "" if none
+	Session    string // live session on the branch or its worktree, "" if none
+	Merged     bool   // fully merged into the merge base branch
+	Fallback   bool   // a <branch>-wt-<n> name from the unique-suffix fallback
+	LastCommit time.Time
+}
+
+// ManagedBranches lists the local branches vibeflow created, newest first:
+// fallback and quick-launch names, and branches a session in this
+// repository recorded creating (SessionMeta.NewBranch, from history or the
+// live sessions). A branch a session merely ran on is the user's and is
+// left out, as are the merge base branch and the main checkout's branch.
+func (wm *WorktreeManager) ManagedBranches(history []HistoryEntry, live []SessionMeta) ([]ManagedBranch, error) {
+	out, err := gitIn(wm.repoRoot, "for-each-ref", "--format=%(refname:short) %(committerdate:unix)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	wts, err := wm.List()
+	if err != nil {
+		return nil, err
+	}
+
+	root := resolvePath(wm.repoRoot) + string(filepath.Separator)
+	inRepo := func(paths ...string) bool {
+		for _, p := range paths {
+			if p != "" && strings.HasPrefix(resolvePath(p)+string(filepath.Separator), root) {
+				return true
+			}
+		}
+		return false
+	}
+
+	base := wm.MergeBaseBranch()
+	skip := map[string]bool{base: true}
+	worktreeOf := make(map[string]string)
+	for i, wt := range wts {
+		if wt.Bare || wt.Detached || wt.Branch == "" {
+			continue
+		}
+		if i == 0 {
+			skip[wt.Branch] = true // the main checkout
+			continue
+		}
+		worktreeOf[wt.Branch] = wt.Path
+	}
+	created := make(map[string]bool)
+	for _, e := range history {
+		if e.NewBranch && e.Branch != "" && inRepo(e.WorktreePath, e.WorkingDir) {
+			created[e.Branch] = true
+		}
+	}
+	for _, m := range live {
+		if m.NewBranch && m.Branch != "" && inRepo(m.WorktreePath, m.WorkingDir) {
+			created[m.Branch] = true
+		}
+	}
+	sessionOf := make(map[string]string)
+	for _, m := range live {
+		if m.Branch != "" && inRepo(m.WorktreePath, m.WorkingDir) {
+			sessionOf[m.Branch] = m.Name
+		}
+	}
+
+	merged := make(map[string]bool)
+	mergeRef := base
+	if !gitRefExists(wm.repoRoot, mergeRef) {
+		mergeRef = "origin/" + base
+	}
+	if list, err := gitIn(wm.repoRoot, "branch", "--merged", mergeRef, "--format=%(refname:short)"); err == nil {
+		for _, name := range strings.Split(list, "\n") {
+			merged[strings.TrimSpace(name)] = true
+		}
+	}
+
+	var branches []ManagedBranch
+	for _, line := range strings.Split(out, "\n") {
+		name, ts, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if name == "" || skip[name] {
+			continue
+		}
+		fallback := fallbackBranchRe.MatchString(name)
+		if !fallback && !quickBranchRe.MatchString(name) && !created[name] {
+			continue
+		}
+		b := ManagedBranch{
+			Name:     name,
+			Worktree: worktreeOf[name],
+			Session:  sessionOf[name],
+			Merged:   merged[name],
+			Fallback: fallback,
+		}
+		if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			b.LastCommit = time.Unix(sec, 0)
+		}
+		branches = append(branches, b)
+	}
+	sort.SliceStable(branches, func(i, j int) bool { return branches[i].LastCommit.After(branches[j].LastCommit) })
+	return branches, nil
+}
+
+// createsBranch reports whether checking out branch in repoDir makes a new
+// local branch, for SessionMeta.NewBranch. Call it before the checkout.
+func createsBranch(repoDir, branch string) bool {
+	return branch != "" && !gitRefExists(repoDir, "refs/heads/"+branch)
+}
+
+// DeleteBranch removes b's worktree, if any, and then the branch itself,
+// even if it isn't merged. A worktree with uncommitted changes stops the
+// deletion; force discards them.
+func (wm *WorktreeManager) DeleteBranch(b ManagedBranch, force bool) error {
+	if b.Session != "" {
+		return fmt.Errorf("branch %s is used by session %s", b.Name, b.Session)
+	}
+	if b.Worktree != "" {
+		if err := wm.Remove(b.Worktree, force); err != nil {
+			return err
+		}
+	}
+	if _, err := gitIn(wm.repoRoot, "branch", "-D", b.Name); err != nil {
+		return fmt.Errorf("delete branch %s: %w", b.Name, err)
+	}
+	return nil
+}
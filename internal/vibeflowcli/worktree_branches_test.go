@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// newBranchTestRepo returns a manager for a repository holding: feat in a
+// worktree with an unmerged commit, a merged x-wt-<n> fallback branch, a
+// merged quick-launch branch, hist (created for a session, per history),
+// develop (a session ran on it, but it was the user's) and other, which
+// vibeflow didn't create.
+func newBranchTestRepo(t *testing.T) (*WorktreeManager, string) {
+	t.Helper()
+	wm, wt := newMergeTestWorktree(t)
+	commitFile(t, wt, "feat.txt", "feat")
+	repo := wm.RepoRoot()
+	for _, name := range []string{"x-wt-1700000000", "quick-20260101-120000", "hist", "develop", "other"} {
+		if _, err := gitIn(repo, "branch", name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return wm, wt
+}
+
+func managedByName(t *testing.T, branches []ManagedBranch) map[string]ManagedBranch {
+	t.Helper()
+	m := make(map[string]ManagedBranch, len(branches))
+	for _, b := range branches {
+		m[b.Name] = b
+	}
+	return m
+}
+
+func TestManagedBranches(t *testing.T) {
+	wm, wt := newBranchTestRepo(t)
+	history := []HistoryEntry{
+		{SessionMeta: SessionMeta{Name: "old", Branch: "hist", NewBranch: true, WorkingDir: wm.RepoRoot()}},
+		{SessionMeta: SessionMeta{Name: "dev", Branch: "develop", WorkingDir: wm.RepoRoot()}},
+	}
+	live := []SessionMeta{{Name: "vibeflow_claude-feat", Branch: "feat", NewBranch: true, WorktreePath: wt}}
+
+	branches, err := wm.ManagedBranches(history, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := managedByName(t, branches)
+	if len(got) != 4 {
+		t.Errorf("branches = %v, want feat, the fallback, the quick branch and hist", branches)
+	}
+	for _, name := range []string{"other", "develop"} {
+		if _, ok := got[name]; ok {
+			t.Errorf("%s, which vibeflow didn't create, was listed", name)
+		}
+	}
+	if _, ok := got[wm.MergeBaseBranch()]; ok {
+		t.Error("the base branch was listed")
+	}
+	feat := got["feat"]
+	if feat.Merged || resolvePath(feat.Worktree) != resolvePath(wt) || feat.Session != "vibeflow_claude-feat" {
+		t.Errorf("feat = %+v, want unmerged, in its worktree and used by the session", feat)
+	}
+	if fb := got["x-wt-1700000000"]; !fb.Fallback || !fb.Merged {
+		t.Errorf("fallback = %+v, want a merged fallback", fb)
+	}
+	if q := got["quick-20260101-120000"]; q.Fallback || !q.Merged {
+		t.Errorf("quick = %+v", q)
+	}
+}
+
+func TestDeleteBranch_RemovesWorktreeAndBranch(t *testing.T) {
+	wm, wt := newBranchTestRepo(t)
+	branches, err := wm.ManagedBranches(nil, []SessionMeta{{Name: "feat", Branch: "feat", NewBranch: true, WorktreePath: wt}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	feat := managedByName(t, branches)["feat"]
+
+	feat.Session = "busy"
+	if err := wm.DeleteBranch(feat, false); err == nil {
+		t.Error("deleting a branch a session uses should fail")
+	}
+	feat.Session = ""
+
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := wm.DeleteBranch(feat, false); err == nil {
+		t.Error("a worktree with uncommitted changes should stop the deletion")
+	}
+	if !gitRefExists(wm.RepoRoot(), "refs/heads/feat") {
+		t.Fatal("the branch went although its worktree was kept")
+	}
+
+	if err := wm.DeleteBranch(feat, true); err != nil {
+		t.Fatal(err)
+	}
+	if wm.Exists(wt) || gitRefExists(wm.RepoRoot(), "refs/heads/feat") {
+		t.Error("worktree or branch still there after DeleteBranch")
+	}
+}
+
+func TestBranchListModel_PruneMerged(t *testing.T) {
+	wm, wt := newBranchTestRepo(t)
+	withTempRoot(t)
+	store := NewStore()
+	if err := store.Add(SessionMeta{Name: "feat", TmuxSession: "vibeflow_claude-feat", Branch: "feat", NewBranch: true, WorktreePath: wt, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove("feat"); err != nil {
+		t.Fatal(err)
+	}
+	bl := NewBranchListModel(wm, store)
+	if len(bl.rows) != 3 {
+		t.Fatalf("rows = %v, want feat and the two merged branches", bl.rows)
+	}
+
+	bl, _ = bl.Update(tea.KeyPressMsg{Code: 'P', Text: "P"})
+	if len(bl.confirm) != 2 || !bl.prune {
+		t.Fatalf("confirm = %v, want the two merged branches", bl.confirm)
+	}
+	bl, cmd := bl.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if cmd == nil {
+		t.Fatal("confirming should start the deletion")
+	}
+	bl, _ = bl.Update(cmd())
+	if bl.result == nil || len(bl.result.deleted) != 2 || len(bl.result.errs) != 0 {
+		t.Fatalf("result = %+v, want two deletions", bl.result)
+	}
+	if len(bl.rows) != 1 || bl.rows[0].Name != "feat" {
+		t.Errorf("rows after prune = %v, want only feat", bl.rows)
+	}
+}
+
+func TestCreatesBranch(t *testing.T) {
+	wm, _ := newBranchTestRepo(t)
+	if createsBranch(wm.RepoRoot(), "develop") {
+		t.Error("checking out an existing branch counted as creating it")
+	}
+	if !createsBranch(wm.RepoRoot(), "brand-new") || createsBranch(wm.RepoRoot(), "") {
+		t.Error("createsBranch got a new or empty branch wrong")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultPruneAfterDays is the `worktrees prune` age threshold when neither
+// --days nor worktree.prune_after_days is set.
+const defaultPruneAfterDays = 7
+
+// StaleWorktree is a worktree eligible for garbage collection.
+type StaleWorktree struct {
+	Path         string
+	Branch       string
+	LastActivity time.Time
+}
+
+// resolvePath returns path made absolute with symlinks resolved, so paths
+// reported by git and paths recorded in the store compare equal.
+func resolvePath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		return real
+	}
+	return abs
+}
+
+// worktreeLastActivity returns the most recent of the worktree's HEAD commit
+// time and its index modification time. The index is rewritten by checkouts,
+// `git add` and most status calls, so it tracks use even without commits.
+func worktreeLastActivity(path string) time.Time {
+	var last time.Time
+	if ct, err := gitIn(path, "log", "-1", "--format=%ct"); err == nil {
+		if sec, err := strconv.ParseInt(ct, 10, 64); err == nil {
+			last = time.Unix(sec, 0)
+		}
+	}
+	if idx, err := gitIn(path, "rev-parse", "--path-format=absolute", "--git-path", "index"); err == nil {
+		if info, err := os.Stat(idx); err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last
+}
+
+// FindStale returns worktrees under the manager's base dir that have seen no
+// activity for maxAge, are not used by any session in inUse (resolved paths)
+// and have no uncommitted or untracked changes. Worktrees created in custom
+// locations are never considered.
+func (wm *WorktreeManager) FindStale(maxAge time.Duration, inUse map[string]bool, now time.Time) ([]StaleWorktree, error) {
+	wts, err := wm.List()
+	if err != nil {
+		return nil, err
+	}
+	base := resolvePath(filepath.Join(wm.repoRoot, wm.baseDir)) + string(filepath.Separator)
+	var stale []StaleWorktree
+	for _, wt := range wts {
+		path := resolvePath(wt.Path)
+		if wt.Bare || !strings.HasPrefix(path, base) || inUse[path] {
+			continue
+		}
+		last := worktreeLastActivity(wt.Path)
+		if last.IsZero() || now.Sub(last) < maxAge {
+			continue
+		}
+		if isDirtyGit(wt.Path) {
+			continue
+		}
+		branch := wt.Branch
+		if wt.Detached {
+			branch = "(detached)"
+		}
+		stale = append(stale, StaleWorktree{Path: wt.Path, Branch: branch, LastActivity: last})
+	}
+	return stale, nil
+}
+
+// PruneStale removes the given worktrees (never forcing, so anything that
+// changed since FindStale makes git refuse) and then lets git drop
+// administrative entries for worktrees deleted by hand. Branches are kept.
+func (wm *WorktreeManager) PruneStale(stale []StaleWorktree) (removed []string, errs []error) {
+	for _, wt := range stale {
+		if err := wm.Remove(wt.Path, false); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, wt.Path)
+	}
+	if _, err := gitIn(wm.repoRoot, "worktree", "prune"); err != nil {
+		errs = append(errs, err)
+	}
+	return removed, errs
+}
+
+// worktreesInUse returns the resolved worktree paths referenced by sessions in
+// the store.
+func worktreesInUse(store *Store) (map[string]bool, error) {
+	inUse := make(map[string]bool)
+	if store == nil {
+		return inUse, nil
+	}
+	metas, err := store.List()
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	for _, m := range metas {
+		if m.WorktreePath != "" {
+			inUse[resolvePath(m.WorktreePath)] = true
+		}
+	}
+	return inUse, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorktreeManager_FindStale(t *testing.T) {
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	idle, err := wm.CreateBranch("idle", "idle", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	used, err := wm.CreateBranch("used", "used", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirty, err := wm.CreateBranch("dirty", "dirty", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirty, "scratch.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	custom, err := wm.CreateBranchInDir(t.TempDir(), "custom", "custom", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inUse := map[string]bool{resolvePath(used): true}
+	later := time.Now().Add(30 * 24 * time.Hour)
+
+	stale, err := wm.FindStale(7*24*time.Hour, inUse, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 1 || resolvePath(stale[0].Path) != resolvePath(idle) || stale[0].Branch != "idle" {
+		t.Fatalf("FindStale = %+v, want only %s (custom %s must be ignored)", stale, idle, custom)
+	}
+
+	// Nothing is stale yet at the real current time.
+	if fresh, _ := wm.FindStale(7*24*time.Hour, inUse, time.Now()); len(fresh) != 0 {
+		t.Errorf("FindStale(now) = %+v, want none", fresh)
+	}
+
+	removed, errs := wm.PruneStale(stale)
+	if len(errs) != 0 || len(removed) != 1 {
+		t.Fatalf("PruneStale removed %v, errs %v", removed, errs)
+	}
+	if wm.Exists(idle) {
+		t.Error("idle worktree should be gone")
+	}
+	if _, err := gitIn(repo, "rev-parse", "--verify", "idle"); err != nil {
+		t.Error("pruning must keep the branch")
+	}
+}
+
+func TestWorktreesInUse(t *testing.T) {
+	withTempRoot(t)
+	dir := t.TempDir()
+	st := NewStore()
+	if err := st.Add(SessionMeta{Name: "a", TmuxSession: "vibeflow_a", WorktreePath: dir}); err != nil {
+		t.Fatal(err)
+	}
+	inUse, err := worktreesInUse(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inUse[resolvePath(dir)] || len(inUse) != 1 {
+		t.Errorf("inUse = %v, want only %s", inUse, dir)
+	}
+}
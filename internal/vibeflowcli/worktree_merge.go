@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// gitIn runs git in dir and returns its trimmed standard output, so callers
+// can parse it without warnings or hook output mixed in. The error carries
+// git's own message (stderr, else stdout) so it can be shown to the user
+// as-is.
+func gitIn(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	s := strings.TrimSpace(string(out))
+	if err != nil {
+		var msg string
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			msg = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		if msg == "" {
+			msg = s
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return s, fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return s, nil
+}
+
+// hasOriginRemote reports whether dir's repository has an "origin" remote.
+func hasOriginRemote(dir string) bool {
+	_, err := gitIn(dir, "remote", "get-url", "origin")
+	return err == nil
+}
+
+// MergeBaseBranch returns the branch worktrees are merged back into: the
+// remote default branch when origin/HEAD is set, otherwise the branch checked
+// out in the main repository.
+func (wm *WorktreeManager) MergeBaseBranch() string {
+	if _, err := gitIn(wm.repoRoot, "symbolic-ref", "-q", "refs/remotes/origin/HEAD"); err == nil {
+		return getDefaultBranch(wm.repoRoot)
+	}
+	if cur, err := gitIn(wm.repoRoot, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && cur != "HEAD" {
+		return cur
+	}
+	return "main"
+}
+
+// WorktreeChanges summarizes what a worktree holds that its base branch
+// doesn't, so the user can tell which worktrees are worth keeping.
+type WorktreeChanges struct {
+	Base     string
+	Status   string // `git status --short`: uncommitted changes
+	DiffStat string // `git diff --stat base...HEAD`: committed changes not on base
+	Ahead    int    // commits on the worktree branch not on base
+	Behind   int    // commits on base not on the worktree branch
+}
+
+// Changes collects status and diff statistics for the worktree at path.
+func (wm *WorktreeManager) Changes(path string) (WorktreeChanges, error) {
+	c := WorktreeChanges{Base: wm.MergeBaseBranch()}
+	status, err := gitIn(path, "status", "--short")
+	if err != nil {
+		return c, err
+	}
+	c.Status = status
+	// Diff against the merge base so changes landed on base since the
+	// worktree forked don't show up as this worktree's work.
+	if stat, err := gitIn(path, "diff", "--stat", c.Base+"...HEAD"); err == nil {
+		c.DiffStat = stat
+	}
+	if counts, err := gitIn(path, "rev-list", "--left-right", "--count", c.Base+"...HEAD"); err == nil {
+		if f := strings.Fields(counts); len(f) == 2 {
+			c.Behind, _ = strconv.Atoi(f[0])
+			c.Ahead, _ = strconv.Atoi(f[1])
+		}
+	}
+	return c, nil
+}
+
+// MergeOptions controls MergeBack.
+type MergeOptions struct {
+	Rebase         bool // rebase the branch onto base and fast-forward; false = merge commit
+	Push           bool // push base to origin afterwards
+	RemoveWorktree bool // remove the worktree once merged
+}
+
+// MergeBack merges the worktree's branch into the base branch checked out in
+// the main repository: fetch, bring base up to date, rebase or merge, then
+// optionally push and remove the worktree. It stops at the first failing
+// step, aborting any half-done rebase or merge so both checkouts are left as
+// they were. The returned log lists the steps that completed.
+func (wm *WorktreeManager) MergeBack(path, branch string, opts MergeOptions) ([]string, error) {
+	var log []string
+	base := wm.MergeBaseBranch()
+	if branch == "" || branch == "(detached)" {
+		return log, fmt.Errorf("worktree is not on a branch")
+	}
+	if branch == base {
+		return log, fmt.Errorf("worktree is on the base branch %s", base)
+	}
+	if isDirtyGit(path) {
+		return log, fmt.Errorf("worktree has uncommitted changes; commit or stash them first")
+	}
+	cur, err := gitIn(wm.repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return log, err
+	}
+	if cur != base {
+		return log, fmt.Errorf("main checkout %s is on %s, not %s", wm.repoRoot, cur, base)
+	}
+	// Untracked files are ignored here: worktrees usually live inside the
+	// main checkout (worktree.base_dir) and would always show up.
+	if dirty, err := gitIn(wm.repoRoot, "status", "--porcelain", "--untracked-files=no"); err != nil || dirty != "" {
+		return log, fmt.Errorf("main checkout %s has uncommitted changes", wm.repoRoot)
+	}
+
+	remote := hasOriginRemote(wm.repoRoot)
+	if remote {
+		if _, err := gitIn(wm.repoRoot, "fetch", "origin"); err != nil {
+			return log, err
+		}
+		log = append(log, "fetched origin")
+		if hasRemoteBranch(wm.repoRoot, base) {
+			if _, err := gitIn(wm.repoRoot, "merge", "--ff-only", "origin/"+base); err != nil {
+				return log, fmt.Errorf("%s has diverged from origin/%s: %w", base, base, err)
+			}
+			log = append(log, fmt.Sprintf("updated %s from origin", base))
+		}
+	}
+
+	if opts.Rebase {
+		if _, err := gitIn(path, "rebase", base); err != nil {
+			_, _ = gitIn(path, "rebase", "--abort")
+			return log, fmt.Errorf("rebase onto %s failed (aborted): %w", base, err)
+		}
+		log = append(log, fmt.Sprintf("rebased %s onto %s", branch, base))
+		if _, err := gitIn(wm.repoRoot, "merge", "--ff-only", branch); err != nil {
+			return log, err
+		}
+		log = append(log, fmt.Sprintf("fast-forwarded %s to %s", base, branch))
+	} else {
+		if _, err := gitIn(wm.repoRoot, "merge", "--no-ff", "--no-edit", branch); err != nil {
+			_, _ = gitIn(wm.repoRoot, "merge", "--abort")
+			return log, fmt.Errorf("merge into %s failed (aborted): %w", base, err)
+		}
+		log = append(log, fmt.Sprintf("merged %s into %s", branch, base))
+	}
+
+	if opts.Push {
+		if !remote {
+			return log, fmt.Errorf("no origin remote to push to")
+		}
+		if _, err := gitIn(wm.repoRoot, "push", "origin", base); err != nil {
+			return log, err
+		}
+		log = append(log, fmt.Sprintf("pushed %s to origin", base))
+	}
+
+	if opts.RemoveWorktree {
+		if err := wm.Remove(path, false); err != nil {
+			return log, err
+		}
+		log = append(log, "removed worktree "+path)
+	}
+	return log, nil
+}
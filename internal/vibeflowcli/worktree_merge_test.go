@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// commitFile writes name in dir and commits it.
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"add", name}, {"commit", "-m", "add " + name}} {
+		if _, err := gitIn(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGitIn_StdoutOnly(t *testing.T) {
+	repo := initTestRepo(t)
+	// GIT_TRACE writes to stderr, like warnings and hook output do.
+	t.Setenv("GIT_TRACE", "2")
+	head, err := gitIn(repo, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(head) != 40 || strings.ContainsAny(head, " \n") {
+		t.Errorf("rev-parse HEAD = %q, want only the commit ID", head)
+	}
+	if _, err := gitIn(repo, "rev-parse", "--verify", "nope"); err == nil || !strings.Contains(err.Error(), "fatal:") {
+		t.Errorf("err = %v, want git's stderr message", err)
+	}
+}
+
+func newMergeTestWorktree(t *testing.T) (*WorktreeManager, string) {
+	t.Helper()
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := wm.CreateBranch("feat", "feat", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wm, wt
+}
+
+func TestWorktreeManager_Changes(t *testing.T) {
+	wm, wt := newMergeTestWorktree(t)
+	commitFile(t, wt, "feature.go", "package x\n")
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := wm.Changes(wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Ahead != 1 || c.Behind != 0 {
+		t.Errorf("ahead/behind = %d/%d, want 1/0", c.Ahead, c.Behind)
+	}
+	if !strings.Contains(c.Status, "wip.txt") {
+		t.Errorf("Status = %q, want wip.txt listed", c.Status)
+	}
+	if !strings.Contains(c.DiffStat, "feature.go") {
+		t.Errorf("DiffStat = %q, want feature.go listed", c.DiffStat)
+	}
+}
+
+func TestWorktreeManager_MergeBack_RebaseAndRemove(t *testing.T) {
+	wm, wt := newMergeTestWorktree(t)
+	commitFile(t, wt, "feature.go", "package x\n")
+	commitFile(t, wm.RepoRoot(), "other.txt", "base moved on\n")
+
+	log, err := wm.MergeBack(wt, "feat", MergeOptions{Rebase: true, RemoveWorktree: true})
+	if err != nil {
+		t.Fatalf("MergeBack: %v (log %v)", err, log)
+	}
+	if _, err := os.Stat(filepath.Join(wm.RepoRoot(), "feature.go")); err != nil {
+		t.Error("feature.go should be on the base branch after merge")
+	}
+	// Rebase + fast-forward keeps history linear: no merge commit.
+	if parents, _ := gitIn(wm.RepoRoot(), "rev-list", "--parents", "-n1", "HEAD"); len(strings.Fields(parents)) != 2 {
+		t.Errorf("HEAD should have one parent, got %q", parents)
+	}
+	if wm.Exists(wt) {
+		t.Error("worktree should have been removed")
+	}
+}
+
+func TestWorktreeManager_MergeBack_Refusals(t *testing.T) {
+	wm, wt := newMergeTestWorktree(t)
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wm.MergeBack(wt, "feat", MergeOptions{}); err == nil || !strings.Contains(err.Error(), "uncommitted") {
+		t.Errorf("dirty worktree: err = %v", err)
+	}
+	if _, err := wm.MergeBack(wt, "(detached)", MergeOptions{}); err == nil {
+		t.Error("detached worktree should be refused")
+	}
+	_ = os.Remove(filepath.Join(wt, "wip.txt"))
+	if _, err := wm.MergeBack(wt, "feat", MergeOptions{Push: true}); err == nil || !strings.Contains(err.Error(), "no origin") {
+		t.Errorf("push without origin: err = %v", err)
+	}
+}
+
+func TestWorktreeManager_MergeBack_ConflictAborts(t *testing.T) {
+	wm, wt := newMergeTestWorktree(t)
+	commitFile(t, wt, "README.md", "feature\n")
+	commitFile(t, wm.RepoRoot(), "README.md", "base\n")
+
+	_, err := wm.MergeBack(wt, "feat", MergeOptions{})
+	if err == nil || !strings.Contains(err.Error(), "aborted") {
+		t.Fatalf("conflicting merge: err = %v", err)
+	}
+	if st, _ := gitIn(wm.RepoRoot(), "status", "--porcelain", "--untracked-files=no"); st != "" {
+		t.Errorf("main checkout left dirty after abort: %q", st)
+	}
+}
+
+func TestWorktreeListModel_MergeDialog(t *testing.T) {
+	wm, wt := newMergeTestWorktree(t)
+	wl := WorktreeListModel{
+		rows: []WorktreeRow{{Path: wt, Branch: "feat", Session: "claude-a", Status: "active"}},
+		wm:   wm,
+	}
+	wl, _ = wl.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	if !wl.merging || !wl.mergeOpts.Rebase {
+		t.Fatalf("m should open the merge dialog with rebase selected: %+v", wl.mergeOpts)
+	}
+	// Active worktrees can't be removed as part of a merge.
+	wl, _ = wl.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if wl.mergeOpts.RemoveWorktree {
+		t.Error("x must not select removal for a worktree in use")
+	}
+	wl, _ = wl.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	if !wl.mergeOpts.Push {
+		t.Error("p should toggle push")
+	}
+	wl, cmd := wl.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil || !wl.mergeRun {
+		t.Fatal("enter should start the merge")
+	}
+	wl, _ = wl.Update(cmd())
+	if wl.mergeRun || wl.mergeResult == nil || wl.mergeResult.err == nil {
+		t.Fatalf("merge with push and no origin should report an error: %+v", wl.mergeResult)
+	}
+	if !strings.Contains(ansiRe.ReplaceAllString(wl.View(), ""), "no origin remote") {
+		t.Error("View should show the merge error")
+	}
+}
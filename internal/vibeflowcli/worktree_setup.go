@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SetSetup sets the post-create steps run in every worktree this manager
+// creates.
+func (wm *WorktreeManager) SetSetup(setup WorktreeSetupConfig) {
+	wm.setup = setup
+}
+
+// setupWorktree brings the untracked files an agent needs into a freshly
+// added worktree: it copies and symlinks the configured globs from the main
+// checkout, then runs the setup command inside the worktree. Paths that
+// already exist in the worktree (tracked files) are left alone.
+func (wm *WorktreeManager) setupWorktree(ctx context.Context, wtPath string, progress func(line string)) error {
+	report := func(format string, args ...any) {
+		if progress != nil {
+			progress(fmt.Sprintf(format, args...))
+		}
+	}
+	for _, rel := range wm.setupMatches(wm.setup.Copy) {
+		dst := filepath.Join(wtPath, rel)
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+		if err := copyPath(filepath.Join(wm.repoRoot, rel), dst); err != nil {
+			return fmt.Errorf("copy %s: %w", rel, err)
+		}
+		report("Copied %s", rel)
+	}
+	for _, rel := range wm.setupMatches(wm.setup.Symlink) {
+		dst := filepath.Join(wtPath, rel)
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("symlink %s: %w", rel, err)
+		}
+		if err := os.Symlink(filepath.Join(wm.repoRoot, rel), dst); err != nil {
+			return fmt.Errorf("symlink %s: %w", rel, err)
+		}
+		report("Linked %s", rel)
+	}
+	if wm.setup.Command == "" {
+		return nil
+	}
+	report("Running %s", wm.setup.Command)
+	cmd := exec.CommandContext(ctx, "sh", "-c", wm.setup.Command)
+	cmd.Dir = wtPath
+	cmd.Env = append(os.Environ(),
+		"VIBEFLOW_REPO_ROOT="+wm.repoRoot,
+		"VIBEFLOW_WORKTREE="+wtPath,
+	)
+	cmd.WaitDelay = gitWaitDelay
+	if out, err := runStreaming(cmd, progress); err != nil {
+		return fmt.Errorf("setup command: %w: %s", err, lastLine(string(out)))
+	}
+	return nil
+}
+
+// setupMatches expands globs relative to the main checkout and returns the
+// matches as paths relative to it. Patterns that escape the repository are
+// ignored, as is the base dir that holds the worktrees themselves.
+func (wm *WorktreeManager) setupMatches(patterns []string) []string {
+	var rels []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		if filepath.IsAbs(pattern) {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(wm.repoRoot, pattern))
+		for _, m := range matches {
+			rel, err := filepath.Rel(wm.repoRoot, m)
+			if err != nil || rel == "." || rel == ".git" || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			if wm.baseDir != "" && (rel == filepath.Clean(wm.baseDir) || strings.HasPrefix(rel, filepath.Clean(wm.baseDir)+string(filepath.Separator))) {
+				continue
+			}
+			if !seen[rel] {
+				seen[rel] = true
+				rels = append(rels, rel)
+			}
+		}
+	}
+	return rels
+}
+
+// copyPath copies a file, symlink or directory tree from src to dst,
+// keeping file modes and recreating symlinks as symlinks.
+func copyPath(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+	case info.IsDir():
+		if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := copyPath(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	case !info.Mode().IsRegular():
+		return nil // sockets, fifos and devices aren't worth carrying over
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// lastLine returns the last non-empty line of s, for short error messages
+// from commands that print a lot.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWorktreeManager_SetupCopiesLinksAndRuns(t *testing.T) {
+	repo := initTestRepo(t)
+	for name, content := range map[string]string{
+		".env":                  "TOKEN=1\n",
+		".env.local":            "LOCAL=1\n",
+		".vscode/settings.json": "{}\n",
+		"node_modules/x/i.js":   "module.exports = 1\n",
+	} {
+		p := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wm.SetSetup(WorktreeSetupConfig{
+		Copy:    []string{".env*", ".vscode", "README.md", ".worktrees"},
+		Symlink: []string{"node_modules"},
+		Command: `echo "$VIBEFLOW_REPO_ROOT" > setup-ran`,
+	})
+	var lines []string
+	path, err := wm.CreateBranchContext(context.Background(), "", "wt", "feat-setup", true, "", nil, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{".env", ".env.local", ".vscode/settings.json"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			t.Errorf("%s not copied: %v", name, err)
+		}
+	}
+	if target, err := os.Readlink(filepath.Join(path, "node_modules")); err != nil || target != filepath.Join(repo, "node_modules") {
+		t.Errorf("node_modules link = %q, %v; want link to %s", target, err, filepath.Join(repo, "node_modules"))
+	}
+	if _, err := os.Stat(filepath.Join(path, ".worktrees")); !os.IsNotExist(err) {
+		t.Errorf("worktree base dir copied into the worktree: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(path, "setup-ran")); err != nil || strings.TrimSpace(string(data)) != wm.RepoRoot() {
+		t.Errorf("setup command output = %q, %v; want the repo root", data, err)
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), "Copied .env") {
+		t.Errorf("progress lines %q do not report the copies", lines)
+	}
+}
+
+func TestWorktreeManager_SetupCommandFailureRemovesWorktree(t *testing.T) {
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wm.SetSetup(WorktreeSetupConfig{Command: "echo missing dependency; exit 3"})
+	_, err = wm.CreateBranchContext(context.Background(), "", "wt", "feat-broken", true, "", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "missing dependency") {
+		t.Fatalf("err = %v, want the setup command's output", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".worktrees", "wt")); !os.IsNotExist(err) {
+		t.Errorf("failed worktree left on disk: %v", err)
+	}
+	if gitRefExists(repo, "refs/heads/feat-broken") {
+		t.Error("failed setup left its branch behind")
+	}
+}
@@ -17,9 +17,14 @@
 package vibeflowcli
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -287,3 +292,113 @@ func TestWorktreeManager_Exists_NotRegistered(t *testing.T) {
 		t.Error("should return false for unregistered path")
 	}
 }
+
+func TestWorktreeManager_CreateBranchContext_StreamsOutput(t *testing.T) {
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	path, err := wm.CreateBranchContext(context.Background(), "", "wt", "feat-stream", true, "", nil, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("worktree not created: %v", err)
+	}
+	if !slices.ContainsFunc(lines, func(l string) bool { return strings.Contains(l, "feat-stream") }) {
+		t.Errorf("progress lines %q do not mention the branch", lines)
+	}
+}
+
+func TestWorktreeManager_CreateBranchContext_CancelledCleansUp(t *testing.T) {
+	repo := initTestRepo(t)
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = wm.CreateBranchContext(ctx, "", "wt", "feat-cancel", true, "", nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".worktrees", "wt")); !os.IsNotExist(err) {
+		t.Errorf("cancelled worktree left on disk: %v", err)
+	}
+	if gitRefExists(repo, "refs/heads/feat-cancel") {
+		t.Error("cancelled creation left its branch behind")
+	}
+}
+
+func TestScanLinesCR(t *testing.T) {
+	sc := bufio.NewScanner(strings.NewReader("Updating files:  50%\rUpdating files: 100%\ndone"))
+	sc.Split(scanLinesCR)
+	var got []string
+	for sc.Scan() {
+		got = append(got, sc.Text())
+	}
+	want := []string{"Updating files:  50%", "Updating files: 100%", "done"}
+	if !slices.Equal(got, want) {
+		t.Errorf("tokens = %q, want %q", got, want)
+	}
+}
+
+func TestWorktreeManager_CreateBranchContext_Sparse(t *testing.T) {
+	repo := initTestRepo(t)
+	for _, dir := range []string{"services/api", "services/web"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"git", "-C", repo, "add", "."},
+		{"git", "-C", repo, "commit", "-m", "add services"},
+	} {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			t.Fatalf("git command %v failed: %s: %v", args, out, err)
+		}
+	}
+
+	wm, err := NewWorktreeManager(repo, ".worktrees")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := wm.CreateBranchContext(context.Background(), "", "wt", "feat-sparse", true, "", []string{"services/api"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "services", "api", "main.go")); err != nil {
+		t.Errorf("sparse path not checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "services", "web")); !os.IsNotExist(err) {
+		t.Errorf("path outside the sparse set was checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "services", "web", "main.go")); err != nil {
+		t.Errorf("main checkout lost files: %v", err)
+	}
+}
+
+func TestParseSparsePaths(t *testing.T) {
+	got, err := ParseSparsePaths(" services/api, libs/shared/ web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"services/api", "libs/shared", "web"}; !slices.Equal(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+	if got, err := ParseSparsePaths("  "); err != nil || got != nil {
+		t.Errorf("empty input = %q, %v; want nil, nil", got, err)
+	}
+	for _, bad := range []string{"/abs", "../up", "a/../../b", "."} {
+		if _, err := ParseSparsePaths(bad); err == nil {
+			t.Errorf("ParseSparsePaths(%q) accepted an invalid path", bad)
+		}
+	}
+}
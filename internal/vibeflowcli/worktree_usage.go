@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// worktreeSizeTTL is how long a measured worktree size is reused before the
+// directory is walked again. Walking a large checkout takes seconds, so
+// reopening the worktree view shouldn't redo it every time.
+const worktreeSizeTTL = 10 * time.Minute
+
+type sizeEntry struct {
+	bytes int64
+	at    time.Time
+}
+
+// sizeCache holds measured directory sizes by path. Measurements run off the
+// UI goroutine, so access is locked. A nil cache caches nothing.
+type sizeCache struct {
+	mu      sync.Mutex
+	entries map[string]sizeEntry
+}
+
+func newSizeCache() *sizeCache {
+	return &sizeCache{entries: make(map[string]sizeEntry)}
+}
+
+// Get returns path's size if it was measured within worktreeSizeTTL of now.
+func (c *sizeCache) Get(path string, now time.Time) (int64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[path]
+	if !ok || now.Sub(e.at) >= worktreeSizeTTL {
+		return 0, false
+	}
+	return e.bytes, true
+}
+
+// Put records path's size measured at now.
+func (c *sizeCache) Put(path string, bytes int64, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path] = sizeEntry{bytes: bytes, at: now}
+}
+
+// Forget drops path's size, e.g. after the worktree was removed.
+func (c *sizeCache) Forget(path string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+}
+
+// dirSize returns the apparent size of the regular files under root,
+// without following symlinks and without descending into the directories
+// in skip (other worktrees nested inside root). Files that vanish or can't
+// be read while walking are left out rather than failing the walk.
+func dirSize(root string, skip map[string]bool) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skip[path] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// BaseDirPath returns the absolute directory new worktrees are created in.
+func (wm *WorktreeManager) BaseDirPath() string {
+	if filepath.IsAbs(wm.baseDir) {
+		return wm.baseDir
+	}
+	return filepath.Join(wm.repoRoot, wm.baseDir)
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.4 GB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2026. AXIOM STUDIO AI Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vibeflowcli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestDirSize_SkipsNestedWorktrees(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, ".claude", "worktrees", "feat")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(nested, "b.txt"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "a.txt"), filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := dirSize(root, nil); err != nil || n != 150 {
+		t.Errorf("dirSize = %d, %v; want 150 (symlinks not followed)", n, err)
+	}
+	if n, _ := dirSize(root, map[string]bool{nested: true}); n != 100 {
+		t.Errorf("dirSize skipping the nested worktree = %d, want 100", n)
+	}
+	if _, err := dirSize(filepath.Join(root, "missing"), nil); err == nil {
+		t.Error("missing root: want an error")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{
+		512:           "512 B",
+		2048:          "2.0 KB",
+		5 << 20:       "5.0 MB",
+		3<<30 + 1<<29: "3.5 GB",
+	} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestSizeCache_Expires(t *testing.T) {
+	c := newSizeCache()
+	now := time.Now()
+	c.Put("/wt", 42, now)
+	if n, ok := c.Get("/wt", now.Add(time.Minute)); !ok || n != 42 {
+		t.Errorf("fresh entry = %d, %v", n, ok)
+	}
+	if _, ok := c.Get("/wt", now.Add(worktreeSizeTTL)); ok {
+		t.Error("entry older than the TTL was reused")
+	}
+	c.Forget("/wt")
+	if _, ok := c.Get("/wt", now); ok {
+		t.Error("forgotten entry still cached")
+	}
+	var none *sizeCache
+	none.Put("/wt", 1, now) // a nil cache caches nothing
+	if _, ok := none.Get("/wt", now); ok {
+		t.Error("nil cache returned a size")
+	}
+}
+
+func TestWorktreeList_SortBySize(t *testing.T) {
+	wl := WorktreeListModel{
+		rows:      []WorktreeRow{{Path: "/repo"}, {Path: "/wt/small"}, {Path: "/wt/big"}, {Path: "/wt/unknown"}},
+		listOrder: map[string]int{"/repo": 0, "/wt/small": 1, "/wt/big": 2, "/wt/unknown": 3},
+		sizes:     map[string]int64{},
+		sizeErrs:  map[string]bool{},
+		cursor:    1, // on /wt/small
+	}
+	for path, n := range map[string]int64{"/repo": 300, "/wt/small": 10, "/wt/big": 900} {
+		wl, _ = wl.Update(worktreeSizeMsg{path: path, bytes: n})
+	}
+	order := func() string {
+		var paths []string
+		for _, r := range wl.rows {
+			paths = append(paths, r.Path)
+		}
+		return strings.Join(paths, " ")
+	}
+
+	wl, _ = wl.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if got := order(); got != "/wt/big /repo /wt/small /wt/unknown" {
+		t.Errorf("sorted by size = %s", got)
+	}
+	if wl.rows[wl.cursor].Path != "/wt/small" {
+		t.Errorf("cursor moved to %s, want it to stay on /wt/small", wl.rows[wl.cursor].Path)
+	}
+	if got := wl.sizeLabel("/wt/unknown"); got != "..." {
+		t.Errorf("unmeasured size label = %q", got)
+	}
+
+	wl, _ = wl.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if got := order(); got != "/repo /wt/small /wt/big /wt/unknown" {
+		t.Errorf("unsorted = %s, want git's order back", got)
+	}
+}